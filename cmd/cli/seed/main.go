@@ -89,6 +89,16 @@ func (a *App) seedUsers(ctx context.Context) error {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
+		{
+			ID:        uuid.New().String(),
+			Code:      "COMPLIANCE001",
+			FullName:  "Carol Compliance",
+			Email:     "compliance@example.com",
+			Role:      "COMPLIANCE",
+			IsActive:  true,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
 		{
 			ID:        uuid.New().String(),
 			Code:      "STUDENT001",
@@ -160,6 +170,9 @@ func (a *App) seedUsers(ctx context.Context) error {
 	a.log.Info(ctx, "\nTeacher:")
 	a.log.Info(ctx, "  Email: teacher@example.com")
 	a.log.Info(ctx, "  Role: TEACHER")
+	a.log.Info(ctx, "\nCompliance officer:")
+	a.log.Info(ctx, "  Email: compliance@example.com")
+	a.log.Info(ctx, "  Role: COMPLIANCE")
 	a.log.Info(ctx, "\nStudents:")
 	a.log.Info(ctx, "  Email: student1@example.com (Active)")
 	a.log.Info(ctx, "  Email: student2@example.com (Active)")
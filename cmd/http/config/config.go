@@ -3,4 +3,6 @@ package config
 type RestServer struct {
 	Path string `json:"path" yaml:"path"`
 	Port string `json:"port" yaml:"port"`
+	// TrustedProxies lists the proxy IPs or CIDRs allowed to set X-Forwarded-For; empty trusts none
+	TrustedProxies []string `json:"trusted_proxies" yaml:"trusted_proxies" mapstructure:"trusted_proxies"`
 }
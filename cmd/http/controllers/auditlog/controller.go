@@ -0,0 +1,31 @@
+package auditlog
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for the audit log HTTP handlers
+type Controller interface {
+	ListAuditLogs(ctx *gin.Context)
+	GetAuditLog(ctx *gin.Context)
+	VerifyAuditChain(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers the audit log routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/audit-logs")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readAuditLogs := middleware.PermissionMiddleware(constants.PermissionAuditRead)
+
+	v1.Use(authMiddleware, readAuditLogs)
+
+	v1.GET("", controller.ListAuditLogs)
+	v1.GET("/verify", controller.VerifyAuditChain)
+	v1.GET("/:id", controller.GetAuditLog)
+}
@@ -0,0 +1,48 @@
+package auditlog
+
+import "time"
+
+// AuditLogResponse represents an audit log entry
+type AuditLogResponse struct {
+	ID         string                 `json:"id"`
+	ActorID    *string                `json:"actor_id"`
+	ActorName  string                 `json:"actor_name"`
+	ActorRole  string                 `json:"actor_role"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	Comment    string                 `json:"comment"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	Changes    map[string]interface{} `json:"changes"`
+	IPAddress  string                 `json:"ip_address"`
+	TraceID    string                 `json:"trace_id"`
+	Sequence   *int64                 `json:"sequence"`
+	PrevHash   string                 `json:"prev_hash"`
+	Hash       string                 `json:"hash"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// ListAuditLogsResponse represents one page of audit log entries
+type ListAuditLogsResponse struct {
+	Logs       []AuditLogResponse `json:"logs"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
+
+// VerifyAuditChainResponse represents the result of checking the hash chain
+type VerifyAuditChainResponse struct {
+	Valid        bool   `json:"valid"`
+	Checked      int64  `json:"checked"`
+	BrokenAt     *int64 `json:"broken_at,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	HeadSequence int64  `json:"head_sequence"`
+	HeadHash     string `json:"head_hash"`
+	Unsealed     int64  `json:"unsealed"`
+}
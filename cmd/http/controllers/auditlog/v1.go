@@ -0,0 +1,209 @@
+package auditlog
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/entities"
+	"doan/internal/usecases/auditlog"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listAuditLogsUseCase    auditlog.ListAuditLogsUseCase
+	getAuditLogUseCase      auditlog.GetAuditLogUseCase
+	verifyAuditChainUseCase auditlog.VerifyAuditChainUseCase
+}
+
+func NewAuditLogControllerV1(
+	listAuditLogsUseCase auditlog.ListAuditLogsUseCase,
+	getAuditLogUseCase auditlog.GetAuditLogUseCase,
+	verifyAuditChainUseCase auditlog.VerifyAuditChainUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listAuditLogsUseCase:    listAuditLogsUseCase,
+		getAuditLogUseCase:      getAuditLogUseCase,
+		verifyAuditChainUseCase: verifyAuditChainUseCase,
+	}
+}
+
+// ListAuditLogs godoc
+// @Summary List audit log entries
+// @Description Search the audit log, newest first (audit:read)
+// @Tags Audit Logs
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query string false "Filter by acting user"
+// @Param action query string false "Filter by action (CREATE, UPDATE, DELETE, RESTORE, PAYMENT_REFUND, ...)"
+// @Param entity_type query string false "Filter by entity type (USER, CLASS, PAYMENT, ...)"
+// @Param entity_id query string false "Filter by entity ID"
+// @Param trace_id query string false "Filter by request trace ID (X-Request-ID)"
+// @Param from query string false "From, inclusive (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "To, exclusive; a date includes that whole day (RFC3339 or YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=ListAuditLogsResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/audit-logs [get]
+func (c *ControllerV1) ListAuditLogs(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	from, err := parseTime(ctx.Query("from"), false)
+	if err != nil {
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid 'from'. Use RFC3339 or YYYY-MM-DD", err)
+		return
+	}
+	to, err := parseTime(ctx.Query("to"), true)
+	if err != nil {
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid 'to'. Use RFC3339 or YYYY-MM-DD", err)
+		return
+	}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listAuditLogsUseCase.Execute(ctx, auditlog.ListAuditLogsInput{
+		ActorID:    ctx.Query("actor_id"),
+		Action:     ctx.Query("action"),
+		EntityType: ctx.Query("entity_type"),
+		EntityID:   ctx.Query("entity_id"),
+		TraceID:    ctx.Query("trace_id"),
+		From:       from,
+		To:         to,
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list audit logs: %v", err)
+		if errors.Is(err, auditlog.ErrInvalidPeriod) {
+			rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list audit logs", err)
+		return
+	}
+
+	logs := make([]AuditLogResponse, 0, len(output.Logs))
+	for _, entry := range output.Logs {
+		logs = append(logs, mapAuditLog(entry))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Audit logs retrieved successfully", ListAuditLogsResponse{
+		Logs: logs,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// GetAuditLog godoc
+// @Summary Get an audit log entry
+// @Description One entry with its changes and position in the hash chain (audit:read)
+// @Tags Audit Logs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Audit log entry ID"
+// @Success 200 {object} rest.BaseResponse{data=AuditLogResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/audit-logs/{id} [get]
+func (c *ControllerV1) GetAuditLog(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	entry, err := c.getAuditLogUseCase.Execute(ctx, auditlog.GetAuditLogInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get audit log: %v", err)
+		if errors.Is(err, auditlog.ErrAuditLogNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to get audit log", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Audit log retrieved successfully", mapAuditLog(entry))
+}
+
+// VerifyAuditChain godoc
+// @Summary Verify the audit log hash chain
+// @Description Recompute every hash of the chain and check the links; keep head_hash elsewhere to prove later that nothing was cut off (audit:read)
+// @Tags Audit Logs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} rest.BaseResponse{data=VerifyAuditChainResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/audit-logs/verify [get]
+func (c *ControllerV1) VerifyAuditChain(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.verifyAuditChainUseCase.Execute(ctx)
+	if err != nil {
+		ctxLogger.Errorf("Failed to verify audit log chain: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to verify audit log chain", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Audit log chain verified", VerifyAuditChainResponse{
+		Valid:        output.Valid,
+		Checked:      output.Checked,
+		BrokenAt:     output.BrokenAt,
+		Reason:       output.Reason,
+		HeadSequence: output.HeadSequence,
+		HeadHash:     output.HeadHash,
+		Unsealed:     output.Unsealed,
+	})
+}
+
+// parseTime reads an RFC3339 time or a date; a date as the end of a period includes that whole day
+func parseTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+func mapAuditLog(entry *entities.AuditLog) AuditLogResponse {
+	response := AuditLogResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Comment:    entry.Comment,
+		Metadata:   entry.Metadata,
+		Changes:    entry.Changes,
+		IPAddress:  entry.IPAddress,
+		TraceID:    entry.TraceID,
+		Sequence:   entry.Sequence,
+		PrevHash:   entry.PrevHash,
+		Hash:       entry.Hash,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.Actor != nil {
+		response.ActorName = entry.Actor.FullName
+	}
+	return response
+}
@@ -3,6 +3,7 @@ package class
 import (
 	"doan/cmd/http/middleware"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +14,7 @@ type Controller interface {
 	UpdateClass(c *gin.Context)
 	DeleteClass(c *gin.Context)
 	ListClasses(c *gin.Context)
+	EvaluateCompliance(c *gin.Context)
 }
 
 // RegisterRoutesV1 registers class routes with the router
@@ -21,14 +23,18 @@ func RegisterRoutesV1(router *gin.RouterGroup, ctrl Controller, configManager co
 
 	// Middleware
 	authMiddleware := middleware.AuthMiddleware(configManager)
-	adminRole := middleware.RoleMiddleware("ADMIN")
+	createClasses := middleware.PermissionMiddleware(constants.PermissionClassCreate)
+	updateClasses := middleware.PermissionMiddleware(constants.PermissionClassUpdate)
+	deleteClasses := middleware.PermissionMiddleware(constants.PermissionClassDelete)
+	evaluateCompliance := middleware.PermissionMiddleware(constants.PermissionClassCompliance)
 
 	// Routes
 	v1.GET("", ctrl.ListClasses)
 	v1.GET("/:id", ctrl.GetClass)
 
-	// Admin-only operations
-	v1.POST("", authMiddleware, adminRole, ctrl.CreateClass)
-	v1.PUT("/:id", authMiddleware, adminRole, ctrl.UpdateClass)
-	v1.DELETE("/:id", authMiddleware, adminRole, ctrl.DeleteClass)
+	// Protected operations
+	v1.POST("", authMiddleware, createClasses, ctrl.CreateClass)
+	v1.PUT("/:id", authMiddleware, updateClasses, ctrl.UpdateClass)
+	v1.DELETE("/:id", authMiddleware, deleteClasses, ctrl.DeleteClass)
+	v1.GET("/:id/compliance", authMiddleware, evaluateCompliance, ctrl.EvaluateCompliance)
 }
@@ -1,50 +1,83 @@
 package class
 
 import (
+	"doan/internal/entities"
+	"doan/internal/services/regulation"
+	"doan/pkg/money"
 	"time"
 )
 
 type CreateClassRequest struct {
-	Code        string     `json:"code" binding:"required"`
-	Name        string     `json:"name" binding:"required"`
-	Notes       string     `json:"notes"`
-	StartDate   time.Time  `json:"start_date" binding:"required"`
-	EndDate     *time.Time `json:"end_date"`
-	MaxStudents int        `json:"max_students" binding:"required,min=1"`
-	Status      string     `json:"status" binding:"omitempty,oneof=OPEN CLOSED CANCELLED"`
-	Price       float64    `json:"price"`
-	ProgramID   *string    `json:"program_id"`
-	CourseID    *string    `json:"course_id"`
-	TeacherID   *string    `json:"teacher_id"`
+	Code        string       `json:"code" binding:"required"`
+	Name        string       `json:"name" binding:"required"`
+	Notes       string       `json:"notes"`
+	StartDate   time.Time    `json:"start_date" binding:"required"`
+	EndDate     *time.Time   `json:"end_date"`
+	MaxStudents int          `json:"max_students" binding:"required,min=1"`
+	Status      string       `json:"status" binding:"omitempty,oneof=OPEN CLOSED CANCELLED"`
+	Price       money.Amount `json:"price" swaggertype:"integer"`
+	ProgramID   *string      `json:"program_id"`
+	CourseID    *string      `json:"course_id"`
+	TeacherID   *string      `json:"teacher_id"`
+	// Schedules are the weekly sessions, checked against the Circular 29 rules
+	Schedules []ClassScheduleRequest `json:"schedules" binding:"omitempty,dive"`
+}
+
+type ClassScheduleRequest struct {
+	DayOfWeek string  `json:"day_of_week" binding:"required,oneof=MONDAY TUESDAY WEDNESDAY THURSDAY FRIDAY SATURDAY SUNDAY"`
+	StartTime string  `json:"start_time" binding:"required"`
+	EndTime   string  `json:"end_time" binding:"required"`
+	RoomID    *string `json:"room_id"`
 }
 
 type UpdateClassRequest struct {
-	Code        string     `json:"code" binding:"required"`
-	Name        string     `json:"name" binding:"required"`
-	Notes       string     `json:"notes"`
-	StartDate   time.Time  `json:"start_date" binding:"required"`
-	EndDate     *time.Time `json:"end_date"`
-	MaxStudents int        `json:"max_students" binding:"required,min=1"`
-	Status      string     `json:"status" binding:"omitempty,oneof=OPEN CLOSED CANCELLED"`
-	Price       float64    `json:"price"`
-	ProgramID   *string    `json:"program_id"`
-	CourseID    *string    `json:"course_id"`
-	TeacherID   *string    `json:"teacher_id"`
+	Code        string       `json:"code" binding:"required"`
+	Name        string       `json:"name" binding:"required"`
+	Notes       string       `json:"notes"`
+	StartDate   time.Time    `json:"start_date" binding:"required"`
+	EndDate     *time.Time   `json:"end_date"`
+	MaxStudents int          `json:"max_students" binding:"required,min=1"`
+	Status      string       `json:"status" binding:"omitempty,oneof=OPEN CLOSED CANCELLED"`
+	Price       money.Amount `json:"price" swaggertype:"integer"`
+	ProgramID   *string      `json:"program_id"`
+	CourseID    *string      `json:"course_id"`
+	TeacherID   *string      `json:"teacher_id"`
 }
 
 type ClassResponse struct {
-	ID          string     `json:"id"`
-	Code        string     `json:"code"`
-	Name        string     `json:"name"`
-	Notes       string     `json:"notes"`
-	StartDate   time.Time  `json:"start_date"`
-	EndDate     *time.Time `json:"end_date"`
-	MaxStudents int        `json:"max_students"`
-	Status      string     `json:"status"`
-	Price       float64    `json:"price"`
-	ProgramID   *string    `json:"program_id,omitempty"`
-	CourseID    *string    `json:"course_id,omitempty"`
-	TeacherID   *string    `json:"teacher_id,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID          string       `json:"id"`
+	Code        string       `json:"code"`
+	Name        string       `json:"name"`
+	Notes       string       `json:"notes"`
+	StartDate   time.Time    `json:"start_date"`
+	EndDate     *time.Time   `json:"end_date"`
+	MaxStudents int          `json:"max_students"`
+	Status      string       `json:"status"`
+	Price       money.Amount `json:"price" swaggertype:"integer"`
+	ProgramID   *string      `json:"program_id,omitempty"`
+	CourseID    *string      `json:"course_id,omitempty"`
+	TeacherID   *string      `json:"teacher_id,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type ClassScheduleResponse struct {
+	ID        string  `json:"id"`
+	DayOfWeek string  `json:"day_of_week"`
+	StartTime string  `json:"start_time"`
+	EndTime   string  `json:"end_time"`
+	RoomID    *string `json:"room_id"`
+}
+
+// CreateClassResponse is the created class with its schedule and the non-blocking Circular 29 violations
+type CreateClassResponse struct {
+	*entities.Class
+	Schedules  []ClassScheduleResponse `json:"schedules"`
+	Compliance *regulation.Report      `json:"compliance"`
+}
+
+type ClassComplianceResponse struct {
+	ClassID string `json:"class_id"`
+	*regulation.Report
+	Blocking bool `json:"blocking"`
 }
@@ -2,7 +2,9 @@ package class
 
 import (
 	"doan/cmd/http/rest"
+	"doan/internal/services/regulation"
 	"doan/internal/usecases/class"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -19,6 +21,8 @@ type ControllerV1 struct {
 	updateClassUseCase class.UpdateClassUseCase
 	deleteClassUseCase class.DeleteClassUseCase
 	listClassesUseCase class.ListClassesUseCase
+
+	evaluateClassComplianceUseCase class.EvaluateClassComplianceUseCase
 }
 
 func NewClassControllerV1(
@@ -27,6 +31,7 @@ func NewClassControllerV1(
 	updateClassUseCase class.UpdateClassUseCase,
 	deleteClassUseCase class.DeleteClassUseCase,
 	listClassesUseCase class.ListClassesUseCase,
+	evaluateClassComplianceUseCase class.EvaluateClassComplianceUseCase,
 ) *ControllerV1 {
 	return &ControllerV1{
 		createClassUseCase: createClassUseCase,
@@ -34,6 +39,8 @@ func NewClassControllerV1(
 		updateClassUseCase: updateClassUseCase,
 		deleteClassUseCase: deleteClassUseCase,
 		listClassesUseCase: listClassesUseCase,
+
+		evaluateClassComplianceUseCase: evaluateClassComplianceUseCase,
 	}
 }
 
@@ -46,6 +53,16 @@ func (ctrl *ControllerV1) CreateClass(c *gin.Context) {
 		return
 	}
 
+	schedules := make([]class.ScheduleInput, 0, len(req.Schedules))
+	for _, s := range req.Schedules {
+		schedules = append(schedules, class.ScheduleInput{
+			DayOfWeek: s.DayOfWeek,
+			StartTime: s.StartTime,
+			EndTime:   s.EndTime,
+			RoomID:    s.RoomID,
+		})
+	}
+
 	output, err := ctrl.createClassUseCase.Execute(c.Request.Context(), class.CreateClassInput{
 		Code:        req.Code,
 		Name:        req.Name,
@@ -58,14 +75,58 @@ func (ctrl *ControllerV1) CreateClass(c *gin.Context) {
 		ProgramID:   req.ProgramID,
 		CourseID:    req.CourseID,
 		TeacherID:   req.TeacherID,
+		Schedules:   schedules,
+	})
+
+	if err != nil {
+		var violationErr *regulation.ViolationError
+		switch {
+		case errors.As(err, &violationErr):
+			rest.ResponseErrorWithData(c, http.StatusUnprocessableEntity, "Class violates Circular 29 rules", err, violationErr.Report)
+		case errors.Is(err, regulation.ErrInvalidSchedule):
+			rest.ResponseError(c, http.StatusBadRequest, "Invalid class schedule", err)
+		case errors.Is(err, class.ErrTeacherNotFound):
+			rest.ResponseError(c, http.StatusBadRequest, "Teacher not found", err)
+		default:
+			rest.ResponseError(c, http.StatusInternalServerError, "Failed to create class", err)
+		}
+		return
+	}
+
+	scheduleResponses := make([]ClassScheduleResponse, 0, len(output.Schedules))
+	for _, s := range output.Schedules {
+		scheduleResponses = append(scheduleResponses, ClassScheduleResponse{
+			ID:        s.ID,
+			DayOfWeek: s.DayOfWeek,
+			StartTime: s.StartTime,
+			EndTime:   s.EndTime,
+			RoomID:    s.RoomID,
+		})
+	}
+
+	rest.ResponseSuccess(c, http.StatusCreated, "Class created successfully", CreateClassResponse{
+		Class:      output.Class,
+		Schedules:  scheduleResponses,
+		Compliance: output.Compliance,
 	})
+}
 
+func (ctrl *ControllerV1) EvaluateCompliance(c *gin.Context) {
+	output, err := ctrl.evaluateClassComplianceUseCase.Execute(c.Request.Context(), class.EvaluateClassComplianceInput{ID: c.Param("id")})
 	if err != nil {
-		rest.ResponseError(c, http.StatusInternalServerError, "Failed to create class", err)
+		if errors.Is(err, class.ErrClassNotFound) {
+			rest.ResponseError(c, http.StatusNotFound, "Class not found", err)
+			return
+		}
+		rest.ResponseError(c, http.StatusInternalServerError, "Failed to evaluate class compliance", err)
 		return
 	}
 
-	rest.ResponseSuccess(c, http.StatusCreated, "Class created successfully", output.Class)
+	rest.ResponseSuccess(c, http.StatusOK, "Class compliance evaluated successfully", ClassComplianceResponse{
+		ClassID:  output.Class.ID,
+		Report:   output.Report,
+		Blocking: output.Report.HasErrors(),
+	})
 }
 
 func (ctrl *ControllerV1) GetClass(c *gin.Context) {
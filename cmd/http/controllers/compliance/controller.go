@@ -0,0 +1,34 @@
+package compliance
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for compliance review HTTP handlers
+type Controller interface {
+	ListReviewQueue(ctx *gin.Context)
+	ApproveMaterial(ctx *gin.Context)
+	RejectMaterial(ctx *gin.Context)
+	ListMaterialAuditLogs(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers compliance routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/compliance")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	reviewMaterials := middleware.PermissionMiddleware(constants.PermissionComplianceReview)
+
+	v1.Use(authMiddleware, reviewMaterials)
+
+	// Review routes
+	v1.GET("/materials", controller.ListReviewQueue)
+	v1.POST("/materials/:id/approve", controller.ApproveMaterial)
+	v1.POST("/materials/:id/reject", controller.RejectMaterial)
+	v1.GET("/materials/:id/audit-logs", controller.ListMaterialAuditLogs)
+}
@@ -0,0 +1,66 @@
+package compliance
+
+import "time"
+
+// ApproveMaterialRequest represents the request body for approving a material
+type ApproveMaterialRequest struct {
+	Comment string `json:"comment" binding:"max=2000"`
+}
+
+// RejectMaterialRequest represents the request body for rejecting a material
+type RejectMaterialRequest struct {
+	Comment string `json:"comment" binding:"required,max=2000"`
+}
+
+// ReviewQueueItemResponse represents a material in the review queue
+type ReviewQueueItemResponse struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	FileName      string     `json:"file_name"`
+	MimeType      string     `json:"mime_type"`
+	UploadedByID  string     `json:"uploaded_by_id"`
+	UploaderName  string     `json:"uploader_name"`
+	AuditStatus   string     `json:"audit_status"`
+	AILabel       *string    `json:"ai_label"`
+	AuditedAt     *time.Time `json:"audited_at"`
+	ReviewStatus  string     `json:"review_status"`
+	ReviewComment string     `json:"review_comment"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	WaitingHours  int        `json:"waiting_hours"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ReviewQueueResponse represents one page of the review queue
+type ReviewQueueResponse struct {
+	Materials  []ReviewQueueItemResponse `json:"materials"`
+	Pagination PaginationMeta            `json:"pagination"`
+}
+
+// ReviewResultResponse represents the material state after a review decision
+type ReviewResultResponse struct {
+	ID            string     `json:"id"`
+	ReviewStatus  string     `json:"review_status"`
+	ReviewComment string     `json:"review_comment"`
+	ReviewedByID  *string    `json:"reviewed_by_id"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+}
+
+// AuditLogResponse represents an audit log entry
+type AuditLogResponse struct {
+	ID        string                 `json:"id"`
+	ActorID   *string                `json:"actor_id"`
+	ActorName string                 `json:"actor_name"`
+	ActorRole string                 `json:"actor_role"`
+	Action    string                 `json:"action"`
+	Comment   string                 `json:"comment"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
@@ -0,0 +1,250 @@
+package compliance
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/entities"
+	"doan/internal/usecases/compliance"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listReviewQueueUseCase       compliance.ListReviewQueueUseCase
+	reviewMaterialUseCase        compliance.ReviewMaterialUseCase
+	listMaterialAuditLogsUseCase compliance.ListMaterialAuditLogsUseCase
+}
+
+func NewComplianceControllerV1(
+	listReviewQueueUseCase compliance.ListReviewQueueUseCase,
+	reviewMaterialUseCase compliance.ReviewMaterialUseCase,
+	listMaterialAuditLogsUseCase compliance.ListMaterialAuditLogsUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listReviewQueueUseCase:       listReviewQueueUseCase,
+		reviewMaterialUseCase:        reviewMaterialUseCase,
+		listMaterialAuditLogsUseCase: listMaterialAuditLogsUseCase,
+	}
+}
+
+// ListReviewQueue godoc
+// @Summary List the compliance review queue
+// @Description List audited materials awaiting review, DANGER first then oldest first (Compliance/Admin)
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ai_label query string false "Comma separated AI labels (SAFE, WARNING, DANGER)"
+// @Param review_status query string false "Review status (PENDING, APPROVED, REJECTED, ALL)" default(PENDING)
+// @Param min_age_hours query int false "Only materials uploaded at least this many hours ago"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=ReviewQueueResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/compliance/materials [get]
+func (c *ControllerV1) ListReviewQueue(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	minAgeHours, _ := strconv.Atoi(ctx.Query("min_age_hours"))
+	var labels []string
+	if value := ctx.Query("ai_label"); value != "" {
+		labels = strings.Split(value, ",")
+	}
+
+	output, err := c.listReviewQueueUseCase.Execute(ctx, compliance.ListReviewQueueInput{
+		Labels:       labels,
+		ReviewStatus: ctx.Query("review_status"),
+		MinAgeHours:  minAgeHours,
+		Page:         page,
+		Limit:        limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list review queue: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list review queue", err)
+		return
+	}
+
+	now := time.Now()
+	materials := make([]ReviewQueueItemResponse, 0, len(output.Materials))
+	for _, m := range output.Materials {
+		item := ReviewQueueItemResponse{
+			ID:            m.ID,
+			Title:         m.Title,
+			FileName:      m.FileName,
+			MimeType:      m.MimeType,
+			UploadedByID:  m.UploadedByID,
+			AuditStatus:   m.AuditStatus,
+			AILabel:       m.AILabel,
+			AuditedAt:     m.AuditedAt,
+			ReviewStatus:  m.ReviewStatus,
+			ReviewComment: m.ReviewComment,
+			ReviewedAt:    m.ReviewedAt,
+			WaitingHours:  int(now.Sub(m.CreatedAt).Hours()),
+			CreatedAt:     m.CreatedAt,
+		}
+		if m.UploadedBy != nil {
+			item.UploaderName = m.UploadedBy.FullName
+		}
+		materials = append(materials, item)
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Review queue retrieved successfully", ReviewQueueResponse{
+		Materials: materials,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// ApproveMaterial godoc
+// @Summary Approve a material
+// @Description Approve an audited material; the uploader is notified by email (Compliance/Admin)
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Param request body ApproveMaterialRequest false "Optional comment"
+// @Success 200 {object} rest.BaseResponse{data=ReviewResultResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Router /v1/compliance/materials/{id}/approve [post]
+func (c *ControllerV1) ApproveMaterial(ctx *gin.Context) {
+	var req ApproveMaterialRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+			return
+		}
+	}
+	c.review(ctx, compliance.DecisionApprove, req.Comment, "Material approved")
+}
+
+// RejectMaterial godoc
+// @Summary Reject a material
+// @Description Reject an audited material with a mandatory comment; students can no longer download it (Compliance/Admin)
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Param request body RejectMaterialRequest true "Reason of the rejection"
+// @Success 200 {object} rest.BaseResponse{data=ReviewResultResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Router /v1/compliance/materials/{id}/reject [post]
+func (c *ControllerV1) RejectMaterial(ctx *gin.Context) {
+	var req RejectMaterialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	c.review(ctx, compliance.DecisionReject, req.Comment, "Material rejected")
+}
+
+func (c *ControllerV1) review(ctx *gin.Context, decision, comment, message string) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.reviewMaterialUseCase.Execute(ctx, compliance.ReviewMaterialInput{
+		MaterialID:   ctx.Param("id"),
+		ReviewerID:   ctx.GetString("user_id"),
+		ReviewerRole: ctx.GetString("user_role"),
+		Decision:     decision,
+		Comment:      comment,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to review material: %v", err)
+		switch {
+		case errors.Is(err, compliance.ErrMaterialNotFound):
+			rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+		case errors.Is(err, compliance.ErrAuditInProgress):
+			rest.ResponseError(ctx, http.StatusConflict, "Material is still being audited", err)
+		case errors.Is(err, compliance.ErrCommentRequired), errors.Is(err, compliance.ErrInvalidDecision):
+			rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+		default:
+			rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to review material", err)
+		}
+		return
+	}
+
+	m := output.Material
+	rest.ResponseSuccess(ctx, http.StatusOK, message, ReviewResultResponse{
+		ID:            m.ID,
+		ReviewStatus:  m.ReviewStatus,
+		ReviewComment: m.ReviewComment,
+		ReviewedByID:  m.ReviewedByID,
+		ReviewedAt:    m.ReviewedAt,
+	})
+}
+
+// ListMaterialAuditLogs godoc
+// @Summary List the audit log of a material
+// @Description Get the review history of a material, newest first (Compliance/Admin)
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 200 {object} rest.BaseResponse{data=[]AuditLogResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Router /v1/compliance/materials/{id}/audit-logs [get]
+func (c *ControllerV1) ListMaterialAuditLogs(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.listMaterialAuditLogsUseCase.Execute(ctx, compliance.ListMaterialAuditLogsInput{
+		MaterialID: ctx.Param("id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list material audit logs: %v", err)
+		if errors.Is(err, compliance.ErrMaterialNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list audit logs", err)
+		return
+	}
+
+	logs := make([]AuditLogResponse, 0, len(output.Logs))
+	for _, l := range output.Logs {
+		logs = append(logs, mapAuditLogToResponse(l))
+	}
+	rest.ResponseSuccess(ctx, http.StatusOK, "Audit logs retrieved successfully", logs)
+}
+
+func mapAuditLogToResponse(l *entities.AuditLog) AuditLogResponse {
+	response := AuditLogResponse{
+		ID:        l.ID,
+		ActorID:   l.ActorID,
+		ActorRole: l.ActorRole,
+		Action:    l.Action,
+		Comment:   l.Comment,
+		Metadata:  l.Metadata,
+		CreatedAt: l.CreatedAt,
+	}
+	if l.Actor != nil {
+		response.ActorName = l.Actor.FullName
+	}
+	return response
+}
@@ -3,6 +3,7 @@ package course
 import (
 	"doan/cmd/http/middleware"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,12 +23,12 @@ func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configMana
 
 	// Middleware
 	authMiddleware := middleware.AuthMiddleware(configManager)
-	adminRole := middleware.RoleMiddleware("ADMIN")
+	writeCourses := middleware.PermissionMiddleware(constants.PermissionCourseWrite)
 
-	// Admin-only routes (CRUD operations)
-	v1.POST("", authMiddleware, adminRole, controller.CreateCourse)
-	v1.PUT("/:id", authMiddleware, adminRole, controller.UpdateCourse)
-	v1.DELETE("/:id", authMiddleware, adminRole, controller.DeleteCourse)
+	// Protected routes (CRUD operations)
+	v1.POST("", authMiddleware, writeCourses, controller.CreateCourse)
+	v1.PUT("/:id", authMiddleware, writeCourses, controller.UpdateCourse)
+	v1.DELETE("/:id", authMiddleware, writeCourses, controller.DeleteCourse)
 
 	// Public/authenticated routes (read operations)
 	v1.GET("", controller.ListCourses)
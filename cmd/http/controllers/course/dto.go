@@ -1,50 +1,53 @@
 package course
 
-import "time"
+import (
+	"doan/pkg/money"
+	"time"
+)
 
 // CreateCourseRequest represents the request body for creating a course
 type CreateCourseRequest struct {
-	Code                   string  `json:"code" binding:"required"`
-	Name                   string  `json:"name" binding:"required"`
-	Description            string  `json:"description"`
-	GradeLevel             string  `json:"grade_level"`
-	Subject                string  `json:"subject"`
-	SessionCount           int     `json:"session_count"`
-	SessionDurationMinutes int     `json:"session_duration_minutes"`
-	TotalHours             float64 `json:"total_hours"`
-	Price                  float64 `json:"price"`
-	Status                 string  `json:"status"`
+	Code                   string       `json:"code" binding:"required"`
+	Name                   string       `json:"name" binding:"required"`
+	Description            string       `json:"description"`
+	GradeLevel             string       `json:"grade_level"`
+	Subject                string       `json:"subject"`
+	SessionCount           int          `json:"session_count"`
+	SessionDurationMinutes int          `json:"session_duration_minutes"`
+	TotalHours             float64      `json:"total_hours"`
+	Price                  money.Amount `json:"price" swaggertype:"integer"`
+	Status                 string       `json:"status"`
 }
 
 // UpdateCourseRequest represents the request body for updating a course
 type UpdateCourseRequest struct {
-	Code                   *string  `json:"code"`
-	Name                   *string  `json:"name"`
-	Description            *string  `json:"description"`
-	GradeLevel             *string  `json:"grade_level"`
-	Subject                *string  `json:"subject"`
-	SessionCount           *int     `json:"session_count"`
-	SessionDurationMinutes *int     `json:"session_duration_minutes"`
-	TotalHours             *float64 `json:"total_hours"`
-	Price                  *float64 `json:"price"`
-	Status                 *string  `json:"status"`
+	Code                   *string       `json:"code"`
+	Name                   *string       `json:"name"`
+	Description            *string       `json:"description"`
+	GradeLevel             *string       `json:"grade_level"`
+	Subject                *string       `json:"subject"`
+	SessionCount           *int          `json:"session_count"`
+	SessionDurationMinutes *int          `json:"session_duration_minutes"`
+	TotalHours             *float64      `json:"total_hours"`
+	Price                  *money.Amount `json:"price" swaggertype:"integer"`
+	Status                 *string       `json:"status"`
 }
 
 // CourseResponse represents a course in the response
 type CourseResponse struct {
-	ID                     string    `json:"id"`
-	Code                   string    `json:"code"`
-	Name                   string    `json:"name"`
-	Description            string    `json:"description"`
-	GradeLevel             string    `json:"grade_level"`
-	Subject                string    `json:"subject"`
-	SessionCount           int       `json:"session_count"`
-	SessionDurationMinutes int       `json:"session_duration_minutes"`
-	TotalHours             float64   `json:"total_hours"`
-	Price                  float64   `json:"price"`
-	Status                 string    `json:"status"`
-	CreatedAt              time.Time `json:"created_at"`
-	UpdatedAt              time.Time `json:"updated_at"`
+	ID                     string       `json:"id"`
+	Code                   string       `json:"code"`
+	Name                   string       `json:"name"`
+	Description            string       `json:"description"`
+	GradeLevel             string       `json:"grade_level"`
+	Subject                string       `json:"subject"`
+	SessionCount           int          `json:"session_count"`
+	SessionDurationMinutes int          `json:"session_duration_minutes"`
+	TotalHours             float64      `json:"total_hours"`
+	Price                  money.Amount `json:"price" swaggertype:"integer"`
+	Status                 string       `json:"status"`
+	CreatedAt              time.Time    `json:"created_at"`
+	UpdatedAt              time.Time    `json:"updated_at"`
 }
 
 // ListCoursesResponse represents the response for listing courses
@@ -0,0 +1,34 @@
+package dashboard
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for admin dashboard HTTP handlers
+type Controller interface {
+	GetSummary(ctx *gin.Context)
+	GetRevenueSeries(ctx *gin.Context)
+	GetEnrollmentSeries(ctx *gin.Context)
+	GetTeacherUtilisation(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers admin dashboard routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/dashboard")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readDashboard := middleware.PermissionMiddleware(constants.PermissionDashboardRead)
+
+	v1.Use(authMiddleware, readDashboard)
+
+	// Dashboard routes
+	v1.GET("/summary", controller.GetSummary)
+	v1.GET("/revenue", controller.GetRevenueSeries)
+	v1.GET("/enrollments", controller.GetEnrollmentSeries)
+	v1.GET("/teacher-utilisation", controller.GetTeacherUtilisation)
+}
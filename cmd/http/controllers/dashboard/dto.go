@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"doan/pkg/money"
+	"time"
+)
+
+// SummaryResponse represents the dashboard KPIs of a period; amounts are whole VND
+type SummaryResponse struct {
+	From     time.Time              `json:"from"`
+	To       time.Time              `json:"to"` // exclusive
+	Students StudentSummaryResponse `json:"students"`
+	Revenue  RevenueSummaryResponse `json:"revenue"`
+	Classes  ClassSummaryResponse   `json:"classes"`
+	Teachers TeacherSummaryResponse `json:"teachers"`
+}
+
+// StudentSummaryResponse represents the student and enrollment KPIs
+type StudentSummaryResponse struct {
+	ActiveStudents      int64   `json:"active_students"`
+	EnrolledStudents    int64   `json:"enrolled_students"`
+	NewStudents         int64   `json:"new_students"`
+	NewEnrollments      int64   `json:"new_enrollments"`
+	ApprovedEnrollments int64   `json:"approved_enrollments"`
+	EnrolledAtStart     int64   `json:"enrolled_at_start"`
+	ChurnedStudents     int64   `json:"churned_students"`
+	ChurnRate           float64 `json:"churn_rate"`
+}
+
+// RevenueSummaryResponse represents revenue billed vs collected
+type RevenueSummaryResponse struct {
+	Billed         money.Amount `json:"billed" swaggertype:"integer"`
+	Collected      money.Amount `json:"collected" swaggertype:"integer"`
+	Refunded       money.Amount `json:"refunded" swaggertype:"integer"`
+	NetCollected   money.Amount `json:"net_collected" swaggertype:"integer"`
+	CollectionRate float64      `json:"collection_rate"`
+	Outstanding    money.Amount `json:"outstanding" swaggertype:"integer"`
+	Overdue        money.Amount `json:"overdue" swaggertype:"integer"`
+}
+
+// ClassSummaryResponse represents class counts per status
+type ClassSummaryResponse struct {
+	Running  int64            `json:"running"`
+	ByStatus map[string]int64 `json:"by_status"`
+}
+
+// TeacherSummaryResponse represents the teaching load of active teachers
+type TeacherSummaryResponse struct {
+	ActiveTeachers  int64   `json:"active_teachers"`
+	TeachingHours   float64 `json:"teaching_hours"`
+	MeanUtilisation float64 `json:"mean_utilisation"`
+}
+
+// RevenueSeriesResponse represents revenue per bucket
+type RevenueSeriesResponse struct {
+	From     time.Time              `json:"from"`
+	To       time.Time              `json:"to"`
+	Interval string                 `json:"interval"`
+	Points   []RevenuePointResponse `json:"points"`
+}
+
+// RevenuePointResponse represents one bucket of revenue
+type RevenuePointResponse struct {
+	BucketStart time.Time    `json:"bucket_start"`
+	Billed      money.Amount `json:"billed" swaggertype:"integer"`
+	Collected   money.Amount `json:"collected" swaggertype:"integer"`
+	Refunded    money.Amount `json:"refunded" swaggertype:"integer"`
+}
+
+// EnrollmentSeriesResponse represents enrollment activity per bucket
+type EnrollmentSeriesResponse struct {
+	From     time.Time                 `json:"from"`
+	To       time.Time                 `json:"to"`
+	Interval string                    `json:"interval"`
+	Points   []EnrollmentPointResponse `json:"points"`
+}
+
+// EnrollmentPointResponse represents one bucket of enrollment activity
+type EnrollmentPointResponse struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Applied     int64     `json:"applied"`
+	Approved    int64     `json:"approved"`
+	Rejected    int64     `json:"rejected"`
+}
+
+// TeacherUtilisationListResponse represents the load of each active teacher
+type TeacherUtilisationListResponse struct {
+	From     time.Time                    `json:"from"`
+	To       time.Time                    `json:"to"`
+	Teachers []TeacherUtilisationResponse `json:"teachers"`
+}
+
+// TeacherUtilisationResponse represents the teaching load of one teacher against their capacity
+type TeacherUtilisationResponse struct {
+	TeacherID      string  `json:"teacher_id"`
+	TeacherCode    string  `json:"teacher_code"`
+	TeacherName    string  `json:"teacher_name"`
+	EmploymentType string  `json:"employment_type"`
+	Lessons        int64   `json:"lessons"`
+	Hours          float64 `json:"hours"`
+	CapacityHours  float64 `json:"capacity_hours"`
+	Utilisation    float64 `json:"utilisation"`
+}
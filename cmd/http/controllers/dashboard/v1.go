@@ -0,0 +1,236 @@
+package dashboard
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/usecases/dashboard"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	getSummaryUseCase            dashboard.GetSummaryUseCase
+	getRevenueSeriesUseCase      dashboard.GetRevenueSeriesUseCase
+	getEnrollmentSeriesUseCase   dashboard.GetEnrollmentSeriesUseCase
+	getTeacherUtilisationUseCase dashboard.GetTeacherUtilisationUseCase
+}
+
+func NewDashboardControllerV1(
+	getSummaryUseCase dashboard.GetSummaryUseCase,
+	getRevenueSeriesUseCase dashboard.GetRevenueSeriesUseCase,
+	getEnrollmentSeriesUseCase dashboard.GetEnrollmentSeriesUseCase,
+	getTeacherUtilisationUseCase dashboard.GetTeacherUtilisationUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		getSummaryUseCase:            getSummaryUseCase,
+		getRevenueSeriesUseCase:      getRevenueSeriesUseCase,
+		getEnrollmentSeriesUseCase:   getEnrollmentSeriesUseCase,
+		getTeacherUtilisationUseCase: getTeacherUtilisationUseCase,
+	}
+}
+
+// GetSummary godoc
+// @Summary Dashboard KPIs
+// @Description Students, enrollments and churn, revenue billed vs collected, classes by status and teacher load over a period; defaults to the current month (Admin)
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} rest.BaseResponse{data=SummaryResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/dashboard/summary [get]
+func (c *ControllerV1) GetSummary(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getSummaryUseCase.Execute(ctx, dashboard.GetSummaryInput{Period: periodOf(ctx)})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get dashboard summary: %v", err)
+		respondDashboardError(ctx, err, "Failed to get dashboard summary")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Dashboard summary retrieved successfully", SummaryResponse{
+		From: output.From,
+		To:   output.To,
+		Students: StudentSummaryResponse{
+			ActiveStudents:      output.Students.ActiveStudents,
+			EnrolledStudents:    output.Students.EnrolledStudents,
+			NewStudents:         output.Students.NewStudents,
+			NewEnrollments:      output.Students.NewEnrollments,
+			ApprovedEnrollments: output.Students.ApprovedEnrollments,
+			EnrolledAtStart:     output.Students.EnrolledAtStart,
+			ChurnedStudents:     output.Students.ChurnedStudents,
+			ChurnRate:           output.Students.ChurnRate,
+		},
+		Revenue: RevenueSummaryResponse{
+			Billed:         output.Revenue.Billed,
+			Collected:      output.Revenue.Collected,
+			Refunded:       output.Revenue.Refunded,
+			NetCollected:   output.Revenue.NetCollected,
+			CollectionRate: output.Revenue.CollectionRate,
+			Outstanding:    output.Revenue.Outstanding,
+			Overdue:        output.Revenue.Overdue,
+		},
+		Classes: ClassSummaryResponse{
+			Running:  output.Classes.Running,
+			ByStatus: output.Classes.ByStatus,
+		},
+		Teachers: TeacherSummaryResponse{
+			ActiveTeachers:  output.Teachers.ActiveTeachers,
+			TeachingHours:   output.Teachers.TeachingHours,
+			MeanUtilisation: output.Teachers.MeanUtilisation,
+		},
+	})
+}
+
+// GetRevenueSeries godoc
+// @Summary Revenue over time
+// @Description Invoices billed, payments collected and refunds per day, week or month in Vietnam time; defaults to the last 12 months by month (Admin)
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period, inclusive (YYYY-MM-DD)"
+// @Param interval query string false "Bucket size (day, week, month)" default(month)
+// @Success 200 {object} rest.BaseResponse{data=RevenueSeriesResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/dashboard/revenue [get]
+func (c *ControllerV1) GetRevenueSeries(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getRevenueSeriesUseCase.Execute(ctx, dashboard.SeriesInput{
+		Period:   periodOf(ctx),
+		Interval: ctx.Query("interval"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get revenue series: %v", err)
+		respondDashboardError(ctx, err, "Failed to get revenue series")
+		return
+	}
+
+	points := make([]RevenuePointResponse, 0, len(output.Points))
+	for _, p := range output.Points {
+		points = append(points, RevenuePointResponse{
+			BucketStart: p.BucketStart,
+			Billed:      p.Billed,
+			Collected:   p.Collected,
+			Refunded:    p.Refunded,
+		})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Revenue series retrieved successfully", RevenueSeriesResponse{
+		From:     output.From,
+		To:       output.To,
+		Interval: output.Interval,
+		Points:   points,
+	})
+}
+
+// GetEnrollmentSeries godoc
+// @Summary Enrollments over time
+// @Description Enrollment applications, approvals and rejections per day, week or month in Vietnam time; defaults to the last 12 months by month (Admin)
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period, inclusive (YYYY-MM-DD)"
+// @Param interval query string false "Bucket size (day, week, month)" default(month)
+// @Success 200 {object} rest.BaseResponse{data=EnrollmentSeriesResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/dashboard/enrollments [get]
+func (c *ControllerV1) GetEnrollmentSeries(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getEnrollmentSeriesUseCase.Execute(ctx, dashboard.SeriesInput{
+		Period:   periodOf(ctx),
+		Interval: ctx.Query("interval"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get enrollment series: %v", err)
+		respondDashboardError(ctx, err, "Failed to get enrollment series")
+		return
+	}
+
+	points := make([]EnrollmentPointResponse, 0, len(output.Points))
+	for _, p := range output.Points {
+		points = append(points, EnrollmentPointResponse{
+			BucketStart: p.BucketStart,
+			Applied:     p.Applied,
+			Approved:    p.Approved,
+			Rejected:    p.Rejected,
+		})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Enrollment series retrieved successfully", EnrollmentSeriesResponse{
+		From:     output.From,
+		To:       output.To,
+		Interval: output.Interval,
+		Points:   points,
+	})
+}
+
+// GetTeacherUtilisation godoc
+// @Summary Teacher utilisation
+// @Description Hours each active teacher taught against the weekly capacity configured for their employment type; defaults to the current month (Admin)
+// @Tags Dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} rest.BaseResponse{data=TeacherUtilisationListResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/dashboard/teacher-utilisation [get]
+func (c *ControllerV1) GetTeacherUtilisation(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getTeacherUtilisationUseCase.Execute(ctx, dashboard.GetTeacherUtilisationInput{Period: periodOf(ctx)})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get teacher utilisation: %v", err)
+		respondDashboardError(ctx, err, "Failed to get teacher utilisation")
+		return
+	}
+
+	teachers := make([]TeacherUtilisationResponse, 0, len(output.Teachers))
+	for _, t := range output.Teachers {
+		teachers = append(teachers, TeacherUtilisationResponse{
+			TeacherID:      t.TeacherID,
+			TeacherCode:    t.TeacherCode,
+			TeacherName:    t.TeacherName,
+			EmploymentType: t.EmploymentType,
+			Lessons:        t.Lessons,
+			Hours:          t.Hours,
+			CapacityHours:  t.CapacityHours,
+			Utilisation:    t.Utilisation,
+		})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Teacher utilisation retrieved successfully", TeacherUtilisationListResponse{
+		From:     output.From,
+		To:       output.To,
+		Teachers: teachers,
+	})
+}
+
+func periodOf(ctx *gin.Context) dashboard.PeriodInput {
+	return dashboard.PeriodInput{From: ctx.Query("from"), To: ctx.Query("to")}
+}
+
+func respondDashboardError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, dashboard.ErrInvalidPeriod),
+		errors.Is(err, dashboard.ErrInvalidInterval),
+		errors.Is(err, dashboard.ErrTooManyBuckets):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
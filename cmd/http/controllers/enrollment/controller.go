@@ -0,0 +1,35 @@
+package enrollment
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for enrollment HTTP handlers
+type Controller interface {
+	CreateEnrollment(ctx *gin.Context)
+	ListEnrollments(ctx *gin.Context)
+	ApproveEnrollment(ctx *gin.Context)
+	RejectEnrollment(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers enrollment routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/enrollments")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readEnrollments := middleware.PermissionMiddleware(constants.PermissionEnrollmentRead)
+	writeEnrollments := middleware.PermissionMiddleware(constants.PermissionEnrollmentWrite)
+	approveEnrollments := middleware.PermissionMiddleware(constants.PermissionEnrollmentApprove)
+
+	v1.Use(authMiddleware)
+
+	v1.POST("", writeEnrollments, controller.CreateEnrollment)
+	v1.GET("", readEnrollments, controller.ListEnrollments)
+	v1.POST("/:id/approve", approveEnrollments, controller.ApproveEnrollment)
+	v1.POST("/:id/reject", approveEnrollments, controller.RejectEnrollment)
+}
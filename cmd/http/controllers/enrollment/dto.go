@@ -0,0 +1,56 @@
+package enrollment
+
+import (
+	"doan/cmd/http/controllers/invoice"
+	"doan/internal/services/regulation"
+	"time"
+)
+
+// CreateEnrollmentRequest represents the request body for applying a student to a class
+type CreateEnrollmentRequest struct {
+	ClassID   string `json:"class_id" binding:"required,uuid"`
+	StudentID string `json:"student_id" binding:"required,uuid"`
+}
+
+// ApproveEnrollmentRequest represents how the tuition of an approved enrollment is billed
+type ApproveEnrollmentRequest struct {
+	BillingPlan   string   `json:"billing_plan" binding:"omitempty,oneof=FULL MONTHLY"`
+	Installments  int      `json:"installments" binding:"omitempty,min=1"`
+	DiscountCodes []string `json:"discount_codes"`
+}
+
+// EnrollmentResponse represents an enrollment
+type EnrollmentResponse struct {
+	ID          string     `json:"id"`
+	ClassID     string     `json:"class_id"`
+	ClassCode   string     `json:"class_code,omitempty"`
+	ClassName   string     `json:"class_name,omitempty"`
+	StudentID   string     `json:"student_id"`
+	StudentCode string     `json:"student_code,omitempty"`
+	StudentName string     `json:"student_name,omitempty"`
+	Status      string     `json:"status"`
+	ApprovedAt  *time.Time `json:"approved_at"`
+	RejectedAt  *time.Time `json:"rejected_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// EnrollmentDecisionResponse represents an enrollment with its Circular 29 evaluation
+type EnrollmentDecisionResponse struct {
+	Enrollment EnrollmentResponse        `json:"enrollment"`
+	Invoices   []invoice.InvoiceResponse `json:"invoices,omitempty"`
+	Compliance *regulation.Report        `json:"compliance,omitempty"`
+}
+
+// EnrollmentListResponse represents one page of enrollments
+type EnrollmentListResponse struct {
+	Enrollments []EnrollmentResponse `json:"enrollments"`
+	Pagination  PaginationMeta       `json:"pagination"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
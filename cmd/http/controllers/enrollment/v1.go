@@ -0,0 +1,249 @@
+package enrollment
+
+import (
+	"doan/cmd/http/controllers/invoice"
+	"doan/cmd/http/rest"
+	"doan/internal/entities"
+	"doan/internal/services/billing"
+	"doan/internal/services/regulation"
+	"doan/internal/usecases/enrollment"
+	"doan/pkg/logger"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	createEnrollmentUseCase  enrollment.CreateEnrollmentUseCase
+	listEnrollmentsUseCase   enrollment.ListEnrollmentsUseCase
+	approveEnrollmentUseCase enrollment.ApproveEnrollmentUseCase
+	rejectEnrollmentUseCase  enrollment.RejectEnrollmentUseCase
+}
+
+func NewEnrollmentControllerV1(
+	createEnrollmentUseCase enrollment.CreateEnrollmentUseCase,
+	listEnrollmentsUseCase enrollment.ListEnrollmentsUseCase,
+	approveEnrollmentUseCase enrollment.ApproveEnrollmentUseCase,
+	rejectEnrollmentUseCase enrollment.RejectEnrollmentUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		createEnrollmentUseCase:  createEnrollmentUseCase,
+		listEnrollmentsUseCase:   listEnrollmentsUseCase,
+		approveEnrollmentUseCase: approveEnrollmentUseCase,
+		rejectEnrollmentUseCase:  rejectEnrollmentUseCase,
+	}
+}
+
+// CreateEnrollment godoc
+// @Summary Apply a student to a class
+// @Description Record an enrollment application with a preview of the Circular 29 check (Admin)
+// @Tags Enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateEnrollmentRequest true "Enrollment request"
+// @Success 201 {object} rest.BaseResponse{data=EnrollmentDecisionResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/enrollments [post]
+func (c *ControllerV1) CreateEnrollment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateEnrollmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createEnrollmentUseCase.Execute(ctx, enrollment.CreateEnrollmentInput{
+		ClassID:   req.ClassID,
+		StudentID: req.StudentID,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create enrollment: %v", err)
+		respondEnrollmentError(ctx, err, "Failed to create enrollment")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Enrollment created successfully", EnrollmentDecisionResponse{
+		Enrollment: mapEnrollment(output.Enrollment),
+		Compliance: output.Compliance,
+	})
+}
+
+// ListEnrollments godoc
+// @Summary List enrollments
+// @Description List enrollments filtered by class, student and status (Admin)
+// @Tags Enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param class_id query string false "Class ID"
+// @Param student_id query string false "Student ID"
+// @Param status query string false "Status (APPLIED, APPROVED, REJECTED)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=EnrollmentListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/enrollments [get]
+func (c *ControllerV1) ListEnrollments(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listEnrollmentsUseCase.Execute(ctx, enrollment.ListEnrollmentsInput{
+		ClassID:   ctx.Query("class_id"),
+		StudentID: ctx.Query("student_id"),
+		Status:    ctx.Query("status"),
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list enrollments: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list enrollments", err)
+		return
+	}
+
+	enrollments := make([]EnrollmentResponse, 0, len(output.Enrollments))
+	for _, e := range output.Enrollments {
+		enrollments = append(enrollments, mapEnrollment(e))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Enrollments retrieved successfully", EnrollmentListResponse{
+		Enrollments: enrollments,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// ApproveEnrollment godoc
+// @Summary Approve an enrollment
+// @Description Approve an application and issue its tuition invoices; blocked when the class is full or ERROR-level Circular 29 rules are violated (Admin)
+// @Tags Enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Enrollment ID"
+// @Param request body ApproveEnrollmentRequest false "Billing options"
+// @Success 200 {object} rest.BaseResponse{data=EnrollmentDecisionResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 422 {object} rest.BaseResponse{data=regulation.Report}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/enrollments/{id}/approve [post]
+func (c *ControllerV1) ApproveEnrollment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	// The body is optional: without it the tuition is billed in full
+	var req ApproveEnrollmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.approveEnrollmentUseCase.Execute(ctx, enrollment.ApproveEnrollmentInput{
+		ID:            ctx.Param("id"),
+		BillingPlan:   req.BillingPlan,
+		Installments:  req.Installments,
+		DiscountCodes: req.DiscountCodes,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to approve enrollment: %v", err)
+		respondEnrollmentError(ctx, err, "Failed to approve enrollment")
+		return
+	}
+
+	invoices := make([]invoice.InvoiceResponse, 0, len(output.Invoices))
+	for _, i := range output.Invoices {
+		invoices = append(invoices, invoice.MapInvoice(i))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Enrollment approved successfully", EnrollmentDecisionResponse{
+		Enrollment: mapEnrollment(output.Enrollment),
+		Invoices:   invoices,
+		Compliance: output.Compliance,
+	})
+}
+
+// RejectEnrollment godoc
+// @Summary Reject an enrollment
+// @Description Reject a pending application (Admin)
+// @Tags Enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Enrollment ID"
+// @Success 200 {object} rest.BaseResponse{data=EnrollmentDecisionResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/enrollments/{id}/reject [post]
+func (c *ControllerV1) RejectEnrollment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.rejectEnrollmentUseCase.Execute(ctx, enrollment.RejectEnrollmentInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to reject enrollment: %v", err)
+		respondEnrollmentError(ctx, err, "Failed to reject enrollment")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Enrollment rejected successfully", EnrollmentDecisionResponse{
+		Enrollment: mapEnrollment(output.Enrollment),
+	})
+}
+
+func respondEnrollmentError(ctx *gin.Context, err error, fallback string) {
+	var violationErr *regulation.ViolationError
+	switch {
+	case errors.As(err, &violationErr):
+		rest.ResponseErrorWithData(ctx, http.StatusUnprocessableEntity, "Enrollment violates Circular 29 rules", err, violationErr.Report)
+	case errors.Is(err, billing.ErrInvalidPlan),
+		errors.Is(err, billing.ErrInvalidInstallments),
+		errors.Is(err, billing.ErrUnknownDiscount):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, enrollment.ErrEnrollmentNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, "Enrollment not found", err)
+	case errors.Is(err, enrollment.ErrClassNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, "Class not found", err)
+	case errors.Is(err, enrollment.ErrStudentNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, "Student not found", err)
+	case errors.Is(err, enrollment.ErrAlreadyEnrolled),
+		errors.Is(err, enrollment.ErrClassFull),
+		errors.Is(err, enrollment.ErrClassNotOpen),
+		errors.Is(err, enrollment.ErrNotPending):
+		rest.ResponseError(ctx, http.StatusConflict, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
+
+func mapEnrollment(e *entities.Enrollment) EnrollmentResponse {
+	return EnrollmentResponse{
+		ID:          e.ID,
+		ClassID:     e.ClassID,
+		ClassCode:   e.Class.Code,
+		ClassName:   e.Class.Name,
+		StudentID:   e.StudentID,
+		StudentCode: e.Student.Code,
+		StudentName: e.Student.FullName,
+		Status:      e.Status,
+		ApprovedAt:  e.ApprovedAt,
+		RejectedAt:  e.RejectedAt,
+		CreatedAt:   e.CreatedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package guardian
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for guardian HTTP handlers
+type Controller interface {
+	ListGuardians(ctx *gin.Context)
+	CreateGuardian(ctx *gin.Context)
+	GetGuardian(ctx *gin.Context)
+	UpdateGuardian(ctx *gin.Context)
+	DeleteGuardian(ctx *gin.Context)
+	LinkStudent(ctx *gin.Context)
+	UnlinkStudent(ctx *gin.Context)
+	LinkAccount(ctx *gin.Context)
+	GetMe(ctx *gin.Context)
+	GetChildTimetable(ctx *gin.Context)
+	GetChildAttendance(ctx *gin.Context)
+	GetChildGrades(ctx *gin.Context)
+	ListChildInvoices(ctx *gin.Context)
+	ListChildLeaveRequests(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers guardian management and guardian portal routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readGuardians := middleware.PermissionMiddleware(constants.PermissionGuardianRead)
+	writeGuardians := middleware.PermissionMiddleware(constants.PermissionGuardianWrite)
+	guardianPortal := middleware.PermissionMiddleware(constants.PermissionGuardianPortal)
+
+	// Guardian management
+	admin := router.Group("/v1/guardians")
+	admin.Use(authMiddleware)
+	admin.GET("", readGuardians, controller.ListGuardians)
+	admin.POST("", writeGuardians, controller.CreateGuardian)
+	admin.GET("/:id", readGuardians, controller.GetGuardian)
+	admin.PUT("/:id", writeGuardians, controller.UpdateGuardian)
+	admin.DELETE("/:id", writeGuardians, controller.DeleteGuardian)
+	admin.PUT("/:id/students/:student_id", writeGuardians, controller.LinkStudent)
+	admin.DELETE("/:id/students/:student_id", writeGuardians, controller.UnlinkStudent)
+	admin.PUT("/:id/account", writeGuardians, controller.LinkAccount)
+
+	// Guardian portal; every child route also checks the student is one of the caller's children
+	portal := router.Group("/v1/guardian")
+	portal.Use(authMiddleware, guardianPortal)
+	portal.GET("/me", controller.GetMe)
+	portal.GET("/students/:student_id/timetable", controller.GetChildTimetable)
+	portal.GET("/students/:student_id/attendance", controller.GetChildAttendance)
+	portal.GET("/students/:student_id/grades", controller.GetChildGrades)
+	portal.GET("/students/:student_id/invoices", controller.ListChildInvoices)
+	portal.GET("/students/:student_id/leave-requests", controller.ListChildLeaveRequests)
+}
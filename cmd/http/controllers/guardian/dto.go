@@ -0,0 +1,235 @@
+package guardian
+
+import (
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"time"
+)
+
+// CreateGuardianRequest represents a new guardian contact
+type CreateGuardianRequest struct {
+	FullName string `json:"full_name" binding:"required"`
+	Phone    string `json:"phone" binding:"required" example:"0901234567"`
+	Email    string `json:"email"`
+	Notes    string `json:"notes"`
+}
+
+// UpdateGuardianRequest represents the guardian fields to change
+type UpdateGuardianRequest struct {
+	FullName *string `json:"full_name"`
+	Phone    *string `json:"phone"`
+	Email    *string `json:"email"`
+	Notes    *string `json:"notes"`
+}
+
+// LinkStudentRequest represents how the guardian is related to the student
+type LinkStudentRequest struct {
+	Relationship string `json:"relationship" example:"MOTHER"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+// LinkAccountRequest represents the login the guardian uses for the portal
+type LinkAccountRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// GuardianResponse represents a guardian with their children
+type GuardianResponse struct {
+	ID        string          `json:"id"`
+	UserID    *string         `json:"user_id"`
+	UserEmail string          `json:"user_email,omitempty"`
+	FullName  string          `json:"full_name"`
+	Phone     string          `json:"phone"`
+	Email     string          `json:"email,omitempty"`
+	Notes     string          `json:"notes,omitempty"`
+	Children  []ChildResponse `json:"children"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ChildResponse represents a student linked to a guardian
+type ChildResponse struct {
+	StudentID    string `json:"student_id"`
+	StudentCode  string `json:"student_code,omitempty"`
+	FullName     string `json:"full_name,omitempty"`
+	GradeLevel   string `json:"grade_level,omitempty"`
+	SchoolName   string `json:"school_name,omitempty"`
+	Relationship string `json:"relationship"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+// GuardianListResponse represents one page of guardians
+type GuardianListResponse struct {
+	Guardians  []GuardianResponse `json:"guardians"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// TimetableEntryResponse represents one lesson of the child
+type TimetableEntryResponse struct {
+	LessonID    string    `json:"lesson_id"`
+	ClassID     string    `json:"class_id"`
+	ClassCode   string    `json:"class_code"`
+	ClassName   string    `json:"class_name"`
+	DateStart   time.Time `json:"date_start"`
+	DateEnd     time.Time `json:"date_end"`
+	RoomName    *string   `json:"room_name"`
+	TeacherName *string   `json:"teacher_name"`
+}
+
+// AttendanceEntryResponse represents the attendance of the child at one lesson
+type AttendanceEntryResponse struct {
+	LessonID  string     `json:"lesson_id"`
+	ClassCode string     `json:"class_code"`
+	ClassName string     `json:"class_name"`
+	DateStart time.Time  `json:"date_start"`
+	Status    int        `json:"status"`
+	Note      string     `json:"note,omitempty"`
+	MarkedAt  *time.Time `json:"marked_at"`
+}
+
+// GradeEntryResponse represents the academic record of the child at one lesson
+type GradeEntryResponse struct {
+	LessonID           string    `json:"lesson_id"`
+	ClassCode          string    `json:"class_code"`
+	ClassName          string    `json:"class_name"`
+	DateStart          time.Time `json:"date_start"`
+	Topic              string    `json:"topic,omitempty"`
+	HomeworkCompleted  bool      `json:"homework_completed"`
+	HomeworkScore      *float64  `json:"homework_score"`
+	AttitudeRating     *int      `json:"attitude_rating"`
+	ParticipationScore *float64  `json:"participation_score"`
+	TotalScore         *float64  `json:"total_score"`
+	PersonalComment    string    `json:"personal_comment,omitempty"`
+	IsCompleted        bool      `json:"is_completed"`
+}
+
+// LeaveRequestResponse represents a leave request of the child
+type LeaveRequestResponse struct {
+	ID              string     `json:"id"`
+	LeaveType       string     `json:"leave_type"`
+	ApplyDate       time.Time  `json:"apply_date"`
+	LateMinutes     int        `json:"late_minutes,omitempty"`
+	EarlyMinutes    int        `json:"early_minutes,omitempty"`
+	Reason          string     `json:"reason"`
+	ClassID         *string    `json:"class_id"`
+	ClassName       string     `json:"class_name,omitempty"`
+	Status          string     `json:"status"`
+	ApprovedAt      *time.Time `json:"approved_at"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// LeaveRequestListResponse represents one page of leave requests
+type LeaveRequestListResponse struct {
+	LeaveRequests []LeaveRequestResponse `json:"leave_requests"`
+	Pagination    PaginationMeta         `json:"pagination"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
+
+func mapGuardian(g *entities.Guardian) GuardianResponse {
+	resp := GuardianResponse{
+		ID:        g.ID,
+		UserID:    g.UserID,
+		FullName:  g.FullName,
+		Phone:     g.Phone,
+		Email:     g.Email,
+		Notes:     g.Notes,
+		Children:  make([]ChildResponse, 0, len(g.Students)),
+		CreatedAt: g.CreatedAt,
+	}
+	if g.User != nil {
+		resp.UserEmail = g.User.Email
+	}
+	for _, link := range g.Students {
+		child := ChildResponse{
+			StudentID:    link.StudentID,
+			Relationship: link.Relationship,
+			IsPrimary:    link.IsPrimary,
+		}
+		if link.Student != nil {
+			child.StudentCode = link.Student.Code
+			child.FullName = link.Student.FullName
+			child.GradeLevel = link.Student.GradeLevel
+			child.SchoolName = link.Student.SchoolName
+		}
+		resp.Children = append(resp.Children, child)
+	}
+	return resp
+}
+
+func mapTimetable(entries []*repointerface.TimetableEntry) []TimetableEntryResponse {
+	result := make([]TimetableEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, TimetableEntryResponse{
+			LessonID:    e.LessonID,
+			ClassID:     e.ClassID,
+			ClassCode:   e.ClassCode,
+			ClassName:   e.ClassName,
+			DateStart:   e.DateStart,
+			DateEnd:     e.DateEnd,
+			RoomName:    e.RoomName,
+			TeacherName: e.TeacherName,
+		})
+	}
+	return result
+}
+
+func mapAttendance(entries []*repointerface.AttendanceEntry) []AttendanceEntryResponse {
+	result := make([]AttendanceEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, AttendanceEntryResponse{
+			LessonID:  e.LessonID,
+			ClassCode: e.ClassCode,
+			ClassName: e.ClassName,
+			DateStart: e.DateStart,
+			Status:    e.Status,
+			Note:      e.Note,
+			MarkedAt:  e.MarkedAt,
+		})
+	}
+	return result
+}
+
+func mapGrades(entries []*repointerface.GradeEntry) []GradeEntryResponse {
+	result := make([]GradeEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, GradeEntryResponse{
+			LessonID:           e.LessonID,
+			ClassCode:          e.ClassCode,
+			ClassName:          e.ClassName,
+			DateStart:          e.DateStart,
+			Topic:              e.Topic,
+			HomeworkCompleted:  e.HomeworkCompleted,
+			HomeworkScore:      e.HomeworkScore,
+			AttitudeRating:     e.AttitudeRating,
+			ParticipationScore: e.ParticipationScore,
+			TotalScore:         e.TotalScore,
+			PersonalComment:    e.PersonalComment,
+			IsCompleted:        e.IsCompleted,
+		})
+	}
+	return result
+}
+
+func mapLeaveRequest(r *entities.LeaveRequest) LeaveRequestResponse {
+	return LeaveRequestResponse{
+		ID:              r.ID,
+		LeaveType:       r.LeaveType,
+		ApplyDate:       r.ApplyDate,
+		LateMinutes:     r.LateMinutes,
+		EarlyMinutes:    r.EarlyMinutes,
+		Reason:          r.Reason,
+		ClassID:         r.ClassID,
+		ClassName:       r.Class.Name,
+		Status:          r.Status,
+		ApprovedAt:      r.ApprovedAt,
+		RejectionReason: r.RejectionReason,
+		CreatedAt:       r.CreatedAt,
+	}
+}
@@ -0,0 +1,589 @@
+package guardian
+
+import (
+	"doan/cmd/http/controllers/invoice"
+	"doan/cmd/http/rest"
+	"doan/internal/repositories"
+	"doan/internal/usecases/guardian"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listGuardiansUseCase          guardian.ListGuardiansUseCase
+	createGuardianUseCase         guardian.CreateGuardianUseCase
+	getGuardianUseCase            guardian.GetGuardianUseCase
+	updateGuardianUseCase         guardian.UpdateGuardianUseCase
+	deleteGuardianUseCase         guardian.DeleteGuardianUseCase
+	linkStudentUseCase            guardian.LinkStudentUseCase
+	unlinkStudentUseCase          guardian.UnlinkStudentUseCase
+	linkAccountUseCase            guardian.LinkAccountUseCase
+	getMyChildrenUseCase          guardian.GetMyChildrenUseCase
+	getChildTimetableUseCase      guardian.GetChildTimetableUseCase
+	getChildAttendanceUseCase     guardian.GetChildAttendanceUseCase
+	getChildGradesUseCase         guardian.GetChildGradesUseCase
+	listChildInvoicesUseCase      guardian.ListChildInvoicesUseCase
+	listChildLeaveRequestsUseCase guardian.ListChildLeaveRequestsUseCase
+}
+
+func NewGuardianControllerV1(
+	listGuardiansUseCase guardian.ListGuardiansUseCase,
+	createGuardianUseCase guardian.CreateGuardianUseCase,
+	getGuardianUseCase guardian.GetGuardianUseCase,
+	updateGuardianUseCase guardian.UpdateGuardianUseCase,
+	deleteGuardianUseCase guardian.DeleteGuardianUseCase,
+	linkStudentUseCase guardian.LinkStudentUseCase,
+	unlinkStudentUseCase guardian.UnlinkStudentUseCase,
+	linkAccountUseCase guardian.LinkAccountUseCase,
+	getMyChildrenUseCase guardian.GetMyChildrenUseCase,
+	getChildTimetableUseCase guardian.GetChildTimetableUseCase,
+	getChildAttendanceUseCase guardian.GetChildAttendanceUseCase,
+	getChildGradesUseCase guardian.GetChildGradesUseCase,
+	listChildInvoicesUseCase guardian.ListChildInvoicesUseCase,
+	listChildLeaveRequestsUseCase guardian.ListChildLeaveRequestsUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listGuardiansUseCase:          listGuardiansUseCase,
+		createGuardianUseCase:         createGuardianUseCase,
+		getGuardianUseCase:            getGuardianUseCase,
+		updateGuardianUseCase:         updateGuardianUseCase,
+		deleteGuardianUseCase:         deleteGuardianUseCase,
+		linkStudentUseCase:            linkStudentUseCase,
+		unlinkStudentUseCase:          unlinkStudentUseCase,
+		linkAccountUseCase:            linkAccountUseCase,
+		getMyChildrenUseCase:          getMyChildrenUseCase,
+		getChildTimetableUseCase:      getChildTimetableUseCase,
+		getChildAttendanceUseCase:     getChildAttendanceUseCase,
+		getChildGradesUseCase:         getChildGradesUseCase,
+		listChildInvoicesUseCase:      listChildInvoicesUseCase,
+		listChildLeaveRequestsUseCase: listChildLeaveRequestsUseCase,
+	}
+}
+
+// ListGuardians godoc
+// @Summary List guardians
+// @Description Guardians matching a name, phone or email (Admin)
+// @Tags Guardians
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Name, phone or email"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=GuardianListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians [get]
+func (c *ControllerV1) ListGuardians(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listGuardiansUseCase.Execute(ctx, guardian.ListGuardiansInput{
+		Search: ctx.Query("search"),
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list guardians: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list guardians", err)
+		return
+	}
+
+	guardians := make([]GuardianResponse, 0, len(output.Guardians))
+	for _, g := range output.Guardians {
+		guardians = append(guardians, mapGuardian(g))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Guardians retrieved successfully", GuardianListResponse{
+		Guardians:  guardians,
+		Pagination: mapPagination(output.Pagination),
+	})
+}
+
+// CreateGuardian godoc
+// @Summary Create a guardian
+// @Description Add a parent or guardian contact; link children and a login afterwards (Admin)
+// @Tags Guardians
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateGuardianRequest true "Guardian"
+// @Success 201 {object} rest.BaseResponse{data=GuardianResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians [post]
+func (c *ControllerV1) CreateGuardian(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateGuardianRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createGuardianUseCase.Execute(ctx, guardian.CreateGuardianInput{
+		FullName: req.FullName,
+		Phone:    req.Phone,
+		Email:    req.Email,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to create guardian")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Guardian created successfully", mapGuardian(output.Guardian))
+}
+
+// GetGuardian godoc
+// @Summary Get a guardian
+// @Description A guardian with their children and linked login (Admin)
+// @Tags Guardians
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guardian ID"
+// @Success 200 {object} rest.BaseResponse{data=GuardianResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians/{id} [get]
+func (c *ControllerV1) GetGuardian(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getGuardianUseCase.Execute(ctx, guardian.GetGuardianInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to get guardian")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Guardian retrieved successfully", mapGuardian(output.Guardian))
+}
+
+// UpdateGuardian godoc
+// @Summary Update a guardian
+// @Description Change the contact details of a guardian (Admin)
+// @Tags Guardians
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guardian ID"
+// @Param request body UpdateGuardianRequest true "Fields to change"
+// @Success 200 {object} rest.BaseResponse{data=GuardianResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians/{id} [put]
+func (c *ControllerV1) UpdateGuardian(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req UpdateGuardianRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.updateGuardianUseCase.Execute(ctx, guardian.UpdateGuardianInput{
+		ID:       ctx.Param("id"),
+		FullName: req.FullName,
+		Phone:    req.Phone,
+		Email:    req.Email,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to update guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to update guardian")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Guardian updated successfully", mapGuardian(output.Guardian))
+}
+
+// DeleteGuardian godoc
+// @Summary Delete a guardian
+// @Description Remove a guardian; their login loses access to the guardian portal (Admin)
+// @Tags Guardians
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guardian ID"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians/{id} [delete]
+func (c *ControllerV1) DeleteGuardian(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := c.deleteGuardianUseCase.Execute(ctx, guardian.DeleteGuardianInput{ID: ctx.Param("id")}); err != nil {
+		ctxLogger.Errorf("Failed to delete guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to delete guardian")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Guardian deleted successfully", nil)
+}
+
+// LinkStudent godoc
+// @Summary Link a student to a guardian
+// @Description Make the student a child of the guardian, or change the relationship when already linked (Admin)
+// @Tags Guardians
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guardian ID"
+// @Param student_id path string true "Student ID"
+// @Param request body LinkStudentRequest false "Relationship"
+// @Success 200 {object} rest.BaseResponse{data=GuardianResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians/{id}/students/{student_id} [put]
+func (c *ControllerV1) LinkStudent(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req LinkStudentRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctxLogger.Errorf("Failed to bind request: %v", err)
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+			return
+		}
+	}
+
+	output, err := c.linkStudentUseCase.Execute(ctx, guardian.LinkStudentInput{
+		GuardianID:   ctx.Param("id"),
+		StudentID:    ctx.Param("student_id"),
+		Relationship: req.Relationship,
+		IsPrimary:    req.IsPrimary,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to link student to guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to link student")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Student linked successfully", mapGuardian(output.Guardian))
+}
+
+// UnlinkStudent godoc
+// @Summary Unlink a student from a guardian
+// @Description The guardian immediately loses portal access to this student (Admin)
+// @Tags Guardians
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guardian ID"
+// @Param student_id path string true "Student ID"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians/{id}/students/{student_id} [delete]
+func (c *ControllerV1) UnlinkStudent(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	err := c.unlinkStudentUseCase.Execute(ctx, guardian.UnlinkStudentInput{
+		GuardianID: ctx.Param("id"),
+		StudentID:  ctx.Param("student_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to unlink student from guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to unlink student")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Student unlinked successfully", nil)
+}
+
+// LinkAccount godoc
+// @Summary Link a login to a guardian
+// @Description Give a user account the GUARDIAN role and attach it to the guardian; staff accounts are refused (Admin)
+// @Tags Guardians
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guardian ID"
+// @Param request body LinkAccountRequest true "User account"
+// @Success 200 {object} rest.BaseResponse{data=GuardianResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardians/{id}/account [put]
+func (c *ControllerV1) LinkAccount(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req LinkAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.linkAccountUseCase.Execute(ctx, guardian.LinkAccountInput{
+		GuardianID: ctx.Param("id"),
+		UserID:     req.UserID,
+		ActorID:    ctx.GetString("user_id"),
+		ActorRole:  ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to link account to guardian: %v", err)
+		respondGuardianError(ctx, err, "Failed to link account")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Account linked successfully", mapGuardian(output.Guardian))
+}
+
+// GetMe godoc
+// @Summary Get my guardian profile
+// @Description The signed-in guardian with their children (Guardian)
+// @Tags Guardian Portal
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} rest.BaseResponse{data=GuardianResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardian/me [get]
+func (c *ControllerV1) GetMe(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getMyChildrenUseCase.Execute(ctx, guardian.GetMyChildrenInput{UserID: ctx.GetString("user_id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian profile: %v", err)
+		respondGuardianError(ctx, err, "Failed to get guardian profile")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Guardian profile retrieved successfully", mapGuardian(output.Guardian))
+}
+
+// GetChildTimetable godoc
+// @Summary Get my child's timetable
+// @Description Lessons of the child's classes, by default the next two weeks (Guardian)
+// @Tags Guardian Portal
+// @Produce json
+// @Security BearerAuth
+// @Param student_id path string true "Student ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {object} rest.BaseResponse{data=[]TimetableEntryResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardian/students/{student_id}/timetable [get]
+func (c *ControllerV1) GetChildTimetable(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getChildTimetableUseCase.Execute(ctx, guardian.GetChildTimetableInput{
+		ChildInput: childInput(ctx),
+		Period:     periodInput(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get child timetable: %v", err)
+		respondGuardianError(ctx, err, "Failed to get timetable")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Timetable retrieved successfully", mapTimetable(output.Entries))
+}
+
+// GetChildAttendance godoc
+// @Summary Get my child's attendance
+// @Description Attendance marks of the child, by default the last 90 days (Guardian)
+// @Tags Guardian Portal
+// @Produce json
+// @Security BearerAuth
+// @Param student_id path string true "Student ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {object} rest.BaseResponse{data=[]AttendanceEntryResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardian/students/{student_id}/attendance [get]
+func (c *ControllerV1) GetChildAttendance(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getChildAttendanceUseCase.Execute(ctx, guardian.GetChildAttendanceInput{
+		ChildInput: childInput(ctx),
+		Period:     periodInput(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get child attendance: %v", err)
+		respondGuardianError(ctx, err, "Failed to get attendance")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Attendance retrieved successfully", mapAttendance(output.Entries))
+}
+
+// GetChildGrades godoc
+// @Summary Get my child's grades
+// @Description Per-lesson scores and teacher comments of the child, by default the last 90 days (Guardian)
+// @Tags Guardian Portal
+// @Produce json
+// @Security BearerAuth
+// @Param student_id path string true "Student ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {object} rest.BaseResponse{data=[]GradeEntryResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardian/students/{student_id}/grades [get]
+func (c *ControllerV1) GetChildGrades(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getChildGradesUseCase.Execute(ctx, guardian.GetChildGradesInput{
+		ChildInput: childInput(ctx),
+		Period:     periodInput(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get child grades: %v", err)
+		respondGuardianError(ctx, err, "Failed to get grades")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Grades retrieved successfully", mapGrades(output.Entries))
+}
+
+// ListChildInvoices godoc
+// @Summary List my child's invoices
+// @Description Tuition invoices of the child (Guardian)
+// @Tags Guardian Portal
+// @Produce json
+// @Security BearerAuth
+// @Param student_id path string true "Student ID"
+// @Param status query string false "Invoice status"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=invoice.InvoiceListResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardian/students/{student_id}/invoices [get]
+func (c *ControllerV1) ListChildInvoices(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listChildInvoicesUseCase.Execute(ctx, guardian.ListChildInvoicesInput{
+		ChildInput: childInput(ctx),
+		Status:     ctx.Query("status"),
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child invoices: %v", err)
+		respondGuardianError(ctx, err, "Failed to list invoices")
+		return
+	}
+
+	invoices := make([]invoice.InvoiceResponse, 0, len(output.Invoices))
+	for _, i := range output.Invoices {
+		invoices = append(invoices, invoice.MapInvoice(i))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Invoices retrieved successfully", invoice.InvoiceListResponse{
+		Invoices: invoices,
+		Pagination: invoice.PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// ListChildLeaveRequests godoc
+// @Summary List my child's leave requests
+// @Description Leave, late and early-leave requests of the child, newest first (Guardian)
+// @Tags Guardian Portal
+// @Produce json
+// @Security BearerAuth
+// @Param student_id path string true "Student ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=LeaveRequestListResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/guardian/students/{student_id}/leave-requests [get]
+func (c *ControllerV1) ListChildLeaveRequests(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listChildLeaveRequestsUseCase.Execute(ctx, guardian.ListChildLeaveRequestsInput{
+		ChildInput: childInput(ctx),
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child leave requests: %v", err)
+		respondGuardianError(ctx, err, "Failed to list leave requests")
+		return
+	}
+
+	requests := make([]LeaveRequestResponse, 0, len(output.LeaveRequests))
+	for _, r := range output.LeaveRequests {
+		requests = append(requests, mapLeaveRequest(r))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Leave requests retrieved successfully", LeaveRequestListResponse{
+		LeaveRequests: requests,
+		Pagination:    mapPagination(output.Pagination),
+	})
+}
+
+func childInput(ctx *gin.Context) guardian.ChildInput {
+	return guardian.ChildInput{
+		UserID:    ctx.GetString("user_id"),
+		StudentID: ctx.Param("student_id"),
+	}
+}
+
+func periodInput(ctx *gin.Context) guardian.PeriodInput {
+	return guardian.PeriodInput{
+		From: ctx.Query("from"),
+		To:   ctx.Query("to"),
+	}
+}
+
+func mapPagination(meta *repositories.Meta) PaginationMeta {
+	return PaginationMeta{
+		ItemsPerPage: meta.ItemsPerPage,
+		TotalItems:   meta.TotalItems,
+		CurrentPage:  meta.CurrentPage,
+		TotalPages:   meta.TotalPages,
+	}
+}
+
+func respondGuardianError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, guardian.ErrGuardianNotFound),
+		errors.Is(err, guardian.ErrStudentNotFound),
+		errors.Is(err, guardian.ErrUserNotFound),
+		errors.Is(err, guardian.ErrStudentNotLinked):
+		rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, guardian.ErrFullNameRequired),
+		errors.Is(err, guardian.ErrPhoneRequired),
+		errors.Is(err, guardian.ErrInvalidRelationship),
+		errors.Is(err, guardian.ErrInvalidPeriod):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, guardian.ErrUserAlreadyLinked),
+		errors.Is(err, guardian.ErrAccountRoleConflict):
+		rest.ResponseError(ctx, http.StatusConflict, err.Error(), err)
+	case errors.Is(err, guardian.ErrNoGuardianProfile),
+		errors.Is(err, guardian.ErrNotGuardianOfStudent):
+		rest.ResponseError(ctx, http.StatusForbidden, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
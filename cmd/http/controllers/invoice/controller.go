@@ -0,0 +1,36 @@
+package invoice
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for invoice HTTP handlers
+type Controller interface {
+	ListInvoices(ctx *gin.Context)
+	GetInvoice(ctx *gin.Context)
+	GetStudentOutstanding(ctx *gin.Context)
+	GetGuardianOutstanding(ctx *gin.Context)
+	ListOverdueInvoices(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers invoice routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/invoices")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readInvoices := middleware.PermissionMiddleware(constants.PermissionInvoiceRead)
+
+	v1.Use(authMiddleware, readInvoices)
+
+	// Billing routes
+	v1.GET("", controller.ListInvoices)
+	v1.GET("/outstanding/students/:student_id", controller.GetStudentOutstanding)
+	v1.GET("/outstanding/guardians", controller.GetGuardianOutstanding)
+	v1.GET("/overdue", controller.ListOverdueInvoices)
+	v1.GET("/:id", controller.GetInvoice)
+}
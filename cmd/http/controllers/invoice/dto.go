@@ -0,0 +1,143 @@
+package invoice
+
+import (
+	"doan/internal/entities"
+	"doan/pkg/money"
+	"time"
+)
+
+// InvoiceLineResponse represents a tuition charge or a discount line
+type InvoiceLineResponse struct {
+	Kind        string       `json:"kind"`
+	Code        string       `json:"code,omitempty"`
+	Description string       `json:"description"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   money.Amount `json:"unit_price" swaggertype:"integer"`
+	Amount      money.Amount `json:"amount" swaggertype:"integer"`
+}
+
+// InvoiceResponse represents an invoice; amounts are whole VND
+type InvoiceResponse struct {
+	ID               string                `json:"id"`
+	Number           string                `json:"number"`
+	EnrollmentID     string                `json:"enrollment_id"`
+	StudentID        string                `json:"student_id"`
+	StudentName      string                `json:"student_name,omitempty"`
+	ClassID          string                `json:"class_id"`
+	ClassName        string                `json:"class_name,omitempty"`
+	BillingPlan      string                `json:"billing_plan"`
+	InstallmentNo    int                   `json:"installment_no"`
+	InstallmentCount int                   `json:"installment_count"`
+	Currency         string                `json:"currency"`
+	Subtotal         money.Amount          `json:"subtotal" swaggertype:"integer"`
+	DiscountTotal    money.Amount          `json:"discount_total" swaggertype:"integer"`
+	Total            money.Amount          `json:"total" swaggertype:"integer"`
+	PaidAmount       money.Amount          `json:"paid_amount" swaggertype:"integer"`
+	Balance          money.Amount          `json:"balance" swaggertype:"integer"`
+	Status           string                `json:"status"`
+	IssuedAt         time.Time             `json:"issued_at"`
+	DueDate          time.Time             `json:"due_date"`
+	Lines            []InvoiceLineResponse `json:"lines,omitempty"`
+}
+
+// InvoiceListResponse represents one page of invoices
+type InvoiceListResponse struct {
+	Invoices   []InvoiceResponse `json:"invoices"`
+	Pagination PaginationMeta    `json:"pagination"`
+}
+
+// StudentBalanceResponse represents what one student owes
+type StudentBalanceResponse struct {
+	StudentID     string       `json:"student_id"`
+	StudentCode   string       `json:"student_code"`
+	StudentName   string       `json:"student_name"`
+	GuardianPhone string       `json:"guardian_phone"`
+	OpenInvoices  int64        `json:"open_invoices"`
+	Billed        money.Amount `json:"billed" swaggertype:"integer"`
+	Paid          money.Amount `json:"paid" swaggertype:"integer"`
+	Outstanding   money.Amount `json:"outstanding" swaggertype:"integer"`
+	Overdue       money.Amount `json:"overdue" swaggertype:"integer"`
+	NextDueDate   *time.Time   `json:"next_due_date"`
+}
+
+// OutstandingBalanceResponse represents per-student balances and their totals
+type OutstandingBalanceResponse struct {
+	Currency    string                   `json:"currency"`
+	Students    []StudentBalanceResponse `json:"students"`
+	Billed      money.Amount             `json:"billed" swaggertype:"integer"`
+	Paid        money.Amount             `json:"paid" swaggertype:"integer"`
+	Outstanding money.Amount             `json:"outstanding" swaggertype:"integer"`
+	Overdue     money.Amount             `json:"overdue" swaggertype:"integer"`
+}
+
+// ReminderResponse represents the last tuition reminder of an invoice
+type ReminderResponse struct {
+	StepCode  string     `json:"step_code"`
+	Status    string     `json:"status"`
+	Recipient string     `json:"recipient"`
+	Attempts  int        `json:"attempts"`
+	SentAt    *time.Time `json:"sent_at"`
+}
+
+// OverdueInvoiceResponse represents an invoice past due with its dunning state
+type OverdueInvoiceResponse struct {
+	InvoiceResponse
+	DaysOverdue     int               `json:"days_overdue"`
+	GuardianPhone   string            `json:"guardian_phone"`
+	RemindersOptOut bool              `json:"reminders_opt_out"`
+	LastReminder    *ReminderResponse `json:"last_reminder"`
+}
+
+// OverdueInvoiceListResponse represents one page of overdue invoices
+type OverdueInvoiceListResponse struct {
+	Invoices   []OverdueInvoiceResponse `json:"invoices"`
+	Pagination PaginationMeta           `json:"pagination"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
+
+// MapInvoice converts an invoice entity, with its lines when loaded
+func MapInvoice(i *entities.Invoice) InvoiceResponse {
+	resp := InvoiceResponse{
+		ID:               i.ID,
+		Number:           i.Number,
+		EnrollmentID:     i.EnrollmentID,
+		StudentID:        i.StudentID,
+		ClassID:          i.ClassID,
+		BillingPlan:      i.BillingPlan,
+		InstallmentNo:    i.InstallmentNo,
+		InstallmentCount: i.InstallmentCount,
+		Currency:         i.Currency,
+		Subtotal:         i.Subtotal,
+		DiscountTotal:    i.DiscountTotal,
+		Total:            i.Total,
+		PaidAmount:       i.PaidAmount,
+		Balance:          i.Balance(),
+		Status:           i.Status,
+		IssuedAt:         i.IssuedAt,
+		DueDate:          i.DueDate,
+	}
+	if i.Student != nil {
+		resp.StudentName = i.Student.FullName
+	}
+	if i.Class != nil {
+		resp.ClassName = i.Class.Name
+	}
+	for _, l := range i.Lines {
+		resp.Lines = append(resp.Lines, InvoiceLineResponse{
+			Kind:        l.Kind,
+			Code:        l.Code,
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			Amount:      l.Amount,
+		})
+	}
+	return resp
+}
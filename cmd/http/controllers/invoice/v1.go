@@ -0,0 +1,257 @@
+package invoice
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/usecases/invoice"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listInvoicesUseCase          invoice.ListInvoicesUseCase
+	getInvoiceUseCase            invoice.GetInvoiceUseCase
+	getOutstandingBalanceUseCase invoice.GetOutstandingBalanceUseCase
+	listOverdueInvoicesUseCase   invoice.ListOverdueInvoicesUseCase
+}
+
+func NewInvoiceControllerV1(
+	listInvoicesUseCase invoice.ListInvoicesUseCase,
+	getInvoiceUseCase invoice.GetInvoiceUseCase,
+	getOutstandingBalanceUseCase invoice.GetOutstandingBalanceUseCase,
+	listOverdueInvoicesUseCase invoice.ListOverdueInvoicesUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listInvoicesUseCase:          listInvoicesUseCase,
+		getInvoiceUseCase:            getInvoiceUseCase,
+		getOutstandingBalanceUseCase: getOutstandingBalanceUseCase,
+		listOverdueInvoicesUseCase:   listOverdueInvoicesUseCase,
+	}
+}
+
+// ListInvoices godoc
+// @Summary List invoices
+// @Description List tuition invoices filtered by student, class, enrollment and status (Admin)
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param student_id query string false "Student ID"
+// @Param class_id query string false "Class ID"
+// @Param enrollment_id query string false "Enrollment ID"
+// @Param status query string false "Status (UNPAID, PARTIALLY_PAID, PAID, VOID)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=InvoiceListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/invoices [get]
+func (c *ControllerV1) ListInvoices(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listInvoicesUseCase.Execute(ctx, invoice.ListInvoicesInput{
+		StudentID:    ctx.Query("student_id"),
+		ClassID:      ctx.Query("class_id"),
+		EnrollmentID: ctx.Query("enrollment_id"),
+		Status:       ctx.Query("status"),
+		Page:         page,
+		Limit:        limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list invoices: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list invoices", err)
+		return
+	}
+
+	invoices := make([]InvoiceResponse, 0, len(output.Invoices))
+	for _, i := range output.Invoices {
+		invoices = append(invoices, MapInvoice(i))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Invoices retrieved successfully", InvoiceListResponse{
+		Invoices: invoices,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// GetInvoice godoc
+// @Summary Get invoice
+// @Description Get an invoice with its tuition and discount lines (Admin)
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} rest.BaseResponse{data=InvoiceResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/invoices/{id} [get]
+func (c *ControllerV1) GetInvoice(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getInvoiceUseCase.Execute(ctx, invoice.GetInvoiceInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get invoice: %v", err)
+		if errors.Is(err, invoice.ErrInvoiceNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, "Invoice not found", err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to get invoice", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Invoice retrieved successfully", MapInvoice(output.Invoice))
+}
+
+// GetStudentOutstanding godoc
+// @Summary Student outstanding balance
+// @Description Billed, paid, outstanding and overdue tuition of a student (Admin)
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param student_id path string true "Student ID"
+// @Success 200 {object} rest.BaseResponse{data=OutstandingBalanceResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/invoices/outstanding/students/{student_id} [get]
+func (c *ControllerV1) GetStudentOutstanding(ctx *gin.Context) {
+	c.respondOutstanding(ctx, invoice.GetOutstandingBalanceInput{StudentID: ctx.Param("student_id")})
+}
+
+// GetGuardianOutstanding godoc
+// @Summary Guardian outstanding balance
+// @Description Outstanding tuition of every student sharing the guardian phone (Admin)
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param phone query string true "Guardian phone"
+// @Success 200 {object} rest.BaseResponse{data=OutstandingBalanceResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/invoices/outstanding/guardians [get]
+func (c *ControllerV1) GetGuardianOutstanding(ctx *gin.Context) {
+	c.respondOutstanding(ctx, invoice.GetOutstandingBalanceInput{GuardianPhone: ctx.Query("phone")})
+}
+
+// ListOverdueInvoices godoc
+// @Summary List overdue invoices
+// @Description Unpaid invoices past their due date, most overdue first, with the last reminder sent and the guardian opt-out (Admin)
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param student_id query string false "Student ID"
+// @Param guardian_phone query string false "Guardian phone"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=OverdueInvoiceListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/invoices/overdue [get]
+func (c *ControllerV1) ListOverdueInvoices(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listOverdueInvoicesUseCase.Execute(ctx, invoice.ListOverdueInvoicesInput{
+		StudentID:     ctx.Query("student_id"),
+		GuardianPhone: ctx.Query("guardian_phone"),
+		Page:          page,
+		Limit:         limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list overdue invoices: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list overdue invoices", err)
+		return
+	}
+
+	invoices := make([]OverdueInvoiceResponse, 0, len(output.Invoices))
+	for _, o := range output.Invoices {
+		resp := OverdueInvoiceResponse{
+			InvoiceResponse: MapInvoice(o.Invoice),
+			DaysOverdue:     o.DaysOverdue,
+			RemindersOptOut: o.OptedOut,
+		}
+		if o.Invoice.Student != nil {
+			resp.GuardianPhone = o.Invoice.Student.GuardianPhone
+		}
+		if o.LastReminder != nil {
+			resp.LastReminder = &ReminderResponse{
+				StepCode:  o.LastReminder.StepCode,
+				Status:    o.LastReminder.Status,
+				Recipient: o.LastReminder.Recipient,
+				Attempts:  o.LastReminder.Attempts,
+				SentAt:    o.LastReminder.SentAt,
+			}
+		}
+		invoices = append(invoices, resp)
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Overdue invoices retrieved successfully", OverdueInvoiceListResponse{
+		Invoices: invoices,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+func (c *ControllerV1) respondOutstanding(ctx *gin.Context, input invoice.GetOutstandingBalanceInput) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getOutstandingBalanceUseCase.Execute(ctx, input)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get outstanding balance: %v", err)
+		switch {
+		case errors.Is(err, invoice.ErrInvalidFilter):
+			rest.ResponseError(ctx, http.StatusBadRequest, "Student or guardian phone is required", err)
+		case errors.Is(err, invoice.ErrStudentNotFound):
+			rest.ResponseError(ctx, http.StatusNotFound, "Student not found", err)
+		default:
+			rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to get outstanding balance", err)
+		}
+		return
+	}
+
+	students := make([]StudentBalanceResponse, 0, len(output.Students))
+	for _, b := range output.Students {
+		students = append(students, StudentBalanceResponse{
+			StudentID:     b.StudentID,
+			StudentCode:   b.StudentCode,
+			StudentName:   b.StudentName,
+			GuardianPhone: b.GuardianPhone,
+			OpenInvoices:  b.OpenInvoices,
+			Billed:        b.Billed,
+			Paid:          b.Paid,
+			Outstanding:   b.Outstanding,
+			Overdue:       b.Overdue,
+			NextDueDate:   b.NextDueDate,
+		})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Outstanding balance retrieved successfully", OutstandingBalanceResponse{
+		Currency:    money.Currency,
+		Students:    students,
+		Billed:      output.Billed,
+		Paid:        output.Paid,
+		Outstanding: output.Outstanding,
+		Overdue:     output.Overdue,
+	})
+}
@@ -0,0 +1,46 @@
+package material
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for teaching material HTTP handlers
+type Controller interface {
+	UploadMaterial(ctx *gin.Context)
+	ListMaterials(ctx *gin.Context)
+	GetMaterial(ctx *gin.Context)
+	DownloadMaterial(ctx *gin.Context)
+	DeleteMaterial(ctx *gin.Context)
+	RequestAudit(ctx *gin.Context)
+	ListAnalyses(ctx *gin.Context)
+	GetMaterialText(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers material routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/materials")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readMaterials := middleware.PermissionMiddleware(constants.PermissionMaterialRead)
+	uploadMaterials := middleware.PermissionMiddleware(constants.PermissionMaterialUpload)
+
+	v1.Use(authMiddleware)
+
+	v1.POST("", uploadMaterials, controller.UploadMaterial)
+
+	// The uploader, or anyone holding material:manage; checked by the use cases
+	v1.DELETE("/:id", controller.DeleteMaterial)
+	v1.POST("/:id/audit", controller.RequestAudit)
+
+	// Read routes; students and guardians do not hold material:read
+	v1.GET("", readMaterials, controller.ListMaterials)
+	v1.GET("/:id", readMaterials, controller.GetMaterial)
+	v1.GET("/:id/download", readMaterials, controller.DownloadMaterial)
+	v1.GET("/:id/analyses", readMaterials, controller.ListAnalyses)
+	v1.GET("/:id/text", readMaterials, controller.GetMaterialText)
+}
@@ -0,0 +1,93 @@
+package material
+
+import "time"
+
+// MaterialResponse represents a material in the response
+type MaterialResponse struct {
+	ID               string              `json:"id"`
+	Title            string              `json:"title"`
+	Description      string              `json:"description"`
+	CourseID         *string             `json:"course_id"`
+	ClassID          *string             `json:"class_id"`
+	UploadedByID     string              `json:"uploaded_by_id"`
+	FileName         string              `json:"file_name"`
+	MimeType         string              `json:"mime_type"`
+	FileSize         int64               `json:"file_size"`
+	Checksum         string              `json:"checksum"`
+	AuditStatus      string              `json:"audit_status"`
+	AILabel          *string             `json:"ai_label"`
+	AuditedAt        *time.Time          `json:"audited_at"`
+	AuditError       string              `json:"audit_error,omitempty"`
+	ExtractedPages   int                 `json:"extracted_pages"`
+	ExtractionErrors []PageErrorResponse `json:"extraction_errors"`
+	ExtractedAt      *time.Time          `json:"extracted_at"`
+	ReviewStatus     string              `json:"review_status"`
+	ReviewComment    string              `json:"review_comment"`
+	ReviewedByID     *string             `json:"reviewed_by_id"`
+	ReviewedAt       *time.Time          `json:"reviewed_at"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
+
+// PageErrorResponse represents a text extraction error; page 0 is the whole document
+type PageErrorResponse struct {
+	Page    int    `json:"page"`
+	Message string `json:"message"`
+}
+
+// MaterialTextResponse represents the text extracted from a material
+type MaterialTextResponse struct {
+	MaterialID       string              `json:"material_id"`
+	Text             string              `json:"text"`
+	Pages            int                 `json:"pages"`
+	ExtractionErrors []PageErrorResponse `json:"extraction_errors"`
+	ExtractedAt      *time.Time          `json:"extracted_at"`
+}
+
+// ListMaterialsResponse represents the response for listing materials
+type ListMaterialsResponse struct {
+	Materials  []MaterialResponse `json:"materials"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// AnalysisReasonResponse represents one finding of an AI analysis
+type AnalysisReasonResponse struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"`
+	Message  string `json:"message"`
+	Excerpt  string `json:"excerpt,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+// AnalysisResultResponse represents one AI analysis of a material
+type AnalysisResultResponse struct {
+	ID         string                   `json:"id"`
+	Analyzer   string                   `json:"analyzer"`
+	Model      string                   `json:"model"`
+	Label      string                   `json:"label"`
+	Score      float64                  `json:"score"`
+	Summary    string                   `json:"summary"`
+	Reasons    []AnalysisReasonResponse `json:"reasons"`
+	TextLength int                      `json:"text_length"`
+	DurationMs int64                    `json:"duration_ms"`
+	CreatedAt  time.Time                `json:"created_at"`
+}
+
+// ListAnalysesResponse represents the audit history of a material
+type ListAnalysesResponse struct {
+	Material MaterialResponse         `json:"material"`
+	Analyses []AnalysisResultResponse `json:"analyses"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
+
+// MessageResponse represents a simple message response
+type MessageResponse struct {
+	Message string `json:"message"`
+}
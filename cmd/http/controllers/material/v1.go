@@ -0,0 +1,460 @@
+package material
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/cmd/http/rest"
+	"doan/internal/entities"
+	"doan/internal/usecases/audit"
+	"doan/internal/usecases/material"
+	"doan/pkg/logger"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	uploadMaterialUseCase   material.UploadMaterialUseCase
+	listMaterialsUseCase    material.ListMaterialsUseCase
+	getMaterialUseCase      material.GetMaterialUseCase
+	downloadMaterialUseCase material.DownloadMaterialUseCase
+	deleteMaterialUseCase   material.DeleteMaterialUseCase
+	requestAuditUseCase     audit.RequestMaterialAuditUseCase
+	listAnalysesUseCase     audit.ListMaterialAnalysesUseCase
+}
+
+func NewMaterialControllerV1(
+	uploadMaterialUseCase material.UploadMaterialUseCase,
+	listMaterialsUseCase material.ListMaterialsUseCase,
+	getMaterialUseCase material.GetMaterialUseCase,
+	downloadMaterialUseCase material.DownloadMaterialUseCase,
+	deleteMaterialUseCase material.DeleteMaterialUseCase,
+	requestAuditUseCase audit.RequestMaterialAuditUseCase,
+	listAnalysesUseCase audit.ListMaterialAnalysesUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		uploadMaterialUseCase:   uploadMaterialUseCase,
+		listMaterialsUseCase:    listMaterialsUseCase,
+		getMaterialUseCase:      getMaterialUseCase,
+		downloadMaterialUseCase: downloadMaterialUseCase,
+		deleteMaterialUseCase:   deleteMaterialUseCase,
+		requestAuditUseCase:     requestAuditUseCase,
+		listAnalysesUseCase:     listAnalysesUseCase,
+	}
+}
+
+// UploadMaterial godoc
+// @Summary Upload a teaching material
+// @Description Upload a file as teaching material (Teacher/Admin). The content type is sniffed from the file content.
+// @Tags Materials
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Material file"
+// @Param title formData string false "Title (defaults to the file name)"
+// @Param description formData string false "Description"
+// @Param course_id formData string false "Course ID"
+// @Param class_id formData string false "Class ID"
+// @Success 201 {object} rest.BaseResponse{data=MaterialResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 413 {object} rest.BaseResponse
+// @Failure 415 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/materials [post]
+func (c *ControllerV1) UploadMaterial(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		rest.ResponseError(ctx, http.StatusBadRequest, "File is required", err)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctxLogger.Errorf("Failed to open uploaded file: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Cannot read uploaded file", err)
+		return
+	}
+	defer file.Close()
+
+	output, err := c.uploadMaterialUseCase.Execute(ctx, material.UploadMaterialInput{
+		Title:        ctx.PostForm("title"),
+		Description:  ctx.PostForm("description"),
+		CourseID:     optionalForm(ctx, "course_id"),
+		ClassID:      optionalForm(ctx, "class_id"),
+		FileName:     fileHeader.Filename,
+		File:         file,
+		UploadedByID: ctx.GetString("user_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to upload material: %v", err)
+		switch {
+		case errors.Is(err, material.ErrFileTooLarge):
+			rest.ResponseError(ctx, http.StatusRequestEntityTooLarge, "File is too large", err)
+		case errors.Is(err, material.ErrFileTypeRejected):
+			rest.ResponseError(ctx, http.StatusUnsupportedMediaType, "File type is not allowed", err)
+		default:
+			rest.ResponseError(ctx, http.StatusBadRequest, "Failed to upload material", err)
+		}
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Material uploaded successfully", mapMaterialToResponse(output.Material))
+}
+
+// ListMaterials godoc
+// @Summary List materials
+// @Description Get a list of teaching materials with filtering and pagination
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search in title and file name"
+// @Param course_id query string false "Filter by course"
+// @Param class_id query string false "Filter by class"
+// @Param uploaded_by_id query string false "Filter by uploader"
+// @Param review_status query string false "Filter by compliance review status (PENDING, APPROVED, REJECTED)"
+// @Param ai_label query string false "Filter by AI label (SAFE, WARNING, DANGER)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param sort_by query string false "Sort field (created_at, updated_at, title, file_name, file_size, review_status, audit_status)"
+// @Param sort_order query string false "Sort order (asc, desc)" default(desc)
+// @Success 200 {object} rest.BaseResponse{data=ListMaterialsResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/materials [get]
+func (c *ControllerV1) ListMaterials(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	output, err := c.listMaterialsUseCase.Execute(ctx, material.ListMaterialsInput{
+		Search:       ctx.Query("search"),
+		CourseID:     ctx.Query("course_id"),
+		ClassID:      ctx.Query("class_id"),
+		UploadedByID: ctx.Query("uploaded_by_id"),
+		ReviewStatus: ctx.Query("review_status"),
+		AILabel:      ctx.Query("ai_label"),
+		Requester:    middleware.Principal(ctx),
+		Page:         page,
+		Limit:        limit,
+		SortBy:       ctx.Query("sort_by"),
+		SortOrder:    ctx.Query("sort_order"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list materials: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list materials", err)
+		return
+	}
+
+	materials := make([]MaterialResponse, 0, len(output.Materials))
+	for _, m := range output.Materials {
+		materials = append(materials, mapMaterialToResponse(m))
+	}
+
+	response := ListMaterialsResponse{
+		Materials: materials,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	}
+	rest.ResponseSuccess(ctx, http.StatusOK, "Materials retrieved successfully", response)
+}
+
+// GetMaterial godoc
+// @Summary Get material by ID
+// @Description Get teaching material metadata by ID
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 200 {object} rest.BaseResponse{data=MaterialResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Router /v1/materials/{id} [get]
+func (c *ControllerV1) GetMaterial(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getMaterialUseCase.Execute(ctx, material.GetMaterialInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Material retrieved successfully", mapMaterialToResponse(output.Material))
+}
+
+// DownloadMaterial godoc
+// @Summary Download material file
+// @Description Stream the stored file of a teaching material. Students cannot download rejected materials.
+// @Tags Materials
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 200 {file} file
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/materials/{id}/download [get]
+func (c *ControllerV1) DownloadMaterial(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.downloadMaterialUseCase.Execute(ctx, material.DownloadMaterialInput{
+		ID:        ctx.Param("id"),
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to download material: %v", err)
+		switch {
+		case errors.Is(err, material.ErrMaterialNotFound):
+			rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+		case errors.Is(err, material.ErrForbidden):
+			rest.ResponseError(ctx, http.StatusForbidden, "You don't have permission to download this material", err)
+		case errors.Is(err, material.ErrMaterialRejected):
+			rest.ResponseError(ctx, http.StatusForbidden, "This material was rejected by compliance review", err)
+		default:
+			rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to download material", err)
+		}
+		return
+	}
+	defer output.Content.Close()
+
+	headers := map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": output.Material.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   fmt.Sprintf("%q", output.Material.Checksum),
+	}
+	ctx.DataFromReader(http.StatusOK, output.Material.FileSize, output.Material.MimeType, output.Content, headers)
+}
+
+// DeleteMaterial godoc
+// @Summary Delete material
+// @Description Soft delete a material (uploader or Admin)
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 200 {object} rest.BaseResponse{data=MessageResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/materials/{id} [delete]
+func (c *ControllerV1) DeleteMaterial(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.deleteMaterialUseCase.Execute(ctx, material.DeleteMaterialInput{
+		ID:        ctx.Param("id"),
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to delete material: %v", err)
+		switch {
+		case errors.Is(err, material.ErrMaterialNotFound):
+			rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+		case errors.Is(err, material.ErrForbidden):
+			rest.ResponseError(ctx, http.StatusForbidden, "You don't have permission to delete this material", err)
+		default:
+			rest.ResponseError(ctx, http.StatusBadRequest, "Failed to delete material", err)
+		}
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, output.Message, MessageResponse{Message: output.Message})
+}
+
+// RequestAudit godoc
+// @Summary Request AI audit
+// @Description Queue a material for (re-)analysis by the AI content audit (uploader or Admin)
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 202 {object} rest.BaseResponse{data=MessageResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/materials/{id}/audit [post]
+func (c *ControllerV1) RequestAudit(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.requestAuditUseCase.Execute(ctx, audit.RequestMaterialAuditInput{
+		MaterialID: ctx.Param("id"),
+		Requester:  middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to request material audit: %v", err)
+		switch {
+		case errors.Is(err, audit.ErrMaterialNotFound):
+			rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+		case errors.Is(err, audit.ErrForbidden):
+			rest.ResponseError(ctx, http.StatusForbidden, "You don't have permission to audit this material", err)
+		default:
+			rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to request material audit", err)
+		}
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusAccepted, output.Message, MessageResponse{Message: output.Message})
+}
+
+// ListAnalyses godoc
+// @Summary List AI analyses
+// @Description Get the AI audit history of a material, newest first
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 200 {object} rest.BaseResponse{data=ListAnalysesResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/materials/{id}/analyses [get]
+func (c *ControllerV1) ListAnalyses(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.listAnalysesUseCase.Execute(ctx, audit.ListMaterialAnalysesInput{
+		MaterialID: ctx.Param("id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list material analyses: %v", err)
+		if errors.Is(err, audit.ErrMaterialNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list material analyses", err)
+		return
+	}
+
+	analyses := make([]AnalysisResultResponse, 0, len(output.Results))
+	for _, result := range output.Results {
+		analyses = append(analyses, mapAnalysisToResponse(result))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Material analyses retrieved successfully", ListAnalysesResponse{
+		Material: mapMaterialToResponse(output.Material),
+		Analyses: analyses,
+	})
+}
+
+// GetMaterialText godoc
+// @Summary Get extracted text
+// @Description Get the plain text extracted from a material by the audit pipeline, with per-page extraction errors
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Material ID"
+// @Success 200 {object} rest.BaseResponse{data=MaterialTextResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Router /v1/materials/{id}/text [get]
+func (c *ControllerV1) GetMaterialText(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getMaterialUseCase.Execute(ctx, material.GetMaterialInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		rest.ResponseError(ctx, http.StatusNotFound, "Material not found", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Material text retrieved successfully", MaterialTextResponse{
+		MaterialID:       output.Material.ID,
+		Text:             output.Material.ExtractedText,
+		Pages:            output.Material.ExtractedPages,
+		ExtractionErrors: mapPageErrors(output.Material.ExtractionErrors),
+		ExtractedAt:      output.Material.ExtractedAt,
+	})
+}
+
+func optionalForm(ctx *gin.Context, key string) *string {
+	value := ctx.PostForm(key)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func mapMaterialToResponse(m *entities.Material) MaterialResponse {
+	return MaterialResponse{
+		ID:               m.ID,
+		Title:            m.Title,
+		Description:      m.Description,
+		CourseID:         m.CourseID,
+		ClassID:          m.ClassID,
+		UploadedByID:     m.UploadedByID,
+		FileName:         m.FileName,
+		MimeType:         m.MimeType,
+		FileSize:         m.FileSize,
+		Checksum:         m.Checksum,
+		AuditStatus:      m.AuditStatus,
+		AILabel:          m.AILabel,
+		AuditedAt:        m.AuditedAt,
+		AuditError:       m.AuditError,
+		ExtractedPages:   m.ExtractedPages,
+		ExtractionErrors: mapPageErrors(m.ExtractionErrors),
+		ExtractedAt:      m.ExtractedAt,
+		ReviewStatus:     m.ReviewStatus,
+		ReviewComment:    m.ReviewComment,
+		ReviewedByID:     m.ReviewedByID,
+		ReviewedAt:       m.ReviewedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func mapPageErrors(errs entities.PageErrors) []PageErrorResponse {
+	responses := make([]PageErrorResponse, 0, len(errs))
+	for _, e := range errs {
+		responses = append(responses, PageErrorResponse{Page: e.Page, Message: e.Message})
+	}
+	return responses
+}
+
+func mapAnalysisToResponse(r *entities.AIAnalysisResult) AnalysisResultResponse {
+	reasons := make([]AnalysisReasonResponse, 0, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		reasons = append(reasons, AnalysisReasonResponse{
+			Code:     reason.Code,
+			Label:    reason.Label,
+			Message:  reason.Message,
+			Excerpt:  reason.Excerpt,
+			Location: reason.Location,
+		})
+	}
+	return AnalysisResultResponse{
+		ID:         r.ID,
+		Analyzer:   r.Analyzer,
+		Model:      r.Model,
+		Label:      r.Label,
+		Score:      r.Score,
+		Summary:    r.Summary,
+		Reasons:    reasons,
+		TextLength: r.TextLength,
+		DurationMs: r.DurationMs,
+		CreatedAt:  r.CreatedAt,
+	}
+}
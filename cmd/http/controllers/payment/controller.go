@@ -0,0 +1,46 @@
+package payment
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for payment HTTP handlers
+type Controller interface {
+	RecordPayment(ctx *gin.Context)
+	ListPayments(ctx *gin.Context)
+	GetPayment(ctx *gin.Context)
+	RefundPayment(ctx *gin.Context)
+	VoidPayment(ctx *gin.Context)
+	GetDailyReconciliation(ctx *gin.Context)
+	GetInvoiceQR(ctx *gin.Context)
+	HandleWebhook(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers payment routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/payments")
+
+	// Provider webhooks are authenticated by the provider signature, not a user token
+	v1.POST("/webhooks/:provider", controller.HandleWebhook)
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readPayments := middleware.PermissionMiddleware(constants.PermissionPaymentRead)
+	recordPayments := middleware.PermissionMiddleware(constants.PermissionPaymentRecord)
+	refundPayments := middleware.PermissionMiddleware(constants.PermissionPaymentRefund)
+
+	staff := v1.Group("", authMiddleware)
+
+	// Cashier routes
+	staff.POST("", recordPayments, controller.RecordPayment)
+	staff.GET("", readPayments, controller.ListPayments)
+	staff.GET("/reconciliation/daily", readPayments, controller.GetDailyReconciliation)
+	staff.GET("/invoices/:invoice_id/vietqr", readPayments, controller.GetInvoiceQR)
+	staff.GET("/:id", readPayments, controller.GetPayment)
+	staff.POST("/:id/refund", refundPayments, controller.RefundPayment)
+	staff.POST("/:id/void", refundPayments, controller.VoidPayment)
+}
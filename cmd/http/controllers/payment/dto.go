@@ -0,0 +1,180 @@
+package payment
+
+import (
+	"doan/cmd/http/controllers/invoice"
+	"doan/internal/entities"
+	"doan/pkg/money"
+	"time"
+)
+
+// AllocationRequest represents the part of a payment or refund applied to one invoice
+type AllocationRequest struct {
+	InvoiceID string       `json:"invoice_id" binding:"required"`
+	Amount    money.Amount `json:"amount" binding:"required" swaggertype:"integer"`
+}
+
+// RecordPaymentRequest represents money received at the counter.
+// Without allocations the amount is applied to the student's open invoices, oldest due first.
+type RecordPaymentRequest struct {
+	Method      string              `json:"method" binding:"required" example:"CASH"`
+	Amount      money.Amount        `json:"amount" binding:"required" swaggertype:"integer" example:"1500000"`
+	Reference   string              `json:"reference"`
+	PayerName   string              `json:"payer_name"`
+	ReceivedAt  *time.Time          `json:"received_at"`
+	Notes       string              `json:"notes"`
+	StudentID   string              `json:"student_id"`
+	Allocations []AllocationRequest `json:"allocations"`
+}
+
+// RefundPaymentRequest represents money returned to the payer
+type RefundPaymentRequest struct {
+	Amount      money.Amount        `json:"amount" binding:"required" swaggertype:"integer"`
+	Method      string              `json:"method"`
+	Reference   string              `json:"reference"`
+	Reason      string              `json:"reason" binding:"required"`
+	Allocations []AllocationRequest `json:"allocations"`
+}
+
+// VoidPaymentRequest represents the reason a payment was entered by mistake
+type VoidPaymentRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// AllocationResponse represents the part of a payment applied to one invoice; negative for refunds
+type AllocationResponse struct {
+	InvoiceID     string       `json:"invoice_id"`
+	InvoiceNumber string       `json:"invoice_number,omitempty"`
+	Amount        money.Amount `json:"amount" swaggertype:"integer"`
+}
+
+// PaymentResponse represents a payment or refund; amounts are whole VND
+type PaymentResponse struct {
+	ID            string               `json:"id"`
+	ReceiptNumber string               `json:"receipt_number"`
+	Kind          string               `json:"kind"`
+	Method        string               `json:"method"`
+	Status        string               `json:"status"`
+	Amount        money.Amount         `json:"amount" swaggertype:"integer"`
+	Currency      string               `json:"currency"`
+	Provider      string               `json:"provider,omitempty"`
+	Reference     string               `json:"reference,omitempty"`
+	PayerName     string               `json:"payer_name,omitempty"`
+	CashierID     *string              `json:"cashier_id"`
+	CashierName   string               `json:"cashier_name,omitempty"`
+	ReceivedAt    time.Time            `json:"received_at"`
+	RefundOfID    *string              `json:"refund_of_id,omitempty"`
+	VoidedAt      *time.Time           `json:"voided_at,omitempty"`
+	VoidReason    string               `json:"void_reason,omitempty"`
+	Notes         string               `json:"notes,omitempty"`
+	Allocations   []AllocationResponse `json:"allocations"`
+}
+
+// PaymentDetailResponse represents a payment with its refunds
+type PaymentDetailResponse struct {
+	PaymentResponse
+	Refunds []PaymentResponse `json:"refunds,omitempty"`
+}
+
+// PaymentResultResponse represents a recorded, refunded or voided payment and the invoices it changed
+type PaymentResultResponse struct {
+	Payment   PaymentResponse           `json:"payment"`
+	Invoices  []invoice.InvoiceResponse `json:"invoices"`
+	Duplicate bool                      `json:"duplicate,omitempty"`
+}
+
+// PaymentListResponse represents one page of payments
+type PaymentListResponse struct {
+	Payments   []PaymentResponse `json:"payments"`
+	Pagination PaginationMeta    `json:"pagination"`
+}
+
+// MethodTotalResponse represents what a cashier handled through one method
+type MethodTotalResponse struct {
+	Method    string       `json:"method"`
+	Payments  int64        `json:"payments"`
+	Collected money.Amount `json:"collected" swaggertype:"integer"`
+	Refunds   int64        `json:"refunds"`
+	Refunded  money.Amount `json:"refunded" swaggertype:"integer"`
+}
+
+// CashierReconciliationResponse represents the till of one cashier for the day
+type CashierReconciliationResponse struct {
+	CashierID   string                `json:"cashier_id"`
+	CashierName string                `json:"cashier_name"`
+	Collected   money.Amount          `json:"collected" swaggertype:"integer"`
+	Refunded    money.Amount          `json:"refunded" swaggertype:"integer"`
+	Net         money.Amount          `json:"net" swaggertype:"integer"`
+	VoidedCount int64                 `json:"voided_count"`
+	Voided      money.Amount          `json:"voided" swaggertype:"integer"`
+	Methods     []MethodTotalResponse `json:"methods"`
+}
+
+// DailyReconciliationResponse represents the day's totals per cashier
+type DailyReconciliationResponse struct {
+	Date      string                          `json:"date"`
+	Currency  string                          `json:"currency"`
+	Cashiers  []CashierReconciliationResponse `json:"cashiers"`
+	Collected money.Amount                    `json:"collected" swaggertype:"integer"`
+	Refunded  money.Amount                    `json:"refunded" swaggertype:"integer"`
+	Net       money.Amount                    `json:"net" swaggertype:"integer"`
+	Voided    money.Amount                    `json:"voided" swaggertype:"integer"`
+}
+
+// InvoiceQRResponse represents a VietQR payload to render as a QR code
+type InvoiceQRResponse struct {
+	Payload       string       `json:"payload"`
+	BankBIN       string       `json:"bank_bin"`
+	AccountNumber string       `json:"account_number"`
+	AccountName   string       `json:"account_name"`
+	Amount        money.Amount `json:"amount" swaggertype:"integer"`
+	Description   string       `json:"description"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
+
+func mapPayment(p *entities.Payment) PaymentResponse {
+	resp := PaymentResponse{
+		ID:            p.ID,
+		ReceiptNumber: p.ReceiptNumber,
+		Kind:          p.Kind,
+		Method:        p.Method,
+		Status:        p.Status,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		Provider:      p.Provider,
+		Reference:     p.Reference,
+		PayerName:     p.PayerName,
+		CashierID:     p.CashierID,
+		ReceivedAt:    p.ReceivedAt,
+		RefundOfID:    p.RefundOfID,
+		VoidedAt:      p.VoidedAt,
+		VoidReason:    p.VoidReason,
+		Notes:         p.Notes,
+		Allocations:   make([]AllocationResponse, 0, len(p.Allocations)),
+	}
+	if p.Cashier != nil {
+		resp.CashierName = p.Cashier.FullName
+	}
+	for _, a := range p.Allocations {
+		allocation := AllocationResponse{InvoiceID: a.InvoiceID, Amount: a.Amount}
+		if a.Invoice != nil {
+			allocation.InvoiceNumber = a.Invoice.Number
+		}
+		resp.Allocations = append(resp.Allocations, allocation)
+	}
+	return resp
+}
+
+func mapInvoices(invoices []*entities.Invoice) []invoice.InvoiceResponse {
+	result := make([]invoice.InvoiceResponse, 0, len(invoices))
+	for _, i := range invoices {
+		result = append(result, invoice.MapInvoice(i))
+	}
+	return result
+}
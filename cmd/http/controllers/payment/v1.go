@@ -0,0 +1,483 @@
+package payment
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/services/gateway"
+	"doan/internal/usecases/payment"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBody bounds webhook payloads; provider notifications are a few KB
+const maxWebhookBody = 1 << 20
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	recordPaymentUseCase          payment.RecordPaymentUseCase
+	refundPaymentUseCase          payment.RefundPaymentUseCase
+	voidPaymentUseCase            payment.VoidPaymentUseCase
+	getPaymentUseCase             payment.GetPaymentUseCase
+	listPaymentsUseCase           payment.ListPaymentsUseCase
+	getDailyReconciliationUseCase payment.GetDailyReconciliationUseCase
+	generateInvoiceQRUseCase      payment.GenerateInvoiceQRUseCase
+	handleWebhookUseCase          payment.HandleWebhookUseCase
+}
+
+func NewPaymentControllerV1(
+	recordPaymentUseCase payment.RecordPaymentUseCase,
+	refundPaymentUseCase payment.RefundPaymentUseCase,
+	voidPaymentUseCase payment.VoidPaymentUseCase,
+	getPaymentUseCase payment.GetPaymentUseCase,
+	listPaymentsUseCase payment.ListPaymentsUseCase,
+	getDailyReconciliationUseCase payment.GetDailyReconciliationUseCase,
+	generateInvoiceQRUseCase payment.GenerateInvoiceQRUseCase,
+	handleWebhookUseCase payment.HandleWebhookUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		recordPaymentUseCase:          recordPaymentUseCase,
+		refundPaymentUseCase:          refundPaymentUseCase,
+		voidPaymentUseCase:            voidPaymentUseCase,
+		getPaymentUseCase:             getPaymentUseCase,
+		listPaymentsUseCase:           listPaymentsUseCase,
+		getDailyReconciliationUseCase: getDailyReconciliationUseCase,
+		generateInvoiceQRUseCase:      generateInvoiceQRUseCase,
+		handleWebhookUseCase:          handleWebhookUseCase,
+	}
+}
+
+// RecordPayment godoc
+// @Summary Record payment
+// @Description Record cash, bank transfer or VietQR money received and allocate it across invoices (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RecordPaymentRequest true "Payment"
+// @Success 201 {object} rest.BaseResponse{data=PaymentResultResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments [post]
+func (c *ControllerV1) RecordPayment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req RecordPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.recordPaymentUseCase.Execute(ctx, payment.RecordPaymentInput{
+		Method:      req.Method,
+		Amount:      req.Amount,
+		Reference:   req.Reference,
+		PayerName:   req.PayerName,
+		ReceivedAt:  req.ReceivedAt,
+		Notes:       req.Notes,
+		StudentID:   req.StudentID,
+		Allocations: toAllocations(req.Allocations),
+		CashierID:   ctx.GetString("user_id"),
+		CashierRole: ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to record payment: %v", err)
+		respondPaymentError(ctx, err, "Failed to record payment")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Payment recorded successfully", PaymentResultResponse{
+		Payment:  mapPayment(output.Payment),
+		Invoices: mapInvoices(output.Invoices),
+	})
+}
+
+// ListPayments godoc
+// @Summary List payments
+// @Description List payments and refunds filtered by invoice, student, cashier, method, kind, status and date (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param invoice_id query string false "Invoice ID"
+// @Param student_id query string false "Student ID"
+// @Param cashier_id query string false "Cashier user ID"
+// @Param method query string false "Method (CASH, BANK_TRANSFER, VIETQR)"
+// @Param kind query string false "Kind (PAYMENT, REFUND)"
+// @Param status query string false "Status (COMPLETED, VOIDED)"
+// @Param from query string false "From date, YYYY-MM-DD"
+// @Param to query string false "To date, YYYY-MM-DD, inclusive"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=PaymentListResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments [get]
+func (c *ControllerV1) ListPayments(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listPaymentsUseCase.Execute(ctx, payment.ListPaymentsInput{
+		InvoiceID: ctx.Query("invoice_id"),
+		StudentID: ctx.Query("student_id"),
+		CashierID: ctx.Query("cashier_id"),
+		Method:    ctx.Query("method"),
+		Kind:      ctx.Query("kind"),
+		Status:    ctx.Query("status"),
+		From:      ctx.Query("from"),
+		To:        ctx.Query("to"),
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list payments: %v", err)
+		respondPaymentError(ctx, err, "Failed to list payments")
+		return
+	}
+
+	payments := make([]PaymentResponse, 0, len(output.Payments))
+	for _, p := range output.Payments {
+		payments = append(payments, mapPayment(p))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payments retrieved successfully", PaymentListResponse{
+		Payments: payments,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// GetPayment godoc
+// @Summary Get payment
+// @Description Get a payment with its allocations and refunds (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Success 200 {object} rest.BaseResponse{data=PaymentDetailResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments/{id} [get]
+func (c *ControllerV1) GetPayment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getPaymentUseCase.Execute(ctx, payment.GetPaymentInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payment: %v", err)
+		respondPaymentError(ctx, err, "Failed to get payment")
+		return
+	}
+
+	resp := PaymentDetailResponse{PaymentResponse: mapPayment(output.Payment)}
+	for _, r := range output.Refunds {
+		resp.Refunds = append(resp.Refunds, mapPayment(r))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payment retrieved successfully", resp)
+}
+
+// RefundPayment godoc
+// @Summary Refund payment
+// @Description Refund part or all of a payment; never more than was paid and not yet refunded (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Param request body RefundPaymentRequest true "Refund"
+// @Success 201 {object} rest.BaseResponse{data=PaymentResultResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments/{id}/refund [post]
+func (c *ControllerV1) RefundPayment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req RefundPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.refundPaymentUseCase.Execute(ctx, payment.RefundPaymentInput{
+		PaymentID:   ctx.Param("id"),
+		Amount:      req.Amount,
+		Method:      req.Method,
+		Reference:   req.Reference,
+		Reason:      req.Reason,
+		Allocations: toAllocations(req.Allocations),
+		ActorID:     ctx.GetString("user_id"),
+		ActorRole:   ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to refund payment: %v", err)
+		respondPaymentError(ctx, err, "Failed to refund payment")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Payment refunded successfully", PaymentResultResponse{
+		Payment:  mapPayment(output.Refund),
+		Invoices: mapInvoices(output.Invoices),
+	})
+}
+
+// VoidPayment godoc
+// @Summary Void payment
+// @Description Void a payment or refund entered by mistake and restore the invoice balances (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Param request body VoidPaymentRequest true "Reason"
+// @Success 200 {object} rest.BaseResponse{data=PaymentResultResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments/{id}/void [post]
+func (c *ControllerV1) VoidPayment(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req VoidPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.voidPaymentUseCase.Execute(ctx, payment.VoidPaymentInput{
+		PaymentID: ctx.Param("id"),
+		Reason:    req.Reason,
+		ActorID:   ctx.GetString("user_id"),
+		ActorRole: ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to void payment: %v", err)
+		respondPaymentError(ctx, err, "Failed to void payment")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payment voided successfully", PaymentResultResponse{
+		Payment:  mapPayment(output.Payment),
+		Invoices: mapInvoices(output.Invoices),
+	})
+}
+
+// GetDailyReconciliation godoc
+// @Summary Daily reconciliation
+// @Description Payments collected and refunded per cashier and method on a business day, for closing the till (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Business day, YYYY-MM-DD in Vietnam time (default today)"
+// @Success 200 {object} rest.BaseResponse{data=DailyReconciliationResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments/reconciliation/daily [get]
+func (c *ControllerV1) GetDailyReconciliation(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getDailyReconciliationUseCase.Execute(ctx, payment.GetDailyReconciliationInput{
+		Date: ctx.Query("date"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to reconcile payments: %v", err)
+		respondPaymentError(ctx, err, "Failed to reconcile payments")
+		return
+	}
+
+	cashiers := make([]CashierReconciliationResponse, 0, len(output.Cashiers))
+	for _, cashier := range output.Cashiers {
+		methods := make([]MethodTotalResponse, 0, len(cashier.Methods))
+		for _, m := range cashier.Methods {
+			methods = append(methods, MethodTotalResponse{
+				Method:    m.Method,
+				Payments:  m.Payments,
+				Collected: m.Collected,
+				Refunds:   m.Refunds,
+				Refunded:  m.Refunded,
+			})
+		}
+		cashiers = append(cashiers, CashierReconciliationResponse{
+			CashierID:   cashier.CashierID,
+			CashierName: cashier.CashierName,
+			Collected:   cashier.Collected,
+			Refunded:    cashier.Refunded,
+			Net:         cashier.Net,
+			VoidedCount: cashier.VoidedCount,
+			Voided:      cashier.Voided,
+			Methods:     methods,
+		})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Reconciliation retrieved successfully", DailyReconciliationResponse{
+		Date:      output.Date,
+		Currency:  money.Currency,
+		Cashiers:  cashiers,
+		Collected: output.Collected,
+		Refunded:  output.Refunded,
+		Net:       output.Net,
+		Voided:    output.Voided,
+	})
+}
+
+// GetInvoiceQR godoc
+// @Summary Invoice VietQR
+// @Description VietQR payload for paying an invoice by bank transfer; the transfer content carries the invoice number (Admin)
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param invoice_id path string true "Invoice ID"
+// @Param amount query int false "Amount in VND (default the remaining balance)"
+// @Success 200 {object} rest.BaseResponse{data=InvoiceQRResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments/invoices/{invoice_id}/vietqr [get]
+func (c *ControllerV1) GetInvoiceQR(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var amount money.Amount
+	if raw := ctx.Query("amount"); raw != "" {
+		parsed, err := money.Parse(raw)
+		if err != nil {
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid amount", err)
+			return
+		}
+		amount = parsed
+	}
+
+	output, err := c.generateInvoiceQRUseCase.Execute(ctx, payment.GenerateInvoiceQRInput{
+		InvoiceID: ctx.Param("invoice_id"),
+		Amount:    amount,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to generate VietQR: %v", err)
+		respondPaymentError(ctx, err, "Failed to generate VietQR")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "VietQR generated successfully", InvoiceQRResponse{
+		Payload:       output.Payload,
+		BankBIN:       output.BankBIN,
+		AccountNumber: output.AccountNumber,
+		AccountName:   output.AccountName,
+		Amount:        output.Amount,
+		Description:   output.Description,
+	})
+}
+
+// HandleWebhook godoc
+// @Summary Payment provider webhook
+// @Description Credit notification from a payment provider, verified by the provider signature. Redeliveries are acknowledged without recording twice.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} rest.BaseResponse{data=PaymentResultResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 422 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payments/webhooks/{provider} [post]
+func (c *ControllerV1) HandleWebhook(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxWebhookBody))
+	if err != nil {
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.handleWebhookUseCase.Execute(ctx, payment.HandleWebhookInput{
+		Provider: ctx.Param("provider"),
+		Header:   ctx.Request.Header,
+		Body:     body,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to handle payment webhook: %v", err)
+		switch {
+		case errors.Is(err, gateway.ErrUnknownProvider):
+			rest.ResponseError(ctx, http.StatusNotFound, "Unknown payment provider", err)
+		case errors.Is(err, gateway.ErrInvalidSignature):
+			rest.ResponseError(ctx, http.StatusUnauthorized, "Invalid webhook signature", err)
+		case errors.Is(err, gateway.ErrInvalidEvent):
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid webhook event", err)
+		case errors.Is(err, payment.ErrUnmatchedTransfer), errors.Is(err, payment.ErrOverpayment):
+			rest.ResponseError(ctx, http.StatusUnprocessableEntity, "Transfer needs manual allocation", err)
+		default:
+			respondPaymentError(ctx, err, "Failed to handle payment webhook")
+		}
+		return
+	}
+
+	message := "Payment recorded successfully"
+	if output.Duplicate {
+		message = "Payment already recorded"
+	}
+	rest.ResponseSuccess(ctx, http.StatusOK, message, PaymentResultResponse{
+		Payment:   mapPayment(output.Payment),
+		Invoices:  mapInvoices(output.Invoices),
+		Duplicate: output.Duplicate,
+	})
+}
+
+func toAllocations(requests []AllocationRequest) []payment.AllocationInput {
+	allocations := make([]payment.AllocationInput, 0, len(requests))
+	for _, r := range requests {
+		allocations = append(allocations, payment.AllocationInput{InvoiceID: r.InvoiceID, Amount: r.Amount})
+	}
+	return allocations
+}
+
+func respondPaymentError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, payment.ErrPaymentNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, "Payment not found", err)
+	case errors.Is(err, payment.ErrInvoiceNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, "Invoice not found", err)
+	case errors.Is(err, payment.ErrInvalidAmount),
+		errors.Is(err, payment.ErrInvalidMethod),
+		errors.Is(err, payment.ErrAllocationRequired),
+		errors.Is(err, payment.ErrAllocationMismatch),
+		errors.Is(err, payment.ErrReasonRequired),
+		errors.Is(err, payment.ErrInvalidDate):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, payment.ErrOverAllocation),
+		errors.Is(err, payment.ErrOverpayment),
+		errors.Is(err, payment.ErrInvoiceVoid),
+		errors.Is(err, payment.ErrInvoiceOverpaid),
+		errors.Is(err, payment.ErrInvoiceSettled),
+		errors.Is(err, payment.ErrNotRefundable),
+		errors.Is(err, payment.ErrRefundExceedsPaid),
+		errors.Is(err, payment.ErrAlreadyVoided),
+		errors.Is(err, payment.ErrHasRefunds):
+		rest.ResponseError(ctx, http.StatusConflict, err.Error(), err)
+	case errors.Is(err, payment.ErrVietQRNotConfigured):
+		rest.ResponseError(ctx, http.StatusServiceUnavailable, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
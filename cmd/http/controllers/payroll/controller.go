@@ -0,0 +1,52 @@
+package payroll
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for teacher payroll HTTP handlers
+type Controller interface {
+	ListRateCards(ctx *gin.Context)
+	CreateRateCard(ctx *gin.Context)
+	UpdateRateCard(ctx *gin.Context)
+	DeleteRateCard(ctx *gin.Context)
+	ListRuns(ctx *gin.Context)
+	CreateRun(ctx *gin.Context)
+	GetRun(ctx *gin.Context)
+	RecalculateRun(ctx *gin.Context)
+	FinaliseRun(ctx *gin.Context)
+	DeleteRun(ctx *gin.Context)
+	ExportRun(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers teacher payroll routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/payroll")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readPayroll := middleware.PermissionMiddleware(constants.PermissionPayrollRead)
+	writePayroll := middleware.PermissionMiddleware(constants.PermissionPayrollWrite)
+	finalisePayroll := middleware.PermissionMiddleware(constants.PermissionPayrollFinalise)
+
+	v1.Use(authMiddleware)
+
+	// Rate cards
+	v1.GET("/rate-cards", readPayroll, controller.ListRateCards)
+	v1.POST("/rate-cards", writePayroll, controller.CreateRateCard)
+	v1.PUT("/rate-cards/:id", writePayroll, controller.UpdateRateCard)
+	v1.DELETE("/rate-cards/:id", writePayroll, controller.DeleteRateCard)
+
+	// Runs
+	v1.GET("/runs", readPayroll, controller.ListRuns)
+	v1.POST("/runs", writePayroll, controller.CreateRun)
+	v1.GET("/runs/:id", readPayroll, controller.GetRun)
+	v1.GET("/runs/:id/export", readPayroll, controller.ExportRun)
+	v1.POST("/runs/:id/recalculate", writePayroll, controller.RecalculateRun)
+	v1.POST("/runs/:id/finalise", finalisePayroll, controller.FinaliseRun)
+	v1.DELETE("/runs/:id", writePayroll, controller.DeleteRun)
+}
@@ -0,0 +1,117 @@
+package payroll
+
+import (
+	"doan/pkg/money"
+	"time"
+)
+
+// CreateRateCardRequest represents a teacher pay rate; omit course_id for the teacher's default rate
+type CreateRateCardRequest struct {
+	TeacherID     string       `json:"teacher_id" binding:"required"`
+	CourseID      string       `json:"course_id"`
+	RateType      string       `json:"rate_type" binding:"required" example:"PER_HOUR"`
+	Amount        money.Amount `json:"amount" binding:"required" swaggertype:"integer" example:"250000"`
+	EffectiveFrom string       `json:"effective_from" binding:"required" example:"2026-09-01"`
+	EffectiveTo   string       `json:"effective_to" example:"2027-08-31"`
+	Notes         string       `json:"notes"`
+}
+
+// UpdateRateCardRequest represents the rate card fields to change; an empty effective_to makes the card open-ended
+type UpdateRateCardRequest struct {
+	RateType      *string       `json:"rate_type"`
+	Amount        *money.Amount `json:"amount" swaggertype:"integer"`
+	EffectiveFrom *string       `json:"effective_from"`
+	EffectiveTo   *string       `json:"effective_to"`
+	Notes         *string       `json:"notes"`
+}
+
+// RateCardResponse represents a rate card; amounts are whole VND
+type RateCardResponse struct {
+	ID            string       `json:"id"`
+	TeacherID     string       `json:"teacher_id"`
+	TeacherName   string       `json:"teacher_name,omitempty"`
+	CourseID      *string      `json:"course_id"`
+	CourseName    string       `json:"course_name,omitempty"`
+	RateType      string       `json:"rate_type"`
+	Amount        money.Amount `json:"amount" swaggertype:"integer"`
+	EffectiveFrom string       `json:"effective_from"`
+	EffectiveTo   *string      `json:"effective_to"`
+	Notes         string       `json:"notes,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// RateCardListResponse represents one page of rate cards
+type RateCardListResponse struct {
+	RateCards  []RateCardResponse `json:"rate_cards"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// CreateRunRequest represents the month to pay
+type CreateRunRequest struct {
+	Period string `json:"period" binding:"required" example:"2026-09"`
+	Notes  string `json:"notes"`
+}
+
+// RunResponse represents a payroll run summary
+type RunResponse struct {
+	ID            string       `json:"id"`
+	Period        string       `json:"period"`
+	Status        string       `json:"status"`
+	TeacherCount  int          `json:"teacher_count"`
+	LessonCount   int          `json:"lesson_count"`
+	UnratedCount  int          `json:"unrated_count"`
+	TotalAmount   money.Amount `json:"total_amount" swaggertype:"integer"`
+	CalculatedAt  time.Time    `json:"calculated_at"`
+	FinalisedAt   *time.Time   `json:"finalised_at,omitempty"`
+	FinalisedByID *string      `json:"finalised_by_id,omitempty"`
+	Notes         string       `json:"notes,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// RunDetailResponse represents a payroll run with its per-teacher items
+type RunDetailResponse struct {
+	RunResponse
+	Items []ItemResponse `json:"items"`
+}
+
+// ItemResponse represents the pay of one teacher
+type ItemResponse struct {
+	TeacherID            string         `json:"teacher_id"`
+	TeacherCode          string         `json:"teacher_code,omitempty"`
+	TeacherName          string         `json:"teacher_name,omitempty"`
+	Sessions             int            `json:"sessions"`
+	Minutes              int            `json:"minutes"`
+	SubstitutionSessions int            `json:"substitution_sessions"`
+	UnratedSessions      int            `json:"unrated_sessions"`
+	Amount               money.Amount   `json:"amount" swaggertype:"integer"`
+	Lines                []LineResponse `json:"lines,omitempty"`
+}
+
+// LineResponse represents one lesson taught and what it paid
+type LineResponse struct {
+	LessonID       string       `json:"lesson_id"`
+	ClassID        string       `json:"class_id"`
+	ClassCode      string       `json:"class_code"`
+	ClassName      string       `json:"class_name"`
+	DateStart      time.Time    `json:"date_start"`
+	Minutes        int          `json:"minutes"`
+	IsSubstitution bool         `json:"is_substitution"`
+	RateCardID     *string      `json:"rate_card_id"`
+	RateType       string       `json:"rate_type"`
+	Rate           money.Amount `json:"rate" swaggertype:"integer"`
+	Amount         money.Amount `json:"amount" swaggertype:"integer"`
+}
+
+// RunListResponse represents one page of payroll runs
+type RunListResponse struct {
+	Runs       []RunResponse  `json:"runs"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
@@ -0,0 +1,530 @@
+package payroll
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/entities"
+	"doan/internal/usecases/payroll"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listRateCardsUseCase         payroll.ListRateCardsUseCase
+	createRateCardUseCase        payroll.CreateRateCardUseCase
+	updateRateCardUseCase        payroll.UpdateRateCardUseCase
+	deleteRateCardUseCase        payroll.DeleteRateCardUseCase
+	listPayrollRunsUseCase       payroll.ListPayrollRunsUseCase
+	createPayrollRunUseCase      payroll.CreatePayrollRunUseCase
+	getPayrollRunUseCase         payroll.GetPayrollRunUseCase
+	recalculatePayrollRunUseCase payroll.RecalculatePayrollRunUseCase
+	finalisePayrollRunUseCase    payroll.FinalisePayrollRunUseCase
+	deletePayrollRunUseCase      payroll.DeletePayrollRunUseCase
+	exportPayrollRunUseCase      payroll.ExportPayrollRunUseCase
+}
+
+func NewPayrollControllerV1(
+	listRateCardsUseCase payroll.ListRateCardsUseCase,
+	createRateCardUseCase payroll.CreateRateCardUseCase,
+	updateRateCardUseCase payroll.UpdateRateCardUseCase,
+	deleteRateCardUseCase payroll.DeleteRateCardUseCase,
+	listPayrollRunsUseCase payroll.ListPayrollRunsUseCase,
+	createPayrollRunUseCase payroll.CreatePayrollRunUseCase,
+	getPayrollRunUseCase payroll.GetPayrollRunUseCase,
+	recalculatePayrollRunUseCase payroll.RecalculatePayrollRunUseCase,
+	finalisePayrollRunUseCase payroll.FinalisePayrollRunUseCase,
+	deletePayrollRunUseCase payroll.DeletePayrollRunUseCase,
+	exportPayrollRunUseCase payroll.ExportPayrollRunUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listRateCardsUseCase:         listRateCardsUseCase,
+		createRateCardUseCase:        createRateCardUseCase,
+		updateRateCardUseCase:        updateRateCardUseCase,
+		deleteRateCardUseCase:        deleteRateCardUseCase,
+		listPayrollRunsUseCase:       listPayrollRunsUseCase,
+		createPayrollRunUseCase:      createPayrollRunUseCase,
+		getPayrollRunUseCase:         getPayrollRunUseCase,
+		recalculatePayrollRunUseCase: recalculatePayrollRunUseCase,
+		finalisePayrollRunUseCase:    finalisePayrollRunUseCase,
+		deletePayrollRunUseCase:      deletePayrollRunUseCase,
+		exportPayrollRunUseCase:      exportPayrollRunUseCase,
+	}
+}
+
+// ListRateCards godoc
+// @Summary List teacher rate cards
+// @Description Pay rates of all teachers or of one teacher, newest first (Admin)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param teacher_id query string false "Only the cards of this teacher"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=RateCardListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/rate-cards [get]
+func (c *ControllerV1) ListRateCards(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listRateCardsUseCase.Execute(ctx, payroll.ListRateCardsInput{
+		TeacherID: ctx.Query("teacher_id"),
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list rate cards: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list rate cards", err)
+		return
+	}
+
+	cards := make([]RateCardResponse, 0, len(output.RateCards))
+	for _, card := range output.RateCards {
+		cards = append(cards, mapRateCard(card))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Rate cards retrieved successfully", RateCardListResponse{
+		RateCards: cards,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// CreateRateCard godoc
+// @Summary Create a teacher rate card
+// @Description Add a pay rate per hour, per session or per class and month; a course_id makes it an override for that course (Admin)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateRateCardRequest true "Rate card"
+// @Success 201 {object} rest.BaseResponse{data=RateCardResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/rate-cards [post]
+func (c *ControllerV1) CreateRateCard(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateRateCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createRateCardUseCase.Execute(ctx, payroll.CreateRateCardInput{
+		TeacherID:     req.TeacherID,
+		CourseID:      req.CourseID,
+		RateType:      req.RateType,
+		Amount:        req.Amount,
+		EffectiveFrom: req.EffectiveFrom,
+		EffectiveTo:   req.EffectiveTo,
+		Notes:         req.Notes,
+		CreatedByID:   ctx.GetString("user_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create rate card: %v", err)
+		respondPayrollError(ctx, err, "Failed to create rate card")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Rate card created successfully", mapRateCard(output.RateCard))
+}
+
+// UpdateRateCard godoc
+// @Summary Update a teacher rate card
+// @Description Change a rate card; finalised payroll runs keep the rate they were calculated with (Admin)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rate card ID"
+// @Param request body UpdateRateCardRequest true "Fields to change"
+// @Success 200 {object} rest.BaseResponse{data=RateCardResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/rate-cards/{id} [put]
+func (c *ControllerV1) UpdateRateCard(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req UpdateRateCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.updateRateCardUseCase.Execute(ctx, payroll.UpdateRateCardInput{
+		ID:            ctx.Param("id"),
+		RateType:      req.RateType,
+		Amount:        req.Amount,
+		EffectiveFrom: req.EffectiveFrom,
+		EffectiveTo:   req.EffectiveTo,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to update rate card: %v", err)
+		respondPayrollError(ctx, err, "Failed to update rate card")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Rate card updated successfully", mapRateCard(output.RateCard))
+}
+
+// DeleteRateCard godoc
+// @Summary Delete a teacher rate card
+// @Description Remove a rate card; finalised payroll runs keep the rate they were calculated with (Admin)
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rate card ID"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/rate-cards/{id} [delete]
+func (c *ControllerV1) DeleteRateCard(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := c.deleteRateCardUseCase.Execute(ctx, ctx.Param("id")); err != nil {
+		ctxLogger.Errorf("Failed to delete rate card: %v", err)
+		respondPayrollError(ctx, err, "Failed to delete rate card")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Rate card deleted successfully", nil)
+}
+
+// ListRuns godoc
+// @Summary List payroll runs
+// @Description Monthly payroll runs, latest month first (Admin)
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=RunListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs [get]
+func (c *ControllerV1) ListRuns(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listPayrollRunsUseCase.Execute(ctx, payroll.ListPayrollRunsInput{Page: page, Limit: limit})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list payroll runs: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list payroll runs", err)
+		return
+	}
+
+	runs := make([]RunResponse, 0, len(output.Runs))
+	for _, run := range output.Runs {
+		runs = append(runs, mapRun(run))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payroll runs retrieved successfully", RunListResponse{
+		Runs: runs,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// CreateRun godoc
+// @Summary Create a payroll run
+// @Description Open the draft payroll of a month and calculate it from the lessons taught so far (Admin)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateRunRequest true "Payroll month"
+// @Success 201 {object} rest.BaseResponse{data=RunDetailResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs [post]
+func (c *ControllerV1) CreateRun(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateRunRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createPayrollRunUseCase.Execute(ctx, payroll.CreatePayrollRunInput{
+		Period:      req.Period,
+		Notes:       req.Notes,
+		CreatedByID: ctx.GetString("user_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create payroll run: %v", err)
+		respondPayrollError(ctx, err, "Failed to create payroll run")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Payroll run created successfully", mapRunDetail(output.Run))
+}
+
+// GetRun godoc
+// @Summary Get a payroll run
+// @Description A payroll run with the pay of each teacher; lines=true adds the lessons behind each amount (Admin)
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payroll run ID"
+// @Param lines query bool false "Include the lessons of each teacher"
+// @Success 200 {object} rest.BaseResponse{data=RunDetailResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs/{id} [get]
+func (c *ControllerV1) GetRun(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	withLines, _ := strconv.ParseBool(ctx.DefaultQuery("lines", "false"))
+	output, err := c.getPayrollRunUseCase.Execute(ctx, payroll.GetPayrollRunInput{
+		RunID:     ctx.Param("id"),
+		WithLines: withLines,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payroll run: %v", err)
+		respondPayrollError(ctx, err, "Failed to get payroll run")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payroll run retrieved successfully", mapRunDetail(output.Run))
+}
+
+// RecalculateRun godoc
+// @Summary Recalculate a payroll run
+// @Description Recompute a draft run after lessons or rate cards changed (Admin)
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payroll run ID"
+// @Success 200 {object} rest.BaseResponse{data=RunDetailResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs/{id}/recalculate [post]
+func (c *ControllerV1) RecalculateRun(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.recalculatePayrollRunUseCase.Execute(ctx, ctx.Param("id"))
+	if err != nil {
+		ctxLogger.Errorf("Failed to recalculate payroll run: %v", err)
+		respondPayrollError(ctx, err, "Failed to recalculate payroll run")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payroll run recalculated successfully", mapRunDetail(output.Run))
+}
+
+// FinaliseRun godoc
+// @Summary Finalise a payroll run
+// @Description Recalculate a draft one last time and lock its figures; the month must be over and every lesson rated (Admin)
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payroll run ID"
+// @Success 200 {object} rest.BaseResponse{data=RunDetailResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs/{id}/finalise [post]
+func (c *ControllerV1) FinaliseRun(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.finalisePayrollRunUseCase.Execute(ctx, payroll.FinalisePayrollRunInput{
+		RunID:     ctx.Param("id"),
+		ActorID:   ctx.GetString("user_id"),
+		ActorRole: ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to finalise payroll run: %v", err)
+		respondPayrollError(ctx, err, "Failed to finalise payroll run")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payroll run finalised successfully", mapRunDetail(output.Run))
+}
+
+// DeleteRun godoc
+// @Summary Delete a draft payroll run
+// @Description Discard a draft run so the month can be started again; finalised runs cannot be deleted (Admin)
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payroll run ID"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs/{id} [delete]
+func (c *ControllerV1) DeleteRun(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := c.deletePayrollRunUseCase.Execute(ctx, ctx.Param("id")); err != nil {
+		ctxLogger.Errorf("Failed to delete payroll run: %v", err)
+		respondPayrollError(ctx, err, "Failed to delete payroll run")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Payroll run deleted successfully", nil)
+}
+
+// ExportRun godoc
+// @Summary Export a payroll run
+// @Description Download the run as CSV, one row per teacher or, with detail=true, one row per lesson (Admin)
+// @Tags Payroll
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Payroll run ID"
+// @Param detail query bool false "One row per lesson instead of per teacher"
+// @Success 200 {file} file
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/payroll/runs/{id}/export [get]
+func (c *ControllerV1) ExportRun(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	detail, _ := strconv.ParseBool(ctx.DefaultQuery("detail", "false"))
+	output, err := c.exportPayrollRunUseCase.Execute(ctx, payroll.ExportPayrollRunInput{
+		RunID:  ctx.Param("id"),
+		Detail: detail,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to export payroll run: %v", err)
+		respondPayrollError(ctx, err, "Failed to export payroll run")
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": output.FileName}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, output.ContentType, output.Content)
+}
+
+func respondPayrollError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, payroll.ErrTeacherNotFound),
+		errors.Is(err, payroll.ErrCourseNotFound),
+		errors.Is(err, payroll.ErrRateCardNotFound),
+		errors.Is(err, payroll.ErrRunNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, payroll.ErrInvalidRateType),
+		errors.Is(err, payroll.ErrInvalidAmount),
+		errors.Is(err, payroll.ErrInvalidDate),
+		errors.Is(err, payroll.ErrInvalidDateRange),
+		errors.Is(err, payroll.ErrInvalidPeriod),
+		errors.Is(err, payroll.ErrFuturePeriod):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, payroll.ErrRateCardOverlap),
+		errors.Is(err, payroll.ErrRunExists),
+		errors.Is(err, payroll.ErrPeriodNotOver),
+		errors.Is(err, payroll.ErrRunFinalised),
+		errors.Is(err, payroll.ErrUnratedLessons):
+		rest.ResponseError(ctx, http.StatusConflict, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
+
+func mapRateCard(card *entities.TeacherRateCard) RateCardResponse {
+	resp := RateCardResponse{
+		ID:            card.ID,
+		TeacherID:     card.TeacherID,
+		CourseID:      card.CourseID,
+		RateType:      card.RateType,
+		Amount:        card.Amount,
+		EffectiveFrom: card.EffectiveFrom.Format(constants.DateOnly),
+		Notes:         card.Notes,
+		CreatedAt:     card.CreatedAt,
+	}
+	if card.EffectiveTo != nil {
+		to := card.EffectiveTo.Format(constants.DateOnly)
+		resp.EffectiveTo = &to
+	}
+	if card.Teacher != nil {
+		resp.TeacherName = card.Teacher.FullName
+	}
+	if card.Course != nil {
+		resp.CourseName = card.Course.Name
+	}
+	return resp
+}
+
+func mapRun(run *entities.PayrollRun) RunResponse {
+	return RunResponse{
+		ID:            run.ID,
+		Period:        run.Period,
+		Status:        run.Status,
+		TeacherCount:  run.TeacherCount,
+		LessonCount:   run.LessonCount,
+		UnratedCount:  run.UnratedCount,
+		TotalAmount:   run.TotalAmount,
+		CalculatedAt:  run.CalculatedAt,
+		FinalisedAt:   run.FinalisedAt,
+		FinalisedByID: run.FinalisedByID,
+		Notes:         run.Notes,
+		CreatedAt:     run.CreatedAt,
+	}
+}
+
+func mapRunDetail(run *entities.PayrollRun) RunDetailResponse {
+	items := make([]ItemResponse, 0, len(run.Items))
+	for _, item := range run.Items {
+		resp := ItemResponse{
+			TeacherID:            item.TeacherID,
+			Sessions:             item.Sessions,
+			Minutes:              item.Minutes,
+			SubstitutionSessions: item.SubstitutionSessions,
+			UnratedSessions:      item.UnratedSessions,
+			Amount:               item.Amount,
+		}
+		if item.Teacher != nil {
+			resp.TeacherCode = item.Teacher.Code
+			resp.TeacherName = item.Teacher.FullName
+		}
+		for _, line := range item.Lines {
+			resp.Lines = append(resp.Lines, LineResponse{
+				LessonID:       line.LessonID,
+				ClassID:        line.ClassID,
+				ClassCode:      line.ClassCode,
+				ClassName:      line.ClassName,
+				DateStart:      line.DateStart,
+				Minutes:        line.Minutes,
+				IsSubstitution: line.IsSubstitution,
+				RateCardID:     line.RateCardID,
+				RateType:       line.RateType,
+				Rate:           line.Rate,
+				Amount:         line.Amount,
+			})
+		}
+		items = append(items, resp)
+	}
+	return RunDetailResponse{RunResponse: mapRun(run), Items: items}
+}
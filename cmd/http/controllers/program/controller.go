@@ -3,6 +3,7 @@ package program
 import (
 	"doan/cmd/http/middleware"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,15 +25,15 @@ func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configMana
 
 	// Middleware
 	authMiddleware := middleware.AuthMiddleware(configManager)
-	adminRole := middleware.RoleMiddleware("ADMIN")
+	writePrograms := middleware.PermissionMiddleware(constants.PermissionProgramWrite)
 
-	// Admin-only routes (CRUD operations)
-	v1.POST("", authMiddleware, adminRole, controller.CreateProgram)
-	v1.PUT("/:id", authMiddleware, adminRole, controller.UpdateProgram)
-	v1.DELETE("/:id", authMiddleware, adminRole, controller.DeleteProgram)
+	// Protected routes (CRUD operations)
+	v1.POST("", authMiddleware, writePrograms, controller.CreateProgram)
+	v1.PUT("/:id", authMiddleware, writePrograms, controller.UpdateProgram)
+	v1.DELETE("/:id", authMiddleware, writePrograms, controller.DeleteProgram)
 
-	v1.POST("/:id/courses", authMiddleware, adminRole, controller.AddCourses)
-	v1.DELETE("/:id/courses", authMiddleware, adminRole, controller.RemoveCourses)
+	v1.POST("/:id/courses", authMiddleware, writePrograms, controller.AddCourses)
+	v1.DELETE("/:id/courses", authMiddleware, writePrograms, controller.RemoveCourses)
 
 	// Public/authenticated routes (read operations)
 	v1.GET("", controller.ListPrograms)
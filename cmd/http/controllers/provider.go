@@ -1,13 +1,26 @@
 package controllers
 
 import (
+	"doan/cmd/http/controllers/auditlog"
 	"doan/cmd/http/controllers/class"
+	"doan/cmd/http/controllers/compliance"
 	"doan/cmd/http/controllers/course"
+	"doan/cmd/http/controllers/dashboard"
+	"doan/cmd/http/controllers/enrollment"
+	"doan/cmd/http/controllers/guardian"
+	"doan/cmd/http/controllers/invoice"
+	"doan/cmd/http/controllers/material"
+	"doan/cmd/http/controllers/payment"
+	"doan/cmd/http/controllers/payroll"
 	"doan/cmd/http/controllers/program"
+	"doan/cmd/http/controllers/reminder"
+	"doan/cmd/http/controllers/report"
+	"doan/cmd/http/controllers/role"
 	"doan/cmd/http/controllers/room"
 	"doan/cmd/http/controllers/student"
 	"doan/cmd/http/controllers/teacher"
 	"doan/cmd/http/controllers/user"
+	"doan/cmd/http/controllers/usermanagement"
 
 	"github.com/google/wire"
 )
@@ -41,4 +54,56 @@ var ControllerProviders = wire.NewSet(
 	// Program controller
 	program.NewProgramControllerV1,
 	wire.Bind(new(program.Controller), new(*program.ControllerV1)),
+
+	// Material controller
+	material.NewMaterialControllerV1,
+	wire.Bind(new(material.Controller), new(*material.ControllerV1)),
+
+	// Compliance controller
+	compliance.NewComplianceControllerV1,
+	wire.Bind(new(compliance.Controller), new(*compliance.ControllerV1)),
+
+	// Enrollment controller
+	enrollment.NewEnrollmentControllerV1,
+	wire.Bind(new(enrollment.Controller), new(*enrollment.ControllerV1)),
+
+	// Invoice controller
+	invoice.NewInvoiceControllerV1,
+	wire.Bind(new(invoice.Controller), new(*invoice.ControllerV1)),
+
+	// Payment controller
+	payment.NewPaymentControllerV1,
+	wire.Bind(new(payment.Controller), new(*payment.ControllerV1)),
+
+	// Payroll controller
+	payroll.NewPayrollControllerV1,
+	wire.Bind(new(payroll.Controller), new(*payroll.ControllerV1)),
+
+	// Dashboard controller
+	dashboard.NewDashboardControllerV1,
+	wire.Bind(new(dashboard.Controller), new(*dashboard.ControllerV1)),
+
+	// Guardian controller
+	guardian.NewGuardianControllerV1,
+	wire.Bind(new(guardian.Controller), new(*guardian.ControllerV1)),
+
+	// Role controller
+	role.NewRoleControllerV1,
+	wire.Bind(new(role.Controller), new(*role.ControllerV1)),
+
+	// Reminder controller
+	reminder.NewReminderControllerV1,
+	wire.Bind(new(reminder.Controller), new(*reminder.ControllerV1)),
+
+	// Report controller
+	report.NewReportControllerV1,
+	wire.Bind(new(report.Controller), new(*report.ControllerV1)),
+
+	// User management controller
+	usermanagement.NewUserManagementControllerV1,
+	wire.Bind(new(usermanagement.Controller), new(*usermanagement.ControllerV1)),
+
+	// Audit log controller
+	auditlog.NewAuditLogControllerV1,
+	wire.Bind(new(auditlog.Controller), new(*auditlog.ControllerV1)),
 )
@@ -0,0 +1,34 @@
+package reminder
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for tuition reminder HTTP handlers
+type Controller interface {
+	RunReminders(ctx *gin.Context)
+	ListOptOuts(ctx *gin.Context)
+	CreateOptOut(ctx *gin.Context)
+	DeleteOptOut(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers tuition reminder routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/reminders")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	manageReminders := middleware.PermissionMiddleware(constants.PermissionReminderManage)
+
+	v1.Use(authMiddleware, manageReminders)
+
+	// Reminder routes
+	v1.POST("/run", controller.RunReminders)
+	v1.GET("/opt-outs", controller.ListOptOuts)
+	v1.POST("/opt-outs", controller.CreateOptOut)
+	v1.DELETE("/opt-outs/:guardian_phone", controller.DeleteOptOut)
+}
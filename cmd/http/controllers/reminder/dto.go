@@ -0,0 +1,42 @@
+package reminder
+
+import "time"
+
+// CreateOptOutRequest represents a guardian asking not to receive tuition reminders
+type CreateOptOutRequest struct {
+	GuardianPhone string `json:"guardian_phone" binding:"required"`
+	Reason        string `json:"reason"`
+}
+
+// OptOutResponse represents a guardian opt-out
+type OptOutResponse struct {
+	ID            string    `json:"id"`
+	GuardianPhone string    `json:"guardian_phone"`
+	Reason        string    `json:"reason"`
+	CreatedByID   *string   `json:"created_by_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// OptOutListResponse represents one page of opt-outs
+type OptOutListResponse struct {
+	OptOuts    []OptOutResponse `json:"opt_outs"`
+	Pagination PaginationMeta   `json:"pagination"`
+}
+
+// RunRemindersResponse counts what a reminder run did
+type RunRemindersResponse struct {
+	Checked     int `json:"checked"`
+	Sent        int `json:"sent"`
+	Failed      int `json:"failed"`
+	AlreadySent int `json:"already_sent"`
+	OptedOut    int `json:"opted_out"`
+	NoRecipient int `json:"no_recipient"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
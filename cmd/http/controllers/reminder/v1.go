@@ -0,0 +1,190 @@
+package reminder
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/entities"
+	"doan/internal/usecases/reminder"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	sendDueRemindersUseCase reminder.SendDueRemindersUseCase
+	listOptOutsUseCase      reminder.ListOptOutsUseCase
+	createOptOutUseCase     reminder.CreateOptOutUseCase
+	deleteOptOutUseCase     reminder.DeleteOptOutUseCase
+}
+
+func NewReminderControllerV1(
+	sendDueRemindersUseCase reminder.SendDueRemindersUseCase,
+	listOptOutsUseCase reminder.ListOptOutsUseCase,
+	createOptOutUseCase reminder.CreateOptOutUseCase,
+	deleteOptOutUseCase reminder.DeleteOptOutUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		sendDueRemindersUseCase: sendDueRemindersUseCase,
+		listOptOutsUseCase:      listOptOutsUseCase,
+		createOptOutUseCase:     createOptOutUseCase,
+		deleteOptOutUseCase:     deleteOptOutUseCase,
+	}
+}
+
+// RunReminders godoc
+// @Summary Run tuition reminders
+// @Description Send the reminders due now without waiting for the scheduled job; steps already sent are skipped (Admin)
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} rest.BaseResponse{data=RunRemindersResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/reminders/run [post]
+func (c *ControllerV1) RunReminders(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.sendDueRemindersUseCase.Execute(ctx, reminder.SendDueRemindersInput{})
+	if err != nil {
+		ctxLogger.Errorf("Failed to run tuition reminders: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to run tuition reminders", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Tuition reminders sent", RunRemindersResponse{
+		Checked:     output.Checked,
+		Sent:        output.Sent,
+		Failed:      output.Failed,
+		AlreadySent: output.AlreadySent,
+		OptedOut:    output.OptedOut,
+		NoRecipient: output.NoRecipient,
+	})
+}
+
+// ListOptOuts godoc
+// @Summary List reminder opt-outs
+// @Description Guardians who asked not to receive tuition reminders (Admin)
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} rest.BaseResponse{data=OptOutListResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/reminders/opt-outs [get]
+func (c *ControllerV1) ListOptOuts(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	output, err := c.listOptOutsUseCase.Execute(ctx, reminder.ListOptOutsInput{Page: page, Limit: limit})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list reminder opt-outs: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list reminder opt-outs", err)
+		return
+	}
+
+	optOuts := make([]OptOutResponse, 0, len(output.OptOuts))
+	for _, o := range output.OptOuts {
+		optOuts = append(optOuts, mapOptOut(o))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Reminder opt-outs retrieved successfully", OptOutListResponse{
+		OptOuts: optOuts,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// CreateOptOut godoc
+// @Summary Opt a guardian out of reminders
+// @Description Stop tuition reminders for every student sharing the guardian phone (Admin)
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateOptOutRequest true "Opt-out"
+// @Success 201 {object} rest.BaseResponse{data=OptOutResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/reminders/opt-outs [post]
+func (c *ControllerV1) CreateOptOut(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateOptOutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createOptOutUseCase.Execute(ctx, reminder.CreateOptOutInput{
+		GuardianPhone: req.GuardianPhone,
+		Reason:        req.Reason,
+		CreatedByID:   ctx.GetString("user_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create reminder opt-out: %v", err)
+		switch {
+		case errors.Is(err, reminder.ErrGuardianPhoneRequired):
+			rest.ResponseError(ctx, http.StatusBadRequest, "Guardian phone is required", err)
+		case errors.Is(err, reminder.ErrAlreadyOptedOut):
+			rest.ResponseError(ctx, http.StatusConflict, "Guardian already opted out", err)
+		default:
+			rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to create reminder opt-out", err)
+		}
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Guardian opted out of reminders", mapOptOut(output.OptOut))
+}
+
+// DeleteOptOut godoc
+// @Summary Opt a guardian back in to reminders
+// @Description Resume tuition reminders for a guardian (Admin)
+// @Tags Reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param guardian_phone path string true "Guardian phone"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/reminders/opt-outs/{guardian_phone} [delete]
+func (c *ControllerV1) DeleteOptOut(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	err := c.deleteOptOutUseCase.Execute(ctx, reminder.DeleteOptOutInput{GuardianPhone: ctx.Param("guardian_phone")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to delete reminder opt-out: %v", err)
+		if errors.Is(err, reminder.ErrOptOutNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, "Guardian has not opted out", err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to delete reminder opt-out", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Guardian opted back in to reminders", nil)
+}
+
+func mapOptOut(o *entities.ReminderOptOut) OptOutResponse {
+	return OptOutResponse{
+		ID:            o.ID,
+		GuardianPhone: o.GuardianPhone,
+		Reason:        o.Reason,
+		CreatedByID:   o.CreatedByID,
+		CreatedAt:     o.CreatedAt,
+	}
+}
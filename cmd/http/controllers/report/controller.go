@@ -0,0 +1,30 @@
+package report
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for management report HTTP handlers
+type Controller interface {
+	GetMaterialQualityStats(ctx *gin.Context)
+	ExportReviewHistory(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers report routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/reports")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readReports := middleware.PermissionMiddleware(constants.PermissionReportRead)
+
+	v1.Use(authMiddleware, readReports)
+
+	// Report routes
+	v1.GET("/materials/quality", controller.GetMaterialQualityStats)
+	v1.GET("/materials/review-history/export", controller.ExportReviewHistory)
+}
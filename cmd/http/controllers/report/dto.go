@@ -0,0 +1,20 @@
+package report
+
+// ReasonCountResponse represents a rejection reason and how many rejected materials cite it
+type ReasonCountResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Count   int64  `json:"count"`
+}
+
+// MaterialQualityStatsResponse represents the material quality report
+type MaterialQualityStatsResponse struct {
+	TotalMaterials   int64                 `json:"total_materials"`
+	ByAILabel        map[string]int64      `json:"by_ai_label"`
+	ByReviewStatus   map[string]int64      `json:"by_review_status"`
+	ApprovedCount    int64                 `json:"approved_count"`
+	RejectedCount    int64                 `json:"rejected_count"`
+	ApprovalRate     float64               `json:"approval_rate"`
+	MeanReviewHours  float64               `json:"mean_review_hours"`
+	RejectionReasons []ReasonCountResponse `json:"rejection_reasons"`
+}
@@ -0,0 +1,133 @@
+package report
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/usecases/report"
+	"doan/pkg/logger"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	getMaterialQualityStatsUseCase report.GetMaterialQualityStatsUseCase
+	exportReviewHistoryUseCase     report.ExportReviewHistoryUseCase
+}
+
+func NewReportControllerV1(
+	getMaterialQualityStatsUseCase report.GetMaterialQualityStatsUseCase,
+	exportReviewHistoryUseCase report.ExportReviewHistoryUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		getMaterialQualityStatsUseCase: getMaterialQualityStatsUseCase,
+		exportReviewHistoryUseCase:     exportReviewHistoryUseCase,
+	}
+}
+
+// GetMaterialQualityStats godoc
+// @Summary Material quality statistics
+// @Description Counts by AI label, approval rate, mean review time and top rejection reasons of materials uploaded in the period (Admin/Compliance)
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param teacher_id query string false "Only materials uploaded by this teacher (user ID)"
+// @Param course_id query string false "Only materials of this course"
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period, inclusive (YYYY-MM-DD)"
+// @Param top_reasons query int false "Number of rejection reasons to return" default(5)
+// @Success 200 {object} rest.BaseResponse{data=MaterialQualityStatsResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/reports/materials/quality [get]
+func (c *ControllerV1) GetMaterialQualityStats(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	topReasons, _ := strconv.Atoi(ctx.DefaultQuery("top_reasons", "5"))
+
+	output, err := c.getMaterialQualityStatsUseCase.Execute(ctx, report.GetMaterialQualityStatsInput{
+		TeacherID:  ctx.Query("teacher_id"),
+		CourseID:   ctx.Query("course_id"),
+		Period:     report.PeriodInput{From: ctx.Query("from"), To: ctx.Query("to")},
+		TopReasons: topReasons,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material quality stats: %v", err)
+		if errors.Is(err, report.ErrInvalidPeriod) {
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid period", err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to get material quality stats", err)
+		return
+	}
+
+	stats := output.Stats
+	reasons := make([]ReasonCountResponse, 0, len(stats.RejectionReasons))
+	for _, r := range stats.RejectionReasons {
+		reasons = append(reasons, ReasonCountResponse{Code: r.Code, Message: r.Message, Count: r.Count})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Material quality stats retrieved successfully", MaterialQualityStatsResponse{
+		TotalMaterials:   stats.TotalMaterials,
+		ByAILabel:        stats.ByAILabel,
+		ByReviewStatus:   stats.ByReviewStatus,
+		ApprovedCount:    stats.ApprovedCount,
+		RejectedCount:    stats.RejectedCount,
+		ApprovalRate:     output.ApprovalRate,
+		MeanReviewHours:  stats.MeanReviewHours,
+		RejectionReasons: reasons,
+	})
+}
+
+// ExportReviewHistory godoc
+// @Summary Export the compliance review history
+// @Description Download every approve/reject decision with its material, teacher and reviewer as CSV or XLSX (Admin/Compliance)
+// @Tags Reports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param teacher_id query string false "Only materials uploaded by this teacher (user ID)"
+// @Param course_id query string false "Only materials of this course"
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period, inclusive (YYYY-MM-DD)"
+// @Param format query string false "Export format (csv, xlsx)" default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/reports/materials/review-history/export [get]
+func (c *ControllerV1) ExportReviewHistory(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.exportReviewHistoryUseCase.Execute(ctx, report.ExportReviewHistoryInput{
+		TeacherID: ctx.Query("teacher_id"),
+		CourseID:  ctx.Query("course_id"),
+		Period:    report.PeriodInput{From: ctx.Query("from"), To: ctx.Query("to")},
+		Format:    ctx.Query("format"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to export review history: %v", err)
+		switch {
+		case errors.Is(err, report.ErrInvalidPeriod):
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid period", err)
+		case errors.Is(err, report.ErrInvalidFormat):
+			rest.ResponseError(ctx, http.StatusBadRequest, "Invalid export format", err)
+		default:
+			rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to export review history", err)
+		}
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": output.FileName}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, output.ContentType, output.Content)
+}
@@ -0,0 +1,35 @@
+package role
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for role HTTP handlers
+type Controller interface {
+	ListRoles(ctx *gin.Context)
+	CreateRole(ctx *gin.Context)
+	GetRole(ctx *gin.Context)
+	UpdateRole(ctx *gin.Context)
+	DeleteRole(ctx *gin.Context)
+}
+
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/roles")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readRoles := middleware.PermissionMiddleware(constants.PermissionRoleRead)
+	writeRoles := middleware.PermissionMiddleware(constants.PermissionRoleWrite)
+
+	v1.Use(authMiddleware)
+
+	v1.GET("", readRoles, controller.ListRoles)
+	v1.GET("/:id", readRoles, controller.GetRole)
+	v1.POST("", writeRoles, controller.CreateRole)
+	v1.PUT("/:id", writeRoles, controller.UpdateRole)
+	v1.DELETE("/:id", writeRoles, controller.DeleteRole)
+}
@@ -0,0 +1,59 @@
+package role
+
+import (
+	"doan/internal/entities"
+	"time"
+)
+
+// CreateRoleRequest represents a new custom role
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required" example:"ACCOUNTANT"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" example:"invoice:read,payment:read"`
+}
+
+// UpdateRoleRequest represents the role fields to change; the name is fixed
+type UpdateRoleRequest struct {
+	Description *string   `json:"description"`
+	Permissions *[]string `json:"permissions"`
+}
+
+// RoleResponse represents a role and its permissions
+type RoleResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	IsSystem    bool      `json:"is_system"`
+	UserCount   *int64    `json:"user_count,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PermissionResponse represents one permission roles can be built from
+type PermissionResponse struct {
+	Name        string `json:"name" example:"class:update"`
+	Description string `json:"description"`
+}
+
+// RoleListResponse represents every role and the permission catalog
+type RoleListResponse struct {
+	Roles       []RoleResponse       `json:"roles"`
+	Permissions []PermissionResponse `json:"permissions"`
+}
+
+func mapRole(role *entities.Role) RoleResponse {
+	permissions := []string(role.Permissions)
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		IsSystem:    role.IsSystem,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
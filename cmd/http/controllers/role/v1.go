@@ -0,0 +1,226 @@
+package role
+
+import (
+	"doan/cmd/http/rest"
+	"doan/internal/usecases/role"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listRolesUseCase  role.ListRolesUseCase
+	createRoleUseCase role.CreateRoleUseCase
+	getRoleUseCase    role.GetRoleUseCase
+	updateRoleUseCase role.UpdateRoleUseCase
+	deleteRoleUseCase role.DeleteRoleUseCase
+}
+
+func NewRoleControllerV1(
+	listRolesUseCase role.ListRolesUseCase,
+	createRoleUseCase role.CreateRoleUseCase,
+	getRoleUseCase role.GetRoleUseCase,
+	updateRoleUseCase role.UpdateRoleUseCase,
+	deleteRoleUseCase role.DeleteRoleUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listRolesUseCase:  listRolesUseCase,
+		createRoleUseCase: createRoleUseCase,
+		getRoleUseCase:    getRoleUseCase,
+		updateRoleUseCase: updateRoleUseCase,
+		deleteRoleUseCase: deleteRoleUseCase,
+	}
+}
+
+// ListRoles godoc
+// @Summary List roles
+// @Description Every role with its permissions, plus the catalog of permissions roles are built from (role:read)
+// @Tags Roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} rest.BaseResponse{data=RoleListResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/roles [get]
+func (c *ControllerV1) ListRoles(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.listRolesUseCase.Execute(ctx)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list roles: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list roles", err)
+		return
+	}
+
+	roles := make([]RoleResponse, 0, len(output.Roles))
+	for _, r := range output.Roles {
+		roles = append(roles, mapRole(r))
+	}
+	permissions := make([]PermissionResponse, 0, len(output.Permissions))
+	for _, p := range output.Permissions {
+		permissions = append(permissions, PermissionResponse{Name: p.Name, Description: p.Description})
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Roles retrieved successfully", RoleListResponse{
+		Roles:       roles,
+		Permissions: permissions,
+	})
+}
+
+// CreateRole godoc
+// @Summary Create a role
+// @Description Create a custom role from a bundle of permissions; "resource:*" grants every action on a resource (role:write)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateRoleRequest true "Role"
+// @Success 201 {object} rest.BaseResponse{data=RoleResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/roles [post]
+func (c *ControllerV1) CreateRole(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createRoleUseCase.Execute(ctx, role.CreateRoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+		ActorID:     ctx.GetString("user_id"),
+		ActorRole:   ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create role: %v", err)
+		respondRoleError(ctx, err, "Failed to create role")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "Role created successfully", mapRole(output.Role))
+}
+
+// GetRole godoc
+// @Summary Get a role
+// @Description A role with its permissions and the number of users holding it (role:read)
+// @Tags Roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {object} rest.BaseResponse{data=RoleResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/roles/{id} [get]
+func (c *ControllerV1) GetRole(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getRoleUseCase.Execute(ctx, role.GetRoleInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get role: %v", err)
+		respondRoleError(ctx, err, "Failed to get role")
+		return
+	}
+
+	response := mapRole(output.Role)
+	response.UserCount = &output.UserCount
+	rest.ResponseSuccess(ctx, http.StatusOK, "Role retrieved successfully", response)
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Change the description or permissions of a role; users get the new permissions on their next login or token refresh (role:write)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Param request body UpdateRoleRequest true "Role fields"
+// @Success 200 {object} rest.BaseResponse{data=RoleResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/roles/{id} [put]
+func (c *ControllerV1) UpdateRole(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req UpdateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.updateRoleUseCase.Execute(ctx, role.UpdateRoleInput{
+		ID:          ctx.Param("id"),
+		Description: req.Description,
+		Permissions: req.Permissions,
+		ActorID:     ctx.GetString("user_id"),
+		ActorRole:   ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to update role: %v", err)
+		respondRoleError(ctx, err, "Failed to update role")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Role updated successfully", mapRole(output.Role))
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Delete a custom role no user holds any more; built-in roles cannot be deleted (role:write)
+// @Tags Roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/roles/{id} [delete]
+func (c *ControllerV1) DeleteRole(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := c.deleteRoleUseCase.Execute(ctx, role.DeleteRoleInput{
+		ID:        ctx.Param("id"),
+		ActorID:   ctx.GetString("user_id"),
+		ActorRole: ctx.GetString("user_role"),
+	}); err != nil {
+		ctxLogger.Errorf("Failed to delete role: %v", err)
+		respondRoleError(ctx, err, "Failed to delete role")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Role deleted successfully", nil)
+}
+
+func respondRoleError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, role.ErrRoleNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, role.ErrInvalidRoleName),
+		errors.Is(err, role.ErrInvalidPermission),
+		errors.Is(err, role.ErrAdminRoleLocked):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, role.ErrRoleExists),
+		errors.Is(err, role.ErrSystemRole),
+		errors.Is(err, role.ErrRoleInUse):
+		rest.ResponseError(ctx, http.StatusConflict, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
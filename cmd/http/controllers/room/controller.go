@@ -3,6 +3,7 @@ package room
 import (
 	"doan/cmd/http/middleware"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,14 +22,14 @@ func RegisterRoutesV1(router *gin.RouterGroup, ctrl Controller, configManager co
 
 	// Middleware
 	authMiddleware := middleware.AuthMiddleware(configManager)
-	adminRole := middleware.RoleMiddleware("ADMIN")
+	writeRooms := middleware.PermissionMiddleware(constants.PermissionRoomWrite)
 
 	// Routes
 	v1.GET("", ctrl.ListRooms)
 	v1.GET("/:id", ctrl.GetRoom)
 
-	// Admin-only operations
-	v1.POST("", authMiddleware, adminRole, ctrl.CreateRoom)
-	v1.PUT("/:id", authMiddleware, adminRole, ctrl.UpdateRoom)
-	v1.DELETE("/:id", authMiddleware, adminRole, ctrl.DeleteRoom)
+	// Protected operations
+	v1.POST("", authMiddleware, writeRooms, ctrl.CreateRoom)
+	v1.PUT("/:id", authMiddleware, writeRooms, ctrl.UpdateRoom)
+	v1.DELETE("/:id", authMiddleware, writeRooms, ctrl.DeleteRoom)
 }
@@ -3,6 +3,7 @@ package student
 import (
 	"doan/cmd/http/middleware"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,16 +13,23 @@ type Controller interface {
 	UpdateStudent(c *gin.Context)
 	DeleteStudent(c *gin.Context)
 	ListStudents(c *gin.Context)
+	InviteStudent(c *gin.Context)
 }
 
 func RegisterRoutesV1(router *gin.RouterGroup, ctrl Controller, manager config.Manager) {
 	studentRoutes := router.Group("/v1/students")
+	readStudents := middleware.PermissionMiddleware(constants.PermissionStudentRead)
+	writeStudents := middleware.PermissionMiddleware(constants.PermissionStudentWrite)
+	inviteStudents := middleware.PermissionMiddleware(constants.PermissionStudentInvite)
+
+	// Guardians see only their own children, through the guardian portal
 	studentRoutes.Use(middleware.AuthMiddleware(manager))
 	{
-		studentRoutes.POST("", ctrl.CreateStudent)
-		studentRoutes.GET("", ctrl.ListStudents)
-		studentRoutes.GET("/:id", ctrl.GetStudent)
-		studentRoutes.PUT("/:id", ctrl.UpdateStudent)
-		studentRoutes.DELETE("/:id", ctrl.DeleteStudent)
+		studentRoutes.POST("", writeStudents, ctrl.CreateStudent)
+		studentRoutes.GET("", readStudents, ctrl.ListStudents)
+		studentRoutes.GET("/:id", readStudents, ctrl.GetStudent)
+		studentRoutes.PUT("/:id", writeStudents, ctrl.UpdateStudent)
+		studentRoutes.DELETE("/:id", writeStudents, ctrl.DeleteStudent)
+		studentRoutes.POST("/:id/invite", inviteStudents, ctrl.InviteStudent)
 	}
 }
@@ -10,11 +10,14 @@ type CreateStudentRequest struct {
 	Email         string     `json:"email"`
 	Phone         string     `json:"phone"`
 	GuardianPhone string     `json:"guardian_phone"`
+	GuardianEmail string     `json:"guardian_email"`
 	GradeLevel    string     `json:"grade_level"`
+	SchoolName    string     `json:"school_name"`
 	Status        string     `json:"status"`
 	DateOfBirth   *time.Time `json:"date_of_birth"`
 	Gender        string     `json:"gender"`
 	Address       string     `json:"address"`
+	InviteAccount bool       `json:"invite_account"` // create a STUDENT login and email an activation link to email
 }
 
 type UpdateStudentRequest struct {
@@ -23,7 +26,9 @@ type UpdateStudentRequest struct {
 	Email         string     `json:"email"`
 	Phone         string     `json:"phone"`
 	GuardianPhone string     `json:"guardian_phone"`
+	GuardianEmail string     `json:"guardian_email"`
 	GradeLevel    string     `json:"grade_level"`
+	SchoolName    string     `json:"school_name"`
 	Status        string     `json:"status"`
 	DateOfBirth   *time.Time `json:"date_of_birth"`
 	Gender        string     `json:"gender"`
@@ -1,8 +1,10 @@
 package student
 
 import (
+	"doan/cmd/http/middleware"
 	"doan/cmd/http/rest"
 	"doan/internal/usecases/student"
+	"doan/pkg/constants"
 	"net/http"
 	"strconv"
 
@@ -19,6 +21,7 @@ type ControllerV1 struct {
 	updateStudentUseCase student.UpdateStudentUseCase
 	deleteStudentUseCase student.DeleteStudentUseCase
 	listStudentsUseCase  student.ListStudentsUseCase
+	inviteStudentUseCase student.InviteStudentUseCase
 }
 
 func NewStudentControllerV1(
@@ -27,6 +30,7 @@ func NewStudentControllerV1(
 	updateStudentUseCase student.UpdateStudentUseCase,
 	deleteStudentUseCase student.DeleteStudentUseCase,
 	listStudentsUseCase student.ListStudentsUseCase,
+	inviteStudentUseCase student.InviteStudentUseCase,
 ) *ControllerV1 {
 	return &ControllerV1{
 		createStudentUseCase: createStudentUseCase,
@@ -34,6 +38,7 @@ func NewStudentControllerV1(
 		updateStudentUseCase: updateStudentUseCase,
 		deleteStudentUseCase: deleteStudentUseCase,
 		listStudentsUseCase:  listStudentsUseCase,
+		inviteStudentUseCase: inviteStudentUseCase,
 	}
 }
 
@@ -46,17 +51,27 @@ func (ctrl *ControllerV1) CreateStudent(c *gin.Context) {
 		return
 	}
 
+	// Creating a login account needs its own permission on top of student:write
+	if req.InviteAccount && !middleware.Principal(c).Can(constants.PermissionStudentInvite) {
+		rest.ResponseError(c, http.StatusForbidden, "You don't have permission to invite student accounts", nil)
+		return
+	}
+
 	output, err := ctrl.createStudentUseCase.Execute(c.Request.Context(), student.CreateStudentInput{
 		Code:          req.Code,
 		FullName:      req.FullName,
 		Email:         req.Email,
 		Phone:         req.Phone,
 		GuardianPhone: req.GuardianPhone,
+		GuardianEmail: req.GuardianEmail,
 		GradeLevel:    req.GradeLevel,
+		SchoolName:    req.SchoolName,
 		Status:        req.Status,
 		DateOfBirth:   req.DateOfBirth,
 		Gender:        req.Gender,
 		Address:       req.Address,
+		InviteAccount: req.InviteAccount,
+		InvitedByID:   c.GetString("user_id"),
 	})
 
 	if err != nil {
@@ -67,6 +82,19 @@ func (ctrl *ControllerV1) CreateStudent(c *gin.Context) {
 	rest.ResponseSuccess(c, http.StatusCreated, "Student created successfully", output.Student)
 }
 
+func (ctrl *ControllerV1) InviteStudent(c *gin.Context) {
+	output, err := ctrl.inviteStudentUseCase.Execute(c.Request.Context(), student.InviteStudentInput{
+		ID:          c.Param("id"),
+		InvitedByID: c.GetString("user_id"),
+	})
+	if err != nil {
+		rest.ResponseError(c, http.StatusBadRequest, "Failed to invite student", err)
+		return
+	}
+
+	rest.ResponseSuccess(c, http.StatusOK, "Invitation sent successfully", output.Student)
+}
+
 func (ctrl *ControllerV1) GetStudent(c *gin.Context) {
 	id := c.Param("id")
 	output, err := ctrl.getStudentUseCase.Execute(c.Request.Context(), id)
@@ -93,7 +121,9 @@ func (ctrl *ControllerV1) UpdateStudent(c *gin.Context) {
 		Email:         req.Email,
 		Phone:         req.Phone,
 		GuardianPhone: req.GuardianPhone,
+		GuardianEmail: req.GuardianEmail,
 		GradeLevel:    req.GradeLevel,
+		SchoolName:    req.SchoolName,
 		Status:        req.Status,
 		DateOfBirth:   req.DateOfBirth,
 		Gender:        req.Gender,
@@ -3,6 +3,7 @@ package teacher
 import (
 	"doan/cmd/http/middleware"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,6 +13,7 @@ type Controller interface {
 	GetTeacher(ctx *gin.Context)
 	UpdateTeacher(ctx *gin.Context)
 	DeleteTeacher(ctx *gin.Context)
+	InviteTeacher(ctx *gin.Context)
 	ListTeachers(ctx *gin.Context)
 	GetTeacherTimetable(ctx *gin.Context)
 	GetTeachingHoursStats(ctx *gin.Context)
@@ -23,12 +25,14 @@ func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configMana
 
 	// Middleware
 	authMiddleware := middleware.AuthMiddleware(configManager)
-	adminRole := middleware.RoleMiddleware("ADMIN")
+	writeTeachers := middleware.PermissionMiddleware(constants.PermissionTeacherWrite)
+	inviteTeachers := middleware.PermissionMiddleware(constants.PermissionTeacherInvite)
 
-	// Admin-only routes (CRUD operations)
-	v1.POST("", authMiddleware, adminRole, controller.CreateTeacher)
-	v1.PUT("/:id", authMiddleware, adminRole, controller.UpdateTeacher)
-	v1.DELETE("/:id", authMiddleware, adminRole, controller.DeleteTeacher)
+	// Protected routes (CRUD operations)
+	v1.POST("", authMiddleware, writeTeachers, controller.CreateTeacher)
+	v1.PUT("/:id", authMiddleware, writeTeachers, controller.UpdateTeacher)
+	v1.DELETE("/:id", authMiddleware, writeTeachers, controller.DeleteTeacher)
+	v1.POST("/:id/invite", authMiddleware, inviteTeachers, controller.InviteTeacher)
 
 	// Public/authenticated routes (read operations)
 	v1.GET("", controller.ListTeachers)
@@ -13,6 +13,7 @@ type CreateTeacherRequest struct {
 	EmploymentType  string `json:"employment_type"` // PART_TIME, FULL_TIME
 	Status          string `json:"status"`          // ACTIVE, INACTIVE
 	Notes           string `json:"notes"`
+	InviteAccount   bool   `json:"invite_account"` // create a TEACHER login and email an activation link to email
 }
 
 // UpdateTeacherRequest represents the request body for updating a teacher
@@ -31,6 +32,7 @@ type UpdateTeacherRequest struct {
 // TeacherResponse represents a teacher in the response
 type TeacherResponse struct {
 	ID              string    `json:"id"`
+	UserID          *string   `json:"user_id"`
 	Code            string    `json:"code"`
 	FullName        string    `json:"full_name"`
 	Email           string    `json:"email"`
@@ -1,9 +1,11 @@
 package teacher
 
 import (
+	"doan/cmd/http/middleware"
 	"doan/cmd/http/rest"
 	"doan/internal/entities"
 	"doan/internal/usecases/teacher"
+	"doan/pkg/constants"
 	"doan/pkg/logger"
 	"net/http"
 	"strconv"
@@ -22,6 +24,7 @@ type ControllerV1 struct {
 	listTeachersUseCase          teacher.ListTeachersUseCase
 	getTeacherTimetableUseCase   teacher.GetTeacherTimetableUseCase
 	getTeachingHoursStatsUseCase teacher.GetTeachingHoursStatsUseCase
+	inviteTeacherUseCase         teacher.InviteTeacherUseCase
 }
 
 func NewTeacherControllerV1(
@@ -32,6 +35,7 @@ func NewTeacherControllerV1(
 	listTeachersUseCase teacher.ListTeachersUseCase,
 	getTeacherTimetableUseCase teacher.GetTeacherTimetableUseCase,
 	getTeachingHoursStatsUseCase teacher.GetTeachingHoursStatsUseCase,
+	inviteTeacherUseCase teacher.InviteTeacherUseCase,
 ) *ControllerV1 {
 	return &ControllerV1{
 		createTeacherUseCase:         createTeacherUseCase,
@@ -41,6 +45,7 @@ func NewTeacherControllerV1(
 		listTeachersUseCase:          listTeachersUseCase,
 		getTeacherTimetableUseCase:   getTeacherTimetableUseCase,
 		getTeachingHoursStatsUseCase: getTeachingHoursStatsUseCase,
+		inviteTeacherUseCase:         inviteTeacherUseCase,
 	}
 }
 
@@ -68,6 +73,12 @@ func (c *ControllerV1) CreateTeacher(ctx *gin.Context) {
 		return
 	}
 
+	// Creating a login account needs its own permission on top of teacher:write
+	if req.InviteAccount && !middleware.Principal(ctx).Can(constants.PermissionTeacherInvite) {
+		rest.ResponseError(ctx, http.StatusForbidden, "You don't have permission to invite teacher accounts", nil)
+		return
+	}
+
 	output, err := c.createTeacherUseCase.Execute(ctx, teacher.CreateTeacherInput{
 		Code:            req.Code,
 		FullName:        req.FullName,
@@ -78,6 +89,8 @@ func (c *ControllerV1) CreateTeacher(ctx *gin.Context) {
 		EmploymentType:  req.EmploymentType,
 		Status:          req.Status,
 		Notes:           req.Notes,
+		InviteAccount:   req.InviteAccount,
+		InvitedByID:     ctx.GetString("user_id"),
 	})
 
 	if err != nil {
@@ -180,6 +193,36 @@ func (c *ControllerV1) UpdateTeacher(ctx *gin.Context) {
 	rest.ResponseSuccess(ctx, http.StatusOK, "Teacher updated successfully", response)
 }
 
+// InviteTeacher godoc
+// @Summary Invite teacher to log in
+// @Description Create an inactive TEACHER login for the teacher's email and send an activation link, or resend the link while the account is inactive (Admin only)
+// @Tags Teachers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Teacher ID"
+// @Success 200 {object} rest.BaseResponse{data=TeacherResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/teachers/{id}/invite [post]
+func (c *ControllerV1) InviteTeacher(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.inviteTeacherUseCase.Execute(ctx, teacher.InviteTeacherInput{
+		ID:          ctx.Param("id"),
+		InvitedByID: ctx.GetString("user_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to invite teacher: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Failed to invite teacher", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Invitation sent successfully", mapTeacherToResponse(output.Teacher))
+}
+
 // DeleteTeacher godoc
 // @Summary Delete teacher
 // @Description Soft delete a teacher (Admin only)
@@ -441,6 +484,7 @@ func (c *ControllerV1) GetTeachingHoursStats(ctx *gin.Context) {
 func mapTeacherToResponse(t *entities.Teacher) TeacherResponse {
 	return TeacherResponse{
 		ID:              t.ID,
+		UserID:          t.UserID,
 		Code:            t.Code,
 		FullName:        t.FullName,
 		Email:           t.Email,
@@ -1,6 +1,10 @@
 package user
 
 import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,25 +17,65 @@ type Controller interface {
 	ResetPassword(ctx *gin.Context)
 	ChangePassword(ctx *gin.Context)
 	VerifyOTP(ctx *gin.Context)
+	ActivateAccount(ctx *gin.Context)
 	GetMe(ctx *gin.Context)
+	ListSessions(ctx *gin.Context)
+	RevokeSessions(ctx *gin.Context)
+	UnlockAccount(ctx *gin.Context)
+	GetPasswordPublicKey(ctx *gin.Context)
+	GetJWKS(ctx *gin.Context)
+	LoginMFA(ctx *gin.Context)
+	LoginMFASetup(ctx *gin.Context)
+	SetupMFA(ctx *gin.Context)
+	ConfirmMFA(ctx *gin.Context)
+	RegenerateRecoveryCodes(ctx *gin.Context)
+	DisableMFA(ctx *gin.Context)
+	ResetMFA(ctx *gin.Context)
+	ListOIDCProviders(ctx *gin.Context)
+	BeginOIDCLogin(ctx *gin.Context)
+	OIDCLogin(ctx *gin.Context)
 }
 
 // RegisterRoutesV1 register routes for version 1
-func RegisterRoutesV1(router *gin.RouterGroup, controller Controller) {
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
 	v1 := router.Group("/v1/auth")
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	// Anonymous endpoints share the "auth" rate limit policy
+	publicLimit := middleware.RateLimitMiddleware("auth")
 	{
-		v1.POST("/login", controller.Login)
+		v1.POST("/login", publicLimit, controller.Login)
+		v1.POST("/login/mfa", publicLimit, controller.LoginMFA)
+		v1.POST("/login/mfa/setup", publicLimit, controller.LoginMFASetup)
+		v1.GET("/oidc/providers", controller.ListOIDCProviders)
+		v1.GET("/oidc/:provider/authorize", publicLimit, controller.BeginOIDCLogin)
+		v1.POST("/oidc/:provider/callback", publicLimit, controller.OIDCLogin)
 		v1.POST("/logout", controller.Logout)
-		v1.POST("/refresh", controller.RefreshToken)
-		v1.POST("/register", controller.Register)
-		v1.POST("/forgot-password", controller.ForgotPassword)
-		v1.POST("/reset-password", controller.ResetPassword)
-		v1.POST("/change-password", controller.ChangePassword)
-		v1.POST("/verify-otp", controller.VerifyOTP)
-		v1.GET("/me", controller.GetMe)
+		v1.POST("/refresh", publicLimit, controller.RefreshToken)
+		v1.POST("/register", publicLimit, controller.Register)
+		v1.POST("/forgot-password", publicLimit, controller.ForgotPassword)
+		v1.POST("/reset-password", publicLimit, controller.ResetPassword)
+		v1.POST("/change-password", authMiddleware, controller.ChangePassword)
+		v1.POST("/verify-otp", publicLimit, controller.VerifyOTP)
+		v1.POST("/activate", publicLimit, controller.ActivateAccount)
+		v1.GET("/public-key", controller.GetPasswordPublicKey)
+		v1.GET("/me", authMiddleware, controller.GetMe)
+		v1.GET("/sessions", authMiddleware, controller.ListSessions)
+		v1.DELETE("/sessions", authMiddleware, controller.RevokeSessions)
+		v1.DELETE("/sessions/:id", authMiddleware, controller.RevokeSessions)
+		v1.POST("/mfa/setup", authMiddleware, controller.SetupMFA)
+		v1.POST("/mfa/confirm", authMiddleware, controller.ConfirmMFA)
+		v1.POST("/mfa/recovery-codes", authMiddleware, controller.RegenerateRecoveryCodes)
+		v1.POST("/mfa/disable", authMiddleware, controller.DisableMFA)
+		v1.POST("/users/:id/unlock", authMiddleware, middleware.PermissionMiddleware(constants.PermissionUserUnlock), controller.UnlockAccount)
+		v1.POST("/users/:id/mfa/reset", authMiddleware, middleware.PermissionMiddleware(constants.PermissionUserMFAReset), controller.ResetMFA)
 	}
 }
 
+// RegisterWellKnownRoutes registers the discovery routes, which live at the root rather than under /api
+func RegisterWellKnownRoutes(router gin.IRouter, controller Controller) {
+	router.GET("/.well-known/jwks.json", controller.GetJWKS)
+}
+
 // RegisterRoutesV2 register routes for version 2
 func RegisterRoutesV2(router *gin.RouterGroup, controller Controller) {
 	v2 := router.Group("/v2/auth")
@@ -1,16 +1,71 @@
 package user
 
-// LoginRequest represents the login request body
+import "time"
+
+// LoginRequest represents the login request body. password_enc is the envelope described at
+// GET /v1/auth/public-key.
 type LoginRequest struct {
-	Username string `json:"username" binding:"required" example:"user@example.com"`
-	Password string `json:"password" binding:"required" example:"password123"`
+	Username    string `json:"username" binding:"required" example:"user@example.com"`
+	PasswordEnc string `json:"password_enc" binding:"required"`
 }
 
-// LoginResponse represents the login response
+// LoginResponse represents the login response. With mfa_required there are no tokens yet: send
+// mfa_token and a code to /v1/auth/login/mfa, after /v1/auth/login/mfa/setup when enrolment is required.
 type LoginResponse struct {
-	AccessToken  string       `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	RefreshToken string       `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	User         UserResponse `json:"user"`
+	AccessToken          string       `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken         string       `json:"refresh_token,omitempty" example:"mZ3k9v0Q2YtJ7sLx..."`
+	MFARequired          bool         `json:"mfa_required,omitempty"`
+	MFAEnrolmentRequired bool         `json:"mfa_enrolment_required,omitempty"`
+	MFAToken             string       `json:"mfa_token,omitempty"`
+	RecoveryCodes        []string     `json:"recovery_codes,omitempty"` // shown once, after a mandatory enrolment
+	User                 UserResponse `json:"user"`
+}
+
+// LoginMFARequest represents the second step of a login; recovery_code replaces code when the
+// authenticator is lost
+type LoginMFARequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recovery_code" example:"abcde-fghjk"`
+}
+
+// OIDCProvidersResponse lists the providers users can sign in with
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers" example:"google"`
+}
+
+// OIDCAuthorizationResponse represents the start of a sign-in with a provider; send the browser to
+// authorization_url. state comes back with the code and is only valid once.
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://accounts.google.com/o/oauth2/v2/auth?..."`
+	State            string `json:"state"`
+}
+
+// OIDCLoginRequest represents the code and state the provider redirected back with
+type OIDCLoginRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// LoginMFASetupRequest represents the start of a mandatory enrolment during a login
+type LoginMFASetupRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// MFASetupResponse represents a pending authenticator; render otpauth_uri as a QR code
+type MFASetupResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"otpauth_uri" example:"otpauth://totp/DoAn:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=DoAn"`
+}
+
+// MFACodeRequest represents a code from the authenticator app
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// RecoveryCodesResponse represents one-time recovery codes; they are shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 // UserResponse represents the user information in response
@@ -38,9 +93,10 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
-// RefreshTokenResponse represents the refresh token response
+// RefreshTokenResponse represents the refresh token response; the refresh token sent is spent
 type RefreshTokenResponse struct {
-	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"mZ3k9v0Q2YtJ7sLx..."`
 }
 
 // Account APIs DTOs
@@ -63,6 +119,11 @@ type MessageResponse struct {
 	Message string `json:"message" example:"Operation successful"`
 }
 
+// PasswordPolicyErrorResponse lists the rules a rejected password breaks, as error codes
+type PasswordPolicyErrorResponse struct {
+	Violations []string `json:"violations" example:"PASSWORD_TOO_SHORT,PASSWORD_MISSING_DIGIT"`
+}
+
 type ResetPasswordRequest struct {
 	Token          string `json:"token" binding:"required"`
 	NewPasswordEnc string `json:"new_password_enc" binding:"required"`
@@ -73,7 +134,70 @@ type ChangePasswordRequest struct {
 	NewPasswordEnc string `json:"new_password_enc" binding:"required"`
 }
 
+// ActivateAccountRequest represents the activation of an invited account
+type ActivateAccountRequest struct {
+	Token       string `json:"token" binding:"required"`
+	PasswordEnc string `json:"password_enc" binding:"required"`
+}
+
+// MeResponse represents the signed-in user with their linked profile
+type MeResponse struct {
+	UserResponse
+	Profile *ProfileResponse `json:"profile"`
+}
+
+// ProfileResponse represents the teacher, student or guardian record linked to the account
+type ProfileResponse struct {
+	Type     string `json:"type" example:"TEACHER"`
+	ID       string `json:"id"`
+	Code     string `json:"code,omitempty"`
+	FullName string `json:"full_name"`
+}
+
 type VerifyOTPRequest struct {
 	UserID string `json:"user_id" binding:"required"`
 	OTP    string `json:"otp" binding:"required"`
 }
+
+// SessionResponse represents one signed-in device
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// RevokeSessionsResponse represents how many sessions were signed out
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked" example:"2"`
+}
+
+// PasswordPublicKeyResponse represents the key password fields are encrypted with
+type PasswordPublicKeyResponse struct {
+	KeyID       string      `json:"kid" example:"2026-10"`
+	Algorithm   string      `json:"alg" example:"RSA-OAEP-256+A256GCM"`
+	PublicKey   string      `json:"public_key"` // PEM SubjectPublicKeyInfo
+	JWK         JWKResponse `json:"jwk"`
+	AcceptPlain bool        `json:"accept_plain"`
+}
+
+// JWKResponse represents a public key in JWK form, for WebCrypto importKey("jwk"): n and e for RSA keys,
+// crv and x for Ed25519 keys
+type JWKResponse struct {
+	Kty string `json:"kty" example:"RSA"`
+	Kid string `json:"kid" example:"2026-10"`
+	Use string `json:"use" example:"enc"`
+	Alg string `json:"alg" example:"RSA-OAEP-256"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty" example:"AQAB"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSResponse represents the JSON Web Key Set access tokens are verified with
+type JWKSResponse struct {
+	Keys []JWKResponse `json:"keys"`
+}
@@ -2,8 +2,14 @@ package user
 
 import (
 	"doan/cmd/http/rest"
+	"doan/internal/services/oidc"
+	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
 	"doan/internal/usecases/user"
 	"doan/pkg/logger"
+	xerror "doan/pkg/x-error"
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -22,6 +28,23 @@ type ControllerV1 struct {
 	changePasswordUseCase user.ChangePasswordUseCase
 	verifyOTPUseCase      user.VerifyOTPUseCase
 	getUserByIdUseCase    user.GetUserByIdUseCase
+	activateUseCase       user.ActivateAccountUseCase
+	getMeUseCase          user.GetMeUseCase
+	listSessionsUseCase   user.ListSessionsUseCase
+	revokeSessionsUseCase user.RevokeSessionsUseCase
+	unlockAccountUseCase  user.UnlockAccountUseCase
+	publicKeyUseCase      user.GetPasswordPublicKeyUseCase
+	jwksUseCase           user.GetJWKSUseCase
+	loginMFAUseCase       user.LoginMFAUseCase
+	loginMFASetupUseCase  user.LoginMFASetupUseCase
+	setupMFAUseCase       user.SetupMFAUseCase
+	confirmMFAUseCase     user.ConfirmMFAUseCase
+	recoveryCodesUseCase  user.RegenerateRecoveryCodesUseCase
+	disableMFAUseCase     user.DisableMFAUseCase
+	resetMFAUseCase       user.ResetMFAUseCase
+	oidcProvidersUseCase  user.ListOIDCProvidersUseCase
+	beginOIDCUseCase      user.BeginOIDCLoginUseCase
+	oidcLoginUseCase      user.OIDCLoginUseCase
 }
 
 func NewUserControllerV1(
@@ -34,6 +57,23 @@ func NewUserControllerV1(
 	changePasswordUseCase user.ChangePasswordUseCase,
 	verifyOTPUseCase user.VerifyOTPUseCase,
 	getUserByIdUseCase user.GetUserByIdUseCase,
+	activateUseCase user.ActivateAccountUseCase,
+	getMeUseCase user.GetMeUseCase,
+	listSessionsUseCase user.ListSessionsUseCase,
+	revokeSessionsUseCase user.RevokeSessionsUseCase,
+	unlockAccountUseCase user.UnlockAccountUseCase,
+	publicKeyUseCase user.GetPasswordPublicKeyUseCase,
+	jwksUseCase user.GetJWKSUseCase,
+	loginMFAUseCase user.LoginMFAUseCase,
+	loginMFASetupUseCase user.LoginMFASetupUseCase,
+	setupMFAUseCase user.SetupMFAUseCase,
+	confirmMFAUseCase user.ConfirmMFAUseCase,
+	recoveryCodesUseCase user.RegenerateRecoveryCodesUseCase,
+	disableMFAUseCase user.DisableMFAUseCase,
+	resetMFAUseCase user.ResetMFAUseCase,
+	oidcProvidersUseCase user.ListOIDCProvidersUseCase,
+	beginOIDCUseCase user.BeginOIDCLoginUseCase,
+	oidcLoginUseCase user.OIDCLoginUseCase,
 ) *ControllerV1 {
 	return &ControllerV1{
 		loginUseCase:          loginUseCase,
@@ -45,12 +85,115 @@ func NewUserControllerV1(
 		changePasswordUseCase: changePasswordUseCase,
 		verifyOTPUseCase:      verifyOTPUseCase,
 		getUserByIdUseCase:    getUserByIdUseCase,
+		activateUseCase:       activateUseCase,
+		getMeUseCase:          getMeUseCase,
+		listSessionsUseCase:   listSessionsUseCase,
+		revokeSessionsUseCase: revokeSessionsUseCase,
+		unlockAccountUseCase:  unlockAccountUseCase,
+		publicKeyUseCase:      publicKeyUseCase,
+		jwksUseCase:           jwksUseCase,
+		loginMFAUseCase:       loginMFAUseCase,
+		loginMFASetupUseCase:  loginMFASetupUseCase,
+		setupMFAUseCase:       setupMFAUseCase,
+		confirmMFAUseCase:     confirmMFAUseCase,
+		recoveryCodesUseCase:  recoveryCodesUseCase,
+		disableMFAUseCase:     disableMFAUseCase,
+		resetMFAUseCase:       resetMFAUseCase,
+		oidcProvidersUseCase:  oidcProvidersUseCase,
+		beginOIDCUseCase:      beginOIDCUseCase,
+		oidcLoginUseCase:      oidcLoginUseCase,
 	}
 }
 
+// respondThrottled answers 429 with Retry-After when err comes from the failed-attempt throttle
+func respondThrottled(ctx *gin.Context, err error) bool {
+	var throttled *userservice.ThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+	ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	rest.ResponseError(ctx, http.StatusTooManyRequests, throttled.Error(), err)
+	return true
+}
+
+// respondPasswordPolicy answers 400 with the broken rules when err is a password policy rejection
+func respondPasswordPolicy(ctx *gin.Context, err error) bool {
+	var policyErr *userservice.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+	rest.ResponseErrorWithData(ctx, http.StatusBadRequest, "Password does not meet the password policy",
+		xerror.NewError(policyErr.ErrCode()), PasswordPolicyErrorResponse{Violations: policyErr.Violations})
+	return true
+}
+
+// respondPasswordPayload answers 400 when the password envelope could not be opened, so the client can
+// fetch the current public key and retry instead of reporting wrong credentials
+func respondPasswordPayload(ctx *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, security.ErrPlainPasswordRejected), errors.Is(err, security.ErrInvalidPasswordEnvelope),
+		errors.Is(err, security.ErrUnknownPasswordKey), errors.Is(err, security.ErrPasswordEnvelopeExpired),
+		errors.Is(err, security.ErrPasswordEnvelopeReplayed):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+		return true
+	}
+	return false
+}
+
+// respondMFAError answers the errors of the two-factor endpoints, reporting false for any other error
+func respondMFAError(ctx *gin.Context, err error) bool {
+	if respondThrottled(ctx, err) {
+		return true
+	}
+	status := 0
+	switch {
+	case errors.Is(err, userservice.ErrInvalidMFAToken), errors.Is(err, userservice.ErrInvalidMFACode):
+		status = http.StatusUnauthorized
+	case errors.Is(err, userservice.ErrMFARequired):
+		status = http.StatusForbidden
+	case errors.Is(err, userservice.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, userservice.ErrMFAAlreadyEnabled):
+		status = http.StatusConflict
+	case errors.Is(err, userservice.ErrMFANotEnabled), errors.Is(err, userservice.ErrMFANotEnrolling):
+		status = http.StatusBadRequest
+	default:
+		return false
+	}
+	rest.ResponseError(ctx, status, err.Error(), err)
+	return true
+}
+
+// respondOIDCError writes the status of a failed sign-in at a provider; false when err is not one of them
+func respondOIDCError(ctx *gin.Context, err error) bool {
+	status := 0
+	switch {
+	case errors.Is(err, oidc.ErrUnknownProvider):
+		status = http.StatusNotFound
+	case errors.Is(err, oidc.ErrInvalidState):
+		status = http.StatusBadRequest
+	case errors.Is(err, oidc.ErrExchangeFailed), errors.Is(err, oidc.ErrInvalidIDToken):
+		status = http.StatusUnauthorized
+	case errors.Is(err, oidc.ErrEmailNotVerified), errors.Is(err, oidc.ErrDomainNotAllowed),
+		errors.Is(err, oidc.ErrProvisionDisabled), errors.Is(err, userservice.ErrUserInactive):
+		status = http.StatusForbidden
+	default:
+		return false
+	}
+	// The provider's error details stay in the log
+	message := err.Error()
+	if status == http.StatusUnauthorized {
+		message = "Sign-in with the provider failed"
+	}
+	rest.ResponseError(ctx, status, message, err)
+	return true
+}
+
 // Login godoc
 // @Summary User login
-// @Description Authenticate user and return JWT tokens
+// @Description Authenticate user and return JWT tokens. Accounts with two-factor authentication, or whose role
+// @Description requires it, get mfa_required and an mfa_token for /v1/auth/login/mfa instead.
+// @Description password_enc is encrypted with the key from /v1/auth/public-key.
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -58,6 +201,7 @@ func NewUserControllerV1(
 // @Success 200 {object} rest.BaseResponse{data=LoginResponse}
 // @Failure 400 {object} rest.BaseResponse
 // @Failure 401 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/login [post]
 func (c *ControllerV1) Login(ctx *gin.Context) {
@@ -72,20 +216,36 @@ func (c *ControllerV1) Login(ctx *gin.Context) {
 
 	// Execute login use case
 	output, err := c.loginUseCase.Execute(ctx, user.LoginInput{
-		Username: req.Username,
-		Password: req.Password,
+		Username:    req.Username,
+		PasswordEnc: req.PasswordEnc,
+		UserAgent:   ctx.Request.UserAgent(),
+		IPAddress:   ctx.ClientIP(),
 	})
 
 	if err != nil {
 		ctxLogger.Errorf("Failed to login: %v", err)
+		if respondThrottled(ctx, err) || respondPasswordPayload(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusUnauthorized, "Invalid credentials", err)
 		return
 	}
 
-	// Map to response
-	response := LoginResponse{
-		AccessToken:  output.AccessToken,
-		RefreshToken: output.RefreshToken,
+	if output.MFARequired {
+		rest.ResponseSuccess(ctx, http.StatusOK, "Two-factor authentication required", toLoginResponse(output))
+		return
+	}
+	rest.ResponseSuccess(ctx, http.StatusOK, "Login successful", toLoginResponse(output))
+}
+
+func toLoginResponse(output *user.LoginOutput) LoginResponse {
+	return LoginResponse{
+		AccessToken:          output.AccessToken,
+		RefreshToken:         output.RefreshToken,
+		MFARequired:          output.MFARequired,
+		MFAEnrolmentRequired: output.MFAEnrolmentRequired,
+		MFAToken:             output.MFAToken,
+		RecoveryCodes:        output.RecoveryCodes,
 		User: UserResponse{
 			ID:       output.User.ID,
 			Code:     output.User.Code,
@@ -95,8 +255,6 @@ func (c *ControllerV1) Login(ctx *gin.Context) {
 			IsActive: output.User.IsActive,
 		},
 	}
-
-	rest.ResponseSuccess(ctx, http.StatusOK, "Login successful", response)
 }
 
 // Logout godoc
@@ -143,7 +301,7 @@ func (c *ControllerV1) Logout(ctx *gin.Context) {
 
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Get a new access token using refresh token
+// @Description Exchange a refresh token for a new access token and a new refresh token; each refresh token works once
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -166,6 +324,8 @@ func (c *ControllerV1) RefreshToken(ctx *gin.Context) {
 	// Execute refresh token use case
 	output, err := c.refreshTokenUseCase.Execute(ctx, user.RefreshTokenInput{
 		RefreshToken: req.RefreshToken,
+		UserAgent:    ctx.Request.UserAgent(),
+		IPAddress:    ctx.ClientIP(),
 	})
 
 	if err != nil {
@@ -176,7 +336,8 @@ func (c *ControllerV1) RefreshToken(ctx *gin.Context) {
 
 	// Map to response
 	response := RefreshTokenResponse{
-		AccessToken: output.AccessToken,
+		AccessToken:  output.AccessToken,
+		RefreshToken: output.RefreshToken,
 	}
 
 	rest.ResponseSuccess(ctx, http.StatusOK, "Token refreshed successfully", response)
@@ -190,7 +351,7 @@ func (c *ControllerV1) RefreshToken(ctx *gin.Context) {
 // @Produce json
 // @Param payload body RegisterRequest true "Register request"
 // @Success 201 {object} rest.BaseResponse{data=RegisterResponse}
-// @Failure 400 {object} rest.BaseResponse
+// @Failure 400 {object} rest.BaseResponse{data=PasswordPolicyErrorResponse}
 // @Failure 409 {object} rest.BaseResponse
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/register [post]
@@ -211,6 +372,9 @@ func (c *ControllerV1) Register(ctx *gin.Context) {
 	})
 	if err != nil {
 		ctxLogger.Errorf("Failed to register: %v", err)
+		if respondPasswordPolicy(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusBadRequest, "Registration failed", err)
 		return
 	}
@@ -228,6 +392,7 @@ func (c *ControllerV1) Register(ctx *gin.Context) {
 // @Param payload body ForgotPasswordRequest true "Forgot password request"
 // @Success 200 {object} rest.BaseResponse{data=MessageResponse}
 // @Failure 400 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/forgot-password [post]
 func (c *ControllerV1) ForgotPassword(ctx *gin.Context) {
@@ -240,8 +405,15 @@ func (c *ControllerV1) ForgotPassword(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.forgotPasswordUseCase.Execute(ctx, user.ForgotPasswordInput{Email: req.Email}); err != nil {
+	if err := c.forgotPasswordUseCase.Execute(ctx, user.ForgotPasswordInput{
+		Email:     req.Email,
+		RequestIP: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}); err != nil {
 		ctxLogger.Errorf("Failed to process forgot password: %v", err)
+		if respondThrottled(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to process request", err)
 		return
 	}
@@ -257,7 +429,7 @@ func (c *ControllerV1) ForgotPassword(ctx *gin.Context) {
 // @Produce json
 // @Param payload body ResetPasswordRequest true "Reset password request"
 // @Success 200 {object} rest.BaseResponse{data=MessageResponse}
-// @Failure 400 {object} rest.BaseResponse
+// @Failure 400 {object} rest.BaseResponse{data=PasswordPolicyErrorResponse}
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/reset-password [post]
 func (c *ControllerV1) ResetPassword(ctx *gin.Context) {
@@ -275,6 +447,9 @@ func (c *ControllerV1) ResetPassword(ctx *gin.Context) {
 		NewPasswordEnc: req.NewPasswordEnc,
 	}); err != nil {
 		ctxLogger.Errorf("Failed to reset password: %v", err)
+		if respondPasswordPolicy(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusBadRequest, "Failed to reset password", err)
 		return
 	}
@@ -291,7 +466,7 @@ func (c *ControllerV1) ResetPassword(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param payload body ChangePasswordRequest true "Change password request"
 // @Success 200 {object} rest.BaseResponse{data=MessageResponse}
-// @Failure 400 {object} rest.BaseResponse
+// @Failure 400 {object} rest.BaseResponse{data=PasswordPolicyErrorResponse}
 // @Failure 401 {object} rest.BaseResponse
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/change-password [post]
@@ -318,6 +493,9 @@ func (c *ControllerV1) ChangePassword(ctx *gin.Context) {
 		NewPasswordEnc: req.NewPasswordEnc,
 	}); err != nil {
 		ctxLogger.Errorf("Failed to change password: %v", err)
+		if respondPasswordPolicy(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusBadRequest, "Failed to change password", err)
 		return
 	}
@@ -334,6 +512,7 @@ func (c *ControllerV1) ChangePassword(ctx *gin.Context) {
 // @Param payload body VerifyOTPRequest true "Verify OTP request"
 // @Success 200 {object} rest.BaseResponse{data=MessageResponse}
 // @Failure 400 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/verify-otp [post]
 func (c *ControllerV1) VerifyOTP(ctx *gin.Context) {
@@ -347,10 +526,14 @@ func (c *ControllerV1) VerifyOTP(ctx *gin.Context) {
 	}
 
 	if err := c.verifyOTPUseCase.Execute(ctx, user.VerifyOTPInput{
-		UserID: req.UserID,
-		OTP:    req.OTP,
+		UserID:    req.UserID,
+		OTP:       req.OTP,
+		RequestIP: ctx.ClientIP(),
 	}); err != nil {
 		ctxLogger.Errorf("Failed to verify OTP: %v", err)
+		if respondThrottled(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusBadRequest, "Failed to verify OTP", err)
 		return
 	}
@@ -359,48 +542,626 @@ func (c *ControllerV1) VerifyOTP(ctx *gin.Context) {
 		MessageResponse{Message: "OTP verified successfully"})
 }
 
+// ActivateAccount godoc
+// @Summary Activate an invited account
+// @Description Set the password of an account created by staff using the emailed activation token; each link works once
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param payload body ActivateAccountRequest true "Activation request"
+// @Success 200 {object} rest.BaseResponse{data=MessageResponse}
+// @Failure 400 {object} rest.BaseResponse{data=PasswordPolicyErrorResponse}
+// @Router /v1/auth/activate [post]
+func (c *ControllerV1) ActivateAccount(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req ActivateAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := c.activateUseCase.Execute(ctx, user.ActivateAccountInput{
+		Token:       req.Token,
+		PasswordEnc: req.PasswordEnc,
+	}); err != nil {
+		ctxLogger.Errorf("Failed to activate account: %v", err)
+		if respondPasswordPolicy(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusBadRequest, "Failed to activate account", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Account activated successfully", MessageResponse{Message: "Account activated successfully"})
+}
+
 // GetMe godoc
 // @Summary Get current user profile
-// @Description Get the profile of the currently authenticated user
+// @Description Get the currently authenticated user with their linked teacher, student or guardian profile
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} rest.BaseResponse{data=UserResponse}
+// @Success 200 {object} rest.BaseResponse{data=MeResponse}
 // @Failure 401 {object} rest.BaseResponse
 // @Failure 500 {object} rest.BaseResponse
 // @Router /v1/auth/me [get]
 func (c *ControllerV1) GetMe(ctx *gin.Context) {
 	ctxLogger := logger.NewLogger(ctx)
 
-	userIDVal, exists := ctx.Get("user_id")
-	if !exists {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
 		rest.ResponseError(ctx, http.StatusUnauthorized, "Unauthorized", nil)
 		return
 	}
-	userID, _ := userIDVal.(string)
 
-	userIDInt, err := strconv.Atoi(userID)
+	out, err := c.getMeUseCase.Execute(ctx, user.GetMeInput{UserID: userID})
 	if err != nil {
-		ctxLogger.Errorf("Failed to convert user ID to int: %v", err)
-		rest.ResponseError(ctx, http.StatusInternalServerError, "Internal server error", nil)
+		ctxLogger.Errorf("Failed to get current user: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to retrieve user profile", err)
 		return
 	}
 
-	out, err := c.getUserByIdUseCase.Execute(ctx, user.GetUserByIdInput{ID: userIDInt})
+	resp := MeResponse{
+		UserResponse: UserResponse{
+			ID:       out.User.ID,
+			Code:     out.User.Code,
+			FullName: out.User.FullName,
+			Email:    out.User.Email,
+			Role:     out.User.Role,
+			IsActive: out.User.IsActive,
+		},
+	}
+	if out.Profile != nil {
+		resp.Profile = &ProfileResponse{
+			Type:     out.Profile.Type,
+			ID:       out.Profile.ID,
+			Code:     out.Profile.Code,
+			FullName: out.Profile.FullName,
+		}
+	}
+	rest.ResponseSuccess(ctx, http.StatusOK, "User profile retrieved successfully", resp)
+}
+
+// ListSessions godoc
+// @Summary List my sessions
+// @Description Devices currently signed in to the account; the session of this request is marked current
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} rest.BaseResponse{data=[]SessionResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/sessions [get]
+func (c *ControllerV1) ListSessions(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	out, err := c.listSessionsUseCase.Execute(ctx, user.ListSessionsInput{
+		UserID:           ctx.GetString("user_id"),
+		CurrentSessionID: ctx.GetString("session_id"),
+	})
 	if err != nil {
-		ctxLogger.Errorf("Failed to get user by ID: %v", err)
-		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to retrieve user profile", err)
+		ctxLogger.Errorf("Failed to list sessions: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list sessions", err)
 		return
 	}
 
-	resp := UserResponse{
-		ID:       out.ID,
-		Code:     out.Code,
-		FullName: out.FullName,
-		Email:    out.Email,
-		Role:     out.Role,
-		IsActive: out.IsActive,
+	sessions := make([]SessionResponse, 0, len(out.Sessions))
+	for _, s := range out.Sessions {
+		sessions = append(sessions, SessionResponse{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.Current,
+		})
+	}
+	rest.ResponseSuccess(ctx, http.StatusOK, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeSessions godoc
+// @Summary Sign out sessions
+// @Description With an ID, sign out that session; without, sign out every session except the current one.
+// @Description Access tokens of revoked sessions stop working immediately.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Param id path string false "Session ID"
+// @Success 200 {object} rest.BaseResponse{data=RevokeSessionsResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/sessions [delete]
+// @Router /v1/auth/sessions/{id} [delete]
+func (c *ControllerV1) RevokeSessions(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	out, err := c.revokeSessionsUseCase.Execute(ctx, user.RevokeSessionsInput{
+		UserID:           ctx.GetString("user_id"),
+		SessionID:        ctx.Param("id"),
+		CurrentSessionID: ctx.GetString("session_id"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to revoke sessions: %v", err)
+		if errors.Is(err, userservice.ErrSessionNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to revoke sessions", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Sessions revoked successfully", RevokeSessionsResponse{Revoked: out.Revoked})
+}
+
+// UnlockAccount godoc
+// @Summary Unlock an account
+// @Description Lift the backoff and lockout left by failed logins or OTP checks of a user. Requires user:unlock.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=MessageResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/users/{id}/unlock [post]
+func (c *ControllerV1) UnlockAccount(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	err := c.unlockAccountUseCase.Execute(ctx, user.UnlockAccountInput{
+		UserID:    ctx.Param("id"),
+		ActorID:   ctx.GetString("user_id"),
+		ActorRole: ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to unlock account: %v", err)
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to unlock account", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Account unlocked successfully", MessageResponse{Message: "Account unlocked successfully"})
+}
+
+// GetPasswordPublicKey godoc
+// @Summary Get the password encryption key
+// @Description Get the public key that password fields (password_enc, new_password_enc, ...) are encrypted with
+// @Description before they are sent. Wrap a fresh AES-256-GCM key with it using RSA-OAEP (SHA-256).
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} rest.BaseResponse{data=PasswordPublicKeyResponse}
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/public-key [get]
+func (c *ControllerV1) GetPasswordPublicKey(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	out, err := c.publicKeyUseCase.Execute(ctx)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get password public key: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to retrieve public key", err)
+		return
+	}
+
+	// Clients may cache the key briefly; rotation keeps the previous key valid for decryption
+	ctx.Header("Cache-Control", "public, max-age=300")
+	rest.ResponseSuccess(ctx, http.StatusOK, "Public key retrieved successfully", PasswordPublicKeyResponse{
+		KeyID:       out.Key.KeyID,
+		Algorithm:   out.Key.Algorithm,
+		PublicKey:   out.Key.PEM,
+		JWK:         toJWKResponse(out.Key.JWK),
+		AcceptPlain: out.AcceptPlain,
+	})
+}
+
+// GetJWKS godoc
+// @Summary Get the token verification keys
+// @Description Get the public keys access tokens are signed with, as a JSON Web Key Set (RFC 7517). Tokens name
+// @Description their key in the kid header; keys scheduled for rotation are listed before they start signing.
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} JWKSResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /.well-known/jwks.json [get]
+func (c *ControllerV1) GetJWKS(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	out, err := c.jwksUseCase.Execute(ctx)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get JWKS: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to retrieve signing keys", err)
+		return
+	}
+
+	keys := make([]JWKResponse, 0, len(out.Keys))
+	for _, key := range out.Keys {
+		keys = append(keys, toJWKResponse(key))
+	}
+	// The key set is served bare, as verifiers expect; new keys are published before they sign
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, JWKSResponse{Keys: keys})
+}
+
+func toJWKResponse(key security.PublicJWK) JWKResponse {
+	return JWKResponse{
+		Kty: key.Kty,
+		Kid: key.Kid,
+		Use: key.Use,
+		Alg: key.Alg,
+		N:   key.N,
+		E:   key.E,
+		Crv: key.Crv,
+		X:   key.X,
+	}
+}
+
+// LoginMFA godoc
+// @Summary Complete a two-factor login
+// @Description Send the mfa_token from /v1/auth/login with a code from the authenticator app, or a recovery code,
+// @Description to receive the tokens. When enrolment was required the code confirms it and recovery codes are returned.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param payload body LoginMFARequest true "Second factor"
+// @Success 200 {object} rest.BaseResponse{data=LoginResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/login/mfa [post]
+func (c *ControllerV1) LoginMFA(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req LoginMFARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.Code == "" && req.RecoveryCode == "" {
+		rest.ResponseError(ctx, http.StatusBadRequest, "code or recovery_code is required", nil)
+		return
+	}
+
+	output, err := c.loginMFAUseCase.Execute(ctx, user.LoginMFAInput{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		UserAgent:    ctx.Request.UserAgent(),
+		IPAddress:    ctx.ClientIP(),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to complete two-factor login: %v", err)
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to login", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Login successful", toLoginResponse(output))
+}
+
+// LoginMFASetup godoc
+// @Summary Set up two-factor authentication during a login
+// @Description For logins that returned mfa_enrolment_required: returns a new secret and its otpauth URI to
+// @Description scan. Confirm it by sending the first code to /v1/auth/login/mfa.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param payload body LoginMFASetupRequest true "Pending login"
+// @Success 200 {object} rest.BaseResponse{data=MFASetupResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/login/mfa/setup [post]
+func (c *ControllerV1) LoginMFASetup(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req LoginMFASetupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	out, err := c.loginMFASetupUseCase.Execute(ctx, user.LoginMFASetupInput{MFAToken: req.MFAToken})
+	if err != nil {
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to set up two-factor authentication", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Scan the code with your authenticator app", MFASetupResponse{
+		Secret:          out.Secret,
+		ProvisioningURI: out.ProvisioningURI,
+	})
+}
+
+// SetupMFA godoc
+// @Summary Set up two-factor authentication
+// @Description Returns a new secret and its otpauth URI to scan; confirm it with /v1/auth/mfa/confirm
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} rest.BaseResponse{data=MFASetupResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/mfa/setup [post]
+func (c *ControllerV1) SetupMFA(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	out, err := c.setupMFAUseCase.Execute(ctx, user.SetupMFAInput{UserID: ctx.GetString("user_id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to set up two-factor authentication: %v", err)
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to set up two-factor authentication", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Scan the code with your authenticator app", MFASetupResponse{
+		Secret:          out.Secret,
+		ProvisioningURI: out.ProvisioningURI,
+	})
+}
+
+// ConfirmMFA godoc
+// @Summary Confirm two-factor authentication
+// @Description Enable the authenticator set up with /v1/auth/mfa/setup using its first code. The recovery codes
+// @Description are returned only once.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payload body MFACodeRequest true "Authenticator code"
+// @Success 200 {object} rest.BaseResponse{data=RecoveryCodesResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/mfa/confirm [post]
+func (c *ControllerV1) ConfirmMFA(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	out, err := c.confirmMFAUseCase.Execute(ctx, c.mfaCodeInput(ctx, req))
+	if err != nil {
+		ctxLogger.Errorf("Failed to confirm two-factor authentication: %v", err)
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to enable two-factor authentication", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Two-factor authentication enabled", RecoveryCodesResponse{RecoveryCodes: out.RecoveryCodes})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes after checking an authenticator code; the old codes stop working
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payload body MFACodeRequest true "Authenticator code"
+// @Success 200 {object} rest.BaseResponse{data=RecoveryCodesResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/mfa/recovery-codes [post]
+func (c *ControllerV1) RegenerateRecoveryCodes(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	out, err := c.recoveryCodesUseCase.Execute(ctx, c.mfaCodeInput(ctx, req))
+	if err != nil {
+		ctxLogger.Errorf("Failed to regenerate recovery codes: %v", err)
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to regenerate recovery codes", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Recovery codes regenerated", RecoveryCodesResponse{RecoveryCodes: out.RecoveryCodes})
+}
+
+// DisableMFA godoc
+// @Summary Disable two-factor authentication
+// @Description Remove the authenticator and recovery codes after checking an authenticator code. Not allowed
+// @Description for roles that require two-factor authentication.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payload body MFACodeRequest true "Authenticator code"
+// @Success 200 {object} rest.BaseResponse{data=MessageResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/mfa/disable [post]
+func (c *ControllerV1) DisableMFA(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := c.disableMFAUseCase.Execute(ctx, c.mfaCodeInput(ctx, req)); err != nil {
+		ctxLogger.Errorf("Failed to disable two-factor authentication: %v", err)
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to disable two-factor authentication", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Two-factor authentication disabled", MessageResponse{Message: "Two-factor authentication disabled"})
+}
+
+// ResetMFA godoc
+// @Summary Reset two-factor authentication of a user
+// @Description Remove the authenticator and recovery codes of a user who lost them. Requires user:mfa_reset.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=MessageResponse}
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/users/{id}/mfa/reset [post]
+func (c *ControllerV1) ResetMFA(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	err := c.resetMFAUseCase.Execute(ctx, user.ResetMFAInput{
+		UserID:    ctx.Param("id"),
+		ActorID:   ctx.GetString("user_id"),
+		ActorRole: ctx.GetString("user_role"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to reset two-factor authentication: %v", err)
+		if respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to reset two-factor authentication", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Two-factor authentication reset", MessageResponse{Message: "Two-factor authentication reset"})
+}
+
+// ListOIDCProviders godoc
+// @Summary List sign-in providers
+// @Description List the OpenID Connect providers (google, ...) users can sign in with
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} rest.BaseResponse{data=OIDCProvidersResponse}
+// @Router /v1/auth/oidc/providers [get]
+func (c *ControllerV1) ListOIDCProviders(ctx *gin.Context) {
+	rest.ResponseSuccess(ctx, http.StatusOK, "Providers retrieved successfully", OIDCProvidersResponse{
+		Providers: c.oidcProvidersUseCase.Execute(ctx),
+	})
+}
+
+// BeginOIDCLogin godoc
+// @Summary Start a sign-in with a provider
+// @Description Returns the provider URL to send the browser to (authorization code flow with PKCE). The provider
+// @Description redirects back to the configured frontend page with code and state, which it posts to the callback.
+// @Tags Authentication
+// @Produce json
+// @Param provider path string true "Provider name" example(google)
+// @Success 200 {object} rest.BaseResponse{data=OIDCAuthorizationResponse}
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/oidc/{provider}/authorize [get]
+func (c *ControllerV1) BeginOIDCLogin(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.beginOIDCUseCase.Execute(ctx, user.BeginOIDCLoginInput{Provider: ctx.Param("provider")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to start provider sign-in: %v", err)
+		if respondOIDCError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to start sign-in", err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	rest.ResponseSuccess(ctx, http.StatusOK, "Authorization URL created", OIDCAuthorizationResponse{
+		AuthorizationURL: output.AuthorizationURL,
+		State:            output.State,
+	})
+}
+
+// OIDCLogin godoc
+// @Summary Finish a sign-in with a provider
+// @Description Exchange the code the provider returned for the same tokens as /v1/auth/login. The first sign-in
+// @Description links the account with the verified email, or creates one. Two-factor authentication still applies.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name" example(google)
+// @Param payload body OIDCLoginRequest true "Code and state from the provider redirect"
+// @Success 200 {object} rest.BaseResponse{data=LoginResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 401 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 429 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/auth/oidc/{provider}/callback [post]
+func (c *ControllerV1) OIDCLogin(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req OIDCLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.oidcLoginUseCase.Execute(ctx, user.OIDCLoginInput{
+		Provider:  ctx.Param("provider"),
+		Code:      req.Code,
+		State:     req.State,
+		UserAgent: ctx.Request.UserAgent(),
+		IPAddress: ctx.ClientIP(),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to sign in with provider: %v", err)
+		if respondOIDCError(ctx, err) || respondMFAError(ctx, err) {
+			return
+		}
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to login", err)
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Login successful", toLoginResponse(output))
+}
+
+func (c *ControllerV1) mfaCodeInput(ctx *gin.Context, req MFACodeRequest) user.MFACodeInput {
+	return user.MFACodeInput{
+		UserID:    ctx.GetString("user_id"),
+		UserRole:  ctx.GetString("user_role"),
+		Code:      req.Code,
+		IPAddress: ctx.ClientIP(),
 	}
-	rest.ResponseSuccess(ctx, http.StatusOK, "User profile retrieved successfully", resp)
 }
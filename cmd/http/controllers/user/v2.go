@@ -53,12 +53,17 @@ func (c *ControllerV2) Login(ctx *gin.Context) {
 
 	// Execute login use case
 	output, err := c.loginUseCase.Execute(ctx, user.LoginInput{
-		Username: req.Username,
-		Password: req.Password,
+		Username:    req.Username,
+		PasswordEnc: req.PasswordEnc,
+		UserAgent:   ctx.Request.UserAgent(),
+		IPAddress:   ctx.ClientIP(),
 	})
 
 	if err != nil {
 		ctxLogger.Errorf("Failed to login: %v", err)
+		if respondPasswordPayload(ctx, err) {
+			return
+		}
 		rest.ResponseError(ctx, http.StatusUnauthorized, "Invalid credentials", err)
 		return
 	}
@@ -124,7 +129,7 @@ func (c *ControllerV2) Logout(ctx *gin.Context) {
 
 // RefreshToken godoc
 // @Summary Refresh access token (v2)
-// @Description Get a new access token using refresh token
+// @Description Exchange a refresh token for a new access token and a new refresh token; each refresh token works once
 // @Tags Authentication v2
 // @Accept json
 // @Produce json
@@ -147,6 +152,8 @@ func (c *ControllerV2) RefreshToken(ctx *gin.Context) {
 	// Execute refresh token use case
 	output, err := c.refreshTokenUseCase.Execute(ctx, user.RefreshTokenInput{
 		RefreshToken: req.RefreshToken,
+		UserAgent:    ctx.Request.UserAgent(),
+		IPAddress:    ctx.ClientIP(),
 	})
 
 	if err != nil {
@@ -157,7 +164,8 @@ func (c *ControllerV2) RefreshToken(ctx *gin.Context) {
 
 	// Map to response
 	response := RefreshTokenResponse{
-		AccessToken: output.AccessToken,
+		AccessToken:  output.AccessToken,
+		RefreshToken: output.RefreshToken,
 	}
 
 	rest.ResponseSuccess(ctx, http.StatusOK, "Token refreshed successfully", response)
@@ -188,7 +196,87 @@ func (c *ControllerV2) VerifyOTP(ctx *gin.Context) {
 	panic("implement me")
 }
 
+func (c *ControllerV2) ActivateAccount(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (c *ControllerV2) GetMe(ctx *gin.Context) {
 	//TODO implement me
 	panic("implement me")
 }
+
+func (c *ControllerV2) ListSessions(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) RevokeSessions(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) UnlockAccount(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) GetPasswordPublicKey(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) GetJWKS(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) LoginMFA(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) LoginMFASetup(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) SetupMFA(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) ConfirmMFA(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) RegenerateRecoveryCodes(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) DisableMFA(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) ResetMFA(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) ListOIDCProviders(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) BeginOIDCLogin(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (c *ControllerV2) OIDCLogin(ctx *gin.Context) {
+	//TODO implement me
+	panic("implement me")
+}
@@ -0,0 +1,48 @@
+package usermanagement
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/pkg/config"
+	"doan/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller defines the interface for the user management HTTP handlers
+type Controller interface {
+	ListUsers(ctx *gin.Context)
+	GetUser(ctx *gin.Context)
+	CreateUser(ctx *gin.Context)
+	UpdateUserRole(ctx *gin.Context)
+	ActivateUser(ctx *gin.Context)
+	DeactivateUser(ctx *gin.Context)
+	DeleteUser(ctx *gin.Context)
+	RestoreUser(ctx *gin.Context)
+	ForcePasswordReset(ctx *gin.Context)
+	ImpersonateUser(ctx *gin.Context)
+}
+
+// RegisterRoutesV1 registers the user management routes with the router
+func RegisterRoutesV1(router *gin.RouterGroup, controller Controller, configManager config.Manager) {
+	v1 := router.Group("/v1/users")
+
+	// Middleware
+	authMiddleware := middleware.AuthMiddleware(configManager)
+	readUsers := middleware.PermissionMiddleware(constants.PermissionUserRead)
+	writeUsers := middleware.PermissionMiddleware(constants.PermissionUserWrite)
+	resetPasswords := middleware.PermissionMiddleware(constants.PermissionUserResetPassword)
+	impersonate := middleware.PermissionMiddleware(constants.PermissionUserImpersonate)
+
+	v1.Use(authMiddleware)
+
+	v1.GET("", readUsers, controller.ListUsers)
+	v1.GET("/:id", readUsers, controller.GetUser)
+	v1.POST("", writeUsers, controller.CreateUser)
+	v1.PUT("/:id/role", writeUsers, controller.UpdateUserRole)
+	v1.POST("/:id/activate", writeUsers, controller.ActivateUser)
+	v1.POST("/:id/deactivate", writeUsers, controller.DeactivateUser)
+	v1.DELETE("/:id", writeUsers, controller.DeleteUser)
+	v1.POST("/:id/restore", writeUsers, controller.RestoreUser)
+	v1.POST("/:id/reset-password", resetPasswords, controller.ForcePasswordReset)
+	v1.POST("/:id/impersonate", impersonate, controller.ImpersonateUser)
+}
@@ -0,0 +1,88 @@
+package usermanagement
+
+import (
+	"doan/internal/entities"
+	"time"
+)
+
+// CreateUserRequest represents a new account; its owner gets an activation link to set the password
+type CreateUserRequest struct {
+	Email    string `json:"email" binding:"required,email" example:"accountant@example.com"`
+	FullName string `json:"full_name" example:"Nguyen Van A"`
+	Role     string `json:"role" binding:"required" example:"TEACHER"`
+}
+
+// UpdateUserRoleRequest represents the new role of an account
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required" example:"COMPLIANCE"`
+}
+
+// UserResponse represents a user account
+type UserResponse struct {
+	ID        string     `json:"id"`
+	Code      string     `json:"code"`
+	FullName  string     `json:"full_name"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// UserDetailResponse represents a user account with its number of active sessions
+type UserDetailResponse struct {
+	UserResponse
+	ActiveSessions int `json:"active_sessions"`
+}
+
+// ListUsersResponse represents one page of users
+type ListUsersResponse struct {
+	Users      []UserResponse `json:"users"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+// PaginationMeta represents pagination metadata
+type PaginationMeta struct {
+	ItemsPerPage uint64 `json:"items_per_page"`
+	TotalItems   uint64 `json:"total_items"`
+	CurrentPage  uint64 `json:"current_page"`
+	TotalPages   uint64 `json:"total_pages"`
+}
+
+// UserStatusResponse represents an account after a change of status and the sessions it ended
+type UserStatusResponse struct {
+	User            UserResponse `json:"user"`
+	RevokedSessions int          `json:"revoked_sessions"`
+}
+
+// RevokedSessionsResponse represents the number of sessions an action ended
+type RevokedSessionsResponse struct {
+	RevokedSessions int `json:"revoked_sessions"`
+}
+
+// ImpersonationResponse represents an access token to act as another user; it cannot be refreshed
+type ImpersonationResponse struct {
+	AccessToken string       `json:"access_token"`
+	SessionID   string       `json:"session_id"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	User        UserResponse `json:"user"`
+}
+
+func mapUser(user *entities.User) UserResponse {
+	response := UserResponse{
+		ID:        user.ID,
+		Code:      user.Code,
+		FullName:  user.FullName,
+		Email:     user.Email,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+	if user.DeletedAt.Valid {
+		deletedAt := user.DeletedAt.Time
+		response.DeletedAt = &deletedAt
+	}
+	return response
+}
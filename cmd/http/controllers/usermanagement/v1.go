@@ -0,0 +1,417 @@
+package usermanagement
+
+import (
+	"doan/cmd/http/middleware"
+	"doan/cmd/http/rest"
+	"doan/internal/services/account"
+	userservice "doan/internal/services/user"
+	"doan/internal/usecases/usermanagement"
+	"doan/pkg/logger"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Controller = (*ControllerV1)(nil)
+
+type ControllerV1 struct {
+	listUsersUseCase          usermanagement.ListUsersUseCase
+	getUserUseCase            usermanagement.GetUserUseCase
+	createUserUseCase         usermanagement.CreateUserUseCase
+	updateUserRoleUseCase     usermanagement.UpdateUserRoleUseCase
+	setUserActiveUseCase      usermanagement.SetUserActiveUseCase
+	deleteUserUseCase         usermanagement.DeleteUserUseCase
+	restoreUserUseCase        usermanagement.RestoreUserUseCase
+	forcePasswordResetUseCase usermanagement.ForcePasswordResetUseCase
+	impersonateUserUseCase    usermanagement.ImpersonateUserUseCase
+}
+
+func NewUserManagementControllerV1(
+	listUsersUseCase usermanagement.ListUsersUseCase,
+	getUserUseCase usermanagement.GetUserUseCase,
+	createUserUseCase usermanagement.CreateUserUseCase,
+	updateUserRoleUseCase usermanagement.UpdateUserRoleUseCase,
+	setUserActiveUseCase usermanagement.SetUserActiveUseCase,
+	deleteUserUseCase usermanagement.DeleteUserUseCase,
+	restoreUserUseCase usermanagement.RestoreUserUseCase,
+	forcePasswordResetUseCase usermanagement.ForcePasswordResetUseCase,
+	impersonateUserUseCase usermanagement.ImpersonateUserUseCase,
+) *ControllerV1 {
+	return &ControllerV1{
+		listUsersUseCase:          listUsersUseCase,
+		getUserUseCase:            getUserUseCase,
+		createUserUseCase:         createUserUseCase,
+		updateUserRoleUseCase:     updateUserRoleUseCase,
+		setUserActiveUseCase:      setUserActiveUseCase,
+		deleteUserUseCase:         deleteUserUseCase,
+		restoreUserUseCase:        restoreUserUseCase,
+		forcePasswordResetUseCase: forcePasswordResetUseCase,
+		impersonateUserUseCase:    impersonateUserUseCase,
+	}
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description Search user accounts with filtering and pagination; status "deleted" lists soft-deleted accounts (user:read)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search in name, email, code"
+// @Param role query string false "Filter by role"
+// @Param status query string false "Filter by status (active, inactive, deleted)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param sort_by query string false "Sort field (created_at, updated_at, email, full_name, role)"
+// @Param sort_order query string false "Sort order (asc, desc)" default(desc)
+// @Success 200 {object} rest.BaseResponse{data=ListUsersResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users [get]
+func (c *ControllerV1) ListUsers(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	output, err := c.listUsersUseCase.Execute(ctx, usermanagement.ListUsersInput{
+		Search:    ctx.Query("search"),
+		Role:      ctx.Query("role"),
+		Status:    ctx.Query("status"),
+		Page:      page,
+		Limit:     limit,
+		SortBy:    ctx.Query("sort_by"),
+		SortOrder: ctx.Query("sort_order"),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list users: %v", err)
+		rest.ResponseError(ctx, http.StatusInternalServerError, "Failed to list users", err)
+		return
+	}
+
+	users := make([]UserResponse, 0, len(output.Users))
+	for _, u := range output.Users {
+		users = append(users, mapUser(u))
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Users retrieved successfully", ListUsersResponse{
+		Users: users,
+		Pagination: PaginationMeta{
+			ItemsPerPage: output.Pagination.ItemsPerPage,
+			TotalItems:   output.Pagination.TotalItems,
+			CurrentPage:  output.Pagination.CurrentPage,
+			TotalPages:   output.Pagination.TotalPages,
+		},
+	})
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description A user account, deleted or not, with its number of active sessions (user:read)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=UserDetailResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id} [get]
+func (c *ControllerV1) GetUser(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.getUserUseCase.Execute(ctx, usermanagement.GetUserInput{ID: ctx.Param("id")})
+	if err != nil {
+		ctxLogger.Errorf("Failed to get user: %v", err)
+		respondUserError(ctx, err, "Failed to get user")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "User retrieved successfully", UserDetailResponse{
+		UserResponse:   mapUser(output.User),
+		ActiveSessions: output.ActiveSessions,
+	})
+}
+
+// CreateUser godoc
+// @Summary Create a user
+// @Description Create an account with a role whose permissions the caller holds; its owner is emailed a link to set the password and activate it (user:write)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateUserRequest true "User"
+// @Success 201 {object} rest.BaseResponse{data=UserResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users [post]
+func (c *ControllerV1) CreateUser(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req CreateUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.createUserUseCase.Execute(ctx, usermanagement.CreateUserInput{
+		Email:     req.Email,
+		FullName:  req.FullName,
+		Role:      req.Role,
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create user: %v", err)
+		respondUserError(ctx, err, "Failed to create user")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusCreated, "User created; an activation link was sent", mapUser(output.User))
+}
+
+// UpdateUserRole godoc
+// @Summary Change the role of a user
+// @Description The caller must hold every permission of the current and the new role; the user's sessions are revoked, so the new permissions apply from their next login (user:write)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body UpdateUserRoleRequest true "Role"
+// @Success 200 {object} rest.BaseResponse{data=UserResponse}
+// @Failure 400 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id}/role [put]
+func (c *ControllerV1) UpdateUserRole(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var req UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxLogger.Errorf("Failed to bind request: %v", err)
+		rest.ResponseError(ctx, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	output, err := c.updateUserRoleUseCase.Execute(ctx, usermanagement.UpdateUserRoleInput{
+		ID:        ctx.Param("id"),
+		Role:      req.Role,
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to change user role: %v", err)
+		respondUserError(ctx, err, "Failed to change user role")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "User role updated successfully", mapUser(output.User))
+}
+
+// ActivateUser godoc
+// @Summary Activate a user
+// @Description Let a deactivated account log in again (user:write)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=UserStatusResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id}/activate [post]
+func (c *ControllerV1) ActivateUser(ctx *gin.Context) {
+	c.setUserActive(ctx, true, "User activated successfully")
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate a user
+// @Description Stop an account from logging in and sign it out of every session (user:write)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=UserStatusResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id}/deactivate [post]
+func (c *ControllerV1) DeactivateUser(ctx *gin.Context) {
+	c.setUserActive(ctx, false, "User deactivated successfully")
+}
+
+func (c *ControllerV1) setUserActive(ctx *gin.Context, active bool, message string) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.setUserActiveUseCase.Execute(ctx, usermanagement.SetUserActiveInput{
+		ID:        ctx.Param("id"),
+		Active:    active,
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to set user active=%t: %v", active, err)
+		respondUserError(ctx, err, "Failed to update user status")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, message, UserStatusResponse{
+		User:            mapUser(output.User),
+		RevokedSessions: output.RevokedSessions,
+	})
+}
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Description Soft-delete an account and sign it out of every session; it can be restored (user:write)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id} [delete]
+func (c *ControllerV1) DeleteUser(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := c.deleteUserUseCase.Execute(ctx, usermanagement.DeleteUserInput{
+		ID:        ctx.Param("id"),
+		Requester: middleware.Principal(ctx),
+	}); err != nil {
+		ctxLogger.Errorf("Failed to delete user: %v", err)
+		respondUserError(ctx, err, "Failed to delete user")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "User deleted successfully", nil)
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Undo the soft delete of an account (user:write)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=UserResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id}/restore [post]
+func (c *ControllerV1) RestoreUser(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.restoreUserUseCase.Execute(ctx, usermanagement.RestoreUserInput{
+		ID:        ctx.Param("id"),
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to restore user: %v", err)
+		respondUserError(ctx, err, "Failed to restore user")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "User restored successfully", mapUser(output.User))
+}
+
+// ForcePasswordReset godoc
+// @Summary Force a password reset
+// @Description Void the password of an active account, sign it out of every session and email a link to set a new one; the account is inactive until then (user:reset_password)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=RevokedSessionsResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id}/reset-password [post]
+func (c *ControllerV1) ForcePasswordReset(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.forcePasswordResetUseCase.Execute(ctx, usermanagement.ForcePasswordResetInput{
+		ID:        ctx.Param("id"),
+		Requester: middleware.Principal(ctx),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to force password reset: %v", err)
+		respondUserError(ctx, err, "Failed to force password reset")
+		return
+	}
+
+	rest.ResponseSuccess(ctx, http.StatusOK, "Password reset link sent", RevokedSessionsResponse{
+		RevokedSessions: output.RevokedSessions,
+	})
+}
+
+// ImpersonateUser godoc
+// @Summary Impersonate a user
+// @Description Get a short-lived access token to act as an active user; every request made with it is audited against the caller (user:impersonate)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} rest.BaseResponse{data=ImpersonationResponse}
+// @Failure 403 {object} rest.BaseResponse
+// @Failure 404 {object} rest.BaseResponse
+// @Failure 409 {object} rest.BaseResponse
+// @Failure 500 {object} rest.BaseResponse
+// @Router /v1/users/{id}/impersonate [post]
+func (c *ControllerV1) ImpersonateUser(ctx *gin.Context) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	output, err := c.impersonateUserUseCase.Execute(ctx, usermanagement.ImpersonateUserInput{
+		ID:             ctx.Param("id"),
+		Requester:      middleware.Principal(ctx),
+		ImpersonatorID: ctx.GetString("impersonator_id"),
+		UserAgent:      ctx.Request.UserAgent(),
+		IPAddress:      ctx.ClientIP(),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to impersonate user: %v", err)
+		respondUserError(ctx, err, "Failed to impersonate user")
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	rest.ResponseSuccess(ctx, http.StatusOK, "Impersonation started", ImpersonationResponse{
+		AccessToken: output.AccessToken,
+		SessionID:   output.SessionID,
+		ExpiresAt:   output.ExpiresAt,
+		User: UserResponse{
+			ID:       output.User.ID,
+			Code:     output.User.Code,
+			FullName: output.User.FullName,
+			Email:    output.User.Email,
+			Role:     output.User.Role,
+			IsActive: output.User.IsActive,
+		},
+	})
+}
+
+func respondUserError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usermanagement.ErrUserNotFound):
+		rest.ResponseError(ctx, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, usermanagement.ErrUnknownRole),
+		errors.Is(err, account.ErrEmailRequired):
+		rest.ResponseError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, usermanagement.ErrOwnAccount),
+		errors.Is(err, usermanagement.ErrPrivilegedUser),
+		errors.Is(err, usermanagement.ErrImpersonationNested):
+		rest.ResponseError(ctx, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, account.ErrEmailTaken),
+		errors.Is(err, usermanagement.ErrUserNotDeleted),
+		errors.Is(err, userservice.ErrUserInactive):
+		rest.ResponseError(ctx, http.StatusConflict, err.Error(), err)
+	default:
+		rest.ResponseError(ctx, http.StatusInternalServerError, fallback, err)
+	}
+}
@@ -1,18 +1,38 @@
 package main
 
 import (
+	"context"
 	httpConfig "doan/cmd/http/config"
+	"doan/cmd/http/controllers/auditlog"
 	"doan/cmd/http/controllers/class"
+	"doan/cmd/http/controllers/compliance"
 	"doan/cmd/http/controllers/course"
+	"doan/cmd/http/controllers/dashboard"
+	"doan/cmd/http/controllers/enrollment"
+	"doan/cmd/http/controllers/guardian"
+	"doan/cmd/http/controllers/invoice"
+	"doan/cmd/http/controllers/material"
+	"doan/cmd/http/controllers/payment"
+	"doan/cmd/http/controllers/payroll"
 	"doan/cmd/http/controllers/program"
+	"doan/cmd/http/controllers/reminder"
+	"doan/cmd/http/controllers/report"
+	"doan/cmd/http/controllers/role"
 	"doan/cmd/http/controllers/room"
 	"doan/cmd/http/controllers/student"
 	"doan/cmd/http/controllers/teacher"
 	"doan/cmd/http/controllers/user"
+	"doan/cmd/http/controllers/usermanagement"
 	_ "doan/cmd/http/docs"
 	"doan/cmd/http/middleware"
+	"doan/cmd/http/workers"
+	"doan/internal/ratelimit"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
 	"doan/pkg/config"
 	"doan/pkg/constants"
+	"doan/pkg/logger"
 	"flag"
 	"fmt"
 	"net/http"
@@ -24,20 +44,36 @@ import (
 )
 
 type App struct {
-	Name                string
-	Version             string
-	ConfigFilePath      string
-	ConfigFile          string
-	router              *gin.Engine
-	restConfig          httpConfig.RestServer
-	userControllerV1    user.Controller
-	userControllerV2    user.Controller
-	classControllerV1   class.Controller
-	roomControllerV1    room.Controller
-	teacherControllerV1 teacher.Controller
-	studentControllerV1 student.Controller
-	courseControllerV1  course.Controller
-	programControllerV1 program.Controller
+	Name                       string
+	Version                    string
+	ConfigFilePath             string
+	ConfigFile                 string
+	router                     *gin.Engine
+	restConfig                 httpConfig.RestServer
+	userControllerV1           user.Controller
+	userControllerV2           user.Controller
+	classControllerV1          class.Controller
+	roomControllerV1           room.Controller
+	teacherControllerV1        teacher.Controller
+	studentControllerV1        student.Controller
+	courseControllerV1         course.Controller
+	programControllerV1        program.Controller
+	materialControllerV1       material.Controller
+	complianceControllerV1     compliance.Controller
+	reportControllerV1         report.Controller
+	enrollmentControllerV1     enrollment.Controller
+	invoiceControllerV1        invoice.Controller
+	paymentControllerV1        payment.Controller
+	reminderControllerV1       reminder.Controller
+	payrollControllerV1        payroll.Controller
+	dashboardControllerV1      dashboard.Controller
+	guardianControllerV1       guardian.Controller
+	roleControllerV1           role.Controller
+	userManagementControllerV1 usermanagement.Controller
+	auditLogControllerV1       auditlog.Controller
+	ctx                        context.Context
+	logger                     logger.Logger
+	workers                    workers.Workers
 }
 
 func (a *App) initFlag() {
@@ -62,6 +98,7 @@ func (a *App) initConfig() {
 
 func (a *App) Run() error {
 	a.registerRoute()
+	a.workers.StartAll(a.ctx, a.logger)
 	err := a.router.Run(fmt.Sprintf("%s:%s", a.restConfig.Path, a.restConfig.Port))
 	if err != nil {
 		return err
@@ -81,7 +118,10 @@ func (a *App) registerRoute() {
 	// Swagger route under /api
 	api.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/api/swagger/doc.json")))
 
-	user.RegisterRoutesV1(api, a.userControllerV1)
+	// Token verification keys for other services
+	user.RegisterWellKnownRoutes(a.router, a.userControllerV1)
+
+	user.RegisterRoutesV1(api, a.userControllerV1, config.GetManager())
 	user.RegisterRoutesV2(api, a.userControllerV2)
 	class.RegisterRoutesV1(api, a.classControllerV1, config.GetManager())
 	room.RegisterRoutesV1(api, a.roomControllerV1, config.GetManager())
@@ -89,6 +129,19 @@ func (a *App) registerRoute() {
 	student.RegisterRoutesV1(api, a.studentControllerV1, config.GetManager())
 	course.RegisterRoutesV1(api, a.courseControllerV1, config.GetManager())
 	program.RegisterRoutesV1(api, a.programControllerV1, config.GetManager())
+	material.RegisterRoutesV1(api, a.materialControllerV1, config.GetManager())
+	compliance.RegisterRoutesV1(api, a.complianceControllerV1, config.GetManager())
+	report.RegisterRoutesV1(api, a.reportControllerV1, config.GetManager())
+	enrollment.RegisterRoutesV1(api, a.enrollmentControllerV1, config.GetManager())
+	invoice.RegisterRoutesV1(api, a.invoiceControllerV1, config.GetManager())
+	payment.RegisterRoutesV1(api, a.paymentControllerV1, config.GetManager())
+	reminder.RegisterRoutesV1(api, a.reminderControllerV1, config.GetManager())
+	payroll.RegisterRoutesV1(api, a.payrollControllerV1, config.GetManager())
+	dashboard.RegisterRoutesV1(api, a.dashboardControllerV1, config.GetManager())
+	guardian.RegisterRoutesV1(api, a.guardianControllerV1, config.GetManager())
+	role.RegisterRoutesV1(api, a.roleControllerV1, config.GetManager())
+	usermanagement.RegisterRoutesV1(api, a.userManagementControllerV1, config.GetManager())
+	auditlog.RegisterRoutesV1(api, a.auditLogControllerV1, config.GetManager())
 
 }
 
@@ -102,6 +155,26 @@ func inject(
 	studentControllerV1 student.Controller,
 	courseControllerV1 course.Controller,
 	programControllerV1 program.Controller,
+	materialControllerV1 material.Controller,
+	complianceControllerV1 compliance.Controller,
+	reportControllerV1 report.Controller,
+	enrollmentControllerV1 enrollment.Controller,
+	invoiceControllerV1 invoice.Controller,
+	paymentControllerV1 payment.Controller,
+	reminderControllerV1 reminder.Controller,
+	payrollControllerV1 payroll.Controller,
+	dashboardControllerV1 dashboard.Controller,
+	guardianControllerV1 guardian.Controller,
+	roleControllerV1 role.Controller,
+	userManagementControllerV1 usermanagement.Controller,
+	auditLogControllerV1 auditlog.Controller,
+	tokenDenylist userservice.TokenDenylist,
+	tokenSigner security.TokenSigner,
+	rateLimiter ratelimit.Limiter,
+	auditLogRepo repositoryinterface.AuditLogRepository,
+	ctx context.Context,
+	log logger.Logger,
+	backgroundWorkers workers.Workers,
 ) error {
 	app.userControllerV1 = userControllerV1
 	app.userControllerV2 = userControllerV2
@@ -111,6 +184,28 @@ func inject(
 	app.studentControllerV1 = studentControllerV1
 	app.courseControllerV1 = courseControllerV1
 	app.programControllerV1 = programControllerV1
+	app.materialControllerV1 = materialControllerV1
+	app.complianceControllerV1 = complianceControllerV1
+	app.reportControllerV1 = reportControllerV1
+	app.enrollmentControllerV1 = enrollmentControllerV1
+	app.invoiceControllerV1 = invoiceControllerV1
+	app.paymentControllerV1 = paymentControllerV1
+	app.reminderControllerV1 = reminderControllerV1
+	app.payrollControllerV1 = payrollControllerV1
+	app.dashboardControllerV1 = dashboardControllerV1
+	app.guardianControllerV1 = guardianControllerV1
+	app.roleControllerV1 = roleControllerV1
+	app.userManagementControllerV1 = userManagementControllerV1
+	app.auditLogControllerV1 = auditLogControllerV1
+	middleware.SetTokenDenylist(tokenDenylist)
+	middleware.SetTokenSigner(tokenSigner)
+	middleware.SetRateLimiter(rateLimiter)
+	middleware.SetAuditLogRepository(auditLogRepo)
+	// Signals WatchKey subscribers, such as the rate limiter, when the config changes
+	config.GetManager().Start(ctx)
+	app.ctx = ctx
+	app.logger = log
+	app.workers = backgroundWorkers
 	return nil
 }
 
@@ -133,7 +228,17 @@ func main() {
 
 	// Init gin router
 	router := gin.New()
-	router.Use(gin.LoggerWithFormatter(middleware.JsonLogMiddleware), gin.Recovery(), middleware.CorsMiddleware())
+	// ClientIP keys the rate limits and login lockouts, so X-Forwarded-For is only read from known proxies
+	if err := router.SetTrustedProxies(restConfig.TrustedProxies); err != nil {
+		panic(err)
+	}
+	router.Use(
+		gin.LoggerWithFormatter(middleware.JsonLogMiddleware),
+		gin.Recovery(),
+		middleware.RequestInfoMiddleware(),
+		middleware.CorsMiddleware(),
+		middleware.RateLimitMiddleware("global"),
+	)
 	router.HandleMethodNotAllowed = true
 	router.NoMethod(
 		func(context *gin.Context) {
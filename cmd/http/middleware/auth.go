@@ -1,15 +1,46 @@
 package middleware
 
 import (
+	"context"
+	"doan/internal/entities"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
+	"doan/pkg/authz"
 	"doan/pkg/config"
-	"doan/pkg/types"
-	"doan/pkg/utils"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// tokenDenylist blocks revoked access tokens; set once at startup by SetTokenDenylist
+var tokenDenylist userservice.TokenDenylist
+
+// SetTokenDenylist makes AuthMiddleware reject access tokens revoked by logout or session revocation
+func SetTokenDenylist(denylist userservice.TokenDenylist) {
+	tokenDenylist = denylist
+}
+
+// tokenSigner verifies access tokens; set once at startup by SetTokenSigner
+var tokenSigner security.TokenSigner
+
+// SetTokenSigner gives AuthMiddleware the keys access tokens are verified with
+func SetTokenSigner(signer security.TokenSigner) {
+	tokenSigner = signer
+}
+
+// auditLogRepo records the requests made with impersonation tokens; set once at startup by SetAuditLogRepository
+var auditLogRepo repositoryinterface.AuditLogRepository
+
+// SetAuditLogRepository gives AuthMiddleware the audit log impersonated requests are written to
+func SetAuditLogRepository(repo repositoryinterface.AuditLogRepository) {
+	auditLogRepo = repo
+}
+
 // AuthMiddleware validates JWT token from Authorization header
 func AuthMiddleware(configManager config.Manager) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -35,18 +66,16 @@ func AuthMiddleware(configManager config.Manager) gin.HandlerFunc {
 
 		token := parts[1]
 
-		// Get JWT config
-		jwtConfig := types.JWTConfig{}
-		if err := configManager.UnmarshalKey("jwt", &jwtConfig); err != nil {
+		if tokenSigner == nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 				"success": false,
-				"message": "Failed to get JWT configuration",
+				"message": "Token verification is not configured",
 			})
 			return
 		}
 
-		// Validate token
-		claims, err := utils.ValidateToken(token, jwtConfig.Secret)
+		// Validate signature, expiry, issuer and audience
+		claims, err := tokenSigner.Verify(token)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -55,17 +84,78 @@ func AuthMiddleware(configManager config.Manager) gin.HandlerFunc {
 			return
 		}
 
+		if tokenDenylist != nil {
+			revoked, err := tokenDenylist.IsRevoked(c, claims.ID, claims.SessionID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"success": false,
+					"message": "Failed to check token revocation",
+				})
+				return
+			}
+			if revoked {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"success": false,
+					"message": "Token has been revoked",
+				})
+				return
+			}
+		}
+
 		// Set user info in context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
+		c.Set("session_id", claims.SessionID)
+
+		c.Set("user_permissions", claims.Permissions)
 
+		if claims.Actor == nil {
+			setRequestUser(c, claims.UserID, claims.Role, "")
+			c.Next()
+			return
+		}
+		// An admin acting as the user: every request is attributed to them
+		c.Set("impersonator_id", claims.Actor.Subject)
+		setRequestUser(c, claims.UserID, claims.Role, claims.Actor.Subject)
 		c.Next()
+		auditImpersonatedRequest(c, claims.UserID, claims.Actor.Subject, claims.Actor.Role)
+	}
+}
+
+// auditImpersonatedRequest records a request an admin made as the user; the response is already
+// written, so a failed audit write is only logged
+func auditImpersonatedRequest(c *gin.Context, userID, actorID, actorRole string) {
+	ctx := context.WithoutCancel(c)
+	if auditLogRepo == nil {
+		logger.NewLogger(ctx).Errorf("Impersonated request %s %s by %s not audited: no audit log", c.Request.Method, c.Request.URL.Path, actorID)
+		return
+	}
+	if _, err := auditLogRepo.Create(ctx, &entities.AuditLog{
+		ActorID:    &actorID,
+		ActorRole:  actorRole,
+		Action:     entities.AuditActionImpersonated,
+		EntityType: entities.AuditEntityUser,
+		EntityID:   userID,
+		Metadata: entities.JSONMap{
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"status":     c.Writer.Status(),
+			"session_id": c.GetString("session_id"),
+		},
+	}); err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to audit impersonated request %s %s by %s: %v", c.Request.Method, c.Request.URL.Path, actorID, err)
 	}
 }
 
-// RoleMiddleware checks if user has the required role
+// RoleMiddleware checks if user has the required role.
+// Unknown role names are a programming error and panic at route registration.
 func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
+	for _, role := range allowedRoles {
+		if !constants.IsValidRole(role) {
+			panic(fmt.Sprintf("RoleMiddleware: unknown role %q", role))
+		}
+	}
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
 		if !exists {
@@ -90,3 +180,45 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 		})
 	}
 }
+
+// PermissionMiddleware checks that the user holds every one of the required permissions.
+// Unknown permissions are a programming error and panic at route registration.
+func PermissionMiddleware(requiredPermissions ...string) gin.HandlerFunc {
+	for _, perm := range requiredPermissions {
+		if !constants.IsValidPermission(perm) {
+			panic(fmt.Sprintf("PermissionMiddleware: unknown permission %q", perm))
+		}
+	}
+	return func(c *gin.Context) {
+		if _, exists := c.Get("user_permissions"); !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "User permissions not found in context",
+			})
+			return
+		}
+
+		principal := Principal(c)
+		for _, perm := range requiredPermissions {
+			if !principal.Can(perm) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"success": false,
+					"message": "You don't have permission to access this resource",
+				})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// Principal returns the authenticated caller set by AuthMiddleware, for use-case level checks
+func Principal(c *gin.Context) authz.Principal {
+	permissions, _ := c.Get("user_permissions")
+	granted, _ := permissions.([]string)
+	return authz.Principal{
+		UserID:      c.GetString("user_id"),
+		Role:        c.GetString("user_role"),
+		Permissions: granted,
+	}
+}
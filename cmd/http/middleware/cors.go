@@ -10,8 +10,8 @@ func CorsMiddleware() gin.HandlerFunc {
 	corsConfig := cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", RequestIDHeader},
 		AllowCredentials: true, // Nếu bạn cần gửi cookie
 		MaxAge:           12 * time.Hour,
 	}
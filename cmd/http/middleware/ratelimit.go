@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"doan/internal/ratelimit"
+	"doan/pkg/logger"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimiter enforces RateLimitMiddleware policies; set once at startup by SetRateLimiter
+var rateLimiter ratelimit.Limiter
+
+// SetRateLimiter makes RateLimitMiddleware enforce the policies of limiter
+func SetRateLimiter(limiter ratelimit.Limiter) {
+	rateLimiter = limiter
+}
+
+// RateLimitMiddleware limits requests by the named policy of "rate_limit.policies" and reports the quota in
+// RateLimit-* headers, adding Retry-After to a 429. A policy missing from the config lets everything through,
+// so policies can be added or dropped by a config reload. A failing store is logged and lets the request through.
+// Place it after AuthMiddleware for policies keyed by user.
+func RateLimitMiddleware(policyName string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rateLimiter == nil {
+			c.Next()
+			return
+		}
+		policy, ok := rateLimiter.Policy(policyName)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		result, err := rateLimiter.Allow(c, policy, rateLimitKey(c, policy))
+		if err != nil {
+			logger.NewLogger(c).Warnf("Rate limit %s unavailable, letting the request through: %v", policy.Name, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(result.ResetAfter))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, policy.WindowSeconds))
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"message": "Too many requests, please try again later",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitKey joins the parts the policy is keyed by
+func rateLimitKey(c *gin.Context, policy ratelimit.Policy) string {
+	parts := make([]string, 0, len(policy.KeyBy))
+	for _, part := range policy.KeyBy {
+		switch part {
+		case ratelimit.KeyByIP:
+			parts = append(parts, "ip="+c.ClientIP())
+		case ratelimit.KeyByUser:
+			if userID := c.GetString("user_id"); userID != "" {
+				parts = append(parts, "user="+userID)
+			} else {
+				parts = append(parts, "ip="+c.ClientIP())
+			}
+		case ratelimit.KeyByRoute:
+			route := c.FullPath()
+			if route == "" {
+				route = "unmatched"
+			}
+			parts = append(parts, "route="+c.Request.Method+" "+route)
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(max(d, 0).Seconds())))
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"doan/pkg/logger"
+	"doan/pkg/requestinfo"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the trace ID of a request, both ways
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID keeps client supplied IDs short and free of characters that could forge log lines
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestInfoMiddleware gives every request a trace ID, the caller's one from X-Request-ID when it looks
+// sane, and records it with the client IP for the logs and the audit trail. AuthMiddleware adds the user.
+func RequestInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(traceID) {
+			traceID = logger.GenerateTraceID("http")
+		}
+
+		info := &requestinfo.Info{
+			IPAddress: c.ClientIP(),
+			TraceID:   traceID,
+		}
+		c.Set(requestinfo.KeyInfo, info)
+		c.Set(requestinfo.KeyTraceID, traceID)
+		c.Set(requestinfo.KeyClientIP, info.IPAddress)
+		// Use cases called with c.Request.Context() see the same Info
+		c.Request = c.Request.WithContext(requestinfo.NewContext(c.Request.Context(), info))
+
+		c.Header(RequestIDHeader, traceID)
+		c.Next()
+	}
+}
+
+// setRequestUser adds the authenticated user to the Info set by RequestInfoMiddleware
+func setRequestUser(c *gin.Context, userID, role, impersonatorID string) {
+	value, exists := c.Get(requestinfo.KeyInfo)
+	if !exists {
+		return
+	}
+	if info, ok := value.(*requestinfo.Info); ok {
+		info.UserID = userID
+		info.Role = role
+		info.ImpersonatorID = impersonatorID
+	}
+}
@@ -58,3 +58,17 @@ func ResponseError(c *gin.Context, statusCode int, message string, err error) {
 		Data:      nil,
 	})
 }
+
+// ResponseErrorWithData sends an error response carrying details about the failure
+func ResponseErrorWithData(c *gin.Context, statusCode int, message string, err error, data interface{}) {
+	errorCode := ""
+	if err != nil {
+		errorCode = err.Error()
+	}
+	c.AbortWithStatusJSON(statusCode, &BaseResponse{
+		Success:   false,
+		Message:   utils.NewStringPtr(message),
+		ErrorCode: utils.NewStringPtr(errorCode),
+		Data:      data,
+	})
+}
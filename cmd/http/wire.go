@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"doan/cmd/http/controllers"
+	"doan/cmd/http/workers"
 	"doan/internal/infrastructure"
 	"doan/internal/services"
 	"doan/internal/usecases"
@@ -34,6 +35,9 @@ func wireApp(app *App) error {
 		// Layer 5: Controllers (HTTP handlers)
 		controllers.ControllerProviders,
 
+		// Background workers (queue consumers)
+		workers.WorkerProviders,
+
 		// Layer 6: Application injection
 		inject,
 	)
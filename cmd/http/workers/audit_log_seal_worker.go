@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"context"
+	"doan/internal/usecases/auditlog"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+)
+
+// AuditLogSealWorker adds new audit log entries to the hash chain every "audit.seal_interval_seconds"
+type AuditLogSealWorker struct {
+	cfg                  config.Manager
+	log                  logger.Logger
+	sealAuditLogsUseCase auditlog.SealAuditLogsUseCase
+}
+
+func NewAuditLogSealWorker(
+	cfg config.Manager,
+	log logger.Logger,
+	sealAuditLogsUseCase auditlog.SealAuditLogsUseCase,
+) *AuditLogSealWorker {
+	return &AuditLogSealWorker{
+		cfg:                  cfg,
+		log:                  log,
+		sealAuditLogsUseCase: sealAuditLogsUseCase,
+	}
+}
+
+func (w *AuditLogSealWorker) Name() string {
+	return "audit-log-seal"
+}
+
+func (w *AuditLogSealWorker) Start(ctx context.Context) error {
+	interval := time.Duration(w.cfg.GetInt("audit.seal_interval_seconds")) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// Sealing is serialised in the database, so every instance of the API may run it
+			w.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *AuditLogSealWorker) run(ctx context.Context) {
+	output, err := w.sealAuditLogsUseCase.Execute(ctx)
+	if err != nil {
+		w.log.Error(ctx, "Audit log sealing failed", "error", err)
+		return
+	}
+	if output.Sealed > 0 {
+		w.log.Debug(ctx, "Audit log entries sealed", "count", output.Sealed)
+	}
+}
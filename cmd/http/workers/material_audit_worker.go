@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"context"
+	_interface "doan/internal/infrastructure/queue/interface"
+	"doan/internal/services/ai"
+	"doan/internal/usecases/audit"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaterialAuditWorker consumes the material audit topic and runs the AI audit for each message
+type MaterialAuditWorker struct {
+	queue                       _interface.Queue
+	cfg                         config.Manager
+	processMaterialAuditUseCase audit.ProcessMaterialAuditUseCase
+}
+
+func NewMaterialAuditWorker(
+	queue _interface.Queue,
+	cfg config.Manager,
+	processMaterialAuditUseCase audit.ProcessMaterialAuditUseCase,
+) *MaterialAuditWorker {
+	return &MaterialAuditWorker{
+		queue:                       queue,
+		cfg:                         cfg,
+		processMaterialAuditUseCase: processMaterialAuditUseCase,
+	}
+}
+
+func (w *MaterialAuditWorker) Name() string {
+	return "material-audit"
+}
+
+func (w *MaterialAuditWorker) Start(ctx context.Context) error {
+	return w.queue.Consume(ctx, ai.MaterialAuditTopicOption(w.cfg), w.handle)
+}
+
+func (w *MaterialAuditWorker) handle(ctx context.Context, message *_interface.Message) (_interface.Response, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var payload ai.MaterialAuditMessage
+	if err := decodeMessage(message, &payload); err != nil {
+		return _interface.Failed, err
+	}
+
+	output, err := w.processMaterialAuditUseCase.Execute(ctx, audit.ProcessMaterialAuditInput{
+		MaterialID: payload.MaterialID,
+	})
+	if err != nil {
+		// A deleted material will never succeed, anything else (storage, AI API) may be transient
+		if errors.Is(err, audit.ErrMaterialNotFound) {
+			return _interface.Failed, err
+		}
+		return _interface.Retry, err
+	}
+
+	ctxLogger.Infof("Material %s audited: %s", payload.MaterialID, output.Result.Label)
+	return _interface.Success, nil
+}
+
+// decodeMessage decodes the JSON payload produced by the queue implementations
+func decodeMessage(message *_interface.Message, out interface{}) error {
+	if message == nil {
+		return errors.New("empty message")
+	}
+	switch data := message.Data.(type) {
+	case []byte:
+		return json.Unmarshal(data, out)
+	case string:
+		return json.Unmarshal([]byte(data), out)
+	default:
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("marshal message data: %w", err)
+		}
+		return json.Unmarshal(raw, out)
+	}
+}
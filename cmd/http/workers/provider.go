@@ -0,0 +1,24 @@
+package workers
+
+import "github.com/google/wire"
+
+// WorkerProviders provides all background workers
+var WorkerProviders = wire.NewSet(
+	NewMaterialAuditWorker,
+	NewTuitionReminderWorker,
+	NewAuditLogSealWorker,
+	NewWorkers,
+)
+
+// NewWorkers collects the workers started by the HTTP application
+func NewWorkers(
+	materialAuditWorker *MaterialAuditWorker,
+	tuitionReminderWorker *TuitionReminderWorker,
+	auditLogSealWorker *AuditLogSealWorker,
+) Workers {
+	return Workers{
+		materialAuditWorker,
+		tuitionReminderWorker,
+		auditLogSealWorker,
+	}
+}
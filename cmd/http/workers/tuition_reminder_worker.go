@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"context"
+	"doan/internal/usecases/reminder"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+)
+
+// TuitionReminderWorker runs the tuition reminder job on "reminders.interval_minutes" while "reminders.enabled" is set
+type TuitionReminderWorker struct {
+	cfg                     config.Manager
+	log                     logger.Logger
+	sendDueRemindersUseCase reminder.SendDueRemindersUseCase
+}
+
+func NewTuitionReminderWorker(
+	cfg config.Manager,
+	log logger.Logger,
+	sendDueRemindersUseCase reminder.SendDueRemindersUseCase,
+) *TuitionReminderWorker {
+	return &TuitionReminderWorker{
+		cfg:                     cfg,
+		log:                     log,
+		sendDueRemindersUseCase: sendDueRemindersUseCase,
+	}
+}
+
+func (w *TuitionReminderWorker) Name() string {
+	return "tuition-reminder"
+}
+
+func (w *TuitionReminderWorker) Start(ctx context.Context) error {
+	if !w.cfg.GetBool("reminders.enabled") {
+		w.log.Info(ctx, "Tuition reminders disabled")
+		return nil
+	}
+
+	interval := time.Duration(w.cfg.GetInt("reminders.interval_minutes")) * time.Minute
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// Steps are per day and claims are unique, so running more often than daily only shortens delays
+			w.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *TuitionReminderWorker) run(ctx context.Context) {
+	if _, err := w.sendDueRemindersUseCase.Execute(ctx, reminder.SendDueRemindersInput{}); err != nil {
+		w.log.Error(ctx, "Tuition reminder run failed", "error", err)
+	}
+}
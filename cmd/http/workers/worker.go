@@ -0,0 +1,26 @@
+package workers
+
+import (
+	"context"
+	"doan/pkg/logger"
+)
+
+// Worker is a background process (queue consumer, scheduled job) started together with the HTTP server
+type Worker interface {
+	Name() string
+	Start(ctx context.Context) error
+}
+
+// Workers holds every background worker of the application
+type Workers []Worker
+
+// StartAll starts all workers; a worker failing to start is logged and does not stop the others
+func (w Workers) StartAll(ctx context.Context, log logger.Logger) {
+	for _, worker := range w {
+		if err := worker.Start(ctx); err != nil {
+			log.Error(ctx, "Failed to start worker", "worker", worker.Name(), "error", err)
+			continue
+		}
+		log.Info(ctx, "Worker started", "worker", worker.Name())
+	}
+}
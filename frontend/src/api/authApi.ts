@@ -73,6 +73,9 @@ export const authApi = baseApi.injectEndpoints({
                 body,
             }),
         }),
+        getPasswordPublicKey: builder.query({
+            query: () => '/v1/auth/public-key',
+        }),
         getMe: builder.query({
             query: () => '/v1/auth/me',
             providesTags: ['User']
@@ -89,5 +92,6 @@ export const {
     useResetPasswordMutation,
     useChangePasswordMutation,
     useGetMeQuery,
-    useLazyGetMeQuery
+    useLazyGetMeQuery,
+    useLazyGetPasswordPublicKeyQuery
 } = authApi;
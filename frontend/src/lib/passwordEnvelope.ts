@@ -0,0 +1,58 @@
+import { useCallback } from 'react';
+import { useLazyGetPasswordPublicKeyQuery } from '@/api/authApi';
+import type { PasswordPublicKey } from '@/types/auth';
+
+const ENVELOPE_ALG = 'RSA-OAEP-256+A256GCM';
+
+const toBase64 = (bytes: ArrayBuffer | Uint8Array) => {
+    const view = bytes instanceof Uint8Array ? bytes : new Uint8Array(bytes);
+    let binary = '';
+    view.forEach((b) => {
+        binary += String.fromCharCode(b);
+    });
+    return btoa(binary);
+};
+
+// encryptPassword builds the envelope the backend expects in password_enc and friends: the password is
+// encrypted with a fresh AES-256-GCM key bound to "<kid>.<ts>", and that key is wrapped with RSA-OAEP (SHA-256).
+export const encryptPassword = async (key: PasswordPublicKey, password: string): Promise<string> => {
+    const subtle = globalThis.crypto?.subtle;
+    if (!subtle) {
+        // WebCrypto only exists in secure contexts (HTTPS or localhost)
+        if (key.accept_plain) return password;
+        throw new Error('Trình duyệt không hỗ trợ mã hóa mật khẩu. Vui lòng truy cập qua HTTPS.');
+    }
+
+    const rsaKey = await subtle.importKey('jwk', key.jwk, { name: 'RSA-OAEP', hash: 'SHA-256' }, false, ['encrypt']);
+    const aesKey = await subtle.generateKey({ name: 'AES-GCM', length: 256 }, true, ['encrypt']);
+    const nonce = crypto.getRandomValues(new Uint8Array(12));
+    const ts = Date.now();
+
+    const ciphertext = await subtle.encrypt(
+        { name: 'AES-GCM', iv: nonce, additionalData: new TextEncoder().encode(`${key.kid}.${ts}`) },
+        aesKey,
+        new TextEncoder().encode(password),
+    );
+    const wrappedKey = await subtle.encrypt({ name: 'RSA-OAEP' }, rsaKey, await subtle.exportKey('raw', aesKey));
+
+    return JSON.stringify({
+        kid: key.kid,
+        alg: ENVELOPE_ALG,
+        ek: toBase64(wrappedKey),
+        nonce: toBase64(nonce),
+        ciphertext: toBase64(ciphertext),
+        ts,
+    });
+};
+
+// usePasswordEncryptor fetches the current public key on every call, so a rotated key is picked up, and
+// returns one envelope per password in the order given.
+export const usePasswordEncryptor = () => {
+    const [fetchPublicKey] = useLazyGetPasswordPublicKeyQuery();
+
+    return useCallback(async (...passwords: string[]) => {
+        const result = await fetchPublicKey(undefined).unwrap();
+        const key = result.data as PasswordPublicKey;
+        return Promise.all(passwords.map((password) => encryptPassword(key, password)));
+    }, [fetchPublicKey]);
+};
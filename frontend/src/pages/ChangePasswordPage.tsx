@@ -4,6 +4,7 @@ import { zodResolver } from '@hookform/resolvers/zod';
 import * as z from 'zod';
 import { useNavigate } from 'react-router-dom';
 import { useChangePasswordMutation } from '@/api/authApi';
+import { usePasswordEncryptor } from '@/lib/passwordEnvelope';
 import {
     Box,
     TextField,
@@ -34,6 +35,7 @@ type ChangePasswordFormValues = z.infer<typeof changePasswordSchema>;
 export const ChangePasswordPage = () => {
     const navigate = useNavigate();
     const [changePassword, { isLoading }] = useChangePasswordMutation();
+    const encryptPasswords = usePasswordEncryptor();
     const [showOldPass, setShowOldPass] = useState(false);
     const [showNewPass, setShowNewPass] = useState(false);
     const [showConfirmPass, setShowConfirmPass] = useState(false);
@@ -50,9 +52,10 @@ export const ChangePasswordPage = () => {
     const onSubmit = async (data: ChangePasswordFormValues) => {
         setErrorMsg(null);
         try {
+            const [oldPasswordEnc, newPasswordEnc] = await encryptPasswords(data.old_password, data.new_password);
             await changePassword({
-                old_password_enc: data.old_password,
-                new_password_enc: data.new_password
+                old_password_enc: oldPasswordEnc,
+                new_password_enc: newPasswordEnc
             }).unwrap();
             toast.success('Đổi mật khẩu thành công');
             navigate('/app/profile');
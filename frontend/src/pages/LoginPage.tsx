@@ -4,6 +4,7 @@ import { zodResolver } from '@hookform/resolvers/zod';
 import * as z from 'zod';
 import { useNavigate, useLocation } from 'react-router-dom';
 import { useLoginMutation } from '@/api/authApi';
+import { usePasswordEncryptor } from '@/lib/passwordEnvelope';
 import {
     Container,
     Box,
@@ -32,6 +33,7 @@ export const LoginPage = () => {
 
     // Replace Context with RTK Mutation
     const [loginMutation, { isLoading }] = useLoginMutation();
+    const encryptPasswords = usePasswordEncryptor();
 
     const [showPassword, setShowPassword] = useState(false);
     const [errorMsg, setErrorMsg] = useState<string | null>(null);
@@ -51,7 +53,8 @@ export const LoginPage = () => {
     const onSubmit = async (data: LoginFormValues) => {
         setErrorMsg(null);
         try {
-            const result = await loginMutation(data).unwrap();
+            const [passwordEnc] = await encryptPasswords(data.password);
+            const result = await loginMutation({ username: data.username, password_enc: passwordEnc }).unwrap();
 
             if (result.success) {
                 const from = location.state?.from?.pathname || '/app';
@@ -4,6 +4,7 @@ import { zodResolver } from '@hookform/resolvers/zod';
 import * as z from 'zod';
 import { Link, useNavigate } from 'react-router-dom';
 import { useRegisterMutation } from '@/api/authApi';
+import { usePasswordEncryptor } from '@/lib/passwordEnvelope';
 import {
     Container,
     Box,
@@ -35,6 +36,7 @@ type RegisterFormValues = z.infer<typeof registerSchema>;
 export const RegisterPage = () => {
     const navigate = useNavigate();
     const [registerUser, { isLoading: loading }] = useRegisterMutation();
+    const encryptPasswords = usePasswordEncryptor();
     const [showPassword, setShowPassword] = useState(false);
     const [showConfirmPassword, setShowConfirmPassword] = useState(false);
     const [errorMsg, setErrorMsg] = useState<string | null>(null);
@@ -50,10 +52,11 @@ export const RegisterPage = () => {
     const onSubmit = async (data: RegisterFormValues) => {
         setErrorMsg(null);
         try {
+            const [passwordEnc] = await encryptPasswords(data.password);
             await registerUser({
                 email: data.email,
                 full_name: data.full_name,
-                password_enc: data.password
+                password_enc: passwordEnc
             }).unwrap();
             toast.success('Đăng ký tài khoản thành công! Vui lòng đăng nhập.');
             navigate('/login');
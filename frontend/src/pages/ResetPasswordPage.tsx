@@ -4,6 +4,7 @@ import { zodResolver } from '@hookform/resolvers/zod';
 import * as z from 'zod';
 import { useNavigate, useSearchParams, Link } from 'react-router-dom';
 import { useResetPasswordMutation } from '@/api/authApi';
+import { usePasswordEncryptor } from '@/lib/passwordEnvelope';
 import {
     Container,
     Box,
@@ -35,6 +36,7 @@ export const ResetPasswordPage = () => {
     const token = searchParams.get('token');
     const navigate = useNavigate();
     const [resetPassword, { isLoading: loading }] = useResetPasswordMutation();
+    const encryptPasswords = usePasswordEncryptor();
     const [showPassword, setShowPassword] = useState(false);
     const [showConfirmPassword, setShowConfirmPassword] = useState(false);
     const [errorMsg, setErrorMsg] = useState<string | null>(null);
@@ -51,9 +53,10 @@ export const ResetPasswordPage = () => {
         if (!token) return;
         setErrorMsg(null);
         try {
+            const [newPasswordEnc] = await encryptPasswords(data.password);
             await resetPassword({
                 token: token,
-                new_password_enc: data.password
+                new_password_enc: newPasswordEnc
             }).unwrap();
             toast.success('Đặt lại mật khẩu thành công');
             navigate('/login');
@@ -26,3 +26,11 @@ export interface ApiError {
     code?: string;
     details?: unknown;
 }
+
+export interface PasswordPublicKey {
+    kid: string;
+    alg: string;
+    public_key: string;
+    jwk: JsonWebKey & { kid: string };
+    accept_plain: boolean;
+}
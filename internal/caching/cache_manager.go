@@ -1,7 +1,29 @@
 package caching
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss is returned by GetString when the key is absent or expired
+var ErrCacheMiss = errors.New("cache miss")
 
 type CacheManager interface {
 	GetString(ctx context.Context, key string) (string, error)
+	// SetString stores the value for ttl; a zero ttl keeps it until deleted or evicted
+	SetString(ctx context.Context, key, value string, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// DeletePattern deletes every key matching a glob pattern such as "rooms:list:*"
+	DeletePattern(ctx context.Context, pattern string) error
+	// Incr adds delta to the integer stored under key, starting from zero, and returns the new value.
+	// A positive ttl is applied when the key is created; an existing expiry is kept.
+	Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// StateStore keeps security state: revoked tokens and sessions, login lockouts, pending MFA logins and
+// one-time nonces. Entries only go away when they expire or are deleted, never to make room, so it must
+// not share an evicting cache with data that callers can fill, such as list pages.
+type StateStore interface {
+	CacheManager
 }
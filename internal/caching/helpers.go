@@ -0,0 +1,127 @@
+package caching
+
+import (
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// loads collapses concurrent GetOrLoad calls for the same key into a single loader call
+var loads singleflight.Group
+
+// GetJSON reads the value stored under key into a T; it returns ErrCacheMiss when the key is absent
+func GetJSON[T any](ctx context.Context, cache CacheManager, key string) (*T, error) {
+	raw, err := cache.GetString(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var value T
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// SetJSON stores value as JSON under key for ttl
+func SetJSON(ctx context.Context, cache CacheManager, key string, value interface{}, ttl time.Duration) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return cache.SetString(ctx, key, string(raw), ttl)
+}
+
+// loadTimeout bounds a shared load, which no longer ends when the caller that started it gives up
+const loadTimeout = 30 * time.Second
+
+// generation counts Invalidate calls. A load stores its result only when no invalidation ran since it
+// started, as the rows it read may predate the write. Holding the read lock from the check to the store
+// keeps Invalidate from slipping in between; Invalidate bumps it before deleting.
+var generation struct {
+	sync.RWMutex
+	n uint64
+}
+
+// GetOrLoad returns the value stored under key, or calls load and stores its result for ttl.
+// Concurrent callers missing the same key share one load, so an expired hot key does not send
+// a burst of identical queries to the database. The load runs on a context detached from the
+// caller's, bounded by loadTimeout, so one caller cancelling does not fail the others; a caller
+// whose context ends stops waiting. Cache failures are logged and fall through to load, so
+// callers keep working without a cache; a non-positive ttl bypasses the cache.
+func GetOrLoad[T any](ctx context.Context, cache CacheManager, key string, ttl time.Duration, load func(ctx context.Context) (*T, error)) (*T, error) {
+	if ttl <= 0 {
+		return load(ctx)
+	}
+	ctxLogger := logger.NewLogger(ctx)
+
+	value, err := GetJSON[T](ctx, cache, key)
+	switch {
+	case err == nil:
+		return value, nil
+	case !errors.Is(err, ErrCacheMiss):
+		ctxLogger.Warnf("Failed to read cache %s: %v", key, err)
+	}
+
+	generation.RLock()
+	startedAt := generation.n
+	generation.RUnlock()
+
+	// Callers arriving after an invalidation start a new load rather than join one that began before it
+	result := loads.DoChan(fmt.Sprintf("%s@%d", key, startedAt), func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		generation.RLock()
+		defer generation.RUnlock()
+		if generation.n != startedAt {
+			return value, nil
+		}
+		if err := SetJSON(loadCtx, cache, key, value, ttl); err != nil {
+			ctxLogger.Warnf("Failed to write cache %s: %v", key, err)
+		}
+		return value, nil
+	})
+
+	select {
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*T), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate deletes the keys matching pattern after a write, and keeps loads already running from
+// storing what they read before it. A failure is only logged: the write has already succeeded and the
+// stale entries expire with their ttl.
+func Invalidate(ctx context.Context, cache CacheManager, pattern string) {
+	generation.Lock()
+	generation.n++
+	generation.Unlock()
+
+	if err := cache.DeletePattern(ctx, pattern); err != nil {
+		logger.NewLogger(ctx).Warnf("Failed to invalidate cache %s: %v", pattern, err)
+	}
+}
+
+// ConfigTTL reads a number of seconds from key, defaulting to def when unset; zero or negative disables caching
+func ConfigTTL(configManager config.Manager, key string, def time.Duration) time.Duration {
+	if !configManager.IsSet(key) {
+		return def
+	}
+	return time.Duration(configManager.GetInt(key)) * time.Second
+}
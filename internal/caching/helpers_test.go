@@ -0,0 +1,122 @@
+package caching
+
+import (
+	"context"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryCache keeps values in a map; only the methods GetOrLoad and Invalidate use are implemented
+type memoryCache struct {
+	CacheManager
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string]string)}
+}
+
+func (c *memoryCache) GetString(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		return "", ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (c *memoryCache) SetString(ctx context.Context, key, value string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *memoryCache) DeletePattern(ctx context.Context, pattern string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.values {
+		if ok, _ := path.Match(pattern, key); ok {
+			delete(c.values, key)
+		}
+	}
+	return nil
+}
+
+func TestGetOrLoadDetachesTheLoad(t *testing.T) {
+	cache := newMemoryCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	started, release := make(chan struct{}), make(chan struct{})
+
+	loadErr := make(chan error, 1)
+	go func() {
+		_, err := GetOrLoad(ctx, cache, "detached", time.Minute, func(ctx context.Context) (*int, error) {
+			close(started)
+			<-release
+			value := 1
+			return &value, ctx.Err()
+		})
+		loadErr <- err
+	}()
+
+	<-started
+	cancel()
+	if err := <-loadErr; err != context.Canceled {
+		t.Errorf("cancelled caller err = %v, want context.Canceled", err)
+	}
+
+	// The load carries on for the callers still waiting and is stored once done
+	close(release)
+	value, err := GetOrLoad(context.Background(), cache, "detached", time.Minute, func(ctx context.Context) (*int, error) {
+		t.Error("second caller loaded again instead of sharing the running load")
+		return nil, nil
+	})
+	if err != nil || *value != 1 {
+		t.Fatalf("second caller = %v, %v; want 1", value, err)
+	}
+	if _, err := GetJSON[int](context.Background(), cache, "detached"); err != nil {
+		t.Errorf("result not stored: %v", err)
+	}
+}
+
+func TestGetOrLoadSkipsStoreAfterInvalidate(t *testing.T) {
+	cache := newMemoryCache()
+	ctx := context.Background()
+	started, release := make(chan struct{}), make(chan struct{})
+
+	staleDone := make(chan *int, 1)
+	go func() {
+		value, _ := GetOrLoad(ctx, cache, "rooms:list:1", time.Minute, func(ctx context.Context) (*int, error) {
+			close(started)
+			<-release
+			stale := 1
+			return &stale, nil
+		})
+		staleDone <- value
+	}()
+
+	// A write lands and invalidates while the first load is still reading
+	<-started
+	Invalidate(ctx, cache, "rooms:list:*")
+
+	// A caller arriving after the invalidation does not join the stale load
+	fresh, err := GetOrLoad(ctx, cache, "rooms:list:1", time.Minute, func(ctx context.Context) (*int, error) {
+		value := 2
+		return &value, nil
+	})
+	if err != nil || *fresh != 2 {
+		t.Fatalf("caller after Invalidate = %v, %v; want 2", fresh, err)
+	}
+
+	close(release)
+	if stale := <-staleDone; *stale != 1 {
+		t.Errorf("caller before Invalidate = %d, want its own load's 1", *stale)
+	}
+	if cached, err := GetJSON[int](ctx, cache, "rooms:list:1"); err != nil || *cached != 2 {
+		t.Errorf("cached = %v, %v; want 2, the stale load must not overwrite it", cached, err)
+	}
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// AccountInvitation is a one-time activation link for an account created by staff.
+// Only the SHA-256 of the token is stored, so a database leak does not expose usable links.
+type AccountInvitation struct {
+	ID          string     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID      string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	User        *User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	TokenHash   string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	RevokedAt   *time.Time `json:"revoked_at"` // superseded by a newer invitation
+	InvitedByID *string    `gorm:"type:uuid" json:"invited_by_id"`
+	CreatedAt   time.Time  `gorm:"default:now()" json:"created_at"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AI audit labels, ordered by severity
+const (
+	AILabelSafe    = "SAFE"
+	AILabelWarning = "WARNING"
+	AILabelDanger  = "DANGER"
+)
+
+// AnalysisReason is one finding cited by a content analyzer
+type AnalysisReason struct {
+	Code     string `json:"code"`
+	Label    string `json:"label"`
+	Message  string `json:"message"`
+	Excerpt  string `json:"excerpt,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+type AnalysisReasons []AnalysisReason
+
+func (r *AnalysisReasons) Scan(src interface{}) error {
+	return scanJSON(src, r)
+}
+
+func (r AnalysisReasons) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	return valueJSON(r)
+}
+
+// AIAnalysisResult stores one run of the AI audit pipeline for a material
+type AIAnalysisResult struct {
+	ID         string          `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	MaterialID string          `gorm:"type:uuid;not null;index" json:"material_id"`
+	Analyzer   string          `gorm:"type:varchar(50);not null" json:"analyzer"`
+	Model      string          `gorm:"type:varchar(100)" json:"model"`
+	Label      string          `gorm:"type:varchar(20);not null;index" json:"label"`
+	Score      float64         `json:"score"`
+	Summary    string          `gorm:"type:text" json:"summary"`
+	Reasons    AnalysisReasons `gorm:"type:jsonb" json:"reasons"`
+	TextLength int             `json:"text_length"`
+	DurationMs int64           `json:"duration_ms"`
+	CreatedAt  time.Time       `gorm:"default:now()" json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `gorm:"index" json:"-"`
+}
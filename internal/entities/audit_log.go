@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditActionMaterialApprove = "MATERIAL_APPROVE"
+	AuditActionMaterialReject  = "MATERIAL_REJECT"
+	AuditActionPaymentRecord   = "PAYMENT_RECORD"
+	AuditActionPaymentRefund   = "PAYMENT_REFUND"
+	AuditActionPaymentVoid     = "PAYMENT_VOID"
+	AuditActionPayrollFinalise = "PAYROLL_FINALISE"
+	AuditActionGuardianLink    = "GUARDIAN_LINK_ACCOUNT"
+	AuditActionRoleCreate      = "ROLE_CREATE"
+	AuditActionRoleUpdate      = "ROLE_UPDATE"
+	AuditActionRoleDelete      = "ROLE_DELETE"
+	AuditActionAccountUnlock   = "ACCOUNT_UNLOCK"
+	AuditActionMFAEnable       = "MFA_ENABLE"
+	AuditActionMFADisable      = "MFA_DISABLE"
+	AuditActionMFAReset        = "MFA_RESET"
+	AuditActionIdentityLink    = "IDENTITY_LINK"
+	AuditActionUserProvision   = "USER_PROVISION"
+	AuditActionUserCreate      = "USER_CREATE"
+	AuditActionUserRoleChange  = "USER_ROLE_CHANGE"
+	AuditActionUserActivate    = "USER_ACTIVATE"
+	AuditActionUserDeactivate  = "USER_DEACTIVATE"
+	AuditActionUserDelete      = "USER_DELETE"
+	AuditActionUserRestore     = "USER_RESTORE"
+	AuditActionPasswordReset   = "PASSWORD_RESET_FORCE"
+	AuditActionImpersonate     = "IMPERSONATION_START"
+	AuditActionImpersonated    = "IMPERSONATED_REQUEST" // a request made with an impersonation token
+
+	// Recorded by the audit trail for every row written through GORM
+	AuditActionCreate  = "CREATE"
+	AuditActionUpdate  = "UPDATE"
+	AuditActionDelete  = "DELETE"
+	AuditActionRestore = "RESTORE" // deleted_at cleared
+)
+
+// AuditActorSystem is the actor role of changes made outside a request, by jobs and migrations
+const AuditActorSystem = "SYSTEM"
+
+// Audit log entity types
+const (
+	AuditEntityMaterial   = "MATERIAL"
+	AuditEntityPayment    = "PAYMENT"
+	AuditEntityPayrollRun = "PAYROLL_RUN"
+	AuditEntityGuardian   = "GUARDIAN"
+	AuditEntityRole       = "ROLE"
+	AuditEntityUser       = "USER"
+)
+
+// AuditLog records who did what to which entity, for accountability of sensitive actions.
+// Entries are append-only: a trigger refuses updates and deletes, except the one that seals an entry
+// into the hash chain by setting Sequence, PrevHash and Hash.
+type AuditLog struct {
+	ID         string    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ActorID    *string   `gorm:"type:uuid;index" json:"actor_id"`
+	Actor      *User     `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	ActorRole  string    `gorm:"type:varchar(50)" json:"actor_role"`
+	Action     string    `gorm:"type:varchar(100);not null;index" json:"action"`
+	EntityType string    `gorm:"type:varchar(50);not null;index:idx_audit_logs_entity" json:"entity_type"`
+	EntityID   string    `gorm:"type:varchar(64);not null;index:idx_audit_logs_entity" json:"entity_id"`
+	Comment    string    `gorm:"type:text" json:"comment"`
+	Metadata   JSONMap   `gorm:"type:jsonb" json:"metadata"`
+	Changes    JSONMap   `gorm:"type:jsonb;not null;default:'{}'" json:"changes"` // column -> {"old", "new"}
+	IPAddress  string    `gorm:"type:varchar(45)" json:"ip_address"`
+	TraceID    string    `gorm:"type:varchar(100);index" json:"trace_id"`
+	Sequence   *int64    `gorm:"uniqueIndex" json:"sequence"` // position in the hash chain, nil until sealed
+	PrevHash   string    `gorm:"type:varchar(64)" json:"prev_hash"`
+	Hash       string    `gorm:"type:varchar(64)" json:"hash"`
+	CreatedAt  time.Time `gorm:"default:now();index" json:"created_at"`
+	// No UpdatedAt or DeletedAt: entries are never changed, and a soft delete would be an UPDATE the
+	// append-only trigger refuses. The nullable columns stay in the table for the shared BaseRepository
+	// queries, which filter on deleted_at.
+}
+
+// ChainHash returns the SHA-256 hex digest of the entry and the hash of the entry before it. It must be
+// computed on values read back from the database, as JSON numbers and timestamps are normalised there.
+func (l *AuditLog) ChainHash() string {
+	metadata, changes := l.Metadata, l.Changes
+	if metadata == nil {
+		metadata = JSONMap{}
+	}
+	if changes == nil {
+		changes = JSONMap{}
+	}
+	var sequence int64
+	if l.Sequence != nil {
+		sequence = *l.Sequence
+	}
+
+	// Struct fields keep their order and maps are encoded with sorted keys, so the encoding is canonical
+	payload, _ := json.Marshal(struct {
+		Sequence   int64   `json:"sequence"`
+		PrevHash   string  `json:"prev_hash"`
+		ActorID    *string `json:"actor_id"`
+		ActorRole  string  `json:"actor_role"`
+		Action     string  `json:"action"`
+		EntityType string  `json:"entity_type"`
+		EntityID   string  `json:"entity_id"`
+		Comment    string  `json:"comment"`
+		Metadata   JSONMap `json:"metadata"`
+		Changes    JSONMap `json:"changes"`
+		IPAddress  string  `json:"ip_address"`
+		TraceID    string  `json:"trace_id"`
+		CreatedAt  string  `json:"created_at"`
+	}{
+		Sequence:   sequence,
+		PrevHash:   l.PrevHash,
+		ActorID:    l.ActorID,
+		ActorRole:  l.ActorRole,
+		Action:     l.Action,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Comment:    l.Comment,
+		Metadata:   metadata,
+		Changes:    changes,
+		IPAddress:  l.IPAddress,
+		TraceID:    l.TraceID,
+		CreatedAt:  l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
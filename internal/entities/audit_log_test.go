@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func sampleAuditLog() *AuditLog {
+	actor := "5f0c7a2e-8d7b-4c4e-9a41-0a1b2c3d4e5f"
+	sequence := int64(7)
+	return &AuditLog{
+		ID:         "a1b2c3d4-0000-4000-8000-000000000001",
+		ActorID:    &actor,
+		ActorRole:  "ADMIN",
+		Action:     AuditActionUpdate,
+		EntityType: "ROOM",
+		EntityID:   "42",
+		Comment:    "capacity raised",
+		Metadata:   JSONMap{"source": "api"},
+		Changes:    JSONMap{"capacity": map[string]interface{}{"old": float64(20), "new": float64(30)}},
+		IPAddress:  "203.0.113.7",
+		TraceID:    "http-1234",
+		Sequence:   &sequence,
+		PrevHash:   "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0",
+		CreatedAt:  time.Date(2026, 10, 19, 8, 30, 0, 123456000, time.UTC),
+	}
+}
+
+func TestAuditLogChainHash(t *testing.T) {
+	base := sampleAuditLog().ChainHash()
+	if len(base) != 64 {
+		t.Fatalf("ChainHash length = %d, want 64 hex characters", len(base))
+	}
+	if again := sampleAuditLog().ChainHash(); again != base {
+		t.Fatalf("ChainHash is not deterministic: %s then %s", base, again)
+	}
+
+	// Fields outside the chain, and the same instant in another zone, keep the hash
+	same := []struct {
+		name   string
+		mutate func(l *AuditLog)
+	}{
+		{"id", func(l *AuditLog) { l.ID = "another" }},
+		{"stored hash", func(l *AuditLog) { l.Hash = "anything" }},
+		{"created at in another zone", func(l *AuditLog) { l.CreatedAt = l.CreatedAt.In(time.FixedZone("ICT", 7*3600)) }},
+	}
+	for _, tt := range same {
+		t.Run("keeps "+tt.name, func(t *testing.T) {
+			entry := sampleAuditLog()
+			tt.mutate(entry)
+			if got := entry.ChainHash(); got != base {
+				t.Errorf("ChainHash changed to %s", got)
+			}
+		})
+	}
+
+	// Every chained field changes the hash
+	changed := []struct {
+		name   string
+		mutate func(l *AuditLog)
+	}{
+		{"sequence", func(l *AuditLog) { s := *l.Sequence + 1; l.Sequence = &s }},
+		{"unsealed", func(l *AuditLog) { l.Sequence = nil }},
+		{"prev hash", func(l *AuditLog) { l.PrevHash = "" }},
+		{"actor", func(l *AuditLog) { l.ActorID = nil }},
+		{"actor role", func(l *AuditLog) { l.ActorRole = "TEACHER" }},
+		{"action", func(l *AuditLog) { l.Action = AuditActionDelete }},
+		{"entity type", func(l *AuditLog) { l.EntityType = "COURSE" }},
+		{"entity id", func(l *AuditLog) { l.EntityID = "43" }},
+		{"comment", func(l *AuditLog) { l.Comment = "" }},
+		{"metadata", func(l *AuditLog) { l.Metadata = JSONMap{"source": "cli"} }},
+		{"changes", func(l *AuditLog) {
+			l.Changes = JSONMap{"capacity": map[string]interface{}{"old": float64(20), "new": float64(31)}}
+		}},
+		{"ip address", func(l *AuditLog) { l.IPAddress = "198.51.100.1" }},
+		{"trace id", func(l *AuditLog) { l.TraceID = "http-5678" }},
+		{"created at", func(l *AuditLog) { l.CreatedAt = l.CreatedAt.Add(time.Microsecond) }},
+	}
+	for _, tt := range changed {
+		t.Run("changes with "+tt.name, func(t *testing.T) {
+			entry := sampleAuditLog()
+			tt.mutate(entry)
+			if got := entry.ChainHash(); got == base {
+				t.Errorf("ChainHash did not change")
+			}
+		})
+	}
+}
+
+func TestAuditLogChainHashEmptyMaps(t *testing.T) {
+	withNil := sampleAuditLog()
+	withNil.Metadata, withNil.Changes = nil, nil
+	withEmpty := sampleAuditLog()
+	withEmpty.Metadata, withEmpty.Changes = JSONMap{}, JSONMap{}
+
+	if withNil.ChainHash() != withEmpty.ChainHash() {
+		t.Error("nil and empty maps hash differently, but both are stored as {}")
+	}
+}
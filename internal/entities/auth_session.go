@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+// Reasons a login session was revoked
+const (
+	SessionRevokedLogout  = "LOGOUT"
+	SessionRevokedByUser  = "USER_REVOKED"
+	SessionRevokedReuse   = "REFRESH_REUSE" // a rotated refresh token was presented again
+	SessionRevokedByAdmin = "ADMIN_REVOKED" // the account was deactivated, deleted or forced to reset its password
+	SessionRevokedRole    = "ROLE_CHANGED"  // the permissions of the user's role changed
+)
+
+// AuthSession is one login on one device; its refresh tokens form a rotation family
+type AuthSession struct {
+	ID            string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID        string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	User          *User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	UserAgent     string         `gorm:"type:varchar(512)" json:"user_agent"`
+	IPAddress     string         `gorm:"type:varchar(64)" json:"ip_address"`
+	ExpiresAt     time.Time      `gorm:"not null" json:"expires_at"` // expiry of the newest refresh token
+	LastUsedAt    time.Time      `gorm:"not null" json:"last_used_at"`
+	RevokedAt     *time.Time     `json:"revoked_at"`
+	RevokedReason string         `gorm:"type:varchar(30)" json:"revoked_reason"`
+	RefreshTokens []RefreshToken `gorm:"foreignKey:SessionID" json:"-"`
+	CreatedAt     time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// RefreshToken is one issued refresh token; only its SHA-256 is stored and it is usable once
+type RefreshToken struct {
+	ID        string     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	SessionID string     `gorm:"type:uuid;not null;index" json:"session_id"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"` // set when rotated; presenting it again revokes the session
+	CreatedAt time.Time  `gorm:"default:now()" json:"created_at"`
+}
@@ -1,11 +1,19 @@
 package entities
 
 import (
+	"doan/pkg/money"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Class statuses
+const (
+	ClassStatusOpen      = "OPEN"
+	ClassStatusClosed    = "CLOSED"
+	ClassStatusCancelled = "CANCELLED"
+)
+
 type Class struct {
 	ID          string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	Code        string         `gorm:"type:varchar(50);unique;not null" json:"code"`
@@ -15,7 +23,7 @@ type Class struct {
 	EndDate     *time.Time     `json:"end_date"`
 	MaxStudents int            `json:"max_students"`
 	Status      string         `gorm:"type:varchar(50);default:'OPEN'" json:"status"`
-	Price       float64        `gorm:"type:numeric(10,2)" json:"price"`
+	Price       money.Amount   `gorm:"type:bigint;default:0" json:"price"`
 	ProgramID   *string        `json:"program_id"`
 	Program     Program        `gorm:"foreignKey:ProgramID" json:"program"`
 	CourseID    *string        `json:"course_id"`
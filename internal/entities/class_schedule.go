@@ -1,5 +1,16 @@
 package entities
 
+// Days of week accepted in ClassSchedule.DayOfWeek
+const (
+	DayMonday    = "MONDAY"
+	DayTuesday   = "TUESDAY"
+	DayWednesday = "WEDNESDAY"
+	DayThursday  = "THURSDAY"
+	DayFriday    = "FRIDAY"
+	DaySaturday  = "SATURDAY"
+	DaySunday    = "SUNDAY"
+)
+
 type ClassSchedule struct {
 	ID        string  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	ClassID   string  `gorm:"not null" json:"class_id"`
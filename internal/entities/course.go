@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"doan/pkg/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,7 +17,7 @@ type Course struct {
 	SessionCount           int            `json:"session_count"`
 	SessionDurationMinutes int            `json:"session_duration_minutes"`
 	TotalHours             float64        `gorm:"type:numeric(8,2)" json:"total_hours"`
-	Price                  float64        `gorm:"type:numeric(10,2)" json:"price"`
+	Price                  money.Amount   `gorm:"type:bigint;default:0" json:"price"`
 	Status                 string         `gorm:"type:varchar(50);default:'ACTIVE'" json:"status"`
 	CreatedAt              time.Time      `gorm:"default:now()" json:"created_at"`
 	UpdatedAt              time.Time      `json:"updated_at"`
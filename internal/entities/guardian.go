@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Guardian relationships to a student
+const (
+	RelationshipFather   = "FATHER"
+	RelationshipMother   = "MOTHER"
+	RelationshipGuardian = "GUARDIAN" // any other legal guardian
+)
+
+// Guardian is a parent or guardian; with a linked GUARDIAN user they can follow their children
+type Guardian struct {
+	ID        string            `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID    *string           `gorm:"type:uuid;index" json:"user_id"`
+	User      *User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	FullName  string            `gorm:"type:varchar(255)" json:"full_name"`
+	Phone     string            `gorm:"type:varchar(20);index" json:"phone"`
+	Email     string            `gorm:"type:varchar(255)" json:"email"`
+	Notes     string            `gorm:"type:text" json:"notes"`
+	Students  []GuardianStudent `gorm:"foreignKey:GuardianID" json:"students,omitempty"`
+	CreatedAt time.Time         `gorm:"default:now()" json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// GuardianStudent links a guardian to one of their children
+type GuardianStudent struct {
+	GuardianID   string    `gorm:"type:uuid;primaryKey" json:"guardian_id"`
+	StudentID    string    `gorm:"type:uuid;primaryKey;index" json:"student_id"`
+	Student      *Student  `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Relationship string    `gorm:"type:varchar(20);not null;default:'GUARDIAN'" json:"relationship"`
+	IsPrimary    bool      `gorm:"not null;default:false" json:"is_primary"` // main contact of the student
+	CreatedAt    time.Time `gorm:"default:now()" json:"created_at"`
+}
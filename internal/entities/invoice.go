@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"doan/pkg/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Invoice statuses
+const (
+	InvoiceUnpaid        = "UNPAID"
+	InvoicePartiallyPaid = "PARTIALLY_PAID"
+	InvoicePaid          = "PAID"
+	InvoiceVoid          = "VOID"
+)
+
+// Billing plans chosen when an enrollment is approved
+const (
+	BillingPlanFull    = "FULL"
+	BillingPlanMonthly = "MONTHLY"
+)
+
+// Invoice line kinds
+const (
+	InvoiceLineTuition  = "TUITION"
+	InvoiceLineDiscount = "DISCOUNT"
+)
+
+// Invoice is one tuition bill of an enrollment; a monthly plan issues one invoice per installment
+type Invoice struct {
+	ID               string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Number           string         `gorm:"type:varchar(30);uniqueIndex;not null" json:"number"`
+	EnrollmentID     string         `gorm:"type:uuid;not null;index" json:"enrollment_id"`
+	Enrollment       *Enrollment    `gorm:"foreignKey:EnrollmentID" json:"-"`
+	StudentID        string         `gorm:"type:uuid;not null;index" json:"student_id"`
+	Student          *Student       `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	ClassID          string         `gorm:"type:uuid;not null;index" json:"class_id"`
+	Class            *Class         `gorm:"foreignKey:ClassID" json:"class,omitempty"`
+	BillingPlan      string         `gorm:"type:varchar(20);not null" json:"billing_plan"`
+	InstallmentNo    int            `gorm:"not null;default:1" json:"installment_no"`
+	InstallmentCount int            `gorm:"not null;default:1" json:"installment_count"`
+	Currency         string         `gorm:"type:varchar(3);not null;default:'VND'" json:"currency"`
+	Subtotal         money.Amount   `gorm:"type:bigint;not null;default:0" json:"subtotal"`
+	DiscountTotal    money.Amount   `gorm:"type:bigint;not null;default:0" json:"discount_total"`
+	Total            money.Amount   `gorm:"type:bigint;not null;default:0" json:"total"`
+	PaidAmount       money.Amount   `gorm:"type:bigint;not null;default:0" json:"paid_amount"`
+	Status           string         `gorm:"type:varchar(20);not null;default:'UNPAID';index" json:"status"`
+	IssuedAt         time.Time      `gorm:"not null" json:"issued_at"`
+	DueDate          time.Time      `gorm:"type:date;not null;index" json:"due_date"`
+	Notes            string         `gorm:"type:text" json:"notes"`
+	Lines            []InvoiceLine  `gorm:"foreignKey:InvoiceID" json:"lines,omitempty"`
+	CreatedAt        time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// Balance is what is left to pay; void invoices owe nothing
+func (i *Invoice) Balance() money.Amount {
+	if i.Status == InvoiceVoid {
+		return money.Zero
+	}
+	return i.Total.Sub(i.PaidAmount)
+}
+
+// InvoiceStatusFor derives the payment status from the amount paid so far
+func InvoiceStatusFor(total, paid money.Amount) string {
+	switch {
+	case paid >= total:
+		return InvoicePaid
+	case paid.IsPositive():
+		return InvoicePartiallyPaid
+	default:
+		return InvoiceUnpaid
+	}
+}
+
+// InvoiceLine is a tuition charge or a discount (negative amount) on an invoice
+type InvoiceLine struct {
+	ID          string       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	InvoiceID   string       `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	Kind        string       `gorm:"type:varchar(20);not null" json:"kind"`
+	Code        string       `gorm:"type:varchar(50)" json:"code"`
+	Description string       `gorm:"type:varchar(255);not null" json:"description"`
+	Quantity    int          `gorm:"not null;default:1" json:"quantity"`
+	UnitPrice   money.Amount `gorm:"type:bigint;not null;default:0" json:"unit_price"`
+	Amount      money.Amount `gorm:"type:bigint;not null;default:0" json:"amount"`
+	CreatedAt   time.Time    `gorm:"default:now()" json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
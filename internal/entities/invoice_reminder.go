@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Invoice reminder statuses
+const (
+	ReminderPending = "PENDING" // claimed by a job run, being sent
+	ReminderSent    = "SENT"
+	ReminderFailed  = "FAILED" // retried by later runs up to the attempt limit
+)
+
+// Reminder channels
+const (
+	ReminderChannelEmail = "EMAIL"
+)
+
+// InvoiceReminder records one dunning step for one invoice. The (invoice, step) pair is unique,
+// which is what keeps a reminder from being sent twice even when several job runs overlap.
+type InvoiceReminder struct {
+	ID        string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	InvoiceID string         `gorm:"type:uuid;not null;uniqueIndex:idx_invoice_reminders_step" json:"invoice_id"`
+	Invoice   *Invoice       `gorm:"foreignKey:InvoiceID" json:"invoice,omitempty"`
+	StepCode  string         `gorm:"type:varchar(50);not null;uniqueIndex:idx_invoice_reminders_step" json:"step_code"`
+	Channel   string         `gorm:"type:varchar(20);not null;default:'EMAIL'" json:"channel"`
+	Recipient string         `gorm:"type:varchar(255)" json:"recipient"`
+	Status    string         `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempts  int            `gorm:"not null;default:0" json:"attempts"`
+	LastError string         `gorm:"type:text" json:"last_error"`
+	SentAt    *time.Time     `json:"sent_at"`
+	CreatedAt time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ReminderOptOut stops tuition reminders to every student sharing a guardian phone
+type ReminderOptOut struct {
+	ID            string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	GuardianPhone string         `gorm:"type:varchar(20);not null;index" json:"guardian_phone"`
+	Reason        string         `gorm:"type:text" json:"reason"`
+	CreatedByID   *string        `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt     time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// scanJSON decodes a jsonb column value into dst
+func scanJSON(src interface{}, dst interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if len(v) == 0 {
+			return nil
+		}
+		return json.Unmarshal(v, dst)
+	case string:
+		if v == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(v), dst)
+	default:
+		return fmt.Errorf("unsupported jsonb source type %T", src)
+	}
+}
+
+// valueJSON encodes v for a jsonb column
+func valueJSON(v interface{}) (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// JSONMap is a free-form jsonb object
+type JSONMap map[string]interface{}
+
+func (m *JSONMap) Scan(src interface{}) error {
+	return scanJSON(src, m)
+}
+
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	return valueJSON(m)
+}
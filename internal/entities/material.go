@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Material audit statuses
+const (
+	MaterialAuditPending    = "PENDING"
+	MaterialAuditQueued     = "QUEUED"
+	MaterialAuditProcessing = "PROCESSING"
+	MaterialAuditCompleted  = "COMPLETED"
+	MaterialAuditFailed     = "FAILED"
+)
+
+// Material compliance review statuses
+const (
+	MaterialReviewPending  = "PENDING"
+	MaterialReviewApproved = "APPROVED"
+	MaterialReviewRejected = "REJECTED"
+)
+
+// Material is a teaching material (lecture notes, worksheets, slides, ...) uploaded by a teacher
+type Material struct {
+	ID            string     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Title         string     `gorm:"type:varchar(255);not null" json:"title"`
+	Description   string     `gorm:"type:text" json:"description"`
+	CourseID      *string    `gorm:"type:uuid;index" json:"course_id"`
+	Course        *Course    `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+	ClassID       *string    `gorm:"type:uuid;index" json:"class_id"`
+	Class         *Class     `gorm:"foreignKey:ClassID" json:"class,omitempty"`
+	UploadedByID  string     `gorm:"type:uuid;not null;index" json:"uploaded_by_id"`
+	UploadedBy    *User      `gorm:"foreignKey:UploadedByID" json:"uploaded_by,omitempty"`
+	FileName      string     `gorm:"type:varchar(255);not null" json:"file_name"`
+	MimeType      string     `gorm:"type:varchar(255);not null" json:"mime_type"`
+	Extension     string     `gorm:"type:varchar(20)" json:"extension"`
+	FileSize      int64      `gorm:"not null" json:"file_size"`
+	Checksum      string     `gorm:"type:varchar(64);not null;index" json:"checksum"`
+	StorageDriver string     `gorm:"type:varchar(20);not null" json:"-"`
+	StorageKey    string     `gorm:"type:text;not null" json:"-"`
+	AuditStatus   string     `gorm:"type:varchar(20);default:'PENDING';index" json:"audit_status"`
+	AILabel       *string    `gorm:"type:varchar(20);index" json:"ai_label"`
+	AuditedAt     *time.Time `json:"audited_at"`
+	AuditError    string     `gorm:"type:text" json:"audit_error"`
+	// Plain text extracted for the audit, normalised to NFC
+	ExtractedText    string     `gorm:"type:text" json:"-"`
+	ExtractedPages   int        `gorm:"default:0" json:"extracted_pages"`
+	ExtractionErrors PageErrors `gorm:"type:jsonb" json:"extraction_errors"`
+	ExtractedAt      *time.Time `json:"extracted_at"`
+	// Compliance officer decision taken after the AI audit
+	ReviewStatus  string         `gorm:"type:varchar(20);default:'PENDING';index" json:"review_status"`
+	ReviewComment string         `gorm:"type:text" json:"review_comment"`
+	ReviewedByID  *string        `gorm:"type:uuid" json:"reviewed_by_id"`
+	ReviewedBy    *User          `gorm:"foreignKey:ReviewedByID" json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time     `json:"reviewed_at"`
+	CreatedAt     time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// PageError is a text extraction failure on one page (PDF page, slide, sheet) of a material.
+// Page 0 means the whole document.
+type PageError struct {
+	Page    int    `json:"page"`
+	Message string `json:"message"`
+}
+
+type PageErrors []PageError
+
+func (p *PageErrors) Scan(src interface{}) error {
+	return scanJSON(src, p)
+}
+
+func (p PageErrors) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	return valueJSON(p)
+}
@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// PasswordHistory is a password a user replaced, kept so the password policy can refuse its reuse
+type PasswordHistory struct {
+	ID           string    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	PasswordHash string    `gorm:"type:text;not null" json:"-"` // hashed with the PasswordHasher
+	CreatedAt    time.Time `gorm:"default:now()" json:"created_at"`
+}
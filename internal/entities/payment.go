@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"doan/pkg/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment kinds
+const (
+	PaymentKindPayment = "PAYMENT"
+	PaymentKindRefund  = "REFUND"
+)
+
+// Payment methods
+const (
+	PaymentMethodCash     = "CASH"
+	PaymentMethodTransfer = "BANK_TRANSFER"
+	PaymentMethodVietQR   = "VIETQR"
+)
+
+// Payment statuses
+const (
+	PaymentCompleted = "COMPLETED"
+	PaymentVoided    = "VOIDED"
+)
+
+// Payment is money received from (or refunded to) a payer, allocated across invoices.
+// Amount is always positive; the allocations of a refund are negative.
+type Payment struct {
+	ID            string              `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReceiptNumber string              `gorm:"type:varchar(30);uniqueIndex;not null" json:"receipt_number"`
+	Kind          string              `gorm:"type:varchar(20);not null;default:'PAYMENT'" json:"kind"`
+	Method        string              `gorm:"type:varchar(20);not null" json:"method"`
+	Status        string              `gorm:"type:varchar(20);not null;default:'COMPLETED';index" json:"status"`
+	Amount        money.Amount        `gorm:"type:bigint;not null" json:"amount"`
+	Currency      string              `gorm:"type:varchar(3);not null;default:'VND'" json:"currency"`
+	Provider      string              `gorm:"type:varchar(50)" json:"provider"`
+	Reference     string              `gorm:"type:varchar(100)" json:"reference"`
+	PayerName     string              `gorm:"type:varchar(255)" json:"payer_name"`
+	CashierID     *string             `gorm:"type:uuid;index" json:"cashier_id"`
+	Cashier       *User               `gorm:"foreignKey:CashierID" json:"cashier,omitempty"`
+	ReceivedAt    time.Time           `gorm:"not null;index" json:"received_at"`
+	RefundOfID    *string             `gorm:"type:uuid;index" json:"refund_of_id"`
+	VoidedAt      *time.Time          `json:"voided_at"`
+	VoidedByID    *string             `gorm:"type:uuid" json:"voided_by_id"`
+	VoidReason    string              `gorm:"type:text" json:"void_reason"`
+	Notes         string              `gorm:"type:text" json:"notes"`
+	Allocations   []PaymentAllocation `gorm:"foreignKey:PaymentID" json:"allocations,omitempty"`
+	CreatedAt     time.Time           `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt      `gorm:"index" json:"deleted_at"`
+}
+
+// PaymentAllocation is the part of a payment applied to one invoice
+type PaymentAllocation struct {
+	ID        string       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PaymentID string       `gorm:"type:uuid;not null;index" json:"payment_id"`
+	InvoiceID string       `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	Invoice   *Invoice     `gorm:"foreignKey:InvoiceID" json:"invoice,omitempty"`
+	Amount    money.Amount `gorm:"type:bigint;not null" json:"amount"`
+	CreatedAt time.Time    `gorm:"default:now()" json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"doan/pkg/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rate card types
+const (
+	RateTypePerHour    = "PER_HOUR"
+	RateTypePerSession = "PER_SESSION"
+	RateTypePerClass   = "PER_CLASS" // per class per month, prorated by the share of the class's lessons taught
+)
+
+// Payroll run statuses
+const (
+	PayrollDraft     = "DRAFT"     // can be recalculated or deleted
+	PayrollFinalised = "FINALISED" // figures are locked
+)
+
+// TeacherRateCard is what a teacher is paid, for all courses (CourseID nil) or as an override for one course
+type TeacherRateCard struct {
+	ID            string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	TeacherID     string         `gorm:"type:uuid;not null;index" json:"teacher_id"`
+	Teacher       *Teacher       `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	CourseID      *string        `gorm:"type:uuid;index" json:"course_id"`
+	Course        *Course        `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+	RateType      string         `gorm:"type:varchar(20);not null" json:"rate_type"`
+	Amount        money.Amount   `gorm:"type:bigint;not null" json:"amount"`
+	EffectiveFrom time.Time      `gorm:"type:date;not null" json:"effective_from"`
+	EffectiveTo   *time.Time     `gorm:"type:date" json:"effective_to"` // inclusive, nil while current
+	Notes         string         `gorm:"type:text" json:"notes"`
+	CreatedByID   *string        `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt     time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// AppliesOn reports whether the card is in effect on the given day
+func (c *TeacherRateCard) AppliesOn(day time.Time) bool {
+	if day.Before(c.EffectiveFrom) {
+		return false
+	}
+	return c.EffectiveTo == nil || !day.After(*c.EffectiveTo)
+}
+
+// PayrollRun is the teacher payroll of one month
+type PayrollRun struct {
+	ID            string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Period        string         `gorm:"type:varchar(7);not null;index" json:"period"` // YYYY-MM
+	Status        string         `gorm:"type:varchar(20);not null;default:'DRAFT'" json:"status"`
+	PeriodStart   time.Time      `gorm:"not null" json:"period_start"`
+	PeriodEnd     time.Time      `gorm:"not null" json:"period_end"` // exclusive
+	TeacherCount  int            `gorm:"not null;default:0" json:"teacher_count"`
+	LessonCount   int            `gorm:"not null;default:0" json:"lesson_count"`
+	UnratedCount  int            `gorm:"not null;default:0" json:"unrated_count"`
+	TotalAmount   money.Amount   `gorm:"type:bigint;not null;default:0" json:"total_amount"`
+	CalculatedAt  time.Time      `json:"calculated_at"`
+	CreatedByID   *string        `gorm:"type:uuid" json:"created_by_id"`
+	FinalisedAt   *time.Time     `json:"finalised_at"`
+	FinalisedByID *string        `gorm:"type:uuid" json:"finalised_by_id"`
+	Notes         string         `gorm:"type:text" json:"notes"`
+	Items         []PayrollItem  `gorm:"foreignKey:RunID" json:"items,omitempty"`
+	CreatedAt     time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PayrollItem is the pay of one teacher in a run
+type PayrollItem struct {
+	ID                   string        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	RunID                string        `gorm:"type:uuid;not null;index" json:"run_id"`
+	TeacherID            string        `gorm:"type:uuid;not null;index" json:"teacher_id"`
+	Teacher              *Teacher      `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Sessions             int           `gorm:"not null;default:0" json:"sessions"`
+	Minutes              int           `gorm:"not null;default:0" json:"minutes"`
+	SubstitutionSessions int           `gorm:"not null;default:0" json:"substitution_sessions"`
+	UnratedSessions      int           `gorm:"not null;default:0" json:"unrated_sessions"`
+	Amount               money.Amount  `gorm:"type:bigint;not null;default:0" json:"amount"`
+	Lines                []PayrollLine `gorm:"foreignKey:ItemID" json:"lines,omitempty"`
+	CreatedAt            time.Time     `gorm:"default:now()" json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+// PayrollLine is one lesson taught, with the rate snapshotted at calculation time
+type PayrollLine struct {
+	ID             string       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	RunID          string       `gorm:"type:uuid;not null;index" json:"run_id"`
+	ItemID         string       `gorm:"type:uuid;not null;index" json:"item_id"`
+	LessonID       string       `gorm:"type:uuid;not null" json:"lesson_id"`
+	ClassID        string       `gorm:"type:uuid;not null" json:"class_id"`
+	ClassCode      string       `gorm:"type:varchar(50)" json:"class_code"`
+	ClassName      string       `gorm:"type:varchar(255)" json:"class_name"`
+	CourseID       *string      `gorm:"type:uuid" json:"course_id"`
+	DateStart      time.Time    `json:"date_start"`
+	Minutes        int          `gorm:"not null" json:"minutes"`
+	IsSubstitution bool         `gorm:"not null;default:false" json:"is_substitution"`
+	RateCardID     *string      `gorm:"type:uuid" json:"rate_card_id"`
+	RateType       string       `gorm:"type:varchar(20)" json:"rate_type"` // empty when no rate card applies
+	Rate           money.Amount `gorm:"type:bigint;not null;default:0" json:"rate"`
+	Amount         money.Amount `gorm:"type:bigint;not null;default:0" json:"amount"`
+	CreatedAt      time.Time    `gorm:"default:now()" json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Role is a named bundle of permissions; users reference it by name through users.role
+type Role struct {
+	ID          string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Name        string         `gorm:"type:varchar(50);uniqueIndex;not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Permissions pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"permissions"`
+	IsSystem    bool           `gorm:"not null;default:false" json:"is_system"` // built-in roles cannot be deleted
+	CreatedAt   time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
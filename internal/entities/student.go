@@ -7,12 +7,15 @@ import (
 
 type Student struct {
 	ID            string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID        *string        `gorm:"type:uuid;index" json:"user_id"` // login account, set by the invite flow
 	Code          string         `gorm:"type:varchar(50);unique" json:"code"`
 	FullName      string         `gorm:"type:varchar(255)" json:"full_name"`
 	Email         string         `gorm:"type:varchar(255)" json:"email"`
 	Phone         string         `gorm:"type:varchar(20)" json:"phone"`
 	GuardianPhone string         `gorm:"type:varchar(20)" json:"guardian_phone"`
+	GuardianEmail string         `gorm:"type:varchar(255)" json:"guardian_email"`
 	GradeLevel    string         `gorm:"type:varchar(50)" json:"grade_level"`
+	SchoolName    string         `gorm:"type:varchar(255)" json:"school_name"`
 	Status        string         `gorm:"type:varchar(50);default:'ACTIVE'" json:"status"`
 	DateOfBirth   *time.Time     `json:"date_of_birth"`
 	Gender        string         `gorm:"type:varchar(20)" json:"gender"`
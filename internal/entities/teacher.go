@@ -7,6 +7,7 @@ import (
 
 type Teacher struct {
 	ID              string         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID          *string        `gorm:"type:uuid;index" json:"user_id"` // login account, set by the invite flow
 	Code            string         `gorm:"type:varchar(50);unique" json:"code"`
 	FullName        string         `gorm:"type:varchar(255)" json:"full_name"`
 	Email           string         `gorm:"type:varchar(255)" json:"email"`
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// UserIdentity links a user to their account at an external OpenID Connect provider, such as Google.
+// Subject is the provider's stable ID of the account; the email may change there.
+type UserIdentity struct {
+	ID          string     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID      string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Provider    string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_provider_subject" json:"provider"`
+	Subject     string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject" json:"subject"`
+	Email       string     `gorm:"type:varchar(255)" json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
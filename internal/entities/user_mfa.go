@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// UserMFA is the TOTP authenticator of a user. It is pending until the first code is confirmed.
+type UserMFA struct {
+	ID           string     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       string     `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Secret       string     `gorm:"type:varchar(64);not null" json:"-"` // base32 TOTP secret
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"` // time step of the last accepted code, never accepted again
+	CreatedAt    time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Enabled reports whether enrolment was confirmed
+func (m *UserMFA) Enabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+// UserRecoveryCode is a one-time code that stands in for a TOTP code when the authenticator is lost
+type UserRecoveryCode struct {
+	ID        string     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:text;not null" json:"-"` // hashed with the PasswordHasher
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"default:now()" json:"created_at"`
+}
@@ -0,0 +1,188 @@
+package caching
+
+import (
+	"container/list"
+	"context"
+	"doan/internal/caching"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryConfig is the "cache.memory" config block
+type MemoryConfig struct {
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+const defaultMaxEntries = 10000
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time // zero: no expiry
+}
+
+// memoryCacheManager is an LRU cache kept in process; each instance of the API has its own copy.
+// It suits tests and single-node setups; use the redis driver once several instances share state.
+// Without maxEntries it is the unbounded store behind NewMemoryStateStore.
+type memoryCacheManager struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List // front: most recently used
+	maxEntries int        // zero: unbounded, never evicts
+	nextSweep  int        // size at which an unbounded store drops its expired entries
+	now        func() time.Time
+}
+
+// NewMemoryCacheManager creates an in-process LRU cache bounded to MaxEntries keys
+func NewMemoryCacheManager(config MemoryConfig) caching.CacheManager {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaultMaxEntries
+	}
+	return &memoryCacheManager{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: config.MaxEntries,
+		now:        time.Now,
+	}
+}
+
+// NewMemoryStateStore creates an in-process state store. It never evicts live entries; expired ones
+// are swept whenever the store has doubled in size since the last sweep.
+func NewMemoryStateStore() caching.StateStore {
+	return &memoryCacheManager{
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+		nextSweep: defaultMaxEntries,
+		now:       time.Now,
+	}
+}
+
+func (m *memoryCacheManager) GetString(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.lookup(key)
+	if !ok {
+		return "", caching.ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (m *memoryCacheManager) SetString(ctx context.Context, key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.store(key, value, m.expiry(ttl))
+	return nil
+}
+
+func (m *memoryCacheManager) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := m.entries[key]; ok {
+			m.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (m *memoryCacheManager) DeletePattern(ctx context.Context, pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid cache key pattern %q: %w", pattern, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, elem := range m.entries {
+		if matched, _ := path.Match(pattern, key); matched {
+			m.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (m *memoryCacheManager) Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := int64(0)
+	expiresAt := m.expiry(ttl)
+	if entry, ok := m.lookup(key); ok {
+		parsed, err := strconv.ParseInt(entry.value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cache value of %s is not an integer", key)
+		}
+		current = parsed
+		expiresAt = entry.expiresAt
+	}
+	current += delta
+	m.store(key, strconv.FormatInt(current, 10), expiresAt)
+	return current, nil
+}
+
+// lookup returns the live entry of key and marks it as recently used, dropping it when expired
+func (m *memoryCacheManager) lookup(key string) (*memoryEntry, bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt) {
+		m.remove(elem)
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return entry, true
+}
+
+// store writes key, evicting the least recently used entry when a bounded cache is full
+func (m *memoryCacheManager) store(key, value string, expiresAt time.Time) {
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(elem)
+		return
+	}
+	if m.maxEntries > 0 {
+		for len(m.entries) >= m.maxEntries {
+			m.remove(m.order.Back())
+		}
+	} else if len(m.entries) >= m.nextSweep {
+		m.sweepExpired()
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+}
+
+// sweepExpired drops every expired entry and sets the size of the next sweep
+func (m *memoryCacheManager) sweepExpired() {
+	now := m.now()
+	for _, elem := range m.entries {
+		entry := elem.Value.(*memoryEntry)
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			m.remove(elem)
+		}
+	}
+	m.nextSweep = 2 * len(m.entries)
+	if m.nextSweep < defaultMaxEntries {
+		m.nextSweep = defaultMaxEntries
+	}
+}
+
+func (m *memoryCacheManager) remove(elem *list.Element) {
+	m.order.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryEntry).key)
+}
+
+func (m *memoryCacheManager) expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return m.now().Add(ttl)
+}
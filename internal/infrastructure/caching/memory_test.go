@@ -0,0 +1,74 @@
+package caching
+
+import (
+	"context"
+	"doan/internal/caching"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryEviction(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		store     caching.CacheManager
+		wantFirst bool
+	}{
+		{"cache evicts the least recently used key", NewMemoryCacheManager(MemoryConfig{MaxEntries: 3}), false},
+		{"state store keeps every key", NewMemoryStateStore(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.store.SetString(ctx, "denylist:first", "1", time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 3*defaultMaxEntries; i++ {
+				if err := tt.store.SetString(ctx, fmt.Sprintf("rooms:list:%d", i), "[]", time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err := tt.store.GetString(ctx, "denylist:first")
+			if got := err == nil; got != tt.wantFirst {
+				t.Errorf("first key present = %v, want %v (err %v)", got, tt.wantFirst, err)
+			}
+		})
+	}
+}
+
+func TestMemoryStateStoreSweepsExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryStateStore().(*memoryCacheManager)
+	store.now = func() time.Time { return now }
+
+	// Fill the store up to its first sweep
+	for i := 0; i < defaultMaxEntries-1; i++ {
+		if err := store.SetString(ctx, fmt.Sprintf("throttle:%d", i), "1", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.SetString(ctx, "session:kept", "1", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := store.SetString(ctx, "session:new", "1", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.entries) != 2 {
+		t.Errorf("entries after sweep = %d, want 2", len(store.entries))
+	}
+	if _, err := store.GetString(ctx, "throttle:0"); !errors.Is(err, caching.ErrCacheMiss) {
+		t.Errorf("expired key: err = %v, want ErrCacheMiss", err)
+	}
+	for _, key := range []string{"session:kept", "session:new"} {
+		if _, err := store.GetString(ctx, key); err != nil {
+			t.Errorf("%s: %v", key, err)
+		}
+	}
+}
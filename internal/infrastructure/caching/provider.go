@@ -1,5 +1,51 @@
 package caching
 
-import "github.com/google/wire"
+import (
+	"doan/internal/caching"
+	"doan/internal/infrastructure/redis"
+	"doan/pkg/config"
+	"fmt"
+	"strings"
 
-var CacheManagerProvider = wire.NewSet(NewRedisCacheManager)
+	"github.com/google/wire"
+)
+
+var CacheManagerProvider = wire.NewSet(ProvideCacheManager, ProvideStateStore)
+
+// ProvideCacheManager provides the cache selected by "cache.driver": memory (default, per process)
+// or redis, shared by every instance and configured by the "redis" block
+func ProvideCacheManager(cfg config.Manager, redisClient redis.Client) caching.CacheManager {
+	driver := strings.ToLower(strings.TrimSpace(cfg.GetString("cache.driver")))
+	switch driver {
+	case "", "memory":
+		memoryConfig := MemoryConfig{}
+		if err := cfg.UnmarshalKey("cache.memory", &memoryConfig); err != nil {
+			panic(fmt.Errorf("read memory cache config: %w", err))
+		}
+		return NewMemoryCacheManager(memoryConfig)
+	case "redis":
+		if cfg.GetString("redis.host") == "" {
+			panic(fmt.Errorf("redis.host is required by the redis cache driver"))
+		}
+		return NewRedisCacheManager(redisClient)
+	default:
+		panic(fmt.Errorf("unsupported cache driver %q", driver))
+	}
+}
+
+// ProvideStateStore provides the store of security state. It follows "cache.driver" so that several
+// instances share revocations and lockouts, but in memory it is a separate store that never evicts.
+func ProvideStateStore(cfg config.Manager, redisClient redis.Client) caching.StateStore {
+	driver := strings.ToLower(strings.TrimSpace(cfg.GetString("cache.driver")))
+	switch driver {
+	case "", "memory":
+		return NewMemoryStateStore()
+	case "redis":
+		if cfg.GetString("redis.host") == "" {
+			panic(fmt.Errorf("redis.host is required by the redis cache driver"))
+		}
+		return NewRedisStateStore(redisClient)
+	default:
+		panic(fmt.Errorf("unsupported cache driver %q", driver))
+	}
+}
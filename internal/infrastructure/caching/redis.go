@@ -3,16 +3,107 @@ package caching
 import (
 	"context"
 	"doan/internal/caching"
+	"doan/internal/infrastructure/redis"
+	"fmt"
+	"strconv"
+	"time"
 )
 
+const redisScanCount = "500"
+
+// incrScript increments a key and sets its expiry only when it has none, atomically, so a counter
+// created by INCRBY never outlives its window by a crash between two commands
+const incrScript = `local v = redis.call('INCRBY', KEYS[1], ARGV[1])
+if tonumber(ARGV[2]) > 0 and redis.call('PTTL', KEYS[1]) == -1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return v`
+
+// redisCacheManager keeps entries in Redis, shared by every instance of the API
 type redisCacheManager struct {
+	client redis.Client
+}
+
+// NewRedisCacheManager creates a cache on top of a Redis client
+func NewRedisCacheManager(client redis.Client) caching.CacheManager {
+	return &redisCacheManager{client: client}
+}
+
+// NewRedisStateStore creates a state store on top of a Redis client. Redis must not evict it:
+// run the server with maxmemory-policy noeviction.
+func NewRedisStateStore(client redis.Client) caching.StateStore {
+	return &redisCacheManager{client: client}
 }
 
 func (r *redisCacheManager) GetString(ctx context.Context, key string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	reply, err := r.client.Do(ctx, "GET", key)
+	if err != nil {
+		return "", err
+	}
+	if reply == nil {
+		return "", caching.ErrCacheMiss
+	}
+	return reply.(string), nil
+}
+
+func (r *redisCacheManager) SetString(ctx context.Context, key, value string, ttl time.Duration) error {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := r.client.Do(ctx, args...)
+	return err
+}
+
+func (r *redisCacheManager) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.client.Do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// DeletePattern walks the keyspace with SCAN rather than KEYS so a large database is never blocked
+func (r *redisCacheManager) DeletePattern(ctx context.Context, pattern string) error {
+	cursor := "0"
+	for {
+		reply, err := r.client.Do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", redisScanCount)
+		if err != nil {
+			return err
+		}
+		page, ok := reply.([]interface{})
+		if !ok || len(page) != 2 {
+			return fmt.Errorf("redis: unexpected SCAN reply %v", reply)
+		}
+		cursor, _ = page[0].(string)
+		found, _ := page[1].([]interface{})
+		keys := make([]string, 0, len(found))
+		for _, key := range found {
+			if s, ok := key.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+		if err := r.Delete(ctx, keys...); err != nil {
+			return err
+		}
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
 }
 
-func NewRedisCacheManager() caching.CacheManager {
-	return &redisCacheManager{}
+func (r *redisCacheManager) Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ttlMs := int64(0)
+	if ttl > 0 {
+		ttlMs = ttl.Milliseconds()
+	}
+	reply, err := r.client.Do(ctx, "EVAL", incrScript, "1", key, strconv.FormatInt(delta, 10), strconv.FormatInt(ttlMs, 10))
+	if err != nil {
+		return 0, err
+	}
+	value, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCRBY reply %v", reply)
+	}
+	return value, nil
 }
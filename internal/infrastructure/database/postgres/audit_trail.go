@@ -0,0 +1,509 @@
+package postgres
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/requestinfo"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/inflection"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	auditLogTable        = "audit_logs"
+	auditSnapshotKey     = "audit_trail:before"
+	auditRedactedValue   = "[REDACTED]"
+	auditSummaryEntityID = "*"
+)
+
+// AuditTrailConfig is the "audit" config block. It is read once, when the connection is opened.
+type AuditTrailConfig struct {
+	Enabled             bool     `mapstructure:"enabled"`
+	MaxRowsPerStatement int      `mapstructure:"max_rows_per_statement"` // beyond it a statement gets one summary entry
+	ExcludeTables       []string `mapstructure:"exclude_tables"`         // added to auditExcludedTables
+	RedactColumns       []string `mapstructure:"redact_columns"`         // added to auditRedactedColumns
+}
+
+func defaultAuditTrailConfig() AuditTrailConfig {
+	return AuditTrailConfig{
+		Enabled:             true,
+		MaxRowsPerStatement: 500,
+	}
+}
+
+// auditExcludedTables hold the log itself and short-lived credentials, whose changes say nothing about
+// the business data and would only bury it. Sign-ins and MFA changes have their own audit actions.
+var auditExcludedTables = []string{
+	auditLogTable,
+	"auth_sessions",
+	"refresh_tokens",
+	"password_resets",
+	"password_histories",
+	"user_otps",
+	"user_mfas",
+	"user_recovery_codes",
+}
+
+// auditRedactedColumns are recorded as changed without their values
+var auditRedactedColumns = []string{
+	"password",
+	"password_hash",
+	"token_hash",
+	"code_hash",
+	"otp_hash",
+	"secret",
+}
+
+// auditIgnoredColumns change with every write and are left out of the diffs
+var auditIgnoredColumns = map[string]bool{"updated_at": true}
+
+// auditSnapshot holds the rows a statement is about to change, read before it runs
+type auditSnapshot struct {
+	rows      []map[string]interface{}
+	truncated bool
+}
+
+type auditTrail struct {
+	log      logger.Logger
+	maxRows  int
+	excluded map[string]bool
+	redacted map[string]bool
+}
+
+// RegisterAuditTrail adds GORM callbacks writing an audit log entry, with the old and new values, for
+// every row created, updated or deleted through GORM. Entries join the transaction of the change, so a
+// change whose entry cannot be written fails too. Raw SQL run with Exec is not recorded.
+func RegisterAuditTrail(db *gorm.DB, log logger.Logger, configManager config.Manager) error {
+	cfg := defaultAuditTrailConfig()
+	if err := configManager.UnmarshalKey("audit", &cfg); err != nil {
+		return fmt.Errorf("failed to unmarshal audit config: %w", err)
+	}
+	if !cfg.Enabled {
+		log.Warn(context.Background(), "Audit trail disabled, data changes are not recorded")
+		return nil
+	}
+	if cfg.MaxRowsPerStatement <= 0 {
+		cfg.MaxRowsPerStatement = defaultAuditTrailConfig().MaxRowsPerStatement
+	}
+
+	t := &auditTrail{
+		log:      log,
+		maxRows:  cfg.MaxRowsPerStatement,
+		excluded: make(map[string]bool),
+		redacted: make(map[string]bool),
+	}
+	for _, table := range append(append([]string{}, auditExcludedTables...), cfg.ExcludeTables...) {
+		t.excluded[strings.ToLower(table)] = true
+	}
+	for _, column := range append(append([]string{}, auditRedactedColumns...), cfg.RedactColumns...) {
+		t.redacted[strings.ToLower(column)] = true
+	}
+
+	// Every callback runs inside the transaction GORM opens around the statement
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_create", t.afterCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:setup_reflect_value").Before("gorm:update").
+		Register("audit:before_update", t.beforeChange); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_update", t.afterUpdate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:before_delete").Before("gorm:delete").
+		Register("audit:before_delete", t.beforeChange); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_delete", t.afterDelete); err != nil {
+		return err
+	}
+
+	log.Info(context.Background(), "Audit trail enabled", "max_rows_per_statement", t.maxRows)
+	return nil
+}
+
+// audited reports whether the statement changes a table the trail records
+func (t *auditTrail) audited(db *gorm.DB) bool {
+	return db.Error == nil && !db.DryRun && db.Statement.Table != "" && !t.excluded[strings.ToLower(db.Statement.Table)]
+}
+
+// beforeChange reads the rows an update or delete is about to change
+func (t *auditTrail) beforeChange(db *gorm.DB) {
+	if !t.audited(db) {
+		return
+	}
+	stmt := db.Statement
+
+	query := newSession(db).Table(stmt.Table).Limit(t.maxRows + 1)
+	if where, ok := stmt.Clauses["WHERE"]; ok {
+		if w, ok := where.Expression.(clause.Where); ok {
+			query = query.Clauses(w)
+		}
+	}
+	// GORM also narrows the statement to the primary key of the model it was given, if set
+	if stmt.Schema != nil && len(stmt.Schema.PrimaryFields) > 0 && stmt.ReflectValue.IsValid() {
+		_, values := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		if column, queryValues := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, values); len(queryValues) > 0 {
+			query = query.Where(clause.IN{Column: column, Values: queryValues})
+		}
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		db.AddError(fmt.Errorf("audit trail: failed to read %s before change: %w", stmt.Table, err))
+		return
+	}
+	snapshot := &auditSnapshot{rows: rows}
+	if len(rows) > t.maxRows {
+		snapshot = &auditSnapshot{truncated: true}
+	}
+	db.InstanceSet(auditSnapshotKey, snapshot)
+}
+
+func (t *auditTrail) afterUpdate(db *gorm.DB) {
+	t.afterChange(db, false)
+}
+
+func (t *auditTrail) afterDelete(db *gorm.DB) {
+	t.afterChange(db, true)
+}
+
+// afterChange compares the rows read by beforeChange with what is left of them. A row that is gone was
+// hard deleted; a soft delete or restore shows as a change of deleted_at.
+func (t *auditTrail) afterChange(db *gorm.DB, deleting bool) {
+	if !t.audited(db) || db.RowsAffected == 0 {
+		return
+	}
+	value, ok := db.InstanceGet(auditSnapshotKey)
+	if !ok {
+		return
+	}
+	snapshot := value.(*auditSnapshot)
+	stmt := db.Statement
+
+	action := entities.AuditActionUpdate
+	if deleting {
+		action = entities.AuditActionDelete
+	}
+	keys := primaryKeyColumns(stmt)
+	if !snapshot.truncated && len(snapshot.rows) == 0 {
+		return
+	}
+	if snapshot.truncated || !hasColumns(snapshot.rows, keys) {
+		t.write(db, []*entities.AuditLog{t.summaryEntry(stmt, action, db.RowsAffected)})
+		return
+	}
+
+	after, err := t.reload(db, keys, snapshot.rows)
+	if err != nil {
+		db.AddError(fmt.Errorf("audit trail: failed to read %s after change: %w", stmt.Table, err))
+		return
+	}
+
+	var logs []*entities.AuditLog
+	for _, old := range snapshot.rows {
+		id := rowKey(keys, old)
+		current, exists := after[id]
+		if !exists {
+			if deleting {
+				logs = append(logs, t.entry(stmt, entities.AuditActionDelete, id, t.diff(old, nil)))
+			}
+			continue
+		}
+		changes := t.diff(old, current)
+		if len(changes) == 0 {
+			continue
+		}
+		logs = append(logs, t.entry(stmt, rowAction(changes), id, changes))
+	}
+	t.write(db, logs)
+}
+
+// afterCreate records the values of the inserted rows, as stored with their defaults
+func (t *auditTrail) afterCreate(db *gorm.DB) {
+	if !t.audited(db) || db.RowsAffected == 0 {
+		return
+	}
+	stmt := db.Statement
+
+	// Creates from maps have no schema to find the keys with: their values are recorded as given
+	if stmt.Schema == nil {
+		rows := createdMaps(stmt.ReflectValue)
+		if len(rows) > t.maxRows {
+			t.write(db, []*entities.AuditLog{t.summaryEntry(stmt, entities.AuditActionCreate, db.RowsAffected)})
+			return
+		}
+		logs := make([]*entities.AuditLog, 0, len(rows))
+		for _, row := range rows {
+			logs = append(logs, t.entry(stmt, entities.AuditActionCreate, rowKey([]string{"id"}, row), t.diff(nil, row)))
+		}
+		t.write(db, logs)
+		return
+	}
+
+	// Rows skipped by ON CONFLICT DO NOTHING have no primary key and are left out
+	keys := stmt.Schema.PrimaryFieldDBNames
+	_, values := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+	if len(keys) == 0 || len(values) == 0 {
+		return
+	}
+	if len(values) > t.maxRows {
+		t.write(db, []*entities.AuditLog{t.summaryEntry(stmt, entities.AuditActionCreate, db.RowsAffected)})
+		return
+	}
+	created := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		row := make(map[string]interface{}, len(keys))
+		for i, key := range keys {
+			row[key] = value[i]
+		}
+		created = append(created, row)
+	}
+
+	rows, err := t.reload(db, keys, created)
+	if err != nil {
+		db.AddError(fmt.Errorf("audit trail: failed to read created %s: %w", stmt.Table, err))
+		return
+	}
+	logs := make([]*entities.AuditLog, 0, len(created))
+	for _, row := range created {
+		id := rowKey(keys, row)
+		if current, ok := rows[id]; ok {
+			logs = append(logs, t.entry(stmt, entities.AuditActionCreate, id, t.diff(nil, current)))
+		}
+	}
+	t.write(db, logs)
+}
+
+// reload reads the rows with the primary keys of rows, by key
+func (t *auditTrail) reload(db *gorm.DB, keys []string, rows []map[string]interface{}) (map[string]map[string]interface{}, error) {
+	if len(rows) == 0 {
+		return map[string]map[string]interface{}{}, nil
+	}
+	conditions := make([]clause.Expression, 0, len(rows))
+	for _, row := range rows {
+		equals := make([]clause.Expression, 0, len(keys))
+		for _, key := range keys {
+			equals = append(equals, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: key}, Value: row[key]})
+		}
+		conditions = append(conditions, clause.And(equals...))
+	}
+
+	var found []map[string]interface{}
+	if err := newSession(db).Table(db.Statement.Table).Where(clause.Or(conditions...)).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]map[string]interface{}, len(found))
+	for _, row := range found {
+		byKey[rowKey(keys, row)] = row
+	}
+	return byKey, nil
+}
+
+// diff returns column -> {"old", "new"} for the columns that differ; old or new is nil for a create or delete
+func (t *auditTrail) diff(old, current map[string]interface{}) entities.JSONMap {
+	changes := entities.JSONMap{}
+	columns := make(map[string]bool, len(old)+len(current))
+	for column := range old {
+		columns[column] = true
+	}
+	for column := range current {
+		columns[column] = true
+	}
+
+	for column := range columns {
+		if auditIgnoredColumns[column] {
+			continue
+		}
+		oldValue, newValue := normalizeAuditValue(old[column]), normalizeAuditValue(current[column])
+		if oldValue == nil && newValue == nil {
+			continue
+		}
+		if sameAuditValue(oldValue, newValue) {
+			continue
+		}
+		if t.redacted[strings.ToLower(column)] {
+			if oldValue != nil {
+				oldValue = auditRedactedValue
+			}
+			if newValue != nil {
+				newValue = auditRedactedValue
+			}
+		}
+		changes[column] = map[string]interface{}{"old": oldValue, "new": newValue}
+	}
+	return changes
+}
+
+// entry builds the log entry of one row, attributed to the caller found in the statement context
+func (t *auditTrail) entry(stmt *gorm.Statement, action, entityID string, changes entities.JSONMap) *entities.AuditLog {
+	info := requestinfo.FromContext(stmt.Context)
+	metadata := entities.JSONMap{"table": stmt.Table}
+	if info.ImpersonatorID != "" {
+		metadata["impersonator_id"] = info.ImpersonatorID
+	}
+	if _, ok := stmt.Clauses["ON CONFLICT"]; ok && action == entities.AuditActionCreate {
+		metadata["upsert"] = true
+	}
+
+	log := &entities.AuditLog{
+		ActorRole:  info.Role,
+		Action:     action,
+		EntityType: auditEntityType(stmt.Table),
+		EntityID:   entityID,
+		Metadata:   metadata,
+		Changes:    changes,
+		IPAddress:  info.IPAddress,
+		TraceID:    info.TraceID,
+	}
+	if info.UserID != "" {
+		userID := info.UserID
+		log.ActorID = &userID
+	}
+	if log.ActorRole == "" {
+		log.ActorRole = entities.AuditActorSystem
+	}
+	return log
+}
+
+// summaryEntry stands for a statement changing more rows than are recorded one by one
+func (t *auditTrail) summaryEntry(stmt *gorm.Statement, action string, rowsAffected int64) *entities.AuditLog {
+	log := t.entry(stmt, action, auditSummaryEntityID, nil)
+	log.Metadata["rows_affected"] = rowsAffected
+	log.Comment = fmt.Sprintf("%d rows changed by one statement, values not recorded", rowsAffected)
+	return log
+}
+
+// write inserts the entries in the transaction of the change, failing it when they cannot be written
+func (t *auditTrail) write(db *gorm.DB, logs []*entities.AuditLog) {
+	if len(logs) == 0 {
+		return
+	}
+	if err := newSession(db).Table(auditLogTable).Create(&logs).Error; err != nil {
+		t.log.Error(db.Statement.Context, "Failed to write audit log", "table", db.Statement.Table, "error", err)
+		db.AddError(fmt.Errorf("audit trail: failed to write audit log: %w", err))
+	}
+}
+
+// newSession returns a statement on the connection or transaction of db, free of its clauses
+func newSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true})
+}
+
+// primaryKeyColumns returns the primary key of the statement's model, "id" for statements on a bare table
+func primaryKeyColumns(stmt *gorm.Statement) []string {
+	if stmt.Schema != nil && len(stmt.Schema.PrimaryFieldDBNames) > 0 {
+		return stmt.Schema.PrimaryFieldDBNames
+	}
+	return []string{"id"}
+}
+
+func hasColumns(rows []map[string]interface{}, columns []string) bool {
+	for _, row := range rows {
+		for _, column := range columns {
+			if _, ok := row[column]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// rowKey joins the primary key values of a row, the entity ID of its entries
+func rowKey(keys []string, row map[string]interface{}) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := normalizeAuditValue(row[key])
+		if value == nil {
+			parts = append(parts, "")
+			continue
+		}
+		parts = append(parts, fmt.Sprint(value))
+	}
+	return strings.Join(parts, ":")
+}
+
+// rowAction tells a soft delete or restore from an update by the change of deleted_at
+func rowAction(changes entities.JSONMap) string {
+	change, ok := changes["deleted_at"].(map[string]interface{})
+	if !ok {
+		return entities.AuditActionUpdate
+	}
+	switch {
+	case change["old"] == nil && change["new"] != nil:
+		return entities.AuditActionDelete
+	case change["old"] != nil && change["new"] == nil:
+		return entities.AuditActionRestore
+	}
+	return entities.AuditActionUpdate
+}
+
+// createdMaps returns the rows of a create from a map or a slice of maps
+func createdMaps(value reflect.Value) []map[string]interface{} {
+	switch value.Kind() {
+	case reflect.Map:
+		if row, ok := value.Interface().(map[string]interface{}); ok {
+			return []map[string]interface{}{row}
+		}
+	case reflect.Slice, reflect.Array:
+		rows := make([]map[string]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if row, ok := reflect.Indirect(value.Index(i)).Interface().(map[string]interface{}); ok {
+				rows = append(rows, row)
+			}
+		}
+		return rows
+	}
+	return nil
+}
+
+// normalizeAuditValue turns column values into what they look like once stored as JSON
+func normalizeAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	case gorm.DeletedAt:
+		if !v.Valid {
+			return nil
+		}
+		return v.Time.UTC().Format(time.RFC3339Nano)
+	case clause.Expression:
+		// Values computed by the database, such as gorm.Expr("NOW()"), are read back on updates
+		return nil
+	}
+	return value
+}
+
+func sameAuditValue(a, b interface{}) bool {
+	left, errLeft := json.Marshal(a)
+	right, errRight := json.Marshal(b)
+	return errLeft == nil && errRight == nil && string(left) == string(right)
+}
+
+// auditEntityType names the entity of a table the way the hand-written audit actions do: "payroll_runs"
+// becomes PAYROLL_RUN
+func auditEntityType(table string) string {
+	return strings.ToUpper(inflection.Singular(table))
+}
@@ -44,6 +44,7 @@ func (r *baseRepository[T]) GetTotal(ctx context.Context, condition *repository.
 	conditionWithoutPaging := repository.CommonCondition{}
 	if condition != nil {
 		conditionWithoutPaging.Conditions = condition.Conditions
+		conditionWithoutPaging.OrConditions = condition.OrConditions
 	}
 	var err error
 	db, err = BuildQuery(db, &conditionWithoutPaging)
@@ -130,6 +130,12 @@ func GetDBContext(appCtx context.Context, log logger.Logger, configManager confi
 			return nil, fmt.Errorf("gorm.Open failed: %w", err)
 		}
 
+		// Ghi audit log cho mọi thay đổi dữ liệu qua GORM
+		if err := RegisterAuditTrail(db, log, configManager); err != nil {
+			log.Error(appCtx, "Failed to register audit trail", "error", err)
+			return nil, fmt.Errorf("failed to register audit trail: %w", err)
+		}
+
 		if isDebug {
 			// Không cần db.Debug() nữa nếu logger GORM đã ở level Info
 			log.Info(appCtx, "GORM debug mode enabled (SQL logging via GORM logger)")
@@ -0,0 +1,63 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type accountInvitationRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.AccountInvitation]
+	db *gorm.DB
+}
+
+func NewAccountInvitationRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.AccountInvitationRepository {
+	modelRepo := postgres.NewBaseRepository[entities.AccountInvitation](log, manager, db, "account_invitations")
+	return &accountInvitationRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// LockPendingByTokenHash locks the unused, unrevoked invitation with this token hash
+func (r *accountInvitationRepository) LockPendingByTokenHash(ctx context.Context, tokenHash string) (*entities.AccountInvitation, error) {
+	var invitation entities.AccountInvitation
+	err := postgres.GetDb(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND accepted_at IS NULL AND revoked_at IS NULL", tokenHash).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// RevokePending revokes the open invitations of a user
+func (r *accountInvitationRepository) RevokePending(ctx context.Context, userID string, at time.Time) error {
+	return postgres.GetDb(ctx, r.db).
+		Model(&entities.AccountInvitation{}).
+		Where("user_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
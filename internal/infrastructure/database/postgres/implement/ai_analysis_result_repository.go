@@ -0,0 +1,49 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+type aiAnalysisResultRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.AIAnalysisResult]
+	db *gorm.DB
+}
+
+func NewAIAnalysisResultRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.AIAnalysisResultRepository {
+	modelRepo := postgres.NewBaseRepository[entities.AIAnalysisResult](log, manager, db, "ai_analysis_results")
+	return &aiAnalysisResultRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// ListByMaterialID returns all analysis runs of a material, newest first
+func (r *aiAnalysisResultRepository) ListByMaterialID(ctx context.Context, materialID string) ([]*entities.AIAnalysisResult, error) {
+	var results []*entities.AIAnalysisResult
+	err := postgres.GetDb(ctx, r.db).
+		Where("material_id = ?", materialID).
+		Order("created_at DESC").
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
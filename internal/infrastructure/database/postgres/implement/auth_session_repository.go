@@ -0,0 +1,146 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type authSessionRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.AuthSession]
+	db *gorm.DB
+}
+
+func NewAuthSessionRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.AuthSessionRepository {
+	modelRepo := postgres.NewBaseRepository[entities.AuthSession](log, manager, db, "auth_sessions")
+	return &authSessionRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// ListActiveByUser lists the unrevoked, unexpired sessions of a user
+func (r *authSessionRepository) ListActiveByUser(ctx context.Context, userID string, now time.Time) ([]*entities.AuthSession, error) {
+	var sessions []*entities.AuthSession
+	err := postgres.GetDb(ctx, r.db).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Revoke revokes the session if it is still active
+func (r *authSessionRepository) Revoke(ctx context.Context, id, reason string, at time.Time) (bool, error) {
+	result := postgres.GetDb(ctx, r.db).
+		Model(&entities.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeAllByUser revokes the active sessions of a user except keepID
+func (r *authSessionRepository) RevokeAllByUser(ctx context.Context, userID, keepID, reason string, at time.Time) ([]string, error) {
+	db := postgres.GetDb(ctx, r.db)
+
+	query := db.Model(&entities.AuthSession{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if keepID != "" {
+		query = query.Where("id <> ?", keepID)
+	}
+	var ids []string
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	err := db.Model(&entities.AuthSession{}).
+		Where("id IN ? AND revoked_at IS NULL", ids).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason}).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// RevokeAllByRole revokes the active sessions of every user holding the role
+func (r *authSessionRepository) RevokeAllByRole(ctx context.Context, role, reason string, at time.Time) ([]string, error) {
+	db := postgres.GetDb(ctx, r.db)
+
+	var ids []string
+	err := db.Model(&entities.AuthSession{}).
+		Where("revoked_at IS NULL AND user_id IN (?)",
+			db.Model(&entities.User{}).Select("id").Where("role = ?", role)).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	err = db.Model(&entities.AuthSession{}).
+		Where("id IN ? AND revoked_at IS NULL", ids).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason}).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+type refreshTokenRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.RefreshToken]
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.RefreshTokenRepository {
+	modelRepo := postgres.NewBaseRepository[entities.RefreshToken](log, manager, db, "refresh_tokens")
+	return &refreshTokenRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// LockByTokenHash locks the refresh token with this hash, used or not
+func (r *refreshTokenRepository) LockByTokenHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	err := postgres.GetDb(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
@@ -1,6 +1,7 @@
 package implement
 
 import (
+	"context"
 	"doan/internal/entities"
 	"doan/internal/infrastructure/database/postgres"
 	"doan/internal/repositories"
@@ -8,8 +9,10 @@ import (
 	"doan/pkg/base_struct"
 	"doan/pkg/config"
 	"doan/pkg/logger"
+	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type classRepository struct {
@@ -33,3 +36,43 @@ func NewClassRepository(
 		db:             db,
 	}
 }
+
+// CreateSchedules inserts the weekly sessions of a class
+func (r *classRepository) CreateSchedules(ctx context.Context, schedules []*entities.ClassSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	return postgres.GetDb(ctx, r.db).Omit("Class", "Room").Create(&schedules).Error
+}
+
+// ListSchedules lists the weekly sessions of the given classes
+func (r *classRepository) ListSchedules(ctx context.Context, classIDs []string) ([]*entities.ClassSchedule, error) {
+	var schedules []*entities.ClassSchedule
+	if len(classIDs) == 0 {
+		return schedules, nil
+	}
+	err := postgres.GetDb(ctx, r.db).
+		Where("class_id IN ?", classIDs).
+		Order("class_id, day_of_week, start_time").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// LockByID loads the class FOR UPDATE so concurrent approvals cannot overfill it
+func (r *classRepository) LockByID(ctx context.Context, id string) (*entities.Class, error) {
+	var class entities.Class
+	err := postgres.GetDb(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&class).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &class, nil
+}
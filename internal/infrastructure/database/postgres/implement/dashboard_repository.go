@@ -0,0 +1,243 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// dashboardTimeZone is the zone buckets are cut in, so a "day" is a Vietnamese calendar day
+const dashboardTimeZone = "Asia/Ho_Chi_Minh"
+
+type dashboardRepository struct {
+	base_struct.BaseDependency
+	db *gorm.DB
+}
+
+func NewDashboardRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.DashboardRepository {
+	return &dashboardRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		db: db,
+	}
+}
+
+// enrolledStudentsSQL selects the students enrolled at the instant bound to the named parameter
+func enrolledStudentsSQL(param string) string {
+	return fmt.Sprintf(`SELECT DISTINCT e.student_id FROM enrollments e
+		JOIN classes c ON c.id = e.class_id AND c.deleted_at IS NULL
+		WHERE e.deleted_at IS NULL AND e.status = '%s' AND c.status <> '%s'
+		AND COALESCE(e.approved_at, e.created_at) <= @%[3]s
+		AND c.start_date <= @%[3]s AND (c.end_date IS NULL OR c.end_date >= @%[3]s)`,
+		entities.EnrollmentApproved, entities.ClassStatusCancelled, param)
+}
+
+// GetStudentStats counts students now and the churn over the period
+func (r *dashboardRepository) GetStudentStats(ctx context.Context, from, to time.Time) (*repointerface.StudentStats, error) {
+	now := time.Now()
+	end := to
+	if now.Before(end) {
+		end = now
+	}
+
+	var stats repointerface.StudentStats
+	err := postgres.GetDb(ctx, r.db).Raw(fmt.Sprintf(`
+		WITH at_start AS (%s), at_end AS (%s), now_enrolled AS (%s)
+		SELECT
+			(SELECT COUNT(*) FROM students WHERE deleted_at IS NULL AND status = 'ACTIVE') AS active_students,
+			(SELECT COUNT(*) FROM now_enrolled) AS enrolled_students,
+			(SELECT COUNT(*) FROM students WHERE deleted_at IS NULL AND created_at >= @from AND created_at < @to) AS new_students,
+			(SELECT COUNT(*) FROM at_start) AS enrolled_at_start,
+			(SELECT COUNT(*) FROM at_start WHERE student_id NOT IN (SELECT student_id FROM at_end)) AS churned_students,
+			(SELECT COUNT(*) FROM enrollments WHERE deleted_at IS NULL AND created_at >= @from AND created_at < @to) AS new_enrollments,
+			(SELECT COUNT(*) FROM enrollments WHERE deleted_at IS NULL AND approved_at >= @from AND approved_at < @to) AS approved_enrollment`,
+		enrolledStudentsSQL("from"), enrolledStudentsSQL("end"), enrolledStudentsSQL("now")),
+		map[string]interface{}{"from": from, "to": to, "end": end, "now": now},
+	).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetRevenueTotals sums invoices issued and payments received in the period, and the balance still open now
+func (r *dashboardRepository) GetRevenueTotals(ctx context.Context, from, to time.Time) (*repointerface.RevenueTotals, error) {
+	var totals repointerface.RevenueTotals
+	err := postgres.GetDb(ctx, r.db).Raw(`
+		SELECT
+			(SELECT COALESCE(SUM(total), 0) FROM invoices
+				WHERE deleted_at IS NULL AND status <> @void AND issued_at >= @from AND issued_at < @to) AS billed,
+			(SELECT COALESCE(SUM(amount), 0) FROM payments
+				WHERE deleted_at IS NULL AND status = @completed AND kind = @payment AND received_at >= @from AND received_at < @to) AS collected,
+			(SELECT COALESCE(SUM(amount), 0) FROM payments
+				WHERE deleted_at IS NULL AND status = @completed AND kind = @refund AND received_at >= @from AND received_at < @to) AS refunded,
+			(SELECT COALESCE(SUM(total - paid_amount), 0) FROM invoices
+				WHERE deleted_at IS NULL AND status IN @open) AS outstanding,
+			(SELECT COALESCE(SUM(total - paid_amount), 0) FROM invoices
+				WHERE deleted_at IS NULL AND status IN @open AND due_date < @today) AS overdue`,
+		map[string]interface{}{
+			"from":      from,
+			"to":        to,
+			"void":      entities.InvoiceVoid,
+			"completed": entities.PaymentCompleted,
+			"payment":   entities.PaymentKindPayment,
+			"refund":    entities.PaymentKindRefund,
+			"open":      []string{entities.InvoiceUnpaid, entities.InvoicePartiallyPaid},
+			"today":     time.Now().In(utils.VietnamLocation()).Format("2006-01-02"),
+		},
+	).Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// bucketsSQL generates every bucket start of the period so empty buckets are reported as zero
+const bucketsSQL = `SELECT generate_series(
+		date_trunc(@unit, @from::timestamptz AT TIME ZONE @tz),
+		(@to::timestamptz - INTERVAL '1 microsecond') AT TIME ZONE @tz,
+		('1 ' || @unit)::interval
+	) AS bucket`
+
+// RevenueSeries buckets invoices issued and payments received by interval, in Vietnam time
+func (r *dashboardRepository) RevenueSeries(ctx context.Context, from, to time.Time, interval string) ([]*repointerface.RevenuePoint, error) {
+	var points []*repointerface.RevenuePoint
+	err := postgres.GetDb(ctx, r.db).Raw(`
+		WITH buckets AS (`+bucketsSQL+`),
+		billed AS (
+			SELECT date_trunc(@unit, issued_at AT TIME ZONE @tz) AS bucket, SUM(total) AS amount
+			FROM invoices
+			WHERE deleted_at IS NULL AND status <> @void AND issued_at >= @from AND issued_at < @to
+			GROUP BY 1
+		),
+		received AS (
+			SELECT date_trunc(@unit, received_at AT TIME ZONE @tz) AS bucket,
+				SUM(CASE WHEN kind = @payment THEN amount ELSE 0 END) AS collected,
+				SUM(CASE WHEN kind = @refund THEN amount ELSE 0 END) AS refunded
+			FROM payments
+			WHERE deleted_at IS NULL AND status = @completed AND received_at >= @from AND received_at < @to
+			GROUP BY 1
+		)
+		SELECT b.bucket AS bucket_start,
+			COALESCE(billed.amount, 0) AS billed,
+			COALESCE(received.collected, 0) AS collected,
+			COALESCE(received.refunded, 0) AS refunded
+		FROM buckets b
+		LEFT JOIN billed ON billed.bucket = b.bucket
+		LEFT JOIN received ON received.bucket = b.bucket
+		ORDER BY b.bucket`,
+		map[string]interface{}{
+			"unit":      interval,
+			"tz":        dashboardTimeZone,
+			"from":      from,
+			"to":        to,
+			"void":      entities.InvoiceVoid,
+			"completed": entities.PaymentCompleted,
+			"payment":   entities.PaymentKindPayment,
+			"refund":    entities.PaymentKindRefund,
+		},
+	).Scan(&points).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range points {
+		p.BucketStart = localBucket(p.BucketStart)
+	}
+	return points, nil
+}
+
+// EnrollmentSeries buckets enrollment applications, approvals and rejections by interval, in Vietnam time
+func (r *dashboardRepository) EnrollmentSeries(ctx context.Context, from, to time.Time, interval string) ([]*repointerface.EnrollmentPoint, error) {
+	var points []*repointerface.EnrollmentPoint
+	err := postgres.GetDb(ctx, r.db).Raw(`
+		WITH buckets AS (`+bucketsSQL+`),
+		applied AS (
+			SELECT date_trunc(@unit, created_at AT TIME ZONE @tz) AS bucket, COUNT(*) AS count
+			FROM enrollments WHERE deleted_at IS NULL AND created_at >= @from AND created_at < @to
+			GROUP BY 1
+		),
+		approved AS (
+			SELECT date_trunc(@unit, approved_at AT TIME ZONE @tz) AS bucket, COUNT(*) AS count
+			FROM enrollments WHERE deleted_at IS NULL AND approved_at >= @from AND approved_at < @to
+			GROUP BY 1
+		),
+		rejected AS (
+			SELECT date_trunc(@unit, rejected_at AT TIME ZONE @tz) AS bucket, COUNT(*) AS count
+			FROM enrollments WHERE deleted_at IS NULL AND rejected_at >= @from AND rejected_at < @to
+			GROUP BY 1
+		)
+		SELECT b.bucket AS bucket_start,
+			COALESCE(applied.count, 0) AS applied,
+			COALESCE(approved.count, 0) AS approved,
+			COALESCE(rejected.count, 0) AS rejected
+		FROM buckets b
+		LEFT JOIN applied ON applied.bucket = b.bucket
+		LEFT JOIN approved ON approved.bucket = b.bucket
+		LEFT JOIN rejected ON rejected.bucket = b.bucket
+		ORDER BY b.bucket`,
+		map[string]interface{}{"unit": interval, "tz": dashboardTimeZone, "from": from, "to": to},
+	).Scan(&points).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range points {
+		p.BucketStart = localBucket(p.BucketStart)
+	}
+	return points, nil
+}
+
+// CountClassesByStatus counts live classes per status, and those running on the given day
+func (r *dashboardRepository) CountClassesByStatus(ctx context.Context, on time.Time) ([]*repointerface.ClassStatusCount, error) {
+	var counts []*repointerface.ClassStatusCount
+	err := postgres.GetDb(ctx, r.db).
+		Table("classes").
+		Select(`status, COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE start_date <= ? AND (end_date IS NULL OR end_date >= ?)) AS running`, on, on).
+		Where("deleted_at IS NULL").
+		Group("status").
+		Order("status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// TeacherHours sums the lesson minutes of each active teacher in the period, zero for teachers without lessons
+func (r *dashboardRepository) TeacherHours(ctx context.Context, from, to time.Time) ([]*repointerface.TeacherHoursRow, error) {
+	var rows []*repointerface.TeacherHoursRow
+	err := postgres.GetDb(ctx, r.db).
+		Table("teachers AS t").
+		Joins("LEFT JOIN lessons l ON l.teacher_id = t.id AND l.date_start >= ? AND l.date_start < ?", from, to).
+		Select(`t.id AS teacher_id, t.code AS teacher_code, t.full_name AS teacher_name, t.employment_type,
+			COUNT(l.id) AS lessons,
+			COALESCE(SUM(EXTRACT(EPOCH FROM (l.date_end - l.date_start)) / 60), 0)::bigint AS minutes`).
+		Where("t.deleted_at IS NULL AND t.status = ?", "ACTIVE").
+		Group("t.id").
+		Order("minutes DESC, t.full_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// localBucket reads a timestamp without time zone, cut in Vietnam time, as a Vietnam instant
+func localBucket(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, utils.VietnamLocation())
+}
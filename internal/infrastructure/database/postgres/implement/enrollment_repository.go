@@ -0,0 +1,162 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type enrollmentRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.Enrollment]
+	db *gorm.DB
+}
+
+func NewEnrollmentRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.EnrollmentRepository {
+	modelRepo := postgres.NewBaseRepository[entities.Enrollment](log, manager, db, "enrollments")
+	return &enrollmentRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetActive returns the applied or approved enrollment of the student in the class
+func (r *enrollmentRepository) GetActive(ctx context.Context, classID, studentID string) (*entities.Enrollment, error) {
+	var enrollment entities.Enrollment
+	err := postgres.GetDb(ctx, r.db).
+		Where("class_id = ? AND student_id = ? AND status IN ?", classID, studentID,
+			[]string{entities.EnrollmentApplied, entities.EnrollmentApproved}).
+		First(&enrollment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+// CountApproved counts the approved enrollments of a class
+func (r *enrollmentRepository) CountApproved(ctx context.Context, classID string) (int64, error) {
+	var count int64
+	err := postgres.GetDb(ctx, r.db).
+		Model(&entities.Enrollment{}).
+		Where("class_id = ? AND status = ?", classID, entities.EnrollmentApproved).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Decide moves an applied enrollment to APPROVED or REJECTED; the status condition makes concurrent decisions
+// on the same enrollment update it only once
+func (r *enrollmentRepository) Decide(ctx context.Context, id, status string, at time.Time) (bool, error) {
+	stamp := "approved_at"
+	if status == entities.EnrollmentRejected {
+		stamp = "rejected_at"
+	}
+	result := postgres.GetDb(ctx, r.db).
+		Model(&entities.Enrollment{}).
+		Where("id = ? AND status = ?", id, entities.EnrollmentApplied).
+		Updates(map[string]interface{}{
+			"status":     status,
+			stamp:        at,
+			"updated_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ListActiveClassIDs lists the open, not yet ended classes the student is approved in
+func (r *enrollmentRepository) ListActiveClassIDs(ctx context.Context, studentID, excludeClassID string) ([]string, error) {
+	query := postgres.GetDb(ctx, r.db).
+		Table("enrollments AS e").
+		Joins("JOIN classes AS c ON c.id = e.class_id AND c.deleted_at IS NULL").
+		Where("e.deleted_at IS NULL AND e.student_id = ? AND e.status = ?", studentID, entities.EnrollmentApproved).
+		Where("c.status = ? AND (c.end_date IS NULL OR c.end_date >= ?)", entities.ClassStatusOpen, time.Now())
+	if excludeClassID != "" {
+		query = query.Where("e.class_id <> ?", excludeClassID)
+	}
+
+	var classIDs []string
+	if err := query.Distinct().Pluck("e.class_id", &classIDs).Error; err != nil {
+		return nil, err
+	}
+	return classIDs, nil
+}
+
+// List lists enrollments with their class and student, newest first
+func (r *enrollmentRepository) List(ctx context.Context, filter repointerface.EnrollmentFilter) (*repositories.Pagination[entities.Enrollment], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.Enrollment{})
+	if filter.ClassID != "" {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.StudentID != "" {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: filter.Page, Limit: filter.Limit}
+	var enrollments []*entities.Enrollment
+	err := query.
+		Preload("Class").
+		Preload("Student").
+		Order("created_at DESC").
+		Limit(int(filter.Limit)).
+		Offset(int((filter.Page - 1) * filter.Limit)).
+		Find(&enrollments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.Enrollment]{
+		Data: enrollments,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// HasSiblingEnrollment reports whether another student with the same guardian phone is approved in an open class
+func (r *enrollmentRepository) HasSiblingEnrollment(ctx context.Context, studentID, guardianPhone string) (bool, error) {
+	if guardianPhone == "" {
+		return false, nil
+	}
+	var count int64
+	err := postgres.GetDb(ctx, r.db).
+		Table("enrollments AS e").
+		Joins("JOIN students AS s ON s.id = e.student_id AND s.deleted_at IS NULL").
+		Joins("JOIN classes AS c ON c.id = e.class_id AND c.deleted_at IS NULL").
+		Where("e.deleted_at IS NULL AND e.status = ? AND c.status = ?", entities.EnrollmentApproved, entities.ClassStatusOpen).
+		Where("s.guardian_phone = ? AND s.id <> ?", guardianPhone, studentID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,151 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type guardianRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.Guardian]
+	db *gorm.DB
+}
+
+func NewGuardianRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.GuardianRepository {
+	modelRepo := postgres.NewBaseRepository[entities.Guardian](log, manager, db, "guardians")
+	return &guardianRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetByUserID returns the guardian profile of a GUARDIAN user
+func (r *guardianRepository) GetByUserID(ctx context.Context, userID string) (*entities.Guardian, error) {
+	var guardian entities.Guardian
+	err := postgres.GetDb(ctx, r.db).Where("user_id = ?", userID).First(&guardian).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &guardian, nil
+}
+
+// GetWithStudents returns the guardian with their linked students
+func (r *guardianRepository) GetWithStudents(ctx context.Context, id string) (*entities.Guardian, error) {
+	var guardian entities.Guardian
+	err := postgres.GetDb(ctx, r.db).
+		Preload("User").
+		Preload("Students", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_primary DESC, created_at")
+		}).
+		Preload("Students.Student").
+		Where("id = ?", id).
+		First(&guardian).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &guardian, nil
+}
+
+// List lists guardians by name, phone or email
+func (r *guardianRepository) List(ctx context.Context, search string, page, limit uint64) (*repositories.Pagination[entities.Guardian], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.Guardian{})
+	if search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("full_name ILIKE ? OR phone ILIKE ? OR email ILIKE ?", pattern, pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: page, Limit: limit}
+	var guardians []*entities.Guardian
+	err := query.
+		Preload("Students").
+		Order("full_name, phone").
+		Limit(int(limit)).
+		Offset(int((page - 1) * limit)).
+		Find(&guardians).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.Guardian]{
+		Data: guardians,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// ListByStudent lists the guardians of a student, primary contact first
+func (r *guardianRepository) ListByStudent(ctx context.Context, studentID string) ([]*entities.Guardian, error) {
+	var guardians []*entities.Guardian
+	err := postgres.GetDb(ctx, r.db).
+		Joins("JOIN guardian_students gs ON gs.guardian_id = guardians.id").
+		Where("gs.student_id = ?", studentID).
+		Order("gs.is_primary DESC, gs.created_at").
+		Find(&guardians).Error
+	if err != nil {
+		return nil, err
+	}
+	return guardians, nil
+}
+
+// LinkStudent links a student to a guardian, updating the relationship when already linked
+func (r *guardianRepository) LinkStudent(ctx context.Context, link *entities.GuardianStudent) error {
+	return postgres.GetDb(ctx, r.db).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "guardian_id"}, {Name: "student_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"relationship", "is_primary"}),
+		}).
+		Create(link).Error
+}
+
+// UnlinkStudent removes the link, reporting whether it existed
+func (r *guardianRepository) UnlinkStudent(ctx context.Context, guardianID, studentID string) (bool, error) {
+	result := postgres.GetDb(ctx, r.db).
+		Where("guardian_id = ? AND student_id = ?", guardianID, studentID).
+		Delete(&entities.GuardianStudent{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// IsLinked reports whether the student is a child of the guardian
+func (r *guardianRepository) IsLinked(ctx context.Context, guardianID, studentID string) (bool, error) {
+	var count int64
+	err := postgres.GetDb(ctx, r.db).
+		Model(&entities.GuardianStudent{}).
+		Where("guardian_id = ? AND student_id = ?", guardianID, studentID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,118 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invoiceReminderRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.InvoiceReminder]
+	db *gorm.DB
+}
+
+func NewInvoiceReminderRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.InvoiceReminderRepository {
+	modelRepo := postgres.NewBaseRepository[entities.InvoiceReminder](log, manager, db, "invoice_reminders")
+	return &invoiceReminderRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// Claim inserts the (invoice, step) row as PENDING, or takes over a FAILED one with attempts left.
+// The unique index on (invoice_id, step_code) makes the claim atomic across concurrent runs.
+func (r *invoiceReminderRepository) Claim(ctx context.Context, invoiceID, stepCode, recipient string, maxAttempts int) (*entities.InvoiceReminder, error) {
+	db := postgres.GetDb(ctx, r.db)
+
+	reminder := &entities.InvoiceReminder{
+		InvoiceID: invoiceID,
+		StepCode:  stepCode,
+		Channel:   entities.ReminderChannelEmail,
+		Recipient: recipient,
+		Status:    entities.ReminderPending,
+		Attempts:  1,
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "invoice_id"}, {Name: "step_code"}},
+		DoNothing: true,
+	}).Create(reminder)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return reminder, nil
+	}
+
+	result = db.Model(&entities.InvoiceReminder{}).
+		Where("invoice_id = ? AND step_code = ? AND status = ? AND attempts < ?",
+			invoiceID, stepCode, entities.ReminderFailed, maxAttempts).
+		Updates(map[string]interface{}{
+			"status":    entities.ReminderPending,
+			"recipient": recipient,
+			"attempts":  gorm.Expr("attempts + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var retried entities.InvoiceReminder
+	err := db.Where("invoice_id = ? AND step_code = ?", invoiceID, stepCode).First(&retried).Error
+	if err != nil {
+		return nil, err
+	}
+	return &retried, nil
+}
+
+// LatestByInvoices returns the most recent reminder of each invoice
+func (r *invoiceReminderRepository) LatestByInvoices(ctx context.Context, invoiceIDs []string) (map[string]*entities.InvoiceReminder, error) {
+	latest := make(map[string]*entities.InvoiceReminder, len(invoiceIDs))
+	if len(invoiceIDs) == 0 {
+		return latest, nil
+	}
+	var reminders []*entities.InvoiceReminder
+	err := postgres.GetDb(ctx, r.db).
+		Raw(`SELECT DISTINCT ON (invoice_id) * FROM invoice_reminders
+			WHERE invoice_id IN ? AND deleted_at IS NULL
+			ORDER BY invoice_id, created_at DESC`, invoiceIDs).
+		Scan(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, reminder := range reminders {
+		latest[reminder.InvoiceID] = reminder
+	}
+	return latest, nil
+}
+
+// ListByInvoice lists the reminders of an invoice, oldest first
+func (r *invoiceReminderRepository) ListByInvoice(ctx context.Context, invoiceID string) ([]*entities.InvoiceReminder, error) {
+	var reminders []*entities.InvoiceReminder
+	err := postgres.GetDb(ctx, r.db).
+		Where("invoice_id = ?", invoiceID).
+		Order("created_at ASC").
+		Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
@@ -0,0 +1,241 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invoiceRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.Invoice]
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.InvoiceRepository {
+	modelRepo := postgres.NewBaseRepository[entities.Invoice](log, manager, db, "invoices")
+	return &invoiceRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// NextNumber draws from invoice_number_seq so numbers never repeat, even across years
+func (r *invoiceRepository) NextNumber(ctx context.Context, issuedAt time.Time) (string, error) {
+	var next int64
+	if err := postgres.GetDb(ctx, r.db).Raw("SELECT nextval('invoice_number_seq')").Scan(&next).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("HD%d%06d", issuedAt.Year(), next), nil
+}
+
+// GetWithLines returns the invoice with its lines, student and class
+func (r *invoiceRepository) GetWithLines(ctx context.Context, id string) (*entities.Invoice, error) {
+	var invoice entities.Invoice
+	err := postgres.GetDb(ctx, r.db).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("kind DESC, created_at ASC") }).
+		Preload("Student").
+		Preload("Class").
+		Where("id = ?", id).
+		First(&invoice).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// List lists invoices newest first
+func (r *invoiceRepository) List(ctx context.Context, filter repointerface.InvoiceFilter) (*repositories.Pagination[entities.Invoice], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.Invoice{})
+	if filter.StudentID != "" {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.ClassID != "" {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.EnrollmentID != "" {
+		query = query.Where("enrollment_id = ?", filter.EnrollmentID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: filter.Page, Limit: filter.Limit}
+	var invoices []*entities.Invoice
+	err := query.
+		Preload("Student").
+		Preload("Class").
+		Order("issued_at DESC, installment_no ASC").
+		Limit(int(filter.Limit)).
+		Offset(int((filter.Page - 1) * filter.Limit)).
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.Invoice]{
+		Data: invoices,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// ListOpenDueBefore lists unsettled, non-void invoices due before the given instant with their students
+func (r *invoiceRepository) ListOpenDueBefore(ctx context.Context, dueBefore time.Time) ([]*entities.Invoice, error) {
+	var invoices []*entities.Invoice
+	err := postgres.GetDb(ctx, r.db).
+		Preload("Student").
+		Where("status IN ? AND due_date < ?",
+			[]string{entities.InvoiceUnpaid, entities.InvoicePartiallyPaid}, dueBefore).
+		Order("due_date ASC, id ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// ListOverdue lists unsettled invoices past due with their students, most overdue first
+func (r *invoiceRepository) ListOverdue(ctx context.Context, filter repointerface.OverdueFilter) (*repositories.Pagination[entities.Invoice], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.Invoice{}).
+		Where("status IN ? AND due_date < ?",
+			[]string{entities.InvoiceUnpaid, entities.InvoicePartiallyPaid}, filter.DueBefore)
+	if filter.StudentID != "" {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.GuardianPhone != "" {
+		query = query.Where("student_id IN (?)", postgres.GetDb(ctx, r.db).
+			Model(&entities.Student{}).Select("id").Where("guardian_phone = ?", filter.GuardianPhone))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: filter.Page, Limit: filter.Limit}
+	var invoices []*entities.Invoice
+	err := query.
+		Preload("Student").
+		Preload("Class").
+		Order("due_date ASC, number ASC").
+		Limit(int(filter.Limit)).
+		Offset(int((filter.Page - 1) * filter.Limit)).
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.Invoice]{
+		Data: invoices,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// ListOutstanding sums the balances of non-void invoices per student in one aggregate query
+func (r *invoiceRepository) ListOutstanding(ctx context.Context, filter repointerface.OutstandingFilter) ([]*repointerface.StudentBalance, error) {
+	asOf := filter.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	query := postgres.GetDb(ctx, r.db).
+		Table("invoices AS i").
+		Joins("JOIN students AS s ON s.id = i.student_id").
+		Where("i.deleted_at IS NULL AND i.status <> ?", entities.InvoiceVoid)
+	if filter.StudentID != "" {
+		query = query.Where("i.student_id = ?", filter.StudentID)
+	}
+	if filter.GuardianPhone != "" {
+		query = query.Where("s.guardian_phone = ? AND s.deleted_at IS NULL", filter.GuardianPhone)
+	}
+
+	var balances []*repointerface.StudentBalance
+	err := query.
+		Select(`s.id AS student_id, s.code AS student_code, s.full_name AS student_name,
+			COALESCE(s.guardian_phone, '') AS guardian_phone,
+			COUNT(*) FILTER (WHERE i.total > i.paid_amount) AS open_invoices,
+			COALESCE(SUM(i.total), 0) AS billed,
+			COALESCE(SUM(i.paid_amount), 0) AS paid,
+			COALESCE(SUM(i.total - i.paid_amount), 0) AS outstanding,
+			COALESCE(SUM(i.total - i.paid_amount) FILTER (WHERE i.due_date < ?), 0) AS overdue,
+			MIN(i.due_date) FILTER (WHERE i.total > i.paid_amount) AS next_due_date`, asOf).
+		Group("s.id, s.code, s.full_name, s.guardian_phone").
+		Order("s.full_name ASC").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// LockByIDs loads the invoices FOR UPDATE so concurrent payments cannot overpay them
+func (r *invoiceRepository) LockByIDs(ctx context.Context, ids []string) ([]*entities.Invoice, error) {
+	var invoices []*entities.Invoice
+	if len(ids) == 0 {
+		return invoices, nil
+	}
+	err := postgres.GetDb(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// LockOpenByStudent loads the student's unsettled, non-void invoices FOR UPDATE, oldest due first
+func (r *invoiceRepository) LockOpenByStudent(ctx context.Context, studentID string) ([]*entities.Invoice, error) {
+	var invoices []*entities.Invoice
+	err := postgres.GetDb(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("student_id = ? AND status IN ?", studentID,
+			[]string{entities.InvoiceUnpaid, entities.InvoicePartiallyPaid}).
+		Order("due_date ASC, installment_no ASC, id ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// GetByNumber returns the invoice with the given number
+func (r *invoiceRepository) GetByNumber(ctx context.Context, number string) (*entities.Invoice, error) {
+	var invoice entities.Invoice
+	err := postgres.GetDb(ctx, r.db).Where("number = ?", number).First(&invoice).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
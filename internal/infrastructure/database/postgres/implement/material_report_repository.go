@@ -0,0 +1,166 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// unlabeledKey groups materials without an AI label in the statistics
+const unlabeledKey = "UNLABELED"
+
+type materialReportRepository struct {
+	base_struct.BaseDependency
+	db *gorm.DB
+}
+
+func NewMaterialReportRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.MaterialReportRepository {
+	return &materialReportRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		db: db,
+	}
+}
+
+// materials returns the live materials matching the filter, the period applying to the upload date
+func (r *materialReportRepository) materials(ctx context.Context, filter repointerface.MaterialReportFilter) *gorm.DB {
+	query := postgres.GetDb(ctx, r.db).Table("materials AS m").Where("m.deleted_at IS NULL")
+	if filter.TeacherID != "" {
+		query = query.Where("m.uploaded_by_id = ?", filter.TeacherID)
+	}
+	if filter.CourseID != "" {
+		query = query.Where("m.course_id = ?", filter.CourseID)
+	}
+	if filter.From != nil {
+		query = query.Where("m.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("m.created_at < ?", *filter.To)
+	}
+	return query
+}
+
+// GetQualityStats computes label and review counts, mean review time and the most cited rejection reasons
+func (r *materialReportRepository) GetQualityStats(ctx context.Context, filter repointerface.MaterialReportFilter, topReasons int) (*repointerface.MaterialQualityStats, error) {
+	stats := &repointerface.MaterialQualityStats{
+		ByAILabel:      make(map[string]int64),
+		ByReviewStatus: make(map[string]int64),
+	}
+
+	var labelRows []struct {
+		Label string
+		Count int64
+	}
+	err := r.materials(ctx, filter).
+		Select("COALESCE(m.ai_label, ?) AS label, COUNT(*) AS count", unlabeledKey).
+		Group("label").
+		Scan(&labelRows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range labelRows {
+		stats.ByAILabel[row.Label] = row.Count
+		stats.TotalMaterials += row.Count
+	}
+
+	var statusRows []struct {
+		Status string
+		Count  int64
+	}
+	err = r.materials(ctx, filter).
+		Select("m.review_status AS status, COUNT(*) AS count").
+		Group("m.review_status").
+		Scan(&statusRows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range statusRows {
+		stats.ByReviewStatus[row.Status] = row.Count
+	}
+	stats.ApprovedCount = stats.ByReviewStatus[entities.MaterialReviewApproved]
+	stats.RejectedCount = stats.ByReviewStatus[entities.MaterialReviewRejected]
+
+	var meanHours *float64
+	err = r.materials(ctx, filter).
+		Where("m.reviewed_at IS NOT NULL").
+		Select("AVG(EXTRACT(EPOCH FROM (m.reviewed_at - m.created_at)) / 3600.0)").
+		Scan(&meanHours).Error
+	if err != nil {
+		return nil, err
+	}
+	if meanHours != nil {
+		stats.MeanReviewHours = *meanHours
+	}
+
+	if topReasons > 0 {
+		var reasons []repointerface.ReasonCount
+		err = r.materials(ctx, filter).
+			Joins(`JOIN LATERAL (
+				SELECT a.reasons FROM ai_analysis_results a
+				WHERE a.material_id = m.id AND a.deleted_at IS NULL
+				ORDER BY a.created_at DESC LIMIT 1
+			) latest ON TRUE`).
+			Joins("CROSS JOIN LATERAL jsonb_to_recordset(latest.reasons) AS reason(code TEXT, message TEXT)").
+			Where("m.review_status = ?", entities.MaterialReviewRejected).
+			Select("reason.code AS code, MIN(reason.message) AS message, COUNT(DISTINCT m.id) AS count").
+			Group("reason.code").
+			Order("count DESC, code ASC").
+			Limit(topReasons).
+			Scan(&reasons).Error
+		if err != nil {
+			return nil, err
+		}
+		stats.RejectionReasons = reasons
+	}
+
+	return stats, nil
+}
+
+// ListReviewHistory returns every review decision in chronological order; the period applies to the decision date
+func (r *materialReportRepository) ListReviewHistory(ctx context.Context, filter repointerface.MaterialReportFilter) ([]*repointerface.ReviewHistoryRow, error) {
+	query := postgres.GetDb(ctx, r.db).
+		Table("audit_logs AS l").
+		Joins("JOIN materials m ON m.id::text = l.entity_id").
+		Joins("JOIN users t ON t.id = m.uploaded_by_id").
+		Joins("LEFT JOIN users rv ON rv.id = l.actor_id").
+		Joins("LEFT JOIN courses c ON c.id = m.course_id").
+		Where("l.deleted_at IS NULL AND l.entity_type = ?", entities.AuditEntityMaterial).
+		Where("l.action IN ?", []string{entities.AuditActionMaterialApprove, entities.AuditActionMaterialReject})
+	if filter.TeacherID != "" {
+		query = query.Where("m.uploaded_by_id = ?", filter.TeacherID)
+	}
+	if filter.CourseID != "" {
+		query = query.Where("m.course_id = ?", filter.CourseID)
+	}
+	if filter.From != nil {
+		query = query.Where("l.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("l.created_at < ?", *filter.To)
+	}
+
+	var rows []*repointerface.ReviewHistoryRow
+	err := query.Select(`l.created_at AS reviewed_at, l.action, COALESCE(l.comment, '') AS comment,
+			COALESCE(rv.full_name, '') AS reviewer_name, COALESCE(rv.email, '') AS reviewer_email, COALESCE(l.actor_role, '') AS reviewer_role,
+			m.id AS material_id, m.title AS material_title, m.file_name, m.ai_label,
+			c.code AS course_code, c.name AS course_name,
+			COALESCE(t.full_name, '') AS teacher_name, t.email AS teacher_email, m.created_at AS uploaded_at`).
+		Order("l.created_at ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
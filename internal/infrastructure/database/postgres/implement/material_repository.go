@@ -0,0 +1,91 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type materialRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.Material]
+	db *gorm.DB
+}
+
+func NewMaterialRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.MaterialRepository {
+	modelRepo := postgres.NewBaseRepository[entities.Material](log, manager, db, "materials")
+	return &materialRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// CountByStorageKey counts non-deleted materials pointing to the given stored object
+func (r *materialRepository) CountByStorageKey(ctx context.Context, driver, key string) (int64, error) {
+	var count int64
+	err := postgres.GetDb(ctx, r.db).
+		Model(&entities.Material{}).
+		Where("storage_driver = ? AND storage_key = ?", driver, key).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListReviewQueue lists materials whose AI audit has finished, ordered by label severity
+// (DANGER, then WARNING or failed audits, then SAFE) and by waiting time
+func (r *materialRepository) ListReviewQueue(ctx context.Context, filter repointerface.ReviewQueueFilter) (*repositories.Pagination[entities.Material], error) {
+	query := postgres.GetDb(ctx, r.db).
+		Model(&entities.Material{}).
+		Where("audit_status IN ?", []string{entities.MaterialAuditCompleted, entities.MaterialAuditFailed})
+	if filter.ReviewStatus != "" {
+		query = query.Where("review_status = ?", filter.ReviewStatus)
+	}
+	if len(filter.Labels) > 0 {
+		query = query.Where("ai_label IN ?", filter.Labels)
+	}
+	if filter.UploadedBefore != nil {
+		query = query.Where("created_at <= ?", *filter.UploadedBefore)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: filter.Page, Limit: filter.Limit}
+	var materials []*entities.Material
+	err := query.
+		Preload("UploadedBy").
+		Order(clause.Expr{SQL: "CASE ai_label WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 1 END",
+			Vars: []interface{}{entities.AILabelDanger, entities.AILabelWarning, entities.AILabelSafe}}).
+		Order("created_at ASC").
+		Limit(int(filter.Limit)).
+		Offset(int((filter.Page - 1) * filter.Limit)).
+		Find(&materials).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.Material]{
+		Data: materials,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
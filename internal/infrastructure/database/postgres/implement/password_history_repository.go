@@ -0,0 +1,65 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+type passwordHistoryRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.PasswordHistory]
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.PasswordHistoryRepository {
+	modelRepo := postgres.NewBaseRepository[entities.PasswordHistory](log, manager, db, "password_histories")
+	return &passwordHistoryRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// ListRecentByUser lists the last replaced passwords of a user, newest first
+func (r *passwordHistoryRepository) ListRecentByUser(ctx context.Context, userID string, limit int) ([]*entities.PasswordHistory, error) {
+	var entries []*entities.PasswordHistory
+	if limit <= 0 {
+		return entries, nil
+	}
+	err := postgres.GetDb(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+// PruneByUser deletes every replaced password of a user but the newest keep ones
+func (r *passwordHistoryRepository) PruneByUser(ctx context.Context, userID string, keep int) error {
+	db := postgres.GetDb(ctx, r.db)
+	if keep <= 0 {
+		return db.Where("user_id = ?", userID).Delete(&entities.PasswordHistory{}).Error
+	}
+	newest := db.Model(&entities.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(keep)
+	return db.Where("user_id = ? AND id NOT IN (?)", userID, newest).
+		Delete(&entities.PasswordHistory{}).Error
+}
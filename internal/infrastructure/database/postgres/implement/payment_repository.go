@@ -0,0 +1,172 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type paymentRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.Payment]
+	db *gorm.DB
+}
+
+func NewPaymentRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.PaymentRepository {
+	modelRepo := postgres.NewBaseRepository[entities.Payment](log, manager, db, "payments")
+	return &paymentRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// NextReceiptNumber draws from receipt_number_seq, shared by receipts (PT) and refund vouchers (PC)
+func (r *paymentRepository) NextReceiptNumber(ctx context.Context, prefix string, at time.Time) (string, error) {
+	var next int64
+	if err := postgres.GetDb(ctx, r.db).Raw("SELECT nextval('receipt_number_seq')").Scan(&next).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d%06d", prefix, at.Year(), next), nil
+}
+
+// GetWithAllocations returns the payment with its allocations, their invoices and the cashier
+func (r *paymentRepository) GetWithAllocations(ctx context.Context, id string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := postgres.GetDb(ctx, r.db).
+		Preload("Allocations", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Allocations.Invoice").
+		Preload("Cashier").
+		Where("id = ?", id).
+		First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// GetByProviderReference returns the payment a provider already reported
+func (r *paymentRepository) GetByProviderReference(ctx context.Context, provider, reference string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := postgres.GetDb(ctx, r.db).
+		Where("provider = ? AND reference = ?", provider, reference).
+		First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ListRefunds lists the refunds of a payment with their allocations
+func (r *paymentRepository) ListRefunds(ctx context.Context, paymentID string) ([]*entities.Payment, error) {
+	var refunds []*entities.Payment
+	err := postgres.GetDb(ctx, r.db).
+		Preload("Allocations").
+		Where("refund_of_id = ? AND kind = ?", paymentID, entities.PaymentKindRefund).
+		Order("received_at ASC").
+		Find(&refunds).Error
+	if err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// List lists payments newest first
+func (r *paymentRepository) List(ctx context.Context, filter repointerface.PaymentFilter) (*repositories.Pagination[entities.Payment], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.Payment{})
+	if filter.InvoiceID != "" {
+		query = query.Where("id IN (?)", postgres.GetDb(ctx, r.db).
+			Table("payment_allocations").Select("payment_id").Where("invoice_id = ?", filter.InvoiceID))
+	}
+	if filter.StudentID != "" {
+		query = query.Where("id IN (?)", postgres.GetDb(ctx, r.db).
+			Table("payment_allocations AS a").Select("a.payment_id").
+			Joins("JOIN invoices AS i ON i.id = a.invoice_id").
+			Where("i.student_id = ?", filter.StudentID))
+	}
+	if filter.CashierID != "" {
+		query = query.Where("cashier_id = ?", filter.CashierID)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("received_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("received_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: filter.Page, Limit: filter.Limit}
+	var payments []*entities.Payment
+	err := query.
+		Preload("Allocations").
+		Preload("Cashier").
+		Order("received_at DESC").
+		Limit(int(filter.Limit)).
+		Offset(int((filter.Page - 1) * filter.Limit)).
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.Payment]{
+		Data: payments,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// Reconcile sums payments received in [from, to) per cashier, method, kind and status
+func (r *paymentRepository) Reconcile(ctx context.Context, from, to time.Time) ([]*repointerface.ReconciliationRow, error) {
+	var rows []*repointerface.ReconciliationRow
+	err := postgres.GetDb(ctx, r.db).
+		Table("payments AS p").
+		Joins("LEFT JOIN users AS u ON u.id = p.cashier_id").
+		Where("p.deleted_at IS NULL AND p.received_at >= ? AND p.received_at < ?", from, to).
+		Select(`COALESCE(CAST(p.cashier_id AS TEXT), '') AS cashier_id,
+			COALESCE(u.full_name, p.provider, '') AS cashier_name,
+			p.method, p.kind, p.status,
+			COUNT(*) AS count,
+			COALESCE(SUM(p.amount), 0) AS amount`).
+		Group("p.cashier_id, u.full_name, p.provider, p.method, p.kind, p.status").
+		Order("cashier_name, p.method, p.kind, p.status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,174 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type payrollRunRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.PayrollRun]
+	db *gorm.DB
+}
+
+func NewPayrollRunRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.PayrollRunRepository {
+	modelRepo := postgres.NewBaseRepository[entities.PayrollRun](log, manager, db, "payroll_runs")
+	return &payrollRunRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetByPeriod returns the run of a month
+func (r *payrollRunRepository) GetByPeriod(ctx context.Context, period string) (*entities.PayrollRun, error) {
+	var run entities.PayrollRun
+	err := postgres.GetDb(ctx, r.db).Where("period = ?", period).First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetWithItems returns the run with its items ordered by teacher name
+func (r *payrollRunRepository) GetWithItems(ctx context.Context, id string, withLines bool) (*entities.PayrollRun, error) {
+	var run entities.PayrollRun
+	query := postgres.GetDb(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Select("payroll_items.*").
+				Joins("LEFT JOIN teachers ON teachers.id = payroll_items.teacher_id").
+				Order("teachers.full_name, payroll_items.teacher_id")
+		}).
+		Preload("Items.Teacher")
+	if withLines {
+		query = query.Preload("Items.Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date_start, class_code")
+		})
+	}
+	err := query.Where("id = ?", id).First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// LockByID loads the run FOR UPDATE so a recalculation cannot race a finalisation
+func (r *payrollRunRepository) LockByID(ctx context.Context, id string) (*entities.PayrollRun, error) {
+	var run entities.PayrollRun
+	err := postgres.GetDb(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ReplaceItems deletes the items and lines of the run and stores the given ones
+func (r *payrollRunRepository) ReplaceItems(ctx context.Context, runID string, items []*entities.PayrollItem) error {
+	db := postgres.GetDb(ctx, r.db)
+	if err := db.Where("run_id = ?", runID).Delete(&entities.PayrollLine{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("run_id = ?", runID).Delete(&entities.PayrollItem{}).Error; err != nil {
+		return err
+	}
+	for _, item := range items {
+		item.RunID = runID
+		lines := item.Lines
+		item.Lines = nil
+		if err := db.Omit(clause.Associations).Create(item).Error; err != nil {
+			return err
+		}
+		for i := range lines {
+			lines[i].RunID = runID
+			lines[i].ItemID = item.ID
+		}
+		if len(lines) > 0 {
+			if err := db.CreateInBatches(lines, 200).Error; err != nil {
+				return err
+			}
+		}
+		item.Lines = lines
+	}
+	return nil
+}
+
+// List lists runs, latest period first
+func (r *payrollRunRepository) List(ctx context.Context, page, limit uint64) (*repositories.Pagination[entities.PayrollRun], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.PayrollRun{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: page, Limit: limit}
+	var runs []*entities.PayrollRun
+	err := query.
+		Order("period DESC").
+		Limit(int(limit)).
+		Offset(int((page - 1) * limit)).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.PayrollRun]{
+		Data: runs,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// ListTaughtLessons lists lessons with a teacher that ended in [from, to), with their class
+func (r *payrollRunRepository) ListTaughtLessons(ctx context.Context, from, to time.Time) ([]*repointerface.TaughtLesson, error) {
+	var lessons []*repointerface.TaughtLesson
+	err := postgres.GetDb(ctx, r.db).
+		Table("lessons").
+		Select(`lessons.id AS lesson_id,
+			lessons.class_id AS class_id,
+			classes.code AS class_code,
+			classes.name AS class_name,
+			classes.course_id AS course_id,
+			classes.teacher_id AS class_teacher_id,
+			lessons.teacher_id AS teacher_id,
+			lessons.date_start AS date_start,
+			lessons.date_end AS date_end`).
+		Joins("JOIN classes ON classes.id = lessons.class_id AND classes.deleted_at IS NULL").
+		Where("lessons.teacher_id IS NOT NULL").
+		Where("lessons.date_end >= ? AND lessons.date_end < ?", from, to).
+		Order("lessons.date_start, lessons.id").
+		Scan(&lessons).Error
+	if err != nil {
+		return nil, err
+	}
+	return lessons, nil
+}
@@ -0,0 +1,96 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type reminderOptOutRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.ReminderOptOut]
+	db *gorm.DB
+}
+
+func NewReminderOptOutRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.ReminderOptOutRepository {
+	modelRepo := postgres.NewBaseRepository[entities.ReminderOptOut](log, manager, db, "reminder_opt_outs")
+	return &reminderOptOutRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetByGuardianPhone returns the opt-out of a guardian
+func (r *reminderOptOutRepository) GetByGuardianPhone(ctx context.Context, phone string) (*entities.ReminderOptOut, error) {
+	var optOut entities.ReminderOptOut
+	err := postgres.GetDb(ctx, r.db).Where("guardian_phone = ?", phone).First(&optOut).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &optOut, nil
+}
+
+// OptedOut returns which of the guardian phones opted out
+func (r *reminderOptOutRepository) OptedOut(ctx context.Context, phones []string) (map[string]bool, error) {
+	optedOut := make(map[string]bool)
+	if len(phones) == 0 {
+		return optedOut, nil
+	}
+	var found []string
+	err := postgres.GetDb(ctx, r.db).
+		Model(&entities.ReminderOptOut{}).
+		Where("guardian_phone IN ?", phones).
+		Pluck("guardian_phone", &found).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, phone := range found {
+		optedOut[phone] = true
+	}
+	return optedOut, nil
+}
+
+// List lists opt-outs newest first
+func (r *reminderOptOutRepository) List(ctx context.Context, page, limit uint64) (*repositories.Pagination[entities.ReminderOptOut], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.ReminderOptOut{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: page, Limit: limit}
+	var optOuts []*entities.ReminderOptOut
+	err := query.
+		Order("created_at DESC").
+		Limit(int(limit)).
+		Offset(int((page - 1) * limit)).
+		Find(&optOuts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.ReminderOptOut]{
+		Data: optOuts,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type roleRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.Role]
+	db *gorm.DB
+}
+
+func NewRoleRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.RoleRepository {
+	modelRepo := postgres.NewBaseRepository[entities.Role](log, manager, db, "roles")
+	return &roleRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetByName returns the role with this name
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*entities.Role, error) {
+	var role entities.Role
+	err := postgres.GetDb(ctx, r.db).Where("name = ?", name).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// ListAll lists every role, built-in roles first
+func (r *roleRepository) ListAll(ctx context.Context) ([]*entities.Role, error) {
+	var roles []*entities.Role
+	err := postgres.GetDb(ctx, r.db).Order("is_system DESC, name").Find(&roles).Error
+	return roles, err
+}
+
+// CountUsers counts the users holding the role
+func (r *roleRepository) CountUsers(ctx context.Context, name string) (int64, error) {
+	var count int64
+	err := postgres.GetDb(ctx, r.db).Model(&entities.User{}).Where("role = ?", name).Count(&count).Error
+	return count, err
+}
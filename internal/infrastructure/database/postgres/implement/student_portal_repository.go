@@ -0,0 +1,123 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type studentPortalRepository struct {
+	base_struct.BaseDependency
+	db *gorm.DB
+}
+
+func NewStudentPortalRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.StudentPortalRepository {
+	return &studentPortalRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		db: db,
+	}
+}
+
+// ListTimetable lists the lessons of the classes the student is approved in
+func (r *studentPortalRepository) ListTimetable(ctx context.Context, studentID string, from, to time.Time) ([]*repointerface.TimetableEntry, error) {
+	var entries []*repointerface.TimetableEntry
+	err := postgres.GetDb(ctx, r.db).
+		Table("lessons AS l").
+		Joins("JOIN classes c ON c.id = l.class_id AND c.deleted_at IS NULL").
+		Joins("JOIN enrollments e ON e.class_id = c.id AND e.deleted_at IS NULL AND e.status = ?", entities.EnrollmentApproved).
+		Joins("LEFT JOIN rooms r ON r.id = l.room_id").
+		Joins("LEFT JOIN teachers t ON t.id = COALESCE(l.teacher_id, c.teacher_id)").
+		Where("e.student_id = ?", studentID).
+		Where("l.date_start >= ? AND l.date_start < ?", from, to).
+		Select(`l.id AS lesson_id, c.id AS class_id, c.code AS class_code, c.name AS class_name,
+			l.date_start, l.date_end, r.name AS room_name, t.full_name AS teacher_name`).
+		Order("l.date_start").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListAttendance lists the attendance marks of the student
+func (r *studentPortalRepository) ListAttendance(ctx context.Context, studentID string, from, to time.Time) ([]*repointerface.AttendanceEntry, error) {
+	var entries []*repointerface.AttendanceEntry
+	err := postgres.GetDb(ctx, r.db).
+		Table("attendances AS a").
+		Joins("JOIN lessons l ON l.id = a.lesson_id").
+		Joins("JOIN classes c ON c.id = l.class_id").
+		Where("a.student_id = ?", studentID).
+		Where("l.date_start >= ? AND l.date_start < ?", from, to).
+		Select(`l.id AS lesson_id, c.code AS class_code, c.name AS class_name, l.date_start,
+			a.status, COALESCE(a.note, '') AS note, a.marked_at`).
+		Order("l.date_start").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListGrades lists the per-lesson academic records of the student
+func (r *studentPortalRepository) ListGrades(ctx context.Context, studentID string, from, to time.Time) ([]*repointerface.GradeEntry, error) {
+	var entries []*repointerface.GradeEntry
+	err := postgres.GetDb(ctx, r.db).
+		Table("academic_records AS ar").
+		Joins("JOIN lesson_summaries ls ON ls.id = ar.lesson_summary_id").
+		Joins("JOIN lessons l ON l.id = ls.lesson_id").
+		Joins("JOIN classes c ON c.id = l.class_id").
+		Where("ar.student_id = ?", studentID).
+		Where("l.date_start >= ? AND l.date_start < ?", from, to).
+		Select(`l.id AS lesson_id, c.code AS class_code, c.name AS class_name, l.date_start,
+			COALESCE(ls.topic, '') AS topic, COALESCE(ar.homework_completed, FALSE) AS homework_completed,
+			ar.homework_score, ar.attitude_rating, ar.participation_score, ar.total_score,
+			COALESCE(ar.personal_comment, '') AS personal_comment, COALESCE(ar.is_completed, FALSE) AS is_completed`).
+		Order("l.date_start").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListLeaveRequests lists the leave requests of the student, newest first
+func (r *studentPortalRepository) ListLeaveRequests(ctx context.Context, studentID string, page, limit uint64) (*repositories.Pagination[entities.LeaveRequest], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.LeaveRequest{}).Where("student_id = ?", studentID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: page, Limit: limit}
+	var requests []*entities.LeaveRequest
+	err := query.
+		Preload("Class").
+		Order("apply_date DESC, created_at DESC").
+		Limit(int(limit)).
+		Offset(int((page - 1) * limit)).
+		Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.LeaveRequest]{
+		Data: requests,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package implement
 
 import (
+	"context"
 	"doan/internal/entities"
 	"doan/internal/infrastructure/database/postgres"
 	"doan/internal/repositories"
@@ -8,6 +9,7 @@ import (
 	"doan/pkg/base_struct"
 	"doan/pkg/config"
 	"doan/pkg/logger"
+	"errors"
 
 	"gorm.io/gorm"
 )
@@ -33,3 +35,16 @@ func NewStudentRepository(
 		db:             db,
 	}
 }
+
+// GetByUserID returns the student profile of a login account
+func (r *studentRepository) GetByUserID(ctx context.Context, userID string) (*entities.Student, error) {
+	var student entities.Student
+	err := postgres.GetDb(ctx, r.db).Where("user_id = ?", userID).First(&student).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &student, nil
+}
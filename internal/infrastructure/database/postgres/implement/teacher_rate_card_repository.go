@@ -0,0 +1,111 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type teacherRateCardRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.TeacherRateCard]
+	db *gorm.DB
+}
+
+func NewTeacherRateCardRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.TeacherRateCardRepository {
+	modelRepo := postgres.NewBaseRepository[entities.TeacherRateCard](log, manager, db, "teacher_rate_cards")
+	return &teacherRateCardRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// ListByTeacher lists the rate cards of a teacher, newest effective date first
+func (r *teacherRateCardRepository) ListByTeacher(ctx context.Context, teacherID string, page, limit uint64) (*repositories.Pagination[entities.TeacherRateCard], error) {
+	query := postgres.GetDb(ctx, r.db).Model(&entities.TeacherRateCard{})
+	if teacherID != "" {
+		query = query.Where("teacher_id = ?", teacherID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paging := &repositories.Paging{Page: page, Limit: limit}
+	var cards []*entities.TeacherRateCard
+	err := query.
+		Preload("Teacher").
+		Preload("Course").
+		Order("effective_from DESC, created_at DESC").
+		Limit(int(limit)).
+		Offset(int((page - 1) * limit)).
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories.Pagination[entities.TeacherRateCard]{
+		Data: cards,
+		Meta: repositories.NewMeta(paging, uint64(total)),
+	}, nil
+}
+
+// ListEffective lists the cards of the teachers that are in effect at some point in [from, to)
+func (r *teacherRateCardRepository) ListEffective(ctx context.Context, teacherIDs []string, from, to time.Time) ([]*entities.TeacherRateCard, error) {
+	var cards []*entities.TeacherRateCard
+	if len(teacherIDs) == 0 {
+		return cards, nil
+	}
+	err := postgres.GetDb(ctx, r.db).
+		Where("teacher_id IN ?", teacherIDs).
+		Where("effective_from < ?", to).
+		Where("effective_to IS NULL OR effective_to >= ?", from).
+		Order("teacher_id, effective_from DESC").
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// HasOverlap reports whether another card of the same teacher and course overlaps the given dates
+func (r *teacherRateCardRepository) HasOverlap(ctx context.Context, card *entities.TeacherRateCard) (bool, error) {
+	query := postgres.GetDb(ctx, r.db).
+		Model(&entities.TeacherRateCard{}).
+		Where("teacher_id = ?", card.TeacherID)
+	if card.CourseID == nil {
+		query = query.Where("course_id IS NULL")
+	} else {
+		query = query.Where("course_id = ?", *card.CourseID)
+	}
+	if card.ID != "" {
+		query = query.Where("id <> ?", card.ID)
+	}
+	if card.EffectiveTo != nil {
+		query = query.Where("effective_from <= ?", *card.EffectiveTo)
+	}
+	query = query.Where("effective_to IS NULL OR effective_to >= ?", card.EffectiveFrom)
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -9,6 +9,7 @@ import (
 	"doan/pkg/base_struct"
 	"doan/pkg/config"
 	"doan/pkg/logger"
+	"errors"
 	"fmt"
 	"time"
 
@@ -58,6 +59,19 @@ func (r *teacherRepository) ExistsByCode(ctx context.Context, code string) (bool
 	return count > 0, nil
 }
 
+// GetByUserID returns the teacher profile of a login account
+func (r *teacherRepository) GetByUserID(ctx context.Context, userID string) (*entities.Teacher, error) {
+	var teacher entities.Teacher
+	err := postgres.GetDb(ctx, r.db).Where("user_id = ?", userID).First(&teacher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &teacher, nil
+}
+
 // GetTeacherLessons retrieves all lessons for a teacher within a date range
 func (r *teacherRepository) GetTeacherLessons(ctx context.Context, teacherID string, from, to time.Time) ([]entities.Lesson, error) {
 	var lessons []entities.Lesson
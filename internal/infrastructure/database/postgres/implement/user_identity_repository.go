@@ -0,0 +1,52 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type userIdentityRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.UserIdentity]
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.UserIdentityRepository {
+	modelRepo := postgres.NewBaseRepository[entities.UserIdentity](log, manager, db, "user_identities")
+	return &userIdentityRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetByProviderSubject returns the linked account of a provider, nil when it is not linked
+func (r *userIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*entities.UserIdentity, error) {
+	var identity entities.UserIdentity
+	err := postgres.GetDb(ctx, r.db).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
@@ -0,0 +1,126 @@
+package implement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/infrastructure/database/postgres"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/base_struct"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type userMFARepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.UserMFA]
+	db *gorm.DB
+}
+
+func NewUserMFARepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.UserMFARepository {
+	modelRepo := postgres.NewBaseRepository[entities.UserMFA](log, manager, db, "user_mfas")
+	return &userMFARepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// GetByUserID returns the authenticator of a user, nil when there is none
+func (r *userMFARepository) GetByUserID(ctx context.Context, userID string) (*entities.UserMFA, error) {
+	var mfa entities.UserMFA
+	err := postgres.GetDb(ctx, r.db).Where("user_id = ?", userID).First(&mfa).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mfa, nil
+}
+
+// AdvanceStep records step as the last accepted one if it is later; two requests racing with one code
+// cannot both win
+func (r *userMFARepository) AdvanceStep(ctx context.Context, id string, step int64) (bool, error) {
+	result := postgres.GetDb(ctx, r.db).
+		Model(&entities.UserMFA{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Updates(map[string]interface{}{"last_used_step": step, "updated_at": time.Now()})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteByUserID removes the authenticator and the recovery codes of a user
+func (r *userMFARepository) DeleteByUserID(ctx context.Context, userID string) error {
+	db := postgres.GetDb(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&entities.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return db.Where("user_id = ?", userID).Delete(&entities.UserMFA{}).Error
+}
+
+type userRecoveryCodeRepository struct {
+	base_struct.BaseDependency
+	repositories.BaseRepository[entities.UserRecoveryCode]
+	db *gorm.DB
+}
+
+func NewUserRecoveryCodeRepository(
+	db *gorm.DB,
+	log logger.Logger,
+	manager config.Manager,
+) repointerface.UserRecoveryCodeRepository {
+	modelRepo := postgres.NewBaseRepository[entities.UserRecoveryCode](log, manager, db, "user_recovery_codes")
+	return &userRecoveryCodeRepository{
+		BaseDependency: base_struct.BaseDependency{
+			Log:           log,
+			ConfigManager: manager,
+		},
+		BaseRepository: modelRepo,
+		db:             db,
+	}
+}
+
+// ListUnusedByUser lists the recovery codes of a user that are still usable
+func (r *userRecoveryCodeRepository) ListUnusedByUser(ctx context.Context, userID string) ([]*entities.UserRecoveryCode, error) {
+	var codes []*entities.UserRecoveryCode
+	err := postgres.GetDb(ctx, r.db).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Find(&codes).Error
+	return codes, err
+}
+
+// Replace deletes every recovery code of the user and stores the new hashes
+func (r *userRecoveryCodeRepository) Replace(ctx context.Context, userID string, codeHashes []string) error {
+	db := postgres.GetDb(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&entities.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	codes := make([]*entities.UserRecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = &entities.UserRecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return db.Create(&codes).Error
+}
+
+// MarkUsed spends the code if it is still unused
+func (r *userRecoveryCodeRepository) MarkUsed(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := postgres.GetDb(ctx, r.db).
+		Model(&entities.UserRecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
@@ -9,6 +9,7 @@ import (
 	"doan/pkg/base_struct"
 	"doan/pkg/config"
 	"doan/pkg/logger"
+	"errors"
 	"gorm.io/gorm"
 	"time"
 )
@@ -37,7 +38,7 @@ func NewUserRepository(
 
 func (u *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	err := u.db.WithContext(ctx).Model(&entities.User{}).Where("email = ?", email).Count(&count).Error
+	err := u.db.WithContext(ctx).Unscoped().Model(&entities.User{}).Where("email = ?", email).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -88,3 +89,54 @@ func (u *userRepository) ActivateUserTx(ctx context.Context, tx *gorm.DB, userID
 		Where("id = ?", userID).
 		Update("is_active", true).Error
 }
+
+func (u *userRepository) GetByIDWithDeleted(ctx context.Context, id string) (*entities.User, error) {
+	var user entities.User
+	err := postgres.GetDb(ctx, u.db).WithContext(ctx).Unscoped().Where("id = ?", id).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (u *userRepository) ListDeleted(ctx context.Context, condition *repositories.CommonCondition) (*repositories.Pagination[entities.User], error) {
+	db := postgres.GetDb(ctx, u.db).WithContext(ctx).Unscoped().Model(&entities.User{}).Where("deleted_at IS NOT NULL")
+
+	counted, err := postgres.BuildQuery(db.Session(&gorm.Session{}), &repositories.CommonCondition{
+		Conditions:   condition.Conditions,
+		OrConditions: condition.OrConditions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	if err := counted.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	query, err := postgres.BuildQuery(db.Session(&gorm.Session{}), condition)
+	if err != nil {
+		return nil, err
+	}
+	var users []*entities.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	meta := repositories.Meta{ItemsPerPage: uint64(total), TotalItems: uint64(total), CurrentPage: 1, TotalPages: 1}
+	if paging := condition.Paging; paging != nil && paging.Limit > 0 && total > 0 {
+		meta.ItemsPerPage = paging.Limit
+		meta.CurrentPage = paging.Page
+		meta.TotalPages = (uint64(total) + paging.Limit - 1) / paging.Limit
+	}
+	return &repositories.Pagination[entities.User]{Data: users, Meta: meta}, nil
+}
+
+func (u *userRepository) Restore(ctx context.Context, id string) error {
+	return postgres.GetDb(ctx, u.db).WithContext(ctx).Unscoped().Model(&entities.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}).Error
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"doan/internal/entities"
 	migrationinterface "doan/internal/repositories/migration"
+	"doan/pkg/constants"
 	"doan/pkg/logger"
 	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type migration struct {
@@ -47,7 +49,19 @@ func (m *migration) Up() error {
 	}
 	m.log.Info(m.ctx, "Auto migration completed successfully", "tables", len(entities))
 
-	// Step 3: Seed sample data (only if table is empty)
+	// Step 3: Make the audit log append-only
+	if err := m.installAuditLogGuard(); err != nil {
+		m.log.Error(m.ctx, "Failed to install audit log guard", "error", err)
+		return fmt.Errorf("failed to install audit log guard: %w", err)
+	}
+
+	// Step 4: Seed the built-in roles (existing roles are left untouched)
+	if err := m.seedRoles(); err != nil {
+		m.log.Error(m.ctx, "Failed to seed roles", "error", err)
+		return fmt.Errorf("failed to seed roles: %w", err)
+	}
+
+	// Step 5: Seed sample data (only if table is empty)
 	if err := m.seedSampleData(); err != nil {
 		m.log.Error(m.ctx, "Failed to seed sample data", "error", err)
 		return fmt.Errorf("failed to seed sample data: %w", err)
@@ -94,6 +108,29 @@ func (m *migration) getAllEntities() []interface{} {
 		&entities.LeaveRequest{},
 		&entities.PasswordReset{}, // Add PasswordReset entity for auto-migration
 		&entities.UserOTP{},       // Add UserOTP entity for auto-migration
+		&entities.Material{},
+		&entities.AIAnalysisResult{},
+		&entities.AuditLog{},
+		&entities.Invoice{},
+		&entities.InvoiceLine{},
+		&entities.Payment{},
+		&entities.PaymentAllocation{},
+		&entities.InvoiceReminder{},
+		&entities.ReminderOptOut{},
+		&entities.TeacherRateCard{},
+		&entities.PayrollRun{},
+		&entities.PayrollItem{},
+		&entities.PayrollLine{},
+		&entities.Guardian{},
+		&entities.GuardianStudent{},
+		&entities.AccountInvitation{},
+		&entities.Role{},
+		&entities.AuthSession{},
+		&entities.RefreshToken{},
+		&entities.UserMFA{},
+		&entities.UserRecoveryCode{},
+		&entities.UserIdentity{},
+		&entities.PasswordHistory{},
 	}
 }
 
@@ -102,6 +139,65 @@ func (m *migration) enableUUIDExtension() error {
 	return m.db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).Error
 }
 
+// auditLogGuardSQL refuses every change to audit_logs but the one sealing an entry into the hash chain:
+// setting sequence, prev_hash and hash of an unsealed entry, and nothing else. Actors are kept even when
+// the user is gone, so the SET NULL foreign key of the SQL migrations is replaced by a restricting one.
+const auditLogGuardSQL = `
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF OLD.sequence IS NULL AND NEW.sequence IS NOT NULL
+            AND to_jsonb(NEW) - ARRAY['sequence', 'prev_hash', 'hash'] = to_jsonb(OLD) - ARRAY['sequence', 'prev_hash', 'hash'] THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    RAISE EXCEPTION 'audit_logs is append-only: % refused', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+DROP TRIGGER IF EXISTS audit_logs_no_truncate ON audit_logs;
+CREATE TRIGGER audit_logs_no_truncate BEFORE TRUNCATE ON audit_logs
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_actor_id_fkey;
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS fk_audit_logs_actor;
+ALTER TABLE audit_logs ADD CONSTRAINT fk_audit_logs_actor FOREIGN KEY (actor_id) REFERENCES users(id);
+`
+
+// installAuditLogGuard (re)creates the append-only trigger of audit_logs
+func (m *migration) installAuditLogGuard() error {
+	return m.db.WithContext(m.ctx).Exec(auditLogGuardSQL).Error
+}
+
+// seedRoles inserts the built-in roles, leaving existing ones untouched
+func (m *migration) seedRoles() error {
+	descriptions := map[string]string{
+		constants.RoleAdmin:      "Centre administrator",
+		constants.RoleTeacher:    "Teacher",
+		constants.RoleStudent:    "Student",
+		constants.RoleCompliance: "Compliance officer reviewing audited materials",
+		constants.RoleGuardian:   "Parent or guardian following their children",
+	}
+
+	roles := make([]entities.Role, 0, len(descriptions))
+	for _, name := range []string{constants.RoleAdmin, constants.RoleTeacher, constants.RoleStudent, constants.RoleCompliance, constants.RoleGuardian} {
+		roles = append(roles, entities.Role{
+			Name:        name,
+			Description: descriptions[name],
+			Permissions: constants.DefaultRolePermissions(name),
+			IsSystem:    true,
+		})
+	}
+
+	return m.db.WithContext(m.ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&roles).Error
+}
+
 // seedSampleData inserts sample users if the table is empty
 func (m *migration) seedSampleData() error {
 	var count int64
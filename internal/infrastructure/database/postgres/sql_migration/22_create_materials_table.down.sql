@@ -0,0 +1,4 @@
+-- 22_create_materials_table.down.sql
+-- Drop materials table
+
+DROP TABLE IF EXISTS materials CASCADE;
@@ -0,0 +1,30 @@
+-- 22_create_materials_table.up.sql
+-- Teaching materials uploaded by teachers; file content lives in the blob storage
+
+CREATE TABLE IF NOT EXISTS materials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    course_id UUID REFERENCES courses(id) ON DELETE SET NULL,
+    class_id UUID REFERENCES classes(id) ON DELETE SET NULL,
+    uploaded_by_id UUID NOT NULL REFERENCES users(id),
+    file_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(255) NOT NULL,
+    extension VARCHAR(20),
+    file_size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    storage_driver VARCHAR(20) NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_materials_course_id ON materials(course_id);
+CREATE INDEX IF NOT EXISTS idx_materials_class_id ON materials(class_id);
+CREATE INDEX IF NOT EXISTS idx_materials_uploaded_by_id ON materials(uploaded_by_id);
+CREATE INDEX IF NOT EXISTS idx_materials_checksum ON materials(checksum);
+CREATE INDEX IF NOT EXISTS idx_materials_deleted_at ON materials(deleted_at);
+
+COMMENT ON TABLE materials IS 'Teaching materials';
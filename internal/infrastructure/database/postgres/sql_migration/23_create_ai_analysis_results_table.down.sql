@@ -0,0 +1,12 @@
+-- 23_create_ai_analysis_results_table.down.sql
+-- Drop ai_analysis_results table and the audit columns of materials
+
+DROP TABLE IF EXISTS ai_analysis_results CASCADE;
+
+DROP INDEX IF EXISTS idx_materials_ai_label;
+DROP INDEX IF EXISTS idx_materials_audit_status;
+
+ALTER TABLE materials DROP COLUMN IF EXISTS audit_error;
+ALTER TABLE materials DROP COLUMN IF EXISTS audited_at;
+ALTER TABLE materials DROP COLUMN IF EXISTS ai_label;
+ALTER TABLE materials DROP COLUMN IF EXISTS audit_status;
@@ -0,0 +1,33 @@
+-- 23_create_ai_analysis_results_table.up.sql
+-- AI audit verdicts of uploaded materials and the audit state on the material itself
+
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS audit_status VARCHAR(20) NOT NULL DEFAULT 'PENDING';
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS ai_label VARCHAR(20);
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS audited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS audit_error TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_materials_audit_status ON materials(audit_status);
+CREATE INDEX IF NOT EXISTS idx_materials_ai_label ON materials(ai_label);
+
+CREATE TABLE IF NOT EXISTS ai_analysis_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    material_id UUID NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    analyzer VARCHAR(50) NOT NULL,
+    model VARCHAR(100),
+    label VARCHAR(20) NOT NULL,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    summary TEXT,
+    reasons JSONB NOT NULL DEFAULT '[]',
+    text_length INTEGER NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_ai_analysis_results_material_id ON ai_analysis_results(material_id);
+CREATE INDEX IF NOT EXISTS idx_ai_analysis_results_label ON ai_analysis_results(label);
+CREATE INDEX IF NOT EXISTS idx_ai_analysis_results_deleted_at ON ai_analysis_results(deleted_at);
+
+COMMENT ON TABLE ai_analysis_results IS 'AI content audit results of materials';
@@ -0,0 +1,7 @@
+-- 24_add_material_extraction_columns.down.sql
+-- Drop the text extraction columns of materials
+
+ALTER TABLE materials DROP COLUMN IF EXISTS extracted_at;
+ALTER TABLE materials DROP COLUMN IF EXISTS extraction_errors;
+ALTER TABLE materials DROP COLUMN IF EXISTS extracted_pages;
+ALTER TABLE materials DROP COLUMN IF EXISTS extracted_text;
@@ -0,0 +1,9 @@
+-- 24_add_material_extraction_columns.up.sql
+-- Plain text extracted from materials for the audit, with per-page extraction errors
+
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS extracted_text TEXT;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS extracted_pages INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS extraction_errors JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS extracted_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN materials.extraction_errors IS 'Extraction errors as [{page, message}], page 0 is the whole document';
@@ -0,0 +1,11 @@
+-- 25_create_audit_logs_and_material_review.down.sql
+-- Drop audit_logs table and the review columns of materials
+
+DROP TABLE IF EXISTS audit_logs CASCADE;
+
+DROP INDEX IF EXISTS idx_materials_review_status;
+
+ALTER TABLE materials DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE materials DROP COLUMN IF EXISTS reviewed_by_id;
+ALTER TABLE materials DROP COLUMN IF EXISTS review_comment;
+ALTER TABLE materials DROP COLUMN IF EXISTS review_status;
@@ -0,0 +1,11 @@
+-- 26_add_circular29_compliance.down.sql
+-- Drop the enrollment indexes and the columns added for the rule engine
+
+DROP INDEX IF EXISTS idx_class_schedules_class_id;
+DROP INDEX IF EXISTS idx_enrollments_deleted_at;
+DROP INDEX IF EXISTS idx_enrollments_student_status;
+DROP INDEX IF EXISTS idx_enrollments_class_status;
+
+ALTER TABLE enrollments DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE students DROP COLUMN IF EXISTS school_name;
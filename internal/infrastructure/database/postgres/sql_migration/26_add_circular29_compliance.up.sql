@@ -0,0 +1,12 @@
+-- 26_add_circular29_compliance.up.sql
+-- Data needed by the Circular 29/2024 rule engine and the enrollment approval flow
+
+ALTER TABLE students ADD COLUMN IF NOT EXISTS school_name VARCHAR(255);
+
+ALTER TABLE enrollments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_enrollments_class_status ON enrollments(class_id, status);
+CREATE INDEX IF NOT EXISTS idx_enrollments_student_status ON enrollments(student_id, status);
+CREATE INDEX IF NOT EXISTS idx_enrollments_deleted_at ON enrollments(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_class_schedules_class_id ON class_schedules(class_id);
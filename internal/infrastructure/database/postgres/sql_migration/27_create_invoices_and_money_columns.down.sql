@@ -0,0 +1,11 @@
+-- 27_create_invoices_and_money_columns.down.sql
+-- Drop invoices and restore the numeric price columns
+
+DROP TABLE IF EXISTS invoice_lines CASCADE;
+DROP TABLE IF EXISTS invoices CASCADE;
+DROP SEQUENCE IF EXISTS invoice_number_seq;
+
+ALTER TABLE courses ALTER COLUMN price DROP DEFAULT;
+ALTER TABLE courses ALTER COLUMN price TYPE NUMERIC(10,2) USING price::NUMERIC(10,2);
+ALTER TABLE classes ALTER COLUMN price DROP DEFAULT;
+ALTER TABLE classes ALTER COLUMN price TYPE NUMERIC(10,2) USING price::NUMERIC(10,2);
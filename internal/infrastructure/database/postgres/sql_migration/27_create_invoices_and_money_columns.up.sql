@@ -0,0 +1,56 @@
+-- 27_create_invoices_and_money_columns.up.sql
+-- Tuition invoices; prices become whole đồng stored as BIGINT instead of numeric/float
+
+ALTER TABLE classes ALTER COLUMN price TYPE BIGINT USING COALESCE(ROUND(price), 0)::BIGINT;
+ALTER TABLE classes ALTER COLUMN price SET DEFAULT 0;
+ALTER TABLE courses ALTER COLUMN price TYPE BIGINT USING COALESCE(ROUND(price), 0)::BIGINT;
+ALTER TABLE courses ALTER COLUMN price SET DEFAULT 0;
+
+CREATE SEQUENCE IF NOT EXISTS invoice_number_seq;
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    number VARCHAR(30) NOT NULL UNIQUE,
+    enrollment_id UUID NOT NULL REFERENCES enrollments(id) ON DELETE RESTRICT,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE RESTRICT,
+    class_id UUID NOT NULL REFERENCES classes(id) ON DELETE RESTRICT,
+    billing_plan VARCHAR(20) NOT NULL,
+    installment_no INT NOT NULL DEFAULT 1,
+    installment_count INT NOT NULL DEFAULT 1,
+    currency VARCHAR(3) NOT NULL DEFAULT 'VND',
+    subtotal BIGINT NOT NULL DEFAULT 0,
+    discount_total BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL DEFAULT 0 CHECK (total >= 0),
+    paid_amount BIGINT NOT NULL DEFAULT 0 CHECK (paid_amount >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'UNPAID',
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    due_date DATE NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    code VARCHAR(50),
+    description VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    unit_price BIGINT NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_invoices_enrollment_id ON invoices(enrollment_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_student_status ON invoices(student_id, status);
+CREATE INDEX IF NOT EXISTS idx_invoices_class_id ON invoices(class_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_status ON invoices(status);
+CREATE INDEX IF NOT EXISTS idx_invoices_due_date ON invoices(due_date);
+CREATE INDEX IF NOT EXISTS idx_invoices_deleted_at ON invoices(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines(invoice_id);
+
+COMMENT ON TABLE invoices IS 'Tuition invoices generated on enrollment approval, amounts in VND';
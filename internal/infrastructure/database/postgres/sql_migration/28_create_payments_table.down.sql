@@ -0,0 +1,6 @@
+-- 28_create_payments_table.down.sql
+-- Drop payments and their allocations
+
+DROP TABLE IF EXISTS payment_allocations CASCADE;
+DROP TABLE IF EXISTS payments CASCADE;
+DROP SEQUENCE IF EXISTS receipt_number_seq;
@@ -0,0 +1,49 @@
+-- 28_create_payments_table.up.sql
+-- Payments received against invoices, their allocations, refunds and voids
+
+CREATE SEQUENCE IF NOT EXISTS receipt_number_seq;
+
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    receipt_number VARCHAR(30) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'PAYMENT',
+    method VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'COMPLETED',
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'VND',
+    provider VARCHAR(50),
+    reference VARCHAR(100),
+    payer_name VARCHAR(255),
+    cashier_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    refund_of_id UUID REFERENCES payments(id) ON DELETE RESTRICT,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    voided_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    void_reason TEXT,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS payment_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_reference ON payments(provider, reference)
+    WHERE provider IS NOT NULL AND provider <> '' AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_received_at ON payments(received_at);
+CREATE INDEX IF NOT EXISTS idx_payments_cashier_id ON payments(cashier_id);
+CREATE INDEX IF NOT EXISTS idx_payments_refund_of_id ON payments(refund_of_id);
+CREATE INDEX IF NOT EXISTS idx_payments_status ON payments(status);
+CREATE INDEX IF NOT EXISTS idx_payments_deleted_at ON payments(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_payment_id ON payment_allocations(payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_invoice_id ON payment_allocations(invoice_id);
+
+COMMENT ON TABLE payments IS 'Cash, bank transfer and VietQR payments and refunds, amounts in VND';
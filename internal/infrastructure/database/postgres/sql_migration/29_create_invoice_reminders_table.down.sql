@@ -0,0 +1,8 @@
+-- 29_create_invoice_reminders_table.down.sql
+-- Drop the tuition reminder log and guardian opt-outs
+
+DROP INDEX IF EXISTS idx_invoices_open_due_date;
+DROP TABLE IF EXISTS reminder_opt_outs;
+DROP TABLE IF EXISTS invoice_reminders;
+
+ALTER TABLE students DROP COLUMN IF EXISTS guardian_email;
@@ -0,0 +1,42 @@
+-- 29_create_invoice_reminders_table.up.sql
+-- Tuition reminder log (one row per invoice and dunning step) and guardian opt-outs
+
+ALTER TABLE students ADD COLUMN IF NOT EXISTS guardian_email VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS invoice_reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    step_code VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL DEFAULT 'EMAIL',
+    recipient VARCHAR(255),
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS reminder_opt_outs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    guardian_phone VARCHAR(20) NOT NULL,
+    reason TEXT,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_reminders_step ON invoice_reminders(invoice_id, step_code);
+CREATE INDEX IF NOT EXISTS idx_invoice_reminders_status ON invoice_reminders(status);
+CREATE INDEX IF NOT EXISTS idx_invoice_reminders_deleted_at ON invoice_reminders(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reminder_opt_outs_guardian_phone ON reminder_opt_outs(guardian_phone)
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reminder_opt_outs_deleted_at ON reminder_opt_outs(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_invoices_open_due_date ON invoices(due_date)
+    WHERE status IN ('UNPAID', 'PARTIALLY_PAID') AND deleted_at IS NULL;
+
+COMMENT ON TABLE invoice_reminders IS 'Tuition reminders sent per invoice and dunning step; the unique step index prevents duplicates';
+COMMENT ON TABLE reminder_opt_outs IS 'Guardians who asked not to receive tuition reminders';
@@ -0,0 +1,8 @@
+-- 30_create_payroll_tables.down.sql
+-- Drop teacher rate cards and payroll runs
+
+DROP INDEX IF EXISTS idx_lessons_date_start;
+DROP TABLE IF EXISTS payroll_lines CASCADE;
+DROP TABLE IF EXISTS payroll_items CASCADE;
+DROP TABLE IF EXISTS payroll_runs CASCADE;
+DROP TABLE IF EXISTS teacher_rate_cards CASCADE;
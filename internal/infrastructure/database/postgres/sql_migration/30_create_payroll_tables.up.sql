@@ -0,0 +1,87 @@
+-- 30_create_payroll_tables.up.sql
+-- Teacher rate cards and monthly payroll runs computed from taught lessons
+
+CREATE TABLE IF NOT EXISTS teacher_rate_cards (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    teacher_id UUID NOT NULL REFERENCES teachers(id) ON DELETE CASCADE,
+    course_id UUID REFERENCES courses(id) ON DELETE CASCADE,
+    rate_type VARCHAR(20) NOT NULL CHECK (rate_type IN ('PER_HOUR', 'PER_SESSION', 'PER_CLASS')),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    notes TEXT,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE TABLE IF NOT EXISTS payroll_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    period VARCHAR(7) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    teacher_count INTEGER NOT NULL DEFAULT 0,
+    lesson_count INTEGER NOT NULL DEFAULT 0,
+    unrated_count INTEGER NOT NULL DEFAULT 0,
+    total_amount BIGINT NOT NULL DEFAULT 0,
+    calculated_at TIMESTAMP WITH TIME ZONE,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    finalised_at TIMESTAMP WITH TIME ZONE,
+    finalised_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS payroll_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    run_id UUID NOT NULL REFERENCES payroll_runs(id) ON DELETE CASCADE,
+    teacher_id UUID NOT NULL REFERENCES teachers(id) ON DELETE RESTRICT,
+    sessions INTEGER NOT NULL DEFAULT 0,
+    minutes INTEGER NOT NULL DEFAULT 0,
+    substitution_sessions INTEGER NOT NULL DEFAULT 0,
+    unrated_sessions INTEGER NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS payroll_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    run_id UUID NOT NULL REFERENCES payroll_runs(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES payroll_items(id) ON DELETE CASCADE,
+    lesson_id UUID NOT NULL,
+    class_id UUID NOT NULL,
+    class_code VARCHAR(50),
+    class_name VARCHAR(255),
+    course_id UUID,
+    date_start TIMESTAMP WITH TIME ZONE,
+    minutes INTEGER NOT NULL,
+    is_substitution BOOLEAN NOT NULL DEFAULT FALSE,
+    rate_card_id UUID,
+    rate_type VARCHAR(20),
+    rate BIGINT NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_teacher_rate_cards_teacher_id ON teacher_rate_cards(teacher_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_rate_cards_course_id ON teacher_rate_cards(course_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_rate_cards_deleted_at ON teacher_rate_cards(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payroll_runs_period ON payroll_runs(period) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payroll_runs_deleted_at ON payroll_runs(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_payroll_items_run_id ON payroll_items(run_id);
+CREATE INDEX IF NOT EXISTS idx_payroll_items_teacher_id ON payroll_items(teacher_id);
+CREATE INDEX IF NOT EXISTS idx_payroll_lines_run_id ON payroll_lines(run_id);
+CREATE INDEX IF NOT EXISTS idx_payroll_lines_item_id ON payroll_lines(item_id);
+CREATE INDEX IF NOT EXISTS idx_lessons_date_start ON lessons(date_start);
+
+COMMENT ON TABLE teacher_rate_cards IS 'Teacher pay rates in VND, per hour, session or class, optionally per course';
+COMMENT ON TABLE payroll_runs IS 'Monthly teacher payroll; FINALISED runs are locked';
+COMMENT ON TABLE payroll_lines IS 'Lessons taught in a payroll run with the rate applied at calculation time';
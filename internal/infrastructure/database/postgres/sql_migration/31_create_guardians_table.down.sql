@@ -0,0 +1,5 @@
+-- 31_create_guardians_table.down.sql
+-- Drop guardian profiles and their links to students
+
+DROP TABLE IF EXISTS guardian_students CASCADE;
+DROP TABLE IF EXISTS guardians CASCADE;
@@ -0,0 +1,46 @@
+-- 31_create_guardians_table.up.sql
+-- Parent/guardian profiles linked to students, backfilled from students.guardian_phone
+
+CREATE TABLE IF NOT EXISTS guardians (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    full_name VARCHAR(255),
+    phone VARCHAR(20),
+    email VARCHAR(255),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS guardian_students (
+    guardian_id UUID NOT NULL REFERENCES guardians(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    relationship VARCHAR(20) NOT NULL DEFAULT 'GUARDIAN',
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (guardian_id, student_id)
+);
+
+-- Indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guardians_user_id ON guardians(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_guardians_phone ON guardians(phone);
+CREATE INDEX IF NOT EXISTS idx_guardians_deleted_at ON guardians(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_guardian_students_student_id ON guardian_students(student_id);
+
+-- One guardian per distinct guardian phone, linked to every student sharing it
+INSERT INTO guardians (phone, email)
+SELECT DISTINCT ON (guardian_phone) guardian_phone, NULLIF(guardian_email, '')
+FROM students
+WHERE deleted_at IS NULL AND COALESCE(guardian_phone, '') <> ''
+ORDER BY guardian_phone, created_at;
+
+INSERT INTO guardian_students (guardian_id, student_id, relationship, is_primary)
+SELECT g.id, s.id, 'GUARDIAN', TRUE
+FROM students s
+JOIN guardians g ON g.phone = s.guardian_phone AND g.deleted_at IS NULL
+WHERE s.deleted_at IS NULL
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE guardians IS 'Parents and guardians; user_id links a GUARDIAN login';
+COMMENT ON TABLE guardian_students IS 'Many-to-many link between guardians and their children';
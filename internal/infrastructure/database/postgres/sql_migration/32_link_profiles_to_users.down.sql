@@ -0,0 +1,10 @@
+-- 32_link_profiles_to_users.down.sql
+-- Drop account invitations and the profile to account links
+
+DROP TABLE IF EXISTS account_invitations CASCADE;
+
+DROP INDEX IF EXISTS idx_students_user_id;
+DROP INDEX IF EXISTS idx_teachers_user_id;
+
+ALTER TABLE students DROP COLUMN IF EXISTS user_id;
+ALTER TABLE teachers DROP COLUMN IF EXISTS user_id;
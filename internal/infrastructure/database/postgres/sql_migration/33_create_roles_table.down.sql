@@ -0,0 +1,4 @@
+-- 33_create_roles_table.down.sql
+-- Drop roles; users.role keeps the role names
+
+DROP TABLE IF EXISTS roles CASCADE;
@@ -0,0 +1,24 @@
+-- 33_create_roles_table.up.sql
+-- Roles as bundles of resource:action permissions, seeded with the built-in roles
+
+CREATE TABLE IF NOT EXISTS roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(50) NOT NULL,
+    description TEXT,
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_name ON roles(name);
+
+-- Built-in roles; keep in sync with pkg/constants/permission.go
+INSERT INTO roles (name, description, permissions, is_system, updated_at) VALUES
+    ('ADMIN', 'Centre administrator', '{*}', TRUE, NOW()),
+    ('TEACHER', 'Teacher', '{student:read,material:upload}', TRUE, NOW()),
+    ('STUDENT', 'Student', '{}', TRUE, NOW()),
+    ('COMPLIANCE', 'Compliance officer reviewing audited materials', '{compliance:review,report:read}', TRUE, NOW()),
+    ('GUARDIAN', 'Parent or guardian following their children', '{guardian:portal}', TRUE, NOW())
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,5 @@
+-- 34_create_auth_sessions_table.down.sql
+-- Drop login sessions and their refresh tokens
+
+DROP TABLE IF EXISTS refresh_tokens CASCADE;
+DROP TABLE IF EXISTS auth_sessions CASCADE;
@@ -0,0 +1,5 @@
+-- 35_create_user_mfa_tables.down.sql
+-- Drop two-factor authentication
+
+DROP TABLE IF EXISTS user_recovery_codes CASCADE;
+DROP TABLE IF EXISTS user_mfas CASCADE;
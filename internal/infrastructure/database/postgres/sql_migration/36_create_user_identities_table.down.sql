@@ -0,0 +1,4 @@
+-- 36_create_user_identities_table.down.sql
+-- Drop linked OpenID Connect accounts
+
+DROP TABLE IF EXISTS user_identities CASCADE;
@@ -0,0 +1,4 @@
+-- 37_create_password_histories_table.down.sql
+-- Drop the password history
+
+DROP TABLE IF EXISTS password_histories CASCADE;
@@ -0,0 +1,12 @@
+-- 37_create_password_histories_table.up.sql
+-- Replaced password hashes, so the last ones cannot be reused
+
+CREATE TABLE IF NOT EXISTS password_histories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_password_histories_user_id ON password_histories(user_id, created_at DESC);
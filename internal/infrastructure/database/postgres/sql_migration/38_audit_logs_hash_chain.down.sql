@@ -0,0 +1,19 @@
+-- 38_audit_logs_hash_chain.down.sql
+-- Drop the hash chain and the append-only guard of the audit log
+
+DROP TRIGGER IF EXISTS audit_logs_no_truncate ON audit_logs;
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+DROP FUNCTION IF EXISTS audit_logs_append_only();
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS fk_audit_logs_actor;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
+
+DROP INDEX IF EXISTS idx_audit_logs_sequence;
+DROP INDEX IF EXISTS idx_audit_logs_trace_id;
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS hash;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS sequence;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS trace_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS changes;
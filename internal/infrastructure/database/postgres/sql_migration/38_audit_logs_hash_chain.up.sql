@@ -0,0 +1,43 @@
+-- 38_audit_logs_hash_chain.up.sql
+-- Row level changes, request context and the tamper-evident hash chain of the audit log
+
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS changes JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45);
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS trace_id VARCHAR(100);
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS sequence BIGINT;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS prev_hash VARCHAR(64);
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS hash VARCHAR(64);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_audit_logs_trace_id ON audit_logs(trace_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_logs_sequence ON audit_logs(sequence);
+
+-- Actors are kept even when the user is gone
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_actor_id_fkey;
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS fk_audit_logs_actor;
+ALTER TABLE audit_logs ADD CONSTRAINT fk_audit_logs_actor FOREIGN KEY (actor_id) REFERENCES users(id);
+
+-- Append-only: only sealing an unsealed entry into the chain may update a row
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF OLD.sequence IS NULL AND NEW.sequence IS NOT NULL
+            AND to_jsonb(NEW) - ARRAY['sequence', 'prev_hash', 'hash'] = to_jsonb(OLD) - ARRAY['sequence', 'prev_hash', 'hash'] THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    RAISE EXCEPTION 'audit_logs is append-only: % refused', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+DROP TRIGGER IF EXISTS audit_logs_no_truncate ON audit_logs;
+CREATE TRIGGER audit_logs_no_truncate BEFORE TRUNCATE ON audit_logs
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
+
+COMMENT ON COLUMN audit_logs.changes IS 'Changed columns as {"column": {"old": ..., "new": ...}}';
+COMMENT ON COLUMN audit_logs.sequence IS 'Position in the hash chain, NULL until sealed';
+COMMENT ON COLUMN audit_logs.hash IS 'SHA-256 of the entry and prev_hash';
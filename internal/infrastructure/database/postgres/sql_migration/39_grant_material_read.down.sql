@@ -0,0 +1,6 @@
+-- 39_grant_material_read.down.sql
+-- Take material:read back from the built-in roles
+
+UPDATE roles
+SET permissions = array_remove(permissions, 'material:read'), updated_at = NOW()
+WHERE name IN ('TEACHER', 'COMPLIANCE');
@@ -0,0 +1,7 @@
+-- 39_grant_material_read.up.sql
+-- Material reads need material:read; grant it to the built-in roles that used to read materials by logging in
+
+UPDATE roles
+SET permissions = array_append(permissions, 'material:read'), updated_at = NOW()
+WHERE name IN ('TEACHER', 'COMPLIANCE')
+  AND NOT ('material:read' = ANY(permissions));
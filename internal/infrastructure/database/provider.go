@@ -19,9 +19,32 @@ var DBProvider = wire.NewSet(
 	implement.NewTeacherRepository,
 	implement.NewRoomRepository,
 	implement.NewClassRepository,
+	implement.NewEnrollmentRepository,
+	implement.NewInvoiceRepository,
+	implement.NewPaymentRepository,
+	implement.NewInvoiceReminderRepository,
+	implement.NewReminderOptOutRepository,
+	implement.NewTeacherRateCardRepository,
+	implement.NewPayrollRunRepository,
 	implement.NewStudentRepository,
 	implement.NewCourseRepository,
 	implement.NewProgramRepository,
+	implement.NewMaterialRepository,
+	implement.NewAIAnalysisResultRepository,
+	implement.NewAuditLogRepository,
+	implement.NewMaterialReportRepository,
+	implement.NewDashboardRepository,
+	implement.NewGuardianRepository,
+	implement.NewStudentPortalRepository,
+	implement.NewAccountInvitationRepository,
+	implement.NewRoleRepository,
+	implement.NewAuthSessionRepository,
+	implement.NewRefreshTokenRepository,
+	implement.NewUserMFARepository,
+	implement.NewUserRecoveryCodeRepository,
+	implement.NewUserIdentityRepository,
+	implement.NewPasswordHistoryRepository,
+	postgres.NewUnitOfWork,
 )
 
 // ProvideDB wraps GetDBContext and panics on error (for Wire)
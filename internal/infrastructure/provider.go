@@ -1,24 +1,68 @@
 package infrastructure
 
 import (
+	"doan/internal/infrastructure/caching"
 	"doan/internal/infrastructure/database"
+	queue_temp "doan/internal/infrastructure/queue"
 	_interface "doan/internal/infrastructure/queue/interface"
+	"doan/internal/infrastructure/queue/memory"
 	"doan/internal/infrastructure/queue/noop"
+	"doan/internal/infrastructure/ratelimit"
+	"doan/internal/infrastructure/redis"
+	"doan/internal/infrastructure/storage"
+	"doan/pkg/config"
+	"fmt"
+	"strings"
 
 	"github.com/google/wire"
 )
 
 // InfrastructureProviders provides all infrastructure dependencies
-// Including: Database, Queue, External services
+// Including: Database, Queue, Storage, Cache, External services
 var InfrastructureProviders = wire.NewSet(
 	// Database layer
 	database.DBProvider,
 
 	// Queue infrastructure
 	ProvideQueue,
+
+	// Blob storage (local disk or S3-compatible)
+	storage.BlobStorageProvider,
+
+	// Redis client, dialled only by the drivers configured to use it
+	redis.ClientProvider,
+
+	// Cache (in-process LRU or Redis)
+	caching.CacheManagerProvider,
+
+	// Rate limit policies and their counters
+	ratelimit.RateLimitProvider,
 )
 
-// ProvideQueue provides queue implementation (noop for now)
-func ProvideQueue() _interface.Queue {
-	return noop.New()
+// ProvideQueue provides the queue implementation selected by "queue.driver":
+// memory (default, in-process), noop, kafka or rabbitmq
+func ProvideQueue(cfg config.Manager) _interface.Queue {
+	driver := strings.ToLower(strings.TrimSpace(cfg.GetString("queue.driver")))
+	switch driver {
+	case "", "memory":
+		memoryConfig := memory.Config{}
+		if err := cfg.UnmarshalKey("queue.memory", &memoryConfig); err != nil {
+			panic(fmt.Errorf("read memory queue config: %w", err))
+		}
+		return memory.New(memoryConfig)
+	case "noop":
+		return noop.New()
+	case string(queue_temp.Kafka), string(queue_temp.RabbitMQ):
+		consumerConfig := queue_temp.ConsumerConfig{}
+		if err := cfg.UnmarshalKey("queue", &consumerConfig); err != nil {
+			panic(fmt.Errorf("read queue config: %w", err))
+		}
+		q, err := queue_temp.New(queue_temp.MappingConfig(consumerConfig))
+		if err != nil {
+			panic(err)
+		}
+		return q
+	default:
+		panic(fmt.Errorf("unsupported queue driver %q", driver))
+	}
 }
@@ -0,0 +1,264 @@
+package memory
+
+import (
+	"context"
+	_interface "doan/internal/infrastructure/queue/interface"
+	"doan/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config is the "queue.memory" config block
+type Config struct {
+	BufferSize     int           `mapstructure:"buffer_size"`
+	Workers        int           `mapstructure:"workers"`
+	MaxRetry       int           `mapstructure:"max_retry"`
+	RetryDelay     time.Duration `mapstructure:"retry_delay"`
+	PublishTimeout time.Duration `mapstructure:"publish_timeout"` // how long Publish waits for room in a full topic
+}
+
+// ErrQueueFull is returned by Publish when a topic stays full for PublishTimeout
+var ErrQueueFull = errors.New("memory queue: topic is full")
+
+type envelope struct {
+	message *_interface.Message
+	attempt int
+}
+
+type topic struct {
+	ch       chan envelope
+	consumed bool
+}
+
+// Queue is an in-process queue backed by buffered channels.
+// Messages are lost on restart, so it suits single instance deployments and local development.
+type Queue struct {
+	config Config
+	topics map[string]*topic
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+	ctx    context.Context
+	closed bool
+}
+
+func New(config Config) _interface.Queue {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 100
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.MaxRetry <= 0 {
+		config.MaxRetry = 3
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = 5 * time.Second
+	}
+	if config.PublishTimeout <= 0 {
+		config.PublishTimeout = time.Second
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Queue{
+		config: config,
+		topics: make(map[string]*topic),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (q *Queue) CreateTopic(ctx context.Context, topicOption _interface.TopicOption) error {
+	name, err := topicName(topicOption)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.getOrCreateTopic(name)
+	return nil
+}
+
+func (q *Queue) Publish(ctx context.Context, topicOption _interface.TopicOption, message *_interface.Message) error {
+	name, err := topicName(topicOption)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return errors.New("memory queue: message is nil")
+	}
+
+	// Data is serialized like the broker backed queues so handlers decode the same payload
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return fmt.Errorf("memory queue: marshal message data: %w", err)
+	}
+	msgId := uuid.NewString()
+	if message.Id != nil {
+		msgId = *message.Id
+	}
+	copied := &_interface.Message{
+		Id:   &msgId,
+		Key:  message.Key,
+		Data: data,
+		Meta: message.Meta,
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return errors.New("memory queue: queue is closed")
+	}
+	t := q.getOrCreateTopic(name)
+	q.mu.Unlock()
+
+	// A full topic holds the caller for PublishTimeout at most, so a slow consumer cannot hang requests
+	timer := time.NewTimer(q.config.PublishTimeout)
+	defer timer.Stop()
+	select {
+	case t.ch <- envelope{message: copied}:
+		logger.NewLogger(ctx).Infof("[MemoryQueue] Message published to topic %s - id: %s", name, msgId)
+		return nil
+	case <-timer.C:
+		logger.NewLogger(ctx).Warnf("[MemoryQueue] Topic %s is full, message not published - id: %s", name, msgId)
+		return ErrQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-q.ctx.Done():
+		return errors.New("memory queue: queue is closed")
+	}
+}
+
+func (q *Queue) Consume(ctx context.Context, topicOption _interface.TopicOption, handler _interface.Handler) error {
+	name, err := topicName(topicOption)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	t := q.getOrCreateTopic(name)
+	if t.consumed {
+		q.mu.Unlock()
+		return fmt.Errorf("memory queue: topic %s is already consumed", name)
+	}
+	t.consumed = true
+	q.mu.Unlock()
+
+	logger.NewLogger(ctx).Infof("[MemoryQueue] Start %d consumer(s) for topic: %s", q.config.Workers, name)
+	for i := 0; i < q.config.Workers; i++ {
+		q.wg.Add(1)
+		go q.consume(ctx, name, t, handler)
+	}
+	return nil
+}
+
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	q.cancel()
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) consume(ctx context.Context, name string, t *topic, handler _interface.Handler) {
+	defer q.wg.Done()
+	ctxLogger := logger.NewLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			ctxLogger.Warnf("[MemoryQueue] Stop consumer for topic: %s", name)
+			return
+		case <-q.ctx.Done():
+			return
+		case env := <-t.ch:
+			q.handle(ctx, name, t, handler, env)
+		}
+	}
+}
+
+func (q *Queue) handle(ctx context.Context, name string, t *topic, handler _interface.Handler, env envelope) {
+	ctxLogger := logger.NewLogger(ctx)
+	msgId := *env.message.Id
+
+	status, err := q.safeHandle(ctx, handler, env.message)
+	if err != nil {
+		ctxLogger.Errorf("[MemoryQueue] Failed to handle message from topic %s - id: %s: %v", name, msgId, err)
+	}
+
+	switch status {
+	case _interface.Success:
+		return
+	case _interface.Retry:
+		env.attempt++
+		if env.attempt >= q.config.MaxRetry {
+			ctxLogger.Warnf("[MemoryQueue] Max retry reached, dropping message from topic %s - id: %s", name, msgId)
+			return
+		}
+		ctxLogger.Warnf("[MemoryQueue] Retry %d for message from topic %s - id: %s", env.attempt, name, msgId)
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			timer := time.NewTimer(q.config.RetryDelay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				select {
+				case t.ch <- env:
+				case <-q.ctx.Done():
+				}
+			case <-q.ctx.Done():
+			}
+		}()
+	default:
+		ctxLogger.Warnf("[MemoryQueue] Message rejected from topic %s - id: %s", name, msgId)
+	}
+}
+
+// safeHandle keeps a panicking handler from killing the consumer goroutine
+func (q *Queue) safeHandle(ctx context.Context, handler _interface.Handler, message *_interface.Message) (status _interface.Response, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			status = _interface.Failed
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, message)
+}
+
+func (q *Queue) getOrCreateTopic(name string) *topic {
+	t, ok := q.topics[name]
+	if !ok {
+		t = &topic{ch: make(chan envelope, q.config.BufferSize)}
+		q.topics[name] = t
+	}
+	return t
+}
+
+func topicName(topicOption _interface.TopicOption) (string, error) {
+	if topicOption.KafkaTopicOption != nil && topicOption.KafkaTopicOption.TopicName != "" {
+		return topicOption.KafkaTopicOption.TopicName, nil
+	}
+	if topicOption.RabbitTopicOption != nil && topicOption.RabbitTopicOption.QueueName != nil && *topicOption.RabbitTopicOption.QueueName != "" {
+		return *topicOption.RabbitTopicOption.QueueName, nil
+	}
+	return "", errors.New("memory queue: topic name is required")
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	_interface "doan/internal/infrastructure/queue/interface"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPublishToFullTopic(t *testing.T) {
+	topic := _interface.TopicOption{KafkaTopicOption: &_interface.KafkaTopicOption{TopicName: "audit"}}
+	q := New(Config{BufferSize: 1, PublishTimeout: 20 * time.Millisecond})
+	defer q.Close(context.Background())
+
+	ctx := context.Background()
+	if err := q.Publish(ctx, topic, &_interface.Message{Data: "first"}); err != nil {
+		t.Fatalf("first Publish: %v", err)
+	}
+
+	started := time.Now()
+	err := q.Publish(ctx, topic, &_interface.Message{Data: "second"})
+	if !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Publish to a full topic err = %v, want ErrQueueFull", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Publish waited %v", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := q.Publish(cancelled, topic, &_interface.Message{Data: "third"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Publish with a cancelled context err = %v, want context.Canceled", err)
+	}
+}
+
+func TestPublishConsume(t *testing.T) {
+	topic := _interface.TopicOption{KafkaTopicOption: &_interface.KafkaTopicOption{TopicName: "audit"}}
+	q := New(Config{})
+	defer q.Close(context.Background())
+
+	received := make(chan string, 1)
+	err := q.Consume(context.Background(), topic, func(ctx context.Context, message *_interface.Message) (_interface.Response, error) {
+		received <- string(message.Data.([]byte))
+		return _interface.Success, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Publish(context.Background(), topic, &_interface.Message{Data: map[string]string{"id": "42"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-received:
+		if got != `{"id":"42"}` {
+			t.Errorf("handler got %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+}
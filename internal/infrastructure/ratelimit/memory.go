@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"doan/internal/ratelimit"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many requests pass between two sweeps of idle keys
+const sweepEvery = 1000
+
+type bucketState struct {
+	tokens    float64
+	last      time.Time
+	expiresAt time.Time // the bucket is full again by then, as good as a new one
+}
+
+type windowState struct {
+	start     time.Time // start of the current fixed window
+	current   int64
+	previous  int64
+	expiresAt time.Time // both counts are out of the sliding window by then
+}
+
+// memoryStore keeps counters in process; each instance of the API enforces its own limits
+type memoryStore struct {
+	mu       sync.Mutex
+	buckets  map[string]*bucketState
+	windows  map[string]*windowState
+	requests int
+	now      func() time.Time
+}
+
+// NewMemoryStore creates an in-process rate limit store
+func NewMemoryStore() ratelimit.Store {
+	return &memoryStore{
+		buckets: make(map[string]*bucketState),
+		windows: make(map[string]*windowState),
+		now:     time.Now,
+	}
+}
+
+func (m *memoryStore) Allow(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.requests++
+	if m.requests%sweepEvery == 0 {
+		m.sweep(now)
+	}
+
+	if policy.Algorithm == ratelimit.AlgorithmTokenBucket {
+		return m.tokenBucket(key, policy, now), nil
+	}
+	return m.slidingWindow(key, policy, now), nil
+}
+
+func (m *memoryStore) tokenBucket(key string, policy ratelimit.Policy, now time.Time) ratelimit.Result {
+	capacity := float64(policy.Capacity())
+	state, ok := m.buckets[key]
+	if !ok {
+		state = &bucketState{tokens: capacity, last: now}
+		m.buckets[key] = state
+	}
+
+	perSecond := float64(policy.Limit) / policy.Window().Seconds()
+	state.tokens = min(capacity, state.tokens+now.Sub(state.last).Seconds()*perSecond)
+	state.last = now
+	state.expiresAt = now.Add(time.Duration((capacity - state.tokens + 1) / perSecond * float64(time.Second)))
+
+	allowed := state.tokens >= 1
+	if allowed {
+		state.tokens--
+	}
+	return ratelimit.TokenBucketResult(policy, state.tokens, allowed)
+}
+
+func (m *memoryStore) slidingWindow(key string, policy ratelimit.Policy, now time.Time) ratelimit.Result {
+	window := policy.Window()
+	start := now.Truncate(window)
+	state, ok := m.windows[key]
+	if !ok {
+		state = &windowState{start: start}
+		m.windows[key] = state
+	}
+
+	switch {
+	case state.start.Equal(start):
+	case state.start.Add(window).Equal(start):
+		state.previous, state.current = state.current, 0
+		state.start = start
+	default:
+		state.previous, state.current = 0, 0
+		state.start = start
+	}
+	state.expiresAt = start.Add(2 * window)
+
+	elapsed := now.Sub(start)
+	weight := float64(window-elapsed) / float64(window)
+	allowed := float64(state.previous)*weight+float64(state.current)+1 <= float64(policy.Limit)
+	if allowed {
+		state.current++
+	}
+	return ratelimit.SlidingWindowResult(policy, state.previous, state.current, elapsed, allowed)
+}
+
+// sweep forgets keys idle long enough that they would start afresh anyway
+func (m *memoryStore) sweep(now time.Time) {
+	for key, state := range m.buckets {
+		if now.After(state.expiresAt) {
+			delete(m.buckets, key)
+		}
+	}
+	for key, state := range m.windows {
+		if now.After(state.expiresAt) {
+			delete(m.windows, key)
+		}
+	}
+}
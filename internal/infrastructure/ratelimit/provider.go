@@ -0,0 +1,30 @@
+package ratelimit
+
+import (
+	"doan/internal/infrastructure/redis"
+	"doan/internal/ratelimit"
+	"doan/pkg/config"
+	"fmt"
+	"strings"
+
+	"github.com/google/wire"
+)
+
+var RateLimitProvider = wire.NewSet(ProvideStore, ratelimit.NewLimiter)
+
+// ProvideStore provides the store selected by "rate_limit.store": memory (default, per process)
+// or redis, shared by every instance and configured by the "redis" block
+func ProvideStore(cfg config.Manager, redisClient redis.Client) ratelimit.Store {
+	store := strings.ToLower(strings.TrimSpace(cfg.GetString("rate_limit.store")))
+	switch store {
+	case "", "memory":
+		return NewMemoryStore()
+	case "redis":
+		if cfg.GetString("redis.host") == "" {
+			panic(fmt.Errorf("redis.host is required by the redis rate limit store"))
+		}
+		return NewRedisStore(redisClient)
+	default:
+		panic(fmt.Errorf("unsupported rate limit store %q", store))
+	}
+}
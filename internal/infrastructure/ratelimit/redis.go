@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"doan/internal/infrastructure/redis"
+	"doan/internal/ratelimit"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Both scripts read the clock of the Redis server, so instances with skewed clocks share one timeline.
+
+// tokenBucketScript refills and takes one token. ARGV: capacity, tokens per millisecond.
+// Returns {allowed, tokens left as a string}.
+const tokenBucketScript = `local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens + 1) / rate))
+return {allowed, tostring(tokens)}`
+
+// slidingWindowScript counts the request in the current fixed window when the weighted sum of both windows
+// leaves room. ARGV: window in milliseconds, limit. Returns {allowed, previous, current, elapsed ms}.
+const slidingWindowScript = `local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local index = math.floor(now / window)
+local elapsed = now - index * window
+local currentKey = KEYS[1] .. ':' .. string.format('%d', index)
+local previousKey = KEYS[1] .. ':' .. string.format('%d', index - 1)
+local current = tonumber(redis.call('GET', currentKey) or '0')
+local previous = tonumber(redis.call('GET', previousKey) or '0')
+local allowed = 0
+if previous * (window - elapsed) / window + current + 1 <= limit then
+	current = redis.call('INCR', currentKey)
+	redis.call('PEXPIRE', currentKey, window * 2)
+	allowed = 1
+end
+return {allowed, previous, current, elapsed}`
+
+// redisStore keeps counters in Redis, so every instance of the API enforces the same limits
+type redisStore struct {
+	client redis.Client
+}
+
+// NewRedisStore creates a rate limit store on top of a Redis client
+func NewRedisStore(client redis.Client) ratelimit.Store {
+	return &redisStore{client: client}
+}
+
+func (r *redisStore) Allow(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	if policy.Algorithm == ratelimit.AlgorithmTokenBucket {
+		return r.tokenBucket(ctx, key, policy)
+	}
+	return r.slidingWindow(ctx, key, policy)
+}
+
+func (r *redisStore) tokenBucket(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	perMs := float64(policy.Limit) / float64(policy.Window().Milliseconds())
+	reply, err := r.client.Do(ctx, "EVAL", tokenBucketScript, "1", key,
+		strconv.Itoa(policy.Capacity()), strconv.FormatFloat(perMs, 'g', -1, 64))
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return ratelimit.Result{}, fmt.Errorf("redis: unexpected token bucket reply %v", reply)
+	}
+	allowed, _ := values[0].(int64)
+	raw, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("redis: unexpected token count %q", raw)
+	}
+	return ratelimit.TokenBucketResult(policy, tokens, allowed == 1), nil
+}
+
+func (r *redisStore) slidingWindow(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	reply, err := r.client.Do(ctx, "EVAL", slidingWindowScript, "1", key,
+		strconv.FormatInt(policy.Window().Milliseconds(), 10), strconv.Itoa(policy.Limit))
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 4 {
+		return ratelimit.Result{}, fmt.Errorf("redis: unexpected sliding window reply %v", reply)
+	}
+	numbers := make([]int64, len(values))
+	for i, value := range values {
+		if numbers[i], ok = value.(int64); !ok {
+			return ratelimit.Result{}, fmt.Errorf("redis: unexpected sliding window reply %v", reply)
+		}
+	}
+	elapsed := time.Duration(numbers[3]) * time.Millisecond
+	return ratelimit.SlidingWindowResult(policy, numbers[1], numbers[2], elapsed, numbers[0] == 1), nil
+}
@@ -0,0 +1,282 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/types"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/wire"
+)
+
+const (
+	defaultPoolSize     = 10
+	defaultDialTimeout  = 5 * time.Second
+	defaultReadTimeout  = 3 * time.Second
+	defaultWriteTimeout = 3 * time.Second
+)
+
+var ClientProvider = wire.NewSet(ProvideClient)
+
+// Error is an error reply from the server; the connection that returned it is still usable
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Client runs commands against a single Redis server. Replies are decoded as string, int64,
+// []interface{} or nil for a null reply; an error element of an array is an Error value.
+type Client interface {
+	Do(ctx context.Context, args ...string) (interface{}, error)
+}
+
+// client speaks RESP over a bounded pool of connections
+type client struct {
+	config types.RedisConfig
+	addr   string
+	slots  chan struct{} // one token per connection that may be open
+	idle   chan *conn    // connections ready for reuse
+}
+
+type conn struct {
+	netConn net.Conn
+	reader  *bufio.Reader
+}
+
+// ProvideClient provides a client for the "redis" config block. Nothing is dialled until the first
+// command, so the client costs nothing when no driver is configured to use Redis.
+func ProvideClient(cfg config.Manager) Client {
+	redisConfig := types.RedisConfig{}
+	if err := cfg.UnmarshalKey("redis", &redisConfig); err != nil {
+		panic(fmt.Errorf("read redis config: %w", err))
+	}
+	return NewClient(redisConfig)
+}
+
+// NewClient creates a client for the server of config. MinIdleConns connections are opened in the
+// background on a best-effort basis; the others are opened on demand, up to PoolSize.
+func NewClient(config types.RedisConfig) Client {
+	if config.PoolSize <= 0 {
+		config.PoolSize = defaultPoolSize
+	}
+	if config.Port == "" {
+		config.Port = "6379"
+	}
+	c := &client{
+		config: config,
+		addr:   net.JoinHostPort(config.Host, config.Port),
+		slots:  make(chan struct{}, config.PoolSize),
+		idle:   make(chan *conn, config.PoolSize),
+	}
+	if config.MinIdleConns > 0 && config.Host != "" {
+		go c.warmUp(min(config.MinIdleConns, config.PoolSize))
+	}
+	return c
+}
+
+// Do runs one command, retrying up to MaxRetries times on a fresh connection when writing it failed.
+// Once the command was written the server may have run it, so a failed read is returned as is: running
+// it again could apply it twice, INCR counting one request twice.
+func (c *client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		cn, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reply, sent, err := cn.command(ctx, c.config, args...)
+		var replyErr Error
+		broken := err != nil && !errors.As(err, &replyErr)
+		c.release(cn, broken)
+		if !broken || sent {
+			return reply, err
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *client) warmUp(n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case c.slots <- struct{}{}:
+		default:
+			return
+		}
+		cn, err := c.dial(context.Background())
+		if err != nil {
+			<-c.slots
+			return
+		}
+		c.release(cn, false)
+	}
+}
+
+// acquire takes an idle connection or dials a new one, waiting while the pool is exhausted. Idle
+// connections the server has closed meanwhile are dropped here, as a command written to one could not
+// be retried.
+func (c *client) acquire(ctx context.Context) (*conn, error) {
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	for cn := c.takeIdle(); cn != nil; cn = c.takeIdle() {
+		if cn.reader.Buffered() == 0 && connAlive(cn.netConn) {
+			return cn, nil
+		}
+		_ = cn.netConn.Close()
+	}
+	cn, err := c.dial(ctx)
+	if err != nil {
+		<-c.slots
+		return nil, err
+	}
+	return cn, nil
+}
+
+// takeIdle returns an idle connection, nil when there is none
+func (c *client) takeIdle() *conn {
+	select {
+	case cn := <-c.idle:
+		return cn
+	default:
+		return nil
+	}
+}
+
+func (c *client) release(cn *conn, broken bool) {
+	if broken {
+		_ = cn.netConn.Close()
+	} else {
+		c.idle <- cn
+	}
+	<-c.slots
+}
+
+// dial opens a connection, then authenticates and selects the configured database
+func (c *client) dial(ctx context.Context) (*conn, error) {
+	dialer := net.Dialer{Timeout: durationMs(c.config.DialTimeoutMs, defaultDialTimeout)}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis: connect %s: %w", c.addr, err)
+	}
+	cn := &conn{netConn: netConn, reader: bufio.NewReader(netConn)}
+
+	var setup [][]string
+	if c.config.Password != "" {
+		setup = append(setup, []string{"AUTH", c.config.Password})
+	}
+	if c.config.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.config.DB)})
+	}
+	for _, args := range setup {
+		if _, _, err := cn.command(ctx, c.config, args...); err != nil {
+			_ = netConn.Close()
+			return nil, fmt.Errorf("redis: %s: %w", strings.ToLower(args[0]), err)
+		}
+	}
+	return cn, nil
+}
+
+// command writes args as a RESP array and reads one reply. sent reports whether the whole command was
+// written; a partly written command is never run, the server discards it when the connection closes.
+func (cn *conn) command(ctx context.Context, config types.RedisConfig, args ...string) (reply interface{}, sent bool, err error) {
+	if err := cn.netConn.SetWriteDeadline(deadline(ctx, durationMs(config.WriteTimeoutMs, defaultWriteTimeout))); err != nil {
+		return nil, false, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(cn.netConn, b.String()); err != nil {
+		return nil, false, err
+	}
+
+	if err := cn.netConn.SetReadDeadline(deadline(ctx, durationMs(config.ReadTimeoutMs, defaultReadTimeout))); err != nil {
+		return nil, true, err
+	}
+	reply, err = cn.readReply()
+	return reply, true, err
+}
+
+// readReply decodes one RESP2 reply: strings, integers and arrays; a null bulk string is nil
+func (cn *conn) readReply() (interface{}, error) {
+	line, err := cn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(cn.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			item, err := cn.readReply()
+			var replyErr Error
+			switch {
+			case errors.As(err, &replyErr):
+				// Keep reading so the connection stays in sync; the caller sees the error element
+				items[i] = replyErr
+			case err != nil:
+				return nil, err
+			default:
+				items[i] = item
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+// deadline is now plus timeout, or the context deadline when that comes first
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	d := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(d) {
+		return ctxDeadline
+	}
+	return d
+}
+
+func durationMs(ms int, fallback time.Duration) time.Duration {
+	if ms <= 0 {
+		return fallback
+	}
+	return time.Duration(ms) * time.Millisecond
+}
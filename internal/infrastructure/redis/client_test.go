@@ -0,0 +1,226 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"doan/pkg/types"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer speaks just enough RESP to count INCR calls. A command named in dropReply is run, then the
+// connection is closed instead of replying, like a server dying between running a command and answering.
+type fakeServer struct {
+	listener net.Listener
+
+	mu        sync.Mutex
+	counter   int64
+	commands  int
+	conns     []net.Conn
+	dropReply map[string]bool
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{listener: listener, dropReply: make(map[string]bool)}
+	t.Cleanup(func() {
+		listener.Close()
+		s.closeConns()
+	})
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) client(maxRetries int) Client {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return NewClient(types.RedisConfig{Host: host, Port: port, PoolSize: 1, MaxRetries: maxRetries, ReadTimeoutMs: 1000})
+}
+
+func (s *fakeServer) serve() {
+	for {
+		netConn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, netConn)
+		s.mu.Unlock()
+		go s.handle(netConn)
+	}
+}
+
+// closeConns closes every connection from the server side, as an idle timeout would
+func (s *fakeServer) closeConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, netConn := range s.conns {
+		netConn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *fakeServer) handle(netConn net.Conn) {
+	defer netConn.Close()
+	reader := bufio.NewReader(netConn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands++
+		var reply string
+		switch strings.ToUpper(args[0]) {
+		case "INCR":
+			s.counter++
+			reply = fmt.Sprintf(":%d\r\n", s.counter)
+		case "PING":
+			reply = "+PONG\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		drop := s.dropReply[strings.ToUpper(args[0])]
+		s.mu.Unlock()
+		if drop {
+			return
+		}
+		if _, err := io.WriteString(netConn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("bad command header %q", line)
+	}
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func (s *fakeServer) stats() (counter int64, commands int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counter, s.commands
+}
+
+func TestDoDoesNotRetryAfterTheWrite(t *testing.T) {
+	server := newFakeServer(t)
+	server.dropReply["INCR"] = true
+	c := server.client(3)
+
+	if _, err := c.Do(context.Background(), "INCR", "hits"); err == nil {
+		t.Fatal("Do returned no error for a reply that never came")
+	}
+	if counter, commands := server.stats(); counter != 1 || commands != 1 {
+		t.Errorf("server ran %d commands and counted %d, want INCR run once", commands, counter)
+	}
+}
+
+// failingConn fails every write, as a connection reset before the command went out
+type failingConn struct {
+	net.Conn
+}
+
+func (failingConn) Write(b []byte) (int, error)        { return 0, errors.New("connection reset by peer") }
+func (failingConn) SetWriteDeadline(t time.Time) error { return nil }
+func (failingConn) Close() error                       { return nil }
+
+func TestDoRetriesAFailedWrite(t *testing.T) {
+	server := newFakeServer(t)
+	c := server.client(1).(*client)
+
+	// failingConn does not expose its socket, so acquire hands it out and the write is what fails
+	dead := failingConn{}
+	c.idle <- &conn{netConn: dead, reader: bufio.NewReader(dead)}
+
+	reply, err := c.Do(context.Background(), "INCR", "hits")
+	if err != nil || reply != int64(1) {
+		t.Fatalf("Do = %v, %v; want 1 from a fresh connection", reply, err)
+	}
+	if counter, commands := server.stats(); counter != 1 || commands != 1 {
+		t.Errorf("server ran %d commands and counted %d, want INCR run once", commands, counter)
+	}
+}
+
+func TestDoReplacesIdleConnectionsClosedByTheServer(t *testing.T) {
+	server := newFakeServer(t)
+	c := server.client(0)
+	ctx := context.Background()
+
+	if reply, err := c.Do(ctx, "INCR", "hits"); err != nil || reply != int64(1) {
+		t.Fatalf("first Do = %v, %v; want 1", reply, err)
+	}
+
+	// The server drops the pooled connection while it sits idle
+	server.closeConns()
+	time.Sleep(50 * time.Millisecond)
+
+	// Without retries left, the second INCR only succeeds when the dead connection is never written to
+	if reply, err := c.Do(ctx, "INCR", "hits"); err != nil || reply != int64(2) {
+		t.Fatalf("Do after the server closed the idle connection = %v, %v; want 2", reply, err)
+	}
+	if counter, commands := server.stats(); counter != 2 || commands != 2 {
+		t.Errorf("server ran %d commands and counted %d, want 2 of each", commands, counter)
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"simple string", "+OK\r\n", "OK"},
+		{"integer", ":42\r\n", "42"},
+		{"bulk string", "$5\r\nhello\r\n", "hello"},
+		{"null bulk string", "$-1\r\n", "<nil>"},
+		{"array with an error element", "*2\r\n:1\r\n-ERR wrong\r\n", "[1 redis: ERR wrong]"},
+		{"error", "-ERR boom\r\n", "error: redis: ERR boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cn := &conn{reader: bufio.NewReader(strings.NewReader(tt.input))}
+			reply, err := cn.readReply()
+			got := fmt.Sprint(reply)
+			if err != nil {
+				got = "error: " + err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("readReply = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
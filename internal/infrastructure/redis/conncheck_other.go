@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package redis
+
+import "net"
+
+// connAlive cannot peek at the socket on this platform; a closed connection shows when it is used
+func connAlive(netConn net.Conn) bool {
+	return true
+}
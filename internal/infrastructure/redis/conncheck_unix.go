@@ -0,0 +1,33 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package redis
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// connAlive peeks at the socket without blocking: an idle connection has nothing to read, so end of
+// file, pending data or an error mean the server closed it or it is out of sync
+func connAlive(netConn net.Conn) bool {
+	sysConn, ok := netConn.(syscall.Conn)
+	if !ok {
+		return true
+	}
+	rawConn, err := sysConn.SyscallConn()
+	if err != nil {
+		return false
+	}
+
+	alive := false
+	err = rawConn.Read(func(fd uintptr) bool {
+		var buf [1]byte
+		// Nothing to read yet is the only healthy answer; 0 bytes is end of file
+		_, _, err := syscall.Recvfrom(int(fd), buf[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		alive = errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EWOULDBLOCK)
+		// Done either way, never wait for the socket to become readable
+		return true
+	})
+	return err == nil && alive
+}
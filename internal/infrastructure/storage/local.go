@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"doan/internal/storage"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const DriverLocal = "local"
+
+type localStorage struct {
+	root string
+}
+
+// NewLocalStorage stores objects as files under root
+func NewLocalStorage(root string) (storage.BlobStorage, error) {
+	if root == "" {
+		root = "./data/storage"
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolve storage root: %w", err)
+	}
+	if err := os.MkdirAll(absRoot, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage root: %w", err)
+	}
+	return &localStorage{root: absRoot}, nil
+}
+
+func (s *localStorage) Driver() string {
+	return DriverLocal
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create object directory: %w", err)
+	}
+
+	// Write to a temp file first so readers never see a partially written object
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("commit object: %w", err)
+	}
+	return nil
+}
+
+func (s *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, storage.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("open object: %w", err)
+	}
+	return f, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	return nil
+}
+
+// resolve maps a key to a path inside root and rejects keys escaping it
+func (s *localStorage) resolve(key string) (string, error) {
+	cleaned := filepath.Clean("/" + filepath.FromSlash(key))
+	path := filepath.Join(s.root, cleaned)
+	if path == s.root || !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return path, nil
+}
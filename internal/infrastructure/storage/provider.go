@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"doan/internal/storage"
+	"doan/pkg/config"
+	"doan/pkg/types"
+	"fmt"
+	"strings"
+
+	"github.com/google/wire"
+)
+
+var BlobStorageProvider = wire.NewSet(ProvideBlobStorage)
+
+// ProvideBlobStorage wraps NewBlobStorage and panics on error (for Wire)
+func ProvideBlobStorage(cfg config.Manager) storage.BlobStorage {
+	blobStorage, err := NewBlobStorage(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return blobStorage
+}
+
+// NewBlobStorage selects the storage backend from the "storage.driver" config (local by default)
+func NewBlobStorage(cfg config.Manager) (storage.BlobStorage, error) {
+	storageConfig := types.BlobStorageConfig{}
+	if err := cfg.UnmarshalKey("storage", &storageConfig); err != nil {
+		return nil, fmt.Errorf("read storage config: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(storageConfig.Driver)) {
+	case "", DriverLocal:
+		return NewLocalStorage(storageConfig.Local.Root)
+	case DriverS3, "minio":
+		return NewS3Storage(storageConfig.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", storageConfig.Driver)
+	}
+}
@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"doan/internal/storage"
+	"doan/pkg/types"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DriverS3 = "s3"
+
+	s3Service          = "s3"
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3AmzDateFormat    = "20060102T150405Z"
+	s3ShortDateFormat  = "20060102"
+	defaultS3Region    = "us-east-1"
+	defaultS3TimeoutIn = 60
+)
+
+// s3Storage talks to S3-compatible object storage using signature V4 over plain HTTP requests
+type s3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Storage creates a storage backed by an S3-compatible bucket (AWS S3, MinIO, R2, ...)
+func NewS3Storage(cfg types.S3StorageConfig) (storage.BlobStorage, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 storage: bucket is required")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("s3 storage: access_key and secret_key are required")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("s3 storage: invalid endpoint: %w", err)
+	}
+	timeout := cfg.TimeoutSeconds
+	if timeout <= 0 {
+		timeout = defaultS3TimeoutIn
+	}
+	return &s3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		pathStyle: cfg.ForcePathStyle,
+		client:    &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *s3Storage) Driver() string {
+	return DriverS3
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError("put", key, resp)
+	}
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, storage.ErrObjectNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s.responseError("get", key, resp)
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// S3 answers 204 for deletes, including keys that do not exist
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.responseError("delete", key, resp)
+	}
+	return nil
+}
+
+func (s *s3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	key = strings.TrimLeft(key, "/")
+	if key == "" {
+		return nil, errors.New("s3 storage: empty object key")
+	}
+
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path = "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + s.endpoint.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = encodeS3Path(u.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("s3 storage: build request: %w", err)
+	}
+	return req, nil
+}
+
+func (s *s3Storage) do(req *http.Request) (*http.Response, error) {
+	s.sign(req)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 storage: %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+func (s *s3Storage) responseError(op, key string, resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 storage: %s %q failed with status %d: %s", op, key, resp.StatusCode, strings.TrimSpace(string(msg)))
+}
+
+// sign adds AWS signature V4 headers to the request. The payload is sent unsigned so uploads can be streamed.
+func (s *s3Storage) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format(s3AmzDateFormat)
+	shortDate := now.Format(s3ShortDateFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaderNames := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	sort.Strings(signedHeaderNames)
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaderNames {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(signedHeaderNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, s.region, s3Service, "aws4_request"}, "/")
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(hashedRequest[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(values url.Values) string {
+	if len(values) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodeS3Path URI-encodes every path segment as required by signature V4
+func encodeS3Path(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		segments[i] = s3Escape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
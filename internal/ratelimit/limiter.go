@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"sync/atomic"
+)
+
+// configKey holds the store and the policies; policies are reloaded when it changes
+const configKey = "rate_limit"
+
+// Store counts requests per key. Implementations must apply one request atomically, so several
+// instances sharing a store enforce one limit between them.
+type Store interface {
+	Allow(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+// Limiter applies the policies of "rate_limit.policies" by name
+type Limiter interface {
+	// Policy returns the named policy; false when it is not configured, in which case nothing is limited
+	Policy(name string) (Policy, bool)
+	// Allow counts one request of key against the policy
+	Allow(ctx context.Context, policy Policy, key string) (Result, error)
+}
+
+type limiter struct {
+	store         Store
+	configManager config.Manager
+	log           logger.Logger
+	policies      atomic.Pointer[map[string]Policy]
+}
+
+// NewLimiter creates a new instance of Limiter and reloads its policies whenever "rate_limit" changes
+func NewLimiter(store Store, configManager config.Manager, log logger.Logger) Limiter {
+	l := &limiter{
+		store:         store,
+		configManager: configManager,
+		log:           log,
+	}
+	l.reload()
+
+	changes := configManager.WatchKey(configKey)
+	go func() {
+		for range changes {
+			l.reload()
+		}
+	}()
+	return l
+}
+
+func (l *limiter) Policy(name string) (Policy, bool) {
+	policy, ok := (*l.policies.Load())[name]
+	return policy, ok
+}
+
+func (l *limiter) Allow(ctx context.Context, policy Policy, key string) (Result, error) {
+	return l.store.Allow(ctx, "ratelimit:"+policy.Name+":"+key, policy)
+}
+
+// reload swaps in the configured policies. Invalid ones are logged and left out; an unreadable
+// block keeps the policies already in force.
+func (l *limiter) reload() {
+	ctx := context.Background()
+	raw := map[string]Policy{}
+	if err := l.configManager.UnmarshalKey(configKey+".policies", &raw); err != nil {
+		l.log.Error(ctx, "Failed to read rate limit policies, keeping the current ones", "error", err)
+		if l.policies.Load() == nil {
+			l.policies.Store(&map[string]Policy{})
+		}
+		return
+	}
+
+	policies := make(map[string]Policy, len(raw))
+	for name, policy := range raw {
+		policy.Name = name
+		if err := policy.Validate(); err != nil {
+			l.log.Error(ctx, "Ignoring invalid rate limit policy", "error", err)
+			continue
+		}
+		policies[name] = policy
+	}
+	l.policies.Store(&policies)
+	l.log.Info(ctx, "Rate limit policies loaded", "count", len(policies))
+}
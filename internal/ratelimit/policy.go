@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Algorithms a policy can use
+const (
+	// AlgorithmTokenBucket refills Limit tokens per window into a bucket of Burst tokens, so short bursts
+	// pass while the long-run rate stays at Limit per window
+	AlgorithmTokenBucket = "token_bucket"
+	// AlgorithmSlidingWindow allows Limit requests in any window, estimated from the counts of the current
+	// and the previous fixed window
+	AlgorithmSlidingWindow = "sliding_window"
+)
+
+// Parts a limit can be keyed by
+const (
+	KeyByIP    = "ip"
+	KeyByUser  = "user"  // the authenticated user; the IP for anonymous requests
+	KeyByRoute = "route" // method and route template, such as "POST /api/v1/auth/register"
+)
+
+// Policy is one entry of "rate_limit.policies", named by its key
+type Policy struct {
+	Name          string   `mapstructure:"-"`
+	Algorithm     string   `mapstructure:"algorithm"`
+	Limit         int      `mapstructure:"limit"`
+	WindowSeconds int      `mapstructure:"window_seconds"`
+	Burst         int      `mapstructure:"burst"` // token bucket capacity, Limit when unset
+	KeyBy         []string `mapstructure:"key_by"`
+}
+
+// Result is the outcome of one request against a policy
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // until the quota is fully available again
+	RetryAfter time.Duration // until the next request may pass; zero when allowed
+}
+
+// Window is the period the policy's Limit applies to
+func (p Policy) Window() time.Duration {
+	return time.Duration(p.WindowSeconds) * time.Second
+}
+
+// Capacity is the size of the token bucket
+func (p Policy) Capacity() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return p.Limit
+}
+
+// Validate reports a policy that cannot be enforced
+func (p Policy) Validate() error {
+	if p.Algorithm != AlgorithmTokenBucket && p.Algorithm != AlgorithmSlidingWindow {
+		return fmt.Errorf("policy %s: unknown algorithm %q", p.Name, p.Algorithm)
+	}
+	if p.Limit <= 0 || p.WindowSeconds <= 0 {
+		return fmt.Errorf("policy %s: limit and window_seconds must be positive", p.Name)
+	}
+	if len(p.KeyBy) == 0 {
+		return fmt.Errorf("policy %s: key_by is required", p.Name)
+	}
+	for _, part := range p.KeyBy {
+		if part != KeyByIP && part != KeyByUser && part != KeyByRoute {
+			return fmt.Errorf("policy %s: unknown key_by %q", p.Name, part)
+		}
+	}
+	return nil
+}
+
+// TokenBucketResult describes a bucket holding tokens after the request was let through or not
+func TokenBucketResult(p Policy, tokens float64, allowed bool) Result {
+	perToken := p.Window() / time.Duration(p.Limit)
+	result := Result{
+		Allowed:    allowed,
+		Limit:      p.Capacity(),
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration((float64(p.Capacity()) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	return result
+}
+
+// SlidingWindowResult describes a sliding window from the count of the previous fixed window, the count of the
+// current one including this request when allowed, and the time elapsed in the current one
+func SlidingWindowResult(p Policy, previous, current int64, elapsed time.Duration, allowed bool) Result {
+	window := p.Window()
+	weight := float64(window-elapsed) / float64(window)
+	used := float64(previous)*weight + float64(current)
+
+	result := Result{
+		Allowed:    allowed,
+		Limit:      p.Limit,
+		Remaining:  max(0, p.Limit-int(math.Ceil(used))),
+		ResetAfter: window - elapsed, // when the current count becomes the fading previous one
+	}
+	if !allowed {
+		// Wait for the previous window's share to fade enough to fit one more request, or for the next window
+		result.RetryAfter = window - elapsed
+		if previous > 0 {
+			excess := used + 1 - float64(p.Limit)
+			fade := time.Duration(excess / float64(previous) * float64(window))
+			result.RetryAfter = min(max(fade, time.Second), window-elapsed)
+		}
+	}
+	return result
+}
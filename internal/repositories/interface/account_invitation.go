@@ -0,0 +1,18 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type AccountInvitationRepository interface {
+	repositories.BaseRepository[entities.AccountInvitation]
+
+	// LockPendingByTokenHash locks the unused, unrevoked invitation with this token hash, nil when not found
+	LockPendingByTokenHash(ctx context.Context, tokenHash string) (*entities.AccountInvitation, error)
+
+	// RevokePending revokes the open invitations of a user so only the newest link works
+	RevokePending(ctx context.Context, userID string, at time.Time) error
+}
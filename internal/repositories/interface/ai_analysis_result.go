@@ -0,0 +1,14 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+)
+
+type AIAnalysisResultRepository interface {
+	repositories.BaseRepository[entities.AIAnalysisResult]
+
+	// ListByMaterialID returns the audit history of a material, newest first
+	ListByMaterialID(ctx context.Context, materialID string) ([]*entities.AIAnalysisResult, error)
+}
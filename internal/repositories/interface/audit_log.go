@@ -0,0 +1,21 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+)
+
+// AuditLogRepository stores the append-only audit log. Update and delete methods always fail.
+type AuditLogRepository interface {
+	repositories.BaseRepository[entities.AuditLog]
+
+	// ListByEntity returns the audit trail of one entity, newest first
+	ListByEntity(ctx context.Context, entityType, entityID string) ([]*entities.AuditLog, error)
+	// SealPending appends up to limit unsealed entries to the hash chain, oldest first, and returns how many
+	SealPending(ctx context.Context, limit int) (int, error)
+	// ListSealed returns up to limit sealed entries after the given sequence, in chain order
+	ListSealed(ctx context.Context, afterSequence int64, limit int) ([]*entities.AuditLog, error)
+	// CountUnsealed returns the number of entries waiting to be sealed
+	CountUnsealed(ctx context.Context) (int64, error)
+}
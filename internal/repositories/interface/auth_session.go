@@ -0,0 +1,31 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type AuthSessionRepository interface {
+	repositories.BaseRepository[entities.AuthSession]
+
+	// ListActiveByUser lists the unrevoked, unexpired sessions of a user, most recently used first
+	ListActiveByUser(ctx context.Context, userID string, now time.Time) ([]*entities.AuthSession, error)
+
+	// Revoke revokes the session if it is still active, reporting whether it was
+	Revoke(ctx context.Context, id, reason string, at time.Time) (bool, error)
+
+	// RevokeAllByUser revokes the active sessions of a user except keepID, returning the revoked IDs
+	RevokeAllByUser(ctx context.Context, userID, keepID, reason string, at time.Time) ([]string, error)
+
+	// RevokeAllByRole revokes the active sessions of every user holding the role, returning the revoked IDs
+	RevokeAllByRole(ctx context.Context, role, reason string, at time.Time) ([]string, error)
+}
+
+type RefreshTokenRepository interface {
+	repositories.BaseRepository[entities.RefreshToken]
+
+	// LockByTokenHash locks the refresh token with this hash, used or not, nil when not found
+	LockByTokenHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+}
@@ -1,10 +1,20 @@
 package repositoryinterface
 
 import (
+	"context"
 	"doan/internal/entities"
 	"doan/internal/repositories"
 )
 
 type ClassRepository interface {
 	repositories.BaseRepository[entities.Class]
+
+	// CreateSchedules inserts the weekly sessions of a class
+	CreateSchedules(ctx context.Context, schedules []*entities.ClassSchedule) error
+
+	// ListSchedules lists the weekly sessions of the given classes
+	ListSchedules(ctx context.Context, classIDs []string) ([]*entities.ClassSchedule, error)
+
+	// LockByID loads the class FOR UPDATE so concurrent approvals cannot overfill it, nil when it does not exist
+	LockByID(ctx context.Context, id string) (*entities.Class, error)
 }
@@ -0,0 +1,89 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/pkg/money"
+	"time"
+)
+
+// Dashboard bucket sizes
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// DashboardRepository computes dashboard figures with SQL aggregates; periods are [From, To)
+type DashboardRepository interface {
+	// GetStudentStats counts students now and the churn over the period
+	GetStudentStats(ctx context.Context, from, to time.Time) (*StudentStats, error)
+
+	// GetRevenueTotals sums invoices issued and payments received in the period, and the balance still open now
+	GetRevenueTotals(ctx context.Context, from, to time.Time) (*RevenueTotals, error)
+
+	// RevenueSeries buckets invoices issued and payments received by interval, in Vietnam time
+	RevenueSeries(ctx context.Context, from, to time.Time, interval string) ([]*RevenuePoint, error)
+
+	// EnrollmentSeries buckets enrollment applications, approvals and rejections by interval, in Vietnam time
+	EnrollmentSeries(ctx context.Context, from, to time.Time, interval string) ([]*EnrollmentPoint, error)
+
+	// CountClassesByStatus counts live classes per status, and those running on the given day
+	CountClassesByStatus(ctx context.Context, on time.Time) ([]*ClassStatusCount, error)
+
+	// TeacherHours sums the lesson minutes of each active teacher in the period, zero for teachers without lessons
+	TeacherHours(ctx context.Context, from, to time.Time) ([]*TeacherHoursRow, error)
+}
+
+// StudentStats are the student KPIs. A student is enrolled while they have an approved enrollment in an open
+// class that has started and not ended; they churned when enrolled at the start of the period but not at its end.
+type StudentStats struct {
+	ActiveStudents     int64 // status ACTIVE
+	EnrolledStudents   int64 // enrolled now
+	NewStudents        int64 // created in the period
+	EnrolledAtStart    int64
+	ChurnedStudents    int64
+	NewEnrollments     int64 // applications in the period
+	ApprovedEnrollment int64 // approvals in the period
+}
+
+// RevenueTotals are the money KPIs of a period in VND; void invoices and voided payments are excluded
+type RevenueTotals struct {
+	Billed      money.Amount
+	Collected   money.Amount // payments, before refunds
+	Refunded    money.Amount
+	Outstanding money.Amount // open balance of all invoices now
+	Overdue     money.Amount // part of Outstanding past its due date
+}
+
+// RevenuePoint is one bucket of the revenue series
+type RevenuePoint struct {
+	BucketStart time.Time
+	Billed      money.Amount
+	Collected   money.Amount
+	Refunded    money.Amount
+}
+
+// EnrollmentPoint is one bucket of the enrollment series
+type EnrollmentPoint struct {
+	BucketStart time.Time
+	Applied     int64
+	Approved    int64
+	Rejected    int64
+}
+
+// ClassStatusCount counts classes with one status
+type ClassStatusCount struct {
+	Status  string
+	Count   int64
+	Running int64 // started and not ended on the day
+}
+
+// TeacherHoursRow is the teaching load of one teacher
+type TeacherHoursRow struct {
+	TeacherID      string
+	TeacherCode    string
+	TeacherName    string
+	EmploymentType string
+	Lessons        int64
+	Minutes        int64
+}
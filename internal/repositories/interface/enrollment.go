@@ -0,0 +1,40 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type EnrollmentRepository interface {
+	repositories.BaseRepository[entities.Enrollment]
+
+	// GetActive returns the applied or approved enrollment of the student in the class, nil when there is none
+	GetActive(ctx context.Context, classID, studentID string) (*entities.Enrollment, error)
+
+	// CountApproved counts the approved enrollments of a class
+	CountApproved(ctx context.Context, classID string) (int64, error)
+
+	// Decide moves an applied enrollment to APPROVED or REJECTED, stamping approved_at or rejected_at.
+	// It reports false when the enrollment is no longer applied, e.g. because a concurrent request decided it first.
+	Decide(ctx context.Context, id, status string, at time.Time) (bool, error)
+
+	// ListActiveClassIDs lists the open, not yet ended classes the student is approved in, except excludeClassID
+	ListActiveClassIDs(ctx context.Context, studentID, excludeClassID string) ([]string, error)
+
+	// HasSiblingEnrollment reports whether another student with the same guardian phone is approved in an open class
+	HasSiblingEnrollment(ctx context.Context, studentID, guardianPhone string) (bool, error)
+
+	// List lists enrollments with their class and student, newest first
+	List(ctx context.Context, filter EnrollmentFilter) (*repositories.Pagination[entities.Enrollment], error)
+}
+
+// EnrollmentFilter selects enrollments; empty fields are ignored
+type EnrollmentFilter struct {
+	ClassID   string
+	StudentID string
+	Status    string
+	Page      uint64
+	Limit     uint64
+}
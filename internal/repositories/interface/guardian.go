@@ -0,0 +1,87 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type GuardianRepository interface {
+	repositories.BaseRepository[entities.Guardian]
+
+	// GetByUserID returns the guardian profile of a GUARDIAN user, nil when not found
+	GetByUserID(ctx context.Context, userID string) (*entities.Guardian, error)
+
+	// GetWithStudents returns the guardian with their linked students, nil when not found
+	GetWithStudents(ctx context.Context, id string) (*entities.Guardian, error)
+
+	// List lists guardians by name, phone or email
+	List(ctx context.Context, search string, page, limit uint64) (*repositories.Pagination[entities.Guardian], error)
+
+	// ListByStudent lists the guardians of a student, primary contact first
+	ListByStudent(ctx context.Context, studentID string) ([]*entities.Guardian, error)
+
+	// LinkStudent links a student to a guardian, updating the relationship when already linked
+	LinkStudent(ctx context.Context, link *entities.GuardianStudent) error
+
+	// UnlinkStudent removes the link, reporting whether it existed
+	UnlinkStudent(ctx context.Context, guardianID, studentID string) (bool, error)
+
+	// IsLinked reports whether the student is a child of the guardian
+	IsLinked(ctx context.Context, guardianID, studentID string) (bool, error)
+}
+
+// StudentPortalRepository reads what a family can see about one student; periods are [From, To)
+type StudentPortalRepository interface {
+	// ListTimetable lists the lessons of the classes the student is approved in
+	ListTimetable(ctx context.Context, studentID string, from, to time.Time) ([]*TimetableEntry, error)
+
+	// ListAttendance lists the attendance marks of the student
+	ListAttendance(ctx context.Context, studentID string, from, to time.Time) ([]*AttendanceEntry, error)
+
+	// ListGrades lists the per-lesson academic records of the student
+	ListGrades(ctx context.Context, studentID string, from, to time.Time) ([]*GradeEntry, error)
+
+	// ListLeaveRequests lists the leave requests of the student, newest first
+	ListLeaveRequests(ctx context.Context, studentID string, page, limit uint64) (*repositories.Pagination[entities.LeaveRequest], error)
+}
+
+// TimetableEntry is one lesson of the student's classes
+type TimetableEntry struct {
+	LessonID    string
+	ClassID     string
+	ClassCode   string
+	ClassName   string
+	DateStart   time.Time
+	DateEnd     time.Time
+	RoomName    *string
+	TeacherName *string
+}
+
+// AttendanceEntry is the attendance mark of one lesson
+type AttendanceEntry struct {
+	LessonID  string
+	ClassCode string
+	ClassName string
+	DateStart time.Time
+	Status    int
+	Note      string
+	MarkedAt  *time.Time
+}
+
+// GradeEntry is the academic record of one lesson
+type GradeEntry struct {
+	LessonID           string
+	ClassCode          string
+	ClassName          string
+	DateStart          time.Time
+	Topic              string
+	HomeworkCompleted  bool
+	HomeworkScore      *float64
+	AttitudeRating     *int
+	ParticipationScore *float64
+	TotalScore         *float64
+	PersonalComment    string
+	IsCompleted        bool
+}
@@ -0,0 +1,80 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"doan/pkg/money"
+	"time"
+)
+
+type InvoiceRepository interface {
+	repositories.BaseRepository[entities.Invoice]
+
+	// NextNumber returns the next legal invoice number, e.g. HD2026000042
+	NextNumber(ctx context.Context, issuedAt time.Time) (string, error)
+
+	// GetWithLines returns the invoice with its lines, student and class, nil when not found
+	GetWithLines(ctx context.Context, id string) (*entities.Invoice, error)
+
+	// LockByIDs loads the invoices FOR UPDATE; call it inside a transaction
+	LockByIDs(ctx context.Context, ids []string) ([]*entities.Invoice, error)
+
+	// LockOpenByStudent loads the student's unsettled, non-void invoices FOR UPDATE, oldest due first
+	LockOpenByStudent(ctx context.Context, studentID string) ([]*entities.Invoice, error)
+
+	// GetByNumber returns the invoice with the given number, nil when not found
+	GetByNumber(ctx context.Context, number string) (*entities.Invoice, error)
+
+	// List lists invoices newest first
+	List(ctx context.Context, filter InvoiceFilter) (*repositories.Pagination[entities.Invoice], error)
+
+	// ListOpenDueBefore lists unsettled, non-void invoices due before the given instant with their students
+	ListOpenDueBefore(ctx context.Context, dueBefore time.Time) ([]*entities.Invoice, error)
+
+	// ListOverdue lists unsettled invoices past due with their students, most overdue first
+	ListOverdue(ctx context.Context, filter OverdueFilter) (*repositories.Pagination[entities.Invoice], error)
+
+	// ListOutstanding sums unpaid balances per student of non-void invoices
+	ListOutstanding(ctx context.Context, filter OutstandingFilter) ([]*StudentBalance, error)
+}
+
+// InvoiceFilter selects invoices; empty fields are ignored
+type InvoiceFilter struct {
+	StudentID    string
+	ClassID      string
+	EnrollmentID string
+	Status       string
+	Page         uint64
+	Limit        uint64
+}
+
+// OverdueFilter selects overdue invoices; invoices due before DueBefore are overdue
+type OverdueFilter struct {
+	DueBefore     time.Time
+	StudentID     string
+	GuardianPhone string
+	Page          uint64
+	Limit         uint64
+}
+
+// OutstandingFilter selects the students whose balances are summed
+type OutstandingFilter struct {
+	StudentID     string
+	GuardianPhone string
+	AsOf          time.Time // invoices due before AsOf count as overdue
+}
+
+// StudentBalance is the billing position of one student
+type StudentBalance struct {
+	StudentID     string
+	StudentCode   string
+	StudentName   string
+	GuardianPhone string
+	OpenInvoices  int64
+	Billed        money.Amount
+	Paid          money.Amount
+	Outstanding   money.Amount
+	Overdue       money.Amount
+	NextDueDate   *time.Time
+}
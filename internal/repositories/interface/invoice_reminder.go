@@ -0,0 +1,34 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+)
+
+type InvoiceReminderRepository interface {
+	repositories.BaseRepository[entities.InvoiceReminder]
+
+	// Claim reserves a reminder step for an invoice before it is sent. It returns nil when the step was already
+	// sent, is being sent by another run, or failed maxAttempts times, so no reminder goes out twice.
+	Claim(ctx context.Context, invoiceID, stepCode, recipient string, maxAttempts int) (*entities.InvoiceReminder, error)
+
+	// LatestByInvoices returns the most recent reminder of each invoice
+	LatestByInvoices(ctx context.Context, invoiceIDs []string) (map[string]*entities.InvoiceReminder, error)
+
+	// ListByInvoice lists the reminders of an invoice, oldest first
+	ListByInvoice(ctx context.Context, invoiceID string) ([]*entities.InvoiceReminder, error)
+}
+
+type ReminderOptOutRepository interface {
+	repositories.BaseRepository[entities.ReminderOptOut]
+
+	// GetByGuardianPhone returns the opt-out of a guardian, nil when not found
+	GetByGuardianPhone(ctx context.Context, phone string) (*entities.ReminderOptOut, error)
+
+	// OptedOut returns which of the guardian phones opted out
+	OptedOut(ctx context.Context, phones []string) (map[string]bool, error)
+
+	// List lists opt-outs newest first
+	List(ctx context.Context, page, limit uint64) (*repositories.Pagination[entities.ReminderOptOut], error)
+}
@@ -0,0 +1,27 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type MaterialRepository interface {
+	repositories.BaseRepository[entities.Material]
+
+	// CountByStorageKey counts live materials sharing the same stored object (uploads are deduplicated by checksum)
+	CountByStorageKey(ctx context.Context, driver, key string) (int64, error)
+
+	// ListReviewQueue lists audited materials awaiting a compliance decision, most severe AI label and oldest first
+	ListReviewQueue(ctx context.Context, filter ReviewQueueFilter) (*repositories.Pagination[entities.Material], error)
+}
+
+// ReviewQueueFilter selects materials of the compliance review queue
+type ReviewQueueFilter struct {
+	ReviewStatus   string
+	Labels         []string
+	UploadedBefore *time.Time
+	Page           uint64
+	Limit          uint64
+}
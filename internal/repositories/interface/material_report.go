@@ -0,0 +1,57 @@
+package repositoryinterface
+
+import (
+	"context"
+	"time"
+)
+
+// MaterialReportFilter narrows material reports to one teacher, one course and/or a period [From, To)
+type MaterialReportFilter struct {
+	TeacherID string
+	CourseID  string
+	From      *time.Time
+	To        *time.Time
+}
+
+// MaterialQualityStats aggregates the AI audit and compliance review of materials uploaded in the period
+type MaterialQualityStats struct {
+	TotalMaterials   int64
+	ByAILabel        map[string]int64 // "UNLABELED" for materials not audited yet
+	ByReviewStatus   map[string]int64
+	ApprovedCount    int64
+	RejectedCount    int64
+	MeanReviewHours  float64 // Upload to review decision, reviewed materials only
+	RejectionReasons []ReasonCount
+}
+
+// ReasonCount counts rejected materials whose latest AI analysis cites the reason code
+type ReasonCount struct {
+	Code    string
+	Message string
+	Count   int64
+}
+
+// ReviewHistoryRow is one review decision with its material, teacher and reviewer
+type ReviewHistoryRow struct {
+	ReviewedAt    time.Time
+	Action        string
+	Comment       string
+	ReviewerName  string
+	ReviewerEmail string
+	ReviewerRole  string
+	MaterialID    string
+	MaterialTitle string
+	FileName      string
+	AILabel       *string
+	CourseCode    *string
+	CourseName    *string
+	TeacherName   string
+	TeacherEmail  string
+	UploadedAt    time.Time
+}
+
+// MaterialReportRepository runs the reporting queries over materials, analyses and review logs
+type MaterialReportRepository interface {
+	GetQualityStats(ctx context.Context, filter MaterialReportFilter, topReasons int) (*MaterialQualityStats, error)
+	ListReviewHistory(ctx context.Context, filter MaterialReportFilter) ([]*ReviewHistoryRow, error)
+}
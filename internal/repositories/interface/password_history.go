@@ -0,0 +1,17 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+)
+
+type PasswordHistoryRepository interface {
+	repositories.BaseRepository[entities.PasswordHistory]
+
+	// ListRecentByUser lists the last replaced passwords of a user, newest first
+	ListRecentByUser(ctx context.Context, userID string, limit int) ([]*entities.PasswordHistory, error)
+
+	// PruneByUser deletes every replaced password of a user but the newest keep ones
+	PruneByUser(ctx context.Context, userID string, keep int) error
+}
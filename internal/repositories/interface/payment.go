@@ -0,0 +1,56 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"doan/pkg/money"
+	"time"
+)
+
+type PaymentRepository interface {
+	repositories.BaseRepository[entities.Payment]
+
+	// NextReceiptNumber returns the next receipt number with the given prefix, e.g. PT2026000042
+	NextReceiptNumber(ctx context.Context, prefix string, at time.Time) (string, error)
+
+	// GetWithAllocations returns the payment with its allocations, their invoices and the cashier, nil when not found
+	GetWithAllocations(ctx context.Context, id string) (*entities.Payment, error)
+
+	// GetByProviderReference returns the payment a provider already reported, nil when not found
+	GetByProviderReference(ctx context.Context, provider, reference string) (*entities.Payment, error)
+
+	// ListRefunds lists the refunds of a payment with their allocations
+	ListRefunds(ctx context.Context, paymentID string) ([]*entities.Payment, error)
+
+	// List lists payments newest first
+	List(ctx context.Context, filter PaymentFilter) (*repositories.Pagination[entities.Payment], error)
+
+	// Reconcile sums payments received in [from, to) per cashier, method, kind and status
+	Reconcile(ctx context.Context, from, to time.Time) ([]*ReconciliationRow, error)
+}
+
+// PaymentFilter selects payments; empty fields are ignored
+type PaymentFilter struct {
+	InvoiceID string
+	StudentID string
+	CashierID string
+	Method    string
+	Kind      string
+	Status    string
+	From      *time.Time
+	To        *time.Time
+	Page      uint64
+	Limit     uint64
+}
+
+// ReconciliationRow is one cashier/method/kind/status bucket of the daily reconciliation
+type ReconciliationRow struct {
+	CashierID   string
+	CashierName string
+	Method      string
+	Kind        string
+	Status      string
+	Count       int64
+	Amount      money.Amount
+}
@@ -0,0 +1,56 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type TeacherRateCardRepository interface {
+	repositories.BaseRepository[entities.TeacherRateCard]
+
+	// ListByTeacher lists the rate cards of a teacher, newest effective date first; empty teacherID lists all
+	ListByTeacher(ctx context.Context, teacherID string, page, limit uint64) (*repositories.Pagination[entities.TeacherRateCard], error)
+
+	// ListEffective lists the cards of the teachers that are in effect at some point in [from, to)
+	ListEffective(ctx context.Context, teacherIDs []string, from, to time.Time) ([]*entities.TeacherRateCard, error)
+
+	// HasOverlap reports whether another card of the same teacher and course overlaps the given dates
+	HasOverlap(ctx context.Context, card *entities.TeacherRateCard) (bool, error)
+}
+
+type PayrollRunRepository interface {
+	repositories.BaseRepository[entities.PayrollRun]
+
+	// GetByPeriod returns the run of a month, nil when not found
+	GetByPeriod(ctx context.Context, period string) (*entities.PayrollRun, error)
+
+	// GetWithItems returns the run with its items, their teachers and, when withLines is set, their lines; nil when not found
+	GetWithItems(ctx context.Context, id string, withLines bool) (*entities.PayrollRun, error)
+
+	// LockByID loads the run FOR UPDATE; call it inside a transaction
+	LockByID(ctx context.Context, id string) (*entities.PayrollRun, error)
+
+	// ReplaceItems deletes the items and lines of the run and stores the given ones
+	ReplaceItems(ctx context.Context, runID string, items []*entities.PayrollItem) error
+
+	// List lists runs, latest period first
+	List(ctx context.Context, page, limit uint64) (*repositories.Pagination[entities.PayrollRun], error)
+
+	// ListTaughtLessons lists lessons with a teacher that ended in [from, to), with their class
+	ListTaughtLessons(ctx context.Context, from, to time.Time) ([]*TaughtLesson, error)
+}
+
+// TaughtLesson is a lesson with the class details payroll needs
+type TaughtLesson struct {
+	LessonID       string
+	ClassID        string
+	ClassCode      string
+	ClassName      string
+	CourseID       *string
+	ClassTeacherID *string
+	TeacherID      string
+	DateStart      time.Time
+	DateEnd        time.Time
+}
@@ -0,0 +1,20 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+)
+
+type RoleRepository interface {
+	repositories.BaseRepository[entities.Role]
+
+	// GetByName returns the role with this name, nil when not found
+	GetByName(ctx context.Context, name string) (*entities.Role, error)
+
+	// ListAll lists every role, built-in roles first
+	ListAll(ctx context.Context) ([]*entities.Role, error)
+
+	// CountUsers counts the users holding the role
+	CountUsers(ctx context.Context, name string) (int64, error)
+}
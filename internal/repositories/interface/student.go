@@ -1,10 +1,14 @@
 package repositoryinterface
 
 import (
+	"context"
 	"doan/internal/entities"
 	"doan/internal/repositories"
 )
 
 type StudentRepository interface {
 	repositories.BaseRepository[entities.Student]
+
+	// GetByUserID returns the student profile of a login account, nil when not found
+	GetByUserID(ctx context.Context, userID string) (*entities.Student, error)
 }
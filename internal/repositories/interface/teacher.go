@@ -15,6 +15,9 @@ type TeacherRepository interface {
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	ExistsByCode(ctx context.Context, code string) (bool, error)
 
+	// GetByUserID returns the teacher profile of a login account, nil when not found
+	GetByUserID(ctx context.Context, userID string) (*entities.Teacher, error)
+
 	// Timetable: Get lessons for a teacher in date range
 	GetTeacherLessons(ctx context.Context, teacherID string, from, to time.Time) ([]entities.Lesson, error)
 
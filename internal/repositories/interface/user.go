@@ -10,10 +10,21 @@ import (
 
 type UserRepository interface {
 	repositories.BaseRepository[entities.User]
+	// ExistsByEmail reports whether an account uses the email, soft-deleted ones included since the
+	// column stays unique
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	CreateTx(ctx context.Context, tx *gorm.DB, user *entities.User) error
 	CreateOTPTx(ctx context.Context, tx *gorm.DB, userID string, otpHash string, expiredAt time.Time) error
 	GetActiveOTPByUserIDTx(ctx context.Context, tx *gorm.DB, userID string) (*entities.UserOTP, error)
 	MarkOTPUsedTx(ctx context.Context, tx *gorm.DB, otpID string, usedAt time.Time) error
 	ActivateUserTx(ctx context.Context, tx *gorm.DB, userID string) error
+
+	// GetByIDWithDeleted returns the user whether or not it is soft-deleted, nil when not found
+	GetByIDWithDeleted(ctx context.Context, id string) (*entities.User, error)
+
+	// ListDeleted lists the soft-deleted users matching the condition
+	ListDeleted(ctx context.Context, condition *repositories.CommonCondition) (*repositories.Pagination[entities.User], error)
+
+	// Restore undoes the soft delete of a user
+	Restore(ctx context.Context, id string) error
 }
@@ -0,0 +1,14 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+)
+
+type UserIdentityRepository interface {
+	repositories.BaseRepository[entities.UserIdentity]
+
+	// GetByProviderSubject returns the linked account of a provider, nil when it is not linked
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*entities.UserIdentity, error)
+}
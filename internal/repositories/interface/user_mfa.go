@@ -0,0 +1,34 @@
+package repositoryinterface
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	"time"
+)
+
+type UserMFARepository interface {
+	repositories.BaseRepository[entities.UserMFA]
+
+	// GetByUserID returns the authenticator of a user, pending or enabled, nil when there is none
+	GetByUserID(ctx context.Context, userID string) (*entities.UserMFA, error)
+
+	// AdvanceStep records step as the last accepted one if it is later, reporting whether it was
+	AdvanceStep(ctx context.Context, id string, step int64) (bool, error)
+
+	// DeleteByUserID removes the authenticator and the recovery codes of a user
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type UserRecoveryCodeRepository interface {
+	repositories.BaseRepository[entities.UserRecoveryCode]
+
+	// ListUnusedByUser lists the recovery codes of a user that are still usable
+	ListUnusedByUser(ctx context.Context, userID string) ([]*entities.UserRecoveryCode, error)
+
+	// Replace deletes every recovery code of the user and stores the new hashes
+	Replace(ctx context.Context, userID string, codeHashes []string) error
+
+	// MarkUsed spends the code if it is still unused, reporting whether it was
+	MarkUsed(ctx context.Context, id string, at time.Time) (bool, error)
+}
@@ -0,0 +1,214 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/mailer"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+)
+
+const (
+	defaultInvitationTTL = 72 * time.Hour
+	defaultActivationURL = "http://localhost:3000/activate"
+)
+
+var (
+	ErrEmailRequired = errors.New("an email is required to invite an account")
+	ErrEmailTaken    = errors.New("another account already uses this email")
+	ErrAccountActive = errors.New("the linked account is already active")
+	ErrUserNotFound  = errors.New("linked account not found")
+)
+
+// InviteInput describes the account to invite. With a UserID the existing inactive account gets a fresh
+// link; without one a new inactive account is created with the given role. PasswordReset words the email
+// for an account an admin made pick a new password.
+type InviteInput struct {
+	UserID        *string
+	Email         string
+	FullName      string
+	Role          string
+	InvitedByID   string
+	PasswordReset bool
+}
+
+// Invitation is an issued activation link; Token is only ever held in memory and in the email
+type Invitation struct {
+	User          *entities.User
+	Token         string
+	ExpiresAt     time.Time
+	PasswordReset bool
+}
+
+// Inviter creates staff-invited accounts and emails their activation links
+type Inviter interface {
+	// Invite creates or reuses the account and issues a new link; call it inside the caller's transaction
+	Invite(ctx context.Context, input InviteInput) (*Invitation, error)
+	// Send emails the activation link in the background; call it after the transaction commits
+	Send(ctx context.Context, invitation *Invitation)
+}
+
+type inviter struct {
+	userRepo       repointerface.UserRepository
+	invitationRepo repointerface.AccountInvitationRepository
+	mailer         mailer.Mailer
+	cfg            config.Manager
+	log            logger.Logger
+}
+
+// NewInviter creates a new instance of Inviter
+func NewInviter(
+	userRepo repointerface.UserRepository,
+	invitationRepo repointerface.AccountInvitationRepository,
+	mailer mailer.Mailer,
+	cfg config.Manager,
+	log logger.Logger,
+) Inviter {
+	return &inviter{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		mailer:         mailer,
+		cfg:            cfg,
+		log:            log,
+	}
+}
+
+func (s *inviter) Invite(ctx context.Context, input InviteInput) (*Invitation, error) {
+	user, err := s.resolveUser(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.invitationRepo.RevokePending(ctx, user.ID, now); err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	invitation := &entities.AccountInvitation{
+		UserID:    user.ID,
+		TokenHash: HashToken(token),
+		ExpiresAt: now.Add(s.ttl()),
+	}
+	if input.InvitedByID != "" {
+		invitation.InvitedByID = &input.InvitedByID
+	}
+	if _, err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return &Invitation{User: user, Token: token, ExpiresAt: invitation.ExpiresAt, PasswordReset: input.PasswordReset}, nil
+}
+
+func (s *inviter) resolveUser(ctx context.Context, input InviteInput) (*entities.User, error) {
+	if input.UserID != nil {
+		user, err := s.userRepo.GetByID(ctx, *input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		if user.IsActive {
+			return nil, ErrAccountActive
+		}
+		return user, nil
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if email == "" {
+		return nil, ErrEmailRequired
+	}
+	exists, err := s.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	// No password until the invitee picks one; login rejects inactive accounts
+	return s.userRepo.Create(ctx, &entities.User{
+		Email:    email,
+		FullName: strings.TrimSpace(input.FullName),
+		Role:     input.Role,
+		IsActive: false,
+	})
+}
+
+func (s *inviter) Send(ctx context.Context, invitation *Invitation) {
+	link := s.activationURL() + "?token=" + url.QueryEscape(invitation.Token)
+	hours := int(s.ttl().Hours())
+	subject := "Kích hoạt tài khoản của bạn"
+	intro := "Trung tâm đã tạo tài khoản cho bạn. Vui lòng nhấp vào liên kết dưới đây để đặt mật khẩu và kích hoạt tài khoản:"
+	action := "Kích hoạt tài khoản"
+	if invitation.PasswordReset {
+		subject = "Đặt mật khẩu mới cho tài khoản của bạn"
+		intro = "Quản trị viên yêu cầu bạn đặt mật khẩu mới. Mật khẩu cũ không còn dùng được; vui lòng nhấp vào liên kết dưới đây để đặt mật khẩu mới:"
+		action = "Đặt mật khẩu mới"
+	}
+	mail := mailer.Mail{
+		To:      invitation.User.Email,
+		Subject: subject,
+		HTML: fmt.Sprintf(`
+			<html>
+			<body style="font-family: Arial, sans-serif;">
+				<h3>Xin chào %s,</h3>
+				<p>%s</p>
+				<p><a href="%s">%s</a></p>
+				<p>Liên kết này sẽ hết hạn sau <strong>%d giờ</strong> và chỉ dùng được một lần.</p>
+			</body>
+			</html>
+		`, html.EscapeString(invitation.User.FullName), intro, html.EscapeString(link), action, hours),
+	}
+
+	// Detach from the request so the mail is not cancelled when the response is written
+	go func(ctx context.Context) {
+		if err := s.mailer.Send(ctx, mail); err != nil {
+			logger.NewLogger(ctx).Errorf("Failed to send invitation to %s: %v", mail.To, err)
+		}
+	}(context.WithoutCancel(ctx))
+}
+
+func (s *inviter) ttl() time.Duration {
+	if hours := s.cfg.GetInt("auth.invitation_ttl_hours"); hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultInvitationTTL
+}
+
+func (s *inviter) activationURL() string {
+	if u := s.cfg.GetString("app.frontend_activation_url"); u != "" {
+		return strings.TrimRight(u, "/")
+	}
+	return defaultActivationURL
+}
+
+// HashToken returns the stored form of an activation token
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"doan/internal/entities"
+)
+
+// AnalysisInput is the material content handed to a ContentAnalyzer
+type AnalysisInput struct {
+	MaterialID string
+	Title      string
+	FileName   string
+	MimeType   string
+	Text       string
+}
+
+// AnalysisResult is the verdict of a ContentAnalyzer
+type AnalysisResult struct {
+	Analyzer string
+	Model    string
+	Label    string
+	Score    float64
+	Summary  string
+	Reasons  []entities.AnalysisReason
+}
+
+// ContentAnalyzer reviews the text of a teaching material and labels it SAFE, WARNING or DANGER
+type ContentAnalyzer interface {
+	Name() string
+	Analyze(ctx context.Context, input AnalysisInput) (*AnalysisResult, error)
+}
+
+// labelRank orders labels by severity so the worst finding wins
+func labelRank(label string) int {
+	switch label {
+	case entities.AILabelDanger:
+		return 2
+	case entities.AILabelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// worstLabel returns the most severe of the given labels
+func worstLabel(labels ...string) string {
+	result := entities.AILabelSafe
+	for _, label := range labels {
+		if labelRank(label) > labelRank(result) {
+			result = label
+		}
+	}
+	return result
+}
+
+// normalizeLabel maps free-form labels (e.g. from an LLM) to the known set
+func normalizeLabel(label string) string {
+	switch label {
+	case entities.AILabelDanger, "danger", "Danger":
+		return entities.AILabelDanger
+	case entities.AILabelWarning, "warning", "Warning":
+		return entities.AILabelWarning
+	case entities.AILabelSafe, "safe", "Safe":
+		return entities.AILabelSafe
+	default:
+		return ""
+	}
+}
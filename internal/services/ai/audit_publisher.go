@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"context"
+	_interface "doan/internal/infrastructure/queue/interface"
+	"doan/pkg/config"
+)
+
+const defaultMaterialAuditTopic = "material-audit"
+
+// MaterialAuditMessage is the payload queued for every material that needs an AI audit
+type MaterialAuditMessage struct {
+	MaterialID string `json:"material_id"`
+}
+
+// AuditPublisher queues materials for the asynchronous AI audit
+type AuditPublisher interface {
+	PublishMaterialAudit(ctx context.Context, materialID string) error
+}
+
+type queueAuditPublisher struct {
+	queue _interface.Queue
+	cfg   config.Manager
+}
+
+func NewAuditPublisher(queue _interface.Queue, cfg config.Manager) AuditPublisher {
+	return &queueAuditPublisher{
+		queue: queue,
+		cfg:   cfg,
+	}
+}
+
+func (p *queueAuditPublisher) PublishMaterialAudit(ctx context.Context, materialID string) error {
+	return p.queue.Publish(ctx, MaterialAuditTopicOption(p.cfg), &_interface.Message{
+		Key:  materialID,
+		Data: MaterialAuditMessage{MaterialID: materialID},
+	})
+}
+
+// MaterialAuditTopicOption returns the topic used by both the publisher and the audit consumer
+func MaterialAuditTopicOption(cfg config.Manager) _interface.TopicOption {
+	topic := cfg.GetString("ai.audit_topic")
+	if topic == "" {
+		topic = defaultMaterialAuditTopic
+	}
+	return _interface.TopicOption{
+		KafkaTopicOption: &_interface.KafkaTopicOption{
+			TopicName:         topic,
+			NumPartitions:     1,
+			ReplicationFactor: 1,
+		},
+		RabbitTopicOption: &_interface.RabbitTopicOption{
+			QueueName:  &topic,
+			RoutingKey: &topic,
+		},
+	}
+}
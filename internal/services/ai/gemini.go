@@ -0,0 +1,259 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"doan/internal/entities"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	AnalyzerGemini = "gemini"
+
+	defaultGeminiEndpoint      = "https://generativelanguage.googleapis.com/v1beta"
+	defaultGeminiModel         = "gemini-2.0-flash"
+	defaultGeminiTimeout       = 60 * time.Second
+	defaultGeminiMaxInputChars = 30000
+)
+
+// GeminiConfig cấu hình cho Google Gemini API
+type GeminiConfig struct {
+	APIKey         string `mapstructure:"api_key"`
+	Model          string `mapstructure:"model"`
+	Endpoint       string `mapstructure:"endpoint"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+	MaxInputChars  int    `mapstructure:"max_input_chars"`
+}
+
+const geminiPrompt = `Bạn là chuyên viên kiểm duyệt tài liệu dạy thêm cho học sinh phổ thông tại Việt Nam (theo Thông tư 29/2024/TT-BGDĐT).
+Hãy đọc tài liệu dưới đây và đánh giá:
+- Sai lệch kiến thức, thông tin sai sự thật so với chương trình giáo dục phổ thông.
+- Nội dung bạo lực, khiêu dâm, ma túy, cờ bạc, chống phá hoặc không phù hợp lứa tuổi.
+- Quảng cáo, chiêu sinh, ép buộc học thêm hoặc thu phí trái quy định.
+- Dấu hiệu sử dụng đề thi chính thức, lộ đề, hoặc chứa thông tin cá nhân của học sinh.
+
+Gán nhãn:
+- SAFE: không có vấn đề.
+- WARNING: có vấn đề nhỏ hoặc cần người kiểm duyệt xem lại.
+- DANGER: vi phạm rõ ràng, không được phép sử dụng.
+
+Chỉ trả về JSON đúng định dạng:
+{"label": "SAFE|WARNING|DANGER", "score": 0.0-1.0, "summary": "tóm tắt ngắn bằng tiếng Việt",
+ "reasons": [{"code": "MÃ_LÝ_DO", "label": "WARNING|DANGER", "message": "giải thích", "excerpt": "trích dẫn nguyên văn từ tài liệu", "location": "trang/đoạn nếu có"}]}
+
+Tiêu đề: %s
+Tên file: %s
+
+--- NỘI DUNG TÀI LIỆU ---
+%s`
+
+type geminiAnalyzer struct {
+	config GeminiConfig
+	client *http.Client
+}
+
+// NewGeminiAnalyzer creates a ContentAnalyzer backed by the Gemini generateContent API
+func NewGeminiAnalyzer(config GeminiConfig) (ContentAnalyzer, error) {
+	if config.APIKey == "" {
+		return nil, errors.New("gemini analyzer: api_key is required")
+	}
+	if config.Model == "" {
+		config.Model = defaultGeminiModel
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = defaultGeminiEndpoint
+	}
+	if config.MaxInputChars <= 0 {
+		config.MaxInputChars = defaultGeminiMaxInputChars
+	}
+	timeout := defaultGeminiTimeout
+	if config.TimeoutSeconds > 0 {
+		timeout = time.Duration(config.TimeoutSeconds) * time.Second
+	}
+	return &geminiAnalyzer{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (a *geminiAnalyzer) Name() string {
+	return AnalyzerGemini
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiRequest struct {
+	Contents         []geminiContent `json:"contents"`
+	GenerationConfig struct {
+		Temperature      float64 `json:"temperature"`
+		ResponseMimeType string  `json:"responseMimeType"`
+	} `json:"generationConfig"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback *struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type geminiVerdict struct {
+	Label   string                    `json:"label"`
+	Score   float64                   `json:"score"`
+	Summary string                    `json:"summary"`
+	Reasons []entities.AnalysisReason `json:"reasons"`
+}
+
+func (a *geminiAnalyzer) Analyze(ctx context.Context, input AnalysisInput) (*AnalysisResult, error) {
+	text := truncateRunes(input.Text, a.config.MaxInputChars)
+
+	reqBody := geminiRequest{
+		Contents: []geminiContent{{
+			Role:  "user",
+			Parts: []geminiPart{{Text: fmt.Sprintf(geminiPrompt, input.Title, input.FileName, text)}},
+		}},
+	}
+	reqBody.GenerationConfig.Temperature = 0
+	reqBody.GenerationConfig.ResponseMimeType = "application/json"
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("gemini analyzer: marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent", strings.TrimRight(a.config.Endpoint, "/"), url.PathEscape(a.config.Model))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("gemini analyzer: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", a.config.APIKey)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gemini analyzer: call api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("gemini analyzer: read response: %w", err)
+	}
+
+	var parsed geminiResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("gemini analyzer: decode response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := http.StatusText(resp.StatusCode)
+		if parsed.Error != nil {
+			msg = parsed.Error.Message
+		}
+		return nil, fmt.Errorf("gemini analyzer: api returned status %d: %s", resp.StatusCode, msg)
+	}
+
+	// A prompt blocked by Gemini safety filters is itself a strong signal
+	if parsed.PromptFeedback != nil && parsed.PromptFeedback.BlockReason != "" {
+		return &AnalysisResult{
+			Analyzer: AnalyzerGemini,
+			Model:    a.config.Model,
+			Label:    entities.AILabelDanger,
+			Score:    1,
+			Summary:  "Gemini từ chối phân tích tài liệu do bộ lọc an toàn",
+			Reasons: []entities.AnalysisReason{{
+				Code:    "SAFETY_BLOCKED",
+				Label:   entities.AILabelDanger,
+				Message: "Prompt bị chặn: " + parsed.PromptFeedback.BlockReason,
+			}},
+		}, nil
+	}
+	if len(parsed.Candidates) == 0 || len(parsed.Candidates[0].Content.Parts) == 0 {
+		return nil, errors.New("gemini analyzer: empty response")
+	}
+
+	var raw strings.Builder
+	for _, part := range parsed.Candidates[0].Content.Parts {
+		raw.WriteString(part.Text)
+	}
+	verdict, err := parseGeminiVerdict(raw.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// The overall label can never be milder than the worst cited reason
+	reasonLabels := make([]string, 0, len(verdict.Reasons))
+	for i := range verdict.Reasons {
+		label := normalizeLabel(verdict.Reasons[i].Label)
+		if label == "" {
+			label = entities.AILabelWarning
+		}
+		verdict.Reasons[i].Label = label
+		reasonLabels = append(reasonLabels, label)
+	}
+	label := worstLabel(append(reasonLabels, normalizeLabel(verdict.Label))...)
+	if normalizeLabel(verdict.Label) == "" && len(reasonLabels) == 0 {
+		return nil, fmt.Errorf("gemini analyzer: unknown label %q", verdict.Label)
+	}
+
+	score := verdict.Score
+	if score < 0 {
+		score = 0
+	}
+	if score > 1 {
+		score = 1
+	}
+
+	return &AnalysisResult{
+		Analyzer: AnalyzerGemini,
+		Model:    a.config.Model,
+		Label:    label,
+		Score:    score,
+		Summary:  verdict.Summary,
+		Reasons:  verdict.Reasons,
+	}, nil
+}
+
+// parseGeminiVerdict decodes the JSON verdict, tolerating markdown code fences around it
+func parseGeminiVerdict(raw string) (*geminiVerdict, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+	if start, end := strings.Index(raw, "{"), strings.LastIndex(raw, "}"); start >= 0 && end > start {
+		raw = raw[start : end+1]
+	}
+	var verdict geminiVerdict
+	if err := json.Unmarshal([]byte(raw), &verdict); err != nil {
+		return nil, fmt.Errorf("gemini analyzer: decode verdict: %w", err)
+	}
+	return &verdict, nil
+}
+
+func truncateRunes(s string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
@@ -0,0 +1,27 @@
+package ai
+
+import (
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// NewContentAnalyzer selects the analyzer from "ai.analyzer" (rule_based by default).
+// Gemini falls back to the rule based analyzer when no API key is configured.
+func NewContentAnalyzer(cfg config.Manager, log logger.Logger) (ContentAnalyzer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.GetString("ai.analyzer"))) {
+	case AnalyzerGemini:
+		geminiConfig := GeminiConfig{}
+		if err := cfg.UnmarshalKey("ai.gemini", &geminiConfig); err != nil {
+			return nil, err
+		}
+		if geminiConfig.APIKey != "" {
+			return NewGeminiAnalyzer(geminiConfig)
+		}
+		log.Warn(context.Background(), "ai.gemini.api_key is empty, falling back to the rule based analyzer")
+		return NewRuleBasedAnalyzer(cfg)
+	default:
+		return NewRuleBasedAnalyzer(cfg)
+	}
+}
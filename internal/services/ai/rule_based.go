@@ -0,0 +1,342 @@
+package ai
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/pkg/config"
+	"doan/pkg/utils"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	AnalyzerRuleBased = "rule_based"
+
+	ruleExcerptRadius = 60
+	maxReasonsPerRule = 3
+)
+
+// Rule is a deterministic check: any keyword (whole word, case-insensitive) or pattern match triggers it
+type Rule struct {
+	Code     string   `mapstructure:"code"`
+	Label    string   `mapstructure:"label"`
+	Message  string   `mapstructure:"message"`
+	Keywords []string `mapstructure:"keywords"`
+	Patterns []string `mapstructure:"patterns"`
+}
+
+type compiledRule struct {
+	Rule
+	keywords []string
+	patterns []*regexp.Regexp
+}
+
+// defaultRules cover the most common problems in tutoring materials
+var defaultRules = []Rule{
+	{
+		Code:     "VIOLENCE",
+		Label:    entities.AILabelDanger,
+		Message:  "Nội dung bạo lực, khủng bố hoặc cổ vũ tự hại",
+		Keywords: []string{"giết người", "tự sát", "tự tử", "đánh bom", "khủng bố", "chế tạo bom", "bạo lực", "terrorist", "suicide"},
+	},
+	{
+		Code:     "DRUGS",
+		Label:    entities.AILabelDanger,
+		Message:  "Đề cập tới ma túy, chất cấm",
+		Keywords: []string{"ma túy", "heroin", "thuốc lắc", "cần sa", "cocaine", "methamphetamine"},
+	},
+	{
+		Code:     "GAMBLING",
+		Label:    entities.AILabelDanger,
+		Message:  "Nội dung cờ bạc, cá độ",
+		Keywords: []string{"cờ bạc", "cá độ", "lô đề", "casino", "đánh bạc"},
+	},
+	{
+		Code:     "SEXUAL_CONTENT",
+		Label:    entities.AILabelDanger,
+		Message:  "Nội dung khiêu dâm, đồi trụy không phù hợp với học sinh",
+		Keywords: []string{"khiêu dâm", "đồi trụy", "porn"},
+	},
+	{
+		Code:     "ANTI_STATE",
+		Label:    entities.AILabelDanger,
+		Message:  "Nội dung chống phá, xuyên tạc",
+		Keywords: []string{"phản động", "lật đổ chính quyền", "xuyên tạc lịch sử"},
+	},
+	{
+		Code:     "EXAM_LEAK",
+		Label:    entities.AILabelWarning,
+		Message:  "Có dấu hiệu sử dụng đề thi chính thức hoặc lộ đề",
+		Keywords: []string{"lộ đề", "đề thi chính thức", "đáp án đề thi thật", "đề thi bị lộ"},
+	},
+	{
+		Code:     "ADVERTISING",
+		Label:    entities.AILabelWarning,
+		Message:  "Tài liệu chứa nội dung quảng cáo, chiêu sinh",
+		Keywords: []string{"khuyến mãi", "giảm giá học phí", "đăng ký ngay", "liên hệ zalo", "hotline", "chuyển khoản"},
+	},
+	{
+		Code:     "FORCED_TUTORING",
+		Label:    entities.AILabelWarning,
+		Message:  "Có dấu hiệu ép buộc học thêm hoặc thu phí trái quy định (Thông tư 29)",
+		Keywords: []string{"bắt buộc học thêm", "không học thêm sẽ", "thu thêm phí", "phải đóng tiền học thêm"},
+	},
+	{
+		Code:     "PERSONAL_DATA",
+		Label:    entities.AILabelWarning,
+		Message:  "Tài liệu chứa thông tin cá nhân (số điện thoại)",
+		Patterns: []string{`(?:\+84|\b0)(?:[35789])(?:[ .\-]?\d){8}\b`},
+	},
+}
+
+type ruleBasedAnalyzer struct {
+	rules []compiledRule
+}
+
+// NewRuleBasedAnalyzer creates a deterministic analyzer from the built-in rules plus "ai.rules" from config.
+// It needs no network access and always produces the same output for the same text.
+func NewRuleBasedAnalyzer(cfg config.Manager) (ContentAnalyzer, error) {
+	rules := append([]Rule{}, defaultRules...)
+	if cfg != nil && cfg.IsSet("ai.rules") {
+		var extra []Rule
+		if err := cfg.UnmarshalKey("ai.rules", &extra); err != nil {
+			return nil, fmt.Errorf("read ai.rules: %w", err)
+		}
+		rules = append(rules, extra...)
+	}
+
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		label := normalizeLabel(rule.Label)
+		if rule.Code == "" || label == "" || label == entities.AILabelSafe {
+			return nil, fmt.Errorf("invalid ai rule %q: code and a WARNING/DANGER label are required", rule.Code)
+		}
+		rule.Label = label
+		c := compiledRule{Rule: rule}
+		for _, keyword := range rule.Keywords {
+			keyword = strings.ToLower(norm.NFC.String(strings.TrimSpace(keyword)))
+			if keyword != "" {
+				c.keywords = append(c.keywords, keyword)
+			}
+		}
+		for _, pattern := range rule.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern in ai rule %q: %w", rule.Code, err)
+			}
+			c.patterns = append(c.patterns, re)
+		}
+		compiled = append(compiled, c)
+	}
+	return &ruleBasedAnalyzer{rules: compiled}, nil
+}
+
+func (a *ruleBasedAnalyzer) Name() string {
+	return AnalyzerRuleBased
+}
+
+func (a *ruleBasedAnalyzer) Analyze(ctx context.Context, input AnalysisInput) (*AnalysisResult, error) {
+	text := norm.NFC.String(input.Text)
+	lower := strings.ToLower(text)
+	folded := utils.FoldText(text)
+
+	var reasons []entities.AnalysisReason
+	labels := make([]string, 0)
+	score := 0.0
+	for _, rule := range a.rules {
+		matches := a.matchRule(rule, text, lower, folded)
+		if len(matches) == 0 {
+			continue
+		}
+		labels = append(labels, rule.Label)
+		if rule.Label == entities.AILabelDanger {
+			score += 0.6
+		} else {
+			score += 0.2
+		}
+		for i, m := range matches {
+			if i >= maxReasonsPerRule {
+				break
+			}
+			reasons = append(reasons, entities.AnalysisReason{
+				Code:     rule.Code,
+				Label:    rule.Label,
+				Message:  rule.Message,
+				Excerpt:  excerpt(text, m.start, m.end),
+				Location: fmt.Sprintf("char %d", m.runeOffset),
+			})
+		}
+	}
+	if score > 1 {
+		score = 1
+	}
+
+	label := worstLabel(labels...)
+	summary := "Không phát hiện nội dung vi phạm"
+	if len(reasons) > 0 {
+		codes := make([]string, 0)
+		seen := map[string]bool{}
+		for _, r := range reasons {
+			if !seen[r.Code] {
+				seen[r.Code] = true
+				codes = append(codes, r.Code)
+			}
+		}
+		summary = fmt.Sprintf("Phát hiện %d dấu hiệu: %s", len(reasons), strings.Join(codes, ", "))
+	}
+
+	return &AnalysisResult{
+		Analyzer: AnalyzerRuleBased,
+		Label:    label,
+		Score:    score,
+		Summary:  summary,
+		Reasons:  reasons,
+	}, nil
+}
+
+type ruleMatch struct {
+	start, end int // byte offsets in the NFC text
+	runeOffset int
+}
+
+func (a *ruleBasedAnalyzer) matchRule(rule compiledRule, text, lower, folded string) []ruleMatch {
+	var matches []ruleMatch
+	for _, keyword := range rule.keywords {
+		matches = append(matches, findWords(text, lower, keyword)...)
+		// Keywords written without diacritics also match unaccented text ("ma tuy")
+		if isASCII(keyword) {
+			matches = append(matches, findFoldedWords(text, folded, keyword)...)
+		}
+	}
+	for _, re := range rule.patterns {
+		for _, loc := range re.FindAllStringIndex(text, maxReasonsPerRule) {
+			matches = append(matches, ruleMatch{start: loc[0], end: loc[1], runeOffset: utf8.RuneCountInString(text[:loc[0]])})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	return dedupeMatches(matches)
+}
+
+// findWords finds whole-word occurrences of keyword in lower, which must be the lowercased text
+func findWords(text, lower, keyword string) []ruleMatch {
+	var matches []ruleMatch
+	// Lowercasing Vietnamese keeps byte lengths for the vast majority of runes; fall back when it does not
+	if len(lower) != len(text) {
+		return findFoldedWords(text, lower, keyword)
+	}
+	for offset := 0; ; {
+		idx := strings.Index(lower[offset:], keyword)
+		if idx < 0 {
+			break
+		}
+		start := offset + idx
+		end := start + len(keyword)
+		if isWordBoundary(lower, start, end) {
+			matches = append(matches, ruleMatch{start: start, end: end, runeOffset: utf8.RuneCountInString(text[:start])})
+		}
+		offset = end
+	}
+	return matches
+}
+
+// findFoldedWords searches a transformed copy of text and maps rune offsets back to the original text
+func findFoldedWords(text, transformed, keyword string) []ruleMatch {
+	var matches []ruleMatch
+	textRunes := []rune(text)
+	transformedRunes := []rune(transformed)
+	if len(textRunes) != len(transformedRunes) {
+		return nil
+	}
+	keywordRunes := []rune(keyword)
+	for i := 0; i+len(keywordRunes) <= len(transformedRunes); i++ {
+		if !equalRunes(transformedRunes[i:i+len(keywordRunes)], keywordRunes) {
+			continue
+		}
+		end := i + len(keywordRunes)
+		if (i > 0 && isWordRune(transformedRunes[i-1])) || (end < len(transformedRunes) && isWordRune(transformedRunes[end])) {
+			continue
+		}
+		startByte := len(string(textRunes[:i]))
+		endByte := startByte + len(string(textRunes[i:end]))
+		matches = append(matches, ruleMatch{start: startByte, end: endByte, runeOffset: i})
+		i = end - 1
+	}
+	return matches
+}
+
+func dedupeMatches(matches []ruleMatch) []ruleMatch {
+	result := make([]ruleMatch, 0, len(matches))
+	for _, m := range matches {
+		if len(result) > 0 && m.start < result[len(result)-1].end {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func isWordBoundary(s string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:start])
+		if isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(s) {
+		r, _ := utf8.DecodeRuneInString(s[end:])
+		if isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// excerpt returns the match with some surrounding context, cut on rune boundaries
+func excerpt(text string, start, end int) string {
+	from := start
+	for i := 0; i < ruleExcerptRadius && from > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:from])
+		from -= size
+	}
+	to := end
+	for i := 0; i < ruleExcerptRadius && to < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[to:])
+		to += size
+	}
+	result := strings.Join(strings.Fields(text[from:to]), " ")
+	if from > 0 {
+		result = "…" + result
+	}
+	if to < len(text) {
+		result += "…"
+	}
+	return result
+}
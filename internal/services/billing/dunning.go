@@ -0,0 +1,136 @@
+package billing
+
+import (
+	"doan/pkg/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reminder templates a dunning step can use
+const (
+	TemplateUpcoming = "upcoming"
+	TemplateDue      = "due"
+	TemplateOverdue  = "overdue"
+)
+
+var ErrInvalidDunningStep = errors.New("invalid dunning step")
+
+// DunningStep sends one reminder OffsetDays after the due date (negative: before it)
+type DunningStep struct {
+	Code       string `mapstructure:"code"`
+	OffsetDays int    `mapstructure:"offset_days"`
+	Template   string `mapstructure:"template"`
+}
+
+// DunningConfig is the "reminders" config block
+type DunningConfig struct {
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	Steps       []DunningStep `mapstructure:"steps"`
+}
+
+var defaultDunningSteps = []DunningStep{
+	{Code: "BEFORE_3D", OffsetDays: -3, Template: TemplateUpcoming},
+	{Code: "DUE", OffsetDays: 0, Template: TemplateDue},
+	{Code: "OVERDUE_7D", OffsetDays: 7, Template: TemplateOverdue},
+	{Code: "OVERDUE_14D", OffsetDays: 14, Template: TemplateOverdue},
+	{Code: "OVERDUE_30D", OffsetDays: 30, Template: TemplateOverdue},
+}
+
+// DunningSchedule decides which reminder an open invoice is due for
+type DunningSchedule interface {
+	// StepFor returns the latest step reached on the given day, nil before the first one.
+	// Earlier steps that were missed are not sent late: an overdue invoice never gets an "upcoming" reminder.
+	StepFor(dueDate, now time.Time) *DunningStep
+	// EarliestOffset is the offset of the first step, used to select candidate invoices
+	EarliestOffset() int
+	// MaxAttempts bounds how often a failed reminder is retried
+	MaxAttempts() int
+}
+
+type dunningSchedule struct {
+	steps       []DunningStep // ordered by offset
+	maxAttempts int
+}
+
+// NewDunningSchedule validates the steps, falling back to the built-in ones when none are configured
+func NewDunningSchedule(config DunningConfig) (DunningSchedule, error) {
+	steps := config.Steps
+	if len(steps) == 0 {
+		steps = defaultDunningSteps
+	}
+	steps = append([]DunningStep(nil), steps...)
+
+	codes := make(map[string]bool, len(steps))
+	offsets := make(map[int]bool, len(steps))
+	for i := range steps {
+		step := &steps[i]
+		step.Code = strings.ToUpper(strings.TrimSpace(step.Code))
+		step.Template = strings.ToLower(strings.TrimSpace(step.Template))
+		if step.Code == "" || codes[step.Code] {
+			return nil, fmt.Errorf("%w: missing or duplicate code %q", ErrInvalidDunningStep, step.Code)
+		}
+		if offsets[step.OffsetDays] {
+			return nil, fmt.Errorf("%w: two steps at offset %d", ErrInvalidDunningStep, step.OffsetDays)
+		}
+		switch step.Template {
+		case TemplateUpcoming, TemplateDue, TemplateOverdue:
+		case "":
+			step.Template = defaultTemplate(step.OffsetDays)
+		default:
+			return nil, fmt.Errorf("%w: unknown template %q", ErrInvalidDunningStep, step.Template)
+		}
+		codes[step.Code] = true
+		offsets[step.OffsetDays] = true
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].OffsetDays < steps[j].OffsetDays })
+
+	maxAttempts := config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	return &dunningSchedule{steps: steps, maxAttempts: maxAttempts}, nil
+}
+
+func (s *dunningSchedule) StepFor(dueDate, now time.Time) *DunningStep {
+	days := DaysPastDue(dueDate, now)
+	var reached *DunningStep
+	for i := range s.steps {
+		if s.steps[i].OffsetDays > days {
+			break
+		}
+		reached = &s.steps[i]
+	}
+	return reached
+}
+
+func (s *dunningSchedule) EarliestOffset() int {
+	return s.steps[0].OffsetDays
+}
+
+func (s *dunningSchedule) MaxAttempts() int {
+	return s.maxAttempts
+}
+
+// DaysPastDue counts Vietnam calendar days from the due date to now; negative before the due date
+func DaysPastDue(dueDate, now time.Time) int {
+	loc := utils.VietnamLocation()
+	due := dueDate.In(loc)
+	today := now.In(loc)
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	todayDay := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	return int(todayDay.Sub(dueDay).Hours() / 24)
+}
+
+func defaultTemplate(offsetDays int) string {
+	switch {
+	case offsetDays < 0:
+		return TemplateUpcoming
+	case offsetDays == 0:
+		return TemplateDue
+	default:
+		return TemplateOverdue
+	}
+}
@@ -0,0 +1,244 @@
+package billing
+
+import (
+	"doan/internal/entities"
+	"doan/pkg/money"
+	"doan/pkg/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Discount kinds available in "billing.discounts"
+const (
+	DiscountSibling   = "sibling"
+	DiscountEarlyBird = "early_bird"
+	DiscountManual    = "manual"
+)
+
+var (
+	ErrInvalidPlan         = errors.New("billing plan must be FULL or MONTHLY")
+	ErrInvalidInstallments = errors.New("invalid number of installments")
+	ErrUnknownDiscount     = errors.New("unknown discount code")
+)
+
+// DiscountRule declares a discount; sibling and early_bird apply automatically, manual ones when staff pick the code
+type DiscountRule struct {
+	Code               string `mapstructure:"code"`
+	Kind               string `mapstructure:"kind"`
+	Percent            int64  `mapstructure:"percent"`
+	Description        string `mapstructure:"description"`
+	MinDaysBeforeStart int    `mapstructure:"min_days_before_start"`
+}
+
+// Config is the "billing" config block
+type Config struct {
+	DueDays             int            `mapstructure:"due_days"`
+	DefaultInstallments int            `mapstructure:"default_installments"`
+	MaxInstallments     int            `mapstructure:"max_installments"`
+	MaxDiscountPercent  int64          `mapstructure:"max_discount_percent"`
+	Discounts           []DiscountRule `mapstructure:"discounts"`
+}
+
+var defaultDiscounts = []DiscountRule{
+	{Code: "SIBLING", Kind: DiscountSibling, Percent: 10, Description: "Giảm học phí anh chị em ruột"},
+	{Code: "EARLY_BIRD", Kind: DiscountEarlyBird, Percent: 5, MinDaysBeforeStart: 14, Description: "Ưu đãi đăng ký sớm"},
+}
+
+// DiscountContext holds the facts automatic discounts depend on
+type DiscountContext struct {
+	HasSibling bool
+	ClassStart time.Time
+	// Codes are manual discounts chosen by staff
+	Codes []string
+}
+
+// PlanInput describes the enrollment being billed
+type PlanInput struct {
+	ClassName    string
+	Price        money.Amount
+	Plan         string
+	Installments int // MONTHLY only, 0 derives it from the class period
+	IssuedAt     time.Time
+	ClassStart   time.Time
+	ClassEnd     *time.Time
+	Discounts    DiscountContext
+}
+
+// Planner turns a tuition price into invoices with their lines; it does not persist anything
+type Planner interface {
+	Plan(input PlanInput) ([]*entities.Invoice, error)
+}
+
+type planner struct {
+	config Config
+}
+
+// NewPlanner creates a planner, filling unset config values with defaults
+func NewPlanner(config Config) (Planner, error) {
+	if config.DueDays <= 0 {
+		config.DueDays = 7
+	}
+	if config.DefaultInstallments <= 0 {
+		config.DefaultInstallments = 3
+	}
+	if config.MaxInstallments <= 0 {
+		config.MaxInstallments = 12
+	}
+	if config.MaxDiscountPercent <= 0 || config.MaxDiscountPercent > 100 {
+		config.MaxDiscountPercent = 50
+	}
+	if len(config.Discounts) == 0 {
+		config.Discounts = append([]DiscountRule(nil), defaultDiscounts...)
+	}
+	for i, rule := range config.Discounts {
+		rule.Code = strings.ToUpper(strings.TrimSpace(rule.Code))
+		rule.Kind = strings.ToLower(strings.TrimSpace(rule.Kind))
+		if rule.Code == "" || rule.Percent <= 0 || rule.Percent > 100 {
+			return nil, fmt.Errorf("billing discount %q: code and a percent in 1..100 are required", rule.Code)
+		}
+		switch rule.Kind {
+		case DiscountSibling, DiscountEarlyBird, DiscountManual:
+		default:
+			return nil, fmt.Errorf("billing discount %q: unknown kind %q", rule.Code, rule.Kind)
+		}
+		if rule.Description == "" {
+			rule.Description = "Giảm giá " + rule.Code
+		}
+		config.Discounts[i] = rule
+	}
+	return &planner{config: config}, nil
+}
+
+func (p *planner) Plan(input PlanInput) ([]*entities.Invoice, error) {
+	plan := strings.ToUpper(input.Plan)
+	if plan == "" {
+		plan = entities.BillingPlanFull
+	}
+
+	installments := 1
+	switch plan {
+	case entities.BillingPlanFull:
+	case entities.BillingPlanMonthly:
+		installments = input.Installments
+		if installments == 0 {
+			installments = p.config.DefaultInstallments
+			if input.ClassEnd != nil {
+				installments = monthsSpanned(input.ClassStart, *input.ClassEnd)
+			}
+		}
+		if installments < 1 || installments > p.config.MaxInstallments {
+			return nil, fmt.Errorf("%w: %d (1..%d)", ErrInvalidInstallments, installments, p.config.MaxInstallments)
+		}
+	default:
+		return nil, ErrInvalidPlan
+	}
+
+	discounts, err := p.applicableDiscounts(input.Discounts)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := utils.VietnamLocation()
+	issued := input.IssuedAt.In(loc)
+	firstDue := time.Date(issued.Year(), issued.Month(), issued.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, p.config.DueDays)
+
+	invoices := make([]*entities.Invoice, 0, installments)
+	for i, gross := range input.Price.Split(installments) {
+		description := fmt.Sprintf("Học phí lớp %s", input.ClassName)
+		if installments > 1 {
+			description = fmt.Sprintf("%s - đợt %d/%d", description, i+1, installments)
+		}
+		lines := []entities.InvoiceLine{{
+			Kind:        entities.InvoiceLineTuition,
+			Description: description,
+			Quantity:    1,
+			UnitPrice:   gross,
+			Amount:      gross,
+		}}
+
+		discountTotal := money.Zero
+		for _, d := range discounts {
+			amount := gross.Percent(d.Percent)
+			if amount.IsZero() {
+				continue
+			}
+			discountTotal = discountTotal.Add(amount)
+			lines = append(lines, entities.InvoiceLine{
+				Kind:        entities.InvoiceLineDiscount,
+				Code:        d.Code,
+				Description: fmt.Sprintf("%s (%d%%)", d.Description, d.Percent),
+				Quantity:    1,
+				UnitPrice:   amount.Neg(),
+				Amount:      amount.Neg(),
+			})
+		}
+
+		invoices = append(invoices, &entities.Invoice{
+			BillingPlan:      plan,
+			InstallmentNo:    i + 1,
+			InstallmentCount: installments,
+			Currency:         money.Currency,
+			Subtotal:         gross,
+			DiscountTotal:    discountTotal,
+			Total:            gross.Sub(discountTotal),
+			Status:           entities.InvoiceUnpaid,
+			IssuedAt:         input.IssuedAt,
+			DueDate:          firstDue.AddDate(0, i, 0),
+			Lines:            lines,
+		})
+	}
+	return invoices, nil
+}
+
+// applicableDiscounts picks the discounts that apply, trimming the last ones so the sum stays under the cap
+func (p *planner) applicableDiscounts(ctx DiscountContext) ([]DiscountRule, error) {
+	requested := make(map[string]bool, len(ctx.Codes))
+	for _, code := range ctx.Codes {
+		requested[strings.ToUpper(strings.TrimSpace(code))] = true
+	}
+
+	var applicable []DiscountRule
+	for _, rule := range p.config.Discounts {
+		apply := false
+		switch rule.Kind {
+		case DiscountSibling:
+			apply = ctx.HasSibling
+		case DiscountEarlyBird:
+			apply = !ctx.ClassStart.IsZero() && time.Until(ctx.ClassStart) >= time.Duration(rule.MinDaysBeforeStart)*24*time.Hour
+		case DiscountManual:
+			apply = requested[rule.Code]
+		}
+		delete(requested, rule.Code)
+		if apply {
+			applicable = append(applicable, rule)
+		}
+	}
+	for code := range requested {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDiscount, code)
+	}
+
+	remaining := p.config.MaxDiscountPercent
+	capped := make([]DiscountRule, 0, len(applicable))
+	for _, rule := range applicable {
+		if remaining <= 0 {
+			break
+		}
+		if rule.Percent > remaining {
+			rule.Percent = remaining
+		}
+		remaining -= rule.Percent
+		capped = append(capped, rule)
+	}
+	return capped, nil
+}
+
+// monthsSpanned counts calendar months touched by [start, end], at least 1
+func monthsSpanned(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month()) + 1
+	if months < 1 {
+		return 1
+	}
+	return months
+}
@@ -0,0 +1,27 @@
+package billing
+
+import (
+	"doan/pkg/config"
+)
+
+// NewInvoicePlanner builds the planner from the "billing" config block
+func NewInvoicePlanner(cfg config.Manager) (Planner, error) {
+	billingConfig := Config{}
+	if cfg.IsSet("billing") {
+		if err := cfg.UnmarshalKey("billing", &billingConfig); err != nil {
+			return nil, err
+		}
+	}
+	return NewPlanner(billingConfig)
+}
+
+// NewReminderSchedule builds the reminder schedule from the "reminders" config block
+func NewReminderSchedule(cfg config.Manager) (DunningSchedule, error) {
+	dunningConfig := DunningConfig{}
+	if cfg.IsSet("reminders") {
+		if err := cfg.UnmarshalKey("reminders", &dunningConfig); err != nil {
+			return nil, err
+		}
+	}
+	return NewDunningSchedule(dunningConfig)
+}
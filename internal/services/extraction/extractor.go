@@ -0,0 +1,121 @@
+package extraction
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/pkg/utils"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedType is returned when no extractor handles the MIME type of a material
+var ErrUnsupportedType = errors.New("no text extractor for this file type")
+
+// maxDecodedSize caps what one document may decompress in all streams or package parts together, so many
+// parts just under their own limit, or one stream drawn on every page, cannot add up to a zip bomb
+const maxDecodedSize = 256 << 20
+
+var errDecodedTooLarge = errors.New("document exceeds the decompressed size limit")
+
+// ExtractionResult is the plain text of a document.
+// Pages counts PDF pages, slides or sheets; single-page formats report 1.
+type ExtractionResult struct {
+	Extractor  string
+	Text       string
+	Pages      int
+	PageErrors []entities.PageError
+}
+
+// TextExtractor turns the content of a material into plain text.
+// Failures limited to some pages are reported in PageErrors, an error is only
+// returned when nothing could be read.
+type TextExtractor interface {
+	Name() string
+	Supports(mimeType string) bool
+	Extract(ctx context.Context, mimeType string, content []byte) (*ExtractionResult, error)
+}
+
+type compositeExtractor struct {
+	extractors []TextExtractor
+}
+
+// NewCompositeExtractor dispatches to the first extractor supporting the MIME type and normalises its text
+func NewCompositeExtractor(extractors ...TextExtractor) TextExtractor {
+	return &compositeExtractor{extractors: extractors}
+}
+
+func (c *compositeExtractor) Name() string {
+	names := make([]string, 0, len(c.extractors))
+	for _, extractor := range c.extractors {
+		names = append(names, extractor.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+func (c *compositeExtractor) Supports(mimeType string) bool {
+	return c.find(mimeType) != nil
+}
+
+func (c *compositeExtractor) Extract(ctx context.Context, mimeType string, content []byte) (*ExtractionResult, error) {
+	extractor := c.find(mimeType)
+	if extractor == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, baseMimeType(mimeType))
+	}
+	result, err := extractor.Extract(ctx, baseMimeType(mimeType), content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", extractor.Name(), err)
+	}
+	result.Extractor = extractor.Name()
+	result.Text = utils.NormalizeText(result.Text)
+	return result, nil
+}
+
+func (c *compositeExtractor) find(mimeType string) TextExtractor {
+	mimeType = baseMimeType(mimeType)
+	for _, extractor := range c.extractors {
+		if extractor.Supports(mimeType) {
+			return extractor
+		}
+	}
+	return nil
+}
+
+// baseMimeType strips parameters such as "; charset=utf-8"
+func baseMimeType(mimeType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(mimeType, ";")[0]))
+}
+
+// decodeBudget counts down the bytes one document may still decompress
+type decodeBudget struct {
+	remaining int
+}
+
+func newDecodeBudget() *decodeBudget {
+	return &decodeBudget{remaining: maxDecodedSize}
+}
+
+// limit is the most the next part may decompress: its own cap, or less once the document is close to its total
+func (b *decodeBudget) limit(partLimit int) int {
+	return min(partLimit, b.remaining)
+}
+
+// spend charges n decompressed bytes, failing once the document total is used up
+func (b *decodeBudget) spend(n int) error {
+	if n > b.remaining {
+		b.remaining = 0
+		return errDecodedTooLarge
+	}
+	b.remaining -= n
+	return nil
+}
+
+// pageError builds a PageError; page 0 refers to the whole document
+func pageError(page int, err error) entities.PageError {
+	return entities.PageError{Page: page, Message: err.Error()}
+}
+
+// joinPages joins page texts with a blank line so page boundaries survive normalisation
+func joinPages(pages []string) string {
+	return strings.Join(pages, "\n\n")
+}
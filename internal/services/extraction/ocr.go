@@ -0,0 +1,86 @@
+package extraction
+
+import (
+	"bytes"
+	"context"
+	"doan/pkg/constants"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ExtractorTesseract reads the text of images through a local Tesseract binary
+const ExtractorTesseract = "tesseract"
+
+// OCRConfig configures the Tesseract adapter
+type OCRConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	Binary         string `mapstructure:"binary"`
+	Languages      string `mapstructure:"languages"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+}
+
+type tesseractExtractor struct {
+	binary    string
+	languages string
+	timeout   time.Duration
+}
+
+// NewTesseractExtractor returns the OCR adapter, or nil when OCR is disabled or the binary is not installed
+func NewTesseractExtractor(config OCRConfig) TextExtractor {
+	if !config.Enabled {
+		return nil
+	}
+	binary := config.Binary
+	if binary == "" {
+		binary = "tesseract"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil
+	}
+	languages := config.Languages
+	if languages == "" {
+		languages = "vie+eng"
+	}
+	timeout := time.Duration(config.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+	return &tesseractExtractor{binary: path, languages: languages, timeout: timeout}
+}
+
+func (e *tesseractExtractor) Name() string {
+	return ExtractorTesseract
+}
+
+func (e *tesseractExtractor) Supports(mimeType string) bool {
+	switch mimeType {
+	case constants.MimeTypeImageJPEG, constants.MimeTypeImagePNG, constants.MimeTypeImageGIF,
+		constants.MimeTypeImageWebP, constants.MimeTypeImageBMP, constants.MimeTypeImageTIFF:
+		return true
+	}
+	return false
+}
+
+func (e *tesseractExtractor) Extract(ctx context.Context, _ string, content []byte) (*ExtractionResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	// "stdin stdout" makes Tesseract read the image from stdin and print the text
+	cmd := exec.CommandContext(ctx, e.binary, "stdin", "stdout", "-l", e.languages)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("OCR timed out after %s", e.timeout)
+		}
+		return nil, fmt.Errorf("OCR failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return &ExtractionResult{Text: stdout.String(), Pages: 1}, nil
+}
@@ -0,0 +1,358 @@
+package extraction
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"doan/pkg/constants"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractorOOXML reads Office Open XML packages (DOCX, XLSX, PPTX)
+const ExtractorOOXML = "ooxml"
+
+// maxZipEntrySize caps the uncompressed size read from one package part (zip bomb guard)
+const maxZipEntrySize = 64 << 20
+
+var errZipEntryTooLarge = errors.New("package part exceeds the size limit")
+
+type ooxmlExtractor struct{}
+
+// NewOOXMLExtractor creates the extractor for DOCX, XLSX and PPTX files
+func NewOOXMLExtractor() TextExtractor {
+	return &ooxmlExtractor{}
+}
+
+func (e *ooxmlExtractor) Name() string {
+	return ExtractorOOXML
+}
+
+func (e *ooxmlExtractor) Supports(mimeType string) bool {
+	switch mimeType {
+	case constants.MimeTypeWordDocx, constants.MimeTypeExcelXlsx, constants.MimeTypePowerPointPptx:
+		return true
+	}
+	return false
+}
+
+func (e *ooxmlExtractor) Extract(ctx context.Context, mimeType string, content []byte) (*ExtractionResult, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("open package: %w", err)
+	}
+	budget := newDecodeBudget()
+	switch mimeType {
+	case constants.MimeTypeWordDocx:
+		return extractDocx(archive, budget)
+	case constants.MimeTypePowerPointPptx:
+		return extractPptx(ctx, archive, budget)
+	default:
+		return extractXlsx(ctx, archive, budget)
+	}
+}
+
+// extractDocx reads the main document part; Word does not store page breaks, so it counts as one page
+func extractDocx(archive *zip.Reader, budget *decodeBudget) (*ExtractionResult, error) {
+	part := findZipEntry(archive, "word/document.xml")
+	if part == nil {
+		return nil, errors.New("word/document.xml not found")
+	}
+	data, err := readZipEntry(part, budget)
+	if err != nil {
+		return nil, err
+	}
+	text, err := drawingMLText(data, "p")
+	if err != nil {
+		return nil, fmt.Errorf("parse document: %w", err)
+	}
+	return &ExtractionResult{Text: text, Pages: 1}, nil
+}
+
+// extractPptx reads every slide in order, each slide being one page
+func extractPptx(ctx context.Context, archive *zip.Reader, budget *decodeBudget) (*ExtractionResult, error) {
+	slides := numberedEntries(archive, "ppt/slides/slide")
+	if len(slides) == 0 {
+		return nil, errors.New("presentation has no slides")
+	}
+
+	result := &ExtractionResult{Pages: len(slides)}
+	texts := make([]string, 0, len(slides))
+	for i, slide := range slides {
+		page := i + 1
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := readZipEntry(slide, budget)
+		if err != nil {
+			result.PageErrors = append(result.PageErrors, pageError(page, err))
+			continue
+		}
+		text, err := drawingMLText(data, "p")
+		if err != nil {
+			result.PageErrors = append(result.PageErrors, pageError(page, fmt.Errorf("parse slide: %w", err)))
+			continue
+		}
+		texts = append(texts, text)
+	}
+	if len(texts) == 0 {
+		return nil, errors.New("no slide could be read")
+	}
+	result.Text = joinPages(texts)
+	return result, nil
+}
+
+// extractXlsx reads every worksheet, each sheet being one page; cells are tab separated
+func extractXlsx(ctx context.Context, archive *zip.Reader, budget *decodeBudget) (*ExtractionResult, error) {
+	sheets := numberedEntries(archive, "xl/worksheets/sheet")
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+
+	result := &ExtractionResult{Pages: len(sheets)}
+	var sharedStrings []string
+	if part := findZipEntry(archive, "xl/sharedStrings.xml"); part != nil {
+		data, err := readZipEntry(part, budget)
+		if err == nil {
+			sharedStrings, err = parseSharedStrings(data)
+		}
+		if err != nil {
+			// Every text cell points into the shared strings, only numbers remain readable
+			result.PageErrors = append(result.PageErrors, pageError(0, fmt.Errorf("shared strings: %w", err)))
+		}
+	}
+
+	texts := make([]string, 0, len(sheets))
+	for i, sheet := range sheets {
+		page := i + 1
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := readZipEntry(sheet, budget)
+		if err != nil {
+			result.PageErrors = append(result.PageErrors, pageError(page, err))
+			continue
+		}
+		text, err := worksheetText(data, sharedStrings)
+		if err != nil {
+			result.PageErrors = append(result.PageErrors, pageError(page, fmt.Errorf("parse sheet: %w", err)))
+			continue
+		}
+		texts = append(texts, text)
+	}
+	if len(texts) == 0 {
+		return nil, errors.New("no sheet could be read")
+	}
+	result.Text = joinPages(texts)
+	return result, nil
+}
+
+// drawingMLText collects <t> runs of WordprocessingML/DrawingML, ending a line at each paragraph element
+func drawingMLText(data []byte, paragraph string) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var b strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return b.String(), err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteByte('\t')
+			case "br", "cr":
+				b.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case paragraph:
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+}
+
+// parseSharedStrings returns the shared string table, one entry per <si> (rich text runs concatenated)
+func parseSharedStrings(data []byte) ([]string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		items   []string
+		current strings.Builder
+		inText  bool
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return items, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "si" {
+				current.Reset()
+			}
+			if t.Name.Local == "t" {
+				inText = true
+			}
+		case xml.EndElement:
+			if t.Name.Local == "t" {
+				inText = false
+			}
+			if t.Name.Local == "si" {
+				items = append(items, current.String())
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		}
+	}
+}
+
+// worksheetText renders a sheet row by row, resolving shared string references
+func worksheetText(data []byte, sharedStrings []string) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		b         strings.Builder
+		row       []string
+		cellType  string
+		value     strings.Builder
+		inValue   bool
+		inlineStr bool
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return b.String(), err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				row = row[:0]
+			case "c":
+				cellType = ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "t" {
+						cellType = attr.Value
+					}
+				}
+				value.Reset()
+			case "v":
+				inValue = true
+			case "is":
+				inlineStr = true
+			case "t":
+				inValue = inlineStr
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "is":
+				inlineStr = false
+			case "c":
+				cell := value.String()
+				if cellType == "s" {
+					index, err := strconv.Atoi(strings.TrimSpace(cell))
+					if err == nil && index >= 0 && index < len(sharedStrings) {
+						cell = sharedStrings[index]
+					} else {
+						cell = ""
+					}
+				}
+				row = append(row, cell)
+			case "row":
+				line := strings.TrimRight(strings.Join(row, "\t"), "\t")
+				if line != "" {
+					b.WriteString(line)
+					b.WriteByte('\n')
+				}
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+		}
+	}
+}
+
+func findZipEntry(archive *zip.Reader, name string) *zip.File {
+	for _, f := range archive.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// numberedEntries returns the parts named <prefix>N.xml ordered by N (slide2 before slide10)
+func numberedEntries(archive *zip.Reader, prefix string) []*zip.File {
+	type numbered struct {
+		number int
+		file   *zip.File
+	}
+	var entries []numbered
+	for _, f := range archive.File {
+		if !strings.HasPrefix(f.Name, prefix) || path.Ext(f.Name) != ".xml" {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(f.Name, prefix), ".xml"))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, numbered{number: number, file: f})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].number < entries[j].number })
+
+	files := make([]*zip.File, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, entry.file)
+	}
+	return files
+}
+
+func readZipEntry(f *zip.File, budget *decodeBudget) ([]byte, error) {
+	reader, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", f.Name, err)
+	}
+	defer reader.Close()
+
+	limit := budget.limit(maxZipEntrySize)
+	data, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", f.Name, err)
+	}
+	if len(data) > maxZipEntrySize {
+		return nil, fmt.Errorf("%s: %w", f.Name, errZipEntryTooLarge)
+	}
+	if err := budget.spend(len(data)); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,91 @@
+package extraction
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"doan/pkg/constants"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// zipFixture builds a package holding the given parts, in the order given
+func zipFixture(t *testing.T, parts ...[2]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for _, part := range parts {
+		f, err := w.Create(part[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(part[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+const docxDocument = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:r><w:t>Bài giảng</w:t></w:r><w:r><w:t xml:space="preserve"> số một</w:t></w:r></w:p>
+    <w:p><w:r><w:t>Second paragraph</w:t></w:r></w:p>
+  </w:body>
+</w:document>`
+
+func TestDocxExtract(t *testing.T) {
+	docx := zipFixture(t, [2]string{"[Content_Types].xml", `<Types/>`}, [2]string{"word/document.xml", docxDocument})
+
+	result, err := NewOOXMLExtractor().Extract(context.Background(), constants.MimeTypeWordDocx, docx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pages != 1 {
+		t.Errorf("pages = %d, want 1", result.Pages)
+	}
+	for _, want := range []string{"Bài giảng số một", "Second paragraph"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("text %q does not contain %q", result.Text, want)
+		}
+	}
+
+	if _, err := NewOOXMLExtractor().Extract(context.Background(), constants.MimeTypeWordDocx,
+		zipFixture(t, [2]string{"word/other.xml", docxDocument})); err == nil {
+		t.Error("Extract read a package without word/document.xml")
+	}
+}
+
+func TestReadZipEntryBudget(t *testing.T) {
+	slide := `<p:sld xmlns:p="p" xmlns:a="a"><a:p><a:r><a:t>` + strings.Repeat("x", 1000) + `</a:t></a:r></a:p></p:sld>`
+	pptx := zipFixture(t,
+		[2]string{"ppt/slides/slide1.xml", slide},
+		[2]string{"ppt/slides/slide2.xml", slide},
+		[2]string{"ppt/slides/slide3.xml", slide},
+	)
+	archive, err := zip.NewReader(bytes.NewReader(pptx), int64(len(pptx)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each slide is well under the part limit, together they are over the document's budget
+	budget := &decodeBudget{remaining: 2*len(slide) + 1}
+	for i, f := range archive.File {
+		_, err := readZipEntry(f, budget)
+		if want := i == 2; errors.Is(err, errDecodedTooLarge) != want {
+			t.Errorf("%s: err = %v, want over the budget %v", f.Name, err, want)
+		}
+	}
+
+	result, err := extractPptx(context.Background(), archive, &decodeBudget{remaining: 2*len(slide) + 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pages != 3 || len(result.PageErrors) != 1 || result.PageErrors[0].Page != 3 {
+		t.Errorf("pages = %d, errors = %v; want 3 pages with slide 3 over the budget", result.Pages, result.PageErrors)
+	}
+}
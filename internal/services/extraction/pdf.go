@@ -0,0 +1,308 @@
+package extraction
+
+import (
+	"bytes"
+	"context"
+	"doan/pkg/constants"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ExtractorPDF reads the text layer of PDF documents natively
+const ExtractorPDF = "pdf"
+
+// maxFormDepth limits nested form XObjects
+const maxFormDepth = 8
+
+var errNoUnicodeMap = errors.New("some text uses a font without Unicode mapping and was skipped")
+
+type pdfExtractor struct {
+	maxPages int
+}
+
+// NewPDFExtractor creates the PDF extractor; maxPages <= 0 means no limit
+func NewPDFExtractor(maxPages int) TextExtractor {
+	return &pdfExtractor{maxPages: maxPages}
+}
+
+func (e *pdfExtractor) Name() string {
+	return ExtractorPDF
+}
+
+func (e *pdfExtractor) Supports(mimeType string) bool {
+	return mimeType == constants.MimeTypePDF
+}
+
+func (e *pdfExtractor) Extract(ctx context.Context, _ string, content []byte) (*ExtractionResult, error) {
+	doc, err := parsePDF(content)
+	if err != nil {
+		return nil, err
+	}
+	pages := doc.pages()
+	if len(pages) == 0 {
+		return nil, errors.New("PDF has no pages")
+	}
+
+	result := &ExtractionResult{Pages: len(pages)}
+	reader := &pdfTextReader{doc: doc, fonts: make(map[int]*pdfFont)}
+	texts := make([]string, 0, len(pages))
+	for i, page := range pages {
+		number := i + 1
+		if e.maxPages > 0 && number > e.maxPages {
+			result.PageErrors = append(result.PageErrors, pageError(number,
+				fmt.Errorf("page limit of %d reached, %d pages not read", e.maxPages, len(pages)-e.maxPages)))
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		text, err := reader.pageText(page)
+		if err != nil {
+			result.PageErrors = append(result.PageErrors, pageError(number, err))
+		}
+		texts = append(texts, text)
+	}
+	result.Text = joinPages(texts)
+	if strings.TrimSpace(result.Text) == "" && len(result.PageErrors) == len(pages) {
+		return nil, errors.New("no page could be read")
+	}
+	return result, nil
+}
+
+// pdfFont decodes the strings shown with one font
+type pdfFont struct {
+	cmap      *pdfCMap
+	composite bool
+}
+
+func (f *pdfFont) decode(data []byte) (string, bool) {
+	switch {
+	case f == nil:
+		return latin1(data), true
+	case f.cmap != nil:
+		return f.cmap.decode(data), true
+	case f.composite:
+		// CID font without ToUnicode: glyph ids carry no character information
+		return "", false
+	default:
+		return latin1(data), true
+	}
+}
+
+// latin1 decodes simple font strings with the WinAnsi encoding, which PDF producers use by default
+func latin1(data []byte) string {
+	decoded, err := charmap.Windows1252.NewDecoder().Bytes(data)
+	if err != nil {
+		return string(data)
+	}
+	return string(decoded)
+}
+
+type pdfTextReader struct {
+	doc   *pdfDocument
+	fonts map[int]*pdfFont
+}
+
+func (r *pdfTextReader) pageText(page pdfPage) (string, error) {
+	content, err := r.doc.pageContent(page)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	missingUnicode := false
+	err = r.contentText(&b, content, page.resources, 0, &missingUnicode)
+	if err == nil && missingUnicode {
+		err = errNoUnicodeMap
+	}
+	return b.String(), err
+}
+
+func (r *pdfTextReader) font(resources pdfDict, name pdfName) *pdfFont {
+	fonts := r.doc.dict(resources["Font"])
+	if fonts == nil {
+		return nil
+	}
+	ref, isRef := fonts[name].(pdfRef)
+	if isRef {
+		if font, ok := r.fonts[ref.num]; ok {
+			return font
+		}
+	}
+
+	dict := r.doc.dict(fonts[name])
+	font := &pdfFont{}
+	if dict != nil {
+		subtype, _ := dict["Subtype"].(pdfName)
+		font.composite = subtype == "Type0"
+		if stream, ok := r.doc.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+			if data, err := r.doc.decodeStream(stream); err == nil {
+				font.cmap = parseCMap(data)
+			}
+		}
+	}
+	if isRef {
+		r.fonts[ref.num] = font
+	}
+	return font
+}
+
+// contentText interprets the text operators of a content stream (ISO 32000-1 section 9.4)
+func (r *pdfTextReader) contentText(b *strings.Builder, content []byte, resources pdfDict, depth int, missingUnicode *bool) error {
+	lexer := newPDFLexer(content)
+	var (
+		operands []interface{}
+		font     *pdfFont
+		lastY    float64
+		haveY    bool
+	)
+	show := func(value interface{}) {
+		data, ok := value.(pdfString)
+		if !ok {
+			return
+		}
+		text, ok := font.decode(data)
+		if !ok {
+			*missingUnicode = true
+		}
+		b.WriteString(text)
+	}
+	newLine := func() {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+	}
+	moveTo := func(y float64) {
+		if haveY && y != lastY {
+			newLine()
+		} else {
+			b.WriteByte(' ')
+		}
+		lastY, haveY = y, true
+	}
+
+	for {
+		object, err := lexer.readObject()
+		if errors.Is(err, errPDFEndOfData) {
+			return nil
+		}
+		if errors.Is(err, errPDFDelimiterEnd) {
+			operands = operands[:0]
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		operator, isOperator := object.(pdfKeyword)
+		if !isOperator {
+			operands = append(operands, object)
+			continue
+		}
+
+		switch operator {
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[len(operands)-2].(pdfName); ok {
+					font = r.font(resources, name)
+				}
+			}
+		case "Tj":
+			if len(operands) >= 1 {
+				show(operands[len(operands)-1])
+			}
+		case "'", "\"":
+			newLine()
+			if len(operands) >= 1 {
+				show(operands[len(operands)-1])
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				items, _ := operands[len(operands)-1].(pdfArray)
+				for _, item := range items {
+					// Large negative kerning is how many producers encode a word space
+					if adjustment, ok := pdfFloat(item); ok && adjustment < -200 {
+						b.WriteByte(' ')
+						continue
+					}
+					show(item)
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, ok := pdfFloat(operands[len(operands)-1]); ok && ty != 0 {
+					newLine()
+					lastY += ty
+				} else {
+					b.WriteByte(' ')
+				}
+			}
+		case "T*":
+			newLine()
+		case "Tm":
+			if len(operands) >= 6 {
+				if y, ok := pdfFloat(operands[len(operands)-1]); ok {
+					moveTo(y)
+				}
+			}
+		case "ET":
+			b.WriteByte(' ')
+		case "Do":
+			if len(operands) >= 1 && depth < maxFormDepth {
+				if name, ok := operands[len(operands)-1].(pdfName); ok {
+					r.formText(b, resources, name, depth, missingUnicode)
+				}
+			}
+		case "ID":
+			skipInlineImage(lexer)
+		}
+		operands = operands[:0]
+	}
+}
+
+// formText reads the text of a form XObject drawn with "Do"; failures only lose that form's text
+func (r *pdfTextReader) formText(b *strings.Builder, resources pdfDict, name pdfName, depth int, missingUnicode *bool) {
+	xobjects := r.doc.dict(resources["XObject"])
+	if xobjects == nil {
+		return
+	}
+	stream, ok := r.doc.resolve(xobjects[name]).(*pdfStream)
+	if !ok {
+		return
+	}
+	if subtype, _ := stream.dict["Subtype"].(pdfName); subtype != "Form" {
+		return
+	}
+	data, err := r.doc.decodeStream(stream)
+	if err != nil {
+		return
+	}
+	formResources := r.doc.dict(stream.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	_ = r.contentText(b, data, formResources, depth+1, missingUnicode)
+	b.WriteByte('\n')
+}
+
+// skipInlineImage moves past the binary data of an inline image (BI ... ID <data> EI)
+func skipInlineImage(lexer *pdfLexer) {
+	data := lexer.data[lexer.pos:]
+	for offset := 0; ; {
+		index := bytes.Index(data[offset:], []byte("EI"))
+		if index < 0 {
+			lexer.pos = len(lexer.data)
+			return
+		}
+		at := offset + index
+		before := at == 0 || isPDFWhitespace(data[at-1])
+		after := at+2 == len(data) || isPDFWhitespace(data[at+2])
+		if before && after {
+			lexer.pos += at + 2
+			return
+		}
+		offset = at + 2
+	}
+}
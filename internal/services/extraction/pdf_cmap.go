@@ -0,0 +1,188 @@
+package extraction
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// pdfCMap maps character codes of a font to Unicode (ToUnicode CMap, ISO 32000-1 section 9.10.3)
+type pdfCMap struct {
+	codeLengths []int
+	chars       map[string]string
+	ranges      []pdfCMapRange
+}
+
+type pdfCMapRange struct {
+	low, high uint32
+	length    int
+	base      []uint16
+	array     []string
+}
+
+func parseCMap(data []byte) *pdfCMap {
+	cmap := &pdfCMap{chars: make(map[string]string)}
+	lengths := make(map[int]bool)
+	lexer := newPDFLexer(data)
+	for {
+		object, err := lexer.readObject()
+		if errors.Is(err, errPDFEndOfData) {
+			break
+		}
+		if err != nil {
+			continue
+		}
+		switch object {
+		case pdfKeyword("begincodespacerange"):
+			for {
+				low, ok := readCMapOperand(lexer, "endcodespacerange")
+				if !ok {
+					break
+				}
+				if _, ok := readCMapOperand(lexer, "endcodespacerange"); !ok {
+					break
+				}
+				lengths[len(low)] = true
+			}
+		case pdfKeyword("beginbfchar"):
+			for {
+				src, ok := readCMapOperand(lexer, "endbfchar")
+				if !ok {
+					break
+				}
+				dst, ok := readCMapOperand(lexer, "endbfchar")
+				if !ok {
+					break
+				}
+				cmap.chars[string(src)] = utf16BEString(dst)
+				lengths[len(src)] = true
+			}
+		case pdfKeyword("beginbfrange"):
+			for {
+				low, ok := readCMapOperand(lexer, "endbfrange")
+				if !ok {
+					break
+				}
+				high, ok := readCMapOperand(lexer, "endbfrange")
+				if !ok {
+					break
+				}
+				dst, err := lexer.readObject()
+				if err != nil {
+					break
+				}
+				r := pdfCMapRange{low: codeValue(low), high: codeValue(high), length: len(low)}
+				switch value := dst.(type) {
+				case pdfString:
+					r.base = utf16Units(value)
+				case pdfArray:
+					for _, item := range value {
+						s, _ := item.(pdfString)
+						r.array = append(r.array, utf16BEString(s))
+					}
+				default:
+					continue
+				}
+				if r.high >= r.low && r.high-r.low < 1<<16 {
+					cmap.ranges = append(cmap.ranges, r)
+					lengths[len(low)] = true
+				}
+			}
+		}
+	}
+
+	for length := range lengths {
+		if length > 0 && length <= 4 {
+			cmap.codeLengths = append(cmap.codeLengths, length)
+		}
+	}
+	sort.Ints(cmap.codeLengths)
+	if len(cmap.codeLengths) == 0 {
+		cmap.codeLengths = []int{2}
+	}
+	return cmap
+}
+
+// readCMapOperand reads a hex string operand, stopping at the given end keyword
+func readCMapOperand(lexer *pdfLexer, end pdfKeyword) ([]byte, bool) {
+	object, err := lexer.readObject()
+	if err != nil || object == end {
+		return nil, false
+	}
+	value, ok := object.(pdfString)
+	return value, ok
+}
+
+// decode maps a shown string to text, trying the shortest code length first
+func (c *pdfCMap) decode(data []byte) string {
+	var b strings.Builder
+	for i := 0; i < len(data); {
+		matched := false
+		for _, length := range c.codeLengths {
+			if i+length > len(data) {
+				continue
+			}
+			if text, ok := c.lookup(data[i : i+length]); ok {
+				b.WriteString(text)
+				i += length
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			// Unmapped code: skip it using the longest code length, the usual case for CID fonts
+			i += c.codeLengths[len(c.codeLengths)-1]
+		}
+	}
+	return b.String()
+}
+
+func (c *pdfCMap) lookup(code []byte) (string, bool) {
+	if text, ok := c.chars[string(code)]; ok {
+		return text, true
+	}
+	value := codeValue(code)
+	for _, r := range c.ranges {
+		if r.length != len(code) || value < r.low || value > r.high {
+			continue
+		}
+		offset := value - r.low
+		if r.array != nil {
+			if int(offset) < len(r.array) {
+				return r.array[offset], true
+			}
+			return "", false
+		}
+		if len(r.base) == 0 {
+			return "", false
+		}
+		units := append([]uint16(nil), r.base...)
+		units[len(units)-1] += uint16(offset)
+		return string(utf16.Decode(units)), true
+	}
+	return "", false
+}
+
+func codeValue(code []byte) uint32 {
+	var value uint32
+	for _, b := range code {
+		value = value<<8 | uint32(b)
+	}
+	return value
+}
+
+func utf16Units(data []byte) []uint16 {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+	}
+	if len(data)%2 == 1 {
+		units = append(units, uint16(data[len(data)-1]))
+	}
+	return units
+}
+
+func utf16BEString(data []byte) string {
+	return string(utf16.Decode(utf16Units(data)))
+}
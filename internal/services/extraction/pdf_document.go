@@ -0,0 +1,390 @@
+package extraction
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+)
+
+var (
+	errEncryptedPDF = errors.New("encrypted PDF is not supported")
+	errNotPDF       = errors.New("not a PDF document")
+
+	pdfObjectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+)
+
+// maxStreamSize caps the decoded size of one PDF stream (zip bomb guard)
+const maxStreamSize = 64 << 20
+
+// pdfDocument indexes the objects of a PDF by scanning for "N G obj" headers instead of
+// trusting the cross-reference table, which is often broken in generated files.
+type pdfDocument struct {
+	objects map[int]interface{}
+	trailer pdfDict
+	budget  *decodeBudget
+}
+
+func parsePDF(data []byte) (*pdfDocument, error) {
+	header := data
+	if len(header) > 1024 {
+		header = header[:1024]
+	}
+	if !bytes.Contains(header, []byte("%PDF-")) {
+		return nil, errNotPDF
+	}
+
+	doc := &pdfDocument{objects: make(map[int]interface{}), budget: newDecodeBudget()}
+	end := 0
+	for _, match := range pdfObjectHeader.FindAllSubmatchIndex(data, -1) {
+		if match[0] < end {
+			// Inside the previous object's stream data
+			continue
+		}
+		num, ok := atoiBytes(data[match[2]:match[3]])
+		if !ok {
+			continue
+		}
+		lexer := newPDFLexer(data)
+		lexer.pos = match[1]
+		object, err := lexer.readObject()
+		if err != nil {
+			continue
+		}
+		if dict, ok := object.(pdfDict); ok {
+			if stream, streamEnd, ok := readStreamData(data, lexer.pos, dict); ok {
+				object = stream
+				lexer.pos = streamEnd
+			}
+			if typ, _ := dict["Type"].(pdfName); typ == "XRef" {
+				doc.trailer = dict
+			}
+		}
+		// Later definitions win: incremental updates append new versions
+		doc.objects[num] = object
+		end = lexer.pos
+	}
+
+	if index := bytes.LastIndex(data, []byte("trailer")); index >= 0 {
+		lexer := newPDFLexer(data)
+		lexer.pos = index + len("trailer")
+		if object, err := lexer.readObject(); err == nil {
+			if dict, ok := object.(pdfDict); ok {
+				doc.trailer = dict
+			}
+		}
+	}
+	if doc.trailer != nil && doc.trailer["Encrypt"] != nil {
+		return nil, errEncryptedPDF
+	}
+
+	doc.loadObjectStreams()
+	if len(doc.objects) == 0 {
+		return nil, errors.New("no PDF objects found")
+	}
+	return doc, nil
+}
+
+// readStreamData returns the stream following a dictionary at pos, if any
+func readStreamData(data []byte, pos int, dict pdfDict) (*pdfStream, int, bool) {
+	lexer := newPDFLexer(data)
+	lexer.pos = pos
+	lexer.skipSpace()
+	if !bytes.HasPrefix(data[lexer.pos:], []byte("stream")) {
+		return nil, pos, false
+	}
+	start := lexer.pos + len("stream")
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start < len(data) && data[start] == '\n' {
+		start++
+	}
+
+	if length, ok := pdfInt(dict["Length"]); ok && length >= 0 && start+length <= len(data) {
+		rest := bytes.TrimLeft(data[start+length:], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return &pdfStream{dict: dict, raw: data[start : start+length]}, start + length, true
+		}
+	}
+	// Indirect or wrong /Length: fall back to the endstream keyword
+	index := bytes.Index(data[start:], []byte("endstream"))
+	if index < 0 {
+		return &pdfStream{dict: dict, raw: data[start:]}, len(data), true
+	}
+	raw := bytes.TrimRight(data[start:start+index], "\r\n")
+	return &pdfStream{dict: dict, raw: raw}, start + index + len("endstream"), true
+}
+
+// loadObjectStreams adds the objects compressed in /Type /ObjStm streams (PDF 1.5+)
+func (d *pdfDocument) loadObjectStreams() {
+	nums := make([]int, 0)
+	for num, object := range d.objects {
+		if stream, ok := object.(*pdfStream); ok {
+			if typ, _ := stream.dict["Type"].(pdfName); typ == "ObjStm" {
+				nums = append(nums, num)
+			}
+		}
+	}
+	sort.Ints(nums)
+
+	for _, num := range nums {
+		stream := d.objects[num].(*pdfStream)
+		data, err := d.decodeStream(stream)
+		if err != nil {
+			continue
+		}
+		count, _ := pdfInt(stream.dict["N"])
+		first, _ := pdfInt(d.resolve(stream.dict["First"]))
+		if first <= 0 || first > len(data) {
+			continue
+		}
+
+		header := newPDFLexer(data[:first])
+		for i := 0; i < count; i++ {
+			objectNumber, err1 := header.readObject()
+			offset, err2 := header.readObject()
+			if err1 != nil || err2 != nil {
+				break
+			}
+			n, ok1 := pdfInt(objectNumber)
+			o, ok2 := pdfInt(offset)
+			if !ok1 || !ok2 || first+o >= len(data) {
+				continue
+			}
+			if _, exists := d.objects[n]; exists {
+				continue
+			}
+			lexer := newPDFLexer(data)
+			lexer.pos = first + o
+			if object, err := lexer.readObject(); err == nil {
+				d.objects[n] = object
+			}
+		}
+	}
+}
+
+// resolve follows indirect references
+func (d *pdfDocument) resolve(v interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = d.objects[ref.num]
+	}
+	return nil
+}
+
+func (d *pdfDocument) dict(v interface{}) pdfDict {
+	switch value := d.resolve(v).(type) {
+	case pdfDict:
+		return value
+	case *pdfStream:
+		return value.dict
+	}
+	return nil
+}
+
+// decodeStream applies the stream filters; only the filters used for text and object streams are supported.
+// Every inflated stream is charged to the document's decode budget, also when it was decoded before.
+func (d *pdfDocument) decodeStream(stream *pdfStream) ([]byte, error) {
+	data := stream.raw
+	var filters []interface{}
+	switch filter := d.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{filter}
+	case pdfArray:
+		filters = filter
+	}
+
+	for _, f := range filters {
+		name, _ := d.resolve(f).(pdfName)
+		var err error
+		switch name {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data, d.budget.limit(maxStreamSize))
+			if err == nil {
+				err = d.budget.spend(len(data))
+			}
+		case "ASCIIHexDecode", "AHx":
+			data, err = asciiHexDecode(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85Decode(data)
+		default:
+			return nil, fmt.Errorf("unsupported stream filter %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return data, nil
+}
+
+// inflate decompresses a zlib stream, failing when it holds more than limit bytes
+func inflate(data []byte, limit int) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	out, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if len(out) > limit {
+		if limit < maxStreamSize {
+			return nil, errDecodedTooLarge
+		}
+		return nil, errors.New("stream exceeds the size limit")
+	}
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	// Truncated streams are common; keep what was inflated
+	return out, nil
+}
+
+func asciiHexDecode(data []byte) ([]byte, error) {
+	lexer := newPDFLexer(append(append([]byte{'<'}, data...), '>'))
+	value, err := lexer.readHexString()
+	if err != nil {
+		return nil, err
+	}
+	return value.(pdfString), nil
+}
+
+func ascii85Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("<~"))
+	if index := bytes.Index(data, []byte("~>")); index >= 0 {
+		data = data[:index]
+	}
+	out := make([]byte, len(data)*4/5+4)
+	n, _, err := ascii85.Decode(out, data, true)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// pdfPage is a leaf of the page tree with its (possibly inherited) resources
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages walks the page tree from the catalog; documents with a broken tree fall back to every /Type /Page object
+func (d *pdfDocument) pages() []pdfPage {
+	var pages []pdfPage
+	root := d.dict(d.trailer["Root"])
+	if root == nil {
+		root = d.findByType("Catalog")
+	}
+	if root != nil {
+		visited := make(map[int]bool)
+		d.walkPages(root["Pages"], nil, visited, &pages, 0)
+	}
+	if len(pages) > 0 {
+		return pages
+	}
+
+	nums := make([]int, 0)
+	for num, object := range d.objects {
+		if dict, ok := object.(pdfDict); ok {
+			if typ, _ := dict["Type"].(pdfName); typ == "Page" {
+				nums = append(nums, num)
+			}
+		}
+	}
+	sort.Ints(nums)
+	for _, num := range nums {
+		dict := d.objects[num].(pdfDict)
+		pages = append(pages, pdfPage{dict: dict, resources: d.dict(dict["Resources"])})
+	}
+	return pages
+}
+
+func (d *pdfDocument) walkPages(node interface{}, inherited pdfDict, visited map[int]bool, pages *[]pdfPage, depth int) {
+	if depth > 64 {
+		return
+	}
+	if ref, ok := node.(pdfRef); ok {
+		if visited[ref.num] {
+			return
+		}
+		visited[ref.num] = true
+	}
+	dict := d.dict(node)
+	if dict == nil {
+		return
+	}
+	resources := inherited
+	if own := d.dict(dict["Resources"]); own != nil {
+		resources = own
+	}
+
+	kids, isTree := d.resolve(dict["Kids"]).(pdfArray)
+	if typ, _ := dict["Type"].(pdfName); typ == "Page" || !isTree {
+		*pages = append(*pages, pdfPage{dict: dict, resources: resources})
+		return
+	}
+	for _, kid := range kids {
+		d.walkPages(kid, resources, visited, pages, depth+1)
+	}
+}
+
+func (d *pdfDocument) findByType(typ pdfName) pdfDict {
+	for _, object := range d.objects {
+		if dict, ok := object.(pdfDict); ok && dict["Type"] == typ {
+			return dict
+		}
+	}
+	return nil
+}
+
+// pageContent concatenates the content streams of a page
+func (d *pdfDocument) pageContent(page pdfPage) ([]byte, error) {
+	var streams []interface{}
+	switch contents := d.resolve(page.dict["Contents"]).(type) {
+	case *pdfStream:
+		streams = []interface{}{contents}
+	case pdfArray:
+		streams = contents
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected page contents %T", contents)
+	}
+
+	var out []byte
+	for _, s := range streams {
+		stream, ok := d.resolve(s).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := d.decodeStream(stream)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data...)
+		out = append(out, '\n')
+	}
+	return out, nil
+}
+
+func atoiBytes(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+		if n > 1<<30 {
+			return 0, false
+		}
+	}
+	return n, len(b) > 0
+}
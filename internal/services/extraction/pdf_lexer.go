@@ -0,0 +1,396 @@
+package extraction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Minimal PDF object syntax (ISO 32000-1, section 7.3), enough to read page trees,
+// content streams and ToUnicode CMaps.
+
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfString  []byte
+	pdfArray   []interface{}
+	pdfDict    map[pdfName]interface{}
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		raw  []byte
+	}
+)
+
+var (
+	errPDFEndOfData    = errors.New("unexpected end of PDF data")
+	errPDFUnbalanced   = errors.New("unbalanced PDF delimiter")
+	errPDFDelimiterEnd = errors.New("closing delimiter")
+	errPDFTooDeep      = errors.New("PDF objects nested too deeply")
+)
+
+// maxPDFNesting bounds how deeply arrays and dictionaries may nest; real files stay in single digits,
+// and without a bound "[[[[..." would recurse until the stack overflows
+const maxPDFNesting = 256
+
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func newPDFLexer(data []byte) *pdfLexer {
+	return &pdfLexer{data: data}
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *pdfLexer) eof() bool {
+	return l.pos >= len(l.data)
+}
+
+// skipSpace skips whitespace and comments
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isPDFWhitespace(c) {
+			l.pos++
+			continue
+		}
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+// readObject reads the next object; operators and other bare words come back as pdfKeyword
+func (l *pdfLexer) readObject() (interface{}, error) {
+	l.skipSpace()
+	if l.eof() {
+		return nil, errPDFEndOfData
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.readName(), nil
+	case c == '(':
+		return l.readLiteralString()
+	case c == '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return l.readDict()
+		}
+		return l.readHexString()
+	case c == '[':
+		l.pos++
+		return l.readArray()
+	case c == ']' || c == '>' || c == ')' || c == '}':
+		l.pos++
+		if c == '>' && l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+		}
+		return pdfKeyword([]byte{c}), errPDFDelimiterEnd
+	case c == '{':
+		l.pos++
+		return pdfKeyword("{"), nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.readNumberOrRef()
+	default:
+		return l.readKeyword(), nil
+	}
+}
+
+func (l *pdfLexer) readToken() []byte {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFWhitespace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start && l.pos < len(l.data) {
+		// Stray delimiter: consume it so the caller makes progress
+		l.pos++
+	}
+	return l.data[start:l.pos]
+}
+
+func (l *pdfLexer) readKeyword() interface{} {
+	word := string(l.readToken())
+	switch word {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	return pdfKeyword(word)
+}
+
+func (l *pdfLexer) readName() pdfName {
+	l.pos++ // '/'
+	raw := l.readNameToken()
+	if bytes.IndexByte(raw, '#') < 0 {
+		return pdfName(raw)
+	}
+	decoded := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if v, err := strconv.ParseUint(string(raw[i+1:i+3]), 16, 8); err == nil {
+				decoded = append(decoded, byte(v))
+				i += 2
+				continue
+			}
+		}
+		decoded = append(decoded, raw[i])
+	}
+	return pdfName(decoded)
+}
+
+func (l *pdfLexer) readNameToken() []byte {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFWhitespace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return l.data[start:l.pos]
+}
+
+func (l *pdfLexer) readNumberOrRef() (interface{}, error) {
+	number := l.readNumber()
+	integer, ok := number.(int64)
+	if !ok || integer < 0 {
+		return number, nil
+	}
+
+	// "N G R" is an indirect reference
+	saved := l.pos
+	l.skipSpace()
+	if l.eof() || l.data[l.pos] < '0' || l.data[l.pos] > '9' {
+		l.pos = saved
+		return number, nil
+	}
+	generation, ok := l.readNumber().(int64)
+	if ok {
+		l.skipSpace()
+		if l.pos < len(l.data) && l.data[l.pos] == 'R' &&
+			(l.pos+1 == len(l.data) || isPDFWhitespace(l.data[l.pos+1]) || isPDFDelimiter(l.data[l.pos+1])) {
+			l.pos++
+			return pdfRef{num: int(integer), gen: int(generation)}, nil
+		}
+	}
+	l.pos = saved
+	return number, nil
+}
+
+func (l *pdfLexer) readNumber() interface{} {
+	token := string(l.readToken())
+	if integer, err := strconv.ParseInt(token, 10, 64); err == nil {
+		return integer
+	}
+	if real, err := strconv.ParseFloat(token, 64); err == nil {
+		return real
+	}
+	return pdfKeyword(token)
+}
+
+func (l *pdfLexer) readLiteralString() (interface{}, error) {
+	l.pos++ // '('
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return pdfString(out), nil
+			}
+		case '\\':
+			if l.eof() {
+				return pdfString(out), errPDFEndOfData
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				// Line continuation
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					value := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(value))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return pdfString(out), errPDFEndOfData
+}
+
+func (l *pdfLexer) readHexString() (interface{}, error) {
+	l.pos++ // '<'
+	var out []byte
+	var high byte
+	haveHigh := false
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		if c == '>' {
+			if haveHigh {
+				out = append(out, high<<4)
+			}
+			return pdfString(out), nil
+		}
+		value, ok := hexValue(c)
+		if !ok {
+			continue
+		}
+		if haveHigh {
+			out = append(out, high<<4|value)
+			haveHigh = false
+		} else {
+			high = value
+			haveHigh = true
+		}
+	}
+	return pdfString(out), errPDFEndOfData
+}
+
+func hexValue(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// enter counts one more level of nesting, undone by leave
+func (l *pdfLexer) enter() error {
+	if l.depth >= maxPDFNesting {
+		return errPDFTooDeep
+	}
+	l.depth++
+	return nil
+}
+
+func (l *pdfLexer) leave() {
+	l.depth--
+}
+
+func (l *pdfLexer) readArray() (interface{}, error) {
+	if err := l.enter(); err != nil {
+		return nil, err
+	}
+	defer l.leave()
+	array := pdfArray{}
+	for {
+		value, err := l.readObject()
+		if errors.Is(err, errPDFDelimiterEnd) {
+			if value == pdfKeyword("]") {
+				return array, nil
+			}
+			return array, errPDFUnbalanced
+		}
+		if err != nil {
+			return array, err
+		}
+		array = append(array, value)
+	}
+}
+
+func (l *pdfLexer) readDict() (interface{}, error) {
+	if err := l.enter(); err != nil {
+		return nil, err
+	}
+	defer l.leave()
+	dict := pdfDict{}
+	for {
+		key, err := l.readObject()
+		if errors.Is(err, errPDFDelimiterEnd) {
+			if key == pdfKeyword(">") {
+				return dict, nil
+			}
+			return dict, errPDFUnbalanced
+		}
+		if err != nil {
+			return dict, err
+		}
+		name, ok := key.(pdfName)
+		if !ok {
+			return dict, fmt.Errorf("dictionary key is %T, not a name", key)
+		}
+		value, err := l.readObject()
+		if errors.Is(err, errPDFDelimiterEnd) {
+			// "/Key >>" with a missing value
+			dict[name] = nil
+			if value == pdfKeyword(">") {
+				return dict, nil
+			}
+			return dict, errPDFUnbalanced
+		}
+		if err != nil {
+			return dict, err
+		}
+		dict[name] = value
+	}
+}
+
+func pdfInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func pdfFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
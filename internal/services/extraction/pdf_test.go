@@ -0,0 +1,134 @@
+package extraction
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"doan/pkg/constants"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// pdfFixture assembles a PDF from object bodies numbered from 1; object 1 must be the catalog.
+// There is no cross-reference table, parsePDF finds the objects by their headers.
+func pdfFixture(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	for i, object := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+func plainStream(data string) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(data), data)
+}
+
+// flateStream is a stream object holding data compressed with FlateDecode
+func flateStream(data []byte) string {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	w.Write(data)
+	w.Close()
+	return fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes())
+}
+
+// twoPagePDF has one page with a plain content stream and one with a compressed one
+func twoPagePDF() []byte {
+	return pdfFixture(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 7 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+		plainStream("BT /F1 12 Tf 72 720 Td (Hello world) Tj ET"),
+		flateStream([]byte("BT /F1 12 Tf 72 720 Td [(Second) -300 (page)] TJ ET")),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	)
+}
+
+func TestPDFExtract(t *testing.T) {
+	result, err := NewPDFExtractor(0).Extract(context.Background(), constants.MimeTypePDF, twoPagePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pages != 2 || len(result.PageErrors) != 0 {
+		t.Fatalf("pages = %d, errors = %v; want 2 pages without errors", result.Pages, result.PageErrors)
+	}
+	for _, want := range []string{"Hello world", "Second page"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("text %q does not contain %q", result.Text, want)
+		}
+	}
+}
+
+func TestPDFLexerNesting(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"arrays at the limit", strings.Repeat("[", maxPDFNesting) + strings.Repeat("]", maxPDFNesting), nil},
+		{"arrays past the limit", strings.Repeat("[", maxPDFNesting+1) + strings.Repeat("]", maxPDFNesting+1), errPDFTooDeep},
+		{"dictionaries past the limit", strings.Repeat("<< /A ", maxPDFNesting+1), errPDFTooDeep},
+		{"unterminated arrays", strings.Repeat("[", 1<<20), errPDFTooDeep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := newPDFLexer([]byte(tt.input))
+			if _, err := lexer.readObject(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("readObject err = %v, want %v", err, tt.wantErr)
+			}
+			if lexer.depth != 0 {
+				t.Errorf("depth = %d after readObject, want 0", lexer.depth)
+			}
+		})
+	}
+
+	// Before the limit a document of nested arrays used to overflow the stack
+	bomb := append([]byte("%PDF-1.4\n1 0 obj\n"), bytes.Repeat([]byte("["), 1<<20)...)
+	if _, err := NewPDFExtractor(0).Extract(context.Background(), constants.MimeTypePDF, bomb); err == nil {
+		t.Error("Extract read a document of nested arrays")
+	}
+}
+
+func TestPDFDecodeBudget(t *testing.T) {
+	doc, err := parsePDF(twoPagePDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := doc.objects[6].(*pdfStream)
+	decoded, err := doc.decodeStream(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decoding the same stream again is charged again, so a stream drawn on every page cannot bypass the budget
+	doc.budget = &decodeBudget{remaining: 2*len(decoded) + 1}
+	for i := 0; i < 2; i++ {
+		if _, err := doc.decodeStream(stream); err != nil {
+			t.Fatalf("decode %d within the budget: %v", i+1, err)
+		}
+	}
+	if _, err := doc.decodeStream(stream); !errors.Is(err, errDecodedTooLarge) {
+		t.Errorf("decode past the budget err = %v, want errDecodedTooLarge", err)
+	}
+	if _, err := doc.decodeStream(stream); !errors.Is(err, errDecodedTooLarge) {
+		t.Errorf("decode with the budget used up err = %v, want errDecodedTooLarge", err)
+	}
+}
+
+func FuzzExtract(f *testing.F) {
+	f.Add(twoPagePDF())
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n[[[[<< /A [(x) <41> /N 1 0 R] >>]]]]\nendobj\n"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n<< /Type /ObjStm /N 2 /First 8 >>\nstream\n2 0 3 4 (a) [1]\nendstream\nendobj\n"))
+
+	extractor := NewPDFExtractor(20)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Any input may be rejected, none may panic or exhaust the stack
+		_, _ = extractor.Extract(context.Background(), constants.MimeTypePDF, data)
+	})
+}
@@ -0,0 +1,121 @@
+package extraction
+
+import (
+	"bytes"
+	"context"
+	"doan/pkg/constants"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ExtractorPlainText reads text based formats (TXT, Markdown, CSV, JSON, YAML, SQL, XML, HTML)
+const ExtractorPlainText = "plain_text"
+
+type plainTextExtractor struct{}
+
+// NewPlainTextExtractor creates the extractor for text based formats
+func NewPlainTextExtractor() TextExtractor {
+	return &plainTextExtractor{}
+}
+
+func (e *plainTextExtractor) Name() string {
+	return ExtractorPlainText
+}
+
+func (e *plainTextExtractor) Supports(mimeType string) bool {
+	switch mimeType {
+	case constants.MimeTypeJSON, constants.MimeTypeYAML, constants.MimeTypeSQL:
+		return true
+	}
+	// SVG is XML but carries no readable text worth auditing; rtf is handled nowhere yet
+	return strings.HasPrefix(mimeType, "text/")
+}
+
+func (e *plainTextExtractor) Extract(_ context.Context, mimeType string, content []byte) (*ExtractionResult, error) {
+	text := decodeText(content)
+	if mimeType == constants.MimeTypeHTML {
+		text = htmlToText(text)
+	}
+	return &ExtractionResult{Text: text, Pages: 1}, nil
+}
+
+// decodeText decodes UTF-8 (with or without BOM) or UTF-16 text; anything else is read as Windows-1252
+func decodeText(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xEF, 0xBB, 0xBF}):
+		return string(content[3:])
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		return decodeUTF16(content[2:], false)
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return decodeUTF16(content[2:], true)
+	case utf8.Valid(content):
+		return string(content)
+	}
+	decoded, err := charmap.Windows1252.NewDecoder().Bytes(content)
+	if err != nil {
+		return strings.ToValidUTF8(string(content), "")
+	}
+	return string(decoded)
+}
+
+func decodeUTF16(content []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(content[i])<<8|uint16(content[i+1]))
+		} else {
+			units = append(units, uint16(content[i+1])<<8|uint16(content[i]))
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// htmlToText keeps the visible text of an HTML document, one line per block element
+func htmlToText(document string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	var b strings.Builder
+	skipDepth := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return b.String()
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := atom.Lookup(name)
+			if tag == atom.Script || tag == atom.Style || tag == atom.Noscript || tag == atom.Template {
+				skipDepth++
+			}
+			if isBlockElement(tag) {
+				b.WriteByte('\n')
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := atom.Lookup(name)
+			if (tag == atom.Script || tag == atom.Style || tag == atom.Noscript || tag == atom.Template) && skipDepth > 0 {
+				skipDepth--
+			}
+			if isBlockElement(tag) {
+				b.WriteByte('\n')
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				b.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+func isBlockElement(tag atom.Atom) bool {
+	switch tag {
+	case atom.P, atom.Div, atom.Br, atom.Li, atom.Tr, atom.Td, atom.Th, atom.Table,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.Section, atom.Article, atom.Header, atom.Footer, atom.Blockquote, atom.Pre, atom.Hr, atom.Title:
+		return true
+	}
+	return false
+}
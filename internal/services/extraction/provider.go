@@ -0,0 +1,36 @@
+package extraction
+
+import (
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+)
+
+// Config configures text extraction ("extraction" key)
+type Config struct {
+	MaxPages int       `mapstructure:"max_pages"`
+	OCR      OCRConfig `mapstructure:"ocr"`
+}
+
+// NewTextExtractor builds the extractor chain from config. OCR is only added when the
+// Tesseract binary is found; images are then left without text.
+func NewTextExtractor(cfg config.Manager, log logger.Logger) (TextExtractor, error) {
+	extractionConfig := Config{MaxPages: 500}
+	if cfg.IsSet("extraction") {
+		if err := cfg.UnmarshalKey("extraction", &extractionConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	extractors := []TextExtractor{
+		NewPlainTextExtractor(),
+		NewOOXMLExtractor(),
+		NewPDFExtractor(extractionConfig.MaxPages),
+	}
+	if ocr := NewTesseractExtractor(extractionConfig.OCR); ocr != nil {
+		extractors = append(extractors, ocr)
+	} else if extractionConfig.OCR.Enabled {
+		log.Warn(context.Background(), "Tesseract binary not found, images will not be OCRed", "binary", extractionConfig.OCR.Binary)
+	}
+	return NewCompositeExtractor(extractors...), nil
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"context"
+	"doan/pkg/money"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown payment provider")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidEvent     = errors.New("invalid webhook event")
+)
+
+// WebhookEvent is a credit notification pushed by a payment provider
+type WebhookEvent struct {
+	Provider  string
+	Method    string       // entities.PaymentMethod*
+	Reference string       // provider transaction ID, unique per provider
+	Amount    money.Amount // credited amount
+	Content   string       // transfer content, expected to carry the invoice number
+	PayerName string
+	PaidAt    time.Time
+}
+
+// Provider verifies and decodes the webhooks of one payment provider (bank, VietQR aggregator, ...)
+type Provider interface {
+	Name() string
+	ParseWebhook(ctx context.Context, header http.Header, body []byte) (*WebhookEvent, error)
+}
+
+// Registry looks providers up by the name used in the webhook URL
+type Registry interface {
+	Get(name string) (Provider, error)
+}
+
+type registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry creates a registry over the given providers
+func NewRegistry(providers ...Provider) Registry {
+	r := &registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *registry) Get(name string) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return provider, nil
+}
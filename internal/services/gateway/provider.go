@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+)
+
+// FakeConfig is "payment.providers.fake"
+type FakeConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Secret  string `mapstructure:"secret"`
+}
+
+// NewProviderRegistry registers the providers enabled under "payment.providers"
+func NewProviderRegistry(cfg config.Manager, log logger.Logger) (Registry, error) {
+	var providers []Provider
+
+	fakeConfig := FakeConfig{}
+	if cfg.IsSet("payment.providers.fake") {
+		if err := cfg.UnmarshalKey("payment.providers.fake", &fakeConfig); err != nil {
+			return nil, err
+		}
+	}
+	if fakeConfig.Enabled {
+		fake, err := NewFakeProvider(fakeConfig.Secret)
+		if err != nil {
+			return nil, err
+		}
+		log.Warn(context.Background(), "Fake payment provider enabled, do not use in production")
+		providers = append(providers, fake)
+	}
+
+	return NewRegistry(providers...), nil
+}
@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// supportedAlgs are the ID token signature algorithms accepted
+var supportedAlgs = []string{"RS256", "RS384", "RS512", "ES256", "ES384"}
+
+// refreshInterval bounds how often an unknown kid makes the key set be fetched again
+const refreshInterval = time.Minute
+
+// keySet caches the public keys a provider publishes at its jwks_uri. A kid it does not know makes it
+// fetch the set again, so the provider's key rotation is picked up.
+type keySet struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+}
+
+type publicKey struct {
+	alg string // empty when the JWK does not name one
+	key interface{}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func newKeySet(url string, client *http.Client) *keySet {
+	return &keySet{url: url, client: client}
+}
+
+func (s *keySet) get(ctx context.Context, kid, alg string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.lookup(kid)
+	if !ok && time.Since(s.fetchedAt) >= refreshInterval {
+		keys, err := s.fetch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch provider keys: %w", err)
+		}
+		s.keys, s.fetchedAt = keys, time.Now()
+		key, ok = s.lookup(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if key.alg != "" && key.alg != alg {
+		return nil, fmt.Errorf("key %q is for %s, not %s", kid, key.alg, alg)
+	}
+	return key.key, nil
+}
+
+// lookup finds the key of kid; a token without kid is accepted only when the set holds a single key
+func (s *keySet) lookup(kid string) (publicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *keySet) fetch(ctx context.Context) (map[string]publicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks_uri returned %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]publicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// Keys of other types are skipped rather than failing the whole set
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = publicKey{alg: jwk.Alg, key: key}
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err1 := base64.RawURLEncoding.DecodeString(k.N)
+		e, err2 := base64.RawURLEncoding.DecodeString(k.E)
+		if err := errors.Join(err1, err2); err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent out of range")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err1 := base64.RawURLEncoding.DecodeString(k.X)
+		y, err2 := base64.RawURLEncoding.DecodeString(k.Y)
+		if err := errors.Join(err1, err2); err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("EC coordinates have the wrong size")
+		}
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -0,0 +1,196 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrUnknownProvider   = errors.New("unknown sign-in provider")
+	ErrInvalidState      = errors.New("sign-in attempt is unknown or expired; start again")
+	ErrInvalidIDToken    = errors.New("invalid ID token from the sign-in provider")
+	ErrExchangeFailed    = errors.New("sign-in provider rejected the authorization code")
+	ErrEmailNotVerified  = errors.New("the provider has not verified this email address")
+	ErrDomainNotAllowed  = errors.New("accounts of this email domain cannot sign in here")
+	ErrProvisionDisabled = errors.New("no account uses this email address")
+)
+
+const flowKeyPrefix = "auth:oidc:flow:"
+
+// Identity is the account a user signed in with, as asserted by the verified ID token
+type Identity struct {
+	Provider      string
+	Subject       string // stable ID of the account at the provider
+	Email         string // lower-cased
+	EmailVerified bool
+	Name          string
+	// Provision and DefaultRole say whether and how an unknown verified email gets an account
+	Provision   bool
+	DefaultRole string
+}
+
+// Authorization is where the browser goes to sign in at the provider
+type Authorization struct {
+	URL   string
+	State string
+}
+
+// Authenticator runs the authorization code flow with PKCE against the providers of "auth.oidc.providers".
+// Begin remembers the state, nonce and code verifier of the attempt in the state store; Finish accepts them once,
+// exchanges the code and verifies the ID token against the provider's published keys.
+type Authenticator interface {
+	// Providers lists the configured provider names
+	Providers() []string
+	Begin(ctx context.Context, provider string) (*Authorization, error)
+	Finish(ctx context.Context, provider, state, code string) (*Identity, error)
+}
+
+// flow is what Begin remembers for Finish
+type flow struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+}
+
+type authenticator struct {
+	providers map[string]*provider
+	cache     caching.StateStore
+	flowTTL   time.Duration
+}
+
+// NewAuthenticator creates a new instance of Authenticator. Providers discover their endpoints on first
+// use, so an unreachable provider does not stop the server.
+func NewAuthenticator(cfg config.Manager, cache caching.StateStore, log logger.Logger) (Authenticator, error) {
+	oidcConfig := Config{}
+	if cfg.IsSet(configKey) {
+		if err := cfg.UnmarshalKey(configKey, &oidcConfig); err != nil {
+			return nil, fmt.Errorf("read %s: %w", configKey, err)
+		}
+	}
+	oidcConfig.applyDefaults()
+
+	a := &authenticator{
+		providers: make(map[string]*provider, len(oidcConfig.Providers)),
+		cache:     cache,
+		flowTTL:   time.Duration(oidcConfig.FlowTTLSeconds) * time.Second,
+	}
+	for name, providerConfig := range oidcConfig.Providers {
+		p, err := newProvider(name, providerConfig, oidcConfig)
+		if err != nil {
+			return nil, err
+		}
+		a.providers[name] = p
+	}
+	if len(a.providers) > 0 {
+		log.Info(context.Background(), "OIDC sign-in providers configured", "providers", a.Providers())
+	}
+	return a, nil
+}
+
+func (a *authenticator) Providers() []string {
+	names := make([]string, 0, len(a.providers))
+	for name := range a.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *authenticator) Begin(ctx context.Context, name string) (*Authorization, error) {
+	p, ok := a.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	f := flow{Provider: name, Verifier: oauth2.GenerateVerifier(), Nonce: nonce}
+	if err := caching.SetJSON(ctx, a.cache, flowKey(state), f, a.flowTTL); err != nil {
+		return nil, fmt.Errorf("store sign-in attempt: %w", err)
+	}
+
+	url := oauthConfig.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(f.Verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	)
+	return &Authorization{URL: url, State: state}, nil
+}
+
+func (a *authenticator) Finish(ctx context.Context, name, state, code string) (*Identity, error) {
+	p, ok := a.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	f, err := a.takeFlow(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if f.Provider != name {
+		return nil, ErrInvalidState
+	}
+	return p.exchange(ctx, code, f.Verifier, f.Nonce)
+}
+
+// takeFlow returns the attempt of state and forgets it; a state presented twice is rejected even when
+// the cache entry could not be deleted
+func (a *authenticator) takeFlow(ctx context.Context, state string) (*flow, error) {
+	if state == "" {
+		return nil, ErrInvalidState
+	}
+	key := flowKey(state)
+	f, err := caching.GetJSON[flow](ctx, a.cache, key)
+	if err != nil {
+		if errors.Is(err, caching.ErrCacheMiss) {
+			return nil, ErrInvalidState
+		}
+		return nil, err
+	}
+	uses, err := a.cache.Incr(ctx, key+":used", 1, a.flowTTL)
+	if err != nil {
+		return nil, err
+	}
+	if uses > 1 {
+		return nil, ErrInvalidState
+	}
+	if err := a.cache.Delete(ctx, key); err != nil {
+		logger.NewLogger(ctx).Warnf("Failed to forget sign-in attempt: %v", err)
+	}
+	return f, nil
+}
+
+func flowKey(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return flowKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// randomString returns 256 random bits, URL-safe encoded
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,291 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"doan/pkg/constants"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	configKey             = "auth.oidc"
+	googleIssuer          = "https://accounts.google.com"
+	defaultFlowTTLSeconds = 600
+	httpTimeout           = 10 * time.Second
+	// clockLeeway tolerates drift between the provider and this server
+	clockLeeway = time.Minute
+)
+
+// Config is "auth.oidc"
+type Config struct {
+	FlowTTLSeconds int                       `mapstructure:"flow_ttl_seconds"`
+	DefaultRole    string                    `mapstructure:"default_role"`
+	Providers      map[string]ProviderConfig `mapstructure:"providers"`
+}
+
+// ProviderConfig is one entry of "auth.oidc.providers". Endpoints are discovered from the issuer unless
+// all three are set, which lets a local mock IdP stand in for a real provider.
+type ProviderConfig struct {
+	Issuer         string   `mapstructure:"issuer"` // defaults to Google's for the provider named "google"
+	ClientID       string   `mapstructure:"client_id"`
+	ClientSecret   string   `mapstructure:"client_secret"`
+	RedirectURL    string   `mapstructure:"redirect_url"` // frontend page that posts code and state back
+	Scopes         []string `mapstructure:"scopes"`
+	AuthURL        string   `mapstructure:"auth_url"`
+	TokenURL       string   `mapstructure:"token_url"`
+	JWKSURL        string   `mapstructure:"jwks_url"`
+	Provision      *bool    `mapstructure:"provision"`       // unknown verified emails get an account; default true
+	DefaultRole    string   `mapstructure:"default_role"`    // role of provisioned accounts; defaults to auth.oidc.default_role
+	AllowedDomains []string `mapstructure:"allowed_domains"` // email domains allowed to sign in; empty allows all
+}
+
+func (c *Config) applyDefaults() {
+	if c.FlowTTLSeconds <= 0 {
+		c.FlowTTLSeconds = defaultFlowTTLSeconds
+	}
+	if c.DefaultRole == "" {
+		c.DefaultRole = constants.RoleGuardian
+	}
+}
+
+// discovery is the part of the OpenID provider metadata that is used
+type discovery struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+type provider struct {
+	name      string
+	cfg       ProviderConfig
+	issuers   []string // accepted iss values
+	provision bool
+	role      string
+	client    *http.Client
+
+	mu    sync.Mutex
+	oauth *oauth2.Config
+	keys  *keySet
+}
+
+func newProvider(name string, cfg ProviderConfig, defaults Config) (*provider, error) {
+	if cfg.Issuer == "" && name == "google" {
+		cfg.Issuer = googleIssuer
+	}
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+	switch {
+	case cfg.Issuer == "":
+		return nil, fmt.Errorf("oidc provider %s: issuer is required", name)
+	case cfg.ClientID == "":
+		return nil, fmt.Errorf("oidc provider %s: client_id is required", name)
+	case cfg.RedirectURL == "":
+		return nil, fmt.Errorf("oidc provider %s: redirect_url is required", name)
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	role := cfg.DefaultRole
+	if role == "" {
+		role = defaults.DefaultRole
+	}
+	if !constants.IsValidRole(role) {
+		return nil, fmt.Errorf("oidc provider %s: unknown default_role %q", name, role)
+	}
+	// Anyone with an account at the provider could otherwise become an administrator
+	if role == constants.RoleAdmin {
+		return nil, fmt.Errorf("oidc provider %s: default_role cannot be %s", name, constants.RoleAdmin)
+	}
+
+	issuers := []string{cfg.Issuer}
+	// Google signs some ID tokens with the issuer without its scheme
+	if cfg.Issuer == googleIssuer {
+		issuers = append(issuers, strings.TrimPrefix(googleIssuer, "https://"))
+	}
+
+	return &provider{
+		name:      name,
+		cfg:       cfg,
+		issuers:   issuers,
+		provision: cfg.Provision == nil || *cfg.Provision,
+		role:      role,
+		client:    &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// oauthConfig returns the client configuration, discovering the endpoints on first use
+func (p *provider) oauthConfig(ctx context.Context) (*oauth2.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, nil
+	}
+
+	endpoints := discovery{Issuer: p.cfg.Issuer, AuthURL: p.cfg.AuthURL, TokenURL: p.cfg.TokenURL, JWKSURL: p.cfg.JWKSURL}
+	if endpoints.AuthURL == "" || endpoints.TokenURL == "" || endpoints.JWKSURL == "" {
+		discovered, err := p.discover(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("discover oidc provider %s: %w", p.name, err)
+		}
+		endpoints = *discovered
+	}
+
+	p.keys = newKeySet(endpoints.JWKSURL, p.client)
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+		Endpoint:     oauth2.Endpoint{AuthURL: endpoints.AuthURL, TokenURL: endpoints.TokenURL},
+	}
+	return p.oauth, nil
+}
+
+func (p *provider) discover(ctx context.Context) (*discovery, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery returned %s", resp.Status)
+	}
+
+	var d discovery
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return nil, fmt.Errorf("decode discovery document: %w", err)
+	}
+	if strings.TrimRight(d.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q", d.Issuer)
+	}
+	if d.AuthURL == "" || d.TokenURL == "" || d.JWKSURL == "" {
+		return nil, errors.New("discovery document lacks an endpoint")
+	}
+	return &d, nil
+}
+
+// idTokenClaims are the ID token claims that are used
+type idTokenClaims struct {
+	Nonce           string   `json:"nonce"`
+	Email           string   `json:"email"`
+	EmailVerified   flexBool `json:"email_verified"`
+	Name            string   `json:"name"`
+	AuthorizedParty string   `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// exchange trades the code for tokens and verifies the ID token
+func (p *provider) exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauthConfig.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code,
+		oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidIDToken)
+	}
+
+	claims, err := p.verify(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider:      p.name,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          strings.TrimSpace(claims.Name),
+		Provision:     p.provision,
+		DefaultRole:   p.role,
+	}
+	if !p.domainAllowed(identity.Email) {
+		return nil, ErrDomainNotAllowed
+	}
+	return identity, nil
+}
+
+func (p *provider) verify(ctx context.Context, rawIDToken, nonce string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims,
+		func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.keys.get(ctx, kid, t.Method.Alg())
+		},
+		jwt.WithValidMethods(supportedAlgs),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	issuerOK := false
+	for _, issuer := range p.issuers {
+		issuerOK = issuerOK || claims.Issuer == issuer
+	}
+	switch {
+	case !issuerOK:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	// With several audiences the token must have been issued to this client
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID:
+		return nil, fmt.Errorf("%w: issued to another client", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+func (p *provider) domainAllowed(email string) bool {
+	if len(p.cfg.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, allowed := range p.cfg.AllowedDomains {
+		if strings.EqualFold(domain, strings.TrimSpace(allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// flexBool accepts the JSON booleans and the "true"/"false" strings some providers send instead
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
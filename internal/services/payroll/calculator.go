@@ -0,0 +1,178 @@
+package payroll
+
+import (
+	"doan/internal/entities"
+	"doan/pkg/money"
+	"doan/pkg/utils"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Lesson is a lesson taught by TeacherID in a class normally taught by ClassTeacherID
+type Lesson struct {
+	ID             string
+	ClassID        string
+	ClassCode      string
+	ClassName      string
+	CourseID       *string
+	ClassTeacherID *string
+	TeacherID      string
+	DateStart      time.Time
+	DateEnd        time.Time
+}
+
+// IsSubstitution reports whether the lesson was taught by someone other than the class teacher
+func (l Lesson) IsSubstitution() bool {
+	return l.ClassTeacherID != nil && *l.ClassTeacherID != l.TeacherID
+}
+
+// Minutes is the length of the lesson, zero when the dates are inverted
+func (l Lesson) Minutes() int {
+	minutes := int(l.DateEnd.Sub(l.DateStart) / time.Minute)
+	if minutes < 0 {
+		return 0
+	}
+	return minutes
+}
+
+// Day is the calendar day of the lesson in Vietnam, at UTC midnight like DATE columns
+func Day(t time.Time) time.Time {
+	local := t.In(utils.VietnamLocation())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// MonthBounds returns [start, end) of a "YYYY-MM" month in Vietnam time
+func MonthBounds(period string) (time.Time, time.Time, error) {
+	month, err := time.ParseInLocation("2006-01", period, utils.VietnamLocation())
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q, expected YYYY-MM", period)
+	}
+	return month, month.AddDate(0, 1, 0), nil
+}
+
+// Calculate turns the lessons of a month into one payroll item per teacher.
+//
+// Each lesson is paid with the card of its course in effect on the lesson day, falling back to the teacher's
+// default card. PER_HOUR pays the lesson minutes, PER_SESSION pays the lesson, and PER_CLASS pays the monthly
+// amount prorated by the lessons the teacher taught out of all lessons of the class in the month, so a
+// substitute and the class teacher share it. Lessons without a card are kept as unrated lines with no amount.
+func Calculate(lessons []Lesson, cards []*entities.TeacherRateCard) []*entities.PayrollItem {
+	cardsByTeacher := make(map[string][]*entities.TeacherRateCard)
+	for _, card := range cards {
+		cardsByTeacher[card.TeacherID] = append(cardsByTeacher[card.TeacherID], card)
+	}
+
+	classLessons := make(map[string]int64)
+	for _, lesson := range lessons {
+		classLessons[lesson.ClassID]++
+	}
+
+	items := make(map[string]*entities.PayrollItem)
+	var order []string
+	// PER_CLASS lines are priced once all lessons are known, per teacher, class and card
+	type lineRef struct {
+		item  *entities.PayrollItem
+		index int
+	}
+	perClass := make(map[string][]lineRef)
+	var perClassKeys []string
+
+	for _, lesson := range lessons {
+		item, ok := items[lesson.TeacherID]
+		if !ok {
+			item = &entities.PayrollItem{TeacherID: lesson.TeacherID}
+			items[lesson.TeacherID] = item
+			order = append(order, lesson.TeacherID)
+		}
+
+		line := entities.PayrollLine{
+			LessonID:       lesson.ID,
+			ClassID:        lesson.ClassID,
+			ClassCode:      lesson.ClassCode,
+			ClassName:      lesson.ClassName,
+			CourseID:       lesson.CourseID,
+			DateStart:      lesson.DateStart,
+			Minutes:        lesson.Minutes(),
+			IsSubstitution: lesson.IsSubstitution(),
+		}
+		card := rateCardFor(cardsByTeacher[lesson.TeacherID], lesson.CourseID, Day(lesson.DateStart))
+		if card != nil {
+			cardID := card.ID
+			line.RateCardID = &cardID
+			line.RateType = card.RateType
+			line.Rate = card.Amount
+			switch card.RateType {
+			case entities.RateTypePerHour:
+				line.Amount = card.Amount.Ratio(int64(line.Minutes), 60)
+			case entities.RateTypePerSession:
+				line.Amount = card.Amount
+			}
+		}
+
+		item.Lines = append(item.Lines, line)
+		item.Sessions++
+		item.Minutes += line.Minutes
+		if line.IsSubstitution {
+			item.SubstitutionSessions++
+		}
+		if card == nil {
+			item.UnratedSessions++
+		}
+		if card != nil && card.RateType == entities.RateTypePerClass {
+			key := lesson.TeacherID + "|" + lesson.ClassID + "|" + card.ID
+			if _, seen := perClass[key]; !seen {
+				perClassKeys = append(perClassKeys, key)
+			}
+			perClass[key] = append(perClass[key], lineRef{item: item, index: len(item.Lines) - 1})
+		}
+	}
+
+	for _, key := range perClassKeys {
+		refs := perClass[key]
+		first := refs[0].item.Lines[refs[0].index]
+		share := first.Rate.Ratio(int64(len(refs)), classLessons[first.ClassID])
+		for i, amount := range share.Split(len(refs)) {
+			refs[i].item.Lines[refs[i].index].Amount = amount
+		}
+	}
+
+	result := make([]*entities.PayrollItem, 0, len(order))
+	for _, teacherID := range order {
+		item := items[teacherID]
+		amounts := make([]money.Amount, 0, len(item.Lines))
+		for _, line := range item.Lines {
+			amounts = append(amounts, line.Amount)
+		}
+		item.Amount = money.Sum(amounts...)
+		result = append(result, item)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TeacherID < result[j].TeacherID
+	})
+	return result
+}
+
+// rateCardFor picks the course card in effect on the day, else the default card; the latest start wins
+func rateCardFor(cards []*entities.TeacherRateCard, courseID *string, day time.Time) *entities.TeacherRateCard {
+	var courseCard, defaultCard *entities.TeacherRateCard
+	for _, card := range cards {
+		if !card.AppliesOn(day) {
+			continue
+		}
+		switch {
+		case card.CourseID == nil:
+			if defaultCard == nil || card.EffectiveFrom.After(defaultCard.EffectiveFrom) {
+				defaultCard = card
+			}
+		case courseID != nil && *card.CourseID == *courseID:
+			if courseCard == nil || card.EffectiveFrom.After(courseCard.EffectiveFrom) {
+				courseCard = card
+			}
+		}
+	}
+	if courseCard != nil {
+		return courseCard
+	}
+	return defaultCard
+}
@@ -1,8 +1,16 @@
 package services
 
 import (
+	"doan/internal/caching"
 	_interface "doan/internal/infrastructure/queue/interface"
+	"doan/internal/services/account"
+	"doan/internal/services/ai"
+	"doan/internal/services/billing"
+	"doan/internal/services/extraction"
+	"doan/internal/services/gateway"
 	"doan/internal/services/mailer"
+	"doan/internal/services/oidc"
+	"doan/internal/services/regulation"
 	"doan/internal/services/security"
 	"doan/internal/services/user"
 	"doan/pkg/config"
@@ -11,28 +19,137 @@ import (
 )
 
 // ServiceProviders provides all application services
-// Including: Auth, Security, Mailer
+// Including: Auth, Account invitations, OIDC sign-in, Security, Mailer, AI, Text extraction, Regulation rules, Billing, Payment gateways
 var ServiceProviders = wire.NewSet(
 	// Auth & User services
 	user.NewAuthService,
+	user.NewTokenDenylist,
+	user.NewAttemptThrottle,
+	user.NewMFAService,
+	user.NewPasswordPolicy,
+	account.NewInviter,
+	NewOIDCAuthenticator,
 
 	// Security services
 	NewPasswordCipher,
 	NewPasswordHasher,
+	NewBreachedPasswords,
+	NewTokenSigner,
 
 	// Mailer service
 	NewMailer,
+
+	// AI content audit
+	NewContentAnalyzer,
+	ai.NewAuditPublisher,
+	NewTextExtractor,
+
+	// Circular 29 compliance rules
+	NewRuleEngine,
+
+	// Tuition billing
+	NewInvoicePlanner,
+	NewReminderSchedule,
+
+	// Payment provider webhooks
+	NewPaymentGateways,
 )
 
 // Wrapper providers to keep wire_gen imports minimal
-func NewPasswordCipher(cfg config.Manager) security.PasswordCipher {
-	return security.NewPasswordCipher(cfg)
+// NewPasswordCipher wraps security.NewPasswordCipher and panics on error (for Wire)
+func NewPasswordCipher(cfg config.Manager, cache caching.StateStore, log logger.Logger) security.PasswordCipher {
+	cipher, err := security.NewPasswordCipher(cfg, cache, log)
+	if err != nil {
+		panic(err)
+	}
+	return cipher
+}
+
+// NewTokenSigner wraps security.NewTokenSigner and panics on error (for Wire)
+func NewTokenSigner(cfg config.Manager, log logger.Logger) security.TokenSigner {
+	signer, err := security.NewTokenSigner(cfg, log)
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}
+
+// NewOIDCAuthenticator wraps oidc.NewAuthenticator and panics on error (for Wire)
+func NewOIDCAuthenticator(cfg config.Manager, cache caching.StateStore, log logger.Logger) oidc.Authenticator {
+	authenticator, err := oidc.NewAuthenticator(cfg, cache, log)
+	if err != nil {
+		panic(err)
+	}
+	return authenticator
 }
 
 func NewPasswordHasher(cfg config.Manager) security.PasswordHasher {
 	return security.NewPasswordHasher(cfg)
 }
 
+// NewBreachedPasswords wraps security.NewBreachedPasswords and panics on error (for Wire)
+func NewBreachedPasswords(cfg config.Manager, log logger.Logger) security.BreachedPasswords {
+	list, err := security.NewBreachedPasswords(cfg, log)
+	if err != nil {
+		panic(err)
+	}
+	return list
+}
+
 func NewMailer(q _interface.Queue, log logger.Logger, cfg config.Manager) mailer.Mailer {
 	return mailer.NewMailer(q, log, cfg)
 }
+
+// NewContentAnalyzer wraps ai.NewContentAnalyzer and panics on error (for Wire)
+func NewContentAnalyzer(cfg config.Manager, log logger.Logger) ai.ContentAnalyzer {
+	analyzer, err := ai.NewContentAnalyzer(cfg, log)
+	if err != nil {
+		panic(err)
+	}
+	return analyzer
+}
+
+// NewTextExtractor wraps extraction.NewTextExtractor and panics on error (for Wire)
+func NewTextExtractor(cfg config.Manager, log logger.Logger) extraction.TextExtractor {
+	extractor, err := extraction.NewTextExtractor(cfg, log)
+	if err != nil {
+		panic(err)
+	}
+	return extractor
+}
+
+// NewRuleEngine wraps regulation.NewRuleEngine and panics on error (for Wire)
+func NewRuleEngine(cfg config.Manager, log logger.Logger) regulation.Engine {
+	engine, err := regulation.NewRuleEngine(cfg, log)
+	if err != nil {
+		panic(err)
+	}
+	return engine
+}
+
+// NewInvoicePlanner wraps billing.NewInvoicePlanner and panics on error (for Wire)
+func NewInvoicePlanner(cfg config.Manager) billing.Planner {
+	planner, err := billing.NewInvoicePlanner(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return planner
+}
+
+// NewReminderSchedule wraps billing.NewReminderSchedule and panics on error (for Wire)
+func NewReminderSchedule(cfg config.Manager) billing.DunningSchedule {
+	schedule, err := billing.NewReminderSchedule(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
+
+// NewPaymentGateways wraps gateway.NewProviderRegistry and panics on error (for Wire)
+func NewPaymentGateways(cfg config.Manager, log logger.Logger) gateway.Registry {
+	registry, err := gateway.NewProviderRegistry(cfg, log)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
@@ -0,0 +1,118 @@
+package regulation
+
+import (
+	"context"
+	"doan/internal/entities"
+	"fmt"
+	"sort"
+)
+
+// Severities, from least to most serious
+const (
+	SeverityInfo    = "INFO"
+	SeverityWarning = "WARNING"
+	SeverityError   = "ERROR"
+)
+
+var severityRank = map[string]int{SeverityInfo: 0, SeverityWarning: 1, SeverityError: 2}
+
+// Subject is what a rule set is evaluated against: a class with its teacher and weekly schedule,
+// and for enrollments also the student and the sessions of the student's other active classes
+type Subject struct {
+	Class     *entities.Class
+	Teacher   *entities.Teacher
+	Schedules []entities.ClassSchedule
+
+	Student          *entities.Student
+	StudentSchedules []entities.ClassSchedule
+}
+
+// IsEnrollment reports whether the subject is a student joining the class
+func (s Subject) IsEnrollment() bool {
+	return s.Student != nil
+}
+
+// Violation is one broken rule
+type Violation struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Report lists violations, most severe first
+type Report struct {
+	Violations []Violation `json:"violations"`
+}
+
+// HasErrors reports whether any violation is ERROR level
+func (r *Report) HasErrors() bool {
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of violations of the given severity
+func (r *Report) Count(severity string) int {
+	n := 0
+	for _, v := range r.Violations {
+		if v.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// ViolationError is returned by Enforce when ERROR violations block the operation
+type ViolationError struct {
+	Report *Report
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("blocked by %d Circular 29 violation(s)", e.Report.Count(SeverityError))
+}
+
+// Rule checks one provision; it returns nothing when the subject is out of its scope
+type Rule interface {
+	Code() string
+	Evaluate(subject Subject) []Violation
+}
+
+// Engine evaluates the configured Circular 29/2024 rules
+type Engine interface {
+	// Evaluate runs every rule and never fails
+	Evaluate(ctx context.Context, subject Subject) *Report
+	// Enforce evaluates and returns a *ViolationError when ERROR violations must block the operation
+	Enforce(ctx context.Context, subject Subject) (*Report, error)
+}
+
+type engine struct {
+	rules        []Rule
+	blockOnError bool
+}
+
+// NewEngine creates an engine over already built rules
+func NewEngine(rules []Rule, blockOnError bool) Engine {
+	return &engine{rules: rules, blockOnError: blockOnError}
+}
+
+func (e *engine) Evaluate(ctx context.Context, subject Subject) *Report {
+	report := &Report{Violations: []Violation{}}
+	for _, rule := range e.rules {
+		report.Violations = append(report.Violations, rule.Evaluate(subject)...)
+	}
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		return severityRank[report.Violations[i].Severity] > severityRank[report.Violations[j].Severity]
+	})
+	return report
+}
+
+func (e *engine) Enforce(ctx context.Context, subject Subject) (*Report, error) {
+	report := e.Evaluate(ctx, subject)
+	if e.blockOnError && report.HasErrors() {
+		return report, &ViolationError{Report: report}
+	}
+	return report, nil
+}
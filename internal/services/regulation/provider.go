@@ -0,0 +1,44 @@
+package regulation
+
+import (
+	"context"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+)
+
+// Config is the "regulation" config block
+type Config struct {
+	// BlockOnError makes class creation and enrollment approval fail on ERROR violations
+	BlockOnError *bool        `mapstructure:"block_on_error"`
+	Rules        []RuleConfig `mapstructure:"rules"`
+}
+
+// NewRuleEngine builds the engine from "regulation"; the built-in rules apply when none are configured
+func NewRuleEngine(cfg config.Manager, log logger.Logger) (Engine, error) {
+	regulationConfig := Config{}
+	if cfg.IsSet("regulation") {
+		if err := cfg.UnmarshalKey("regulation", &regulationConfig); err != nil {
+			return nil, err
+		}
+	}
+	declarations := regulationConfig.Rules
+	if len(declarations) == 0 {
+		declarations = defaultRules
+	}
+
+	rules := make([]Rule, 0, len(declarations))
+	for _, declaration := range declarations {
+		if declaration.Enabled != nil && !*declaration.Enabled {
+			continue
+		}
+		rule, err := BuildRule(declaration)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	blockOnError := regulationConfig.BlockOnError == nil || *regulationConfig.BlockOnError
+	log.Info(context.Background(), "Regulation rule engine ready", "rules", len(rules), "block_on_error", blockOnError)
+	return NewEngine(rules, blockOnError), nil
+}
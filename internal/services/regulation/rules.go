@@ -0,0 +1,265 @@
+package regulation
+
+import (
+	"doan/pkg/utils"
+	"fmt"
+	"strings"
+)
+
+// Rule kinds available in the "regulation.rules" config
+const (
+	KindSessionTimeWindow  = "session_time_window"
+	KindMaxSessionsPerWeek = "max_sessions_per_week"
+	KindOwnSchoolPupil     = "own_school_pupil"
+	KindSchoolTeacher      = "school_teacher"
+)
+
+// RuleConfig declares one rule; Params depend on the kind
+type RuleConfig struct {
+	Code     string                 `mapstructure:"code"`
+	Kind     string                 `mapstructure:"kind"`
+	Severity string                 `mapstructure:"severity"`
+	Enabled  *bool                  `mapstructure:"enabled"`
+	Message  string                 `mapstructure:"message"`
+	Params   map[string]interface{} `mapstructure:"params"`
+}
+
+// defaultRules encode the provisions of Circular 29/2024/TT-BGDĐT on extra teaching
+var defaultRules = []RuleConfig{
+	{
+		Code:     "SESSION_TIME_WINDOW",
+		Kind:     KindSessionTimeWindow,
+		Severity: SeverityError,
+		Message:  "Buổi học ngoài khung giờ cho phép",
+		Params:   map[string]interface{}{"earliest": "07:00", "latest": "21:00"},
+	},
+	{
+		Code:     "WEEKLY_SESSION_CAP",
+		Kind:     KindMaxSessionsPerWeek,
+		Severity: SeverityError,
+		Message:  "Vượt quá số buổi học thêm tối đa mỗi tuần của học sinh",
+		Params:   map[string]interface{}{"max": 6},
+	},
+	{
+		Code:     "OWN_SCHOOL_PUPIL",
+		Kind:     KindOwnSchoolPupil,
+		Severity: SeverityError,
+		Message:  "Giáo viên đang công tác tại trường không được dạy thêm có thu tiền học sinh của trường mình",
+	},
+	{
+		Code:     "SCHOOL_TEACHER_REPORT",
+		Kind:     KindSchoolTeacher,
+		Severity: SeverityWarning,
+		Message:  "Giáo viên đang công tác tại trường phải báo cáo Hiệu trưởng về việc dạy thêm ngoài nhà trường",
+	},
+}
+
+type ruleFactory func(cfg RuleConfig) (Rule, error)
+
+var ruleFactories = map[string]ruleFactory{
+	KindSessionTimeWindow:  newSessionTimeWindowRule,
+	KindMaxSessionsPerWeek: newMaxSessionsPerWeekRule,
+	KindOwnSchoolPupil:     newOwnSchoolPupilRule,
+	KindSchoolTeacher:      newSchoolTeacherRule,
+}
+
+// BuildRule validates a rule declaration and builds it
+func BuildRule(cfg RuleConfig) (Rule, error) {
+	factory, ok := ruleFactories[strings.ToLower(cfg.Kind)]
+	if !ok {
+		return nil, fmt.Errorf("regulation rule %q: unknown kind %q", cfg.Code, cfg.Kind)
+	}
+	if cfg.Code == "" {
+		return nil, fmt.Errorf("regulation rule of kind %q has no code", cfg.Kind)
+	}
+	cfg.Severity = strings.ToUpper(cfg.Severity)
+	if cfg.Severity == "" {
+		cfg.Severity = SeverityError
+	}
+	if _, ok := severityRank[cfg.Severity]; !ok {
+		return nil, fmt.Errorf("regulation rule %q: unknown severity %q", cfg.Code, cfg.Severity)
+	}
+	rule, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("regulation rule %q: %w", cfg.Code, err)
+	}
+	return rule, nil
+}
+
+type baseRule struct {
+	code     string
+	severity string
+	message  string
+}
+
+func (r baseRule) Code() string {
+	return r.code
+}
+
+func (r baseRule) violation(detail string) Violation {
+	message := r.message
+	if detail != "" {
+		message = fmt.Sprintf("%s: %s", message, detail)
+	}
+	return Violation{Code: r.code, Severity: r.severity, Message: message}
+}
+
+func newBaseRule(cfg RuleConfig, defaultMessage string) baseRule {
+	message := cfg.Message
+	if message == "" {
+		message = defaultMessage
+	}
+	return baseRule{code: cfg.Code, severity: cfg.Severity, message: message}
+}
+
+// sessionTimeWindowRule rejects sessions starting before "earliest" or ending after "latest"
+type sessionTimeWindowRule struct {
+	baseRule
+	earliest int
+	latest   int
+}
+
+func newSessionTimeWindowRule(cfg RuleConfig) (Rule, error) {
+	earliest, err := ParseClock(paramString(cfg.Params, "earliest", "00:00"))
+	if err != nil {
+		return nil, err
+	}
+	latest, err := ParseClock(paramString(cfg.Params, "latest", "23:59"))
+	if err != nil {
+		return nil, err
+	}
+	if earliest >= latest {
+		return nil, fmt.Errorf("earliest must be before latest")
+	}
+	return &sessionTimeWindowRule{
+		baseRule: newBaseRule(cfg, "Buổi học ngoài khung giờ cho phép"),
+		earliest: earliest,
+		latest:   latest,
+	}, nil
+}
+
+func (r *sessionTimeWindowRule) Evaluate(subject Subject) []Violation {
+	if subject.IsEnrollment() {
+		// The class schedule was already checked when the class was created
+		return nil
+	}
+	var violations []Violation
+	for _, s := range subject.Schedules {
+		start, errStart := ParseClock(s.StartTime)
+		end, errEnd := ParseClock(s.EndTime)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		if start < r.earliest || end > r.latest {
+			violations = append(violations, r.violation(fmt.Sprintf("%s %s-%s (cho phép %s-%s)",
+				s.DayOfWeek, s.StartTime, s.EndTime, formatClock(r.earliest), formatClock(r.latest))))
+		}
+	}
+	return violations
+}
+
+// maxSessionsPerWeekRule caps the weekly sessions of a class, and of a student across active classes
+type maxSessionsPerWeekRule struct {
+	baseRule
+	max int
+}
+
+func newMaxSessionsPerWeekRule(cfg RuleConfig) (Rule, error) {
+	limit := paramInt(cfg.Params, "max", 0)
+	if limit <= 0 {
+		return nil, fmt.Errorf("params.max must be a positive number")
+	}
+	return &maxSessionsPerWeekRule{
+		baseRule: newBaseRule(cfg, "Vượt quá số buổi học thêm tối đa mỗi tuần"),
+		max:      limit,
+	}, nil
+}
+
+func (r *maxSessionsPerWeekRule) Evaluate(subject Subject) []Violation {
+	total := len(subject.Schedules)
+	if subject.IsEnrollment() {
+		total += len(subject.StudentSchedules)
+	}
+	if total <= r.max {
+		return nil
+	}
+	if subject.IsEnrollment() {
+		return []Violation{r.violation(fmt.Sprintf("học sinh %s sẽ học %d buổi/tuần (tối đa %d)", subject.Student.FullName, total, r.max))}
+	}
+	return []Violation{r.violation(fmt.Sprintf("lớp có %d buổi/tuần (tối đa %d)", total, r.max))}
+}
+
+// ownSchoolPupilRule forbids a school teacher from tutoring pupils of the school they teach at
+type ownSchoolPupilRule struct {
+	baseRule
+}
+
+func newOwnSchoolPupilRule(cfg RuleConfig) (Rule, error) {
+	return &ownSchoolPupilRule{
+		baseRule: newBaseRule(cfg, "Giáo viên không được dạy thêm học sinh của trường mình"),
+	}, nil
+}
+
+func (r *ownSchoolPupilRule) Evaluate(subject Subject) []Violation {
+	if !subject.IsEnrollment() || subject.Teacher == nil || !subject.Teacher.IsSchoolTeacher {
+		return nil
+	}
+	teacherSchool := normalizeSchoolName(subject.Teacher.SchoolName)
+	if teacherSchool == "" || teacherSchool != normalizeSchoolName(subject.Student.SchoolName) {
+		return nil
+	}
+	return []Violation{r.violation(fmt.Sprintf("giáo viên %s và học sinh %s cùng trường %s",
+		subject.Teacher.FullName, subject.Student.FullName, subject.Teacher.SchoolName))}
+}
+
+// schoolTeacherRule flags classes taught by a teacher employed at a school
+type schoolTeacherRule struct {
+	baseRule
+}
+
+func newSchoolTeacherRule(cfg RuleConfig) (Rule, error) {
+	return &schoolTeacherRule{
+		baseRule: newBaseRule(cfg, "Giáo viên đang công tác tại trường"),
+	}, nil
+}
+
+func (r *schoolTeacherRule) Evaluate(subject Subject) []Violation {
+	if subject.IsEnrollment() || subject.Teacher == nil || !subject.Teacher.IsSchoolTeacher {
+		return nil
+	}
+	detail := subject.Teacher.FullName
+	if subject.Teacher.SchoolName != "" {
+		detail = fmt.Sprintf("%s (%s)", subject.Teacher.FullName, subject.Teacher.SchoolName)
+	}
+	return []Violation{r.violation(detail)}
+}
+
+func normalizeSchoolName(name string) string {
+	return strings.ToLower(utils.NormalizeText(name))
+}
+
+func paramString(params map[string]interface{}, key, fallback string) string {
+	if value, ok := params[key]; ok {
+		if s := strings.TrimSpace(fmt.Sprint(value)); s != "" {
+			return s
+		}
+	}
+	return fallback
+}
+
+func paramInt(params map[string]interface{}, key string, fallback int) int {
+	switch value := params[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case string:
+		var n int
+		if _, err := fmt.Sscanf(value, "%d", &n); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,53 @@
+package regulation
+
+import (
+	"doan/internal/entities"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+var validDays = map[string]bool{
+	entities.DayMonday:    true,
+	entities.DayTuesday:   true,
+	entities.DayWednesday: true,
+	entities.DayThursday:  true,
+	entities.DayFriday:    true,
+	entities.DaySaturday:  true,
+	entities.DaySunday:    true,
+}
+
+// ParseClock parses "HH:MM" into minutes since midnight
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidSchedule, value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateSchedule checks the day name and that the session starts before it ends
+func ValidateSchedule(schedule entities.ClassSchedule) error {
+	if !validDays[schedule.DayOfWeek] {
+		return fmt.Errorf("%w: unknown day of week %q", ErrInvalidSchedule, schedule.DayOfWeek)
+	}
+	start, err := ParseClock(schedule.StartTime)
+	if err != nil {
+		return err
+	}
+	end, err := ParseClock(schedule.EndTime)
+	if err != nil {
+		return err
+	}
+	if start >= end {
+		return fmt.Errorf("%w: %s session must start before it ends", ErrInvalidSchedule, schedule.DayOfWeek)
+	}
+	return nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
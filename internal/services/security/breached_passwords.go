@@ -0,0 +1,101 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// breachedPasswordsFile lists common and breached passwords by SHA-1, see the header of the file
+//
+//go:embed breached_passwords.txt
+var breachedPasswordsFile string
+
+// rangePrefixLength is the number of hex characters of a digest a range is looked up by
+const rangePrefixLength = 5
+
+// BreachedPasswords tells whether a password is known to be common or breached. The lists only hold
+// SHA-1 digests, grouped by their first five hex characters as in the Have I Been Pwned range API, so a
+// remote k-anonymity service can stand in for them without ever seeing a password.
+type BreachedPasswords interface {
+	// Range returns the digest suffixes (the 35 hex characters after prefix) of the listed passwords
+	Range(prefix string) []string
+	// Contains reports whether the password is listed
+	Contains(plain string) bool
+}
+
+type breachedPasswords struct {
+	ranges map[string]map[string]struct{}
+}
+
+// NewBreachedPasswords loads the bundled list and, when "security.password_policy.breached_list_file"
+// is set, the digests of that file too. Lines are "HASH" or "HASH:COUNT"; "#" starts a comment.
+func NewBreachedPasswords(cfg config.Manager, log logger.Logger) (BreachedPasswords, error) {
+	list := &breachedPasswords{ranges: make(map[string]map[string]struct{})}
+	if err := list.load(strings.NewReader(breachedPasswordsFile)); err != nil {
+		return nil, fmt.Errorf("bundled breached passwords: %w", err)
+	}
+
+	var path string
+	_ = cfg.UnmarshalKey("security.password_policy.breached_list_file", &path)
+	if path = strings.TrimSpace(path); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("breached passwords: %w", err)
+		}
+		defer f.Close()
+		if err := list.load(f); err != nil {
+			return nil, fmt.Errorf("breached passwords %s: %w", path, err)
+		}
+		log.Info(context.Background(), "Loaded breached passwords", "file", path)
+	}
+	return list, nil
+}
+
+func (l *breachedPasswords) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		digest, _, _ := strings.Cut(text, ":")
+		digest = strings.ToUpper(digest)
+		if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha1.Size*2 {
+			return fmt.Errorf("line %d: not a SHA-1 hex digest", line)
+		}
+		prefix, suffix := digest[:rangePrefixLength], digest[rangePrefixLength:]
+		if l.ranges[prefix] == nil {
+			l.ranges[prefix] = make(map[string]struct{})
+		}
+		l.ranges[prefix][suffix] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+func (l *breachedPasswords) Range(prefix string) []string {
+	suffixes := make([]string, 0, len(l.ranges[strings.ToUpper(prefix)]))
+	for suffix := range l.ranges[strings.ToUpper(prefix)] {
+		suffixes = append(suffixes, suffix)
+	}
+	return suffixes
+}
+
+func (l *breachedPasswords) Contains(plain string) bool {
+	sum := sha1.Sum([]byte(plain))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	for _, suffix := range l.Range(digest[:rangePrefixLength]) {
+		if suffix == digest[rangePrefixLength:] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,71 +1,234 @@
 package security
 
 import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"doan/internal/caching"
 	"doan/pkg/config"
+	"doan/pkg/logger"
 )
 
-// PasswordCipher defines decrypt contract for FE->BE encrypted password payloads.
-// Phase 1: allow plaintext when security.accept_plain_password=true (default for dev).
-// If plaintext is not accepted, this implementation currently supports a minimal JSON
-// envelope and returns an error (AES-GCM can be added later without changing call sites).
+// PasswordCipher turns the password fields sent by the frontend (password_enc and friends) back into
+// plaintext. The browser fetches the active public key from GET /api/v1/auth/public-key, encrypts the
+// password with a fresh AES-256-GCM key, wraps that key with RSA-OAEP (SHA-256) and sends the envelope:
 //
-// Expected JSON envelope (future-proof):
-// {
-//   "alg": "AES-256-GCM",
-//   "nonce": "<base64>",
-//   "ciphertext": "<base64>"
-// }
+//	{
+//	  "kid": "<key ID from the public key endpoint>",
+//	  "alg": "RSA-OAEP-256+A256GCM",
+//	  "ek": "<base64 RSA-OAEP wrapped AES key>",
+//	  "nonce": "<base64 12-byte GCM nonce>",
+//	  "ciphertext": "<base64 ciphertext followed by the GCM tag>",
+//	  "ts": <unix milliseconds when encrypted>
+//	}
 //
-// For now, if accept_plain_password=true, Decrypt returns the input as-is.
-// If accept_plain_password=false and the input looks like a JSON envelope, an
-// informative error is returned so clients know encryption is not yet enabled.
-
+// The additional authenticated data is "<kid>.<ts>", so the timestamp cannot be altered. An envelope older
+// or newer than security.password_transport.max_age_seconds is rejected, and so is a nonce seen before.
+// Plaintext is refused unless security.accept_plain_password is set to true, which is meant for local development.
 type PasswordCipher interface {
-	Decrypt(enc string) (string, error)
+	Decrypt(ctx context.Context, enc string) (string, error)
+	// PublicKey is the key clients should encrypt with
+	PublicKey() PasswordPublicKey
+	// AcceptsPlain reports whether a plaintext password is still accepted
+	AcceptsPlain() bool
+}
+
+// PasswordEnvelopeAlg is the only envelope algorithm understood
+const PasswordEnvelopeAlg = "RSA-OAEP-256+A256GCM"
+
+const (
+	defaultEnvelopeMaxAge = 5 * time.Minute
+	envelopeNonceSize     = 12
+	envelopeNoncePrefix   = "security:password-nonce:"
+)
+
+var (
+	ErrPlainPasswordRejected    = errors.New("plaintext password is not accepted; send an encrypted envelope")
+	ErrInvalidPasswordEnvelope  = errors.New("invalid password envelope")
+	ErrUnknownPasswordKey       = errors.New("password envelope uses an unknown key; fetch the current public key")
+	ErrPasswordEnvelopeExpired  = errors.New("password envelope timestamp is outside the accepted window")
+	ErrPasswordEnvelopeReplayed = errors.New("password envelope has already been used")
+)
+
+// PasswordTransportConfig is "security.password_transport"
+type PasswordTransportConfig struct {
+	MaxAgeSeconds int                 `mapstructure:"max_age_seconds"`
+	Keys          []PasswordKeyConfig `mapstructure:"keys"`
 }
 
 type passwordCipher struct {
 	acceptPlain bool
+	maxAge      time.Duration
+	ring        *passwordKeyRing
+	cache       caching.StateStore
+	now         func() time.Time
 }
 
 type encEnvelope struct {
+	KeyID      string `json:"kid"`
 	Alg        string `json:"alg"`
+	WrappedKey string `json:"ek"`
 	Nonce      string `json:"nonce"`
 	Ciphertext string `json:"ciphertext"`
+	Timestamp  int64  `json:"ts"`
+}
+
+// NewPasswordCipher creates a new instance of PasswordCipher. Without configured keys a throwaway key is
+// generated, which is fine for development but breaks as soon as several instances serve requests.
+func NewPasswordCipher(cfg config.Manager, cache caching.StateStore, log logger.Logger) (PasswordCipher, error) {
+	// Plaintext passwords are refused unless explicitly enabled; a value that does not read as a bool
+	// stops startup instead of falling back to either side
+	acceptPlain := false
+	if cfg.IsSet("security.accept_plain_password") {
+		if err := cfg.UnmarshalKey("security.accept_plain_password", &acceptPlain); err != nil {
+			return nil, fmt.Errorf("read security.accept_plain_password: %w", err)
+		}
+	}
+	if acceptPlain {
+		log.Warn(context.Background(), "Plaintext password fields are accepted, enable this for development only")
+	}
+
+	transport := PasswordTransportConfig{}
+	if err := cfg.UnmarshalKey("security.password_transport", &transport); err != nil {
+		return nil, fmt.Errorf("read security.password_transport: %w", err)
+	}
+	maxAge := defaultEnvelopeMaxAge
+	if transport.MaxAgeSeconds > 0 {
+		maxAge = time.Duration(transport.MaxAgeSeconds) * time.Second
+	}
+
+	ring, ephemeral, err := newPasswordKeyRing(transport.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if ephemeral {
+		log.Warn(context.Background(), "No password transport keys configured, using a throwaway key for this process",
+			"kid", ring.active.KeyID)
+	}
+
+	return &passwordCipher{
+		acceptPlain: acceptPlain,
+		maxAge:      maxAge,
+		ring:        ring,
+		cache:       cache,
+		now:         time.Now,
+	}, nil
+}
+
+func (p *passwordCipher) PublicKey() PasswordPublicKey {
+	return p.ring.active
 }
 
-func NewPasswordCipher(cfg config.Manager) PasswordCipher {
-	// Default behavior: allow plaintext by default for developer convenience.
-	var acceptPlain bool
-	if err := cfg.UnmarshalKey("security.accept_plain_password", &acceptPlain); err != nil {
-		acceptPlain = true
-	}
-	// If app.env is explicitly set to a development value, always allow plaintext.
-	var appEnv string
-	_ = cfg.UnmarshalKey("app.env", &appEnv)
-	appEnv = strings.ToLower(strings.TrimSpace(appEnv))
-	switch appEnv {
-	case "dev", "development", "local":
-		acceptPlain = true
-	}
-	return &passwordCipher{acceptPlain: acceptPlain}
+func (p *passwordCipher) AcceptsPlain() bool {
+	return p.acceptPlain
+}
+
+func (p *passwordCipher) Decrypt(ctx context.Context, enc string) (string, error) {
+	env, ok := parseEnvelope(enc)
+	if !ok {
+		if p.acceptPlain {
+			return enc, nil
+		}
+		return "", ErrPlainPasswordRejected
+	}
+
+	if env.Alg != PasswordEnvelopeAlg {
+		return "", fmt.Errorf("%w: unsupported alg %q", ErrInvalidPasswordEnvelope, env.Alg)
+	}
+	key, ok := p.ring.keys[env.KeyID]
+	if !ok {
+		return "", ErrUnknownPasswordKey
+	}
+	age := p.now().Sub(time.UnixMilli(env.Timestamp))
+	if age > p.maxAge || age < -p.maxAge {
+		return "", ErrPasswordEnvelopeExpired
+	}
+
+	wrappedKey, err1 := decodeBase64(env.WrappedKey)
+	nonce, err2 := decodeBase64(env.Nonce)
+	ciphertext, err3 := decodeBase64(env.Ciphertext)
+	if err := errors.Join(err1, err2, err3); err != nil || len(nonce) != envelopeNonceSize {
+		return "", ErrInvalidPasswordEnvelope
+	}
+
+	plain, err := openEnvelope(key, wrappedKey, nonce, ciphertext, env.KeyID+"."+strconv.FormatInt(env.Timestamp, 10))
+	if err != nil {
+		return "", ErrInvalidPasswordEnvelope
+	}
+
+	// Only authentic envelopes reach the nonce cache, so garbage cannot fill it
+	if err := p.rememberNonce(ctx, nonce); err != nil {
+		return "", err
+	}
+	return plain, nil
 }
 
-func (p *passwordCipher) Decrypt(enc string) (string, error) {
-	if p.acceptPlain {
-		return enc, nil
+// rememberNonce records the nonce for as long as its envelope could pass the timestamp check. The store
+// failing lets the envelope through: the timestamp window still bounds any replay.
+func (p *passwordCipher) rememberNonce(ctx context.Context, nonce []byte) error {
+	key := envelopeNoncePrefix + base64.RawURLEncoding.EncodeToString(nonce)
+	seen, err := p.cache.Incr(ctx, key, 1, 2*p.maxAge)
+	if err != nil {
+		logger.NewLogger(ctx).Warnf("Failed to record password envelope nonce: %v", err)
+		return nil
+	}
+	if seen > 1 {
+		return ErrPasswordEnvelopeReplayed
 	}
-	// Try to parse as JSON envelope to provide friendly error
+	return nil
+}
+
+// parseEnvelope reports whether enc is an envelope rather than a plaintext password that happens to be JSON
+func parseEnvelope(enc string) (encEnvelope, bool) {
 	trim := strings.TrimSpace(enc)
-	if strings.HasPrefix(trim, "{") && strings.HasSuffix(trim, "}") {
-		var env encEnvelope
-		_ = json.Unmarshal([]byte(trim), &env)
-		return "", errors.New("encrypted password received but AES-GCM decryption is not enabled yet on server")
+	if !strings.HasPrefix(trim, "{") || !strings.HasSuffix(trim, "}") {
+		return encEnvelope{}, false
+	}
+	var env encEnvelope
+	if err := json.Unmarshal([]byte(trim), &env); err != nil || env.Alg == "" || env.Ciphertext == "" {
+		return encEnvelope{}, false
+	}
+	return env, true
+}
+
+func openEnvelope(key *rsa.PrivateKey, wrappedKey, nonce, ciphertext []byte, aad string) (string, error) {
+	aesKey, err := rsa.DecryptOAEP(sha256.New(), nil, key, wrappedKey, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(aesKey) != 32 {
+		return "", errors.New("wrapped key is not an AES-256 key")
+	}
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcm.Open(nil, nonce, ciphertext, []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// decodeBase64 accepts standard and URL-safe base64, padded or not, as browsers produce either
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
 	}
-	return "", fmt.Errorf("plaintext password is not accepted; enable security.accept_plain_password or send encrypted payload")
+	return base64.RawURLEncoding.DecodeString(s)
 }
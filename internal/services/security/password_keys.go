@@ -0,0 +1,164 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// ephemeralKeyBits is the size of the key generated when none is configured
+const ephemeralKeyBits = 2048
+
+// PasswordKeyConfig is one entry of "security.password_transport.keys"
+type PasswordKeyConfig struct {
+	KeyID          string `mapstructure:"kid"`
+	PrivateKey     string `mapstructure:"private_key"`      // PEM, PKCS#1 or PKCS#8
+	PrivateKeyFile string `mapstructure:"private_key_file"` // path to a PEM file, used when private_key is empty
+	Active         bool   `mapstructure:"active"`           // the key handed to clients; the others only decrypt
+}
+
+// PasswordPublicKey is what a client needs to encrypt a password for the server
+type PasswordPublicKey struct {
+	KeyID     string
+	Algorithm string // envelope algorithm, see PasswordEnvelopeAlg
+	PEM       string // SubjectPublicKeyInfo, as accepted by WebCrypto importKey("spki")
+	JWK       PublicJWK
+}
+
+// PublicJWK is a public key in JWK form (RFC 7517): N and E for RSA keys, Crv and X for Ed25519 (RFC 8037)
+type PublicJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// passwordKeyRing holds every key that may still decrypt, by key ID
+type passwordKeyRing struct {
+	keys   map[string]*rsa.PrivateKey
+	active PasswordPublicKey
+}
+
+// newPasswordKeyRing loads the configured keys. The active key is the one marked active, or the first one.
+// Without keys a throwaway key is generated, which only works while a single process serves requests.
+func newPasswordKeyRing(configs []PasswordKeyConfig) (*passwordKeyRing, bool, error) {
+	ephemeral := len(configs) == 0
+	if ephemeral {
+		key, err := rsa.GenerateKey(rand.Reader, ephemeralKeyBits)
+		if err != nil {
+			return nil, false, fmt.Errorf("generate password key: %w", err)
+		}
+		kid := "ephemeral-" + time.Now().UTC().Format("20060102150405")
+		ring := &passwordKeyRing{keys: map[string]*rsa.PrivateKey{kid: key}}
+		ring.active, err = publicKeyOf(kid, key)
+		return ring, true, err
+	}
+
+	ring := &passwordKeyRing{keys: make(map[string]*rsa.PrivateKey, len(configs))}
+	activeID := ""
+	for i, c := range configs {
+		if strings.TrimSpace(c.KeyID) == "" {
+			return nil, false, fmt.Errorf("password key #%d: kid is required", i+1)
+		}
+		if _, dup := ring.keys[c.KeyID]; dup {
+			return nil, false, fmt.Errorf("password key %s: duplicate kid", c.KeyID)
+		}
+		key, err := loadPrivateKey(c)
+		if err != nil {
+			return nil, false, fmt.Errorf("password key %s: %w", c.KeyID, err)
+		}
+		ring.keys[c.KeyID] = key
+		if c.Active {
+			if activeID != "" {
+				return nil, false, fmt.Errorf("password keys %s and %s are both active", activeID, c.KeyID)
+			}
+			activeID = c.KeyID
+		}
+	}
+	if activeID == "" {
+		activeID = configs[0].KeyID
+	}
+
+	var err error
+	ring.active, err = publicKeyOf(activeID, ring.keys[activeID])
+	return ring, false, err
+}
+
+func loadPrivateKey(c PasswordKeyConfig) (*rsa.PrivateKey, error) {
+	parsed, err := readPrivateKeyPEM(c.PrivateKey, c.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("only RSA keys are supported")
+	}
+	if key.N.BitLen() < ephemeralKeyBits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", ephemeralKeyBits)
+	}
+	return key, nil
+}
+
+// readPrivateKeyPEM parses an inline PEM private key, or the file it names when inline is empty. PKCS#1
+// RSA keys and PKCS#8 keys of any type are accepted.
+func readPrivateKeyPEM(inline, file string) (interface{}, error) {
+	data := []byte(inline)
+	if strings.TrimSpace(inline) == "" {
+		if file == "" {
+			return nil, errors.New("private_key or private_key_file is required")
+		}
+		var err error
+		if data, err = os.ReadFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	return key, nil
+}
+
+func publicKeyOf(kid string, key *rsa.PrivateKey) (PasswordPublicKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return PasswordPublicKey{}, fmt.Errorf("encode password key %s: %w", kid, err)
+	}
+	return PasswordPublicKey{
+		KeyID:     kid,
+		Algorithm: PasswordEnvelopeAlg,
+		PEM:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		JWK:       rsaJWK(kid, "enc", "RSA-OAEP-256", &key.PublicKey),
+	}, nil
+}
+
+func rsaJWK(kid, use, alg string, key *rsa.PublicKey) PublicJWK {
+	return PublicJWK{
+		Kty: "RSA",
+		Kid: kid,
+		Use: use,
+		Alg: alg,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
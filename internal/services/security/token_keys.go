@@ -0,0 +1,197 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"doan/pkg/types"
+	"doan/pkg/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey is one key of the ring with what is needed to verify its signatures
+type signingKey struct {
+	utils.SigningKey
+	public    interface{} // verification key; the secret itself for HMAC
+	notBefore time.Time   // signing starts at this time
+	jwk       *PublicJWK  // nil for the shared secret, which is never published
+}
+
+// signingKeyRing holds the asymmetric keys ordered by not_before, and the legacy shared secret. The
+// signing key is the newest key whose not_before has passed, or the secret before the first one. A key
+// replaced by a newer one still verifies for one access token lifetime, so every token it signed can run
+// out; keys scheduled for the future verify and are published already, so verifiers that cache the JWKS
+// know them in time.
+type signingKeyRing struct {
+	keys      []signingKey
+	legacy    *signingKey // HS256 with jwt.secret; signs only until the first key starts
+	audience  utils.TokenAudience
+	grace     time.Duration
+	ephemeral bool
+}
+
+// newSigningKeyRing loads "jwt". Without keys the shared secret keeps signing with HS256, and without
+// either a throwaway Ed25519 key is generated, which only works while a single process serves requests.
+func newSigningKeyRing(jwtConfig types.JWTConfig, grace time.Duration) (*signingKeyRing, error) {
+	ring := &signingKeyRing{
+		audience: utils.TokenAudience{Issuer: defaultTokenIssuer, Audience: []string{defaultTokenAudience}},
+		grace:    grace,
+	}
+	if issuer := strings.TrimSpace(jwtConfig.Issuer); issuer != "" {
+		ring.audience.Issuer = issuer
+	}
+	if len(jwtConfig.Audience) > 0 {
+		ring.audience.Audience = jwtConfig.Audience
+	}
+	if jwtConfig.Secret != "" {
+		secret := []byte(jwtConfig.Secret)
+		ring.legacy = &signingKey{
+			SigningKey: utils.SigningKey{Method: jwt.SigningMethodHS256, Key: secret},
+			public:     secret,
+		}
+	}
+
+	seen := make(map[string]bool, len(jwtConfig.Keys))
+	for i, c := range jwtConfig.Keys {
+		if strings.TrimSpace(c.KeyID) == "" {
+			return nil, fmt.Errorf("jwt key #%d: kid is required", i+1)
+		}
+		if seen[c.KeyID] {
+			return nil, fmt.Errorf("jwt key %s: duplicate kid", c.KeyID)
+		}
+		seen[c.KeyID] = true
+		key, err := loadSigningKey(c)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", c.KeyID, err)
+		}
+		ring.keys = append(ring.keys, key)
+	}
+	// Stable, so of two keys starting together the one listed last wins
+	sort.SliceStable(ring.keys, func(i, j int) bool {
+		return ring.keys[i].notBefore.Before(ring.keys[j].notBefore)
+	})
+
+	if len(ring.keys) == 0 && ring.legacy == nil {
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate jwt key: %w", err)
+		}
+		kid := "ephemeral-" + time.Now().UTC().Format("20060102150405")
+		key, err := newSigningKey(kid, "", private, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		ring.keys = []signingKey{key}
+		ring.ephemeral = true
+	}
+	return ring, nil
+}
+
+// current is the key new tokens are signed with
+func (r *signingKeyRing) current(now time.Time) (signingKey, error) {
+	if len(r.keys) == 0 {
+		return *r.legacy, nil
+	}
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !r.keys[i].notBefore.After(now) {
+			return r.keys[i], nil
+		}
+	}
+	// Keys scheduled ahead of a switch from the shared secret
+	if r.legacy != nil {
+		return *r.legacy, nil
+	}
+	return signingKey{}, errors.New("no jwt key is active yet; the earliest not_before is in the future")
+}
+
+// verifying returns the keys a valid token may be signed with: every key not yet replaced for longer
+// than the grace period, including those scheduled for the future
+func (r *signingKeyRing) verifying(now time.Time) []signingKey {
+	keys := make([]signingKey, 0, len(r.keys))
+	for i, key := range r.keys {
+		if i+1 < len(r.keys) && !r.keys[i+1].notBefore.Add(r.grace).After(now) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// lookup finds the verification key of a token from its kid and alg headers. Tokens without a kid were
+// signed with the shared secret.
+func (r *signingKeyRing) lookup(kid, alg string, now time.Time) (interface{}, error) {
+	if kid == "" {
+		if r.legacy != nil && alg == r.legacy.Method.Alg() {
+			return r.legacy.public, nil
+		}
+		return nil, errors.New("token has no kid")
+	}
+	for _, key := range r.verifying(now) {
+		if key.ID == kid {
+			if key.Method.Alg() != alg {
+				return nil, fmt.Errorf("token alg %s does not match key %s", alg, kid)
+			}
+			return key.public, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown or retired jwt key %q", kid)
+}
+
+func loadSigningKey(c types.JWTKeyConfig) (signingKey, error) {
+	private, err := readPrivateKeyPEM(c.PrivateKey, c.PrivateKeyFile)
+	if err != nil {
+		return signingKey{}, err
+	}
+	var notBefore time.Time
+	if strings.TrimSpace(c.NotBefore) != "" {
+		if notBefore, err = time.Parse(time.RFC3339, c.NotBefore); err != nil {
+			return signingKey{}, fmt.Errorf("not_before: %w", err)
+		}
+	}
+	return newSigningKey(c.KeyID, c.Algorithm, private, notBefore)
+}
+
+// newSigningKey checks that the algorithm suits the key, inferring it when empty
+func newSigningKey(kid, alg string, private interface{}, notBefore time.Time) (signingKey, error) {
+	key := signingKey{notBefore: notBefore}
+	var jwk PublicJWK
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if alg != "" && alg != jwt.SigningMethodRS256.Alg() {
+			return signingKey{}, fmt.Errorf("algorithm %s does not suit an RSA key", alg)
+		}
+		if k.N.BitLen() < ephemeralKeyBits {
+			return signingKey{}, fmt.Errorf("RSA key must be at least %d bits", ephemeralKeyBits)
+		}
+		key.SigningKey = utils.SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Key: k}
+		key.public = &k.PublicKey
+		jwk = rsaJWK(kid, "sig", jwt.SigningMethodRS256.Alg(), &k.PublicKey)
+	case ed25519.PrivateKey:
+		if alg != "" && alg != jwt.SigningMethodEdDSA.Alg() {
+			return signingKey{}, fmt.Errorf("algorithm %s does not suit an Ed25519 key", alg)
+		}
+		public := k.Public().(ed25519.PublicKey)
+		key.SigningKey = utils.SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Key: k}
+		key.public = public
+		jwk = PublicJWK{
+			Kty: "OKP",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}
+	default:
+		return signingKey{}, errors.New("only RSA and Ed25519 keys are supported")
+	}
+	key.jwk = &jwk
+	return key, nil
+}
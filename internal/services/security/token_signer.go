@@ -0,0 +1,119 @@
+package security
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/types"
+	"doan/pkg/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenSigner signs and verifies the access tokens. Keys come from "jwt.keys" and are identified by the
+// kid header, so they can be rotated on a schedule: add the next key with a future not_before, and remove
+// the old one once the next has been signing for an access token lifetime. Other services verify tokens
+// with the public keys of GET /.well-known/jwks.json instead of sharing a secret. Without keys, the
+// legacy jwt.secret keeps signing with HS256; once keys are configured the secret only verifies tokens
+// issued before the switch and should be removed after one access token lifetime.
+// Every token carries iss and aud, which are checked on verification.
+type TokenSigner interface {
+	Sign(claims utils.JWTClaims, duration time.Duration) (string, error)
+	Verify(token string) (*utils.JWTClaims, error)
+	// JWKS returns the public keys a valid token may be signed with, including scheduled ones
+	JWKS() []PublicJWK
+}
+
+const (
+	jwtConfigKey               = "jwt"
+	defaultTokenIssuer         = "doan"
+	defaultTokenAudience       = "doan-api"
+	defaultAccessTokenDuration = 24 * time.Hour
+)
+
+type tokenSigner struct {
+	configManager config.Manager
+	log           logger.Logger
+	ring          atomic.Pointer[signingKeyRing]
+	now           func() time.Time
+}
+
+// NewTokenSigner creates a new instance of TokenSigner and reloads its keys whenever "jwt" changes
+func NewTokenSigner(configManager config.Manager, log logger.Logger) (TokenSigner, error) {
+	s := &tokenSigner{configManager: configManager, log: log, now: time.Now}
+	ring, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if ring.ephemeral {
+		log.Warn(context.Background(), "No JWT keys or secret configured, signing with a throwaway key for this process",
+			"kid", ring.keys[0].ID)
+	}
+	s.ring.Store(ring)
+
+	changes := configManager.WatchKey(jwtConfigKey)
+	go func() {
+		for range changes {
+			s.reload()
+		}
+	}()
+	return s, nil
+}
+
+func (s *tokenSigner) Sign(claims utils.JWTClaims, duration time.Duration) (string, error) {
+	ring := s.ring.Load()
+	key, err := ring.current(s.now())
+	if err != nil {
+		return "", err
+	}
+	return utils.GenerateToken(claims, key.SigningKey, ring.audience, duration)
+}
+
+func (s *tokenSigner) Verify(token string) (*utils.JWTClaims, error) {
+	ring := s.ring.Load()
+	now := s.now()
+	return utils.ValidateToken(token, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return ring.lookup(kid, t.Method.Alg(), now)
+	}, ring.audience)
+}
+
+func (s *tokenSigner) JWKS() []PublicJWK {
+	keys := s.ring.Load().verifying(s.now())
+	jwks := make([]PublicJWK, 0, len(keys))
+	for _, key := range keys {
+		jwks = append(jwks, *key.jwk)
+	}
+	return jwks
+}
+
+func (s *tokenSigner) load() (*signingKeyRing, error) {
+	jwtConfig := types.JWTConfig{}
+	if err := s.configManager.UnmarshalKey(jwtConfigKey, &jwtConfig); err != nil {
+		return nil, err
+	}
+	grace, err := time.ParseDuration(jwtConfig.AccessTokenDuration)
+	if err != nil || grace <= 0 {
+		grace = defaultAccessTokenDuration
+	}
+	return newSigningKeyRing(jwtConfig, grace)
+}
+
+// reload swaps in the configured keys; an invalid config keeps the keys in force. A throwaway key in
+// use is kept while the config still has no keys, so its tokens stay valid.
+func (s *tokenSigner) reload() {
+	ctx := context.Background()
+	ring, err := s.load()
+	if err != nil {
+		s.log.Error(ctx, "Failed to reload JWT keys, keeping the current ones", "error", err)
+		return
+	}
+	if current := s.ring.Load(); ring.ephemeral && current.ephemeral {
+		ring.keys = current.keys
+	}
+	s.ring.Store(ring)
+	s.log.Info(ctx, "JWT keys reloaded", "keys", len(ring.keys))
+}
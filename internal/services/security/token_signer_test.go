@@ -0,0 +1,303 @@
+package security
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/types"
+	"doan/pkg/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtConfigManager serves the "jwt" block
+type jwtConfigManager struct {
+	config.Manager
+	jwt types.JWTConfig
+}
+
+func (m *jwtConfigManager) UnmarshalKey(key string, rawVal interface{}) error {
+	*rawVal.(*types.JWTConfig) = m.jwt
+	return nil
+}
+
+type discardLogger struct {
+	logger.Logger
+}
+
+func (discardLogger) Info(ctx context.Context, msg string, keysAndValues ...interface{})  {}
+func (discardLogger) Warn(ctx context.Context, msg string, keysAndValues ...interface{})  {}
+func (discardLogger) Error(ctx context.Context, msg string, keysAndValues ...interface{}) {}
+
+func ed25519KeyConfig(t *testing.T, kid string, notBefore time.Time) types.JWTKeyConfig {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.JWTKeyConfig{
+		KeyID:      kid,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		NotBefore:  notBefore.UTC().Format(time.RFC3339),
+	}
+}
+
+// newTestSigner builds a signer reading jwtConfig, with a clock the test moves
+func newTestSigner(t *testing.T, jwtConfig types.JWTConfig, now *time.Time) *tokenSigner {
+	t.Helper()
+	s := &tokenSigner{
+		configManager: &jwtConfigManager{jwt: jwtConfig},
+		log:           discardLogger{},
+		now:           func() time.Time { return *now },
+	}
+	ring, err := s.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ring.Store(ring)
+	return s
+}
+
+func tokenKid(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &utils.JWTClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func jwksKids(s *tokenSigner) string {
+	var kids []string
+	for _, key := range s.JWKS() {
+		kids = append(kids, key.Kid)
+	}
+	return strings.Join(kids, ",")
+}
+
+func TestTokenSignerRotation(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	now := start
+	s := newTestSigner(t, types.JWTConfig{
+		AccessTokenDuration: "24h",
+		Keys: []types.JWTKeyConfig{
+			// Listed out of order: the ring sorts by not_before
+			ed25519KeyConfig(t, "next", start.Add(time.Hour)),
+			ed25519KeyConfig(t, "old", start.Add(-48*time.Hour)),
+		},
+	}, &now)
+	claims := utils.JWTClaims{UserID: "user-1", Role: "ADMIN"}
+
+	oldToken, err := s.Sign(claims, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		at            time.Time
+		wantSigner    string
+		wantJWKS      string
+		wantOldTokens bool
+	}{
+		{"before the switch", start, "old", "old,next", true},
+		{"after the switch, in the grace period", start.Add(2 * time.Hour), "next", "old,next", true},
+		{"grace period over", start.Add(25*time.Hour + time.Second), "next", "next", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = tt.at
+			token, err := s.Sign(claims, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kid := tokenKid(t, token); kid != tt.wantSigner {
+				t.Errorf("signed with %q, want %q", kid, tt.wantSigner)
+			}
+			if got, err := s.Verify(token); err != nil || got.UserID != claims.UserID {
+				t.Errorf("Verify(new token) = %v, %v", got, err)
+			}
+			if kids := jwksKids(s); kids != tt.wantJWKS {
+				t.Errorf("JWKS = %s, want %s", kids, tt.wantJWKS)
+			}
+			if _, err := s.Verify(oldToken); (err == nil) != tt.wantOldTokens {
+				t.Errorf("Verify(token of the old key) err = %v, want accepted %v", err, tt.wantOldTokens)
+			}
+		})
+	}
+}
+
+func TestTokenSignerLegacySecret(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	now := start
+	s := newTestSigner(t, types.JWTConfig{
+		Secret: "legacy-secret",
+		Keys:   []types.JWTKeyConfig{ed25519KeyConfig(t, "first", start.Add(time.Hour))},
+	}, &now)
+	claims := utils.JWTClaims{UserID: "user-1"}
+
+	legacyToken, err := s.Sign(claims, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := tokenKid(t, legacyToken); kid != "" {
+		t.Fatalf("secret token has kid %q", kid)
+	}
+	if kids := jwksKids(s); kids != "first" {
+		t.Errorf("JWKS = %s, want the scheduled key only", kids)
+	}
+
+	now = start.Add(2 * time.Hour)
+	token, err := s.Sign(claims, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := tokenKid(t, token); kid != "first" {
+		t.Errorf("signed with %q once the key started, want first", kid)
+	}
+	if _, err := s.Verify(legacyToken); err != nil {
+		t.Errorf("secret token rejected after the switch: %v", err)
+	}
+}
+
+func TestTokenSignerRejects(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	now := start
+	s := newTestSigner(t, types.JWTConfig{
+		Issuer:   "doan",
+		Audience: []string{"doan-api"},
+		Keys:     []types.JWTKeyConfig{ed25519KeyConfig(t, "k1", start.Add(-time.Hour))},
+	}, &now)
+	key, err := s.ring.Load().current(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := newTestSigner(t, types.JWTConfig{
+		Keys: []types.JWTKeyConfig{ed25519KeyConfig(t, "k1", start.Add(-time.Hour))},
+	}, &now)
+
+	sign := func(method jwt.SigningMethod, kid string, signingKey interface{}, audience utils.TokenAudience) string {
+		token, err := utils.GenerateToken(utils.JWTClaims{UserID: "user-1"},
+			utils.SigningKey{ID: kid, Method: method, Key: signingKey}, audience, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	audience := utils.TokenAudience{Issuer: "doan", Audience: []string{"doan-api"}}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"HMAC token claiming an asymmetric kid", sign(jwt.SigningMethodHS256, "k1", []byte("guess"), audience)},
+		{"token without kid and no secret", sign(jwt.SigningMethodHS256, "", []byte("guess"), audience)},
+		{"unknown kid", sign(key.Method, "k2", key.Key, audience)},
+		{"same kid, another key", func() string {
+			token, err := other.Sign(utils.JWTClaims{UserID: "user-1"}, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}()},
+		{"another issuer", sign(key.Method, key.ID, key.Key, utils.TokenAudience{Issuer: "evil", Audience: audience.Audience})},
+		{"another audience", sign(key.Method, key.ID, key.Key, utils.TokenAudience{Issuer: "doan", Audience: []string{"other"}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(tt.token); err == nil {
+				t.Error("Verify accepted the token")
+			}
+		})
+	}
+}
+
+func TestTokenSignerConfigErrors(t *testing.T) {
+	start := time.Now()
+	valid := ed25519KeyConfig(t, "k1", start)
+
+	tests := []struct {
+		name string
+		keys []types.JWTKeyConfig
+		want string
+	}{
+		{"missing kid", []types.JWTKeyConfig{{PrivateKey: valid.PrivateKey}}, "kid is required"},
+		{"duplicate kid", []types.JWTKeyConfig{valid, valid}, "duplicate kid"},
+		{"bad not_before", []types.JWTKeyConfig{{KeyID: "k1", PrivateKey: valid.PrivateKey, NotBefore: "tomorrow"}}, "not_before"},
+		{"algorithm not suiting the key", []types.JWTKeyConfig{{KeyID: "k1", PrivateKey: valid.PrivateKey, Algorithm: "RS256"}}, "does not suit"},
+		{"no key material", []types.JWTKeyConfig{{KeyID: "k1"}}, "private_key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSigningKeyRing(types.JWTConfig{Keys: tt.keys}, time.Hour)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	// Keys that all start in the future cannot sign without a secret to bridge the gap
+	ring, err := newSigningKeyRing(types.JWTConfig{Keys: []types.JWTKeyConfig{ed25519KeyConfig(t, "k1", start.Add(time.Hour))}}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ring.current(start); err == nil {
+		t.Error("current returned a key before any not_before")
+	}
+}
+
+func TestTokenSignerReload(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	now := start
+	s := newTestSigner(t, types.JWTConfig{
+		Keys: []types.JWTKeyConfig{ed25519KeyConfig(t, "k1", start.Add(-time.Hour))},
+	}, &now)
+	manager := s.configManager.(*jwtConfigManager)
+
+	// An invalid config keeps the keys in force
+	manager.jwt = types.JWTConfig{Keys: []types.JWTKeyConfig{{KeyID: "broken"}}}
+	s.reload()
+	if kids := jwksKids(s); kids != "k1" {
+		t.Fatalf("JWKS after an invalid reload = %s, want k1", kids)
+	}
+
+	manager.jwt = types.JWTConfig{Keys: []types.JWTKeyConfig{
+		ed25519KeyConfig(t, "k1", start.Add(-time.Hour)),
+		ed25519KeyConfig(t, "k2", start.Add(-time.Minute)),
+	}}
+	s.reload()
+	token, err := s.Sign(utils.JWTClaims{UserID: "user-1"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := tokenKid(t, token); kid != "k2" {
+		t.Errorf("signed with %q after the reload, want k2", kid)
+	}
+
+	// A throwaway key survives reloads while the config still has none, so its tokens stay valid
+	s = newTestSigner(t, types.JWTConfig{}, &now)
+	token, err = s.Sign(utils.JWTClaims{UserID: "user-1"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.reload()
+	if _, err := s.Verify(token); err != nil {
+		t.Errorf("throwaway key token rejected after a reload: %v", err)
+	}
+}
@@ -2,46 +2,124 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"doan/internal/entities"
 	"doan/internal/repositories"
 	_interface "doan/internal/repositories/interface"
+	"doan/internal/services/mailer"
+	"doan/internal/services/security"
 	"doan/pkg/config"
+	"doan/pkg/constants"
 	"doan/pkg/logger"
 	"doan/pkg/types"
 	"doan/pkg/utils"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"golang.org/x/crypto/bcrypt"
+	"html"
 	"time"
 )
 
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; the session has been revoked")
+	ErrTokenRevoked        = errors.New("token has been revoked")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrUserInactive        = errors.New("user is not active")
+)
+
+// defaultImpersonationTTL bounds an impersonation unless auth.impersonation_ttl_minutes is set
+const defaultImpersonationTTL = 15 * time.Minute
+
 type AuthService interface {
 	CreateAuthToken(ctx context.Context, input CreateAuthTokenInput) (*CreateAuthTokenOutput, error)
 	ValidateToken(ctx context.Context, token string) (*TokenClaims, error)
-	RefreshAccessToken(ctx context.Context, refreshToken string) (string, error)
+	// RefreshAccessToken rotates the refresh token; presenting a rotated token again revokes its session
+	RefreshAccessToken(ctx context.Context, input RefreshAccessTokenInput) (*RefreshAccessTokenOutput, error)
+	// Logout revokes the session of the access token and the token itself
+	Logout(ctx context.Context, token string) error
+	ListSessions(ctx context.Context, userID string) ([]*entities.AuthSession, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	// RevokeOtherSessions revokes every session of the user except keepSessionID, returning how many
+	RevokeOtherSessions(ctx context.Context, userID, keepSessionID string) (int, error)
+	// UnlockAccount lifts the failed-attempt backoff and lockout of the user
+	UnlockAccount(ctx context.Context, userID string) error
+	// CompleteMFALogin finishes a login that CreateAuthToken left waiting for the second factor
+	CompleteMFALogin(ctx context.Context, input CompleteMFALoginInput) (*CreateAuthTokenOutput, error)
+	// BeginLoginMFAEnrolment starts enrolment for a login whose role requires 2FA the user does not have yet
+	BeginLoginMFAEnrolment(ctx context.Context, mfaToken string) (*MFAEnrolment, error)
+	// CreateAuthTokenForUser logs in a user authenticated elsewhere, such as at an OIDC provider, the same
+	// way as CreateAuthToken after the password check: the second factor still applies
+	CreateAuthTokenForUser(ctx context.Context, user *entities.User, userAgent, ipAddress string) (*CreateAuthTokenOutput, error)
+	// RevokeUserSessions signs the user out everywhere on an admin's behalf, returning how many sessions ended
+	RevokeUserSessions(ctx context.Context, userID string) (int, error)
+	// RevokeRoleSessions signs out every holder of the role, whose tokens carry its old permissions
+	RevokeRoleSessions(ctx context.Context, role string) (int, error)
+	// Impersonate issues an access token for the user carrying the acting admin in its "act" claim. It
+	// belongs to a session of its own without refresh token, so it cannot outlive auth.impersonation_ttl_minutes.
+	Impersonate(ctx context.Context, input ImpersonateInput) (*ImpersonateOutput, error)
+	// RolePermissions returns the permissions a role grants
+	RolePermissions(ctx context.Context, role string) ([]string, error)
 }
 
 type authService struct {
-	userRepo      _interface.UserRepository
-	configManager config.Manager
-	log           logger.Logger
+	userRepo         _interface.UserRepository
+	roleRepo         _interface.RoleRepository
+	sessionRepo      _interface.AuthSessionRepository
+	refreshTokenRepo _interface.RefreshTokenRepository
+	denylist         TokenDenylist
+	throttle         AttemptThrottle
+	mfa              MFAService
+	signer           security.TokenSigner
+	mailer           mailer.Mailer
+	uow              repositories.UnitOfWork
+	configManager    config.Manager
+	log              logger.Logger
 }
 
 func NewAuthService(
 	userRepo _interface.UserRepository,
+	roleRepo _interface.RoleRepository,
+	sessionRepo _interface.AuthSessionRepository,
+	refreshTokenRepo _interface.RefreshTokenRepository,
+	denylist TokenDenylist,
+	throttle AttemptThrottle,
+	mfa MFAService,
+	signer security.TokenSigner,
+	mailer mailer.Mailer,
+	uow repositories.UnitOfWork,
 	configManager config.Manager,
 	log logger.Logger,
 ) AuthService {
 	return &authService{
-		userRepo:      userRepo,
-		configManager: configManager,
-		log:           log,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		denylist:         denylist,
+		throttle:         throttle,
+		mfa:              mfa,
+		signer:           signer,
+		mailer:           mailer,
+		uow:              uow,
+		configManager:    configManager,
+		log:              log,
 	}
 }
 
 func (s *authService) CreateAuthToken(ctx context.Context, input CreateAuthTokenInput) (*CreateAuthTokenOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
+	attempt := Attempt{Scope: ScopeLogin, Account: input.Username, IP: input.IPAddress}
+	if err := s.throttle.Check(ctx, attempt); err != nil {
+		ctxLogger.Infof("login throttled for %s from %s", input.Username, input.IPAddress)
+		return nil, err
+	}
+
 	// Get user by email
 	userCondition := repositories.NewCommonCondition()
 	userCondition.AddCondition("email", input.Username, repositories.Equal)
@@ -52,6 +130,7 @@ func (s *authService) CreateAuthToken(ctx context.Context, input CreateAuthToken
 	}
 	if userPagination == nil || len(userPagination.Data) == 0 {
 		ctxLogger.Info("user not found")
+		s.throttle.Fail(ctx, attempt)
 		return nil, errors.New("user not found")
 	}
 
@@ -60,52 +139,186 @@ func (s *authService) CreateAuthToken(ctx context.Context, input CreateAuthToken
 	// Check if user is active
 	if !user.IsActive {
 		ctxLogger.Info("user is not active")
-		return nil, errors.New("user is not active")
+		return nil, ErrUserInactive
 	}
 
 	// Verify password
 	if compareErr := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); compareErr != nil {
 		ctxLogger.Errorf("password does not match: %v", compareErr)
+		if s.throttle.Fail(ctx, attempt) {
+			ctxLogger.Warnf("account %s locked after repeated failed logins", user.Email)
+			s.sendLockoutNotice(ctx, user, input.IPAddress)
+		}
 		return nil, errors.New("invalid credentials")
 	}
+	s.throttle.Succeed(ctx, attempt)
 
-	// Get JWT config
-	jwtConfig := types.JWTConfig{}
-	if err := s.configManager.UnmarshalKey("jwt", &jwtConfig); err != nil {
+	return s.CreateAuthTokenForUser(ctx, user, input.UserAgent, input.IPAddress)
+}
+
+func (s *authService) CreateAuthTokenForUser(ctx context.Context, user *entities.User, userAgent, ipAddress string) (*CreateAuthTokenOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	// With an authenticator, or a role that requires one, the first factor only gets the login half way
+	mfaEnabled, err := s.mfa.Enabled(ctx, user.ID)
+	if err != nil {
+		ctxLogger.Errorf("failed to get two-factor status: %v", err)
+		return nil, err
+	}
+	if mfaEnabled || s.mfa.Required(user.Role) {
+		token, err := s.mfa.StartLogin(ctx, MFALogin{UserID: user.ID, Enrol: !mfaEnabled})
+		if err != nil {
+			ctxLogger.Errorf("failed to start two-factor login: %v", err)
+			return nil, err
+		}
+		ctxLogger.Infof("user %s passed the first step, waiting for the second factor", user.Email)
+		return &CreateAuthTokenOutput{
+			MFARequired:          true,
+			MFAEnrolmentRequired: !mfaEnabled,
+			MFAToken:             token,
+			User:                 s.mapUserToOutput(user),
+		}, nil
+	}
+
+	return s.issueLogin(ctx, user, userAgent, ipAddress)
+}
+
+func (s *authService) CompleteMFALogin(ctx context.Context, input CompleteMFALoginInput) (*CreateAuthTokenOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	pending, err := s.mfa.PendingLogin(ctx, input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := Attempt{Scope: ScopeVerifyMFA, Account: pending.UserID, IP: input.IPAddress}
+	if err := s.throttle.Check(ctx, attempt); err != nil {
+		ctxLogger.Infof("two-factor check throttled for %s from %s", pending.UserID, input.IPAddress)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, pending.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		s.mfa.FinishLogin(ctx, input.MFAToken)
+		return nil, ErrInvalidMFAToken
+	}
+
+	var recoveryCodes []string
+	switch {
+	case pending.Enrol:
+		recoveryCodes, err = s.mfa.ConfirmEnrolment(ctx, user.ID, input.Code)
+	case input.RecoveryCode != "":
+		err = s.mfa.UseRecoveryCode(ctx, user.ID, input.RecoveryCode)
+	default:
+		err = s.mfa.Verify(ctx, user.ID, input.Code)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidMFACode) && s.throttle.Fail(ctx, attempt) {
+			ctxLogger.Warnf("account %s locked after repeated failed two-factor codes", user.Email)
+			s.sendLockoutNotice(ctx, user, input.IPAddress)
+		}
+		return nil, err
+	}
+	s.throttle.Succeed(ctx, attempt)
+	s.mfa.FinishLogin(ctx, input.MFAToken)
+
+	output, err := s.issueLogin(ctx, user, input.UserAgent, input.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	output.RecoveryCodes = recoveryCodes
+	return output, nil
+}
+
+func (s *authService) BeginLoginMFAEnrolment(ctx context.Context, mfaToken string) (*MFAEnrolment, error) {
+	pending, err := s.mfa.PendingLogin(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if !pending.Enrol {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	user, err := s.userRepo.GetByID(ctx, pending.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, ErrInvalidMFAToken
+	}
+	return s.mfa.BeginEnrolment(ctx, user)
+}
+
+// issueLogin opens a session for the user and issues its first access and refresh tokens
+func (s *authService) issueLogin(ctx context.Context, user *entities.User, userAgent, ipAddress string) (*CreateAuthTokenOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	accessTokenDuration, refreshTokenDuration, err := s.tokenLifetimes()
+	if err != nil {
 		ctxLogger.Errorf("failed to get jwt config: %v", err)
-		return nil, fmt.Errorf("failed to get jwt config: %w", err)
+		return nil, err
 	}
 
-	// Parse token durations
-	accessTokenDuration, err := time.ParseDuration(jwtConfig.AccessTokenDuration)
+	permissions, err := s.rolePermissions(ctx, user.Role)
 	if err != nil {
-		accessTokenDuration = 24 * time.Hour // Default to 24 hours
+		ctxLogger.Errorf("failed to get role permissions: %v", err)
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
 	}
 
-	refreshTokenDuration, err := time.ParseDuration(jwtConfig.RefreshTokenDuration)
+	type issued struct {
+		sessionID    string
+		refreshToken string
+	}
+
+	// Every login opens a session holding the refresh token family
+	now := time.Now()
+	result, err := repositories.ExecuteInTransaction(ctx, s.uow, s.log, func(txCtx context.Context) (interface{}, error) {
+		session, err := s.sessionRepo.Create(txCtx, &entities.AuthSession{
+			UserID:     user.ID,
+			UserAgent:  truncate(userAgent, 512),
+			IPAddress:  truncate(ipAddress, 64),
+			ExpiresAt:  now.Add(refreshTokenDuration),
+			LastUsedAt: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		refreshToken, err := s.issueRefreshToken(txCtx, session.ID, session.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		return &issued{sessionID: session.ID, refreshToken: refreshToken}, nil
+	})
 	if err != nil {
-		refreshTokenDuration = 168 * time.Hour // Default to 7 days
+		ctxLogger.Errorf("failed to create session: %v", err)
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
+	login := result.(*issued)
 
 	// Generate access token (JWT)
-	accessToken, err := utils.GenerateToken(user.ID, user.Email, user.Role, jwtConfig.Secret, accessTokenDuration)
+	accessToken, err := s.signer.Sign(utils.JWTClaims{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        user.Role,
+		Permissions: permissions,
+		SessionID:   login.sessionID,
+	}, accessTokenDuration)
 	if err != nil {
 		ctxLogger.Errorf("failed to generate access token: %v", err)
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	// Generate refresh token
-	refreshToken, err := utils.GenerateToken(user.ID, user.Email, user.Role, jwtConfig.Secret, refreshTokenDuration)
-	if err != nil {
-		ctxLogger.Errorf("failed to generate refresh token: %v", err)
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
-	}
-
 	ctxLogger.Infof("user %s logged in successfully", user.Email)
 
 	return &CreateAuthTokenOutput{
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: login.refreshToken,
 		User:         s.mapUserToOutput(user),
 	}, nil
 }
@@ -113,51 +326,393 @@ func (s *authService) CreateAuthToken(ctx context.Context, input CreateAuthToken
 func (s *authService) ValidateToken(ctx context.Context, token string) (*TokenClaims, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
-	// Get JWT config
-	jwtConfig := types.JWTConfig{}
-	if err := s.configManager.UnmarshalKey("jwt", &jwtConfig); err != nil {
-		ctxLogger.Errorf("failed to get jwt config: %v", err)
-		return nil, fmt.Errorf("failed to get jwt config: %w", err)
-	}
-
-	// Validate token
-	claims, err := utils.ValidateToken(token, jwtConfig.Secret)
+	// Validate signature, expiry, issuer and audience
+	claims, err := s.signer.Verify(token)
 	if err != nil {
 		ctxLogger.Errorf("failed to validate token: %v", err)
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
 
+	revoked, err := s.denylist.IsRevoked(ctx, claims.ID, claims.SessionID)
+	if err != nil {
+		ctxLogger.Errorf("failed to check token denylist: %v", err)
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
 	return &TokenClaims{
-		UserID: claims.UserID,
-		Email:  claims.Email,
-		Role:   claims.Role,
+		UserID:      claims.UserID,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		Permissions: claims.Permissions,
+		SessionID:   claims.SessionID,
+		TokenID:     claims.ID,
+		ExpiresAt:   expiresAt,
 	}, nil
 }
 
-func (s *authService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
+func (s *authService) RefreshAccessToken(ctx context.Context, input RefreshAccessTokenInput) (*RefreshAccessTokenOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
-	// Get JWT config
+	accessTokenDuration, refreshTokenDuration, err := s.tokenLifetimes()
+	if err != nil {
+		ctxLogger.Errorf("failed to get jwt config: %v", err)
+		return nil, err
+	}
+
+	type rotation struct {
+		user         *entities.User
+		sessionID    string
+		refreshToken string
+		reused       bool
+	}
+
+	now := time.Now()
+	result, err := repositories.ExecuteInTransaction(ctx, s.uow, s.log, func(txCtx context.Context) (interface{}, error) {
+		token, err := s.refreshTokenRepo.LockByTokenHash(txCtx, hashToken(input.RefreshToken))
+		if err != nil {
+			return nil, err
+		}
+		if token == nil {
+			return nil, ErrInvalidRefreshToken
+		}
+		session, err := s.sessionRepo.GetByID(txCtx, token.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil || session.RevokedAt != nil {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		// A rotated token coming back means it leaked: end the whole family, and commit that
+		if token.UsedAt != nil {
+			if _, err := s.sessionRepo.Revoke(txCtx, session.ID, entities.SessionRevokedReuse, now); err != nil {
+				return nil, err
+			}
+			return &rotation{sessionID: session.ID, reused: true}, nil
+		}
+		if !now.Before(token.ExpiresAt) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		// Reload the user so role and permission changes apply from the next access token
+		user, err := s.userRepo.GetByID(txCtx, session.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil || !user.IsActive {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		if err := s.refreshTokenRepo.Update(txCtx, token.ID, map[string]interface{}{"used_at": now}); err != nil {
+			return nil, err
+		}
+		expiresAt := now.Add(refreshTokenDuration)
+		refreshToken, err := s.issueRefreshToken(txCtx, session.ID, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		updates := map[string]interface{}{"expires_at": expiresAt, "last_used_at": now}
+		if input.UserAgent != "" {
+			updates["user_agent"] = truncate(input.UserAgent, 512)
+		}
+		if input.IPAddress != "" {
+			updates["ip_address"] = truncate(input.IPAddress, 64)
+		}
+		if err := s.sessionRepo.Update(txCtx, session.ID, updates); err != nil {
+			return nil, err
+		}
+		return &rotation{user: user, sessionID: session.ID, refreshToken: refreshToken}, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("failed to refresh token: %v", err)
+		return nil, err
+	}
+
+	rotated := result.(*rotation)
+	if rotated.reused {
+		ctxLogger.Warnf("refresh token reuse detected, revoked session %s", rotated.sessionID)
+		if err := s.denylist.RevokeSessions(ctx, rotated.sessionID); err != nil {
+			ctxLogger.Errorf("failed to deny access tokens of session %s: %v", rotated.sessionID, err)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	permissions, err := s.rolePermissions(ctx, rotated.user.Role)
+	if err != nil {
+		ctxLogger.Errorf("failed to get role permissions: %v", err)
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	accessToken, err := s.signer.Sign(utils.JWTClaims{
+		UserID:      rotated.user.ID,
+		Email:       rotated.user.Email,
+		Role:        rotated.user.Role,
+		Permissions: permissions,
+		SessionID:   rotated.sessionID,
+	}, accessTokenDuration)
+	if err != nil {
+		ctxLogger.Errorf("failed to generate access token: %v", err)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &RefreshAccessTokenOutput{
+		AccessToken:  accessToken,
+		RefreshToken: rotated.refreshToken,
+	}, nil
+}
+
+func (s *authService) Logout(ctx context.Context, token string) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	claims, err := s.ValidateToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if claims.SessionID != "" {
+		if _, err := s.sessionRepo.Revoke(ctx, claims.SessionID, entities.SessionRevokedLogout, time.Now()); err != nil {
+			ctxLogger.Errorf("failed to revoke session: %v", err)
+			return err
+		}
+		if err := s.denylist.RevokeSessions(ctx, claims.SessionID); err != nil {
+			ctxLogger.Errorf("failed to deny session tokens: %v", err)
+			return err
+		}
+	}
+	if err := s.denylist.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+		ctxLogger.Errorf("failed to deny access token: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *authService) ListSessions(ctx context.Context, userID string) ([]*entities.AuthSession, error) {
+	return s.sessionRepo.ListActiveByUser(ctx, userID, time.Now())
+}
+
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	// Someone else's session is reported as missing rather than forbidden
+	if session == nil || session.UserID != userID || session.RevokedAt != nil {
+		return ErrSessionNotFound
+	}
+
+	if _, err := s.sessionRepo.Revoke(ctx, session.ID, entities.SessionRevokedByUser, time.Now()); err != nil {
+		return err
+	}
+	return s.denylist.RevokeSessions(ctx, session.ID)
+}
+
+func (s *authService) RevokeOtherSessions(ctx context.Context, userID, keepSessionID string) (int, error) {
+	ids, err := s.sessionRepo.RevokeAllByUser(ctx, userID, keepSessionID, entities.SessionRevokedByUser, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if err := s.denylist.RevokeSessions(ctx, ids...); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+func (s *authService) RevokeUserSessions(ctx context.Context, userID string) (int, error) {
+	ids, err := s.sessionRepo.RevokeAllByUser(ctx, userID, "", entities.SessionRevokedByAdmin, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if err := s.denylist.RevokeSessions(ctx, ids...); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+func (s *authService) RevokeRoleSessions(ctx context.Context, role string) (int, error) {
+	ids, err := s.sessionRepo.RevokeAllByRole(ctx, role, entities.SessionRevokedRole, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if err := s.denylist.RevokeSessions(ctx, ids...); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+func (s *authService) Impersonate(ctx context.Context, input ImpersonateInput) (*ImpersonateOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	user := input.User
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+	permissions, err := s.rolePermissions(ctx, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	ttl := defaultImpersonationTTL
+	if minutes := s.configManager.GetInt("auth.impersonation_ttl_minutes"); minutes > 0 {
+		ttl = time.Duration(minutes) * time.Minute
+	}
+	now := time.Now()
+	session, err := s.sessionRepo.Create(ctx, &entities.AuthSession{
+		UserID:     user.ID,
+		UserAgent:  truncate(input.UserAgent, 512),
+		IPAddress:  truncate(input.IPAddress, 64),
+		ExpiresAt:  now.Add(ttl),
+		LastUsedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	accessToken, err := s.signer.Sign(utils.JWTClaims{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        user.Role,
+		Permissions: permissions,
+		SessionID:   session.ID,
+		Actor:       &utils.TokenActor{Subject: input.ActorID, Role: input.ActorRole},
+	}, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	ctxLogger.Infof("user %s impersonated by %s", user.Email, input.ActorID)
+	return &ImpersonateOutput{
+		AccessToken: accessToken,
+		SessionID:   session.ID,
+		ExpiresAt:   session.ExpiresAt,
+		User:        s.mapUserToOutput(user),
+	}, nil
+}
+
+func (s *authService) RolePermissions(ctx context.Context, role string) ([]string, error) {
+	return s.rolePermissions(ctx, role)
+}
+
+func (s *authService) UnlockAccount(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	// Login counts by email, OTP verification by user ID
+	return s.throttle.Unlock(ctx, user.Email, user.ID)
+}
+
+// sendLockoutNotice tells the owner their account was locked, in the background
+func (s *authService) sendLockoutNotice(ctx context.Context, user *entities.User, ipAddress string) {
+	lockout := time.Duration(s.throttleLockoutSeconds()) * time.Second
+	mail := mailer.Mail{
+		To:      user.Email,
+		Subject: "Tài khoản của bạn đã bị tạm khóa",
+		HTML: fmt.Sprintf(`
+			<html>
+			<body style="font-family: Arial, sans-serif;">
+				<h3>Xin chào %s,</h3>
+				<p>Tài khoản của bạn đã bị tạm khóa sau nhiều lần đăng nhập sai liên tiếp (địa chỉ IP gần nhất: %s).</p>
+				<p>Bạn có thể thử lại sau <strong>%d phút</strong> hoặc liên hệ quản trị viên để mở khóa.</p>
+				<p>Nếu đó không phải là bạn, hãy đổi mật khẩu ngay sau khi đăng nhập lại.</p>
+			</body>
+			</html>
+		`, html.EscapeString(user.FullName), html.EscapeString(ipAddress), int(lockout.Minutes())),
+	}
+
+	// Detach from the request so the mail is not cancelled when the response is written
+	go func(ctx context.Context) {
+		if err := s.mailer.Send(ctx, mail); err != nil {
+			logger.NewLogger(ctx).Errorf("Failed to send lockout notice to %s: %v", mail.To, err)
+		}
+	}(context.WithoutCancel(ctx))
+}
+
+func (s *authService) throttleLockoutSeconds() int {
+	if seconds := s.configManager.GetInt("auth.throttle.lockout_seconds"); seconds > 0 {
+		return seconds
+	}
+	return defaultThrottleConfig().LockoutSeconds
+}
+
+// tokenLifetimes reads the access and refresh token lifetimes, defaulting to 24 hours and 7 days
+func (s *authService) tokenLifetimes() (time.Duration, time.Duration, error) {
 	jwtConfig := types.JWTConfig{}
 	if err := s.configManager.UnmarshalKey("jwt", &jwtConfig); err != nil {
-		ctxLogger.Errorf("failed to get jwt config: %v", err)
-		return "", fmt.Errorf("failed to get jwt config: %w", err)
+		return 0, 0, fmt.Errorf("failed to get jwt config: %w", err)
 	}
 
-	// Parse access token duration
-	accessTokenDuration, err := time.ParseDuration(jwtConfig.AccessTokenDuration)
+	refreshTokenDuration, err := time.ParseDuration(jwtConfig.RefreshTokenDuration)
 	if err != nil {
-		accessTokenDuration = 24 * time.Hour // Default to 24 hours
+		refreshTokenDuration = 168 * time.Hour // Default to 7 days
 	}
+	return accessTokenDuration(s.configManager), refreshTokenDuration, nil
+}
 
-	// Refresh token
-	newAccessToken, err := utils.RefreshToken(refreshToken, jwtConfig.Secret, accessTokenDuration)
+// issueRefreshToken stores a new single-use refresh token of the session and returns it
+func (s *authService) issueRefreshToken(ctx context.Context, sessionID string, expiresAt time.Time) (string, error) {
+	token, err := randomToken()
 	if err != nil {
-		ctxLogger.Errorf("failed to refresh token: %v", err)
-		return "", fmt.Errorf("failed to refresh token: %w", err)
+		return "", err
+	}
+
+	if _, err := s.refreshTokenRepo.Create(ctx, &entities.RefreshToken{
+		SessionID: sessionID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// randomToken returns 256 random bits, URL-safe encoded
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
-	return newAccessToken, nil
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
+
+// rolePermissions returns the permissions of a role, falling back to the built-in bundle
+// when the roles table has no row for it; never nil so the claim is always present
+func (s *authService) rolePermissions(ctx context.Context, roleName string) ([]string, error) {
+	role, err := s.roleRepo.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	var permissions []string
+	if role != nil {
+		permissions = role.Permissions
+	} else {
+		permissions = constants.DefaultRolePermissions(roleName)
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return permissions, nil
 }
 
 func (s *authService) mapUserToOutput(user *entities.User) UserOutput {
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"doan/pkg/types"
+	"errors"
+	"time"
+)
+
+const (
+	denyTokenKeyPrefix   = "auth:deny:jti:"
+	denySessionKeyPrefix = "auth:deny:sid:"
+)
+
+// TokenDenylist revokes access tokens before they expire. Entries only live as long as the tokens
+// they block, so a cache is enough; with the per-process memory cache a revocation only reaches
+// the instance that made it.
+type TokenDenylist interface {
+	// RevokeToken blocks one access token, identified by its jti, until it expires
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeSessions blocks every access token issued for the sessions
+	RevokeSessions(ctx context.Context, sessionIDs ...string) error
+	// IsRevoked reports whether the token or its session has been revoked
+	IsRevoked(ctx context.Context, jti, sessionID string) (bool, error)
+}
+
+type tokenDenylist struct {
+	cache         caching.StateStore
+	configManager config.Manager
+}
+
+// NewTokenDenylist creates a new instance of TokenDenylist
+func NewTokenDenylist(cache caching.StateStore, configManager config.Manager) TokenDenylist {
+	return &tokenDenylist{
+		cache:         cache,
+		configManager: configManager,
+	}
+}
+
+func (d *tokenDenylist) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+	return d.cache.SetString(ctx, denyTokenKeyPrefix+jti, "1", ttl)
+}
+
+func (d *tokenDenylist) RevokeSessions(ctx context.Context, sessionIDs ...string) error {
+	// Any access token of the session was issued at most one access token lifetime ago
+	ttl := accessTokenDuration(d.configManager)
+	for _, id := range sessionIDs {
+		if err := d.cache.SetString(ctx, denySessionKeyPrefix+id, "1", ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *tokenDenylist) IsRevoked(ctx context.Context, jti, sessionID string) (bool, error) {
+	for _, key := range []string{denyTokenKeyPrefix + jti, denySessionKeyPrefix + sessionID} {
+		if key == denyTokenKeyPrefix || key == denySessionKeyPrefix {
+			continue
+		}
+		_, err := d.cache.GetString(ctx, key)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, caching.ErrCacheMiss) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// accessTokenDuration reads jwt.access_token_duration, defaulting to 24 hours
+func accessTokenDuration(configManager config.Manager) time.Duration {
+	jwtConfig := types.JWTConfig{}
+	if err := configManager.UnmarshalKey("jwt", &jwtConfig); err != nil {
+		return 24 * time.Hour
+	}
+	duration, err := time.ParseDuration(jwtConfig.AccessTokenDuration)
+	if err != nil || duration <= 0 {
+		return 24 * time.Hour
+	}
+	return duration
+}
@@ -1,16 +1,51 @@
 package user
 
+import (
+	"doan/internal/entities"
+	"time"
+)
+
 // CreateAuthTokenInput is a struct that contains the input for CreateAuthToken method
 type CreateAuthTokenInput struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	UserAgent string `json:"user_agent"`
+	IPAddress string `json:"ip_address"`
 }
 
-// CreateAuthTokenOutput is a struct that contains the output for CreateAuthToken method
+// CreateAuthTokenOutput is a struct that contains the output for CreateAuthToken method. When MFARequired
+// is set no tokens are issued yet: MFAToken resumes the login with the second factor.
 type CreateAuthTokenOutput struct {
-	AccessToken  string     `json:"access_token"`
-	RefreshToken string     `json:"refresh_token"`
-	User         UserOutput `json:"user"`
+	AccessToken          string     `json:"access_token"`
+	RefreshToken         string     `json:"refresh_token"`
+	MFARequired          bool       `json:"mfa_required"`
+	MFAEnrolmentRequired bool       `json:"mfa_enrolment_required"`
+	MFAToken             string     `json:"mfa_token"`
+	RecoveryCodes        []string   `json:"recovery_codes"` // issued when the login confirmed a mandatory enrolment
+	User                 UserOutput `json:"user"`
+}
+
+// CompleteMFALoginInput is a struct that contains the input for CompleteMFALogin method; RecoveryCode
+// replaces Code when the authenticator is lost
+type CompleteMFALoginInput struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	UserAgent    string `json:"user_agent"`
+	IPAddress    string `json:"ip_address"`
+}
+
+// RefreshAccessTokenInput is a struct that contains the input for RefreshAccessToken method
+type RefreshAccessTokenInput struct {
+	RefreshToken string `json:"refresh_token"`
+	UserAgent    string `json:"user_agent"`
+	IPAddress    string `json:"ip_address"`
+}
+
+// RefreshAccessTokenOutput is a struct that contains the new access token and the rotated refresh token
+type RefreshAccessTokenOutput struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // UserOutput is a struct that contains user information
@@ -23,9 +58,30 @@ type UserOutput struct {
 	IsActive bool   `json:"is_active"`
 }
 
+// ImpersonateInput is a struct that contains the input for Impersonate method
+type ImpersonateInput struct {
+	User      *entities.User
+	ActorID   string
+	ActorRole string
+	UserAgent string
+	IPAddress string
+}
+
+// ImpersonateOutput is a struct that contains the impersonation access token; there is no refresh token
+type ImpersonateOutput struct {
+	AccessToken string     `json:"access_token"`
+	SessionID   string     `json:"session_id"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	User        UserOutput `json:"user"`
+}
+
 // TokenClaims is a struct that contains JWT token claims
 type TokenClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	Permissions []string  `json:"permissions"`
+	SessionID   string    `json:"session_id"`
+	TokenID     string    `json:"token_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
 }
@@ -0,0 +1,408 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"doan/internal/caching"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	_interface "doan/internal/repositories/interface"
+	"doan/internal/services/security"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/totp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const mfaPendingKeyPrefix = "auth:mfa:pending:"
+
+// recoveryCodeAlphabet leaves out characters that are easily confused when copied by hand
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+var (
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolling   = errors.New("no two-factor enrolment in progress; start the setup again")
+	ErrMFARequired       = errors.New("two-factor authentication is mandatory for this role")
+	ErrInvalidMFACode    = errors.New("invalid two-factor code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired two-factor login token")
+)
+
+// MFAConfig is the "auth.mfa" config block
+type MFAConfig struct {
+	Issuer                 string   `mapstructure:"issuer"`                    // shown by the authenticator app
+	RequiredRoles          []string `mapstructure:"required_roles"`            // roles that must use 2FA to sign in
+	PendingTokenTTLSeconds int      `mapstructure:"pending_token_ttl_seconds"` // time to enter the code after the password
+	RecoveryCodes          int      `mapstructure:"recovery_codes"`            // recovery codes issued at a time
+	SkewSteps              int      `mapstructure:"skew_steps"`                // 30 s steps of clock drift tolerated either way
+}
+
+func defaultMFAConfig() MFAConfig {
+	return MFAConfig{
+		Issuer:                 "DoAn",
+		PendingTokenTTLSeconds: 300,
+		RecoveryCodes:          10,
+		SkewSteps:              1,
+	}
+}
+
+// MFAEnrolment is what the authenticator app needs; ProvisioningURI is rendered as a QR code
+type MFAEnrolment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// MFALogin is a login whose password was accepted and which waits for the second factor
+type MFALogin struct {
+	UserID string `json:"user_id"`
+	Enrol  bool   `json:"enrol"` // the role requires 2FA but the user has none yet: the code confirms enrolment
+}
+
+// MFAService manages TOTP authenticators (RFC 6238) and their recovery codes. Enrolment is two-step:
+// BeginEnrolment stores a pending secret and ConfirmEnrolment enables it once the app produces a valid code.
+type MFAService interface {
+	// Required reports whether the role has to use two-factor authentication
+	Required(role string) bool
+	// Enabled reports whether the user confirmed an authenticator
+	Enabled(ctx context.Context, userID string) (bool, error)
+	BeginEnrolment(ctx context.Context, user *entities.User) (*MFAEnrolment, error)
+	// ConfirmEnrolment enables the pending authenticator and returns the first recovery codes
+	ConfirmEnrolment(ctx context.Context, userID, code string) ([]string, error)
+	// Verify checks a TOTP code; each code is accepted once
+	Verify(ctx context.Context, userID, code string) error
+	// UseRecoveryCode spends one recovery code
+	UseRecoveryCode(ctx context.Context, userID, code string) error
+	// RegenerateRecoveryCodes replaces the recovery codes after checking a TOTP code
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	// Disable removes the authenticator of a user, checking a TOTP code first
+	Disable(ctx context.Context, user *entities.User, code string) error
+	// Reset removes the authenticator of a user without a code, for admins helping someone who lost it
+	Reset(ctx context.Context, userID string) error
+
+	// StartLogin parks a login until the second factor arrives and returns the token that resumes it
+	StartLogin(ctx context.Context, login MFALogin) (string, error)
+	// PendingLogin returns the parked login of a token
+	PendingLogin(ctx context.Context, token string) (*MFALogin, error)
+	// FinishLogin discards a parked login so its token cannot be used again
+	FinishLogin(ctx context.Context, token string)
+}
+
+type mfaService struct {
+	mfaRepo          _interface.UserMFARepository
+	recoveryCodeRepo _interface.UserRecoveryCodeRepository
+	hasher           security.PasswordHasher
+	cache            caching.StateStore
+	uow              repositories.UnitOfWork
+	configManager    config.Manager
+	log              logger.Logger
+	now              func() time.Time
+}
+
+// NewMFAService creates a new instance of MFAService
+func NewMFAService(
+	mfaRepo _interface.UserMFARepository,
+	recoveryCodeRepo _interface.UserRecoveryCodeRepository,
+	hasher security.PasswordHasher,
+	cache caching.StateStore,
+	uow repositories.UnitOfWork,
+	configManager config.Manager,
+	log logger.Logger,
+) MFAService {
+	return &mfaService{
+		mfaRepo:          mfaRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		hasher:           hasher,
+		cache:            cache,
+		uow:              uow,
+		configManager:    configManager,
+		log:              log,
+		now:              time.Now,
+	}
+}
+
+func (s *mfaService) Required(role string) bool {
+	return slices.Contains(s.config().RequiredRoles, role)
+}
+
+func (s *mfaService) Enabled(ctx context.Context, userID string) (bool, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa.Enabled(), nil
+}
+
+func (s *mfaService) BeginEnrolment(ctx context.Context, user *entities.User) (*MFAEnrolment, error) {
+	existing, err := s.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	// Starting again replaces a pending secret the user never confirmed
+	if existing != nil {
+		err = s.mfaRepo.Update(ctx, existing.ID, map[string]interface{}{"secret": secret, "last_used_step": 0})
+	} else {
+		_, err = s.mfaRepo.Create(ctx, &entities.UserMFA{UserID: user.ID, Secret: secret})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrolment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.config().Issuer, user.Email, secret),
+	}, nil
+}
+
+func (s *mfaService) ConfirmEnrolment(ctx context.Context, userID, code string) ([]string, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, ErrMFANotEnrolling
+	}
+	if mfa.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if err := s.checkCode(ctx, mfa, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	_, err = repositories.ExecuteInTransaction(ctx, s.uow, s.log, func(txCtx context.Context) (interface{}, error) {
+		if err := s.mfaRepo.Update(txCtx, mfa.ID, map[string]interface{}{"enabled_at": s.now()}); err != nil {
+			return nil, err
+		}
+		return nil, s.recoveryCodeRepo.Replace(txCtx, userID, hashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *mfaService) Verify(ctx context.Context, userID, code string) error {
+	mfa, err := s.enabledMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.checkCode(ctx, mfa, code)
+}
+
+// UseRecoveryCode compares the code with each unused hash; there are only a handful per user
+func (s *mfaService) UseRecoveryCode(ctx context.Context, userID, code string) error {
+	if _, err := s.enabledMFA(ctx, userID); err != nil {
+		return err
+	}
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return ErrInvalidMFACode
+	}
+
+	codes, err := s.recoveryCodeRepo.ListUnusedByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, stored := range codes {
+		if s.hasher.Compare(stored.CodeHash, normalized) != nil {
+			continue
+		}
+		used, err := s.recoveryCodeRepo.MarkUsed(ctx, stored.ID, s.now())
+		if err != nil {
+			return err
+		}
+		if !used {
+			// Spent by a concurrent request
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+	return ErrInvalidMFACode
+}
+
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	_, err = repositories.ExecuteInTransaction(ctx, s.uow, s.log, func(txCtx context.Context) (interface{}, error) {
+		return nil, s.recoveryCodeRepo.Replace(txCtx, userID, hashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, user *entities.User, code string) error {
+	if s.Required(user.Role) {
+		return ErrMFARequired
+	}
+	if err := s.Verify(ctx, user.ID, code); err != nil {
+		return err
+	}
+	return s.Reset(ctx, user.ID)
+}
+
+func (s *mfaService) Reset(ctx context.Context, userID string) error {
+	_, err := repositories.ExecuteInTransaction(ctx, s.uow, s.log, func(txCtx context.Context) (interface{}, error) {
+		return nil, s.mfaRepo.DeleteByUserID(txCtx, userID)
+	})
+	return err
+}
+
+func (s *mfaService) StartLogin(ctx context.Context, login MFALogin) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	ttl := time.Duration(s.config().PendingTokenTTLSeconds) * time.Second
+	if err := caching.SetJSON(ctx, s.cache, mfaPendingKeyPrefix+hashToken(token), login, ttl); err != nil {
+		return "", fmt.Errorf("failed to store pending login: %w", err)
+	}
+	return token, nil
+}
+
+func (s *mfaService) PendingLogin(ctx context.Context, token string) (*MFALogin, error) {
+	if token == "" {
+		return nil, ErrInvalidMFAToken
+	}
+	login, err := caching.GetJSON[MFALogin](ctx, s.cache, mfaPendingKeyPrefix+hashToken(token))
+	if errors.Is(err, caching.ErrCacheMiss) {
+		return nil, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+func (s *mfaService) FinishLogin(ctx context.Context, token string) {
+	if err := s.cache.Delete(ctx, mfaPendingKeyPrefix+hashToken(token)); err != nil {
+		logger.NewLogger(ctx).Warnf("Failed to discard pending login: %v", err)
+	}
+}
+
+func (s *mfaService) enabledMFA(ctx context.Context, userID string) (*entities.UserMFA, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.Enabled() {
+		return nil, ErrMFANotEnabled
+	}
+	return mfa, nil
+}
+
+// checkCode validates a TOTP code and records its time step, so the same code cannot be replayed
+func (s *mfaService) checkCode(ctx context.Context, mfa *entities.UserMFA, code string) error {
+	step, ok := totp.Validate(mfa.Secret, code, s.now(), s.config().SkewSteps)
+	if !ok || step <= mfa.LastUsedStep {
+		return ErrInvalidMFACode
+	}
+	advanced, err := s.mfaRepo.AdvanceStep(ctx, mfa.ID, step)
+	if err != nil {
+		return err
+	}
+	if !advanced {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// randomRecoveryChars returns n characters drawn uniformly from recoveryCodeAlphabet. Bytes from the
+// incomplete last cycle of the alphabet are thrown away, as keeping them would favour its first letters.
+func randomRecoveryChars(n int) (string, error) {
+	limit := 256 - 256%len(recoveryCodeAlphabet)
+	chars := make([]byte, 0, n)
+	buf := make([]byte, n)
+	for len(chars) < n {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) < limit && len(chars) < n {
+				chars = append(chars, recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+			}
+		}
+	}
+	return string(chars), nil
+}
+
+// newRecoveryCodes returns fresh codes, formatted "xxxxx-xxxxx", and their hashes. The hashes are
+// computed concurrently as each one costs a full bcrypt round.
+func (s *mfaService) newRecoveryCodes() ([]string, []string, error) {
+	count := s.config().RecoveryCodes
+	codes := make([]string, count)
+	hashes := make([]string, count)
+	group := errgroup.Group{}
+	for i := range codes {
+		raw, err := randomRecoveryChars(10)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+
+		group.Go(func() error {
+			hash, err := s.hasher.Hash(normalizeRecoveryCode(codes[i]))
+			hashes[i] = hash
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return codes, hashes, nil
+}
+
+func (s *mfaService) config() MFAConfig {
+	cfg := defaultMFAConfig()
+	if err := s.configManager.UnmarshalKey("auth.mfa", &cfg); err != nil {
+		return defaultMFAConfig()
+	}
+	defaults := defaultMFAConfig()
+	if cfg.Issuer == "" {
+		cfg.Issuer = defaults.Issuer
+	}
+	if cfg.PendingTokenTTLSeconds <= 0 {
+		cfg.PendingTokenTTLSeconds = defaults.PendingTokenTTLSeconds
+	}
+	if cfg.RecoveryCodes <= 0 {
+		cfg.RecoveryCodes = defaults.RecoveryCodes
+	}
+	if cfg.SkewSteps < 0 {
+		cfg.SkewSteps = 0
+	}
+	return cfg
+}
+
+// normalizeRecoveryCode ignores case, dashes and spaces the user may type or leave out
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRandomRecoveryChars(t *testing.T) {
+	const perChar = 10000
+	alphabetSize := len(recoveryCodeAlphabet)
+
+	chars, err := randomRecoveryChars(perChar * alphabetSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chars) != perChar*alphabetSize {
+		t.Fatalf("got %d characters, want %d", len(chars), perChar*alphabetSize)
+	}
+
+	counts := make(map[rune]int, alphabetSize)
+	for _, c := range chars {
+		if !strings.ContainsRune(recoveryCodeAlphabet, c) {
+			t.Fatalf("character %q is outside the alphabet", c)
+		}
+		counts[c]++
+	}
+	// Modulo bias would give the first 8 letters about 9% more draws; 5% is over 5 standard deviations
+	for _, c := range recoveryCodeAlphabet {
+		if n := counts[c]; n < perChar*95/100 || n > perChar*105/100 {
+			t.Errorf("%q drawn %d times, want about %d", c, n, perChar)
+		}
+	}
+}
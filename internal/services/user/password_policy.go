@@ -0,0 +1,247 @@
+package user
+
+import (
+	"context"
+	"doan/internal/entities"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/security"
+	"doan/pkg/config"
+	xerror "doan/pkg/x-error"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// bcryptMaxBytes is the length bcrypt refuses to hash beyond
+const bcryptMaxBytes = 72
+
+// minPersonalInfoLength keeps short name parts ("An", "Le") from rejecting most passwords
+const minPersonalInfoLength = 3
+
+// PasswordPolicyConfig is the "security.password_policy" config block
+type PasswordPolicyConfig struct {
+	MinLength          int  `mapstructure:"min_length"`
+	MaxLength          int  `mapstructure:"max_length"` // never above the 72 bytes bcrypt can hash
+	RequireLowercase   bool `mapstructure:"require_lowercase"`
+	RequireUppercase   bool `mapstructure:"require_uppercase"`
+	RequireDigit       bool `mapstructure:"require_digit"`
+	RequireSymbol      bool `mapstructure:"require_symbol"`
+	ForbidPersonalInfo bool `mapstructure:"forbid_personal_info"` // the email's local part or a word of the name
+	HistorySize        int  `mapstructure:"history_size"`         // the current and previous passwords refused; 0 allows reuse
+	CheckBreached      bool `mapstructure:"check_breached"`
+}
+
+func defaultPasswordPolicyConfig() PasswordPolicyConfig {
+	return PasswordPolicyConfig{
+		MinLength:          8,
+		MaxLength:          bcryptMaxBytes,
+		RequireLowercase:   true,
+		RequireUppercase:   true,
+		RequireDigit:       true,
+		ForbidPersonalInfo: true,
+		HistorySize:        5,
+		CheckBreached:      true,
+	}
+}
+
+// PasswordPolicyError lists every rule a new password breaks, as pkg/x-error codes
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return xerror.PasswordPolicyViolation + ": " + strings.Join(e.Violations, ", ")
+}
+
+// ErrCode is the x-error code of the whole rejection; Violations has the details
+func (e *PasswordPolicyError) ErrCode() string {
+	return xerror.PasswordPolicyViolation
+}
+
+// PasswordCandidate is a password a user wants to set. UserID and CurrentHash are empty for a new account.
+type PasswordCandidate struct {
+	Plain       string
+	UserID      string
+	Email       string
+	FullName    string
+	CurrentHash string
+}
+
+// PasswordPolicy decides which passwords users may set. Rules come from "security.password_policy" and are
+// read on every call, so config changes apply at once.
+type PasswordPolicy interface {
+	// Validate returns a *PasswordPolicyError listing every rule the candidate breaks, nil when it is accepted
+	Validate(ctx context.Context, candidate PasswordCandidate) error
+	// Retire records the hash of the password a user is replacing, so it cannot be set again for a while
+	Retire(ctx context.Context, userID, hash string) error
+}
+
+type passwordPolicy struct {
+	configManager config.Manager
+	hasher        security.PasswordHasher
+	breached      security.BreachedPasswords
+	historyRepo   repositoryinterface.PasswordHistoryRepository
+}
+
+func NewPasswordPolicy(
+	configManager config.Manager,
+	hasher security.PasswordHasher,
+	breached security.BreachedPasswords,
+	historyRepo repositoryinterface.PasswordHistoryRepository,
+) PasswordPolicy {
+	return &passwordPolicy{
+		configManager: configManager,
+		hasher:        hasher,
+		breached:      breached,
+		historyRepo:   historyRepo,
+	}
+}
+
+func (p *passwordPolicy) Validate(ctx context.Context, candidate PasswordCandidate) error {
+	cfg := p.config()
+	violations := checkPasswordRules(cfg, candidate)
+	if cfg.CheckBreached && p.breached.Contains(candidate.Plain) {
+		violations = append(violations, xerror.PasswordBreached)
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	// Each comparison costs a bcrypt hash, so only passwords that pass every other rule get here
+	if cfg.HistorySize > 0 && candidate.UserID != "" {
+		reused, err := p.reused(ctx, cfg.HistorySize, candidate)
+		if err != nil {
+			return err
+		}
+		if reused {
+			return &PasswordPolicyError{Violations: []string{xerror.PasswordReused}}
+		}
+	}
+	return nil
+}
+
+func (p *passwordPolicy) Retire(ctx context.Context, userID, hash string) error {
+	if userID == "" || hash == "" {
+		return nil
+	}
+	// The current password takes one of the HistorySize places
+	keep := p.config().HistorySize - 1
+	if keep > 0 {
+		if _, err := p.historyRepo.Create(ctx, &entities.PasswordHistory{UserID: userID, PasswordHash: hash}); err != nil {
+			return err
+		}
+	}
+	return p.historyRepo.PruneByUser(ctx, userID, keep)
+}
+
+// reused compares the candidate with the current password and the retired ones that still count
+func (p *passwordPolicy) reused(ctx context.Context, historySize int, candidate PasswordCandidate) (bool, error) {
+	var hashes []string
+	if candidate.CurrentHash != "" {
+		hashes = append(hashes, candidate.CurrentHash)
+	}
+	retired, err := p.historyRepo.ListRecentByUser(ctx, candidate.UserID, historySize-len(hashes))
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range retired {
+		hashes = append(hashes, entry.PasswordHash)
+	}
+	for _, hash := range hashes {
+		if p.hasher.Compare(hash, candidate.Plain) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkPasswordRules applies the length, character class and personal information rules
+func checkPasswordRules(cfg PasswordPolicyConfig, candidate PasswordCandidate) []string {
+	var violations []string
+	plain := candidate.Plain
+
+	length := utf8.RuneCountInString(plain)
+	if length < cfg.MinLength {
+		violations = append(violations, xerror.PasswordTooShort)
+	}
+	if length > cfg.MaxLength || len(plain) > bcryptMaxBytes {
+		violations = append(violations, xerror.PasswordTooLong)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range plain {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	for _, class := range []struct {
+		required, present bool
+		code              string
+	}{
+		{cfg.RequireLowercase, lower, xerror.PasswordMissingLowercase},
+		{cfg.RequireUppercase, upper, xerror.PasswordMissingUppercase},
+		{cfg.RequireDigit, digit, xerror.PasswordMissingDigit},
+		{cfg.RequireSymbol, symbol, xerror.PasswordMissingSymbol},
+	} {
+		if class.required && !class.present {
+			violations = append(violations, class.code)
+		}
+	}
+
+	if cfg.ForbidPersonalInfo {
+		folded := foldForComparison(plain)
+		local, _, _ := strings.Cut(candidate.Email, "@")
+		if local = foldForComparison(local); utf8.RuneCountInString(local) >= minPersonalInfoLength && strings.Contains(folded, local) {
+			violations = append(violations, xerror.PasswordContainsEmail)
+		}
+		for _, part := range strings.Fields(candidate.FullName) {
+			if part = foldForComparison(part); utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(folded, part) {
+				violations = append(violations, xerror.PasswordContainsName)
+				break
+			}
+		}
+	}
+	return violations
+}
+
+// foldForComparison lowercases s and drops Vietnamese diacritics, so "Nguyễn" matches "nguyen"
+func foldForComparison(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ':
+			r = 'd'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// config reads "security.password_policy", keeping the default of every unset field
+func (p *passwordPolicy) config() PasswordPolicyConfig {
+	cfg := defaultPasswordPolicyConfig()
+	if err := p.configManager.UnmarshalKey("security.password_policy", &cfg); err != nil {
+		return defaultPasswordPolicyConfig()
+	}
+	if cfg.MinLength < 1 {
+		cfg.MinLength = 1
+	}
+	if cfg.MaxLength <= 0 || cfg.MaxLength > bcryptMaxBytes {
+		cfg.MaxLength = bcryptMaxBytes
+	}
+	if cfg.HistorySize < 0 {
+		cfg.HistorySize = 0
+	}
+	return cfg
+}
@@ -0,0 +1,255 @@
+package user
+
+import (
+	"context"
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AttemptScope names a throttled flow; failures in one scope never count against another
+type AttemptScope string
+
+const (
+	ScopeLogin          AttemptScope = "login"
+	ScopeForgotPassword AttemptScope = "forgot_password"
+	ScopeVerifyOTP      AttemptScope = "verify_otp"
+	ScopeVerifyMFA      AttemptScope = "verify_mfa"
+)
+
+var attemptScopes = []AttemptScope{ScopeLogin, ScopeForgotPassword, ScopeVerifyOTP, ScopeVerifyMFA}
+
+// ErrTooManyAttempts matches every *ThrottledError
+var ErrTooManyAttempts = errors.New("too many attempts, please try again later")
+
+// ThrottledError rejects an attempt while its account or IP backs off or is locked out
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool // the account reached the lockout threshold rather than a backoff step
+}
+
+func (e *ThrottledError) Error() string {
+	if e.Locked {
+		return "account temporarily locked after too many failed attempts"
+	}
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// Attempt identifies who is trying: the account (an email or a user ID, depending on the scope) and the client IP.
+// Either may be empty.
+type Attempt struct {
+	Scope   AttemptScope
+	Account string
+	IP      string
+}
+
+// ThrottleConfig is the "auth.throttle" config block
+type ThrottleConfig struct {
+	WindowSeconds     int `mapstructure:"window_seconds"`      // failures are forgotten this long after the first one
+	BackoffAfter      int `mapstructure:"backoff_after"`       // failures of an account before backoff starts
+	BackoffBaseMs     int `mapstructure:"backoff_base_ms"`     // first backoff, doubled on every further failure
+	BackoffMaxSeconds int `mapstructure:"backoff_max_seconds"` // longest backoff
+	LockoutAfter      int `mapstructure:"lockout_after"`       // failures of an account before it is locked
+	LockoutSeconds    int `mapstructure:"lockout_seconds"`
+	IPLockoutAfter    int `mapstructure:"ip_lockout_after"` // failures from one IP, across accounts, before it is locked
+}
+
+func defaultThrottleConfig() ThrottleConfig {
+	return ThrottleConfig{
+		WindowSeconds:     900,
+		BackoffAfter:      3,
+		BackoffBaseMs:     1000,
+		BackoffMaxSeconds: 300,
+		LockoutAfter:      10,
+		LockoutSeconds:    900,
+		IPLockoutAfter:    100,
+	}
+}
+
+// AttemptThrottle counts failed attempts per account and per IP in the state store. Once an account fails
+// BackoffAfter times each further attempt has to wait twice as long as the previous one; at LockoutAfter
+// failures it is locked for LockoutSeconds. An IP is only locked, at the much higher IPLockoutAfter, so
+// users behind one NAT do not slow each other down.
+//
+// Cache failures are logged and let the attempt through: the throttle never takes login down with it.
+type AttemptThrottle interface {
+	// Check returns a *ThrottledError while the account or the IP is backing off or locked
+	Check(ctx context.Context, attempt Attempt) error
+	// Fail records a failed attempt and reports whether it locked the account
+	Fail(ctx context.Context, attempt Attempt) (locked bool)
+	// Succeed forgets the failures of the account; the IP counter only expires
+	Succeed(ctx context.Context, attempt Attempt)
+	// Unlock forgets the failures and lockouts of the accounts in every scope
+	Unlock(ctx context.Context, accounts ...string) error
+}
+
+type attemptThrottle struct {
+	cache         caching.StateStore
+	configManager config.Manager
+	now           func() time.Time
+}
+
+// NewAttemptThrottle creates a new instance of AttemptThrottle
+func NewAttemptThrottle(cache caching.StateStore, configManager config.Manager) AttemptThrottle {
+	return &attemptThrottle{
+		cache:         cache,
+		configManager: configManager,
+		now:           time.Now,
+	}
+}
+
+const (
+	blockKindBackoff = "backoff"
+	blockKindLock    = "lock"
+)
+
+func (t *attemptThrottle) Check(ctx context.Context, attempt Attempt) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	for _, prefix := range attemptPrefixes(attempt) {
+		raw, err := t.cache.GetString(ctx, prefix+":block")
+		if errors.Is(err, caching.ErrCacheMiss) {
+			continue
+		}
+		if err != nil {
+			ctxLogger.Warnf("Failed to read attempt throttle %s: %v", prefix, err)
+			continue
+		}
+		kind, untilMs, _ := strings.Cut(raw, ":")
+		until, err := strconv.ParseInt(untilMs, 10, 64)
+		if err != nil {
+			continue
+		}
+		retryAfter := time.UnixMilli(until).Sub(t.now())
+		if retryAfter <= 0 {
+			continue
+		}
+		return &ThrottledError{RetryAfter: retryAfter, Locked: kind == blockKindLock}
+	}
+	return nil
+}
+
+func (t *attemptThrottle) Fail(ctx context.Context, attempt Attempt) bool {
+	ctxLogger := logger.NewLogger(ctx)
+	cfg := t.config()
+	window := time.Duration(cfg.WindowSeconds) * time.Second
+	lockout := time.Duration(cfg.LockoutSeconds) * time.Second
+
+	locked := false
+	if attempt.Account != "" {
+		prefix := attemptPrefix(attempt.Scope, "acct", attempt.Account)
+		failures, err := t.cache.Incr(ctx, prefix+":fails", 1, window)
+		switch {
+		case err != nil:
+			ctxLogger.Warnf("Failed to count attempt %s: %v", prefix, err)
+		case failures >= int64(cfg.LockoutAfter):
+			t.block(ctx, prefix, blockKindLock, lockout)
+			// Only the failure crossing the threshold reports the lock, so the owner is told once
+			locked = failures == int64(cfg.LockoutAfter)
+		case failures >= int64(cfg.BackoffAfter):
+			t.block(ctx, prefix, blockKindBackoff, backoff(cfg, failures))
+		}
+	}
+	if attempt.IP != "" {
+		prefix := attemptPrefix(attempt.Scope, "ip", attempt.IP)
+		failures, err := t.cache.Incr(ctx, prefix+":fails", 1, window)
+		switch {
+		case err != nil:
+			ctxLogger.Warnf("Failed to count attempt %s: %v", prefix, err)
+		case failures >= int64(cfg.IPLockoutAfter):
+			t.block(ctx, prefix, blockKindLock, lockout)
+		}
+	}
+	return locked
+}
+
+func (t *attemptThrottle) Succeed(ctx context.Context, attempt Attempt) {
+	if attempt.Account == "" {
+		return
+	}
+	prefix := attemptPrefix(attempt.Scope, "acct", attempt.Account)
+	if err := t.cache.Delete(ctx, prefix+":fails", prefix+":block"); err != nil {
+		logger.NewLogger(ctx).Warnf("Failed to reset attempt throttle %s: %v", prefix, err)
+	}
+}
+
+func (t *attemptThrottle) Unlock(ctx context.Context, accounts ...string) error {
+	var keys []string
+	for _, scope := range attemptScopes {
+		for _, account := range accounts {
+			if account == "" {
+				continue
+			}
+			prefix := attemptPrefix(scope, "acct", account)
+			keys = append(keys, prefix+":fails", prefix+":block")
+		}
+	}
+	return t.cache.Delete(ctx, keys...)
+}
+
+func (t *attemptThrottle) block(ctx context.Context, prefix, kind string, duration time.Duration) {
+	until := t.now().Add(duration).UnixMilli()
+	if err := t.cache.SetString(ctx, prefix+":block", fmt.Sprintf("%s:%d", kind, until), duration); err != nil {
+		logger.NewLogger(ctx).Warnf("Failed to block attempts %s: %v", prefix, err)
+	}
+}
+
+// config reads "auth.throttle", keeping the default of every unset or non-positive field
+func (t *attemptThrottle) config() ThrottleConfig {
+	cfg := defaultThrottleConfig()
+	read := ThrottleConfig{}
+	if err := t.configManager.UnmarshalKey("auth.throttle", &read); err != nil {
+		return cfg
+	}
+	for _, field := range []struct{ dst, src *int }{
+		{&cfg.WindowSeconds, &read.WindowSeconds},
+		{&cfg.BackoffAfter, &read.BackoffAfter},
+		{&cfg.BackoffBaseMs, &read.BackoffBaseMs},
+		{&cfg.BackoffMaxSeconds, &read.BackoffMaxSeconds},
+		{&cfg.LockoutAfter, &read.LockoutAfter},
+		{&cfg.LockoutSeconds, &read.LockoutSeconds},
+		{&cfg.IPLockoutAfter, &read.IPLockoutAfter},
+	} {
+		if *field.src > 0 {
+			*field.dst = *field.src
+		}
+	}
+	return cfg
+}
+
+// backoff doubles from BackoffBaseMs at the BackoffAfter-th failure, up to BackoffMaxSeconds
+func backoff(cfg ThrottleConfig, failures int64) time.Duration {
+	maximum := time.Duration(cfg.BackoffMaxSeconds) * time.Second
+	delay := time.Duration(cfg.BackoffBaseMs) * time.Millisecond
+	for step := failures - int64(cfg.BackoffAfter); step > 0; step-- {
+		delay *= 2
+		if delay >= maximum {
+			return maximum
+		}
+	}
+	return min(delay, maximum)
+}
+
+func attemptPrefixes(attempt Attempt) []string {
+	var prefixes []string
+	if attempt.Account != "" {
+		prefixes = append(prefixes, attemptPrefix(attempt.Scope, "acct", attempt.Account))
+	}
+	if attempt.IP != "" {
+		prefixes = append(prefixes, attemptPrefix(attempt.Scope, "ip", attempt.IP))
+	}
+	return prefixes
+}
+
+func attemptPrefix(scope AttemptScope, kind, subject string) string {
+	return fmt.Sprintf("auth:throttle:%s:%s:%s", scope, kind, strings.ToLower(strings.TrimSpace(subject)))
+}
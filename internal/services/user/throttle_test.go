@@ -0,0 +1,204 @@
+package user
+
+import (
+	"context"
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// mapStore is a StateStore without expiry; the throttle compares block deadlines with its own clock
+type mapStore struct {
+	caching.StateStore
+	values map[string]string
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{values: make(map[string]string)}
+}
+
+func (s *mapStore) GetString(ctx context.Context, key string) (string, error) {
+	value, ok := s.values[key]
+	if !ok {
+		return "", caching.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (s *mapStore) SetString(ctx context.Context, key, value string, ttl time.Duration) error {
+	s.values[key] = value
+	return nil
+}
+
+func (s *mapStore) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+	return nil
+}
+
+func (s *mapStore) Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	current, _ := strconv.ParseInt(s.values[key], 10, 64)
+	current += delta
+	s.values[key] = strconv.FormatInt(current, 10)
+	return current, nil
+}
+
+// throttleConfigManager serves one "auth.throttle" block
+type throttleConfigManager struct {
+	config.Manager
+	throttle ThrottleConfig
+}
+
+func (m *throttleConfigManager) UnmarshalKey(key string, rawVal interface{}) error {
+	if key != "auth.throttle" {
+		return fmt.Errorf("unexpected key %s", key)
+	}
+	*rawVal.(*ThrottleConfig) = m.throttle
+	return nil
+}
+
+func TestBackoff(t *testing.T) {
+	cfg := defaultThrottleConfig()
+
+	tests := []struct {
+		name     string
+		cfg      ThrottleConfig
+		failures int64
+		want     time.Duration
+	}{
+		{"first backoff", cfg, 3, time.Second},
+		{"doubles", cfg, 4, 2 * time.Second},
+		{"doubles again", cfg, 5, 4 * time.Second},
+		{"below the cap", cfg, 11, 256 * time.Second},
+		{"capped", cfg, 12, 300 * time.Second},
+		{"stays capped", cfg, 1000, 300 * time.Second},
+		{"base above the cap", ThrottleConfig{BackoffAfter: 1, BackoffBaseMs: 10000, BackoffMaxSeconds: 5}, 1, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backoff(tt.cfg, tt.failures); got != tt.want {
+				t.Errorf("backoff(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttemptThrottle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1790000000, 0)
+	newThrottle := func(cfg ThrottleConfig) *attemptThrottle {
+		return &attemptThrottle{
+			cache:         newMapStore(),
+			configManager: &throttleConfigManager{throttle: cfg},
+			now:           func() time.Time { return now },
+		}
+	}
+	login := Attempt{Scope: ScopeLogin, Account: "An@Example.com", IP: "203.0.113.7"}
+
+	t.Run("backs off, then locks the account once", func(t *testing.T) {
+		throttle := newThrottle(ThrottleConfig{})
+
+		tests := []struct {
+			failures    int
+			wantLocked  bool
+			wantBlocked bool
+			wantRetry   time.Duration
+			wantLockout bool
+		}{
+			{1, false, false, 0, false},
+			{2, false, false, 0, false},
+			{3, false, true, time.Second, false},
+			{4, false, true, 2 * time.Second, false},
+			{9, false, true, 64 * time.Second, false},
+			{10, true, true, 900 * time.Second, true},
+			{11, false, true, 900 * time.Second, true},
+		}
+
+		failures := 0
+		for _, tt := range tests {
+			var locked bool
+			for failures < tt.failures {
+				locked = throttle.Fail(ctx, login)
+				failures++
+			}
+			if locked != tt.wantLocked {
+				t.Errorf("failure %d: locked = %v, want %v", tt.failures, locked, tt.wantLocked)
+			}
+
+			err := throttle.Check(ctx, login)
+			if !tt.wantBlocked {
+				if err != nil {
+					t.Errorf("failure %d: Check = %v, want nil", tt.failures, err)
+				}
+				continue
+			}
+			var throttled *ThrottledError
+			if !errors.As(err, &throttled) || !errors.Is(err, ErrTooManyAttempts) {
+				t.Fatalf("failure %d: Check = %v, want a ThrottledError", tt.failures, err)
+			}
+			if throttled.RetryAfter != tt.wantRetry || throttled.Locked != tt.wantLockout {
+				t.Errorf("failure %d: Check = (%v, locked %v), want (%v, locked %v)",
+					tt.failures, throttled.RetryAfter, throttled.Locked, tt.wantRetry, tt.wantLockout)
+			}
+		}
+	})
+
+	t.Run("a block ends with its deadline", func(t *testing.T) {
+		throttle := newThrottle(ThrottleConfig{BackoffAfter: 1})
+		throttle.Fail(ctx, login)
+		if err := throttle.Check(ctx, login); err == nil {
+			t.Fatal("Check = nil right after the failure")
+		}
+		throttle.now = func() time.Time { return now.Add(time.Second) }
+		if err := throttle.Check(ctx, login); err != nil {
+			t.Errorf("Check after the backoff = %v, want nil", err)
+		}
+	})
+
+	t.Run("success and unlock forget the account", func(t *testing.T) {
+		throttle := newThrottle(ThrottleConfig{BackoffAfter: 1, IPLockoutAfter: 1000})
+		throttle.Fail(ctx, login)
+		throttle.Succeed(ctx, login)
+		if err := throttle.Check(ctx, login); err != nil {
+			t.Errorf("Check after Succeed = %v, want nil", err)
+		}
+
+		throttle.Fail(ctx, login)
+		if err := throttle.Unlock(ctx, "an@example.com"); err != nil {
+			t.Fatal(err)
+		}
+		if err := throttle.Check(ctx, login); err != nil {
+			t.Errorf("Check after Unlock = %v, want nil", err)
+		}
+	})
+
+	t.Run("scopes are counted apart", func(t *testing.T) {
+		throttle := newThrottle(ThrottleConfig{BackoffAfter: 1})
+		throttle.Fail(ctx, login)
+		otp := Attempt{Scope: ScopeVerifyOTP, Account: login.Account}
+		if err := throttle.Check(ctx, otp); err != nil {
+			t.Errorf("Check in another scope = %v, want nil", err)
+		}
+	})
+
+	t.Run("an IP is locked across accounts", func(t *testing.T) {
+		throttle := newThrottle(ThrottleConfig{BackoffAfter: 100, LockoutAfter: 100, IPLockoutAfter: 3})
+		for i := 0; i < 3; i++ {
+			throttle.Fail(ctx, Attempt{Scope: ScopeLogin, Account: fmt.Sprintf("user%d@example.com", i), IP: login.IP})
+		}
+		err := throttle.Check(ctx, Attempt{Scope: ScopeLogin, Account: "fresh@example.com", IP: login.IP})
+		var throttled *ThrottledError
+		if !errors.As(err, &throttled) || !throttled.Locked {
+			t.Errorf("Check from the locked IP = %v, want a lock", err)
+		}
+		if err := throttle.Check(ctx, Attempt{Scope: ScopeLogin, Account: "fresh@example.com", IP: "198.51.100.1"}); err != nil {
+			t.Errorf("Check from another IP = %v, want nil", err)
+		}
+	})
+}
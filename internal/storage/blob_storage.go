@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrObjectNotFound is returned when the requested key does not exist in the storage backend
+var ErrObjectNotFound = errors.New("storage: object not found")
+
+// BlobStorage abstracts where uploaded files are kept (local disk, S3-compatible bucket, ...)
+type BlobStorage interface {
+	// Driver returns the name of the backend, stored alongside the object key
+	Driver() string
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
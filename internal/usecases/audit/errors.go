@@ -0,0 +1,8 @@
+package audit
+
+import "errors"
+
+var (
+	ErrMaterialNotFound = errors.New("material not found")
+	ErrForbidden        = errors.New("you are not allowed to audit this material")
+)
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"errors"
+)
+
+// ListMaterialAnalysesInput represents the input for listing the audit history of a material
+type ListMaterialAnalysesInput struct {
+	MaterialID string
+}
+
+// ListMaterialAnalysesOutput represents the audit history, newest first
+type ListMaterialAnalysesOutput struct {
+	Material *entities.Material
+	Results  []*entities.AIAnalysisResult
+}
+
+// ListMaterialAnalysesUseCase defines the interface for listing AI analyses of a material
+type ListMaterialAnalysesUseCase interface {
+	Execute(ctx context.Context, input ListMaterialAnalysesInput) (*ListMaterialAnalysesOutput, error)
+}
+
+type listMaterialAnalysesUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	analysisRepo repointerface.AIAnalysisResultRepository
+}
+
+// NewListMaterialAnalysesUseCase creates a new instance of ListMaterialAnalysesUseCase
+func NewListMaterialAnalysesUseCase(
+	materialRepo repointerface.MaterialRepository,
+	analysisRepo repointerface.AIAnalysisResultRepository,
+) ListMaterialAnalysesUseCase {
+	return &listMaterialAnalysesUseCase{
+		materialRepo: materialRepo,
+		analysisRepo: analysisRepo,
+	}
+}
+
+func (uc *listMaterialAnalysesUseCase) Execute(ctx context.Context, input ListMaterialAnalysesInput) (*ListMaterialAnalysesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.MaterialID == "" {
+		return nil, errors.New("material ID is required")
+	}
+	material, err := uc.materialRepo.GetByID(ctx, input.MaterialID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+
+	results, err := uc.analysisRepo.ListByMaterialID(ctx, material.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list analysis results: %v", err)
+		return nil, err
+	}
+
+	return &ListMaterialAnalysesOutput{Material: material, Results: results}, nil
+}
@@ -0,0 +1,198 @@
+package audit
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/ai"
+	"doan/internal/services/extraction"
+	"doan/internal/storage"
+	"doan/pkg/logger"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// ProcessMaterialAuditInput represents the input for auditing one material
+type ProcessMaterialAuditInput struct {
+	MaterialID string
+}
+
+// ProcessMaterialAuditOutput represents the stored analysis
+type ProcessMaterialAuditOutput struct {
+	Result *entities.AIAnalysisResult
+}
+
+// ProcessMaterialAuditUseCase extracts the text of a material, stores it on the material,
+// runs the ContentAnalyzer and stores the verdict
+type ProcessMaterialAuditUseCase interface {
+	Execute(ctx context.Context, input ProcessMaterialAuditInput) (*ProcessMaterialAuditOutput, error)
+}
+
+type processMaterialAuditUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	analysisRepo repointerface.AIAnalysisResultRepository
+	blobStorage  storage.BlobStorage
+	analyzer     ai.ContentAnalyzer
+	extractor    extraction.TextExtractor
+}
+
+// NewProcessMaterialAuditUseCase creates a new instance of ProcessMaterialAuditUseCase
+func NewProcessMaterialAuditUseCase(
+	materialRepo repointerface.MaterialRepository,
+	analysisRepo repointerface.AIAnalysisResultRepository,
+	blobStorage storage.BlobStorage,
+	analyzer ai.ContentAnalyzer,
+	extractor extraction.TextExtractor,
+) ProcessMaterialAuditUseCase {
+	return &processMaterialAuditUseCase{
+		materialRepo: materialRepo,
+		analysisRepo: analysisRepo,
+		blobStorage:  blobStorage,
+		analyzer:     analyzer,
+		extractor:    extractor,
+	}
+}
+
+func (uc *processMaterialAuditUseCase) Execute(ctx context.Context, input ProcessMaterialAuditInput) (*ProcessMaterialAuditOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.MaterialID == "" {
+		return nil, errors.New("material ID is required")
+	}
+	material, err := uc.materialRepo.GetByID(ctx, input.MaterialID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+
+	if err := uc.materialRepo.Update(ctx, material.ID, map[string]interface{}{
+		"audit_status": entities.MaterialAuditProcessing,
+	}); err != nil {
+		ctxLogger.Errorf("Failed to mark material as processing: %v", err)
+		return nil, err
+	}
+
+	started := time.Now()
+	text, err := uc.readText(ctx, material)
+	if err != nil {
+		uc.markFailed(ctx, material.ID, err)
+		return nil, err
+	}
+
+	var analysis *ai.AnalysisResult
+	if strings.TrimSpace(text) == "" {
+		analysis = noTextAnalysis(uc.analyzer.Name())
+	} else {
+		analysis, err = uc.analyzer.Analyze(ctx, ai.AnalysisInput{
+			MaterialID: material.ID,
+			Title:      material.Title,
+			FileName:   material.FileName,
+			MimeType:   material.MimeType,
+			Text:       text,
+		})
+		if err != nil {
+			ctxLogger.Errorf("Failed to analyze material %s: %v", material.ID, err)
+			uc.markFailed(ctx, material.ID, err)
+			return nil, err
+		}
+	}
+
+	result, err := uc.analysisRepo.Create(ctx, &entities.AIAnalysisResult{
+		MaterialID: material.ID,
+		Analyzer:   analysis.Analyzer,
+		Model:      analysis.Model,
+		Label:      analysis.Label,
+		Score:      analysis.Score,
+		Summary:    analysis.Summary,
+		Reasons:    analysis.Reasons,
+		TextLength: len([]rune(text)),
+		DurationMs: time.Since(started).Milliseconds(),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to save analysis result: %v", err)
+		uc.markFailed(ctx, material.ID, err)
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := uc.materialRepo.Update(ctx, material.ID, map[string]interface{}{
+		"audit_status": entities.MaterialAuditCompleted,
+		"ai_label":     analysis.Label,
+		"audited_at":   now,
+		"audit_error":  "",
+	}); err != nil {
+		ctxLogger.Errorf("Failed to update material audit status: %v", err)
+		return nil, err
+	}
+
+	return &ProcessMaterialAuditOutput{Result: result}, nil
+}
+
+// readText extracts and stores the text of a material. Unreadable documents are not an error:
+// they get an empty text with the reason in extraction_errors and go to manual review.
+func (uc *processMaterialAuditUseCase) readText(ctx context.Context, material *entities.Material) (string, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	reader, err := uc.blobStorage.Get(ctx, material.StorageKey)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := uc.extractor.Extract(ctx, material.MimeType, content)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		ctxLogger.Warnf("Failed to extract text of material %s: %v", material.ID, err)
+		result = &extraction.ExtractionResult{
+			PageErrors: []entities.PageError{{Page: 0, Message: err.Error()}},
+		}
+	}
+
+	now := time.Now()
+	if err := uc.materialRepo.Update(ctx, material.ID, map[string]interface{}{
+		"extracted_text":    result.Text,
+		"extracted_pages":   result.Pages,
+		"extraction_errors": entities.PageErrors(result.PageErrors),
+		"extracted_at":      now,
+	}); err != nil {
+		ctxLogger.Errorf("Failed to store extracted text: %v", err)
+		return "", err
+	}
+	return result.Text, nil
+}
+
+func (uc *processMaterialAuditUseCase) markFailed(ctx context.Context, materialID string, cause error) {
+	ctxLogger := logger.NewLogger(ctx)
+	if err := uc.materialRepo.Update(ctx, materialID, map[string]interface{}{
+		"audit_status": entities.MaterialAuditFailed,
+		"audit_error":  cause.Error(),
+	}); err != nil {
+		ctxLogger.Errorf("Failed to mark material %s as failed: %v", materialID, err)
+	}
+}
+
+// noTextAnalysis flags materials whose text cannot be read so a human reviews them
+func noTextAnalysis(analyzer string) *ai.AnalysisResult {
+	return &ai.AnalysisResult{
+		Analyzer: analyzer,
+		Label:    entities.AILabelWarning,
+		Summary:  "Không trích xuất được nội dung văn bản, cần kiểm duyệt thủ công",
+		Reasons: []entities.AnalysisReason{{
+			Code:    "NO_TEXT",
+			Label:   entities.AILabelWarning,
+			Message: "Không trích xuất được nội dung văn bản từ tài liệu",
+		}},
+	}
+}
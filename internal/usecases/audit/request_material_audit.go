@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/ai"
+	"doan/pkg/authz"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"errors"
+)
+
+// RequestMaterialAuditInput represents the input for (re)queueing a material audit
+type RequestMaterialAuditInput struct {
+	MaterialID string
+	Requester  authz.Principal
+}
+
+// RequestMaterialAuditOutput represents the output after queueing
+type RequestMaterialAuditOutput struct {
+	Message string
+}
+
+// RequestMaterialAuditUseCase puts a material back on the audit queue
+type RequestMaterialAuditUseCase interface {
+	Execute(ctx context.Context, input RequestMaterialAuditInput) (*RequestMaterialAuditOutput, error)
+}
+
+type requestMaterialAuditUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	publisher    ai.AuditPublisher
+}
+
+// NewRequestMaterialAuditUseCase creates a new instance of RequestMaterialAuditUseCase
+func NewRequestMaterialAuditUseCase(
+	materialRepo repointerface.MaterialRepository,
+	publisher ai.AuditPublisher,
+) RequestMaterialAuditUseCase {
+	return &requestMaterialAuditUseCase{
+		materialRepo: materialRepo,
+		publisher:    publisher,
+	}
+}
+
+func (uc *requestMaterialAuditUseCase) Execute(ctx context.Context, input RequestMaterialAuditInput) (*RequestMaterialAuditOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.MaterialID == "" {
+		return nil, errors.New("material ID is required")
+	}
+	material, err := uc.materialRepo.GetByID(ctx, input.MaterialID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+	if err := input.Requester.RequireOwnerOr(material.UploadedByID, constants.PermissionMaterialManage); err != nil {
+		return nil, ErrForbidden
+	}
+	if material.AuditStatus == entities.MaterialAuditQueued || material.AuditStatus == entities.MaterialAuditProcessing {
+		return &RequestMaterialAuditOutput{Message: "Material is already waiting for audit"}, nil
+	}
+
+	// Mark as queued first so a fast consumer's result is not overwritten
+	if err := uc.materialRepo.Update(ctx, material.ID, map[string]interface{}{
+		"audit_status": entities.MaterialAuditQueued,
+	}); err != nil {
+		ctxLogger.Errorf("Failed to update material audit status: %v", err)
+		return nil, err
+	}
+	if err := uc.publisher.PublishMaterialAudit(ctx, material.ID); err != nil {
+		ctxLogger.Errorf("Failed to queue material audit: %v", err)
+		_ = uc.materialRepo.Update(ctx, material.ID, map[string]interface{}{
+			"audit_status": material.AuditStatus,
+		})
+		return nil, err
+	}
+
+	return &RequestMaterialAuditOutput{Message: "Material queued for audit"}, nil
+}
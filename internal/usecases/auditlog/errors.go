@@ -0,0 +1,8 @@
+package auditlog
+
+import "errors"
+
+var (
+	ErrAuditLogNotFound = errors.New("audit log entry not found")
+	ErrInvalidPeriod    = errors.New("from must be before to")
+)
@@ -0,0 +1,45 @@
+package auditlog
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetAuditLogInput represents the entry to show
+type GetAuditLogInput struct {
+	ID string
+}
+
+// GetAuditLogUseCase shows one audit log entry with its changes and chain position
+type GetAuditLogUseCase interface {
+	Execute(ctx context.Context, input GetAuditLogInput) (*entities.AuditLog, error)
+}
+
+type getAuditLogUseCase struct {
+	auditLogRepo repointerface.AuditLogRepository
+}
+
+// NewGetAuditLogUseCase creates a new instance of GetAuditLogUseCase
+func NewGetAuditLogUseCase(auditLogRepo repointerface.AuditLogRepository) GetAuditLogUseCase {
+	return &getAuditLogUseCase{auditLogRepo: auditLogRepo}
+}
+
+func (uc *getAuditLogUseCase) Execute(ctx context.Context, input GetAuditLogInput) (*entities.AuditLog, error) {
+	condition := repositories.NewCommonCondition()
+	condition.SetPaging(1, 1)
+	condition.SetPreload([]string{"Actor"})
+	condition.AddCondition("id", input.ID, repositories.Equal)
+
+	page, err := uc.auditLogRepo.GetByCondition(ctx, condition)
+	if err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to get audit log %s: %v", input.ID, err)
+		return nil, err
+	}
+	if page == nil || len(page.Data) == 0 {
+		return nil, ErrAuditLogNotFound
+	}
+	return page.Data[0], nil
+}
@@ -0,0 +1,99 @@
+package auditlog
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+	"time"
+)
+
+// ListAuditLogsInput represents the filters of the audit log; zero values do not filter
+type ListAuditLogsInput struct {
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	TraceID    string
+	From       time.Time // inclusive
+	To         time.Time // exclusive
+	Page       int
+	Limit      int
+}
+
+// ListAuditLogsOutput represents one page of entries, newest first
+type ListAuditLogsOutput struct {
+	Logs       []*entities.AuditLog
+	Pagination *repositories.Meta
+}
+
+// ListAuditLogsUseCase searches the audit log
+type ListAuditLogsUseCase interface {
+	Execute(ctx context.Context, input ListAuditLogsInput) (*ListAuditLogsOutput, error)
+}
+
+type listAuditLogsUseCase struct {
+	auditLogRepo repointerface.AuditLogRepository
+}
+
+// NewListAuditLogsUseCase creates a new instance of ListAuditLogsUseCase
+func NewListAuditLogsUseCase(auditLogRepo repointerface.AuditLogRepository) ListAuditLogsUseCase {
+	return &listAuditLogsUseCase{auditLogRepo: auditLogRepo}
+}
+
+func (uc *listAuditLogsUseCase) Execute(ctx context.Context, input ListAuditLogsInput) (*ListAuditLogsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, ErrInvalidPeriod
+	}
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 {
+		input.Limit = 20
+	}
+	if input.Limit > 100 {
+		input.Limit = 100
+	}
+
+	condition := repositories.NewCommonCondition()
+	condition.SetPaging(uint64(input.Limit), uint64(input.Page))
+	condition.SetPreload([]string{"Actor"})
+	condition.AddSorting("created_at", repositories.Desc)
+
+	if input.ActorID != "" {
+		condition.AddCondition("actor_id", input.ActorID, repositories.Equal)
+	}
+	if input.Action != "" {
+		condition.AddCondition("action", strings.ToUpper(input.Action), repositories.Equal)
+	}
+	if input.EntityType != "" {
+		condition.AddCondition("entity_type", strings.ToUpper(input.EntityType), repositories.Equal)
+	}
+	if input.EntityID != "" {
+		condition.AddCondition("entity_id", input.EntityID, repositories.Equal)
+	}
+	if input.TraceID != "" {
+		condition.AddCondition("trace_id", input.TraceID, repositories.Equal)
+	}
+	if !input.From.IsZero() {
+		condition.AddCondition("created_at", input.From, repositories.GreaterThanOrEqual)
+	}
+	if !input.To.IsZero() {
+		condition.AddCondition("created_at", input.To, repositories.LessThan)
+	}
+
+	page, err := uc.auditLogRepo.GetByCondition(ctx, condition)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list audit logs: %v", err)
+		return nil, err
+	}
+	if page == nil {
+		return &ListAuditLogsOutput{Logs: []*entities.AuditLog{}, Pagination: &repositories.Meta{CurrentPage: 1, TotalPages: 1}}, nil
+	}
+
+	return &ListAuditLogsOutput{Logs: page.Data, Pagination: &page.Meta}, nil
+}
@@ -0,0 +1,44 @@
+package auditlog
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// sealBatchSize is the number of entries sealed per transaction
+const sealBatchSize = 500
+
+// SealAuditLogsOutput counts the entries added to the hash chain
+type SealAuditLogsOutput struct {
+	Sealed int
+}
+
+// SealAuditLogsUseCase adds every entry written since the last run to the hash chain
+type SealAuditLogsUseCase interface {
+	Execute(ctx context.Context) (*SealAuditLogsOutput, error)
+}
+
+type sealAuditLogsUseCase struct {
+	auditLogRepo repointerface.AuditLogRepository
+}
+
+// NewSealAuditLogsUseCase creates a new instance of SealAuditLogsUseCase
+func NewSealAuditLogsUseCase(auditLogRepo repointerface.AuditLogRepository) SealAuditLogsUseCase {
+	return &sealAuditLogsUseCase{auditLogRepo: auditLogRepo}
+}
+
+func (uc *sealAuditLogsUseCase) Execute(ctx context.Context) (*SealAuditLogsOutput, error) {
+	output := &SealAuditLogsOutput{}
+	for {
+		sealed, err := uc.auditLogRepo.SealPending(ctx, sealBatchSize)
+		if err != nil {
+			logger.NewLogger(ctx).Errorf("Failed to seal audit logs: %v", err)
+			return output, err
+		}
+		output.Sealed += sealed
+		if sealed < sealBatchSize || ctx.Err() != nil {
+			return output, nil
+		}
+	}
+}
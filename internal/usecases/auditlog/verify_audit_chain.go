@@ -0,0 +1,93 @@
+package auditlog
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"fmt"
+)
+
+// verifyBatchSize is the number of entries read at a time while walking the chain
+const verifyBatchSize = 1000
+
+// VerifyAuditChainOutput is the result of walking the whole chain. Head is the last sealed entry: kept
+// outside the database, it also proves later that no entries were cut off the end.
+type VerifyAuditChainOutput struct {
+	Checked      int64
+	Valid        bool
+	BrokenAt     *int64 // sequence of the first entry failing, nil when valid
+	Reason       string
+	HeadSequence int64
+	HeadHash     string
+	Unsealed     int64 // entries not in the chain yet
+}
+
+// VerifyAuditChainUseCase recomputes every hash of the audit log chain and checks the links between them
+type VerifyAuditChainUseCase interface {
+	Execute(ctx context.Context) (*VerifyAuditChainOutput, error)
+}
+
+type verifyAuditChainUseCase struct {
+	auditLogRepo repointerface.AuditLogRepository
+}
+
+// NewVerifyAuditChainUseCase creates a new instance of VerifyAuditChainUseCase
+func NewVerifyAuditChainUseCase(auditLogRepo repointerface.AuditLogRepository) VerifyAuditChainUseCase {
+	return &verifyAuditChainUseCase{auditLogRepo: auditLogRepo}
+}
+
+func (uc *verifyAuditChainUseCase) Execute(ctx context.Context) (*VerifyAuditChainOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+	output := &VerifyAuditChainOutput{Valid: true}
+
+	var prev *entities.AuditLog
+	for {
+		batch, err := uc.auditLogRepo.ListSealed(ctx, output.HeadSequence, verifyBatchSize)
+		if err != nil {
+			ctxLogger.Errorf("Failed to read the audit log chain after %d: %v", output.HeadSequence, err)
+			return nil, err
+		}
+		for _, entry := range batch {
+			if reason := brokenLink(prev, entry); reason != "" {
+				sequence := *entry.Sequence
+				output.Valid, output.BrokenAt, output.Reason = false, &sequence, reason
+				ctxLogger.Errorf("Audit log chain broken at %d: %s", sequence, reason)
+				return uc.withUnsealed(ctx, output)
+			}
+			output.Checked++
+			output.HeadSequence, output.HeadHash = *entry.Sequence, entry.Hash
+			prev = entry
+		}
+		if len(batch) < verifyBatchSize {
+			break
+		}
+	}
+	return uc.withUnsealed(ctx, output)
+}
+
+func (uc *verifyAuditChainUseCase) withUnsealed(ctx context.Context, output *VerifyAuditChainOutput) (*VerifyAuditChainOutput, error) {
+	unsealed, err := uc.auditLogRepo.CountUnsealed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output.Unsealed = unsealed
+	return output, nil
+}
+
+// brokenLink tells why entry does not follow prev in the chain, empty when it does
+func brokenLink(prev, entry *entities.AuditLog) string {
+	expected, prevHash := int64(1), ""
+	if prev != nil {
+		expected, prevHash = *prev.Sequence+1, prev.Hash
+	}
+	switch {
+	case *entry.Sequence != expected:
+		return fmt.Sprintf("entry %d missing", expected)
+	case entry.PrevHash != prevHash:
+		return "previous hash does not match"
+	case entry.Hash != entry.ChainHash():
+		return "entry was modified"
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package auditlog
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// sealedChain returns n entries sealed the way the repository seals them
+func sealedChain(n int) []*entities.AuditLog {
+	chain := make([]*entities.AuditLog, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		sequence := int64(i)
+		entry := &entities.AuditLog{
+			Action:     entities.AuditActionUpdate,
+			EntityType: "ROOM",
+			EntityID:   fmt.Sprint(i),
+			Changes:    entities.JSONMap{"capacity": map[string]interface{}{"old": float64(i), "new": float64(i + 1)}},
+			Sequence:   &sequence,
+			PrevHash:   prevHash,
+			CreatedAt:  time.Date(2026, 10, 19, 8, 0, i, 0, time.UTC),
+		}
+		entry.Hash = entry.ChainHash()
+		prevHash = entry.Hash
+		chain = append(chain, entry)
+	}
+	return chain
+}
+
+func TestBrokenLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(chain []*entities.AuditLog)
+		index  int // entry checked against the one before it
+		want   string
+	}{
+		{"first entry", nil, 0, ""},
+		{"linked entry", nil, 2, ""},
+		{"chain not starting at one", func(c []*entities.AuditLog) { s := int64(2); c[0].Sequence = &s }, 0, "entry 1 missing"},
+		{"first entry with a previous hash", func(c []*entities.AuditLog) { c[0].PrevHash = c[1].Hash }, 0, "previous hash does not match"},
+		{"gap in the sequence", func(c []*entities.AuditLog) { s := int64(4); c[2].Sequence = &s }, 2, "entry 3 missing"},
+		{"previous hash rewritten", func(c []*entities.AuditLog) { c[2].PrevHash = c[0].Hash }, 2, "previous hash does not match"},
+		{"field modified", func(c []*entities.AuditLog) { c[2].EntityID = "99" }, 2, "entry was modified"},
+		{"changes modified", func(c []*entities.AuditLog) { c[2].Changes = entities.JSONMap{} }, 2, "entry was modified"},
+		{"hash replaced", func(c []*entities.AuditLog) { c[2].Hash = c[1].Hash }, 2, "entry was modified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := sealedChain(4)
+			if tt.mutate != nil {
+				tt.mutate(chain)
+			}
+			var prev *entities.AuditLog
+			if tt.index > 0 {
+				prev = chain[tt.index-1]
+			}
+			if got := brokenLink(prev, chain[tt.index]); got != tt.want {
+				t.Errorf("brokenLink = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// chainRepository serves a chain to ListSealed in the order the repository does
+type chainRepository struct {
+	repointerface.AuditLogRepository
+	chain    []*entities.AuditLog
+	unsealed int64
+}
+
+func (r *chainRepository) ListSealed(ctx context.Context, afterSequence int64, limit int) ([]*entities.AuditLog, error) {
+	var batch []*entities.AuditLog
+	for _, entry := range r.chain {
+		if *entry.Sequence > afterSequence && len(batch) < limit {
+			batch = append(batch, entry)
+		}
+	}
+	return batch, nil
+}
+
+func (r *chainRepository) CountUnsealed(ctx context.Context) (int64, error) {
+	return r.unsealed, nil
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	ctx := context.Background()
+	length := verifyBatchSize + 5 // spans two batches
+
+	tests := []struct {
+		name         string
+		mutate       func(chain []*entities.AuditLog) []*entities.AuditLog
+		wantValid    bool
+		wantBrokenAt int64
+		wantChecked  int64
+	}{
+		{"intact", nil, true, 0, int64(length)},
+		{"modified in the second batch", func(c []*entities.AuditLog) []*entities.AuditLog {
+			c[verifyBatchSize+1].Comment = "edited"
+			return c
+		}, false, verifyBatchSize + 2, verifyBatchSize + 1},
+		{"entry deleted", func(c []*entities.AuditLog) []*entities.AuditLog {
+			return append(c[:9:9], c[10:]...)
+		}, false, 11, 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := sealedChain(length)
+			if tt.mutate != nil {
+				chain = tt.mutate(chain)
+			}
+			uc := NewVerifyAuditChainUseCase(&chainRepository{chain: chain, unsealed: 3})
+
+			output, err := uc.Execute(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Valid != tt.wantValid || output.Checked != tt.wantChecked || output.Unsealed != 3 {
+				t.Errorf("Execute = valid %v, checked %d, unsealed %d; want valid %v, checked %d, unsealed 3",
+					output.Valid, output.Checked, output.Unsealed, tt.wantValid, tt.wantChecked)
+			}
+			if tt.wantValid {
+				last := chain[len(chain)-1]
+				if output.BrokenAt != nil || output.HeadSequence != *last.Sequence || output.HeadHash != last.Hash {
+					t.Errorf("head = (%d, %s), want (%d, %s)", output.HeadSequence, output.HeadHash, *last.Sequence, last.Hash)
+				}
+				return
+			}
+			if output.BrokenAt == nil || *output.BrokenAt != tt.wantBrokenAt || output.Reason == "" {
+				t.Errorf("BrokenAt = %v (%q), want %d", output.BrokenAt, output.Reason, tt.wantBrokenAt)
+			}
+		})
+	}
+}
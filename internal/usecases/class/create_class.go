@@ -2,13 +2,19 @@ package class
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"doan/internal/entities"
+	"doan/internal/repositories"
 	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/regulation"
 	"doan/pkg/logger"
+	"doan/pkg/money"
 )
 
+var ErrTeacherNotFound = errors.New("teacher not found")
+
 type CreateClassInput struct {
 	Code        string
 	Name        string
@@ -17,14 +23,26 @@ type CreateClassInput struct {
 	EndDate     *time.Time
 	MaxStudents int
 	Status      string
-	Price       float64
+	Price       money.Amount
 	ProgramID   *string
 	CourseID    *string
 	TeacherID   *string
+	Schedules   []ScheduleInput
+}
+
+// ScheduleInput is one weekly session of the class
+type ScheduleInput struct {
+	DayOfWeek string
+	StartTime string
+	EndTime   string
+	RoomID    *string
 }
 
 type CreateClassOutput struct {
-	Class *entities.Class
+	Class     *entities.Class
+	Schedules []entities.ClassSchedule
+	// Compliance holds the non-blocking Circular 29 violations (warnings)
+	Compliance *regulation.Report
 }
 
 type CreateClassUseCase interface {
@@ -32,12 +50,26 @@ type CreateClassUseCase interface {
 }
 
 type createClassUseCase struct {
-	classRepo repointerface.ClassRepository
+	classRepo   repointerface.ClassRepository
+	teacherRepo repointerface.TeacherRepository
+	uow         repositories.UnitOfWork
+	ruleEngine  regulation.Engine
+	log         logger.Logger
 }
 
-func NewCreateClassUseCase(classRepo repointerface.ClassRepository) CreateClassUseCase {
+func NewCreateClassUseCase(
+	classRepo repointerface.ClassRepository,
+	teacherRepo repointerface.TeacherRepository,
+	uow repositories.UnitOfWork,
+	ruleEngine regulation.Engine,
+	log logger.Logger,
+) CreateClassUseCase {
 	return &createClassUseCase{
-		classRepo: classRepo,
+		classRepo:   classRepo,
+		teacherRepo: teacherRepo,
+		uow:         uow,
+		ruleEngine:  ruleEngine,
+		log:         log,
 	}
 }
 
@@ -45,7 +77,7 @@ func (uc *createClassUseCase) Execute(ctx context.Context, input CreateClassInpu
 	ctxLogger := logger.NewLogger(ctx)
 
 	if input.Status == "" {
-		input.Status = "OPEN"
+		input.Status = entities.ClassStatusOpen
 	}
 
 	classEntity := &entities.Class{
@@ -62,11 +94,61 @@ func (uc *createClassUseCase) Execute(ctx context.Context, input CreateClassInpu
 		TeacherID:   input.TeacherID,
 	}
 
-	createdClass, err := uc.classRepo.Create(ctx, classEntity)
+	schedules := make([]entities.ClassSchedule, 0, len(input.Schedules))
+	for _, s := range input.Schedules {
+		schedule := entities.ClassSchedule{
+			DayOfWeek: s.DayOfWeek,
+			StartTime: s.StartTime,
+			EndTime:   s.EndTime,
+			RoomID:    s.RoomID,
+		}
+		if err := regulation.ValidateSchedule(schedule); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	var teacher *entities.Teacher
+	if input.TeacherID != nil && *input.TeacherID != "" {
+		var err error
+		teacher, err = uc.teacherRepo.GetByID(ctx, *input.TeacherID)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get teacher: %v", err)
+			return nil, err
+		}
+		if teacher == nil {
+			return nil, ErrTeacherNotFound
+		}
+	}
+
+	report, err := uc.ruleEngine.Enforce(ctx, regulation.Subject{
+		Class:     classEntity,
+		Teacher:   teacher,
+		Schedules: schedules,
+	})
+	if err != nil {
+		ctxLogger.Warnf("Class %s blocked by Circular 29 rules: %v", input.Code, err)
+		return nil, err
+	}
+
+	_, err = repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		createdClass, err := uc.classRepo.Create(txCtx, classEntity)
+		if err != nil {
+			return nil, err
+		}
+		classEntity = createdClass
+
+		records := make([]*entities.ClassSchedule, 0, len(schedules))
+		for i := range schedules {
+			schedules[i].ClassID = createdClass.ID
+			records = append(records, &schedules[i])
+		}
+		return nil, uc.classRepo.CreateSchedules(txCtx, records)
+	})
 	if err != nil {
 		ctxLogger.Errorf("Failed to create class: %v", err)
 		return nil, err
 	}
 
-	return &CreateClassOutput{Class: createdClass}, nil
+	return &CreateClassOutput{Class: classEntity, Schedules: schedules, Compliance: report}, nil
 }
@@ -0,0 +1,80 @@
+package class
+
+import (
+	"context"
+	"errors"
+
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/regulation"
+	"doan/pkg/logger"
+)
+
+var ErrClassNotFound = errors.New("class not found")
+
+type EvaluateClassComplianceInput struct {
+	ID string
+}
+
+type EvaluateClassComplianceOutput struct {
+	Class  *entities.Class
+	Report *regulation.Report
+}
+
+// EvaluateClassComplianceUseCase re-checks an existing class against the current Circular 29 rules
+type EvaluateClassComplianceUseCase interface {
+	Execute(ctx context.Context, input EvaluateClassComplianceInput) (*EvaluateClassComplianceOutput, error)
+}
+
+type evaluateClassComplianceUseCase struct {
+	classRepo   repointerface.ClassRepository
+	teacherRepo repointerface.TeacherRepository
+	ruleEngine  regulation.Engine
+}
+
+func NewEvaluateClassComplianceUseCase(
+	classRepo repointerface.ClassRepository,
+	teacherRepo repointerface.TeacherRepository,
+	ruleEngine regulation.Engine,
+) EvaluateClassComplianceUseCase {
+	return &evaluateClassComplianceUseCase{
+		classRepo:   classRepo,
+		teacherRepo: teacherRepo,
+		ruleEngine:  ruleEngine,
+	}
+}
+
+func (uc *evaluateClassComplianceUseCase) Execute(ctx context.Context, input EvaluateClassComplianceInput) (*EvaluateClassComplianceOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	classEntity, err := uc.classRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get class: %v", err)
+		return nil, err
+	}
+	if classEntity == nil {
+		return nil, ErrClassNotFound
+	}
+
+	subject := regulation.Subject{Class: classEntity}
+	if classEntity.TeacherID != nil {
+		subject.Teacher, err = uc.teacherRepo.GetByID(ctx, *classEntity.TeacherID)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get teacher: %v", err)
+			return nil, err
+		}
+	}
+	schedules, err := uc.classRepo.ListSchedules(ctx, []string{classEntity.ID})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list class schedules: %v", err)
+		return nil, err
+	}
+	for _, s := range schedules {
+		subject.Schedules = append(subject.Schedules, *s)
+	}
+
+	return &EvaluateClassComplianceOutput{
+		Class:  classEntity,
+		Report: uc.ruleEngine.Evaluate(ctx, subject),
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
+	"doan/pkg/money"
 )
 
 type UpdateClassInput struct {
@@ -18,7 +19,7 @@ type UpdateClassInput struct {
 	EndDate     *time.Time
 	MaxStudents int
 	Status      string
-	Price       float64
+	Price       money.Amount
 	ProgramID   *string
 	CourseID    *string
 	TeacherID   *string
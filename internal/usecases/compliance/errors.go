@@ -0,0 +1,10 @@
+package compliance
+
+import "errors"
+
+var (
+	ErrMaterialNotFound = errors.New("material not found")
+	ErrAuditInProgress  = errors.New("material is still being audited")
+	ErrCommentRequired  = errors.New("a comment is required to reject a material")
+	ErrInvalidDecision  = errors.New("decision must be APPROVE or REJECT")
+)
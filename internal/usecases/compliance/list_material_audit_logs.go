@@ -0,0 +1,63 @@
+package compliance
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"errors"
+)
+
+// ListMaterialAuditLogsInput represents the input for the review history of a material
+type ListMaterialAuditLogsInput struct {
+	MaterialID string
+}
+
+// ListMaterialAuditLogsOutput represents the review history, newest first
+type ListMaterialAuditLogsOutput struct {
+	Logs []*entities.AuditLog
+}
+
+// ListMaterialAuditLogsUseCase lists the audit log entries of a material
+type ListMaterialAuditLogsUseCase interface {
+	Execute(ctx context.Context, input ListMaterialAuditLogsInput) (*ListMaterialAuditLogsOutput, error)
+}
+
+type listMaterialAuditLogsUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	auditLogRepo repointerface.AuditLogRepository
+}
+
+// NewListMaterialAuditLogsUseCase creates a new instance of ListMaterialAuditLogsUseCase
+func NewListMaterialAuditLogsUseCase(
+	materialRepo repointerface.MaterialRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+) ListMaterialAuditLogsUseCase {
+	return &listMaterialAuditLogsUseCase{
+		materialRepo: materialRepo,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+func (uc *listMaterialAuditLogsUseCase) Execute(ctx context.Context, input ListMaterialAuditLogsInput) (*ListMaterialAuditLogsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.MaterialID == "" {
+		return nil, errors.New("material ID is required")
+	}
+	material, err := uc.materialRepo.GetByID(ctx, input.MaterialID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+
+	logs, err := uc.auditLogRepo.ListByEntity(ctx, entities.AuditEntityMaterial, material.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list audit logs: %v", err)
+		return nil, err
+	}
+	return &ListMaterialAuditLogsOutput{Logs: logs}, nil
+}
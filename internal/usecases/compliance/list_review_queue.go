@@ -0,0 +1,89 @@
+package compliance
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+	"time"
+)
+
+// ListReviewQueueInput represents the filters of the compliance review queue
+type ListReviewQueueInput struct {
+	Labels       []string // AI labels, empty means all
+	ReviewStatus string   // Defaults to PENDING; "ALL" disables the filter
+	MinAgeHours  int      // Only materials uploaded at least this many hours ago
+	Page         int
+	Limit        int
+}
+
+// ListReviewQueueOutput represents one page of the review queue
+type ListReviewQueueOutput struct {
+	Materials  []*entities.Material
+	Pagination *repositories.Meta
+}
+
+// ListReviewQueueUseCase lists audited materials awaiting a compliance decision
+type ListReviewQueueUseCase interface {
+	Execute(ctx context.Context, input ListReviewQueueInput) (*ListReviewQueueOutput, error)
+}
+
+type listReviewQueueUseCase struct {
+	materialRepo repointerface.MaterialRepository
+}
+
+// NewListReviewQueueUseCase creates a new instance of ListReviewQueueUseCase
+func NewListReviewQueueUseCase(materialRepo repointerface.MaterialRepository) ListReviewQueueUseCase {
+	return &listReviewQueueUseCase{
+		materialRepo: materialRepo,
+	}
+}
+
+func (uc *listReviewQueueUseCase) Execute(ctx context.Context, input ListReviewQueueInput) (*ListReviewQueueOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	// Set default pagination
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 {
+		input.Limit = 20
+	}
+	if input.Limit > 100 {
+		input.Limit = 100 // Max limit
+	}
+
+	filter := repointerface.ReviewQueueFilter{
+		ReviewStatus: strings.ToUpper(strings.TrimSpace(input.ReviewStatus)),
+		Page:         uint64(input.Page),
+		Limit:        uint64(input.Limit),
+	}
+	switch filter.ReviewStatus {
+	case "":
+		filter.ReviewStatus = entities.MaterialReviewPending
+	case "ALL":
+		filter.ReviewStatus = ""
+	}
+	for _, label := range input.Labels {
+		if label = strings.ToUpper(strings.TrimSpace(label)); label != "" {
+			filter.Labels = append(filter.Labels, label)
+		}
+	}
+	if input.MinAgeHours > 0 {
+		before := time.Now().Add(-time.Duration(input.MinAgeHours) * time.Hour)
+		filter.UploadedBefore = &before
+	}
+
+	pagination, err := uc.materialRepo.ListReviewQueue(ctx, filter)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list review queue: %v", err)
+		return nil, err
+	}
+
+	return &ListReviewQueueOutput{
+		Materials:  pagination.Data,
+		Pagination: &pagination.Meta,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package compliance
+
+import (
+	"doan/internal/entities"
+	"doan/internal/services/mailer"
+	"fmt"
+	"html"
+)
+
+// reviewDecisionMail builds the email telling a teacher the compliance decision on their material
+func reviewDecisionMail(uploader *entities.User, material *entities.Material) mailer.Mail {
+	decision, color := "đã được phê duyệt", "#28a745"
+	if material.ReviewStatus == entities.MaterialReviewRejected {
+		decision, color = "đã bị từ chối", "#dc3545"
+	}
+
+	comment := ""
+	if material.ReviewComment != "" {
+		comment = fmt.Sprintf(`<p><strong>Nhận xét của cán bộ kiểm duyệt:</strong></p>
+					<blockquote style="border-left: 4px solid #ccc; margin: 0; padding-left: 10px;">%s</blockquote>`,
+			html.EscapeString(material.ReviewComment))
+	}
+
+	htmlBody := fmt.Sprintf(`
+			<!DOCTYPE html>
+			<html>
+			<head>
+				<meta charset="UTF-8">
+			</head>
+			<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+				<div style="max-width: 600px; margin: 20px auto; padding: 20px; border: 1px solid #ddd; border-radius: 8px;">
+					<h2>Kết quả kiểm duyệt tài liệu</h2>
+					<p>Xin chào %s,</p>
+					<p>Tài liệu <strong>%s</strong> (%s) <strong style="color: %s;">%s</strong>.</p>
+					%s
+					<p>Bạn có thể xem trạng thái kiểm duyệt trong danh sách tài liệu của mình.</p>
+					<p style="margin-top: 20px; font-size: 0.9em; color: #777;">Trân trọng,<br>Bộ phận kiểm duyệt nội dung</p>
+				</div>
+			</body>
+			</html>
+		`,
+		html.EscapeString(uploader.FullName),
+		html.EscapeString(material.Title),
+		html.EscapeString(material.FileName),
+		color, decision, comment)
+
+	return mailer.Mail{
+		To:      uploader.Email,
+		Subject: fmt.Sprintf("Kết quả kiểm duyệt tài liệu: %s", material.Title),
+		HTML:    htmlBody,
+	}
+}
@@ -0,0 +1,160 @@
+package compliance
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/mailer"
+	"doan/pkg/logger"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Review decisions
+const (
+	DecisionApprove = "APPROVE"
+	DecisionReject  = "REJECT"
+)
+
+// ReviewMaterialInput represents a compliance officer's decision on a material
+type ReviewMaterialInput struct {
+	MaterialID   string
+	ReviewerID   string
+	ReviewerRole string
+	Decision     string
+	Comment      string
+}
+
+// ReviewMaterialOutput represents the reviewed material
+type ReviewMaterialOutput struct {
+	Material *entities.Material
+}
+
+// ReviewMaterialUseCase approves or rejects a material, writes the audit log and notifies the uploader
+type ReviewMaterialUseCase interface {
+	Execute(ctx context.Context, input ReviewMaterialInput) (*ReviewMaterialOutput, error)
+}
+
+type reviewMaterialUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	auditLogRepo repointerface.AuditLogRepository
+	userRepo     repointerface.UserRepository
+	uow          repositories.UnitOfWork
+	mailer       mailer.Mailer
+	log          logger.Logger
+}
+
+// NewReviewMaterialUseCase creates a new instance of ReviewMaterialUseCase
+func NewReviewMaterialUseCase(
+	materialRepo repointerface.MaterialRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	userRepo repointerface.UserRepository,
+	uow repositories.UnitOfWork,
+	mailer mailer.Mailer,
+	log logger.Logger,
+) ReviewMaterialUseCase {
+	return &reviewMaterialUseCase{
+		materialRepo: materialRepo,
+		auditLogRepo: auditLogRepo,
+		userRepo:     userRepo,
+		uow:          uow,
+		mailer:       mailer,
+		log:          log,
+	}
+}
+
+func (uc *reviewMaterialUseCase) Execute(ctx context.Context, input ReviewMaterialInput) (*ReviewMaterialOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.MaterialID == "" {
+		return nil, errors.New("material ID is required")
+	}
+	input.Comment = strings.TrimSpace(input.Comment)
+
+	var status, action string
+	switch strings.ToUpper(input.Decision) {
+	case DecisionApprove:
+		status, action = entities.MaterialReviewApproved, entities.AuditActionMaterialApprove
+	case DecisionReject:
+		if input.Comment == "" {
+			return nil, ErrCommentRequired
+		}
+		status, action = entities.MaterialReviewRejected, entities.AuditActionMaterialReject
+	default:
+		return nil, ErrInvalidDecision
+	}
+
+	material, err := uc.materialRepo.GetByID(ctx, input.MaterialID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+	if material.AuditStatus == entities.MaterialAuditQueued || material.AuditStatus == entities.MaterialAuditProcessing {
+		return nil, ErrAuditInProgress
+	}
+
+	previousStatus := material.ReviewStatus
+	now := time.Now()
+	_, err = repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		if err := uc.materialRepo.Update(txCtx, material.ID, map[string]interface{}{
+			"review_status":  status,
+			"review_comment": input.Comment,
+			"reviewed_by_id": input.ReviewerID,
+			"reviewed_at":    now,
+		}); err != nil {
+			return nil, err
+		}
+
+		var aiLabel interface{}
+		if material.AILabel != nil {
+			aiLabel = *material.AILabel
+		}
+		return uc.auditLogRepo.Create(txCtx, &entities.AuditLog{
+			ActorID:    &input.ReviewerID,
+			ActorRole:  input.ReviewerRole,
+			Action:     action,
+			EntityType: entities.AuditEntityMaterial,
+			EntityID:   material.ID,
+			Comment:    input.Comment,
+			Metadata: entities.JSONMap{
+				"previous_status": previousStatus,
+				"review_status":   status,
+				"ai_label":        aiLabel,
+				"audit_status":    material.AuditStatus,
+			},
+		})
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to review material %s: %v", material.ID, err)
+		return nil, err
+	}
+
+	material.ReviewStatus = status
+	material.ReviewComment = input.Comment
+	material.ReviewedByID = &input.ReviewerID
+	material.ReviewedAt = &now
+
+	uc.notifyUploader(material)
+
+	return &ReviewMaterialOutput{Material: material}, nil
+}
+
+// notifyUploader emails the decision to the teacher in the background; a mail failure does not undo the review
+func (uc *reviewMaterialUseCase) notifyUploader(material *entities.Material) {
+	go func() {
+		ctx := context.Background()
+		uploader, err := uc.userRepo.GetByID(ctx, material.UploadedByID)
+		if err != nil || uploader == nil || uploader.Email == "" {
+			uc.log.Warn(ctx, "Cannot notify material uploader", "material_id", material.ID, "error", err)
+			return
+		}
+		if err := uc.mailer.Send(ctx, reviewDecisionMail(uploader, material)); err != nil {
+			uc.log.Error(ctx, "Failed to send review decision email", "material_id", material.ID, "error", err)
+		}
+	}()
+}
@@ -0,0 +1,29 @@
+package course
+
+import (
+	"context"
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"fmt"
+	"time"
+)
+
+const (
+	listCacheKeyPrefix = "courses:list:"
+	defaultListTTL     = time.Minute
+)
+
+// listCacheTTL reads "cache.list_ttl_seconds"; zero or negative disables caching of lists
+func listCacheTTL(configManager config.Manager) time.Duration {
+	return caching.ConfigTTL(configManager, "cache.list_ttl_seconds", defaultListTTL)
+}
+
+// listCacheKey identifies one page of the courses list by every field of the input
+func listCacheKey(input ListCoursesInput) string {
+	return listCacheKeyPrefix + fmt.Sprintf("%q:%q:%q:%d:%d:%q:%q", input.Search, input.Status, input.Subject, input.Page, input.Limit, input.SortBy, input.SortOrder)
+}
+
+// invalidateLists drops every cached page of the courses list after a write
+func invalidateLists(ctx context.Context, cache caching.CacheManager) {
+	caching.Invalidate(ctx, cache, listCacheKeyPrefix+"*")
+}
@@ -2,23 +2,25 @@ package course
 
 import (
 	"context"
+	"doan/internal/caching"
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
+	"doan/pkg/money"
 	"errors"
 )
 
 type CreateCourseInput struct {
-	Code                   string  `json:"code"`
-	Name                   string  `json:"name"`
-	Description            string  `json:"description"`
-	GradeLevel             string  `json:"grade_level"`
-	Subject                string  `json:"subject"`
-	SessionCount           int     `json:"session_count"`
-	SessionDurationMinutes int     `json:"session_duration_minutes"`
-	TotalHours             float64 `json:"total_hours"`
-	Price                  float64 `json:"price"`
-	Status                 string  `json:"status"`
+	Code                   string       `json:"code"`
+	Name                   string       `json:"name"`
+	Description            string       `json:"description"`
+	GradeLevel             string       `json:"grade_level"`
+	Subject                string       `json:"subject"`
+	SessionCount           int          `json:"session_count"`
+	SessionDurationMinutes int          `json:"session_duration_minutes"`
+	TotalHours             float64      `json:"total_hours"`
+	Price                  money.Amount `json:"price"`
+	Status                 string       `json:"status"`
 }
 
 type CreateCourseOutput struct {
@@ -30,11 +32,12 @@ type CreateCourseUseCase interface {
 }
 
 type createCourseUseCaseImpl struct {
-	repo repointerface.CourseRepository
+	repo  repointerface.CourseRepository
+	cache caching.CacheManager
 }
 
-func NewCreateCourseUseCase(repo repointerface.CourseRepository) CreateCourseUseCase {
-	return &createCourseUseCaseImpl{repo: repo}
+func NewCreateCourseUseCase(repo repointerface.CourseRepository, cache caching.CacheManager) CreateCourseUseCase {
+	return &createCourseUseCaseImpl{repo: repo, cache: cache}
 }
 
 func (uc *createCourseUseCaseImpl) Execute(ctx context.Context, input CreateCourseInput) (*CreateCourseOutput, error) {
@@ -62,5 +65,6 @@ func (uc *createCourseUseCaseImpl) Execute(ctx context.Context, input CreateCour
 		ctxLogger.Errorf("Error creating course: %v", err)
 		return nil, err
 	}
+	invalidateLists(ctx, uc.cache)
 	return &CreateCourseOutput{Course: created}, nil
 }
@@ -2,6 +2,7 @@ package course
 
 import (
 	"context"
+	"doan/internal/caching"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
 )
@@ -19,11 +20,12 @@ type DeleteCourseUseCase interface {
 }
 
 type deleteCourseUseCaseImpl struct {
-	repo repointerface.CourseRepository
+	repo  repointerface.CourseRepository
+	cache caching.CacheManager
 }
 
-func NewDeleteCourseUseCase(repo repointerface.CourseRepository) DeleteCourseUseCase {
-	return &deleteCourseUseCaseImpl{repo: repo}
+func NewDeleteCourseUseCase(repo repointerface.CourseRepository, cache caching.CacheManager) DeleteCourseUseCase {
+	return &deleteCourseUseCaseImpl{repo: repo, cache: cache}
 }
 
 func (uc *deleteCourseUseCaseImpl) Execute(ctx context.Context, input DeleteCourseInput) (*DeleteCourseOutput, error) {
@@ -33,5 +35,6 @@ func (uc *deleteCourseUseCaseImpl) Execute(ctx context.Context, input DeleteCour
 		ctxLogger.Errorf("Error soft deleting course: %v", err)
 		return nil, err
 	}
+	invalidateLists(ctx, uc.cache)
 	return &DeleteCourseOutput{Message: "Course deleted successfully"}, nil
 }
@@ -2,9 +2,11 @@ package course
 
 import (
 	"context"
+	"doan/internal/caching"
 	"doan/internal/entities"
 	"doan/internal/repositories"
 	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
 	"doan/pkg/logger"
 )
 
@@ -35,14 +37,27 @@ type ListCoursesUseCase interface {
 }
 
 type listCoursesUseCaseImpl struct {
-	repo repointerface.CourseRepository
+	repo          repointerface.CourseRepository
+	cache         caching.CacheManager
+	configManager config.Manager
 }
 
-func NewListCoursesUseCase(repo repointerface.CourseRepository) ListCoursesUseCase {
-	return &listCoursesUseCaseImpl{repo: repo}
+func NewListCoursesUseCase(
+	repo repointerface.CourseRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) ListCoursesUseCase {
+	return &listCoursesUseCaseImpl{repo: repo, cache: cache, configManager: configManager}
 }
 
+// Execute serves the page from the cache; writes to courses invalidate every cached page
 func (uc *listCoursesUseCaseImpl) Execute(ctx context.Context, input ListCoursesInput) (*ListCoursesOutput, error) {
+	return caching.GetOrLoad(ctx, uc.cache, listCacheKey(input), listCacheTTL(uc.configManager), func(ctx context.Context) (*ListCoursesOutput, error) {
+		return uc.list(ctx, input)
+	})
+}
+
+func (uc *listCoursesUseCaseImpl) list(ctx context.Context, input ListCoursesInput) (*ListCoursesOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 	condition := repositories.NewCommonCondition().WithPaging(uint64(input.Limit), uint64(input.Page))
 	if input.Status != "" {
@@ -2,23 +2,25 @@ package course
 
 import (
 	"context"
+	"doan/internal/caching"
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
+	"doan/pkg/money"
 )
 
 type UpdateCourseInput struct {
-	ID                     string   `json:"id"`
-	Code                   *string  `json:"code"`
-	Name                   *string  `json:"name"`
-	Description            *string  `json:"description"`
-	GradeLevel             *string  `json:"grade_level"`
-	Subject                *string  `json:"subject"`
-	SessionCount           *int     `json:"session_count"`
-	SessionDurationMinutes *int     `json:"session_duration_minutes"`
-	TotalHours             *float64 `json:"total_hours"`
-	Price                  *float64 `json:"price"`
-	Status                 *string  `json:"status"`
+	ID                     string        `json:"id"`
+	Code                   *string       `json:"code"`
+	Name                   *string       `json:"name"`
+	Description            *string       `json:"description"`
+	GradeLevel             *string       `json:"grade_level"`
+	Subject                *string       `json:"subject"`
+	SessionCount           *int          `json:"session_count"`
+	SessionDurationMinutes *int          `json:"session_duration_minutes"`
+	TotalHours             *float64      `json:"total_hours"`
+	Price                  *money.Amount `json:"price"`
+	Status                 *string       `json:"status"`
 }
 
 type UpdateCourseOutput struct {
@@ -30,11 +32,12 @@ type UpdateCourseUseCase interface {
 }
 
 type updateCourseUseCaseImpl struct {
-	repo repointerface.CourseRepository
+	repo  repointerface.CourseRepository
+	cache caching.CacheManager
 }
 
-func NewUpdateCourseUseCase(repo repointerface.CourseRepository) UpdateCourseUseCase {
-	return &updateCourseUseCaseImpl{repo: repo}
+func NewUpdateCourseUseCase(repo repointerface.CourseRepository, cache caching.CacheManager) UpdateCourseUseCase {
+	return &updateCourseUseCaseImpl{repo: repo, cache: cache}
 }
 
 func (uc *updateCourseUseCaseImpl) Execute(ctx context.Context, input UpdateCourseInput) (*UpdateCourseOutput, error) {
@@ -93,5 +96,6 @@ func (uc *updateCourseUseCaseImpl) Execute(ctx context.Context, input UpdateCour
 		ctxLogger.Errorf("Error updating course: %v", err)
 		return nil, err
 	}
+	invalidateLists(ctx, uc.cache)
 	return &UpdateCourseOutput{Course: course}, nil
 }
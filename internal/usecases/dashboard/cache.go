@@ -0,0 +1,37 @@
+package dashboard
+
+import (
+	"context"
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultCacheTTL = 5 * time.Minute
+
+// cacheTTL reads "dashboard.cache_ttl_seconds"; zero or negative disables caching
+func cacheTTL(configManager config.Manager) time.Duration {
+	return caching.ConfigTTL(configManager, "dashboard.cache_ttl_seconds", defaultCacheTTL)
+}
+
+// cacheKey builds the key of one dashboard query from its name and parameters
+func cacheKey(name string, parts ...interface{}) string {
+	values := make([]string, 0, len(parts)+2)
+	values = append(values, "dashboard", name)
+	for _, part := range parts {
+		if t, ok := part.(time.Time); ok {
+			values = append(values, t.UTC().Format(time.RFC3339))
+			continue
+		}
+		values = append(values, fmt.Sprint(part))
+	}
+	return strings.Join(values, ":")
+}
+
+// cached returns the value stored under key, or loads and stores it. Cache failures are logged and the query
+// runs against the database, so the dashboard keeps working without a cache.
+func cached[T any](ctx context.Context, cache caching.CacheManager, ttl time.Duration, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	return caching.GetOrLoad(ctx, cache, key, ttl, load)
+}
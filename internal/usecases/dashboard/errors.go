@@ -0,0 +1,9 @@
+package dashboard
+
+import "errors"
+
+var (
+	ErrInvalidPeriod   = errors.New("invalid period, expected from <= to as YYYY-MM-DD")
+	ErrInvalidInterval = errors.New("interval must be day, week or month")
+	ErrTooManyBuckets  = errors.New("period has too many buckets for this interval; use a shorter period or a larger interval")
+)
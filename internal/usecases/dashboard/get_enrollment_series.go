@@ -0,0 +1,64 @@
+package dashboard
+
+import (
+	"context"
+	"doan/internal/caching"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+)
+
+// GetEnrollmentSeriesOutput represents enrollment applications, approvals and rejections per bucket
+type GetEnrollmentSeriesOutput struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+	Points   []*repointerface.EnrollmentPoint
+}
+
+// GetEnrollmentSeriesUseCase returns enrollment activity over time
+type GetEnrollmentSeriesUseCase interface {
+	Execute(ctx context.Context, input SeriesInput) (*GetEnrollmentSeriesOutput, error)
+}
+
+type getEnrollmentSeriesUseCase struct {
+	dashboardRepo repointerface.DashboardRepository
+	cache         caching.CacheManager
+	configManager config.Manager
+}
+
+// NewGetEnrollmentSeriesUseCase creates a new instance of GetEnrollmentSeriesUseCase
+func NewGetEnrollmentSeriesUseCase(
+	dashboardRepo repointerface.DashboardRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) GetEnrollmentSeriesUseCase {
+	return &getEnrollmentSeriesUseCase{
+		dashboardRepo: dashboardRepo,
+		cache:         cache,
+		configManager: configManager,
+	}
+}
+
+func (uc *getEnrollmentSeriesUseCase) Execute(ctx context.Context, input SeriesInput) (*GetEnrollmentSeriesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	from, to, err := resolvePeriod(input.Period, 11)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := resolveInterval(input.Interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached(ctx, uc.cache, cacheTTL(uc.configManager), cacheKey("enrollments", from, to, interval), func(ctx context.Context) (*GetEnrollmentSeriesOutput, error) {
+		points, err := uc.dashboardRepo.EnrollmentSeries(ctx, from, to, interval)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get enrollment series: %v", err)
+			return nil, err
+		}
+		return &GetEnrollmentSeriesOutput{From: from, To: to, Interval: interval, Points: points}, nil
+	})
+}
@@ -0,0 +1,70 @@
+package dashboard
+
+import (
+	"context"
+	"doan/internal/caching"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+)
+
+// SeriesInput represents a time series request; the period defaults to the last 12 months, by month
+type SeriesInput struct {
+	Period   PeriodInput
+	Interval string // day, week or month
+}
+
+// GetRevenueSeriesOutput represents billed and collected revenue per bucket
+type GetRevenueSeriesOutput struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+	Points   []*repointerface.RevenuePoint
+}
+
+// GetRevenueSeriesUseCase returns revenue billed vs collected over time
+type GetRevenueSeriesUseCase interface {
+	Execute(ctx context.Context, input SeriesInput) (*GetRevenueSeriesOutput, error)
+}
+
+type getRevenueSeriesUseCase struct {
+	dashboardRepo repointerface.DashboardRepository
+	cache         caching.CacheManager
+	configManager config.Manager
+}
+
+// NewGetRevenueSeriesUseCase creates a new instance of GetRevenueSeriesUseCase
+func NewGetRevenueSeriesUseCase(
+	dashboardRepo repointerface.DashboardRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) GetRevenueSeriesUseCase {
+	return &getRevenueSeriesUseCase{
+		dashboardRepo: dashboardRepo,
+		cache:         cache,
+		configManager: configManager,
+	}
+}
+
+func (uc *getRevenueSeriesUseCase) Execute(ctx context.Context, input SeriesInput) (*GetRevenueSeriesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	from, to, err := resolvePeriod(input.Period, 11)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := resolveInterval(input.Interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached(ctx, uc.cache, cacheTTL(uc.configManager), cacheKey("revenue", from, to, interval), func(ctx context.Context) (*GetRevenueSeriesOutput, error) {
+		points, err := uc.dashboardRepo.RevenueSeries(ctx, from, to, interval)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get revenue series: %v", err)
+			return nil, err
+		}
+		return &GetRevenueSeriesOutput{From: from, To: to, Interval: interval, Points: points}, nil
+	})
+}
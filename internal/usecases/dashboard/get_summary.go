@@ -0,0 +1,176 @@
+package dashboard
+
+import (
+	"context"
+	"doan/internal/caching"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"time"
+)
+
+// GetSummaryInput represents the KPI period; it defaults to the current month
+type GetSummaryInput struct {
+	Period PeriodInput
+}
+
+// GetSummaryOutput represents the dashboard KPIs; amounts are whole VND
+type GetSummaryOutput struct {
+	From     time.Time
+	To       time.Time
+	Students StudentSummary
+	Revenue  RevenueSummary
+	Classes  ClassSummary
+	Teachers TeacherSummary
+}
+
+// StudentSummary are the student and enrollment KPIs
+type StudentSummary struct {
+	ActiveStudents      int64
+	EnrolledStudents    int64
+	NewStudents         int64
+	NewEnrollments      int64
+	ApprovedEnrollments int64
+	EnrolledAtStart     int64
+	ChurnedStudents     int64
+	ChurnRate           float64 // percent of the students enrolled at the start of the period
+}
+
+// RevenueSummary compares what was billed with what was collected
+type RevenueSummary struct {
+	Billed         money.Amount
+	Collected      money.Amount
+	Refunded       money.Amount
+	NetCollected   money.Amount
+	CollectionRate float64 // net collected as a percent of billed
+	Outstanding    money.Amount
+	Overdue        money.Amount
+}
+
+// ClassSummary counts classes per status
+type ClassSummary struct {
+	Running  int64
+	ByStatus map[string]int64
+}
+
+// TeacherSummary is the teaching load of active teachers
+type TeacherSummary struct {
+	ActiveTeachers  int64
+	TeachingHours   float64
+	MeanUtilisation float64 // percent, over teachers with a configured capacity
+}
+
+// GetSummaryUseCase computes the KPIs of the admin dashboard
+type GetSummaryUseCase interface {
+	Execute(ctx context.Context, input GetSummaryInput) (*GetSummaryOutput, error)
+}
+
+type getSummaryUseCase struct {
+	dashboardRepo repointerface.DashboardRepository
+	cache         caching.CacheManager
+	configManager config.Manager
+}
+
+// NewGetSummaryUseCase creates a new instance of GetSummaryUseCase
+func NewGetSummaryUseCase(
+	dashboardRepo repointerface.DashboardRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) GetSummaryUseCase {
+	return &getSummaryUseCase{
+		dashboardRepo: dashboardRepo,
+		cache:         cache,
+		configManager: configManager,
+	}
+}
+
+func (uc *getSummaryUseCase) Execute(ctx context.Context, input GetSummaryInput) (*GetSummaryOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	from, to, err := resolvePeriod(input.Period, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached(ctx, uc.cache, cacheTTL(uc.configManager), cacheKey("summary", from, to), func(ctx context.Context) (*GetSummaryOutput, error) {
+		students, err := uc.dashboardRepo.GetStudentStats(ctx, from, to)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get student stats: %v", err)
+			return nil, err
+		}
+		revenue, err := uc.dashboardRepo.GetRevenueTotals(ctx, from, to)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get revenue totals: %v", err)
+			return nil, err
+		}
+		classes, err := uc.dashboardRepo.CountClassesByStatus(ctx, time.Now())
+		if err != nil {
+			ctxLogger.Errorf("Failed to count classes: %v", err)
+			return nil, err
+		}
+		hours, err := uc.dashboardRepo.TeacherHours(ctx, from, to)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get teacher hours: %v", err)
+			return nil, err
+		}
+
+		output := &GetSummaryOutput{
+			From: from,
+			To:   to,
+			Students: StudentSummary{
+				ActiveStudents:      students.ActiveStudents,
+				EnrolledStudents:    students.EnrolledStudents,
+				NewStudents:         students.NewStudents,
+				NewEnrollments:      students.NewEnrollments,
+				ApprovedEnrollments: students.ApprovedEnrollment,
+				EnrolledAtStart:     students.EnrolledAtStart,
+				ChurnedStudents:     students.ChurnedStudents,
+				ChurnRate:           percent(students.ChurnedStudents, students.EnrolledAtStart),
+			},
+			Revenue: RevenueSummary{
+				Billed:       revenue.Billed,
+				Collected:    revenue.Collected,
+				Refunded:     revenue.Refunded,
+				NetCollected: revenue.Collected.Sub(revenue.Refunded),
+				Outstanding:  revenue.Outstanding,
+				Overdue:      revenue.Overdue,
+			},
+			Classes: ClassSummary{ByStatus: make(map[string]int64)},
+		}
+		output.Revenue.CollectionRate = percent(output.Revenue.NetCollected.Int64(), revenue.Billed.Int64())
+
+		for _, c := range classes {
+			output.Classes.ByStatus[c.Status] = c.Count
+			output.Classes.Running += c.Running
+		}
+
+		var minutes int64
+		var utilisationSum float64
+		var withCapacity int
+		for _, u := range utilisationOf(hours, from, to, weeklyCapacity(uc.configManager)) {
+			if u.CapacityHours > 0 {
+				utilisationSum += u.Utilisation
+				withCapacity++
+			}
+		}
+		for _, row := range hours {
+			minutes += row.Minutes
+		}
+		output.Teachers.ActiveTeachers = int64(len(hours))
+		output.Teachers.TeachingHours = round2(float64(minutes) / 60)
+		if withCapacity > 0 {
+			output.Teachers.MeanUtilisation = round2(utilisationSum / float64(withCapacity))
+		}
+
+		return output, nil
+	})
+}
+
+// percent returns part as a percent of whole, zero when whole is zero
+func percent(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return round2(float64(part) / float64(whole) * 100)
+}
@@ -0,0 +1,68 @@
+package dashboard
+
+import (
+	"context"
+	"doan/internal/caching"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"time"
+)
+
+// GetTeacherUtilisationInput represents the period; it defaults to the current month
+type GetTeacherUtilisationInput struct {
+	Period PeriodInput
+}
+
+// GetTeacherUtilisationOutput represents the load of each active teacher, busiest first
+type GetTeacherUtilisationOutput struct {
+	From     time.Time
+	To       time.Time
+	Teachers []TeacherUtilisation
+}
+
+// GetTeacherUtilisationUseCase compares the hours each teacher taught with their configured weekly capacity
+type GetTeacherUtilisationUseCase interface {
+	Execute(ctx context.Context, input GetTeacherUtilisationInput) (*GetTeacherUtilisationOutput, error)
+}
+
+type getTeacherUtilisationUseCase struct {
+	dashboardRepo repointerface.DashboardRepository
+	cache         caching.CacheManager
+	configManager config.Manager
+}
+
+// NewGetTeacherUtilisationUseCase creates a new instance of GetTeacherUtilisationUseCase
+func NewGetTeacherUtilisationUseCase(
+	dashboardRepo repointerface.DashboardRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) GetTeacherUtilisationUseCase {
+	return &getTeacherUtilisationUseCase{
+		dashboardRepo: dashboardRepo,
+		cache:         cache,
+		configManager: configManager,
+	}
+}
+
+func (uc *getTeacherUtilisationUseCase) Execute(ctx context.Context, input GetTeacherUtilisationInput) (*GetTeacherUtilisationOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	from, to, err := resolvePeriod(input.Period, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached(ctx, uc.cache, cacheTTL(uc.configManager), cacheKey("teachers", from, to), func(ctx context.Context) (*GetTeacherUtilisationOutput, error) {
+		rows, err := uc.dashboardRepo.TeacherHours(ctx, from, to)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get teacher hours: %v", err)
+			return nil, err
+		}
+		return &GetTeacherUtilisationOutput{
+			From:     from,
+			To:       to,
+			Teachers: utilisationOf(rows, from, to, weeklyCapacity(uc.configManager)),
+		}, nil
+	})
+}
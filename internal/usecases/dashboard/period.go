@@ -0,0 +1,70 @@
+package dashboard
+
+import (
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"doan/pkg/utils"
+	"strings"
+	"time"
+)
+
+// maxBuckets bounds a time series so a wide period with a day interval cannot produce thousands of points
+const maxBuckets = 400
+
+// PeriodInput is an inclusive date range in Vietnam time; empty ends fall back to the use case default
+type PeriodInput struct {
+	From string // YYYY-MM-DD
+	To   string // YYYY-MM-DD, inclusive
+}
+
+// resolvePeriod converts the dates to [from 00:00, day after to 00:00). Without dates the period ends today
+// and starts defaultMonths months earlier on the first of the month, or on the first of this month when zero.
+func resolvePeriod(period PeriodInput, defaultMonths int) (time.Time, time.Time, error) {
+	loc := utils.VietnamLocation()
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	to := today.AddDate(0, 0, 1)
+	if period.To != "" {
+		t, err := time.ParseInLocation(constants.DateOnly, period.To, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+	last := to.AddDate(0, 0, -1)
+	from := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, loc).AddDate(0, -defaultMonths, 0)
+	if period.From != "" {
+		t, err := time.ParseInLocation(constants.DateOnly, period.From, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	return from, to, nil
+}
+
+// resolveInterval normalises the bucket size and checks the series stays within maxBuckets
+func resolveInterval(interval string, from, to time.Time) (string, error) {
+	interval = strings.ToLower(strings.TrimSpace(interval))
+	days := int(to.Sub(from).Hours()/24) + 1
+	var buckets int
+	switch interval {
+	case "", repointerface.IntervalMonth:
+		interval = repointerface.IntervalMonth
+		buckets = days/28 + 1
+	case repointerface.IntervalWeek:
+		buckets = days/7 + 1
+	case repointerface.IntervalDay:
+		buckets = days
+	default:
+		return "", ErrInvalidInterval
+	}
+	if buckets > maxBuckets {
+		return "", ErrTooManyBuckets
+	}
+	return interval, nil
+}
@@ -0,0 +1,68 @@
+package dashboard
+
+import (
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
+	"math"
+	"strings"
+	"time"
+)
+
+// defaultWeeklyCapacityHours are the teaching hours a week counted as 100% utilisation, per employment type
+var defaultWeeklyCapacityHours = map[string]float64{
+	"full_time": 24,
+	"part_time": 12,
+}
+
+// TeacherUtilisation is the teaching load of one teacher against their capacity over the period
+type TeacherUtilisation struct {
+	TeacherID      string
+	TeacherCode    string
+	TeacherName    string
+	EmploymentType string
+	Lessons        int64
+	Hours          float64
+	CapacityHours  float64 // zero when no capacity is configured for the employment type
+	Utilisation    float64 // percent of capacity, may exceed 100
+}
+
+// weeklyCapacity reads "dashboard.weekly_capacity_hours", keyed by lower-case employment type
+func weeklyCapacity(configManager config.Manager) map[string]float64 {
+	capacity := make(map[string]float64, len(defaultWeeklyCapacityHours))
+	for kind, hours := range defaultWeeklyCapacityHours {
+		capacity[kind] = hours
+	}
+	configured := map[string]float64{}
+	if err := configManager.UnmarshalKey("dashboard.weekly_capacity_hours", &configured); err == nil {
+		for kind, hours := range configured {
+			capacity[strings.ToLower(kind)] = hours
+		}
+	}
+	return capacity
+}
+
+// utilisationOf converts lesson minutes to hours and compares them with the capacity of the period
+func utilisationOf(rows []*repointerface.TeacherHoursRow, from, to time.Time, weekly map[string]float64) []TeacherUtilisation {
+	weeks := to.Sub(from).Hours() / (24 * 7)
+	result := make([]TeacherUtilisation, 0, len(rows))
+	for _, row := range rows {
+		u := TeacherUtilisation{
+			TeacherID:      row.TeacherID,
+			TeacherCode:    row.TeacherCode,
+			TeacherName:    row.TeacherName,
+			EmploymentType: row.EmploymentType,
+			Lessons:        row.Lessons,
+			Hours:          round2(float64(row.Minutes) / 60),
+		}
+		if hours := weekly[strings.ToLower(row.EmploymentType)]; hours > 0 {
+			u.CapacityHours = round2(hours * weeks)
+			u.Utilisation = round2(float64(row.Minutes) / 60 / (hours * weeks) * 100)
+		}
+		result = append(result, u)
+	}
+	return result
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,218 @@
+package enrollment
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/billing"
+	"doan/internal/services/regulation"
+	"doan/pkg/logger"
+	"time"
+)
+
+// ApproveEnrollmentInput represents the enrollment to approve and how its tuition is billed
+type ApproveEnrollmentInput struct {
+	ID            string
+	BillingPlan   string // FULL (default) or MONTHLY
+	Installments  int    // MONTHLY only, 0 derives it from the class period
+	DiscountCodes []string
+}
+
+// ApproveEnrollmentOutput represents the approved enrollment, its invoices and remaining warnings
+type ApproveEnrollmentOutput struct {
+	Enrollment *entities.Enrollment
+	Invoices   []*entities.Invoice
+	Compliance *regulation.Report
+}
+
+// ApproveEnrollmentUseCase approves an application unless the class is full or Circular 29 ERROR rules are broken,
+// and issues the tuition invoices in the same transaction
+type ApproveEnrollmentUseCase interface {
+	Execute(ctx context.Context, input ApproveEnrollmentInput) (*ApproveEnrollmentOutput, error)
+}
+
+type approveEnrollmentUseCase struct {
+	enrollmentRepo repointerface.EnrollmentRepository
+	classRepo      repointerface.ClassRepository
+	studentRepo    repointerface.StudentRepository
+	courseRepo     repointerface.CourseRepository
+	invoiceRepo    repointerface.InvoiceRepository
+	loader         subjectLoader
+	ruleEngine     regulation.Engine
+	planner        billing.Planner
+	uow            repositories.UnitOfWork
+	log            logger.Logger
+}
+
+// NewApproveEnrollmentUseCase creates a new instance of ApproveEnrollmentUseCase
+func NewApproveEnrollmentUseCase(
+	enrollmentRepo repointerface.EnrollmentRepository,
+	classRepo repointerface.ClassRepository,
+	studentRepo repointerface.StudentRepository,
+	teacherRepo repointerface.TeacherRepository,
+	courseRepo repointerface.CourseRepository,
+	invoiceRepo repointerface.InvoiceRepository,
+	ruleEngine regulation.Engine,
+	planner billing.Planner,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) ApproveEnrollmentUseCase {
+	return &approveEnrollmentUseCase{
+		enrollmentRepo: enrollmentRepo,
+		classRepo:      classRepo,
+		studentRepo:    studentRepo,
+		courseRepo:     courseRepo,
+		invoiceRepo:    invoiceRepo,
+		loader:         subjectLoader{classRepo: classRepo, teacherRepo: teacherRepo, enrollmentRepo: enrollmentRepo},
+		ruleEngine:     ruleEngine,
+		planner:        planner,
+		uow:            uow,
+		log:            log,
+	}
+}
+
+func (uc *approveEnrollmentUseCase) Execute(ctx context.Context, input ApproveEnrollmentInput) (*ApproveEnrollmentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	enrollment, err := uc.enrollmentRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get enrollment: %v", err)
+		return nil, err
+	}
+	if enrollment == nil {
+		return nil, ErrEnrollmentNotFound
+	}
+	if enrollment.Status != entities.EnrollmentApplied {
+		return nil, ErrNotPending
+	}
+
+	class, err := uc.classRepo.GetByID(ctx, enrollment.ClassID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get class: %v", err)
+		return nil, err
+	}
+	if class == nil {
+		return nil, ErrClassNotFound
+	}
+	if class.Status != entities.ClassStatusOpen {
+		return nil, ErrClassNotOpen
+	}
+	student, err := uc.studentRepo.GetByID(ctx, enrollment.StudentID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get student: %v", err)
+		return nil, err
+	}
+	if student == nil {
+		return nil, ErrStudentNotFound
+	}
+
+	subject, err := uc.loader.load(ctx, class, student)
+	if err != nil {
+		ctxLogger.Errorf("Failed to load compliance subject: %v", err)
+		return nil, err
+	}
+	report, err := uc.ruleEngine.Enforce(ctx, subject)
+	if err != nil {
+		ctxLogger.Warnf("Enrollment %s blocked by Circular 29 rules: %v", enrollment.ID, err)
+		return nil, err
+	}
+
+	now := time.Now()
+	invoices, err := uc.planInvoices(ctx, input, class, student, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		// The class row lock serialises approvals into the class, so the count below cannot go stale
+		// before this approval commits
+		locked, err := uc.classRepo.LockByID(txCtx, class.ID)
+		if err != nil {
+			return nil, err
+		}
+		if locked == nil {
+			return nil, ErrClassNotFound
+		}
+		if locked.Status != entities.ClassStatusOpen {
+			return nil, ErrClassNotOpen
+		}
+		if locked.MaxStudents > 0 {
+			approved, err := uc.enrollmentRepo.CountApproved(txCtx, class.ID)
+			if err != nil {
+				return nil, err
+			}
+			if approved >= int64(locked.MaxStudents) {
+				return nil, ErrClassFull
+			}
+		}
+		// The status check above ran outside the transaction; only one request gets to move it off APPLIED
+		decided, err := uc.enrollmentRepo.Decide(txCtx, enrollment.ID, entities.EnrollmentApproved, now)
+		if err != nil {
+			return nil, err
+		}
+		if !decided {
+			return nil, ErrNotPending
+		}
+
+		for _, invoice := range invoices {
+			number, err := uc.invoiceRepo.NextNumber(txCtx, now)
+			if err != nil {
+				return nil, err
+			}
+			invoice.Number = number
+			invoice.EnrollmentID = enrollment.ID
+			invoice.StudentID = student.ID
+			invoice.ClassID = class.ID
+			if _, err := uc.invoiceRepo.Create(txCtx, invoice); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to approve enrollment %s: %v", enrollment.ID, err)
+		return nil, err
+	}
+
+	enrollment.Status = entities.EnrollmentApproved
+	enrollment.ApprovedAt = &now
+	return &ApproveEnrollmentOutput{Enrollment: enrollment, Invoices: invoices, Compliance: report}, nil
+}
+
+// planInvoices prices the enrollment from the class, or its course when the class has no price; free classes get no invoice
+func (uc *approveEnrollmentUseCase) planInvoices(ctx context.Context, input ApproveEnrollmentInput, class *entities.Class, student *entities.Student, now time.Time) ([]*entities.Invoice, error) {
+	price := class.Price
+	if price.IsZero() && class.CourseID != nil {
+		course, err := uc.courseRepo.GetByID(ctx, *class.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		if course != nil {
+			price = course.Price
+		}
+	}
+	if !price.IsPositive() {
+		return nil, nil
+	}
+
+	hasSibling, err := uc.enrollmentRepo.HasSiblingEnrollment(ctx, student.ID, student.GuardianPhone)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.planner.Plan(billing.PlanInput{
+		ClassName:    class.Name,
+		Price:        price,
+		Plan:         input.BillingPlan,
+		Installments: input.Installments,
+		IssuedAt:     now,
+		ClassStart:   class.StartDate,
+		ClassEnd:     class.EndDate,
+		Discounts: billing.DiscountContext{
+			HasSibling: hasSibling,
+			ClassStart: class.StartDate,
+			Codes:      input.DiscountCodes,
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package enrollment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/regulation"
+	"doan/pkg/logger"
+)
+
+// CreateEnrollmentInput represents a student applying to a class
+type CreateEnrollmentInput struct {
+	ClassID   string
+	StudentID string
+}
+
+// CreateEnrollmentOutput represents the application and a preview of its compliance check
+type CreateEnrollmentOutput struct {
+	Enrollment *entities.Enrollment
+	Compliance *regulation.Report
+}
+
+// CreateEnrollmentUseCase records an application; rules are only enforced on approval
+type CreateEnrollmentUseCase interface {
+	Execute(ctx context.Context, input CreateEnrollmentInput) (*CreateEnrollmentOutput, error)
+}
+
+type createEnrollmentUseCase struct {
+	enrollmentRepo repointerface.EnrollmentRepository
+	classRepo      repointerface.ClassRepository
+	studentRepo    repointerface.StudentRepository
+	loader         subjectLoader
+	ruleEngine     regulation.Engine
+}
+
+// NewCreateEnrollmentUseCase creates a new instance of CreateEnrollmentUseCase
+func NewCreateEnrollmentUseCase(
+	enrollmentRepo repointerface.EnrollmentRepository,
+	classRepo repointerface.ClassRepository,
+	studentRepo repointerface.StudentRepository,
+	teacherRepo repointerface.TeacherRepository,
+	ruleEngine regulation.Engine,
+) CreateEnrollmentUseCase {
+	return &createEnrollmentUseCase{
+		enrollmentRepo: enrollmentRepo,
+		classRepo:      classRepo,
+		studentRepo:    studentRepo,
+		loader:         subjectLoader{classRepo: classRepo, teacherRepo: teacherRepo, enrollmentRepo: enrollmentRepo},
+		ruleEngine:     ruleEngine,
+	}
+}
+
+func (uc *createEnrollmentUseCase) Execute(ctx context.Context, input CreateEnrollmentInput) (*CreateEnrollmentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	class, err := uc.classRepo.GetByID(ctx, input.ClassID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get class: %v", err)
+		return nil, err
+	}
+	if class == nil {
+		return nil, ErrClassNotFound
+	}
+	if class.Status != entities.ClassStatusOpen {
+		return nil, ErrClassNotOpen
+	}
+	student, err := uc.studentRepo.GetByID(ctx, input.StudentID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get student: %v", err)
+		return nil, err
+	}
+	if student == nil {
+		return nil, ErrStudentNotFound
+	}
+
+	existing, err := uc.enrollmentRepo.GetActive(ctx, class.ID, student.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to check existing enrollment: %v", err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyEnrolled
+	}
+
+	subject, err := uc.loader.load(ctx, class, student)
+	if err != nil {
+		ctxLogger.Errorf("Failed to load compliance subject: %v", err)
+		return nil, err
+	}
+
+	enrollment, err := uc.enrollmentRepo.Create(ctx, &entities.Enrollment{
+		ClassID:   class.ID,
+		StudentID: student.ID,
+		Status:    entities.EnrollmentApplied,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create enrollment: %v", err)
+		return nil, err
+	}
+
+	return &CreateEnrollmentOutput{
+		Enrollment: enrollment,
+		Compliance: uc.ruleEngine.Evaluate(ctx, subject),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package enrollment
+
+import "errors"
+
+var (
+	ErrEnrollmentNotFound = errors.New("enrollment not found")
+	ErrClassNotFound      = errors.New("class not found")
+	ErrStudentNotFound    = errors.New("student not found")
+	ErrClassNotOpen       = errors.New("class is not open for enrollment")
+	ErrClassFull          = errors.New("class has reached its maximum number of students")
+	ErrAlreadyEnrolled    = errors.New("student already has an active enrollment in this class")
+	ErrNotPending         = errors.New("only applied enrollments can be approved or rejected")
+)
@@ -0,0 +1,69 @@
+package enrollment
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// ListEnrollmentsInput represents the enrollment list filters
+type ListEnrollmentsInput struct {
+	ClassID   string
+	StudentID string
+	Status    string
+	Page      int
+	Limit     int
+}
+
+// ListEnrollmentsOutput represents one page of enrollments
+type ListEnrollmentsOutput struct {
+	Enrollments []*entities.Enrollment
+	Pagination  *repositories.Meta
+}
+
+// ListEnrollmentsUseCase lists enrollments by class, student and status
+type ListEnrollmentsUseCase interface {
+	Execute(ctx context.Context, input ListEnrollmentsInput) (*ListEnrollmentsOutput, error)
+}
+
+type listEnrollmentsUseCase struct {
+	enrollmentRepo repointerface.EnrollmentRepository
+}
+
+// NewListEnrollmentsUseCase creates a new instance of ListEnrollmentsUseCase
+func NewListEnrollmentsUseCase(enrollmentRepo repointerface.EnrollmentRepository) ListEnrollmentsUseCase {
+	return &listEnrollmentsUseCase{
+		enrollmentRepo: enrollmentRepo,
+	}
+}
+
+func (uc *listEnrollmentsUseCase) Execute(ctx context.Context, input ListEnrollmentsInput) (*ListEnrollmentsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.enrollmentRepo.List(ctx, repointerface.EnrollmentFilter{
+		ClassID:   input.ClassID,
+		StudentID: input.StudentID,
+		Status:    strings.ToUpper(input.Status),
+		Page:      uint64(input.Page),
+		Limit:     uint64(input.Limit),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list enrollments: %v", err)
+		return nil, err
+	}
+
+	return &ListEnrollmentsOutput{
+		Enrollments: result.Data,
+		Pagination:  &result.Meta,
+	}, nil
+}
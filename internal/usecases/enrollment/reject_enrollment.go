@@ -0,0 +1,66 @@
+package enrollment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"time"
+)
+
+// RejectEnrollmentInput represents the enrollment to reject
+type RejectEnrollmentInput struct {
+	ID string
+}
+
+// RejectEnrollmentOutput represents the rejected enrollment
+type RejectEnrollmentOutput struct {
+	Enrollment *entities.Enrollment
+}
+
+// RejectEnrollmentUseCase rejects a pending application
+type RejectEnrollmentUseCase interface {
+	Execute(ctx context.Context, input RejectEnrollmentInput) (*RejectEnrollmentOutput, error)
+}
+
+type rejectEnrollmentUseCase struct {
+	enrollmentRepo repointerface.EnrollmentRepository
+}
+
+// NewRejectEnrollmentUseCase creates a new instance of RejectEnrollmentUseCase
+func NewRejectEnrollmentUseCase(enrollmentRepo repointerface.EnrollmentRepository) RejectEnrollmentUseCase {
+	return &rejectEnrollmentUseCase{
+		enrollmentRepo: enrollmentRepo,
+	}
+}
+
+func (uc *rejectEnrollmentUseCase) Execute(ctx context.Context, input RejectEnrollmentInput) (*RejectEnrollmentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	enrollment, err := uc.enrollmentRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get enrollment: %v", err)
+		return nil, err
+	}
+	if enrollment == nil {
+		return nil, ErrEnrollmentNotFound
+	}
+	if enrollment.Status != entities.EnrollmentApplied {
+		return nil, ErrNotPending
+	}
+
+	now := time.Now()
+	decided, err := uc.enrollmentRepo.Decide(ctx, enrollment.ID, entities.EnrollmentRejected, now)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reject enrollment %s: %v", enrollment.ID, err)
+		return nil, err
+	}
+	if !decided {
+		// Approved or rejected by a concurrent request since it was read
+		return nil, ErrNotPending
+	}
+
+	enrollment.Status = entities.EnrollmentRejected
+	enrollment.RejectedAt = &now
+	return &RejectEnrollmentOutput{Enrollment: enrollment}, nil
+}
@@ -0,0 +1,44 @@
+package enrollment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/regulation"
+)
+
+// subjectLoader gathers what the Circular 29 rules need to judge a student joining a class
+type subjectLoader struct {
+	classRepo      repointerface.ClassRepository
+	teacherRepo    repointerface.TeacherRepository
+	enrollmentRepo repointerface.EnrollmentRepository
+}
+
+func (l subjectLoader) load(ctx context.Context, class *entities.Class, student *entities.Student) (regulation.Subject, error) {
+	subject := regulation.Subject{Class: class, Student: student}
+
+	if class.TeacherID != nil {
+		teacher, err := l.teacherRepo.GetByID(ctx, *class.TeacherID)
+		if err != nil {
+			return subject, err
+		}
+		subject.Teacher = teacher
+	}
+
+	otherClassIDs, err := l.enrollmentRepo.ListActiveClassIDs(ctx, student.ID, class.ID)
+	if err != nil {
+		return subject, err
+	}
+	schedules, err := l.classRepo.ListSchedules(ctx, append(otherClassIDs, class.ID))
+	if err != nil {
+		return subject, err
+	}
+	for _, s := range schedules {
+		if s.ClassID == class.ID {
+			subject.Schedules = append(subject.Schedules, *s)
+		} else {
+			subject.StudentSchedules = append(subject.StudentSchedules, *s)
+		}
+	}
+	return subject, nil
+}
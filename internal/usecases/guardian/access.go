@@ -0,0 +1,99 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"strings"
+	"time"
+)
+
+// maxPeriodDays bounds the portal lists so a family cannot page through years of lessons in one call
+const maxPeriodDays = 366
+
+// ChildInput identifies one child of the signed-in guardian
+type ChildInput struct {
+	UserID    string
+	StudentID string
+}
+
+// PeriodInput is an inclusive date range in Vietnam time; empty ends fall back to the use case default
+type PeriodInput struct {
+	From string // YYYY-MM-DD
+	To   string // YYYY-MM-DD, inclusive
+}
+
+// guardianOfUser returns the guardian profile linked to the signed-in account
+func guardianOfUser(ctx context.Context, guardianRepo repointerface.GuardianRepository, userID string) (*entities.Guardian, error) {
+	guardian, err := guardianRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to get guardian by user: %v", err)
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, ErrNoGuardianProfile
+	}
+	return guardian, nil
+}
+
+// authorizeChild checks the student is linked to the guardian profile of the signed-in account.
+// Role checks only say the caller is a guardian; this is what keeps them to their own children.
+func authorizeChild(ctx context.Context, guardianRepo repointerface.GuardianRepository, input ChildInput) error {
+	guardian, err := guardianOfUser(ctx, guardianRepo, input.UserID)
+	if err != nil {
+		return err
+	}
+	linked, err := guardianRepo.IsLinked(ctx, guardian.ID, input.StudentID)
+	if err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to check guardian link: %v", err)
+		return err
+	}
+	if !linked {
+		return ErrNotGuardianOfStudent
+	}
+	return nil
+}
+
+// resolvePeriod converts the dates to [from 00:00, day after to 00:00). Missing ends default to
+// backDays before today and aheadDays after today.
+func resolvePeriod(period PeriodInput, backDays, aheadDays int) (time.Time, time.Time, error) {
+	loc := utils.VietnamLocation()
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	from := today.AddDate(0, 0, -backDays)
+	if period.From != "" {
+		t, err := time.ParseInLocation(constants.DateOnly, period.From, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		from = t
+	}
+	to := today.AddDate(0, 0, aheadDays+1)
+	if period.To != "" {
+		t, err := time.ParseInLocation(constants.DateOnly, period.To, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+	if !from.Before(to) || to.Sub(from) > maxPeriodDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	return from, to, nil
+}
+
+// validRelationship normalises the relationship, defaulting to GUARDIAN
+func validRelationship(relationship string) (string, bool) {
+	relationship = strings.ToUpper(strings.TrimSpace(relationship))
+	switch relationship {
+	case "":
+		return entities.RelationshipGuardian, true
+	case entities.RelationshipFather, entities.RelationshipMother, entities.RelationshipGuardian:
+		return relationship, true
+	}
+	return "", false
+}
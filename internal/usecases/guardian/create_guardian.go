@@ -0,0 +1,63 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// CreateGuardianInput represents a new guardian contact
+type CreateGuardianInput struct {
+	FullName string
+	Phone    string
+	Email    string
+	Notes    string
+}
+
+// CreateGuardianOutput represents the created guardian
+type CreateGuardianOutput struct {
+	Guardian *entities.Guardian
+}
+
+// CreateGuardianUseCase creates a guardian; children and a login are linked separately
+type CreateGuardianUseCase interface {
+	Execute(ctx context.Context, input CreateGuardianInput) (*CreateGuardianOutput, error)
+}
+
+type createGuardianUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewCreateGuardianUseCase creates a new instance of CreateGuardianUseCase
+func NewCreateGuardianUseCase(guardianRepo repointerface.GuardianRepository) CreateGuardianUseCase {
+	return &createGuardianUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *createGuardianUseCase) Execute(ctx context.Context, input CreateGuardianInput) (*CreateGuardianOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	guardian := &entities.Guardian{
+		FullName: strings.TrimSpace(input.FullName),
+		Phone:    strings.TrimSpace(input.Phone),
+		Email:    strings.TrimSpace(input.Email),
+		Notes:    strings.TrimSpace(input.Notes),
+	}
+	if guardian.FullName == "" {
+		return nil, ErrFullNameRequired
+	}
+	if guardian.Phone == "" {
+		return nil, ErrPhoneRequired
+	}
+
+	created, err := uc.guardianRepo.Create(ctx, guardian)
+	if err != nil {
+		ctxLogger.Errorf("Failed to create guardian: %v", err)
+		return nil, err
+	}
+
+	return &CreateGuardianOutput{Guardian: created}, nil
+}
@@ -0,0 +1,48 @@
+package guardian
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// DeleteGuardianInput identifies the guardian to delete
+type DeleteGuardianInput struct {
+	ID string
+}
+
+// DeleteGuardianUseCase soft-deletes a guardian. Their links stay so a restore keeps the children,
+// but a deleted guardian no longer resolves from their login and loses portal access.
+type DeleteGuardianUseCase interface {
+	Execute(ctx context.Context, input DeleteGuardianInput) error
+}
+
+type deleteGuardianUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewDeleteGuardianUseCase creates a new instance of DeleteGuardianUseCase
+func NewDeleteGuardianUseCase(guardianRepo repointerface.GuardianRepository) DeleteGuardianUseCase {
+	return &deleteGuardianUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *deleteGuardianUseCase) Execute(ctx context.Context, input DeleteGuardianInput) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	guardian, err := uc.guardianRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian: %v", err)
+		return err
+	}
+	if guardian == nil {
+		return ErrGuardianNotFound
+	}
+
+	if err := uc.guardianRepo.SoftDelete(ctx, guardian.ID); err != nil {
+		ctxLogger.Errorf("Failed to delete guardian: %v", err)
+		return err
+	}
+	return nil
+}
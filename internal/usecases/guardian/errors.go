@@ -0,0 +1,18 @@
+package guardian
+
+import "errors"
+
+var (
+	ErrGuardianNotFound     = errors.New("guardian not found")
+	ErrStudentNotFound      = errors.New("student not found")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrFullNameRequired     = errors.New("full name is required")
+	ErrPhoneRequired        = errors.New("phone is required")
+	ErrInvalidRelationship  = errors.New("relationship must be FATHER, MOTHER or GUARDIAN")
+	ErrStudentNotLinked     = errors.New("student is not linked to this guardian")
+	ErrUserAlreadyLinked    = errors.New("this account is already linked to another guardian")
+	ErrAccountRoleConflict  = errors.New("staff accounts cannot be linked to a guardian")
+	ErrNoGuardianProfile    = errors.New("no guardian profile is linked to this account")
+	ErrNotGuardianOfStudent = errors.New("you can only view your own children")
+	ErrInvalidPeriod        = errors.New("invalid period, expected YYYY-MM-DD dates spanning at most a year")
+)
@@ -0,0 +1,59 @@
+package guardian
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetChildAttendanceInput selects a period of one child's attendance
+type GetChildAttendanceInput struct {
+	ChildInput
+	Period PeriodInput
+}
+
+// GetChildAttendanceOutput represents the child's attendance
+type GetChildAttendanceOutput struct {
+	Entries []*repointerface.AttendanceEntry
+}
+
+// GetChildAttendanceUseCase lists the attendance of one child, by default over the last 90 days
+type GetChildAttendanceUseCase interface {
+	Execute(ctx context.Context, input GetChildAttendanceInput) (*GetChildAttendanceOutput, error)
+}
+
+type getChildAttendanceUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	portalRepo   repointerface.StudentPortalRepository
+}
+
+// NewGetChildAttendanceUseCase creates a new instance of GetChildAttendanceUseCase
+func NewGetChildAttendanceUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	portalRepo repointerface.StudentPortalRepository,
+) GetChildAttendanceUseCase {
+	return &getChildAttendanceUseCase{
+		guardianRepo: guardianRepo,
+		portalRepo:   portalRepo,
+	}
+}
+
+func (uc *getChildAttendanceUseCase) Execute(ctx context.Context, input GetChildAttendanceInput) (*GetChildAttendanceOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := authorizeChild(ctx, uc.guardianRepo, input.ChildInput); err != nil {
+		return nil, err
+	}
+	from, to, err := resolvePeriod(input.Period, 90, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.portalRepo.ListAttendance(ctx, input.StudentID, from, to)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child attendance: %v", err)
+		return nil, err
+	}
+
+	return &GetChildAttendanceOutput{Entries: entries}, nil
+}
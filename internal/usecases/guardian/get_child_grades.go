@@ -0,0 +1,59 @@
+package guardian
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetChildGradesInput selects a period of one child's grades
+type GetChildGradesInput struct {
+	ChildInput
+	Period PeriodInput
+}
+
+// GetChildGradesOutput represents the child's grades
+type GetChildGradesOutput struct {
+	Entries []*repointerface.GradeEntry
+}
+
+// GetChildGradesUseCase lists the lesson grades of one child, by default over the last 90 days
+type GetChildGradesUseCase interface {
+	Execute(ctx context.Context, input GetChildGradesInput) (*GetChildGradesOutput, error)
+}
+
+type getChildGradesUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	portalRepo   repointerface.StudentPortalRepository
+}
+
+// NewGetChildGradesUseCase creates a new instance of GetChildGradesUseCase
+func NewGetChildGradesUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	portalRepo repointerface.StudentPortalRepository,
+) GetChildGradesUseCase {
+	return &getChildGradesUseCase{
+		guardianRepo: guardianRepo,
+		portalRepo:   portalRepo,
+	}
+}
+
+func (uc *getChildGradesUseCase) Execute(ctx context.Context, input GetChildGradesInput) (*GetChildGradesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := authorizeChild(ctx, uc.guardianRepo, input.ChildInput); err != nil {
+		return nil, err
+	}
+	from, to, err := resolvePeriod(input.Period, 90, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.portalRepo.ListGrades(ctx, input.StudentID, from, to)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child grades: %v", err)
+		return nil, err
+	}
+
+	return &GetChildGradesOutput{Entries: entries}, nil
+}
@@ -0,0 +1,59 @@
+package guardian
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetChildTimetableInput selects a period of one child's timetable
+type GetChildTimetableInput struct {
+	ChildInput
+	Period PeriodInput
+}
+
+// GetChildTimetableOutput represents the child's timetable
+type GetChildTimetableOutput struct {
+	Entries []*repointerface.TimetableEntry
+}
+
+// GetChildTimetableUseCase lists the lessons of one child, by default from today for two weeks
+type GetChildTimetableUseCase interface {
+	Execute(ctx context.Context, input GetChildTimetableInput) (*GetChildTimetableOutput, error)
+}
+
+type getChildTimetableUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	portalRepo   repointerface.StudentPortalRepository
+}
+
+// NewGetChildTimetableUseCase creates a new instance of GetChildTimetableUseCase
+func NewGetChildTimetableUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	portalRepo repointerface.StudentPortalRepository,
+) GetChildTimetableUseCase {
+	return &getChildTimetableUseCase{
+		guardianRepo: guardianRepo,
+		portalRepo:   portalRepo,
+	}
+}
+
+func (uc *getChildTimetableUseCase) Execute(ctx context.Context, input GetChildTimetableInput) (*GetChildTimetableOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := authorizeChild(ctx, uc.guardianRepo, input.ChildInput); err != nil {
+		return nil, err
+	}
+	from, to, err := resolvePeriod(input.Period, 0, 13)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.portalRepo.ListTimetable(ctx, input.StudentID, from, to)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child timetable: %v", err)
+		return nil, err
+	}
+
+	return &GetChildTimetableOutput{Entries: entries}, nil
+}
@@ -0,0 +1,49 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetGuardianInput identifies the guardian to get
+type GetGuardianInput struct {
+	ID string
+}
+
+// GetGuardianOutput represents the guardian with their children and linked account
+type GetGuardianOutput struct {
+	Guardian *entities.Guardian
+}
+
+// GetGuardianUseCase gets a guardian with their children and linked account
+type GetGuardianUseCase interface {
+	Execute(ctx context.Context, input GetGuardianInput) (*GetGuardianOutput, error)
+}
+
+type getGuardianUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewGetGuardianUseCase creates a new instance of GetGuardianUseCase
+func NewGetGuardianUseCase(guardianRepo repointerface.GuardianRepository) GetGuardianUseCase {
+	return &getGuardianUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *getGuardianUseCase) Execute(ctx context.Context, input GetGuardianInput) (*GetGuardianOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	guardian, err := uc.guardianRepo.GetWithStudents(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian: %v", err)
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, ErrGuardianNotFound
+	}
+
+	return &GetGuardianOutput{Guardian: guardian}, nil
+}
@@ -0,0 +1,54 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetMyChildrenInput identifies the signed-in guardian
+type GetMyChildrenInput struct {
+	UserID string
+}
+
+// GetMyChildrenOutput represents the guardian profile with their children
+type GetMyChildrenOutput struct {
+	Guardian *entities.Guardian
+}
+
+// GetMyChildrenUseCase returns the guardian profile of the signed-in account with their children
+type GetMyChildrenUseCase interface {
+	Execute(ctx context.Context, input GetMyChildrenInput) (*GetMyChildrenOutput, error)
+}
+
+type getMyChildrenUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewGetMyChildrenUseCase creates a new instance of GetMyChildrenUseCase
+func NewGetMyChildrenUseCase(guardianRepo repointerface.GuardianRepository) GetMyChildrenUseCase {
+	return &getMyChildrenUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *getMyChildrenUseCase) Execute(ctx context.Context, input GetMyChildrenInput) (*GetMyChildrenOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	guardian, err := guardianOfUser(ctx, uc.guardianRepo, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	guardian, err = uc.guardianRepo.GetWithStudents(ctx, guardian.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian children: %v", err)
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, ErrNoGuardianProfile
+	}
+
+	return &GetMyChildrenOutput{Guardian: guardian}, nil
+}
@@ -0,0 +1,128 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+)
+
+// LinkAccountInput represents the login a guardian will use for the portal
+type LinkAccountInput struct {
+	GuardianID string
+	UserID     string
+	ActorID    string
+	ActorRole  string
+}
+
+// LinkAccountOutput represents the guardian with their linked account
+type LinkAccountOutput struct {
+	Guardian *entities.Guardian
+}
+
+// LinkAccountUseCase links a user account to a guardian and gives it the GUARDIAN role.
+// Staff accounts are refused so a role change here cannot demote an admin or teacher.
+type LinkAccountUseCase interface {
+	Execute(ctx context.Context, input LinkAccountInput) (*LinkAccountOutput, error)
+}
+
+type linkAccountUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	userRepo     repointerface.UserRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewLinkAccountUseCase creates a new instance of LinkAccountUseCase
+func NewLinkAccountUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	userRepo repointerface.UserRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) LinkAccountUseCase {
+	return &linkAccountUseCase{
+		guardianRepo: guardianRepo,
+		userRepo:     userRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *linkAccountUseCase) Execute(ctx context.Context, input LinkAccountInput) (*LinkAccountOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	_, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		guardian, err := uc.guardianRepo.GetByID(txCtx, input.GuardianID)
+		if err != nil {
+			return nil, err
+		}
+		if guardian == nil {
+			return nil, ErrGuardianNotFound
+		}
+
+		user, err := uc.userRepo.GetByID(txCtx, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		switch user.Role {
+		case constants.RoleAdmin, constants.RoleTeacher, constants.RoleCompliance:
+			return nil, ErrAccountRoleConflict
+		}
+
+		owner, err := uc.guardianRepo.GetByUserID(txCtx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if owner != nil && owner.ID != guardian.ID {
+			return nil, ErrUserAlreadyLinked
+		}
+
+		if err := uc.guardianRepo.Update(txCtx, guardian.ID, map[string]interface{}{"user_id": user.ID}); err != nil {
+			return nil, err
+		}
+		if user.Role != constants.RoleGuardian {
+			if err := uc.userRepo.Update(txCtx, user.ID, map[string]interface{}{"role": constants.RoleGuardian}); err != nil {
+				return nil, err
+			}
+		}
+
+		var actorID *string
+		if input.ActorID != "" {
+			actorID = &input.ActorID
+		}
+		if _, err := uc.auditLogRepo.Create(txCtx, &entities.AuditLog{
+			ActorID:    actorID,
+			ActorRole:  input.ActorRole,
+			Action:     entities.AuditActionGuardianLink,
+			EntityType: entities.AuditEntityGuardian,
+			EntityID:   guardian.ID,
+			Metadata: entities.JSONMap{
+				"user_id":       user.ID,
+				"previous_role": user.Role,
+			},
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to link account to guardian %s: %v", input.GuardianID, err)
+		return nil, err
+	}
+
+	guardian, err := uc.guardianRepo.GetWithStudents(ctx, input.GuardianID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reload guardian: %v", err)
+		return nil, err
+	}
+
+	return &LinkAccountOutput{Guardian: guardian}, nil
+}
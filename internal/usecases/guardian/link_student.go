@@ -0,0 +1,87 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// LinkStudentInput represents a child of the guardian; linking again updates the relationship
+type LinkStudentInput struct {
+	GuardianID   string
+	StudentID    string
+	Relationship string
+	IsPrimary    bool
+}
+
+// LinkStudentOutput represents the guardian with their children
+type LinkStudentOutput struct {
+	Guardian *entities.Guardian
+}
+
+// LinkStudentUseCase links a student to a guardian, giving the guardian portal access to that student
+type LinkStudentUseCase interface {
+	Execute(ctx context.Context, input LinkStudentInput) (*LinkStudentOutput, error)
+}
+
+type linkStudentUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	studentRepo  repointerface.StudentRepository
+}
+
+// NewLinkStudentUseCase creates a new instance of LinkStudentUseCase
+func NewLinkStudentUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	studentRepo repointerface.StudentRepository,
+) LinkStudentUseCase {
+	return &linkStudentUseCase{
+		guardianRepo: guardianRepo,
+		studentRepo:  studentRepo,
+	}
+}
+
+func (uc *linkStudentUseCase) Execute(ctx context.Context, input LinkStudentInput) (*LinkStudentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	relationship, ok := validRelationship(input.Relationship)
+	if !ok {
+		return nil, ErrInvalidRelationship
+	}
+
+	guardian, err := uc.guardianRepo.GetByID(ctx, input.GuardianID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian: %v", err)
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, ErrGuardianNotFound
+	}
+
+	student, err := uc.studentRepo.GetByID(ctx, input.StudentID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get student: %v", err)
+		return nil, err
+	}
+	if student == nil {
+		return nil, ErrStudentNotFound
+	}
+
+	if err := uc.guardianRepo.LinkStudent(ctx, &entities.GuardianStudent{
+		GuardianID:   guardian.ID,
+		StudentID:    student.ID,
+		Relationship: relationship,
+		IsPrimary:    input.IsPrimary,
+	}); err != nil {
+		ctxLogger.Errorf("Failed to link student to guardian: %v", err)
+		return nil, err
+	}
+
+	guardian, err = uc.guardianRepo.GetWithStudents(ctx, guardian.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reload guardian: %v", err)
+		return nil, err
+	}
+
+	return &LinkStudentOutput{Guardian: guardian}, nil
+}
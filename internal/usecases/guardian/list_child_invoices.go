@@ -0,0 +1,76 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// ListChildInvoicesInput selects a page of one child's invoices
+type ListChildInvoicesInput struct {
+	ChildInput
+	Status string
+	Page   int
+	Limit  int
+}
+
+// ListChildInvoicesOutput represents one page of the child's invoices
+type ListChildInvoicesOutput struct {
+	Invoices   []*entities.Invoice
+	Pagination *repositories.Meta
+}
+
+// ListChildInvoicesUseCase lists the tuition invoices of one child
+type ListChildInvoicesUseCase interface {
+	Execute(ctx context.Context, input ListChildInvoicesInput) (*ListChildInvoicesOutput, error)
+}
+
+type listChildInvoicesUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	invoiceRepo  repointerface.InvoiceRepository
+}
+
+// NewListChildInvoicesUseCase creates a new instance of ListChildInvoicesUseCase
+func NewListChildInvoicesUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	invoiceRepo repointerface.InvoiceRepository,
+) ListChildInvoicesUseCase {
+	return &listChildInvoicesUseCase{
+		guardianRepo: guardianRepo,
+		invoiceRepo:  invoiceRepo,
+	}
+}
+
+func (uc *listChildInvoicesUseCase) Execute(ctx context.Context, input ListChildInvoicesInput) (*ListChildInvoicesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := authorizeChild(ctx, uc.guardianRepo, input.ChildInput); err != nil {
+		return nil, err
+	}
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.invoiceRepo.List(ctx, repointerface.InvoiceFilter{
+		StudentID: input.StudentID,
+		Status:    strings.ToUpper(input.Status),
+		Page:      uint64(input.Page),
+		Limit:     uint64(input.Limit),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child invoices: %v", err)
+		return nil, err
+	}
+
+	return &ListChildInvoicesOutput{
+		Invoices:   result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// ListChildLeaveRequestsInput selects a page of one child's leave requests
+type ListChildLeaveRequestsInput struct {
+	ChildInput
+	Page  int
+	Limit int
+}
+
+// ListChildLeaveRequestsOutput represents one page of the child's leave requests
+type ListChildLeaveRequestsOutput struct {
+	LeaveRequests []*entities.LeaveRequest
+	Pagination    *repositories.Meta
+}
+
+// ListChildLeaveRequestsUseCase lists the leave requests of one child, newest first
+type ListChildLeaveRequestsUseCase interface {
+	Execute(ctx context.Context, input ListChildLeaveRequestsInput) (*ListChildLeaveRequestsOutput, error)
+}
+
+type listChildLeaveRequestsUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+	portalRepo   repointerface.StudentPortalRepository
+}
+
+// NewListChildLeaveRequestsUseCase creates a new instance of ListChildLeaveRequestsUseCase
+func NewListChildLeaveRequestsUseCase(
+	guardianRepo repointerface.GuardianRepository,
+	portalRepo repointerface.StudentPortalRepository,
+) ListChildLeaveRequestsUseCase {
+	return &listChildLeaveRequestsUseCase{
+		guardianRepo: guardianRepo,
+		portalRepo:   portalRepo,
+	}
+}
+
+func (uc *listChildLeaveRequestsUseCase) Execute(ctx context.Context, input ListChildLeaveRequestsInput) (*ListChildLeaveRequestsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := authorizeChild(ctx, uc.guardianRepo, input.ChildInput); err != nil {
+		return nil, err
+	}
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.portalRepo.ListLeaveRequests(ctx, input.StudentID, uint64(input.Page), uint64(input.Limit))
+	if err != nil {
+		ctxLogger.Errorf("Failed to list child leave requests: %v", err)
+		return nil, err
+	}
+
+	return &ListChildLeaveRequestsOutput{
+		LeaveRequests: result.Data,
+		Pagination:    &result.Meta,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// ListGuardiansInput represents the guardian search
+type ListGuardiansInput struct {
+	Search string
+	Page   int
+	Limit  int
+}
+
+// ListGuardiansOutput represents one page of guardians
+type ListGuardiansOutput struct {
+	Guardians  []*entities.Guardian
+	Pagination *repositories.Meta
+}
+
+// ListGuardiansUseCase lists guardians by name, phone or email
+type ListGuardiansUseCase interface {
+	Execute(ctx context.Context, input ListGuardiansInput) (*ListGuardiansOutput, error)
+}
+
+type listGuardiansUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewListGuardiansUseCase creates a new instance of ListGuardiansUseCase
+func NewListGuardiansUseCase(guardianRepo repointerface.GuardianRepository) ListGuardiansUseCase {
+	return &listGuardiansUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *listGuardiansUseCase) Execute(ctx context.Context, input ListGuardiansInput) (*ListGuardiansOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.guardianRepo.List(ctx, strings.TrimSpace(input.Search), uint64(input.Page), uint64(input.Limit))
+	if err != nil {
+		ctxLogger.Errorf("Failed to list guardians: %v", err)
+		return nil, err
+	}
+
+	return &ListGuardiansOutput{
+		Guardians:  result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package guardian
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// UnlinkStudentInput identifies the link to remove
+type UnlinkStudentInput struct {
+	GuardianID string
+	StudentID  string
+}
+
+// UnlinkStudentUseCase removes a student from a guardian; the guardian loses portal access to them at once
+type UnlinkStudentUseCase interface {
+	Execute(ctx context.Context, input UnlinkStudentInput) error
+}
+
+type unlinkStudentUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewUnlinkStudentUseCase creates a new instance of UnlinkStudentUseCase
+func NewUnlinkStudentUseCase(guardianRepo repointerface.GuardianRepository) UnlinkStudentUseCase {
+	return &unlinkStudentUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *unlinkStudentUseCase) Execute(ctx context.Context, input UnlinkStudentInput) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	removed, err := uc.guardianRepo.UnlinkStudent(ctx, input.GuardianID, input.StudentID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to unlink student from guardian: %v", err)
+		return err
+	}
+	if !removed {
+		return ErrStudentNotLinked
+	}
+	return nil
+}
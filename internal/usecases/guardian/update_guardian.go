@@ -0,0 +1,86 @@
+package guardian
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// UpdateGuardianInput represents the fields to change; nil fields are kept
+type UpdateGuardianInput struct {
+	ID       string
+	FullName *string
+	Phone    *string
+	Email    *string
+	Notes    *string
+}
+
+// UpdateGuardianOutput represents the updated guardian
+type UpdateGuardianOutput struct {
+	Guardian *entities.Guardian
+}
+
+// UpdateGuardianUseCase changes the contact details of a guardian
+type UpdateGuardianUseCase interface {
+	Execute(ctx context.Context, input UpdateGuardianInput) (*UpdateGuardianOutput, error)
+}
+
+type updateGuardianUseCase struct {
+	guardianRepo repointerface.GuardianRepository
+}
+
+// NewUpdateGuardianUseCase creates a new instance of UpdateGuardianUseCase
+func NewUpdateGuardianUseCase(guardianRepo repointerface.GuardianRepository) UpdateGuardianUseCase {
+	return &updateGuardianUseCase{
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (uc *updateGuardianUseCase) Execute(ctx context.Context, input UpdateGuardianInput) (*UpdateGuardianOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	guardian, err := uc.guardianRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get guardian: %v", err)
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, ErrGuardianNotFound
+	}
+
+	updates := make(map[string]interface{})
+	if input.FullName != nil {
+		guardian.FullName = strings.TrimSpace(*input.FullName)
+		if guardian.FullName == "" {
+			return nil, ErrFullNameRequired
+		}
+		updates["full_name"] = guardian.FullName
+	}
+	if input.Phone != nil {
+		guardian.Phone = strings.TrimSpace(*input.Phone)
+		if guardian.Phone == "" {
+			return nil, ErrPhoneRequired
+		}
+		updates["phone"] = guardian.Phone
+	}
+	if input.Email != nil {
+		guardian.Email = strings.TrimSpace(*input.Email)
+		updates["email"] = guardian.Email
+	}
+	if input.Notes != nil {
+		guardian.Notes = strings.TrimSpace(*input.Notes)
+		updates["notes"] = guardian.Notes
+	}
+	if len(updates) == 0 {
+		return &UpdateGuardianOutput{Guardian: guardian}, nil
+	}
+
+	if err := uc.guardianRepo.Update(ctx, guardian.ID, updates); err != nil {
+		ctxLogger.Errorf("Failed to update guardian: %v", err)
+		return nil, err
+	}
+
+	return &UpdateGuardianOutput{Guardian: guardian}, nil
+}
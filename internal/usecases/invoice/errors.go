@@ -0,0 +1,9 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrInvoiceNotFound = errors.New("invoice not found")
+	ErrStudentNotFound = errors.New("student not found")
+	ErrInvalidFilter   = errors.New("either a student or a guardian phone is required")
+)
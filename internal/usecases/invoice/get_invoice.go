@@ -0,0 +1,49 @@
+package invoice
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetInvoiceInput represents the invoice to fetch
+type GetInvoiceInput struct {
+	ID string
+}
+
+// GetInvoiceOutput represents an invoice with its lines
+type GetInvoiceOutput struct {
+	Invoice *entities.Invoice
+}
+
+// GetInvoiceUseCase returns an invoice with its lines
+type GetInvoiceUseCase interface {
+	Execute(ctx context.Context, input GetInvoiceInput) (*GetInvoiceOutput, error)
+}
+
+type getInvoiceUseCase struct {
+	invoiceRepo repointerface.InvoiceRepository
+}
+
+// NewGetInvoiceUseCase creates a new instance of GetInvoiceUseCase
+func NewGetInvoiceUseCase(invoiceRepo repointerface.InvoiceRepository) GetInvoiceUseCase {
+	return &getInvoiceUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+func (uc *getInvoiceUseCase) Execute(ctx context.Context, input GetInvoiceInput) (*GetInvoiceOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	invoice, err := uc.invoiceRepo.GetWithLines(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get invoice: %v", err)
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, ErrInvoiceNotFound
+	}
+
+	return &GetInvoiceOutput{Invoice: invoice}, nil
+}
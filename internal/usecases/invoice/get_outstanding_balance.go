@@ -0,0 +1,84 @@
+package invoice
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"strings"
+	"time"
+)
+
+// GetOutstandingBalanceInput selects one student, or every student of a guardian by phone
+type GetOutstandingBalanceInput struct {
+	StudentID     string
+	GuardianPhone string
+}
+
+// GetOutstandingBalanceOutput represents per-student balances and their totals
+type GetOutstandingBalanceOutput struct {
+	Students    []*repointerface.StudentBalance
+	Billed      money.Amount
+	Paid        money.Amount
+	Outstanding money.Amount
+	Overdue     money.Amount
+}
+
+// GetOutstandingBalanceUseCase sums what a student or a guardian still owes
+type GetOutstandingBalanceUseCase interface {
+	Execute(ctx context.Context, input GetOutstandingBalanceInput) (*GetOutstandingBalanceOutput, error)
+}
+
+type getOutstandingBalanceUseCase struct {
+	invoiceRepo repointerface.InvoiceRepository
+	studentRepo repointerface.StudentRepository
+}
+
+// NewGetOutstandingBalanceUseCase creates a new instance of GetOutstandingBalanceUseCase
+func NewGetOutstandingBalanceUseCase(
+	invoiceRepo repointerface.InvoiceRepository,
+	studentRepo repointerface.StudentRepository,
+) GetOutstandingBalanceUseCase {
+	return &getOutstandingBalanceUseCase{
+		invoiceRepo: invoiceRepo,
+		studentRepo: studentRepo,
+	}
+}
+
+func (uc *getOutstandingBalanceUseCase) Execute(ctx context.Context, input GetOutstandingBalanceInput) (*GetOutstandingBalanceOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	input.GuardianPhone = strings.TrimSpace(input.GuardianPhone)
+	if (input.StudentID == "") == (input.GuardianPhone == "") {
+		return nil, ErrInvalidFilter
+	}
+	if input.StudentID != "" {
+		student, err := uc.studentRepo.GetByID(ctx, input.StudentID)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get student: %v", err)
+			return nil, err
+		}
+		if student == nil {
+			return nil, ErrStudentNotFound
+		}
+	}
+
+	balances, err := uc.invoiceRepo.ListOutstanding(ctx, repointerface.OutstandingFilter{
+		StudentID:     input.StudentID,
+		GuardianPhone: input.GuardianPhone,
+		AsOf:          time.Now(),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list outstanding balances: %v", err)
+		return nil, err
+	}
+
+	output := &GetOutstandingBalanceOutput{Students: balances}
+	for _, b := range balances {
+		output.Billed = output.Billed.Add(b.Billed)
+		output.Paid = output.Paid.Add(b.Paid)
+		output.Outstanding = output.Outstanding.Add(b.Outstanding)
+		output.Overdue = output.Overdue.Add(b.Overdue)
+	}
+	return output, nil
+}
@@ -0,0 +1,71 @@
+package invoice
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// ListInvoicesInput represents the invoice list filters
+type ListInvoicesInput struct {
+	StudentID    string
+	ClassID      string
+	EnrollmentID string
+	Status       string
+	Page         int
+	Limit        int
+}
+
+// ListInvoicesOutput represents one page of invoices
+type ListInvoicesOutput struct {
+	Invoices   []*entities.Invoice
+	Pagination *repositories.Meta
+}
+
+// ListInvoicesUseCase lists invoices by student, class, enrollment and status
+type ListInvoicesUseCase interface {
+	Execute(ctx context.Context, input ListInvoicesInput) (*ListInvoicesOutput, error)
+}
+
+type listInvoicesUseCase struct {
+	invoiceRepo repointerface.InvoiceRepository
+}
+
+// NewListInvoicesUseCase creates a new instance of ListInvoicesUseCase
+func NewListInvoicesUseCase(invoiceRepo repointerface.InvoiceRepository) ListInvoicesUseCase {
+	return &listInvoicesUseCase{
+		invoiceRepo: invoiceRepo,
+	}
+}
+
+func (uc *listInvoicesUseCase) Execute(ctx context.Context, input ListInvoicesInput) (*ListInvoicesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.invoiceRepo.List(ctx, repointerface.InvoiceFilter{
+		StudentID:    input.StudentID,
+		ClassID:      input.ClassID,
+		EnrollmentID: input.EnrollmentID,
+		Status:       strings.ToUpper(input.Status),
+		Page:         uint64(input.Page),
+		Limit:        uint64(input.Limit),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list invoices: %v", err)
+		return nil, err
+	}
+
+	return &ListInvoicesOutput{
+		Invoices:   result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package invoice
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/billing"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"time"
+)
+
+// ListOverdueInvoicesInput represents the overdue list filters
+type ListOverdueInvoicesInput struct {
+	StudentID     string
+	GuardianPhone string
+	Page          int
+	Limit         int
+}
+
+// OverdueInvoice is an invoice past due with its dunning state
+type OverdueInvoice struct {
+	Invoice      *entities.Invoice
+	DaysOverdue  int
+	LastReminder *entities.InvoiceReminder
+	OptedOut     bool
+}
+
+// ListOverdueInvoicesOutput represents one page of overdue invoices, most overdue first
+type ListOverdueInvoicesOutput struct {
+	Invoices   []*OverdueInvoice
+	Pagination *repositories.Meta
+}
+
+// ListOverdueInvoicesUseCase lists unpaid invoices past their due date with the last reminder sent
+type ListOverdueInvoicesUseCase interface {
+	Execute(ctx context.Context, input ListOverdueInvoicesInput) (*ListOverdueInvoicesOutput, error)
+}
+
+type listOverdueInvoicesUseCase struct {
+	invoiceRepo  repointerface.InvoiceRepository
+	reminderRepo repointerface.InvoiceReminderRepository
+	optOutRepo   repointerface.ReminderOptOutRepository
+}
+
+// NewListOverdueInvoicesUseCase creates a new instance of ListOverdueInvoicesUseCase
+func NewListOverdueInvoicesUseCase(
+	invoiceRepo repointerface.InvoiceRepository,
+	reminderRepo repointerface.InvoiceReminderRepository,
+	optOutRepo repointerface.ReminderOptOutRepository,
+) ListOverdueInvoicesUseCase {
+	return &listOverdueInvoicesUseCase{
+		invoiceRepo:  invoiceRepo,
+		reminderRepo: reminderRepo,
+		optOutRepo:   optOutRepo,
+	}
+}
+
+func (uc *listOverdueInvoicesUseCase) Execute(ctx context.Context, input ListOverdueInvoicesInput) (*ListOverdueInvoicesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	// Due dates are Vietnam midnights, so an invoice is overdue once today's midnight has passed it
+	now := time.Now()
+	today := now.In(utils.VietnamLocation())
+	result, err := uc.invoiceRepo.ListOverdue(ctx, repointerface.OverdueFilter{
+		DueBefore:     time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location()),
+		StudentID:     input.StudentID,
+		GuardianPhone: input.GuardianPhone,
+		Page:          uint64(input.Page),
+		Limit:         uint64(input.Limit),
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list overdue invoices: %v", err)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.Data))
+	phones := make([]string, 0, len(result.Data))
+	for _, i := range result.Data {
+		ids = append(ids, i.ID)
+		if i.Student != nil && i.Student.GuardianPhone != "" {
+			phones = append(phones, i.Student.GuardianPhone)
+		}
+	}
+	reminders, err := uc.reminderRepo.LatestByInvoices(ctx, ids)
+	if err != nil {
+		ctxLogger.Errorf("Failed to load invoice reminders: %v", err)
+		return nil, err
+	}
+	optedOut, err := uc.optOutRepo.OptedOut(ctx, phones)
+	if err != nil {
+		ctxLogger.Errorf("Failed to load reminder opt-outs: %v", err)
+		return nil, err
+	}
+
+	invoices := make([]*OverdueInvoice, 0, len(result.Data))
+	for _, i := range result.Data {
+		overdue := &OverdueInvoice{
+			Invoice:      i,
+			DaysOverdue:  billing.DaysPastDue(i.DueDate, now),
+			LastReminder: reminders[i.ID],
+		}
+		if i.Student != nil {
+			overdue.OptedOut = optedOut[i.Student.GuardianPhone]
+		}
+		invoices = append(invoices, overdue)
+	}
+
+	return &ListOverdueInvoicesOutput{
+		Invoices:   invoices,
+		Pagination: &result.Meta,
+	}, nil
+}
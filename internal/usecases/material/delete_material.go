@@ -0,0 +1,82 @@
+package material
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/storage"
+	"doan/pkg/authz"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"errors"
+)
+
+// DeleteMaterialInput represents the input for deleting a material
+type DeleteMaterialInput struct {
+	ID        string
+	Requester authz.Principal
+}
+
+// DeleteMaterialOutput represents the output after deleting a material
+type DeleteMaterialOutput struct {
+	Message string
+}
+
+// DeleteMaterialUseCase defines the interface for deleting a material
+type DeleteMaterialUseCase interface {
+	Execute(ctx context.Context, input DeleteMaterialInput) (*DeleteMaterialOutput, error)
+}
+
+type deleteMaterialUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	blobStorage  storage.BlobStorage
+}
+
+// NewDeleteMaterialUseCase creates a new instance of DeleteMaterialUseCase
+func NewDeleteMaterialUseCase(
+	materialRepo repointerface.MaterialRepository,
+	blobStorage storage.BlobStorage,
+) DeleteMaterialUseCase {
+	return &deleteMaterialUseCase{
+		materialRepo: materialRepo,
+		blobStorage:  blobStorage,
+	}
+}
+
+func (uc *deleteMaterialUseCase) Execute(ctx context.Context, input DeleteMaterialInput) (*DeleteMaterialOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.ID == "" {
+		return nil, errors.New("material ID is required")
+	}
+
+	material, err := uc.materialRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+
+	// Only the uploader or an admin may delete a material
+	if err := input.Requester.RequireOwnerOr(material.UploadedByID, constants.PermissionMaterialManage); err != nil {
+		return nil, ErrForbidden
+	}
+
+	if err := uc.materialRepo.SoftDelete(ctx, material.ID); err != nil {
+		ctxLogger.Errorf("Failed to delete material: %v", err)
+		return nil, err
+	}
+
+	// Identical uploads share one stored object, remove it only when the last reference is gone
+	remaining, err := uc.materialRepo.CountByStorageKey(ctx, material.StorageDriver, material.StorageKey)
+	if err != nil {
+		ctxLogger.Errorf("Failed to count material references: %v", err)
+	} else if remaining == 0 && material.StorageDriver == uc.blobStorage.Driver() {
+		if err := uc.blobStorage.Delete(ctx, material.StorageKey); err != nil {
+			ctxLogger.Errorf("Failed to delete material file %s: %v", material.StorageKey, err)
+		}
+	}
+
+	return &DeleteMaterialOutput{Message: "Material deleted successfully"}, nil
+}
@@ -0,0 +1,79 @@
+package material
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/storage"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+	"errors"
+	"io"
+)
+
+// DownloadMaterialInput represents the input for downloading a material
+type DownloadMaterialInput struct {
+	ID        string
+	Requester authz.Principal
+}
+
+// DownloadMaterialOutput holds the material and an open reader on its content; the caller must close Content
+type DownloadMaterialOutput struct {
+	Material *entities.Material
+	Content  io.ReadCloser
+}
+
+// DownloadMaterialUseCase defines the interface for downloading a material file
+type DownloadMaterialUseCase interface {
+	Execute(ctx context.Context, input DownloadMaterialInput) (*DownloadMaterialOutput, error)
+}
+
+type downloadMaterialUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	blobStorage  storage.BlobStorage
+}
+
+// NewDownloadMaterialUseCase creates a new instance of DownloadMaterialUseCase
+func NewDownloadMaterialUseCase(
+	materialRepo repointerface.MaterialRepository,
+	blobStorage storage.BlobStorage,
+) DownloadMaterialUseCase {
+	return &downloadMaterialUseCase{
+		materialRepo: materialRepo,
+		blobStorage:  blobStorage,
+	}
+}
+
+func (uc *downloadMaterialUseCase) Execute(ctx context.Context, input DownloadMaterialInput) (*DownloadMaterialOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.ID == "" {
+		return nil, errors.New("material ID is required")
+	}
+
+	material, err := uc.materialRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+	// Rejected materials stay downloadable for uploaders, reviewers and the owner so they can be fixed
+	if material.ReviewStatus == entities.MaterialReviewRejected && !canSeeRejected(input.Requester) &&
+		!input.Requester.Owns(material.UploadedByID) {
+		return nil, ErrMaterialRejected
+	}
+
+	content, err := uc.blobStorage.Get(ctx, material.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			ctxLogger.Errorf("Material %s has no stored file at %s", material.ID, material.StorageKey)
+			return nil, ErrMaterialNotFound
+		}
+		ctxLogger.Errorf("Failed to read material file: %v", err)
+		return nil, err
+	}
+
+	return &DownloadMaterialOutput{Material: material, Content: content}, nil
+}
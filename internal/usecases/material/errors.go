@@ -0,0 +1,11 @@
+package material
+
+import "errors"
+
+var (
+	ErrMaterialNotFound = errors.New("material not found")
+	ErrForbidden        = errors.New("you are not allowed to access this material")
+	ErrFileTooLarge     = errors.New("file exceeds the maximum upload size")
+	ErrFileTypeRejected = errors.New("file type is not allowed")
+	ErrMaterialRejected = errors.New("material was rejected by compliance review")
+)
@@ -0,0 +1,54 @@
+package material
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"errors"
+)
+
+// GetMaterialInput represents the input for getting a material
+type GetMaterialInput struct {
+	ID string
+}
+
+// GetMaterialOutput represents the output after getting a material
+type GetMaterialOutput struct {
+	Material *entities.Material
+}
+
+// GetMaterialUseCase defines the interface for getting a material by ID
+type GetMaterialUseCase interface {
+	Execute(ctx context.Context, input GetMaterialInput) (*GetMaterialOutput, error)
+}
+
+type getMaterialUseCase struct {
+	materialRepo repointerface.MaterialRepository
+}
+
+// NewGetMaterialUseCase creates a new instance of GetMaterialUseCase
+func NewGetMaterialUseCase(materialRepo repointerface.MaterialRepository) GetMaterialUseCase {
+	return &getMaterialUseCase{
+		materialRepo: materialRepo,
+	}
+}
+
+func (uc *getMaterialUseCase) Execute(ctx context.Context, input GetMaterialInput) (*GetMaterialOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.ID == "" {
+		return nil, errors.New("material ID is required")
+	}
+
+	material, err := uc.materialRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get material: %v", err)
+		return nil, err
+	}
+	if material == nil {
+		return nil, ErrMaterialNotFound
+	}
+
+	return &GetMaterialOutput{Material: material}, nil
+}
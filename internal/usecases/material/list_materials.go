@@ -0,0 +1,134 @@
+package material
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/authz"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+)
+
+// sortableFields are the columns materials can be sorted by
+var sortableFields = map[string]bool{
+	"created_at":    true,
+	"updated_at":    true,
+	"title":         true,
+	"file_name":     true,
+	"file_size":     true,
+	"review_status": true,
+	"audit_status":  true,
+}
+
+// ListMaterialsInput represents the input for listing materials
+type ListMaterialsInput struct {
+	Search       string
+	CourseID     string
+	ClassID      string
+	UploadedByID string
+	ReviewStatus string
+	AILabel      string
+	// Requester without upload or review permissions does not see rejected materials
+	Requester authz.Principal
+	Page      int
+	Limit     int
+	SortBy    string
+	SortOrder string
+}
+
+// ListMaterialsOutput represents the output after listing materials
+type ListMaterialsOutput struct {
+	Materials  []*entities.Material
+	Pagination *repositories.Meta
+}
+
+// ListMaterialsUseCase defines the interface for listing materials
+type ListMaterialsUseCase interface {
+	Execute(ctx context.Context, input ListMaterialsInput) (*ListMaterialsOutput, error)
+}
+
+type listMaterialsUseCase struct {
+	materialRepo repointerface.MaterialRepository
+}
+
+// NewListMaterialsUseCase creates a new instance of ListMaterialsUseCase
+func NewListMaterialsUseCase(materialRepo repointerface.MaterialRepository) ListMaterialsUseCase {
+	return &listMaterialsUseCase{
+		materialRepo: materialRepo,
+	}
+}
+
+func (uc *listMaterialsUseCase) Execute(ctx context.Context, input ListMaterialsInput) (*ListMaterialsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	// Set default pagination
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 {
+		input.Limit = 10
+	}
+	if input.Limit > 100 {
+		input.Limit = 100 // Max limit
+	}
+
+	condition := repositories.NewCommonCondition()
+	condition.SetPaging(uint64(input.Limit), uint64(input.Page))
+
+	if input.Search != "" {
+		orConditions := []repositories.Condition{
+			{Field: "title", Value: "%" + input.Search + "%", Op: repositories.Like},
+			{Field: "file_name", Value: "%" + input.Search + "%", Op: repositories.Like},
+		}
+		condition.AddOrCondition(orConditions)
+	}
+	if input.CourseID != "" {
+		condition.AddCondition("course_id", input.CourseID, repositories.Equal)
+	}
+	if input.ClassID != "" {
+		condition.AddCondition("class_id", input.ClassID, repositories.Equal)
+	}
+	if input.UploadedByID != "" {
+		condition.AddCondition("uploaded_by_id", input.UploadedByID, repositories.Equal)
+	}
+	if input.ReviewStatus != "" {
+		condition.AddCondition("review_status", input.ReviewStatus, repositories.Equal)
+	}
+	if input.AILabel != "" {
+		condition.AddCondition("ai_label", input.AILabel, repositories.Equal)
+	}
+	if !canSeeRejected(input.Requester) {
+		condition.AddCondition("review_status", entities.MaterialReviewRejected, repositories.NotEqual)
+	}
+
+	// Only known columns: the sort field is written into the query as is
+	if sortableFields[input.SortBy] {
+		order := repositories.Asc
+		if input.SortOrder == repositories.Desc {
+			order = repositories.Desc
+		}
+		condition.AddSorting(input.SortBy, order)
+	} else {
+		condition.AddSorting("created_at", repositories.Desc)
+	}
+
+	pagination, err := uc.materialRepo.GetByCondition(ctx, condition)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list materials: %v", err)
+		return nil, err
+	}
+	if pagination == nil {
+		return &ListMaterialsOutput{Materials: []*entities.Material{}, Pagination: &repositories.Meta{}}, nil
+	}
+
+	return &ListMaterialsOutput{
+		Materials:  pagination.Data,
+		Pagination: &pagination.Meta,
+	}, nil
+}
+
+// canSeeRejected reports whether the requester works on materials and so still sees rejected ones
+func canSeeRejected(requester authz.Principal) bool {
+	return requester.CanAny(constants.PermissionMaterialUpload, constants.PermissionComplianceReview)
+}
@@ -0,0 +1,209 @@
+package material
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/ai"
+	"doan/internal/storage"
+	"doan/pkg/config"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const defaultMaxUploadSizeMB = 50
+
+// UploadMaterialInput represents the input for uploading a material
+type UploadMaterialInput struct {
+	Title        string
+	Description  string
+	CourseID     *string
+	ClassID      *string
+	FileName     string
+	File         io.Reader
+	UploadedByID string
+}
+
+// UploadMaterialOutput represents the output after uploading a material
+type UploadMaterialOutput struct {
+	Material *entities.Material
+}
+
+// UploadMaterialUseCase defines the interface for uploading a material
+type UploadMaterialUseCase interface {
+	Execute(ctx context.Context, input UploadMaterialInput) (*UploadMaterialOutput, error)
+}
+
+type uploadMaterialUseCase struct {
+	materialRepo repointerface.MaterialRepository
+	courseRepo   repointerface.CourseRepository
+	classRepo    repointerface.ClassRepository
+	blobStorage  storage.BlobStorage
+	publisher    ai.AuditPublisher
+	cfg          config.Manager
+}
+
+// NewUploadMaterialUseCase creates a new instance of UploadMaterialUseCase
+func NewUploadMaterialUseCase(
+	materialRepo repointerface.MaterialRepository,
+	courseRepo repointerface.CourseRepository,
+	classRepo repointerface.ClassRepository,
+	blobStorage storage.BlobStorage,
+	publisher ai.AuditPublisher,
+	cfg config.Manager,
+) UploadMaterialUseCase {
+	return &uploadMaterialUseCase{
+		materialRepo: materialRepo,
+		courseRepo:   courseRepo,
+		classRepo:    classRepo,
+		blobStorage:  blobStorage,
+		publisher:    publisher,
+		cfg:          cfg,
+	}
+}
+
+func (uc *uploadMaterialUseCase) Execute(ctx context.Context, input UploadMaterialInput) (*UploadMaterialOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.File == nil {
+		return nil, errors.New("file is required")
+	}
+	if input.UploadedByID == "" {
+		return nil, errors.New("uploader is required")
+	}
+	fileName := filepath.Base(strings.TrimSpace(input.FileName))
+	if fileName == "." || fileName == string(filepath.Separator) {
+		return nil, errors.New("file name is required")
+	}
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+
+	if err := uc.validateReferences(ctx, input.CourseID, input.ClassID); err != nil {
+		return nil, err
+	}
+
+	// Read at most max+1 bytes so oversize uploads are detected without buffering them fully
+	maxSize := uc.maxUploadSize()
+	content, err := io.ReadAll(io.LimitReader(input.File, maxSize+1))
+	if err != nil {
+		ctxLogger.Errorf("Failed to read uploaded file: %v", err)
+		return nil, err
+	}
+	if int64(len(content)) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	if len(content) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	// The allowlist is checked against the sniffed content type, never the client supplied one
+	fileType, err := utils.DetectFileTypeFromContent(content, fileName)
+	if err != nil {
+		ctxLogger.Errorf("Failed to detect file type: %v", err)
+		return nil, err
+	}
+	if !utils.IsAllowedFileType(fileType.DetectedMime) {
+		return nil, fmt.Errorf("%w: %s", ErrFileTypeRejected, fileType.DetectedMime)
+	}
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	storageKey := path.Join("materials", filepath.ToSlash(utils.BuildPathFromChecksum(checksum, fileType.Extension)))
+
+	if err := uc.blobStorage.Put(ctx, storageKey, bytes.NewReader(content), int64(len(content)), fileType.DetectedMime); err != nil {
+		ctxLogger.Errorf("Failed to store material file: %v", err)
+		return nil, err
+	}
+
+	material := &entities.Material{
+		Title:         title,
+		Description:   input.Description,
+		CourseID:      input.CourseID,
+		ClassID:       input.ClassID,
+		UploadedByID:  input.UploadedByID,
+		FileName:      fileName,
+		MimeType:      fileType.DetectedMime,
+		Extension:     fileType.Extension,
+		FileSize:      int64(len(content)),
+		Checksum:      checksum,
+		StorageDriver: uc.blobStorage.Driver(),
+		StorageKey:    storageKey,
+	}
+
+	created, err := uc.materialRepo.Create(ctx, material)
+	if err != nil {
+		ctxLogger.Errorf("Failed to create material: %v", err)
+		uc.cleanupOrphan(ctx, storageKey)
+		return nil, err
+	}
+
+	// The AI audit runs asynchronously. The status is set before publishing so a fast consumer
+	// cannot be overwritten; on queue failure the material stays PENDING and can be re-queued.
+	if err := uc.materialRepo.Update(ctx, created.ID, map[string]interface{}{
+		"audit_status": entities.MaterialAuditQueued,
+	}); err != nil {
+		ctxLogger.Errorf("Failed to update material audit status: %v", err)
+	} else if err := uc.publisher.PublishMaterialAudit(ctx, created.ID); err != nil {
+		ctxLogger.Errorf("Failed to queue material %s for audit: %v", created.ID, err)
+		_ = uc.materialRepo.Update(ctx, created.ID, map[string]interface{}{
+			"audit_status": entities.MaterialAuditPending,
+		})
+	} else {
+		created.AuditStatus = entities.MaterialAuditQueued
+	}
+
+	return &UploadMaterialOutput{Material: created}, nil
+}
+
+func (uc *uploadMaterialUseCase) validateReferences(ctx context.Context, courseID, classID *string) error {
+	if courseID != nil && *courseID != "" {
+		course, err := uc.courseRepo.GetByID(ctx, *courseID)
+		if err != nil {
+			return err
+		}
+		if course == nil {
+			return errors.New("course not found")
+		}
+	}
+	if classID != nil && *classID != "" {
+		class, err := uc.classRepo.GetByID(ctx, *classID)
+		if err != nil {
+			return err
+		}
+		if class == nil {
+			return errors.New("class not found")
+		}
+	}
+	return nil
+}
+
+// cleanupOrphan removes the stored object when no material references it
+func (uc *uploadMaterialUseCase) cleanupOrphan(ctx context.Context, storageKey string) {
+	ctxLogger := logger.NewLogger(ctx)
+	count, err := uc.materialRepo.CountByStorageKey(ctx, uc.blobStorage.Driver(), storageKey)
+	if err != nil || count > 0 {
+		return
+	}
+	if err := uc.blobStorage.Delete(ctx, storageKey); err != nil {
+		ctxLogger.Errorf("Failed to remove orphan material file %s: %v", storageKey, err)
+	}
+}
+
+func (uc *uploadMaterialUseCase) maxUploadSize() int64 {
+	sizeMB := uc.cfg.GetInt("material.max_upload_size_mb")
+	if sizeMB <= 0 {
+		sizeMB = defaultMaxUploadSizeMB
+	}
+	return int64(sizeMB) << 20
+}
@@ -0,0 +1,25 @@
+package payment
+
+import "errors"
+
+var (
+	ErrPaymentNotFound     = errors.New("payment not found")
+	ErrInvoiceNotFound     = errors.New("invoice not found")
+	ErrInvalidAmount       = errors.New("amount must be a positive number of dong")
+	ErrInvalidMethod       = errors.New("payment method must be CASH, BANK_TRANSFER or VIETQR")
+	ErrAllocationRequired  = errors.New("either allocations or a student is required")
+	ErrAllocationMismatch  = errors.New("allocations must add up to the payment amount")
+	ErrOverAllocation      = errors.New("allocation exceeds the invoice balance")
+	ErrOverpayment         = errors.New("payment exceeds the student's outstanding balance")
+	ErrInvoiceVoid         = errors.New("cannot allocate to a void invoice")
+	ErrInvoiceOverpaid     = errors.New("invoice would be paid more than its total")
+	ErrNotRefundable       = errors.New("only completed payments can be refunded")
+	ErrRefundExceedsPaid   = errors.New("refund exceeds the amount paid and not yet refunded")
+	ErrReasonRequired      = errors.New("a reason is required")
+	ErrAlreadyVoided       = errors.New("payment is already voided")
+	ErrHasRefunds          = errors.New("void the refunds of this payment first")
+	ErrInvalidDate         = errors.New("date must be YYYY-MM-DD")
+	ErrUnmatchedTransfer   = errors.New("transfer content does not reference a known invoice")
+	ErrVietQRNotConfigured = errors.New("VietQR receiving account is not configured")
+	ErrInvoiceSettled      = errors.New("invoice has nothing left to pay")
+)
@@ -0,0 +1,143 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"doan/pkg/utils"
+	"time"
+)
+
+// GetDailyReconciliationInput represents the business day to reconcile, YYYY-MM-DD in Vietnam time, default today
+type GetDailyReconciliationInput struct {
+	Date string
+}
+
+// MethodTotal is what one cashier handled through one payment method
+type MethodTotal struct {
+	Method    string
+	Payments  int64
+	Collected money.Amount
+	Refunds   int64
+	Refunded  money.Amount
+}
+
+// CashierReconciliation is the till of one cashier (or provider, for webhook payments) for the day.
+// Net is collected minus refunded; voided entries are reported apart and excluded from the net.
+type CashierReconciliation struct {
+	CashierID   string
+	CashierName string
+	Collected   money.Amount
+	Refunded    money.Amount
+	Net         money.Amount
+	VoidedCount int64
+	Voided      money.Amount
+	Methods     []*MethodTotal
+}
+
+// GetDailyReconciliationOutput represents the day's reconciliation per cashier and in total
+type GetDailyReconciliationOutput struct {
+	Date      string
+	From      time.Time
+	To        time.Time
+	Cashiers  []*CashierReconciliation
+	Collected money.Amount
+	Refunded  money.Amount
+	Net       money.Amount
+	Voided    money.Amount
+}
+
+// GetDailyReconciliationUseCase totals the day's payments and refunds per cashier and method for closing the till
+type GetDailyReconciliationUseCase interface {
+	Execute(ctx context.Context, input GetDailyReconciliationInput) (*GetDailyReconciliationOutput, error)
+}
+
+type getDailyReconciliationUseCase struct {
+	paymentRepo repointerface.PaymentRepository
+}
+
+// NewGetDailyReconciliationUseCase creates a new instance of GetDailyReconciliationUseCase
+func NewGetDailyReconciliationUseCase(paymentRepo repointerface.PaymentRepository) GetDailyReconciliationUseCase {
+	return &getDailyReconciliationUseCase{
+		paymentRepo: paymentRepo,
+	}
+}
+
+func (uc *getDailyReconciliationUseCase) Execute(ctx context.Context, input GetDailyReconciliationInput) (*GetDailyReconciliationOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Date == "" {
+		input.Date = time.Now().In(utils.VietnamLocation()).Format(constants.DateOnly)
+	}
+	from, to, err := parseDay(input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := uc.paymentRepo.Reconcile(ctx, from, to)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reconcile payments: %v", err)
+		return nil, err
+	}
+
+	output := &GetDailyReconciliationOutput{Date: input.Date, From: from, To: to}
+	cashiers := make(map[string]*CashierReconciliation)
+	for _, row := range rows {
+		cashier, ok := cashiers[row.CashierID+"|"+row.CashierName]
+		if !ok {
+			cashier = &CashierReconciliation{CashierID: row.CashierID, CashierName: row.CashierName}
+			cashiers[row.CashierID+"|"+row.CashierName] = cashier
+			output.Cashiers = append(output.Cashiers, cashier)
+		}
+
+		if row.Status == entities.PaymentVoided {
+			cashier.VoidedCount += row.Count
+			cashier.Voided = cashier.Voided.Add(row.Amount)
+			continue
+		}
+
+		method := cashier.method(row.Method)
+		if row.Kind == entities.PaymentKindRefund {
+			method.Refunds += row.Count
+			method.Refunded = method.Refunded.Add(row.Amount)
+			cashier.Refunded = cashier.Refunded.Add(row.Amount)
+		} else {
+			method.Payments += row.Count
+			method.Collected = method.Collected.Add(row.Amount)
+			cashier.Collected = cashier.Collected.Add(row.Amount)
+		}
+	}
+
+	for _, cashier := range output.Cashiers {
+		cashier.Net = cashier.Collected.Sub(cashier.Refunded)
+		output.Collected = output.Collected.Add(cashier.Collected)
+		output.Refunded = output.Refunded.Add(cashier.Refunded)
+		output.Voided = output.Voided.Add(cashier.Voided)
+	}
+	output.Net = output.Collected.Sub(output.Refunded)
+
+	return output, nil
+}
+
+func (c *CashierReconciliation) method(name string) *MethodTotal {
+	for _, m := range c.Methods {
+		if m.Method == name {
+			return m
+		}
+	}
+	m := &MethodTotal{Method: name}
+	c.Methods = append(c.Methods, m)
+	return m
+}
+
+// parseDay returns the half-open instant range [00:00, next day 00:00) of a Vietnam calendar day
+func parseDay(date string) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation(constants.DateOnly, date, utils.VietnamLocation())
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDate
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,59 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetPaymentInput represents the payment to fetch
+type GetPaymentInput struct {
+	ID string
+}
+
+// GetPaymentOutput represents a payment with its allocations and refunds
+type GetPaymentOutput struct {
+	Payment *entities.Payment
+	Refunds []*entities.Payment
+}
+
+// GetPaymentUseCase returns a payment with its allocations and, for payments, the refunds issued against it
+type GetPaymentUseCase interface {
+	Execute(ctx context.Context, input GetPaymentInput) (*GetPaymentOutput, error)
+}
+
+type getPaymentUseCase struct {
+	paymentRepo repointerface.PaymentRepository
+}
+
+// NewGetPaymentUseCase creates a new instance of GetPaymentUseCase
+func NewGetPaymentUseCase(paymentRepo repointerface.PaymentRepository) GetPaymentUseCase {
+	return &getPaymentUseCase{
+		paymentRepo: paymentRepo,
+	}
+}
+
+func (uc *getPaymentUseCase) Execute(ctx context.Context, input GetPaymentInput) (*GetPaymentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	payment, err := uc.paymentRepo.GetWithAllocations(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payment: %v", err)
+		return nil, err
+	}
+	if payment == nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	var refunds []*entities.Payment
+	if payment.Kind == entities.PaymentKindPayment {
+		refunds, err = uc.paymentRepo.ListRefunds(ctx, payment.ID)
+		if err != nil {
+			ctxLogger.Errorf("Failed to list refunds: %v", err)
+			return nil, err
+		}
+	}
+
+	return &GetPaymentOutput{Payment: payment, Refunds: refunds}, nil
+}
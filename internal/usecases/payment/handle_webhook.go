@@ -0,0 +1,92 @@
+package payment
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/gateway"
+	"doan/pkg/logger"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// invoiceNumberPattern matches invoice numbers such as HD2026000042 inside transfer content
+var invoiceNumberPattern = regexp.MustCompile(`HD\d{10,}`)
+
+// HandleWebhookInput represents a raw webhook request from a payment provider
+type HandleWebhookInput struct {
+	Provider string
+	Header   http.Header
+	Body     []byte
+}
+
+// HandleWebhookOutput represents the payment recorded for the webhook
+type HandleWebhookOutput = RecordPaymentOutput
+
+// HandleWebhookUseCase verifies a provider webhook and records the credit against the invoice named in the
+// transfer content. Redelivered webhooks are recognised by provider reference and recorded once.
+type HandleWebhookUseCase interface {
+	Execute(ctx context.Context, input HandleWebhookInput) (*HandleWebhookOutput, error)
+}
+
+type handleWebhookUseCase struct {
+	gateways      gateway.Registry
+	invoiceRepo   repointerface.InvoiceRepository
+	recordPayment RecordPaymentUseCase
+}
+
+// NewHandleWebhookUseCase creates a new instance of HandleWebhookUseCase
+func NewHandleWebhookUseCase(
+	gateways gateway.Registry,
+	invoiceRepo repointerface.InvoiceRepository,
+	recordPayment RecordPaymentUseCase,
+) HandleWebhookUseCase {
+	return &handleWebhookUseCase{
+		gateways:      gateways,
+		invoiceRepo:   invoiceRepo,
+		recordPayment: recordPayment,
+	}
+}
+
+func (uc *handleWebhookUseCase) Execute(ctx context.Context, input HandleWebhookInput) (*HandleWebhookOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	provider, err := uc.gateways.Get(input.Provider)
+	if err != nil {
+		return nil, err
+	}
+	event, err := provider.ParseWebhook(ctx, input.Header, input.Body)
+	if err != nil {
+		ctxLogger.Warnf("Rejected %s webhook: %v", input.Provider, err)
+		return nil, err
+	}
+
+	// Banks often upper-case or lower-case the transfer content, so match case-insensitively
+	number := invoiceNumberPattern.FindString(strings.ToUpper(event.Content))
+	if number == "" {
+		ctxLogger.Warnf("Unmatched %s transfer %s: %q", event.Provider, event.Reference, event.Content)
+		return nil, ErrUnmatchedTransfer
+	}
+	invoice, err := uc.invoiceRepo.GetByNumber(ctx, number)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get invoice %s: %v", number, err)
+		return nil, err
+	}
+	if invoice == nil {
+		ctxLogger.Warnf("Unmatched %s transfer %s: invoice %s not found", event.Provider, event.Reference, number)
+		return nil, ErrUnmatchedTransfer
+	}
+
+	paidAt := event.PaidAt
+	return uc.recordPayment.Execute(ctx, RecordPaymentInput{
+		Method:          event.Method,
+		Amount:          event.Amount,
+		Provider:        event.Provider,
+		Reference:       event.Reference,
+		PayerName:       event.PayerName,
+		ReceivedAt:      &paidAt,
+		Notes:           event.Content,
+		StudentID:       invoice.StudentID,
+		PreferInvoiceID: invoice.ID,
+	})
+}
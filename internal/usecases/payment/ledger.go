@@ -0,0 +1,125 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/money"
+	"strings"
+)
+
+// AllocationInput is the part of a payment or refund applied to one invoice, always positive
+type AllocationInput struct {
+	InvoiceID string
+	Amount    money.Amount
+}
+
+func validMethod(method string) bool {
+	switch method {
+	case entities.PaymentMethodCash, entities.PaymentMethodTransfer, entities.PaymentMethodVietQR:
+		return true
+	}
+	return false
+}
+
+// mergeAllocations sums allocations to the same invoice, keeping the first-seen order
+func mergeAllocations(allocations []AllocationInput) ([]AllocationInput, error) {
+	merged := make([]AllocationInput, 0, len(allocations))
+	index := make(map[string]int, len(allocations))
+	for _, a := range allocations {
+		a.InvoiceID = strings.TrimSpace(a.InvoiceID)
+		if a.InvoiceID == "" || !a.Amount.IsPositive() {
+			return nil, ErrInvalidAmount
+		}
+		if i, ok := index[a.InvoiceID]; ok {
+			merged[i].Amount = merged[i].Amount.Add(a.Amount)
+			continue
+		}
+		index[a.InvoiceID] = len(merged)
+		merged = append(merged, a)
+	}
+	return merged, nil
+}
+
+// lockInvoices locks the invoices of the allocations, failing if any is missing
+func lockInvoices(ctx context.Context, invoiceRepo repointerface.InvoiceRepository, ids []string) (map[string]*entities.Invoice, error) {
+	invoices, err := invoiceRepo.LockByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entities.Invoice, len(invoices))
+	for _, invoice := range invoices {
+		byID[invoice.ID] = invoice
+	}
+	for _, id := range ids {
+		if byID[id] == nil {
+			return nil, ErrInvoiceNotFound
+		}
+	}
+	return byID, nil
+}
+
+// postToInvoices adds each delta to paid_amount of its locked invoice and recomputes the status.
+// Payments post positive deltas, refunds and voided payments negative ones.
+func postToInvoices(
+	ctx context.Context,
+	invoiceRepo repointerface.InvoiceRepository,
+	invoices map[string]*entities.Invoice,
+	deltas []AllocationInput,
+) ([]*entities.Invoice, error) {
+	updated := make([]*entities.Invoice, 0, len(deltas))
+	for _, delta := range deltas {
+		invoice := invoices[delta.InvoiceID]
+		paid := invoice.PaidAmount.Add(delta.Amount)
+		if paid.IsNegative() {
+			return nil, ErrRefundExceedsPaid
+		}
+		if paid > invoice.Total {
+			return nil, ErrInvoiceOverpaid
+		}
+
+		status := invoice.Status
+		if status != entities.InvoiceVoid {
+			status = entities.InvoiceStatusFor(invoice.Total, paid)
+		}
+		if err := invoiceRepo.Update(ctx, invoice.ID, map[string]interface{}{
+			"paid_amount": paid,
+			"status":      status,
+		}); err != nil {
+			return nil, err
+		}
+		invoice.PaidAmount = paid
+		invoice.Status = status
+		updated = append(updated, invoice)
+	}
+	return updated, nil
+}
+
+func toEntityAllocations(allocations []AllocationInput, sign int64) []entities.PaymentAllocation {
+	result := make([]entities.PaymentAllocation, 0, len(allocations))
+	for _, a := range allocations {
+		result = append(result, entities.PaymentAllocation{
+			InvoiceID: a.InvoiceID,
+			Amount:    a.Amount.Mul(sign),
+		})
+	}
+	return result
+}
+
+func allocationMetadata(allocations []AllocationInput) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(allocations))
+	for _, a := range allocations {
+		result = append(result, map[string]interface{}{
+			"invoice_id": a.InvoiceID,
+			"amount":     a.Amount.Int64(),
+		})
+	}
+	return result
+}
+
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,239 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/money"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// invoiceRepository keeps invoice updates in memory and serves a fixed list of open invoices
+type invoiceRepository struct {
+	repointerface.InvoiceRepository
+	open    []*entities.Invoice
+	updates map[string]map[string]interface{}
+}
+
+func (r *invoiceRepository) Update(ctx context.Context, id interface{}, updatedData map[string]interface{}) error {
+	if r.updates == nil {
+		r.updates = make(map[string]map[string]interface{})
+	}
+	r.updates[id.(string)] = updatedData
+	return nil
+}
+
+func (r *invoiceRepository) LockOpenByStudent(ctx context.Context, studentID string) ([]*entities.Invoice, error) {
+	return r.open, nil
+}
+
+// paymentRepository serves the refunds of one payment
+type paymentRepository struct {
+	repointerface.PaymentRepository
+	refunds []*entities.Payment
+}
+
+func (r *paymentRepository) ListRefunds(ctx context.Context, paymentID string) ([]*entities.Payment, error) {
+	return r.refunds, nil
+}
+
+func TestMergeAllocations(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []AllocationInput
+		want    []AllocationInput
+		wantErr error
+	}{
+		{"empty", nil, []AllocationInput{}, nil},
+		{"sums the same invoice in first-seen order", []AllocationInput{
+			{InvoiceID: "b", Amount: 100}, {InvoiceID: " a ", Amount: 50}, {InvoiceID: "b", Amount: 25},
+		}, []AllocationInput{{InvoiceID: "b", Amount: 125}, {InvoiceID: "a", Amount: 50}}, nil},
+		{"zero amount", []AllocationInput{{InvoiceID: "a", Amount: 0}}, nil, ErrInvalidAmount},
+		{"negative amount", []AllocationInput{{InvoiceID: "a", Amount: -1}}, nil, ErrInvalidAmount},
+		{"missing invoice", []AllocationInput{{InvoiceID: " ", Amount: 10}}, nil, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeAllocations(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeAllocations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostToInvoices(t *testing.T) {
+	tests := []struct {
+		name       string
+		invoice    entities.Invoice
+		delta      money.Amount
+		wantPaid   money.Amount
+		wantStatus string
+		wantErr    error
+	}{
+		{"partial payment", entities.Invoice{Total: 1000, Status: entities.InvoiceUnpaid}, 400, 400, entities.InvoicePartiallyPaid, nil},
+		{"settles the invoice", entities.Invoice{Total: 1000, PaidAmount: 400, Status: entities.InvoicePartiallyPaid}, 600, 1000, entities.InvoicePaid, nil},
+		{"refund reopens it", entities.Invoice{Total: 1000, PaidAmount: 1000, Status: entities.InvoicePaid}, -300, 700, entities.InvoicePartiallyPaid, nil},
+		{"full refund", entities.Invoice{Total: 1000, PaidAmount: 1000, Status: entities.InvoicePaid}, -1000, 0, entities.InvoiceUnpaid, nil},
+		{"void stays void", entities.Invoice{Total: 1000, PaidAmount: 500, Status: entities.InvoiceVoid}, -500, 0, entities.InvoiceVoid, nil},
+		{"overpaid", entities.Invoice{Total: 1000, PaidAmount: 900}, 101, 0, "", ErrInvoiceOverpaid},
+		{"refund above paid", entities.Invoice{Total: 1000, PaidAmount: 100}, -101, 0, "", ErrRefundExceedsPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := tt.invoice
+			invoice.ID = "inv-1"
+			repo := &invoiceRepository{}
+
+			updated, err := postToInvoices(context.Background(), repo, map[string]*entities.Invoice{invoice.ID: &invoice},
+				[]AllocationInput{{InvoiceID: invoice.ID, Amount: tt.delta}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.updates) != 0 {
+					t.Errorf("invoice updated despite the error: %v", repo.updates)
+				}
+				return
+			}
+			if len(updated) != 1 || updated[0].PaidAmount != tt.wantPaid || updated[0].Status != tt.wantStatus {
+				t.Fatalf("invoice = (%d, %s), want (%d, %s)", invoice.PaidAmount, invoice.Status, tt.wantPaid, tt.wantStatus)
+			}
+			want := map[string]interface{}{"paid_amount": tt.wantPaid, "status": tt.wantStatus}
+			if !reflect.DeepEqual(repo.updates[invoice.ID], want) {
+				t.Errorf("update = %v, want %v", repo.updates[invoice.ID], want)
+			}
+		})
+	}
+}
+
+func TestAutoAllocate(t *testing.T) {
+	open := func() []*entities.Invoice {
+		return []*entities.Invoice{
+			{ID: "oldest", Total: 1000, PaidAmount: 200},
+			{ID: "settled", Total: 500, PaidAmount: 500},
+			{ID: "middle", Total: 1000},
+			{ID: "newest", Total: 1000},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		amount  money.Amount
+		prefer  string
+		want    []AllocationInput
+		wantErr error
+	}{
+		{"oldest due first", 1000, "", []AllocationInput{{InvoiceID: "oldest", Amount: 800}, {InvoiceID: "middle", Amount: 200}}, nil},
+		{"preferred invoice first", 1500, "newest", []AllocationInput{{InvoiceID: "newest", Amount: 1000}, {InvoiceID: "oldest", Amount: 500}}, nil},
+		{"exactly the outstanding balance", 2800, "", []AllocationInput{
+			{InvoiceID: "oldest", Amount: 800}, {InvoiceID: "middle", Amount: 1000}, {InvoiceID: "newest", Amount: 1000},
+		}, nil},
+		{"more than is owed", 2801, "", nil, ErrOverpayment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &recordPaymentUseCase{invoiceRepo: &invoiceRepository{open: open()}}
+			invoices, got, err := uc.autoAllocate(context.Background(), RecordPaymentInput{
+				StudentID: "student-1", Amount: tt.amount, PreferInvoiceID: tt.prefer,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocations = %v, want %v", got, tt.want)
+			}
+			if len(invoices) != len(got) {
+				t.Errorf("%d invoices locked for %d allocations", len(invoices), len(got))
+			}
+			if money.Sum(amounts(got)...) != tt.amount {
+				t.Errorf("allocations sum to %d, want %d", money.Sum(amounts(got)...), tt.amount)
+			}
+		})
+	}
+}
+
+func TestRefundable(t *testing.T) {
+	original := &entities.Payment{
+		ID: "pay-1",
+		Allocations: []entities.PaymentAllocation{
+			{InvoiceID: "a", Amount: 600},
+			{InvoiceID: "b", Amount: 400},
+			{InvoiceID: "a", Amount: 100},
+		},
+	}
+	refunds := []*entities.Payment{
+		{Status: entities.PaymentCompleted, Allocations: []entities.PaymentAllocation{{InvoiceID: "a", Amount: -200}}},
+		{Status: entities.PaymentVoided, Allocations: []entities.PaymentAllocation{{InvoiceID: "b", Amount: -400}}},
+		{Status: entities.PaymentCompleted, Allocations: []entities.PaymentAllocation{{InvoiceID: "b", Amount: -50}}},
+	}
+
+	uc := &refundPaymentUseCase{paymentRepo: &paymentRepository{refunds: refunds}}
+	got, err := uc.refundable(context.Background(), original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]money.Amount{"a": 500, "b": 350}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("refundable = %v, want %v (voided refunds give nothing back)", got, want)
+	}
+}
+
+func TestTakeLatestFirst(t *testing.T) {
+	original := []entities.PaymentAllocation{
+		{InvoiceID: "a", Amount: 600},
+		{InvoiceID: "b", Amount: 400},
+		{InvoiceID: "c", Amount: 300},
+	}
+
+	tests := []struct {
+		name       string
+		refundable map[string]money.Amount
+		amount     money.Amount
+		want       []AllocationInput
+	}{
+		{"within the latest invoice", map[string]money.Amount{"a": 600, "b": 400, "c": 300}, 200,
+			[]AllocationInput{{InvoiceID: "c", Amount: 200}}},
+		{"spills over to earlier invoices", map[string]money.Amount{"a": 600, "b": 400, "c": 300}, 900,
+			[]AllocationInput{{InvoiceID: "c", Amount: 300}, {InvoiceID: "b", Amount: 400}, {InvoiceID: "a", Amount: 200}}},
+		{"skips invoices already refunded", map[string]money.Amount{"a": 600, "b": 0, "c": 100}, 300,
+			[]AllocationInput{{InvoiceID: "c", Amount: 100}, {InvoiceID: "a", Amount: 200}}},
+		{"stops at what is refundable", map[string]money.Amount{"a": 100, "b": 0, "c": 0}, 500,
+			[]AllocationInput{{InvoiceID: "a", Amount: 100}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := takeLatestFirst(original, tt.refundable, tt.amount)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("takeLatestFirst = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// An invoice allocated twice is drawn from once per allocation, never beyond its refundable amount
+	repeated := []entities.PaymentAllocation{{InvoiceID: "a", Amount: 300}, {InvoiceID: "a", Amount: 300}}
+	got := takeLatestFirst(repeated, map[string]money.Amount{"a": 400}, 600)
+	if want := []AllocationInput{{InvoiceID: "a", Amount: 400}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("takeLatestFirst with a repeated invoice = %v, want %v", got, want)
+	}
+}
+
+func TestToEntityAllocations(t *testing.T) {
+	got := toEntityAllocations([]AllocationInput{{InvoiceID: "a", Amount: 300}, {InvoiceID: "b", Amount: 200}}, -1)
+	want := []entities.PaymentAllocation{{InvoiceID: "a", Amount: -300}, {InvoiceID: "b", Amount: -200}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toEntityAllocations = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// ListPaymentsInput represents the payment list filters; dates are YYYY-MM-DD in Vietnam time, both inclusive
+type ListPaymentsInput struct {
+	InvoiceID string
+	StudentID string
+	CashierID string
+	Method    string
+	Kind      string
+	Status    string
+	From      string
+	To        string
+	Page      int
+	Limit     int
+}
+
+// ListPaymentsOutput represents one page of payments
+type ListPaymentsOutput struct {
+	Payments   []*entities.Payment
+	Pagination *repositories.Meta
+}
+
+// ListPaymentsUseCase lists payments and refunds by invoice, student, cashier, method and date
+type ListPaymentsUseCase interface {
+	Execute(ctx context.Context, input ListPaymentsInput) (*ListPaymentsOutput, error)
+}
+
+type listPaymentsUseCase struct {
+	paymentRepo repointerface.PaymentRepository
+}
+
+// NewListPaymentsUseCase creates a new instance of ListPaymentsUseCase
+func NewListPaymentsUseCase(paymentRepo repointerface.PaymentRepository) ListPaymentsUseCase {
+	return &listPaymentsUseCase{
+		paymentRepo: paymentRepo,
+	}
+}
+
+func (uc *listPaymentsUseCase) Execute(ctx context.Context, input ListPaymentsInput) (*ListPaymentsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	filter := repointerface.PaymentFilter{
+		InvoiceID: input.InvoiceID,
+		StudentID: input.StudentID,
+		CashierID: input.CashierID,
+		Method:    strings.ToUpper(input.Method),
+		Kind:      strings.ToUpper(input.Kind),
+		Status:    strings.ToUpper(input.Status),
+		Page:      uint64(input.Page),
+		Limit:     uint64(input.Limit),
+	}
+	if input.From != "" {
+		from, _, err := parseDay(input.From)
+		if err != nil {
+			return nil, err
+		}
+		filter.From = &from
+	}
+	if input.To != "" {
+		_, to, err := parseDay(input.To)
+		if err != nil {
+			return nil, err
+		}
+		filter.To = &to
+	}
+
+	result, err := uc.paymentRepo.List(ctx, filter)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list payments: %v", err)
+		return nil, err
+	}
+
+	return &ListPaymentsOutput{
+		Payments:   result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
@@ -0,0 +1,240 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"doan/pkg/utils"
+	"strings"
+	"time"
+)
+
+// RecordPaymentInput represents money received at the counter or reported by a provider.
+// Without allocations the amount is applied to the student's open invoices, oldest due first.
+type RecordPaymentInput struct {
+	Method          string
+	Amount          money.Amount
+	Provider        string // set by webhooks, empty for staff entries
+	Reference       string // bank or provider transaction ID
+	PayerName       string
+	ReceivedAt      *time.Time
+	Notes           string
+	Allocations     []AllocationInput
+	StudentID       string
+	PreferInvoiceID string // auto-allocation starts with this invoice
+	CashierID       string
+	CashierRole     string
+}
+
+// RecordPaymentOutput represents the recorded payment and the invoices it settled.
+// Duplicate is set when the provider reference was already recorded; nothing is written then.
+type RecordPaymentOutput struct {
+	Payment   *entities.Payment
+	Invoices  []*entities.Invoice
+	Duplicate bool
+}
+
+// RecordPaymentUseCase records a payment, allocates it across invoices and writes the audit log in one transaction
+type RecordPaymentUseCase interface {
+	Execute(ctx context.Context, input RecordPaymentInput) (*RecordPaymentOutput, error)
+}
+
+type recordPaymentUseCase struct {
+	paymentRepo  repointerface.PaymentRepository
+	invoiceRepo  repointerface.InvoiceRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewRecordPaymentUseCase creates a new instance of RecordPaymentUseCase
+func NewRecordPaymentUseCase(
+	paymentRepo repointerface.PaymentRepository,
+	invoiceRepo repointerface.InvoiceRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) RecordPaymentUseCase {
+	return &recordPaymentUseCase{
+		paymentRepo:  paymentRepo,
+		invoiceRepo:  invoiceRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *recordPaymentUseCase) Execute(ctx context.Context, input RecordPaymentInput) (*RecordPaymentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	input.Method = strings.ToUpper(strings.TrimSpace(input.Method))
+	input.Reference = strings.TrimSpace(input.Reference)
+	if !validMethod(input.Method) {
+		return nil, ErrInvalidMethod
+	}
+	if !input.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	allocations, err := mergeAllocations(input.Allocations)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocations) == 0 && input.StudentID == "" {
+		return nil, ErrAllocationRequired
+	}
+	if len(allocations) > 0 && money.Sum(amounts(allocations)...) != input.Amount {
+		return nil, ErrAllocationMismatch
+	}
+
+	if input.Provider != "" && input.Reference != "" {
+		existing, err := uc.paymentRepo.GetByProviderReference(ctx, input.Provider, input.Reference)
+		if err != nil {
+			ctxLogger.Errorf("Failed to look up payment reference: %v", err)
+			return nil, err
+		}
+		if existing != nil {
+			return &RecordPaymentOutput{Payment: existing, Duplicate: true}, nil
+		}
+	}
+
+	receivedAt := time.Now()
+	if input.ReceivedAt != nil {
+		receivedAt = *input.ReceivedAt
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		var invoices map[string]*entities.Invoice
+		var err error
+		if len(allocations) > 0 {
+			invoices, err = uc.lockAllocated(txCtx, allocations)
+		} else {
+			invoices, allocations, err = uc.autoAllocate(txCtx, input)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		number, err := uc.paymentRepo.NextReceiptNumber(txCtx, "PT", receivedAt.In(utils.VietnamLocation()))
+		if err != nil {
+			return nil, err
+		}
+		payment, err := uc.paymentRepo.Create(txCtx, &entities.Payment{
+			ReceiptNumber: number,
+			Kind:          entities.PaymentKindPayment,
+			Method:        input.Method,
+			Status:        entities.PaymentCompleted,
+			Amount:        input.Amount,
+			Currency:      money.Currency,
+			Provider:      input.Provider,
+			Reference:     input.Reference,
+			PayerName:     strings.TrimSpace(input.PayerName),
+			CashierID:     optionalID(input.CashierID),
+			ReceivedAt:    receivedAt,
+			Notes:         strings.TrimSpace(input.Notes),
+			Allocations:   toEntityAllocations(allocations, 1),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := postToInvoices(txCtx, uc.invoiceRepo, invoices, allocations)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := uc.auditLogRepo.Create(txCtx, &entities.AuditLog{
+			ActorID:    optionalID(input.CashierID),
+			ActorRole:  input.CashierRole,
+			Action:     entities.AuditActionPaymentRecord,
+			EntityType: entities.AuditEntityPayment,
+			EntityID:   payment.ID,
+			Comment:    payment.Notes,
+			Metadata: entities.JSONMap{
+				"receipt_number": payment.ReceiptNumber,
+				"method":         payment.Method,
+				"amount":         payment.Amount.Int64(),
+				"provider":       payment.Provider,
+				"reference":      payment.Reference,
+				"allocations":    allocationMetadata(allocations),
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		return &RecordPaymentOutput{Payment: payment, Invoices: updated}, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to record payment: %v", err)
+		return nil, err
+	}
+
+	return result.(*RecordPaymentOutput), nil
+}
+
+// lockAllocated locks the chosen invoices and checks each allocation fits the invoice balance
+func (uc *recordPaymentUseCase) lockAllocated(ctx context.Context, allocations []AllocationInput) (map[string]*entities.Invoice, error) {
+	ids := make([]string, 0, len(allocations))
+	for _, a := range allocations {
+		ids = append(ids, a.InvoiceID)
+	}
+	invoices, err := lockInvoices(ctx, uc.invoiceRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range allocations {
+		invoice := invoices[a.InvoiceID]
+		if invoice.Status == entities.InvoiceVoid {
+			return nil, ErrInvoiceVoid
+		}
+		if a.Amount > invoice.Balance() {
+			return nil, ErrOverAllocation
+		}
+	}
+	return invoices, nil
+}
+
+// autoAllocate spreads the amount over the student's open invoices, the preferred one first, then oldest due
+func (uc *recordPaymentUseCase) autoAllocate(ctx context.Context, input RecordPaymentInput) (map[string]*entities.Invoice, []AllocationInput, error) {
+	open, err := uc.invoiceRepo.LockOpenByStudent(ctx, input.StudentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, invoice := range open {
+		if invoice.ID == input.PreferInvoiceID && i > 0 {
+			open = append([]*entities.Invoice{invoice}, append(open[:i:i], open[i+1:]...)...)
+			break
+		}
+	}
+
+	invoices := make(map[string]*entities.Invoice, len(open))
+	var allocations []AllocationInput
+	remaining := input.Amount
+	for _, invoice := range open {
+		if remaining.IsZero() {
+			break
+		}
+		share := money.Min(remaining, invoice.Balance())
+		if !share.IsPositive() {
+			continue
+		}
+		invoices[invoice.ID] = invoice
+		allocations = append(allocations, AllocationInput{InvoiceID: invoice.ID, Amount: share})
+		remaining = remaining.Sub(share)
+	}
+	if remaining.IsPositive() {
+		return nil, nil, ErrOverpayment
+	}
+	return invoices, allocations, nil
+}
+
+func amounts(allocations []AllocationInput) []money.Amount {
+	result := make([]money.Amount, 0, len(allocations))
+	for _, a := range allocations {
+		result = append(result, a.Amount)
+	}
+	return result
+}
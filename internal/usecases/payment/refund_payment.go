@@ -0,0 +1,230 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"doan/pkg/utils"
+	"strings"
+	"time"
+)
+
+// RefundPaymentInput represents money returned to the payer of a payment.
+// Without allocations the refund is taken from the most recently allocated invoices first.
+type RefundPaymentInput struct {
+	PaymentID   string
+	Amount      money.Amount
+	Method      string // defaults to the method of the original payment
+	Reference   string
+	Reason      string
+	Allocations []AllocationInput
+	ActorID     string
+	ActorRole   string
+}
+
+// RefundPaymentOutput represents the refund voucher and the invoices it reopened
+type RefundPaymentOutput struct {
+	Refund   *entities.Payment
+	Invoices []*entities.Invoice
+}
+
+// RefundPaymentUseCase issues a refund against a completed payment, never more than was paid and not yet refunded
+type RefundPaymentUseCase interface {
+	Execute(ctx context.Context, input RefundPaymentInput) (*RefundPaymentOutput, error)
+}
+
+type refundPaymentUseCase struct {
+	paymentRepo  repointerface.PaymentRepository
+	invoiceRepo  repointerface.InvoiceRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewRefundPaymentUseCase creates a new instance of RefundPaymentUseCase
+func NewRefundPaymentUseCase(
+	paymentRepo repointerface.PaymentRepository,
+	invoiceRepo repointerface.InvoiceRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) RefundPaymentUseCase {
+	return &refundPaymentUseCase{
+		paymentRepo:  paymentRepo,
+		invoiceRepo:  invoiceRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *refundPaymentUseCase) Execute(ctx context.Context, input RefundPaymentInput) (*RefundPaymentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		return nil, ErrReasonRequired
+	}
+	if !input.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	requested, err := mergeAllocations(input.Allocations)
+	if err != nil {
+		return nil, err
+	}
+	if len(requested) > 0 && money.Sum(amounts(requested)...) != input.Amount {
+		return nil, ErrAllocationMismatch
+	}
+
+	original, err := uc.paymentRepo.GetWithAllocations(ctx, input.PaymentID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payment: %v", err)
+		return nil, err
+	}
+	if original == nil {
+		return nil, ErrPaymentNotFound
+	}
+	if original.Kind != entities.PaymentKindPayment || original.Status != entities.PaymentCompleted {
+		return nil, ErrNotRefundable
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(input.Method))
+	if method == "" {
+		method = original.Method
+	}
+	if !validMethod(method) {
+		return nil, ErrInvalidMethod
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		ids := make([]string, 0, len(original.Allocations))
+		for _, a := range original.Allocations {
+			ids = append(ids, a.InvoiceID)
+		}
+		// Locking the invoices serialises refunds of the same payment, so the refundable amounts below are current
+		invoices, err := lockInvoices(txCtx, uc.invoiceRepo, ids)
+		if err != nil {
+			return nil, err
+		}
+		refundable, err := uc.refundable(txCtx, original)
+		if err != nil {
+			return nil, err
+		}
+
+		allocations := requested
+		if len(allocations) == 0 {
+			allocations = takeLatestFirst(original.Allocations, refundable, input.Amount)
+		}
+		for _, a := range allocations {
+			if a.Amount > refundable[a.InvoiceID] {
+				return nil, ErrRefundExceedsPaid
+			}
+		}
+		if money.Sum(amounts(allocations)...) != input.Amount {
+			return nil, ErrRefundExceedsPaid
+		}
+
+		now := time.Now()
+		number, err := uc.paymentRepo.NextReceiptNumber(txCtx, "PC", now.In(utils.VietnamLocation()))
+		if err != nil {
+			return nil, err
+		}
+		refund, err := uc.paymentRepo.Create(txCtx, &entities.Payment{
+			ReceiptNumber: number,
+			Kind:          entities.PaymentKindRefund,
+			Method:        method,
+			Status:        entities.PaymentCompleted,
+			Amount:        input.Amount,
+			Currency:      money.Currency,
+			Reference:     strings.TrimSpace(input.Reference),
+			PayerName:     original.PayerName,
+			CashierID:     optionalID(input.ActorID),
+			ReceivedAt:    now,
+			RefundOfID:    &original.ID,
+			Notes:         input.Reason,
+			Allocations:   toEntityAllocations(allocations, -1),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		deltas := make([]AllocationInput, 0, len(allocations))
+		for _, a := range allocations {
+			deltas = append(deltas, AllocationInput{InvoiceID: a.InvoiceID, Amount: a.Amount.Neg()})
+		}
+		updated, err := postToInvoices(txCtx, uc.invoiceRepo, invoices, deltas)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := uc.auditLogRepo.Create(txCtx, &entities.AuditLog{
+			ActorID:    optionalID(input.ActorID),
+			ActorRole:  input.ActorRole,
+			Action:     entities.AuditActionPaymentRefund,
+			EntityType: entities.AuditEntityPayment,
+			EntityID:   refund.ID,
+			Comment:    input.Reason,
+			Metadata: entities.JSONMap{
+				"receipt_number":          refund.ReceiptNumber,
+				"refund_of_id":            original.ID,
+				"refund_of_receipt":       original.ReceiptNumber,
+				"method":                  refund.Method,
+				"amount":                  refund.Amount.Int64(),
+				"allocations":             allocationMetadata(allocations),
+				"original_payment_amount": original.Amount.Int64(),
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		return &RefundPaymentOutput{Refund: refund, Invoices: updated}, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to refund payment %s: %v", input.PaymentID, err)
+		return nil, err
+	}
+
+	return result.(*RefundPaymentOutput), nil
+}
+
+// refundable is, per invoice, what the payment allocated minus what its completed refunds took back
+func (uc *refundPaymentUseCase) refundable(ctx context.Context, original *entities.Payment) (map[string]money.Amount, error) {
+	left := make(map[string]money.Amount, len(original.Allocations))
+	for _, a := range original.Allocations {
+		left[a.InvoiceID] = left[a.InvoiceID].Add(a.Amount)
+	}
+	refunds, err := uc.paymentRepo.ListRefunds(ctx, original.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, refund := range refunds {
+		if refund.Status != entities.PaymentCompleted {
+			continue
+		}
+		for _, a := range refund.Allocations {
+			left[a.InvoiceID] = left[a.InvoiceID].Add(a.Amount) // refund allocations are negative
+		}
+	}
+	return left, nil
+}
+
+// takeLatestFirst draws the amount from the payment's allocations in reverse order
+func takeLatestFirst(original []entities.PaymentAllocation, refundable map[string]money.Amount, amount money.Amount) []AllocationInput {
+	var allocations []AllocationInput
+	taken := make(map[string]money.Amount, len(original))
+	for i := len(original) - 1; i >= 0 && amount.IsPositive(); i-- {
+		invoiceID := original[i].InvoiceID
+		share := money.Min(amount, refundable[invoiceID].Sub(taken[invoiceID]))
+		if !share.IsPositive() {
+			continue
+		}
+		taken[invoiceID] = taken[invoiceID].Add(share)
+		allocations = append(allocations, AllocationInput{InvoiceID: invoiceID, Amount: share})
+		amount = amount.Sub(share)
+	}
+	merged, _ := mergeAllocations(allocations)
+	return merged
+}
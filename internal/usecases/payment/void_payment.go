@@ -0,0 +1,146 @@
+package payment
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+	"time"
+)
+
+// VoidPaymentInput represents a payment or refund entered by mistake
+type VoidPaymentInput struct {
+	PaymentID string
+	Reason    string
+	ActorID   string
+	ActorRole string
+}
+
+// VoidPaymentOutput represents the voided payment and the invoices whose balances were restored
+type VoidPaymentOutput struct {
+	Payment  *entities.Payment
+	Invoices []*entities.Invoice
+}
+
+// VoidPaymentUseCase cancels a payment or refund and reverses its allocations; the record is kept for the audit trail
+type VoidPaymentUseCase interface {
+	Execute(ctx context.Context, input VoidPaymentInput) (*VoidPaymentOutput, error)
+}
+
+type voidPaymentUseCase struct {
+	paymentRepo  repointerface.PaymentRepository
+	invoiceRepo  repointerface.InvoiceRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewVoidPaymentUseCase creates a new instance of VoidPaymentUseCase
+func NewVoidPaymentUseCase(
+	paymentRepo repointerface.PaymentRepository,
+	invoiceRepo repointerface.InvoiceRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) VoidPaymentUseCase {
+	return &voidPaymentUseCase{
+		paymentRepo:  paymentRepo,
+		invoiceRepo:  invoiceRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *voidPaymentUseCase) Execute(ctx context.Context, input VoidPaymentInput) (*VoidPaymentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	payment, err := uc.paymentRepo.GetWithAllocations(ctx, input.PaymentID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payment: %v", err)
+		return nil, err
+	}
+	if payment == nil {
+		return nil, ErrPaymentNotFound
+	}
+	if payment.Status == entities.PaymentVoided {
+		return nil, ErrAlreadyVoided
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		ids := make([]string, 0, len(payment.Allocations))
+		deltas := make([]AllocationInput, 0, len(payment.Allocations))
+		for _, a := range payment.Allocations {
+			ids = append(ids, a.InvoiceID)
+			deltas = append(deltas, AllocationInput{InvoiceID: a.InvoiceID, Amount: a.Amount.Neg()})
+		}
+		invoices, err := lockInvoices(txCtx, uc.invoiceRepo, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		if payment.Kind == entities.PaymentKindPayment {
+			refunds, err := uc.paymentRepo.ListRefunds(txCtx, payment.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, refund := range refunds {
+				if refund.Status == entities.PaymentCompleted {
+					return nil, ErrHasRefunds
+				}
+			}
+		}
+
+		updated, err := postToInvoices(txCtx, uc.invoiceRepo, invoices, deltas)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		if err := uc.paymentRepo.Update(txCtx, payment.ID, map[string]interface{}{
+			"status":       entities.PaymentVoided,
+			"voided_at":    now,
+			"voided_by_id": optionalID(input.ActorID),
+			"void_reason":  input.Reason,
+		}); err != nil {
+			return nil, err
+		}
+		payment.Status = entities.PaymentVoided
+		payment.VoidedAt = &now
+		payment.VoidedByID = optionalID(input.ActorID)
+		payment.VoidReason = input.Reason
+
+		if _, err := uc.auditLogRepo.Create(txCtx, &entities.AuditLog{
+			ActorID:    optionalID(input.ActorID),
+			ActorRole:  input.ActorRole,
+			Action:     entities.AuditActionPaymentVoid,
+			EntityType: entities.AuditEntityPayment,
+			EntityID:   payment.ID,
+			Comment:    input.Reason,
+			Metadata: entities.JSONMap{
+				"receipt_number": payment.ReceiptNumber,
+				"kind":           payment.Kind,
+				"method":         payment.Method,
+				"amount":         payment.Amount.Int64(),
+				"reversed":       allocationMetadata(deltas),
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		return &VoidPaymentOutput{Payment: payment, Invoices: updated}, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to void payment %s: %v", input.PaymentID, err)
+		return nil, err
+	}
+
+	return result.(*VoidPaymentOutput), nil
+}
@@ -0,0 +1,112 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/payroll"
+	"doan/pkg/constants"
+	"doan/pkg/money"
+	"strings"
+	"time"
+)
+
+// calculateRun recomputes the items of a draft run from the lessons that ended in its period before now,
+// stores them and updates the run totals. Call it inside a transaction.
+func calculateRun(
+	ctx context.Context,
+	runRepo repointerface.PayrollRunRepository,
+	rateCardRepo repointerface.TeacherRateCardRepository,
+	run *entities.PayrollRun,
+	now time.Time,
+) error {
+	to := run.PeriodEnd
+	if now.Before(to) {
+		to = now
+	}
+	taught, err := runRepo.ListTaughtLessons(ctx, run.PeriodStart, to)
+	if err != nil {
+		return err
+	}
+
+	lessons := make([]payroll.Lesson, 0, len(taught))
+	teacherIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, t := range taught {
+		lessons = append(lessons, payroll.Lesson{
+			ID:             t.LessonID,
+			ClassID:        t.ClassID,
+			ClassCode:      t.ClassCode,
+			ClassName:      t.ClassName,
+			CourseID:       t.CourseID,
+			ClassTeacherID: t.ClassTeacherID,
+			TeacherID:      t.TeacherID,
+			DateStart:      t.DateStart,
+			DateEnd:        t.DateEnd,
+		})
+		if !seen[t.TeacherID] {
+			seen[t.TeacherID] = true
+			teacherIDs = append(teacherIDs, t.TeacherID)
+		}
+	}
+
+	cards, err := rateCardRepo.ListEffective(ctx, teacherIDs, payroll.Day(run.PeriodStart), payroll.Day(run.PeriodEnd))
+	if err != nil {
+		return err
+	}
+
+	items := payroll.Calculate(lessons, cards)
+	if err := runRepo.ReplaceItems(ctx, run.ID, items); err != nil {
+		return err
+	}
+
+	totals := make([]money.Amount, 0, len(items))
+	unrated := 0
+	for _, item := range items {
+		totals = append(totals, item.Amount)
+		unrated += item.UnratedSessions
+	}
+	run.TeacherCount = len(items)
+	run.LessonCount = len(lessons)
+	run.UnratedCount = unrated
+	run.TotalAmount = money.Sum(totals...)
+	run.CalculatedAt = now
+	run.Items = make([]entities.PayrollItem, 0, len(items))
+	for _, item := range items {
+		run.Items = append(run.Items, *item)
+	}
+
+	return runRepo.Update(ctx, run.ID, map[string]interface{}{
+		"teacher_count": run.TeacherCount,
+		"lesson_count":  run.LessonCount,
+		"unrated_count": run.UnratedCount,
+		"total_amount":  run.TotalAmount,
+		"calculated_at": run.CalculatedAt,
+	})
+}
+
+// parseDate parses a YYYY-MM-DD date as stored in DATE columns
+func parseDate(value string) (time.Time, error) {
+	day, err := time.Parse(constants.DateOnly, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return day, nil
+}
+
+// validRateType normalises a rate type, reporting whether it is known
+func validRateType(rateType string) (string, bool) {
+	rateType = strings.ToUpper(strings.TrimSpace(rateType))
+	switch rateType {
+	case entities.RateTypePerHour, entities.RateTypePerSession, entities.RateTypePerClass:
+		return rateType, true
+	}
+	return rateType, false
+}
+
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
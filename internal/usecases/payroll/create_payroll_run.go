@@ -0,0 +1,105 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/payroll"
+	"doan/pkg/logger"
+	"strings"
+	"time"
+)
+
+// CreatePayrollRunInput represents the month to pay, e.g. 2026-10
+type CreatePayrollRunInput struct {
+	Period      string
+	Notes       string
+	CreatedByID string
+}
+
+// CreatePayrollRunOutput represents the calculated draft run with its items
+type CreatePayrollRunOutput struct {
+	Run *entities.PayrollRun
+}
+
+// CreatePayrollRunUseCase opens the draft payroll of a month and calculates it from the lessons taught so far
+type CreatePayrollRunUseCase interface {
+	Execute(ctx context.Context, input CreatePayrollRunInput) (*CreatePayrollRunOutput, error)
+}
+
+type createPayrollRunUseCase struct {
+	runRepo      repointerface.PayrollRunRepository
+	rateCardRepo repointerface.TeacherRateCardRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewCreatePayrollRunUseCase creates a new instance of CreatePayrollRunUseCase
+func NewCreatePayrollRunUseCase(
+	runRepo repointerface.PayrollRunRepository,
+	rateCardRepo repointerface.TeacherRateCardRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) CreatePayrollRunUseCase {
+	return &createPayrollRunUseCase{
+		runRepo:      runRepo,
+		rateCardRepo: rateCardRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *createPayrollRunUseCase) Execute(ctx context.Context, input CreatePayrollRunInput) (*CreatePayrollRunOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	period := strings.TrimSpace(input.Period)
+	start, end, err := payroll.MonthBounds(period)
+	if err != nil {
+		return nil, ErrInvalidPeriod
+	}
+	now := time.Now()
+	if !start.Before(now) {
+		return nil, ErrFuturePeriod
+	}
+
+	existing, err := uc.runRepo.GetByPeriod(ctx, period)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payroll run: %v", err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrRunExists
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		run, err := uc.runRepo.Create(txCtx, &entities.PayrollRun{
+			Period:       period,
+			Status:       entities.PayrollDraft,
+			PeriodStart:  start,
+			PeriodEnd:    end,
+			CalculatedAt: now,
+			CreatedByID:  optionalID(input.CreatedByID),
+			Notes:        strings.TrimSpace(input.Notes),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := calculateRun(txCtx, uc.runRepo, uc.rateCardRepo, run, now); err != nil {
+			return nil, err
+		}
+		return run, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create payroll run for %s: %v", period, err)
+		return nil, err
+	}
+
+	run, err := uc.runRepo.GetWithItems(ctx, result.(*entities.PayrollRun).ID, false)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reload payroll run: %v", err)
+		return nil, err
+	}
+
+	return &CreatePayrollRunOutput{Run: run}, nil
+}
@@ -0,0 +1,123 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"strings"
+)
+
+// CreateRateCardInput represents a teacher pay rate; dates are YYYY-MM-DD and EffectiveTo is optional
+type CreateRateCardInput struct {
+	TeacherID     string
+	CourseID      string // empty for the teacher's default rate
+	RateType      string
+	Amount        money.Amount
+	EffectiveFrom string
+	EffectiveTo   string
+	Notes         string
+	CreatedByID   string
+}
+
+// CreateRateCardOutput represents the created rate card
+type CreateRateCardOutput struct {
+	RateCard *entities.TeacherRateCard
+}
+
+// CreateRateCardUseCase adds a pay rate for a teacher, optionally overriding it for one course
+type CreateRateCardUseCase interface {
+	Execute(ctx context.Context, input CreateRateCardInput) (*CreateRateCardOutput, error)
+}
+
+type createRateCardUseCase struct {
+	rateCardRepo repointerface.TeacherRateCardRepository
+	teacherRepo  repointerface.TeacherRepository
+	courseRepo   repointerface.CourseRepository
+}
+
+// NewCreateRateCardUseCase creates a new instance of CreateRateCardUseCase
+func NewCreateRateCardUseCase(
+	rateCardRepo repointerface.TeacherRateCardRepository,
+	teacherRepo repointerface.TeacherRepository,
+	courseRepo repointerface.CourseRepository,
+) CreateRateCardUseCase {
+	return &createRateCardUseCase{
+		rateCardRepo: rateCardRepo,
+		teacherRepo:  teacherRepo,
+		courseRepo:   courseRepo,
+	}
+}
+
+func (uc *createRateCardUseCase) Execute(ctx context.Context, input CreateRateCardInput) (*CreateRateCardOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	rateType, ok := validRateType(input.RateType)
+	if !ok {
+		return nil, ErrInvalidRateType
+	}
+	if !input.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	card := &entities.TeacherRateCard{
+		TeacherID:   input.TeacherID,
+		RateType:    rateType,
+		Amount:      input.Amount,
+		Notes:       strings.TrimSpace(input.Notes),
+		CreatedByID: optionalID(input.CreatedByID),
+	}
+	from, err := parseDate(input.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+	card.EffectiveFrom = from
+	if input.EffectiveTo != "" {
+		to, err := parseDate(input.EffectiveTo)
+		if err != nil {
+			return nil, err
+		}
+		if to.Before(from) {
+			return nil, ErrInvalidDateRange
+		}
+		card.EffectiveTo = &to
+	}
+
+	teacher, err := uc.teacherRepo.GetByID(ctx, input.TeacherID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get teacher: %v", err)
+		return nil, err
+	}
+	if teacher == nil {
+		return nil, ErrTeacherNotFound
+	}
+	if input.CourseID != "" {
+		course, err := uc.courseRepo.GetByID(ctx, input.CourseID)
+		if err != nil {
+			ctxLogger.Errorf("Failed to get course: %v", err)
+			return nil, err
+		}
+		if course == nil {
+			return nil, ErrCourseNotFound
+		}
+		card.CourseID = &input.CourseID
+	}
+
+	overlap, err := uc.rateCardRepo.HasOverlap(ctx, card)
+	if err != nil {
+		ctxLogger.Errorf("Failed to check rate card overlap: %v", err)
+		return nil, err
+	}
+	if overlap {
+		return nil, ErrRateCardOverlap
+	}
+
+	created, err := uc.rateCardRepo.Create(ctx, card)
+	if err != nil {
+		ctxLogger.Errorf("Failed to create rate card: %v", err)
+		return nil, err
+	}
+
+	return &CreateRateCardOutput{RateCard: created}, nil
+}
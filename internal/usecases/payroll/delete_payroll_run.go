@@ -0,0 +1,59 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// DeletePayrollRunUseCase discards a draft run so the month can be started again; finalised runs are kept
+type DeletePayrollRunUseCase interface {
+	Execute(ctx context.Context, runID string) error
+}
+
+type deletePayrollRunUseCase struct {
+	runRepo repointerface.PayrollRunRepository
+	uow     repositories.UnitOfWork
+	log     logger.Logger
+}
+
+// NewDeletePayrollRunUseCase creates a new instance of DeletePayrollRunUseCase
+func NewDeletePayrollRunUseCase(
+	runRepo repointerface.PayrollRunRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) DeletePayrollRunUseCase {
+	return &deletePayrollRunUseCase{
+		runRepo: runRepo,
+		uow:     uow,
+		log:     log,
+	}
+}
+
+func (uc *deletePayrollRunUseCase) Execute(ctx context.Context, runID string) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	_, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		run, err := uc.runRepo.LockByID(txCtx, runID)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			return nil, ErrRunNotFound
+		}
+		if run.Status != entities.PayrollDraft {
+			return nil, ErrRunFinalised
+		}
+		if err := uc.runRepo.ReplaceItems(txCtx, run.ID, nil); err != nil {
+			return nil, err
+		}
+		return nil, uc.runRepo.SoftDelete(txCtx, run.ID)
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to delete payroll run %s: %v", runID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package payroll
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// DeleteRateCardUseCase removes a rate card; finalised payroll runs keep the rate they were calculated with
+type DeleteRateCardUseCase interface {
+	Execute(ctx context.Context, id string) error
+}
+
+type deleteRateCardUseCase struct {
+	rateCardRepo repointerface.TeacherRateCardRepository
+}
+
+// NewDeleteRateCardUseCase creates a new instance of DeleteRateCardUseCase
+func NewDeleteRateCardUseCase(rateCardRepo repointerface.TeacherRateCardRepository) DeleteRateCardUseCase {
+	return &deleteRateCardUseCase{
+		rateCardRepo: rateCardRepo,
+	}
+}
+
+func (uc *deleteRateCardUseCase) Execute(ctx context.Context, id string) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	card, err := uc.rateCardRepo.GetByID(ctx, id)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get rate card: %v", err)
+		return err
+	}
+	if card == nil {
+		return ErrRateCardNotFound
+	}
+
+	if err := uc.rateCardRepo.SoftDelete(ctx, id); err != nil {
+		ctxLogger.Errorf("Failed to delete rate card: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package payroll
+
+import "errors"
+
+var (
+	ErrTeacherNotFound  = errors.New("teacher not found")
+	ErrCourseNotFound   = errors.New("course not found")
+	ErrRateCardNotFound = errors.New("rate card not found")
+	ErrInvalidRateType  = errors.New("rate type must be PER_HOUR, PER_SESSION or PER_CLASS")
+	ErrInvalidAmount    = errors.New("rate amount must be positive")
+	ErrInvalidDate      = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidDateRange = errors.New("effective_to must not be before effective_from")
+	ErrRateCardOverlap  = errors.New("another rate card of this teacher and course is in effect on these dates")
+	ErrInvalidPeriod    = errors.New("invalid period, expected YYYY-MM")
+	ErrRunNotFound      = errors.New("payroll run not found")
+	ErrRunExists        = errors.New("a payroll run already exists for this period")
+	ErrFuturePeriod     = errors.New("payroll period has not started yet")
+	ErrPeriodNotOver    = errors.New("payroll period is not over yet")
+	ErrRunFinalised     = errors.New("payroll run is finalised and can no longer change")
+	ErrUnratedLessons   = errors.New("some lessons have no rate card; add the missing rate cards and recalculate")
+)
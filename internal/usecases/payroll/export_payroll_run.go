@@ -0,0 +1,179 @@
+package payroll
+
+import (
+	"bytes"
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/export"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ExportPayrollRunInput represents the run to export; Detail exports one row per lesson instead of per teacher
+type ExportPayrollRunInput struct {
+	RunID  string
+	Detail bool
+}
+
+// ExportPayrollRunOutput is the generated CSV file
+type ExportPayrollRunOutput struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+	Rows        int
+}
+
+// ExportPayrollRunUseCase exports a payroll run as CSV for accounting; amounts are whole VND without separators
+type ExportPayrollRunUseCase interface {
+	Execute(ctx context.Context, input ExportPayrollRunInput) (*ExportPayrollRunOutput, error)
+}
+
+type exportPayrollRunUseCase struct {
+	runRepo repointerface.PayrollRunRepository
+}
+
+// NewExportPayrollRunUseCase creates a new instance of ExportPayrollRunUseCase
+func NewExportPayrollRunUseCase(runRepo repointerface.PayrollRunRepository) ExportPayrollRunUseCase {
+	return &exportPayrollRunUseCase{
+		runRepo: runRepo,
+	}
+}
+
+var payrollSummaryHeaders = []string{
+	"Kỳ lương",
+	"Trạng thái",
+	"Mã giáo viên",
+	"Giáo viên",
+	"Email",
+	"Số buổi",
+	"Số phút",
+	"Buổi dạy thay",
+	"Buổi chưa có đơn giá",
+	"Thành tiền (VND)",
+}
+
+var payrollDetailHeaders = []string{
+	"Kỳ lương",
+	"Mã giáo viên",
+	"Giáo viên",
+	"Ngày dạy",
+	"Bắt đầu",
+	"Mã lớp",
+	"Tên lớp",
+	"Số phút",
+	"Dạy thay",
+	"Loại đơn giá",
+	"Đơn giá (VND)",
+	"Thành tiền (VND)",
+}
+
+func (uc *exportPayrollRunUseCase) Execute(ctx context.Context, input ExportPayrollRunInput) (*ExportPayrollRunOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	run, err := uc.runRepo.GetWithItems(ctx, input.RunID, input.Detail)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payroll run: %v", err)
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrRunNotFound
+	}
+
+	loc := utils.VietnamLocation()
+	headers := payrollSummaryHeaders
+	var rows [][]string
+	for _, item := range run.Items {
+		code, name, email := teacherColumns(item.Teacher)
+		if !input.Detail {
+			rows = append(rows, []string{
+				run.Period,
+				statusLabel(run.Status),
+				code,
+				name,
+				email,
+				strconv.Itoa(item.Sessions),
+				strconv.Itoa(item.Minutes),
+				strconv.Itoa(item.SubstitutionSessions),
+				strconv.Itoa(item.UnratedSessions),
+				strconv.FormatInt(item.Amount.Int64(), 10),
+			})
+			continue
+		}
+		for _, line := range item.Lines {
+			start := line.DateStart.In(loc)
+			substitution := ""
+			if line.IsSubstitution {
+				substitution = "Có"
+			}
+			rows = append(rows, []string{
+				run.Period,
+				code,
+				name,
+				start.Format("02/01/2006"),
+				start.Format("15:04"),
+				line.ClassCode,
+				line.ClassName,
+				strconv.Itoa(line.Minutes),
+				substitution,
+				rateTypeLabel(line.RateType),
+				strconv.FormatInt(line.Rate.Int64(), 10),
+				strconv.FormatInt(line.Amount.Int64(), 10),
+			})
+		}
+	}
+	if input.Detail {
+		headers = payrollDetailHeaders
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteCSV(&buf, headers, rows); err != nil {
+		ctxLogger.Errorf("Failed to write payroll export: %v", err)
+		return nil, err
+	}
+
+	name := "bang-luong"
+	if input.Detail {
+		name = "bang-luong-chi-tiet"
+	}
+	return &ExportPayrollRunOutput{
+		FileName:    fmt.Sprintf("%s-%s-%s.%s", name, run.Period, time.Now().In(loc).Format("20060102-150405"), export.FormatCSV),
+		ContentType: export.ContentTypeCSV,
+		Content:     buf.Bytes(),
+		Rows:        len(rows),
+	}, nil
+}
+
+func teacherColumns(teacher *entities.Teacher) (string, string, string) {
+	if teacher == nil {
+		return "", "", ""
+	}
+	return teacher.Code, teacher.FullName, teacher.Email
+}
+
+func statusLabel(status string) string {
+	switch status {
+	case entities.PayrollDraft:
+		return "Nháp"
+	case entities.PayrollFinalised:
+		return "Đã chốt"
+	}
+	return status
+}
+
+func rateTypeLabel(rateType string) string {
+	switch rateType {
+	case entities.RateTypePerHour:
+		return "Theo giờ"
+	case entities.RateTypePerSession:
+		return "Theo buổi"
+	case entities.RateTypePerClass:
+		return "Theo lớp/tháng"
+	case "":
+		return "Chưa có đơn giá"
+	}
+	return rateType
+}
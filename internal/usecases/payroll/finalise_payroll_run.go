@@ -0,0 +1,122 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"time"
+)
+
+// FinalisePayrollRunInput represents the approval of a draft run
+type FinalisePayrollRunInput struct {
+	RunID     string
+	ActorID   string
+	ActorRole string
+}
+
+// FinalisePayrollRunOutput represents the locked run
+type FinalisePayrollRunOutput struct {
+	Run *entities.PayrollRun
+}
+
+// FinalisePayrollRunUseCase recalculates a draft one last time and locks its figures. The month must be over
+// and every lesson must have a rate, so nothing taught goes unpaid.
+type FinalisePayrollRunUseCase interface {
+	Execute(ctx context.Context, input FinalisePayrollRunInput) (*FinalisePayrollRunOutput, error)
+}
+
+type finalisePayrollRunUseCase struct {
+	runRepo      repointerface.PayrollRunRepository
+	rateCardRepo repointerface.TeacherRateCardRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewFinalisePayrollRunUseCase creates a new instance of FinalisePayrollRunUseCase
+func NewFinalisePayrollRunUseCase(
+	runRepo repointerface.PayrollRunRepository,
+	rateCardRepo repointerface.TeacherRateCardRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) FinalisePayrollRunUseCase {
+	return &finalisePayrollRunUseCase{
+		runRepo:      runRepo,
+		rateCardRepo: rateCardRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *finalisePayrollRunUseCase) Execute(ctx context.Context, input FinalisePayrollRunInput) (*FinalisePayrollRunOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		run, err := uc.runRepo.LockByID(txCtx, input.RunID)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			return nil, ErrRunNotFound
+		}
+		if run.Status != entities.PayrollDraft {
+			return nil, ErrRunFinalised
+		}
+		now := time.Now()
+		if now.Before(run.PeriodEnd) {
+			return nil, ErrPeriodNotOver
+		}
+
+		if err := calculateRun(txCtx, uc.runRepo, uc.rateCardRepo, run, now); err != nil {
+			return nil, err
+		}
+		if run.UnratedCount > 0 {
+			return nil, ErrUnratedLessons
+		}
+
+		if err := uc.runRepo.Update(txCtx, run.ID, map[string]interface{}{
+			"status":          entities.PayrollFinalised,
+			"finalised_at":    now,
+			"finalised_by_id": optionalID(input.ActorID),
+		}); err != nil {
+			return nil, err
+		}
+		run.Status = entities.PayrollFinalised
+		run.FinalisedAt = &now
+		run.FinalisedByID = optionalID(input.ActorID)
+
+		if _, err := uc.auditLogRepo.Create(txCtx, &entities.AuditLog{
+			ActorID:    optionalID(input.ActorID),
+			ActorRole:  input.ActorRole,
+			Action:     entities.AuditActionPayrollFinalise,
+			EntityType: entities.AuditEntityPayrollRun,
+			EntityID:   run.ID,
+			Metadata: entities.JSONMap{
+				"period":        run.Period,
+				"teacher_count": run.TeacherCount,
+				"lesson_count":  run.LessonCount,
+				"total_amount":  run.TotalAmount.Int64(),
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		return run, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to finalise payroll run %s: %v", input.RunID, err)
+		return nil, err
+	}
+
+	run, err := uc.runRepo.GetWithItems(ctx, result.(*entities.PayrollRun).ID, false)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reload payroll run: %v", err)
+		return nil, err
+	}
+
+	return &FinalisePayrollRunOutput{Run: run}, nil
+}
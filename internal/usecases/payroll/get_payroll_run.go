@@ -0,0 +1,50 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetPayrollRunInput represents the run to show; WithLines adds the lessons behind each item
+type GetPayrollRunInput struct {
+	RunID     string
+	WithLines bool
+}
+
+// GetPayrollRunOutput represents the run with its per-teacher items
+type GetPayrollRunOutput struct {
+	Run *entities.PayrollRun
+}
+
+// GetPayrollRunUseCase returns a payroll run with its items
+type GetPayrollRunUseCase interface {
+	Execute(ctx context.Context, input GetPayrollRunInput) (*GetPayrollRunOutput, error)
+}
+
+type getPayrollRunUseCase struct {
+	runRepo repointerface.PayrollRunRepository
+}
+
+// NewGetPayrollRunUseCase creates a new instance of GetPayrollRunUseCase
+func NewGetPayrollRunUseCase(runRepo repointerface.PayrollRunRepository) GetPayrollRunUseCase {
+	return &getPayrollRunUseCase{
+		runRepo: runRepo,
+	}
+}
+
+func (uc *getPayrollRunUseCase) Execute(ctx context.Context, input GetPayrollRunInput) (*GetPayrollRunOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	run, err := uc.runRepo.GetWithItems(ctx, input.RunID, input.WithLines)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get payroll run: %v", err)
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrRunNotFound
+	}
+
+	return &GetPayrollRunOutput{Run: run}, nil
+}
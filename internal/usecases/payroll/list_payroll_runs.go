@@ -0,0 +1,59 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// ListPayrollRunsInput represents the page to list
+type ListPayrollRunsInput struct {
+	Page  int
+	Limit int
+}
+
+// ListPayrollRunsOutput represents one page of runs, without items
+type ListPayrollRunsOutput struct {
+	Runs       []*entities.PayrollRun
+	Pagination *repositories.Meta
+}
+
+// ListPayrollRunsUseCase lists payroll runs, latest month first
+type ListPayrollRunsUseCase interface {
+	Execute(ctx context.Context, input ListPayrollRunsInput) (*ListPayrollRunsOutput, error)
+}
+
+type listPayrollRunsUseCase struct {
+	runRepo repointerface.PayrollRunRepository
+}
+
+// NewListPayrollRunsUseCase creates a new instance of ListPayrollRunsUseCase
+func NewListPayrollRunsUseCase(runRepo repointerface.PayrollRunRepository) ListPayrollRunsUseCase {
+	return &listPayrollRunsUseCase{
+		runRepo: runRepo,
+	}
+}
+
+func (uc *listPayrollRunsUseCase) Execute(ctx context.Context, input ListPayrollRunsInput) (*ListPayrollRunsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.runRepo.List(ctx, uint64(input.Page), uint64(input.Limit))
+	if err != nil {
+		ctxLogger.Errorf("Failed to list payroll runs: %v", err)
+		return nil, err
+	}
+
+	return &ListPayrollRunsOutput{
+		Runs:       result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// ListRateCardsInput represents the rate card list filters
+type ListRateCardsInput struct {
+	TeacherID string
+	Page      int
+	Limit     int
+}
+
+// ListRateCardsOutput represents one page of rate cards
+type ListRateCardsOutput struct {
+	RateCards  []*entities.TeacherRateCard
+	Pagination *repositories.Meta
+}
+
+// ListRateCardsUseCase lists rate cards, optionally of one teacher
+type ListRateCardsUseCase interface {
+	Execute(ctx context.Context, input ListRateCardsInput) (*ListRateCardsOutput, error)
+}
+
+type listRateCardsUseCase struct {
+	rateCardRepo repointerface.TeacherRateCardRepository
+}
+
+// NewListRateCardsUseCase creates a new instance of ListRateCardsUseCase
+func NewListRateCardsUseCase(rateCardRepo repointerface.TeacherRateCardRepository) ListRateCardsUseCase {
+	return &listRateCardsUseCase{
+		rateCardRepo: rateCardRepo,
+	}
+}
+
+func (uc *listRateCardsUseCase) Execute(ctx context.Context, input ListRateCardsInput) (*ListRateCardsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.rateCardRepo.ListByTeacher(ctx, input.TeacherID, uint64(input.Page), uint64(input.Limit))
+	if err != nil {
+		ctxLogger.Errorf("Failed to list rate cards: %v", err)
+		return nil, err
+	}
+
+	return &ListRateCardsOutput{
+		RateCards:  result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
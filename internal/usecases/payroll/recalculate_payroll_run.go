@@ -0,0 +1,75 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"time"
+)
+
+// RecalculatePayrollRunOutput represents the recalculated draft run with its items
+type RecalculatePayrollRunOutput struct {
+	Run *entities.PayrollRun
+}
+
+// RecalculatePayrollRunUseCase recomputes a draft run after lessons or rate cards changed
+type RecalculatePayrollRunUseCase interface {
+	Execute(ctx context.Context, runID string) (*RecalculatePayrollRunOutput, error)
+}
+
+type recalculatePayrollRunUseCase struct {
+	runRepo      repointerface.PayrollRunRepository
+	rateCardRepo repointerface.TeacherRateCardRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewRecalculatePayrollRunUseCase creates a new instance of RecalculatePayrollRunUseCase
+func NewRecalculatePayrollRunUseCase(
+	runRepo repointerface.PayrollRunRepository,
+	rateCardRepo repointerface.TeacherRateCardRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) RecalculatePayrollRunUseCase {
+	return &recalculatePayrollRunUseCase{
+		runRepo:      runRepo,
+		rateCardRepo: rateCardRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *recalculatePayrollRunUseCase) Execute(ctx context.Context, runID string) (*RecalculatePayrollRunOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		run, err := uc.runRepo.LockByID(txCtx, runID)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			return nil, ErrRunNotFound
+		}
+		if run.Status != entities.PayrollDraft {
+			return nil, ErrRunFinalised
+		}
+		if err := calculateRun(txCtx, uc.runRepo, uc.rateCardRepo, run, time.Now()); err != nil {
+			return nil, err
+		}
+		return run, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to recalculate payroll run %s: %v", runID, err)
+		return nil, err
+	}
+
+	run, err := uc.runRepo.GetWithItems(ctx, result.(*entities.PayrollRun).ID, false)
+	if err != nil {
+		ctxLogger.Errorf("Failed to reload payroll run: %v", err)
+		return nil, err
+	}
+
+	return &RecalculatePayrollRunOutput{Run: run}, nil
+}
@@ -0,0 +1,117 @@
+package payroll
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"doan/pkg/money"
+	"strings"
+)
+
+// UpdateRateCardInput represents the fields to change; nil fields are kept and an empty EffectiveTo makes the card open-ended
+type UpdateRateCardInput struct {
+	ID            string
+	RateType      *string
+	Amount        *money.Amount
+	EffectiveFrom *string
+	EffectiveTo   *string
+	Notes         *string
+}
+
+// UpdateRateCardOutput represents the updated rate card
+type UpdateRateCardOutput struct {
+	RateCard *entities.TeacherRateCard
+}
+
+// UpdateRateCardUseCase changes a rate card. Finalised payroll runs keep the rate they were calculated with;
+// drafts pick the change up when recalculated.
+type UpdateRateCardUseCase interface {
+	Execute(ctx context.Context, input UpdateRateCardInput) (*UpdateRateCardOutput, error)
+}
+
+type updateRateCardUseCase struct {
+	rateCardRepo repointerface.TeacherRateCardRepository
+}
+
+// NewUpdateRateCardUseCase creates a new instance of UpdateRateCardUseCase
+func NewUpdateRateCardUseCase(rateCardRepo repointerface.TeacherRateCardRepository) UpdateRateCardUseCase {
+	return &updateRateCardUseCase{
+		rateCardRepo: rateCardRepo,
+	}
+}
+
+func (uc *updateRateCardUseCase) Execute(ctx context.Context, input UpdateRateCardInput) (*UpdateRateCardOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	card, err := uc.rateCardRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get rate card: %v", err)
+		return nil, err
+	}
+	if card == nil {
+		return nil, ErrRateCardNotFound
+	}
+
+	updates := make(map[string]interface{})
+	if input.RateType != nil {
+		rateType, ok := validRateType(*input.RateType)
+		if !ok {
+			return nil, ErrInvalidRateType
+		}
+		card.RateType = rateType
+		updates["rate_type"] = rateType
+	}
+	if input.Amount != nil {
+		if !input.Amount.IsPositive() {
+			return nil, ErrInvalidAmount
+		}
+		card.Amount = *input.Amount
+		updates["amount"] = card.Amount
+	}
+	if input.EffectiveFrom != nil {
+		from, err := parseDate(*input.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		card.EffectiveFrom = from
+		updates["effective_from"] = from
+	}
+	if input.EffectiveTo != nil {
+		card.EffectiveTo = nil
+		if strings.TrimSpace(*input.EffectiveTo) != "" {
+			to, err := parseDate(*input.EffectiveTo)
+			if err != nil {
+				return nil, err
+			}
+			card.EffectiveTo = &to
+		}
+		updates["effective_to"] = card.EffectiveTo
+	}
+	if input.Notes != nil {
+		card.Notes = strings.TrimSpace(*input.Notes)
+		updates["notes"] = card.Notes
+	}
+	if len(updates) == 0 {
+		return &UpdateRateCardOutput{RateCard: card}, nil
+	}
+	if card.EffectiveTo != nil && card.EffectiveTo.Before(card.EffectiveFrom) {
+		return nil, ErrInvalidDateRange
+	}
+
+	overlap, err := uc.rateCardRepo.HasOverlap(ctx, card)
+	if err != nil {
+		ctxLogger.Errorf("Failed to check rate card overlap: %v", err)
+		return nil, err
+	}
+	if overlap {
+		return nil, ErrRateCardOverlap
+	}
+
+	if err := uc.rateCardRepo.Update(ctx, card.ID, updates); err != nil {
+		ctxLogger.Errorf("Failed to update rate card: %v", err)
+		return nil, err
+	}
+
+	return &UpdateRateCardOutput{RateCard: card}, nil
+}
@@ -0,0 +1,29 @@
+package program
+
+import (
+	"context"
+	"doan/internal/caching"
+	"doan/pkg/config"
+	"fmt"
+	"time"
+)
+
+const (
+	listCacheKeyPrefix = "programs:list:"
+	defaultListTTL     = time.Minute
+)
+
+// listCacheTTL reads "cache.list_ttl_seconds"; zero or negative disables caching of lists
+func listCacheTTL(configManager config.Manager) time.Duration {
+	return caching.ConfigTTL(configManager, "cache.list_ttl_seconds", defaultListTTL)
+}
+
+// listCacheKey identifies one page of the programs list by every field of the input
+func listCacheKey(input ListProgramsInput) string {
+	return listCacheKeyPrefix + fmt.Sprintf("%q:%q:%d:%d", input.Search, input.Track, input.Page, input.Limit)
+}
+
+// invalidateLists drops every cached page of the programs list after a write
+func invalidateLists(ctx context.Context, cache caching.CacheManager) {
+	caching.Invalidate(ctx, cache, listCacheKeyPrefix+"*")
+}
@@ -2,6 +2,7 @@ package program
 
 import (
 	"context"
+	"doan/internal/caching"
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
@@ -27,11 +28,12 @@ type CreateProgramUseCase interface {
 }
 
 type createProgramUseCaseImpl struct {
-	repo repointerface.ProgramRepository
+	repo  repointerface.ProgramRepository
+	cache caching.CacheManager
 }
 
-func NewCreateProgramUseCase(repo repointerface.ProgramRepository) CreateProgramUseCase {
-	return &createProgramUseCaseImpl{repo: repo}
+func NewCreateProgramUseCase(repo repointerface.ProgramRepository, cache caching.CacheManager) CreateProgramUseCase {
+	return &createProgramUseCaseImpl{repo: repo, cache: cache}
 }
 
 func (uc *createProgramUseCaseImpl) Execute(ctx context.Context, input CreateProgramInput) (*CreateProgramOutput, error) {
@@ -52,5 +54,6 @@ func (uc *createProgramUseCaseImpl) Execute(ctx context.Context, input CreatePro
 		ctxLogger.Errorf("Error creating program: %v", err)
 		return nil, err
 	}
+	invalidateLists(ctx, uc.cache)
 	return &CreateProgramOutput{Program: created}, nil
 }
@@ -2,6 +2,7 @@ package program
 
 import (
 	"context"
+	"doan/internal/caching"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
 )
@@ -19,11 +20,12 @@ type DeleteProgramUseCase interface {
 }
 
 type deleteProgramUseCaseImpl struct {
-	repo repointerface.ProgramRepository
+	repo  repointerface.ProgramRepository
+	cache caching.CacheManager
 }
 
-func NewDeleteProgramUseCase(repo repointerface.ProgramRepository) DeleteProgramUseCase {
-	return &deleteProgramUseCaseImpl{repo: repo}
+func NewDeleteProgramUseCase(repo repointerface.ProgramRepository, cache caching.CacheManager) DeleteProgramUseCase {
+	return &deleteProgramUseCaseImpl{repo: repo, cache: cache}
 }
 
 func (uc *deleteProgramUseCaseImpl) Execute(ctx context.Context, input DeleteProgramInput) (*DeleteProgramOutput, error) {
@@ -33,5 +35,6 @@ func (uc *deleteProgramUseCaseImpl) Execute(ctx context.Context, input DeletePro
 		ctxLogger.Errorf("Error soft deleting program: %v", err)
 		return nil, err
 	}
+	invalidateLists(ctx, uc.cache)
 	return &DeleteProgramOutput{Message: "Program deleted successfully"}, nil
 }
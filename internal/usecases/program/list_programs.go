@@ -2,9 +2,11 @@ package program
 
 import (
 	"context"
+	"doan/internal/caching"
 	"doan/internal/entities"
 	"doan/internal/repositories"
 	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
 	"doan/pkg/logger"
 )
 
@@ -32,14 +34,27 @@ type ListProgramsUseCase interface {
 }
 
 type listProgramsUseCaseImpl struct {
-	repo repointerface.ProgramRepository
+	repo          repointerface.ProgramRepository
+	cache         caching.CacheManager
+	configManager config.Manager
 }
 
-func NewListProgramsUseCase(repo repointerface.ProgramRepository) ListProgramsUseCase {
-	return &listProgramsUseCaseImpl{repo: repo}
+func NewListProgramsUseCase(
+	repo repointerface.ProgramRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) ListProgramsUseCase {
+	return &listProgramsUseCaseImpl{repo: repo, cache: cache, configManager: configManager}
 }
 
+// Execute serves the page from the cache; writes to programs invalidate every cached page
 func (uc *listProgramsUseCaseImpl) Execute(ctx context.Context, input ListProgramsInput) (*ListProgramsOutput, error) {
+	return caching.GetOrLoad(ctx, uc.cache, listCacheKey(input), listCacheTTL(uc.configManager), func(ctx context.Context) (*ListProgramsOutput, error) {
+		return uc.list(ctx, input)
+	})
+}
+
+func (uc *listProgramsUseCaseImpl) list(ctx context.Context, input ListProgramsInput) (*ListProgramsOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 	condition := repositories.NewCommonCondition().WithPaging(uint64(input.Limit), uint64(input.Page))
 	if input.Track != "" {
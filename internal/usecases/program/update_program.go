@@ -2,6 +2,7 @@ package program
 
 import (
 	"context"
+	"doan/internal/caching"
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
@@ -27,11 +28,12 @@ type UpdateProgramUseCase interface {
 }
 
 type updateProgramUseCaseImpl struct {
-	repo repointerface.ProgramRepository
+	repo  repointerface.ProgramRepository
+	cache caching.CacheManager
 }
 
-func NewUpdateProgramUseCase(repo repointerface.ProgramRepository) UpdateProgramUseCase {
-	return &updateProgramUseCaseImpl{repo: repo}
+func NewUpdateProgramUseCase(repo repointerface.ProgramRepository, cache caching.CacheManager) UpdateProgramUseCase {
+	return &updateProgramUseCaseImpl{repo: repo, cache: cache}
 }
 
 func (uc *updateProgramUseCaseImpl) Execute(ctx context.Context, input UpdateProgramInput) (*UpdateProgramOutput, error) {
@@ -73,5 +75,6 @@ func (uc *updateProgramUseCaseImpl) Execute(ctx context.Context, input UpdatePro
 		ctxLogger.Errorf("Error updating program: %v", err)
 		return nil, err
 	}
+	invalidateLists(ctx, uc.cache)
 	return &UpdateProgramOutput{Program: prog}, nil
 }
@@ -1,13 +1,27 @@
 package usecases
 
 import (
+	"doan/internal/usecases/audit"
+	"doan/internal/usecases/auditlog"
 	"doan/internal/usecases/class"
+	"doan/internal/usecases/compliance"
 	"doan/internal/usecases/course"
+	"doan/internal/usecases/dashboard"
+	"doan/internal/usecases/enrollment"
+	"doan/internal/usecases/guardian"
+	"doan/internal/usecases/invoice"
+	"doan/internal/usecases/material"
+	"doan/internal/usecases/payment"
+	"doan/internal/usecases/payroll"
 	"doan/internal/usecases/program"
+	"doan/internal/usecases/reminder"
+	"doan/internal/usecases/report"
+	"doan/internal/usecases/role"
 	"doan/internal/usecases/room"
 	"doan/internal/usecases/student"
 	"doan/internal/usecases/teacher"
 	"doan/internal/usecases/user"
+	"doan/internal/usecases/usermanagement"
 
 	"github.com/google/wire"
 )
@@ -22,6 +36,23 @@ var UserUseCaseProviders = wire.NewSet(
 	user.NewResetPasswordUseCase,
 	user.NewChangePasswordUseCase,
 	user.NewVerifyOTPUseCase,
+	user.NewActivateAccountUseCase,
+	user.NewGetMeUseCase,
+	user.NewListSessionsUseCase,
+	user.NewRevokeSessionsUseCase,
+	user.NewUnlockAccountUseCase,
+	user.NewGetPasswordPublicKeyUseCase,
+	user.NewGetJWKSUseCase,
+	user.NewListOIDCProvidersUseCase,
+	user.NewBeginOIDCLoginUseCase,
+	user.NewOIDCLoginUseCase,
+	user.NewLoginMFAUseCase,
+	user.NewLoginMFASetupUseCase,
+	user.NewSetupMFAUseCase,
+	user.NewConfirmMFAUseCase,
+	user.NewRegenerateRecoveryCodesUseCase,
+	user.NewDisableMFAUseCase,
+	user.NewResetMFAUseCase,
 )
 
 var TeacherUseCaseProviders = wire.NewSet(
@@ -32,6 +63,7 @@ var TeacherUseCaseProviders = wire.NewSet(
 	teacher.NewGetTeacherTimetableUseCase,
 	teacher.NewListTeachersUseCase,
 	teacher.NewUpdateTeacherUseCase,
+	teacher.NewInviteTeacherUseCase,
 )
 
 var RoomUseCaseProviders = wire.NewSet(
@@ -48,6 +80,7 @@ var ClassUseCaseProviders = wire.NewSet(
 	class.NewUpdateClassUseCase,
 	class.NewDeleteClassUseCase,
 	class.NewListClassesUseCase,
+	class.NewEvaluateClassComplianceUseCase,
 )
 
 var StudentUseCaseProviders = wire.NewSet(
@@ -56,6 +89,7 @@ var StudentUseCaseProviders = wire.NewSet(
 	student.NewUpdateStudentUseCase,
 	student.NewDeleteStudentUseCase,
 	student.NewListStudentsUseCase,
+	student.NewInviteStudentUseCase,
 )
 
 var CourseUseCaseProviders = wire.NewSet(
@@ -76,6 +110,128 @@ var ProgramUseCaseProviders = wire.NewSet(
 	program.NewRemoveCoursesUseCase,
 )
 
+var MaterialUseCaseProviders = wire.NewSet(
+	material.NewUploadMaterialUseCase,
+	material.NewListMaterialsUseCase,
+	material.NewGetMaterialUseCase,
+	material.NewDownloadMaterialUseCase,
+	material.NewDeleteMaterialUseCase,
+)
+
+var AuditUseCaseProviders = wire.NewSet(
+	audit.NewProcessMaterialAuditUseCase,
+	audit.NewRequestMaterialAuditUseCase,
+	audit.NewListMaterialAnalysesUseCase,
+)
+
+var ComplianceUseCaseProviders = wire.NewSet(
+	compliance.NewListReviewQueueUseCase,
+	compliance.NewReviewMaterialUseCase,
+	compliance.NewListMaterialAuditLogsUseCase,
+)
+
+var EnrollmentUseCaseProviders = wire.NewSet(
+	enrollment.NewCreateEnrollmentUseCase,
+	enrollment.NewApproveEnrollmentUseCase,
+	enrollment.NewRejectEnrollmentUseCase,
+	enrollment.NewListEnrollmentsUseCase,
+)
+
+var InvoiceUseCaseProviders = wire.NewSet(
+	invoice.NewListInvoicesUseCase,
+	invoice.NewGetInvoiceUseCase,
+	invoice.NewGetOutstandingBalanceUseCase,
+	invoice.NewListOverdueInvoicesUseCase,
+)
+
+var PaymentUseCaseProviders = wire.NewSet(
+	payment.NewRecordPaymentUseCase,
+	payment.NewRefundPaymentUseCase,
+	payment.NewVoidPaymentUseCase,
+	payment.NewGetPaymentUseCase,
+	payment.NewListPaymentsUseCase,
+	payment.NewGetDailyReconciliationUseCase,
+	payment.NewHandleWebhookUseCase,
+	payment.NewGenerateInvoiceQRUseCase,
+)
+
+var DashboardUseCaseProviders = wire.NewSet(
+	dashboard.NewGetSummaryUseCase,
+	dashboard.NewGetRevenueSeriesUseCase,
+	dashboard.NewGetEnrollmentSeriesUseCase,
+	dashboard.NewGetTeacherUtilisationUseCase,
+)
+
+var PayrollUseCaseProviders = wire.NewSet(
+	payroll.NewCreateRateCardUseCase,
+	payroll.NewUpdateRateCardUseCase,
+	payroll.NewDeleteRateCardUseCase,
+	payroll.NewListRateCardsUseCase,
+	payroll.NewCreatePayrollRunUseCase,
+	payroll.NewRecalculatePayrollRunUseCase,
+	payroll.NewFinalisePayrollRunUseCase,
+	payroll.NewGetPayrollRunUseCase,
+	payroll.NewListPayrollRunsUseCase,
+	payroll.NewDeletePayrollRunUseCase,
+	payroll.NewExportPayrollRunUseCase,
+)
+
+var GuardianUseCaseProviders = wire.NewSet(
+	guardian.NewCreateGuardianUseCase,
+	guardian.NewUpdateGuardianUseCase,
+	guardian.NewDeleteGuardianUseCase,
+	guardian.NewGetGuardianUseCase,
+	guardian.NewListGuardiansUseCase,
+	guardian.NewLinkStudentUseCase,
+	guardian.NewUnlinkStudentUseCase,
+	guardian.NewLinkAccountUseCase,
+	guardian.NewGetMyChildrenUseCase,
+	guardian.NewGetChildTimetableUseCase,
+	guardian.NewGetChildAttendanceUseCase,
+	guardian.NewGetChildGradesUseCase,
+	guardian.NewListChildInvoicesUseCase,
+	guardian.NewListChildLeaveRequestsUseCase,
+)
+
+var RoleUseCaseProviders = wire.NewSet(
+	role.NewCreateRoleUseCase,
+	role.NewUpdateRoleUseCase,
+	role.NewDeleteRoleUseCase,
+	role.NewGetRoleUseCase,
+	role.NewListRolesUseCase,
+)
+
+var UserManagementUseCaseProviders = wire.NewSet(
+	usermanagement.NewListUsersUseCase,
+	usermanagement.NewGetUserUseCase,
+	usermanagement.NewCreateUserUseCase,
+	usermanagement.NewUpdateUserRoleUseCase,
+	usermanagement.NewSetUserActiveUseCase,
+	usermanagement.NewDeleteUserUseCase,
+	usermanagement.NewRestoreUserUseCase,
+	usermanagement.NewForcePasswordResetUseCase,
+	usermanagement.NewImpersonateUserUseCase,
+)
+
+var AuditLogUseCaseProviders = wire.NewSet(
+	auditlog.NewListAuditLogsUseCase,
+	auditlog.NewGetAuditLogUseCase,
+	auditlog.NewVerifyAuditChainUseCase,
+	auditlog.NewSealAuditLogsUseCase,
+)
+
+var ReminderUseCaseProviders = wire.NewSet(
+	reminder.NewSendDueRemindersUseCase,
+	reminder.NewCreateOptOutUseCase,
+	reminder.NewDeleteOptOutUseCase,
+	reminder.NewListOptOutsUseCase,
+)
+
+var ReportUseCaseProviders = wire.NewSet(
+	report.NewGetMaterialQualityStatsUseCase,
+	report.NewExportReviewHistoryUseCase,
+)
+
 var UseCaseProviders = wire.NewSet(
 	UserUseCaseProviders,
 	TeacherUseCaseProviders,
@@ -84,4 +240,18 @@ var UseCaseProviders = wire.NewSet(
 	StudentUseCaseProviders,
 	CourseUseCaseProviders,
 	ProgramUseCaseProviders,
+	MaterialUseCaseProviders,
+	AuditUseCaseProviders,
+	ComplianceUseCaseProviders,
+	ReportUseCaseProviders,
+	EnrollmentUseCaseProviders,
+	InvoiceUseCaseProviders,
+	PaymentUseCaseProviders,
+	ReminderUseCaseProviders,
+	PayrollUseCaseProviders,
+	DashboardUseCaseProviders,
+	GuardianUseCaseProviders,
+	RoleUseCaseProviders,
+	UserManagementUseCaseProviders,
+	AuditLogUseCaseProviders,
 )
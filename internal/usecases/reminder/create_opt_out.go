@@ -0,0 +1,70 @@
+package reminder
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// CreateOptOutInput represents a guardian asking not to receive tuition reminders
+type CreateOptOutInput struct {
+	GuardianPhone string
+	Reason        string
+	CreatedByID   string
+}
+
+// CreateOptOutOutput represents the recorded opt-out
+type CreateOptOutOutput struct {
+	OptOut *entities.ReminderOptOut
+}
+
+// CreateOptOutUseCase stops reminders for every student sharing the guardian phone
+type CreateOptOutUseCase interface {
+	Execute(ctx context.Context, input CreateOptOutInput) (*CreateOptOutOutput, error)
+}
+
+type createOptOutUseCase struct {
+	optOutRepo repointerface.ReminderOptOutRepository
+}
+
+// NewCreateOptOutUseCase creates a new instance of CreateOptOutUseCase
+func NewCreateOptOutUseCase(optOutRepo repointerface.ReminderOptOutRepository) CreateOptOutUseCase {
+	return &createOptOutUseCase{
+		optOutRepo: optOutRepo,
+	}
+}
+
+func (uc *createOptOutUseCase) Execute(ctx context.Context, input CreateOptOutInput) (*CreateOptOutOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	phone := strings.TrimSpace(input.GuardianPhone)
+	if phone == "" {
+		return nil, ErrGuardianPhoneRequired
+	}
+
+	existing, err := uc.optOutRepo.GetByGuardianPhone(ctx, phone)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get reminder opt-out: %v", err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyOptedOut
+	}
+
+	optOut := &entities.ReminderOptOut{
+		GuardianPhone: phone,
+		Reason:        strings.TrimSpace(input.Reason),
+	}
+	if input.CreatedByID != "" {
+		optOut.CreatedByID = &input.CreatedByID
+	}
+	created, err := uc.optOutRepo.Create(ctx, optOut)
+	if err != nil {
+		ctxLogger.Errorf("Failed to create reminder opt-out: %v", err)
+		return nil, err
+	}
+
+	return &CreateOptOutOutput{OptOut: created}, nil
+}
@@ -0,0 +1,48 @@
+package reminder
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// DeleteOptOutInput represents a guardian opting back in to tuition reminders
+type DeleteOptOutInput struct {
+	GuardianPhone string
+}
+
+// DeleteOptOutUseCase resumes reminders for a guardian
+type DeleteOptOutUseCase interface {
+	Execute(ctx context.Context, input DeleteOptOutInput) error
+}
+
+type deleteOptOutUseCase struct {
+	optOutRepo repointerface.ReminderOptOutRepository
+}
+
+// NewDeleteOptOutUseCase creates a new instance of DeleteOptOutUseCase
+func NewDeleteOptOutUseCase(optOutRepo repointerface.ReminderOptOutRepository) DeleteOptOutUseCase {
+	return &deleteOptOutUseCase{
+		optOutRepo: optOutRepo,
+	}
+}
+
+func (uc *deleteOptOutUseCase) Execute(ctx context.Context, input DeleteOptOutInput) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	optOut, err := uc.optOutRepo.GetByGuardianPhone(ctx, strings.TrimSpace(input.GuardianPhone))
+	if err != nil {
+		ctxLogger.Errorf("Failed to get reminder opt-out: %v", err)
+		return err
+	}
+	if optOut == nil {
+		return ErrOptOutNotFound
+	}
+
+	if err := uc.optOutRepo.SoftDelete(ctx, optOut.ID); err != nil {
+		ctxLogger.Errorf("Failed to delete reminder opt-out: %v", err)
+		return err
+	}
+	return nil
+}
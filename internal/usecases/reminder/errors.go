@@ -0,0 +1,9 @@
+package reminder
+
+import "errors"
+
+var (
+	ErrGuardianPhoneRequired = errors.New("guardian phone is required")
+	ErrAlreadyOptedOut       = errors.New("guardian already opted out of reminders")
+	ErrOptOutNotFound        = errors.New("guardian has not opted out of reminders")
+)
@@ -0,0 +1,59 @@
+package reminder
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// ListOptOutsInput represents the page of opt-outs to list
+type ListOptOutsInput struct {
+	Page  int
+	Limit int
+}
+
+// ListOptOutsOutput represents one page of opt-outs
+type ListOptOutsOutput struct {
+	OptOuts    []*entities.ReminderOptOut
+	Pagination *repositories.Meta
+}
+
+// ListOptOutsUseCase lists the guardians who opted out of tuition reminders
+type ListOptOutsUseCase interface {
+	Execute(ctx context.Context, input ListOptOutsInput) (*ListOptOutsOutput, error)
+}
+
+type listOptOutsUseCase struct {
+	optOutRepo repointerface.ReminderOptOutRepository
+}
+
+// NewListOptOutsUseCase creates a new instance of ListOptOutsUseCase
+func NewListOptOutsUseCase(optOutRepo repointerface.ReminderOptOutRepository) ListOptOutsUseCase {
+	return &listOptOutsUseCase{
+		optOutRepo: optOutRepo,
+	}
+}
+
+func (uc *listOptOutsUseCase) Execute(ctx context.Context, input ListOptOutsInput) (*ListOptOutsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 || input.Limit > 100 {
+		input.Limit = 20
+	}
+
+	result, err := uc.optOutRepo.List(ctx, uint64(input.Page), uint64(input.Limit))
+	if err != nil {
+		ctxLogger.Errorf("Failed to list reminder opt-outs: %v", err)
+		return nil, err
+	}
+
+	return &ListOptOutsOutput{
+		OptOuts:    result.Data,
+		Pagination: &result.Meta,
+	}, nil
+}
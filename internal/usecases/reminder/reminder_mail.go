@@ -0,0 +1,85 @@
+package reminder
+
+import (
+	"doan/internal/entities"
+	"doan/internal/services/billing"
+	"doan/internal/services/mailer"
+	"doan/pkg/utils"
+	"fmt"
+	"html"
+	"time"
+)
+
+// reminderMail renders the tuition reminder of a dunning step
+func reminderMail(invoice *entities.Invoice, step *billing.DunningStep, recipient string, now time.Time) mailer.Mail {
+	dueDate := invoice.DueDate.In(utils.VietnamLocation()).Format("02/01/2006")
+	daysLate := billing.DaysPastDue(invoice.DueDate, now)
+
+	var subject, lead string
+	switch step.Template {
+	case billing.TemplateUpcoming:
+		subject = fmt.Sprintf("Nhắc hạn nộp học phí hóa đơn %s", invoice.Number)
+		lead = fmt.Sprintf("Hóa đơn học phí <strong>%s</strong> sẽ đến hạn thanh toán vào ngày <strong>%s</strong>.",
+			html.EscapeString(invoice.Number), dueDate)
+	case billing.TemplateDue:
+		subject = fmt.Sprintf("Hôm nay là hạn nộp học phí hóa đơn %s", invoice.Number)
+		lead = fmt.Sprintf("Hóa đơn học phí <strong>%s</strong> đến hạn thanh toán <strong>hôm nay (%s)</strong>.",
+			html.EscapeString(invoice.Number), dueDate)
+	default:
+		subject = fmt.Sprintf("Học phí quá hạn %d ngày - hóa đơn %s", daysLate, invoice.Number)
+		lead = fmt.Sprintf(`Hóa đơn học phí <strong>%s</strong> đã <strong style="color: #dc3545;">quá hạn %d ngày</strong> (hạn thanh toán %s).`,
+			html.EscapeString(invoice.Number), daysLate, dueDate)
+	}
+
+	studentName := ""
+	if invoice.Student != nil {
+		studentName = invoice.Student.FullName
+	}
+
+	htmlBody := fmt.Sprintf(`
+			<!DOCTYPE html>
+			<html>
+			<head>
+				<meta charset="UTF-8">
+			</head>
+			<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+				<div style="max-width: 600px; margin: 20px auto; padding: 20px; border: 1px solid #ddd; border-radius: 8px;">
+					<h2>Thông báo học phí</h2>
+					<p>Kính gửi Quý phụ huynh học viên <strong>%s</strong>,</p>
+					<p>%s</p>
+					<table style="border-collapse: collapse; margin: 10px 0;">
+						<tr><td style="padding: 4px 12px 4px 0;">Tổng tiền</td><td><strong>%s</strong></td></tr>
+						<tr><td style="padding: 4px 12px 4px 0;">Đã thanh toán</td><td>%s</td></tr>
+						<tr><td style="padding: 4px 12px 4px 0;">Còn phải nộp</td><td><strong>%s</strong></td></tr>
+					</table>
+					<p>Khi chuyển khoản, vui lòng ghi nội dung <strong>%s</strong> để trung tâm đối soát tự động.</p>
+					<p>Nếu Quý phụ huynh đã thanh toán, xin vui lòng bỏ qua thư này.</p>
+					<p style="margin-top: 20px; font-size: 0.9em; color: #777;">Trân trọng,<br>Phòng kế toán</p>
+				</div>
+			</body>
+			</html>
+		`,
+		html.EscapeString(studentName),
+		lead,
+		invoice.Total.Format(),
+		invoice.PaidAmount.Format(),
+		invoice.Balance().Format(),
+		html.EscapeString(invoice.Number))
+
+	return mailer.Mail{
+		To:      recipient,
+		Subject: subject,
+		HTML:    htmlBody,
+	}
+}
+
+// recipientOf is the guardian email, or the student's own when no guardian email is on file
+func recipientOf(student *entities.Student) string {
+	if student == nil {
+		return ""
+	}
+	if student.GuardianEmail != "" {
+		return student.GuardianEmail
+	}
+	return student.Email
+}
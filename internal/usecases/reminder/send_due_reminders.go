@@ -0,0 +1,156 @@
+package reminder
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/billing"
+	"doan/internal/services/mailer"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"time"
+)
+
+// SendDueRemindersInput represents one run of the reminder job; AsOf defaults to now
+type SendDueRemindersInput struct {
+	AsOf time.Time
+}
+
+// SendDueRemindersOutput counts what the run did with each candidate invoice
+type SendDueRemindersOutput struct {
+	Checked     int // open invoices inside the reminder window
+	Sent        int
+	Failed      int // retried by a later run until the attempt limit
+	AlreadySent int // step already sent, in flight or out of attempts
+	OptedOut    int
+	NoRecipient int // neither guardian nor student email on file
+}
+
+// SendDueRemindersUseCase sends each open invoice the latest dunning step it has reached, at most once per step
+type SendDueRemindersUseCase interface {
+	Execute(ctx context.Context, input SendDueRemindersInput) (*SendDueRemindersOutput, error)
+}
+
+type sendDueRemindersUseCase struct {
+	invoiceRepo  repointerface.InvoiceRepository
+	reminderRepo repointerface.InvoiceReminderRepository
+	optOutRepo   repointerface.ReminderOptOutRepository
+	schedule     billing.DunningSchedule
+	mailer       mailer.Mailer
+	log          logger.Logger
+}
+
+// NewSendDueRemindersUseCase creates a new instance of SendDueRemindersUseCase
+func NewSendDueRemindersUseCase(
+	invoiceRepo repointerface.InvoiceRepository,
+	reminderRepo repointerface.InvoiceReminderRepository,
+	optOutRepo repointerface.ReminderOptOutRepository,
+	schedule billing.DunningSchedule,
+	mailer mailer.Mailer,
+	log logger.Logger,
+) SendDueRemindersUseCase {
+	return &sendDueRemindersUseCase{
+		invoiceRepo:  invoiceRepo,
+		reminderRepo: reminderRepo,
+		optOutRepo:   optOutRepo,
+		schedule:     schedule,
+		mailer:       mailer,
+		log:          log,
+	}
+}
+
+func (uc *sendDueRemindersUseCase) Execute(ctx context.Context, input SendDueRemindersInput) (*SendDueRemindersOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	now := input.AsOf
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	// An invoice enters the window on the day of the first step: due before (tomorrow - earliest offset)
+	today := now.In(utils.VietnamLocation())
+	tomorrow := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location()).AddDate(0, 0, 1)
+	invoices, err := uc.invoiceRepo.ListOpenDueBefore(ctx, tomorrow.AddDate(0, 0, -uc.schedule.EarliestOffset()))
+	if err != nil {
+		ctxLogger.Errorf("Failed to list invoices for reminders: %v", err)
+		return nil, err
+	}
+
+	phones := make([]string, 0, len(invoices))
+	for _, invoice := range invoices {
+		if invoice.Student != nil && invoice.Student.GuardianPhone != "" {
+			phones = append(phones, invoice.Student.GuardianPhone)
+		}
+	}
+	optedOut, err := uc.optOutRepo.OptedOut(ctx, phones)
+	if err != nil {
+		ctxLogger.Errorf("Failed to load reminder opt-outs: %v", err)
+		return nil, err
+	}
+
+	output := &SendDueRemindersOutput{Checked: len(invoices)}
+	for _, invoice := range invoices {
+		if ctx.Err() != nil {
+			return output, ctx.Err()
+		}
+
+		step := uc.schedule.StepFor(invoice.DueDate, now)
+		if step == nil {
+			continue
+		}
+		if invoice.Student != nil && optedOut[invoice.Student.GuardianPhone] {
+			output.OptedOut++
+			continue
+		}
+		recipient := recipientOf(invoice.Student)
+		if recipient == "" {
+			output.NoRecipient++
+			continue
+		}
+
+		reminder, err := uc.reminderRepo.Claim(ctx, invoice.ID, step.Code, recipient, uc.schedule.MaxAttempts())
+		if err != nil {
+			ctxLogger.Errorf("Failed to claim reminder %s for invoice %s: %v", step.Code, invoice.Number, err)
+			return output, err
+		}
+		if reminder == nil {
+			output.AlreadySent++
+			continue
+		}
+
+		uc.send(ctx, invoice, step, reminder, now, output)
+	}
+
+	ctxLogger.Infof("Tuition reminders: checked %d, sent %d, failed %d, opted out %d, no recipient %d",
+		output.Checked, output.Sent, output.Failed, output.OptedOut, output.NoRecipient)
+	return output, nil
+}
+
+// send mails a claimed reminder and records the outcome; a failed send stays claimable for retries
+func (uc *sendDueRemindersUseCase) send(
+	ctx context.Context,
+	invoice *entities.Invoice,
+	step *billing.DunningStep,
+	reminder *entities.InvoiceReminder,
+	now time.Time,
+	output *SendDueRemindersOutput,
+) {
+	updateData := map[string]interface{}{}
+	if err := uc.mailer.Send(ctx, reminderMail(invoice, step, reminder.Recipient, now)); err != nil {
+		uc.log.Warn(ctx, "Failed to send tuition reminder",
+			"invoice", invoice.Number, "step", step.Code, "attempt", reminder.Attempts, "error", err)
+		updateData["status"] = entities.ReminderFailed
+		updateData["last_error"] = err.Error()
+		output.Failed++
+	} else {
+		updateData["status"] = entities.ReminderSent
+		updateData["last_error"] = ""
+		updateData["sent_at"] = time.Now()
+		output.Sent++
+	}
+
+	// A reminder left PENDING after a crash is never retried: it may already have been delivered
+	if err := uc.reminderRepo.Update(ctx, reminder.ID, updateData); err != nil {
+		uc.log.Error(ctx, "Failed to record tuition reminder", "invoice", invoice.Number, "step", step.Code, "error", err)
+	}
+}
@@ -0,0 +1,8 @@
+package report
+
+import "errors"
+
+var (
+	ErrInvalidPeriod = errors.New("invalid period: dates must be YYYY-MM-DD and from must not be after to")
+	ErrInvalidFormat = errors.New("export format must be csv or xlsx")
+)
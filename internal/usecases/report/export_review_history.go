@@ -0,0 +1,142 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/export"
+	"doan/pkg/logger"
+	"doan/pkg/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportReviewHistoryInput represents the filters and format of the review history export
+type ExportReviewHistoryInput struct {
+	TeacherID string
+	CourseID  string
+	Period    PeriodInput
+	Format    string // csv (default) or xlsx
+}
+
+// ExportReviewHistoryOutput is the generated file
+type ExportReviewHistoryOutput struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+	Rows        int
+}
+
+// ExportReviewHistoryUseCase exports every compliance decision for inspectors
+type ExportReviewHistoryUseCase interface {
+	Execute(ctx context.Context, input ExportReviewHistoryInput) (*ExportReviewHistoryOutput, error)
+}
+
+type exportReviewHistoryUseCase struct {
+	reportRepo repointerface.MaterialReportRepository
+}
+
+// NewExportReviewHistoryUseCase creates a new instance of ExportReviewHistoryUseCase
+func NewExportReviewHistoryUseCase(reportRepo repointerface.MaterialReportRepository) ExportReviewHistoryUseCase {
+	return &exportReviewHistoryUseCase{
+		reportRepo: reportRepo,
+	}
+}
+
+var reviewHistoryHeaders = []string{
+	"Thời gian kiểm duyệt",
+	"Quyết định",
+	"Nhận xét",
+	"Người kiểm duyệt",
+	"Email người kiểm duyệt",
+	"Vai trò",
+	"Mã tài liệu",
+	"Tên tài liệu",
+	"Tên tệp",
+	"Nhãn AI",
+	"Mã khóa học",
+	"Tên khóa học",
+	"Giáo viên",
+	"Email giáo viên",
+	"Thời gian tải lên",
+}
+
+func (uc *exportReviewHistoryUseCase) Execute(ctx context.Context, input ExportReviewHistoryInput) (*ExportReviewHistoryOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = export.FormatCSV
+	}
+	if format != export.FormatCSV && format != export.FormatXLSX {
+		return nil, ErrInvalidFormat
+	}
+	from, to, err := parsePeriod(input.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.reportRepo.ListReviewHistory(ctx, repointerface.MaterialReportFilter{
+		TeacherID: input.TeacherID,
+		CourseID:  input.CourseID,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to list review history: %v", err)
+		return nil, err
+	}
+
+	loc := utils.VietnamLocation()
+	rows := make([][]string, 0, len(history))
+	for _, h := range history {
+		rows = append(rows, []string{
+			h.ReviewedAt.In(loc).Format(time.DateTime),
+			decisionLabel(h.Action),
+			h.Comment,
+			h.ReviewerName,
+			h.ReviewerEmail,
+			h.ReviewerRole,
+			h.MaterialID,
+			h.MaterialTitle,
+			h.FileName,
+			utils.Deref(h.AILabel),
+			utils.Deref(h.CourseCode),
+			utils.Deref(h.CourseName),
+			h.TeacherName,
+			h.TeacherEmail,
+			h.UploadedAt.In(loc).Format(time.DateTime),
+		})
+	}
+
+	var buf bytes.Buffer
+	output := &ExportReviewHistoryOutput{
+		FileName: fmt.Sprintf("lich-su-kiem-duyet-%s.%s", time.Now().In(loc).Format("20060102-150405"), format),
+		Rows:     len(rows),
+	}
+	if format == export.FormatXLSX {
+		output.ContentType = export.ContentTypeXLSX
+		err = export.WriteXLSX(&buf, "Lịch sử kiểm duyệt", reviewHistoryHeaders, rows)
+	} else {
+		output.ContentType = export.ContentTypeCSV
+		err = export.WriteCSV(&buf, reviewHistoryHeaders, rows)
+	}
+	if err != nil {
+		ctxLogger.Errorf("Failed to write review history export: %v", err)
+		return nil, err
+	}
+	output.Content = buf.Bytes()
+	return output, nil
+}
+
+func decisionLabel(action string) string {
+	switch action {
+	case entities.AuditActionMaterialApprove:
+		return "Phê duyệt"
+	case entities.AuditActionMaterialReject:
+		return "Từ chối"
+	}
+	return action
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"context"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetMaterialQualityStatsInput represents the filters of the material quality report
+type GetMaterialQualityStatsInput struct {
+	TeacherID  string
+	CourseID   string
+	Period     PeriodInput
+	TopReasons int
+}
+
+// GetMaterialQualityStatsOutput represents the material quality report
+type GetMaterialQualityStatsOutput struct {
+	Stats *repointerface.MaterialQualityStats
+	// ApprovalRate is approved / (approved + rejected), 0 when nothing was reviewed
+	ApprovalRate float64
+}
+
+// GetMaterialQualityStatsUseCase aggregates AI labels and compliance decisions for the Circular 29 report
+type GetMaterialQualityStatsUseCase interface {
+	Execute(ctx context.Context, input GetMaterialQualityStatsInput) (*GetMaterialQualityStatsOutput, error)
+}
+
+type getMaterialQualityStatsUseCase struct {
+	reportRepo repointerface.MaterialReportRepository
+}
+
+// NewGetMaterialQualityStatsUseCase creates a new instance of GetMaterialQualityStatsUseCase
+func NewGetMaterialQualityStatsUseCase(reportRepo repointerface.MaterialReportRepository) GetMaterialQualityStatsUseCase {
+	return &getMaterialQualityStatsUseCase{
+		reportRepo: reportRepo,
+	}
+}
+
+func (uc *getMaterialQualityStatsUseCase) Execute(ctx context.Context, input GetMaterialQualityStatsInput) (*GetMaterialQualityStatsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	from, to, err := parsePeriod(input.Period)
+	if err != nil {
+		return nil, err
+	}
+	if input.TopReasons <= 0 {
+		input.TopReasons = 5
+	}
+	if input.TopReasons > 50 {
+		input.TopReasons = 50
+	}
+
+	stats, err := uc.reportRepo.GetQualityStats(ctx, repointerface.MaterialReportFilter{
+		TeacherID: input.TeacherID,
+		CourseID:  input.CourseID,
+		From:      from,
+		To:        to,
+	}, input.TopReasons)
+	if err != nil {
+		ctxLogger.Errorf("Failed to compute material quality stats: %v", err)
+		return nil, err
+	}
+
+	output := &GetMaterialQualityStatsOutput{Stats: stats}
+	if reviewed := stats.ApprovedCount + stats.RejectedCount; reviewed > 0 {
+		output.ApprovalRate = float64(stats.ApprovedCount) / float64(reviewed)
+	}
+	return output, nil
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"doan/pkg/constants"
+	"doan/pkg/utils"
+	"time"
+)
+
+// PeriodInput is an inclusive date range in Vietnam time, both ends optional
+type PeriodInput struct {
+	From string // YYYY-MM-DD
+	To   string // YYYY-MM-DD, inclusive
+}
+
+// parsePeriod converts the dates to the half-open instant range [from 00:00, day after to 00:00)
+func parsePeriod(period PeriodInput) (from, to *time.Time, err error) {
+	loc := utils.VietnamLocation()
+	if period.From != "" {
+		t, err := time.ParseInLocation(constants.DateOnly, period.From, loc)
+		if err != nil {
+			return nil, nil, ErrInvalidPeriod
+		}
+		from = &t
+	}
+	if period.To != "" {
+		t, err := time.ParseInLocation(constants.DateOnly, period.To, loc)
+		if err != nil {
+			return nil, nil, ErrInvalidPeriod
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, ErrInvalidPeriod
+	}
+	return from, to, nil
+}
@@ -0,0 +1,97 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// CreateRoleInput represents a new custom role
+type CreateRoleInput struct {
+	Name        string
+	Description string
+	Permissions []string
+	ActorID     string
+	ActorRole   string
+}
+
+// CreateRoleOutput represents the created role
+type CreateRoleOutput struct {
+	Role *entities.Role
+}
+
+// CreateRoleUseCase creates a custom role from a bundle of permissions
+type CreateRoleUseCase interface {
+	Execute(ctx context.Context, input CreateRoleInput) (*CreateRoleOutput, error)
+}
+
+type createRoleUseCase struct {
+	roleRepo     repointerface.RoleRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewCreateRoleUseCase creates a new instance of CreateRoleUseCase
+func NewCreateRoleUseCase(
+	roleRepo repointerface.RoleRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) CreateRoleUseCase {
+	return &createRoleUseCase{
+		roleRepo:     roleRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *createRoleUseCase) Execute(ctx context.Context, input CreateRoleInput) (*CreateRoleOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	name, err := normalizeName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := normalizePermissions(input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		existing, err := uc.roleRepo.GetByName(txCtx, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrRoleExists
+		}
+
+		created, err := uc.roleRepo.Create(txCtx, &entities.Role{
+			Name:        name,
+			Description: strings.TrimSpace(input.Description),
+			Permissions: permissions,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.ActorID, input.ActorRole, entities.AuditActionRoleCreate, created, entities.JSONMap{
+			"name":        created.Name,
+			"permissions": permissions,
+		}); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create role %s: %v", name, err)
+		return nil, err
+	}
+
+	return &CreateRoleOutput{Role: result.(*entities.Role)}, nil
+}
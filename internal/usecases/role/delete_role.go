@@ -0,0 +1,85 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// DeleteRoleInput represents the role to delete
+type DeleteRoleInput struct {
+	ID        string
+	ActorID   string
+	ActorRole string
+}
+
+// DeleteRoleUseCase deletes a custom role that no user holds any more
+type DeleteRoleUseCase interface {
+	Execute(ctx context.Context, input DeleteRoleInput) error
+}
+
+type deleteRoleUseCase struct {
+	roleRepo     repointerface.RoleRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewDeleteRoleUseCase creates a new instance of DeleteRoleUseCase
+func NewDeleteRoleUseCase(
+	roleRepo repointerface.RoleRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) DeleteRoleUseCase {
+	return &deleteRoleUseCase{
+		roleRepo:     roleRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *deleteRoleUseCase) Execute(ctx context.Context, input DeleteRoleInput) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	_, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		role, err := uc.roleRepo.GetByID(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, ErrRoleNotFound
+		}
+		if role.IsSystem {
+			return nil, ErrSystemRole
+		}
+
+		holders, err := uc.roleRepo.CountUsers(txCtx, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		if holders > 0 {
+			return nil, ErrRoleInUse
+		}
+
+		if err := uc.roleRepo.HardDelete(txCtx, role.ID); err != nil {
+			return nil, err
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.ActorID, input.ActorRole, entities.AuditActionRoleDelete, role, entities.JSONMap{
+			"name":        role.Name,
+			"permissions": []string(role.Permissions),
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to delete role %s: %v", input.ID, err)
+		return err
+	}
+
+	return nil
+}
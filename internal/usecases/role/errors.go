@@ -0,0 +1,13 @@
+package role
+
+import "errors"
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrInvalidRoleName   = errors.New("role name must be 2-50 upper-case letters, digits or underscores, starting with a letter")
+	ErrRoleExists        = errors.New("a role with this name already exists")
+	ErrInvalidPermission = errors.New("unknown permission")
+	ErrSystemRole        = errors.New("built-in roles cannot be deleted")
+	ErrAdminRoleLocked   = errors.New("the permissions of the ADMIN role cannot be changed")
+	ErrRoleInUse         = errors.New("role is still assigned to users")
+)
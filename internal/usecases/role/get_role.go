@@ -0,0 +1,56 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+)
+
+// GetRoleInput represents the role to fetch
+type GetRoleInput struct {
+	ID string
+}
+
+// GetRoleOutput represents the role with the number of users holding it
+type GetRoleOutput struct {
+	Role      *entities.Role
+	UserCount int64
+}
+
+// GetRoleUseCase fetches a role
+type GetRoleUseCase interface {
+	Execute(ctx context.Context, input GetRoleInput) (*GetRoleOutput, error)
+}
+
+type getRoleUseCase struct {
+	roleRepo repointerface.RoleRepository
+}
+
+// NewGetRoleUseCase creates a new instance of GetRoleUseCase
+func NewGetRoleUseCase(roleRepo repointerface.RoleRepository) GetRoleUseCase {
+	return &getRoleUseCase{
+		roleRepo: roleRepo,
+	}
+}
+
+func (uc *getRoleUseCase) Execute(ctx context.Context, input GetRoleInput) (*GetRoleOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	role, err := uc.roleRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get role: %v", err)
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	count, err := uc.roleRepo.CountUsers(ctx, role.Name)
+	if err != nil {
+		ctxLogger.Errorf("Failed to count role users: %v", err)
+		return nil, err
+	}
+
+	return &GetRoleOutput{Role: role, UserCount: count}, nil
+}
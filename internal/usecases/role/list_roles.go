@@ -0,0 +1,43 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+)
+
+// ListRolesOutput represents every role and the permission catalog roles are built from
+type ListRolesOutput struct {
+	Roles       []*entities.Role
+	Permissions []constants.PermissionInfo
+}
+
+// ListRolesUseCase lists the roles, built-in roles first
+type ListRolesUseCase interface {
+	Execute(ctx context.Context) (*ListRolesOutput, error)
+}
+
+type listRolesUseCase struct {
+	roleRepo repointerface.RoleRepository
+}
+
+// NewListRolesUseCase creates a new instance of ListRolesUseCase
+func NewListRolesUseCase(roleRepo repointerface.RoleRepository) ListRolesUseCase {
+	return &listRolesUseCase{
+		roleRepo: roleRepo,
+	}
+}
+
+func (uc *listRolesUseCase) Execute(ctx context.Context) (*ListRolesOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	roles, err := uc.roleRepo.ListAll(ctx)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list roles: %v", err)
+		return nil, err
+	}
+
+	return &ListRolesOutput{Roles: roles, Permissions: constants.Permissions()}, nil
+}
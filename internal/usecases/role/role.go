@@ -0,0 +1,56 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,49}$`)
+
+// normalizeName upper-cases a role name and checks its format
+func normalizeName(name string) (string, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !roleNamePattern.MatchString(name) {
+		return "", ErrInvalidRoleName
+	}
+	return name, nil
+}
+
+// normalizePermissions trims and de-duplicates permissions, rejecting unknown ones
+func normalizePermissions(permissions []string) ([]string, error) {
+	out := make([]string, 0, len(permissions))
+	seen := make(map[string]bool, len(permissions))
+	for _, perm := range permissions {
+		perm = strings.TrimSpace(perm)
+		if !constants.IsValidPermission(perm) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPermission, perm)
+		}
+		if !seen[perm] {
+			seen[perm] = true
+			out = append(out, perm)
+		}
+	}
+	return out, nil
+}
+
+// writeAudit records a role change made by the actor
+func writeAudit(ctx context.Context, auditLogRepo repointerface.AuditLogRepository, actorID, actorRole, action string, role *entities.Role, metadata entities.JSONMap) error {
+	var actor *string
+	if actorID != "" {
+		actor = &actorID
+	}
+	_, err := auditLogRepo.Create(ctx, &entities.AuditLog{
+		ActorID:    actor,
+		ActorRole:  actorRole,
+		Action:     action,
+		EntityType: entities.AuditEntityRole,
+		EntityID:   role.ID,
+		Metadata:   metadata,
+	})
+	return err
+}
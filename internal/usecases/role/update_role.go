@@ -0,0 +1,134 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// UpdateRoleInput represents the fields to change; nil fields are kept. The name cannot change
+// because users reference their role by name.
+type UpdateRoleInput struct {
+	ID          string
+	Description *string
+	Permissions *[]string
+	ActorID     string
+	ActorRole   string
+}
+
+// UpdateRoleOutput represents the updated role
+type UpdateRoleOutput struct {
+	Role *entities.Role
+}
+
+// UpdateRoleUseCase changes the description or permission bundle of a role. When the permissions change,
+// every session of the role's holders is revoked: their tokens carry the old permissions, and the new
+// ones apply from the next login.
+type UpdateRoleUseCase interface {
+	Execute(ctx context.Context, input UpdateRoleInput) (*UpdateRoleOutput, error)
+}
+
+type updateRoleUseCase struct {
+	roleRepo     repointerface.RoleRepository
+	authService  user.AuthService
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewUpdateRoleUseCase creates a new instance of UpdateRoleUseCase
+func NewUpdateRoleUseCase(
+	roleRepo repointerface.RoleRepository,
+	authService user.AuthService,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) UpdateRoleUseCase {
+	return &updateRoleUseCase{
+		roleRepo:     roleRepo,
+		authService:  authService,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *updateRoleUseCase) Execute(ctx context.Context, input UpdateRoleInput) (*UpdateRoleOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	var permissions []string
+	if input.Permissions != nil {
+		var err error
+		if permissions, err = normalizePermissions(*input.Permissions); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		role, err := uc.roleRepo.GetByID(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, ErrRoleNotFound
+		}
+
+		updates := make(map[string]interface{})
+		metadata := entities.JSONMap{}
+		permissionsChanged := false
+		if input.Description != nil {
+			role.Description = strings.TrimSpace(*input.Description)
+			updates["description"] = role.Description
+		}
+		if input.Permissions != nil {
+			// Keeping ADMIN on "*" guarantees someone can always manage roles
+			if role.Name == constants.RoleAdmin {
+				return nil, ErrAdminRoleLocked
+			}
+			permissionsChanged = !samePermissions(role.Permissions, permissions)
+			metadata["previous_permissions"] = []string(role.Permissions)
+			metadata["permissions"] = permissions
+			role.Permissions = permissions
+			updates["permissions"] = pq.StringArray(permissions)
+		}
+		if len(updates) == 0 {
+			return role, nil
+		}
+
+		if err := uc.roleRepo.Update(txCtx, role.ID, updates); err != nil {
+			return nil, err
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.ActorID, input.ActorRole, entities.AuditActionRoleUpdate, role, metadata); err != nil {
+			return nil, err
+		}
+		// Last, so the sessions are only denied once everything else went through
+		if permissionsChanged {
+			if _, err := uc.authService.RevokeRoleSessions(txCtx, role.Name); err != nil {
+				return nil, err
+			}
+		}
+		return role, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to update role %s: %v", input.ID, err)
+		return nil, err
+	}
+
+	return &UpdateRoleOutput{Role: result.(*entities.Role)}, nil
+}
+
+// samePermissions reports whether both bundles grant the same permissions, in whatever order
+func samePermissions(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,99 @@
+package role
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/logger"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// roleRepository serves one role and records updates to it
+type roleRepository struct {
+	repointerface.RoleRepository
+	role    entities.Role
+	updated bool
+}
+
+func (r *roleRepository) GetByID(ctx context.Context, id interface{}) (*entities.Role, error) {
+	role := r.role
+	return &role, nil
+}
+
+func (r *roleRepository) Update(ctx context.Context, id interface{}, updatedData map[string]interface{}) error {
+	r.updated = true
+	return nil
+}
+
+type auditLogRepository struct {
+	repointerface.AuditLogRepository
+}
+
+func (auditLogRepository) Create(ctx context.Context, entity *entities.AuditLog) (*entities.AuditLog, error) {
+	return entity, nil
+}
+
+// authService records the roles whose sessions were revoked
+type authService struct {
+	user.AuthService
+	revoked []string
+}
+
+func (s *authService) RevokeRoleSessions(ctx context.Context, role string) (int, error) {
+	s.revoked = append(s.revoked, role)
+	return 1, nil
+}
+
+type unitOfWork struct{}
+
+func (unitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (unitOfWork) Commit(ctx context.Context) error                   { return nil }
+func (unitOfWork) Rollback(ctx context.Context) error                 { return nil }
+
+type discardLogger struct {
+	logger.Logger
+}
+
+func (discardLogger) Info(ctx context.Context, msg string, keysAndValues ...interface{})  {}
+func (discardLogger) Error(ctx context.Context, msg string, keysAndValues ...interface{}) {}
+
+func TestUpdateRoleRevokesSessions(t *testing.T) {
+	description := "Teachers of the centre"
+
+	tests := []struct {
+		name        string
+		input       UpdateRoleInput
+		wantRevoked bool
+	}{
+		{"permission added", UpdateRoleInput{Permissions: &[]string{"student:read", "material:read", "material:upload"}}, true},
+		{"permission removed", UpdateRoleInput{Permissions: &[]string{"student:read"}}, true},
+		{"same permissions in another order", UpdateRoleInput{Permissions: &[]string{"material:upload", "student:read"}}, false},
+		{"description only", UpdateRoleInput{Description: &description}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := &roleRepository{role: entities.Role{
+				ID:          "role-1",
+				Name:        "TEACHER",
+				Permissions: pq.StringArray{"student:read", "material:upload"},
+			}}
+			auth := &authService{}
+			uc := NewUpdateRoleUseCase(roles, auth, auditLogRepository{}, unitOfWork{}, discardLogger{})
+
+			tt.input.ID = "role-1"
+			if _, err := uc.Execute(context.Background(), tt.input); err != nil {
+				t.Fatal(err)
+			}
+			if !roles.updated {
+				t.Error("role not updated")
+			}
+			if revoked := len(auth.revoked) == 1 && auth.revoked[0] == "TEACHER"; revoked != tt.wantRevoked {
+				t.Errorf("revoked sessions of %v, want revoked %v", auth.revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
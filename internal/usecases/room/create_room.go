@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 
+	"doan/internal/caching"
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
@@ -25,11 +26,13 @@ type CreateRoomUseCase interface {
 
 type createRoomUseCase struct {
 	roomRepo repointerface.RoomRepository
+	cache    caching.CacheManager
 }
 
-func NewCreateRoomUseCase(roomRepo repointerface.RoomRepository) CreateRoomUseCase {
+func NewCreateRoomUseCase(roomRepo repointerface.RoomRepository, cache caching.CacheManager) CreateRoomUseCase {
 	return &createRoomUseCase{
 		roomRepo: roomRepo,
+		cache:    cache,
 	}
 }
 
@@ -47,5 +50,6 @@ func (uc *createRoomUseCase) Execute(ctx context.Context, input CreateRoomInput)
 		return nil, err
 	}
 
+	invalidateLists(ctx, uc.cache)
 	return &CreateRoomOutput{Room: createdRoom}, nil
 }
@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 
+	"doan/internal/caching"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
 )
@@ -21,11 +22,13 @@ type DeleteRoomUseCase interface {
 
 type deleteRoomUseCase struct {
 	roomRepo repointerface.RoomRepository
+	cache    caching.CacheManager
 }
 
-func NewDeleteRoomUseCase(roomRepo repointerface.RoomRepository) DeleteRoomUseCase {
+func NewDeleteRoomUseCase(roomRepo repointerface.RoomRepository, cache caching.CacheManager) DeleteRoomUseCase {
 	return &deleteRoomUseCase{
 		roomRepo: roomRepo,
+		cache:    cache,
 	}
 }
 
@@ -38,5 +41,6 @@ func (uc *deleteRoomUseCase) Execute(ctx context.Context, input DeleteRoomInput)
 		return nil, err
 	}
 
+	invalidateLists(ctx, uc.cache)
 	return &DeleteRoomOutput{Message: "Room deleted successfully"}, nil
 }
@@ -3,9 +3,11 @@ package room
 import (
 	"context"
 
+	"doan/internal/caching"
 	"doan/internal/entities"
 	"doan/internal/repositories"
 	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/config"
 	"doan/pkg/logger"
 )
 
@@ -33,16 +35,31 @@ type ListRoomsUseCase interface {
 }
 
 type listRoomsUseCase struct {
-	roomRepo repointerface.RoomRepository
+	roomRepo      repointerface.RoomRepository
+	cache         caching.CacheManager
+	configManager config.Manager
 }
 
-func NewListRoomsUseCase(roomRepo repointerface.RoomRepository) ListRoomsUseCase {
+func NewListRoomsUseCase(
+	roomRepo repointerface.RoomRepository,
+	cache caching.CacheManager,
+	configManager config.Manager,
+) ListRoomsUseCase {
 	return &listRoomsUseCase{
-		roomRepo: roomRepo,
+		roomRepo:      roomRepo,
+		cache:         cache,
+		configManager: configManager,
 	}
 }
 
+// Execute serves the page from the cache; writes to rooms invalidate every cached page
 func (uc *listRoomsUseCase) Execute(ctx context.Context, input ListRoomsInput) (*ListRoomsOutput, error) {
+	return caching.GetOrLoad(ctx, uc.cache, listCacheKey(input), listCacheTTL(uc.configManager), func(ctx context.Context) (*ListRoomsOutput, error) {
+		return uc.list(ctx, input)
+	})
+}
+
+func (uc *listRoomsUseCase) list(ctx context.Context, input ListRoomsInput) (*ListRoomsOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
 	commonCond := repositories.NewCommonCondition()
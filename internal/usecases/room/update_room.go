@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 
+	"doan/internal/caching"
 	"doan/internal/entities"
 	repointerface "doan/internal/repositories/interface"
 	"doan/pkg/logger"
@@ -26,11 +27,13 @@ type UpdateRoomUseCase interface {
 
 type updateRoomUseCase struct {
 	roomRepo repointerface.RoomRepository
+	cache    caching.CacheManager
 }
 
-func NewUpdateRoomUseCase(roomRepo repointerface.RoomRepository) UpdateRoomUseCase {
+func NewUpdateRoomUseCase(roomRepo repointerface.RoomRepository, cache caching.CacheManager) UpdateRoomUseCase {
 	return &updateRoomUseCase{
 		roomRepo: roomRepo,
+		cache:    cache,
 	}
 }
 
@@ -58,5 +61,6 @@ func (uc *updateRoomUseCase) Execute(ctx context.Context, input UpdateRoomInput)
 	room.Name = input.Name
 	room.Capacity = input.Capacity
 
+	invalidateLists(ctx, uc.cache)
 	return &UpdateRoomOutput{Room: room}, nil
 }
@@ -5,7 +5,10 @@ import (
 	"time"
 
 	"doan/internal/entities"
+	"doan/internal/repositories"
 	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/pkg/constants"
 	"doan/pkg/logger"
 )
 
@@ -15,11 +18,15 @@ type CreateStudentInput struct {
 	Email         string
 	Phone         string
 	GuardianPhone string
+	GuardianEmail string
 	GradeLevel    string
+	SchoolName    string
 	Status        string
 	DateOfBirth   *time.Time
 	Gender        string
 	Address       string
+	InviteAccount bool // create an inactive STUDENT login and email its activation link
+	InvitedByID   string
 }
 
 type CreateStudentOutput struct {
@@ -32,11 +39,22 @@ type CreateStudentUseCase interface {
 
 type createStudentUseCase struct {
 	studentRepo repointerface.StudentRepository
+	inviter     account.Inviter
+	uow         repositories.UnitOfWork
+	log         logger.Logger
 }
 
-func NewCreateStudentUseCase(studentRepo repointerface.StudentRepository) CreateStudentUseCase {
+func NewCreateStudentUseCase(
+	studentRepo repointerface.StudentRepository,
+	inviter account.Inviter,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) CreateStudentUseCase {
 	return &createStudentUseCase{
 		studentRepo: studentRepo,
+		inviter:     inviter,
+		uow:         uow,
+		log:         log,
 	}
 }
 
@@ -49,18 +67,41 @@ func (uc *createStudentUseCase) Execute(ctx context.Context, input CreateStudent
 		Email:         input.Email,
 		Phone:         input.Phone,
 		GuardianPhone: input.GuardianPhone,
+		GuardianEmail: input.GuardianEmail,
 		GradeLevel:    input.GradeLevel,
+		SchoolName:    input.SchoolName,
 		Status:        input.Status,
 		DateOfBirth:   input.DateOfBirth,
 		Gender:        input.Gender,
 		Address:       input.Address,
 	}
 
-	createdStudent, err := uc.studentRepo.Create(ctx, student)
+	// The login account is created with the profile so neither exists without the other
+	var invitation *account.Invitation
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		if input.InviteAccount {
+			var err error
+			invitation, err = uc.inviter.Invite(txCtx, account.InviteInput{
+				Email:       input.Email,
+				FullName:    input.FullName,
+				Role:        constants.RoleStudent,
+				InvitedByID: input.InvitedByID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			student.UserID = &invitation.User.ID
+		}
+		return uc.studentRepo.Create(txCtx, student)
+	})
 	if err != nil {
 		ctxLogger.Errorf("Failed to create student: %v", err)
 		return nil, err
 	}
 
-	return &CreateStudentOutput{Student: createdStudent}, nil
+	if invitation != nil {
+		uc.inviter.Send(ctx, invitation)
+	}
+
+	return &CreateStudentOutput{Student: result.(*entities.Student)}, nil
 }
@@ -0,0 +1,90 @@
+package student
+
+import (
+	"context"
+	"errors"
+
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+)
+
+type InviteStudentInput struct {
+	ID          string
+	InvitedByID string
+}
+
+type InviteStudentOutput struct {
+	Student *entities.Student
+}
+
+// InviteStudentUseCase emails a student an activation link. A student without an account gets a new
+// inactive STUDENT login linked to their profile; one whose account is still inactive gets a fresh link.
+type InviteStudentUseCase interface {
+	Execute(ctx context.Context, input InviteStudentInput) (*InviteStudentOutput, error)
+}
+
+type inviteStudentUseCase struct {
+	studentRepo repointerface.StudentRepository
+	inviter     account.Inviter
+	uow         repositories.UnitOfWork
+	log         logger.Logger
+}
+
+func NewInviteStudentUseCase(
+	studentRepo repointerface.StudentRepository,
+	inviter account.Inviter,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) InviteStudentUseCase {
+	return &inviteStudentUseCase{
+		studentRepo: studentRepo,
+		inviter:     inviter,
+		uow:         uow,
+		log:         log,
+	}
+}
+
+func (uc *inviteStudentUseCase) Execute(ctx context.Context, input InviteStudentInput) (*InviteStudentOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	student, err := uc.studentRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get student: %v", err)
+		return nil, err
+	}
+	if student == nil {
+		return nil, errors.New("student not found")
+	}
+
+	var invitation *account.Invitation
+	_, err = repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		var err error
+		invitation, err = uc.inviter.Invite(txCtx, account.InviteInput{
+			UserID:      student.UserID,
+			Email:       student.Email,
+			FullName:    student.FullName,
+			Role:        constants.RoleStudent,
+			InvitedByID: input.InvitedByID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if student.UserID == nil {
+			student.UserID = &invitation.User.ID
+			return nil, uc.studentRepo.Update(txCtx, student.ID, map[string]interface{}{"user_id": invitation.User.ID})
+		}
+		return nil, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to invite student %s: %v", student.ID, err)
+		return nil, err
+	}
+
+	uc.inviter.Send(ctx, invitation)
+
+	return &InviteStudentOutput{Student: student}, nil
+}
@@ -16,7 +16,9 @@ type UpdateStudentInput struct {
 	Email         string
 	Phone         string
 	GuardianPhone string
+	GuardianEmail string
 	GradeLevel    string
+	SchoolName    string
 	Status        string
 	DateOfBirth   *time.Time
 	Gender        string
@@ -51,7 +53,9 @@ func (uc *updateStudentUseCase) Execute(ctx context.Context, input UpdateStudent
 	updateData["email"] = input.Email
 	updateData["phone"] = input.Phone
 	updateData["guardian_phone"] = input.GuardianPhone
+	updateData["guardian_email"] = input.GuardianEmail
 	updateData["grade_level"] = input.GradeLevel
+	updateData["school_name"] = input.SchoolName
 	updateData["status"] = input.Status
 	updateData["date_of_birth"] = input.DateOfBirth
 	updateData["gender"] = input.Gender
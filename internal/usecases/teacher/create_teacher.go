@@ -3,7 +3,10 @@ package teacher
 import (
 	"context"
 	"doan/internal/entities"
+	"doan/internal/repositories"
 	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/pkg/constants"
 	"doan/pkg/logger"
 	"errors"
 )
@@ -19,6 +22,8 @@ type CreateTeacherInput struct {
 	EmploymentType  string `json:"employment_type"`
 	Status          string `json:"status"`
 	Notes           string `json:"notes"`
+	InviteAccount   bool   `json:"invite_account"` // create an inactive TEACHER login and email its activation link
+	InvitedByID     string `json:"invited_by_id"`
 }
 
 // CreateTeacherOutput represents the output after creating a teacher
@@ -33,12 +38,23 @@ type CreateTeacherUseCase interface {
 
 type createTeacherUseCase struct {
 	teacherRepo repointerface.TeacherRepository
+	inviter     account.Inviter
+	uow         repositories.UnitOfWork
+	log         logger.Logger
 }
 
 // NewCreateTeacherUseCase creates a new instance of CreateTeacherUseCase
-func NewCreateTeacherUseCase(teacherRepo repointerface.TeacherRepository) CreateTeacherUseCase {
+func NewCreateTeacherUseCase(
+	teacherRepo repointerface.TeacherRepository,
+	inviter account.Inviter,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) CreateTeacherUseCase {
 	return &createTeacherUseCase{
 		teacherRepo: teacherRepo,
+		inviter:     inviter,
+		uow:         uow,
+		log:         log,
 	}
 }
 
@@ -95,12 +111,32 @@ func (uc *createTeacherUseCase) Execute(ctx context.Context, input CreateTeacher
 		Notes:           input.Notes,
 	}
 
-	// Save to database
-	createdTeacher, err := uc.teacherRepo.Create(ctx, teacher)
+	// Save to database, with the login account when inviting so neither exists without the other
+	var invitation *account.Invitation
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		if input.InviteAccount {
+			var err error
+			invitation, err = uc.inviter.Invite(txCtx, account.InviteInput{
+				Email:       input.Email,
+				FullName:    input.FullName,
+				Role:        constants.RoleTeacher,
+				InvitedByID: input.InvitedByID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			teacher.UserID = &invitation.User.ID
+		}
+		return uc.teacherRepo.Create(txCtx, teacher)
+	})
 	if err != nil {
 		ctxLogger.Errorf("Failed to create teacher: %v", err)
 		return nil, err
 	}
 
-	return &CreateTeacherOutput{Teacher: createdTeacher}, nil
+	if invitation != nil {
+		uc.inviter.Send(ctx, invitation)
+	}
+
+	return &CreateTeacherOutput{Teacher: result.(*entities.Teacher)}, nil
 }
@@ -0,0 +1,92 @@
+package teacher
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/pkg/constants"
+	"doan/pkg/logger"
+	"errors"
+)
+
+// InviteTeacherInput identifies the teacher to invite
+type InviteTeacherInput struct {
+	ID          string
+	InvitedByID string
+}
+
+// InviteTeacherOutput represents the teacher linked to their login account
+type InviteTeacherOutput struct {
+	Teacher *entities.Teacher
+}
+
+// InviteTeacherUseCase emails a teacher an activation link. A teacher without an account gets a new
+// inactive TEACHER login linked to their profile; one whose account is still inactive gets a fresh link.
+type InviteTeacherUseCase interface {
+	Execute(ctx context.Context, input InviteTeacherInput) (*InviteTeacherOutput, error)
+}
+
+type inviteTeacherUseCase struct {
+	teacherRepo repointerface.TeacherRepository
+	inviter     account.Inviter
+	uow         repositories.UnitOfWork
+	log         logger.Logger
+}
+
+// NewInviteTeacherUseCase creates a new instance of InviteTeacherUseCase
+func NewInviteTeacherUseCase(
+	teacherRepo repointerface.TeacherRepository,
+	inviter account.Inviter,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) InviteTeacherUseCase {
+	return &inviteTeacherUseCase{
+		teacherRepo: teacherRepo,
+		inviter:     inviter,
+		uow:         uow,
+		log:         log,
+	}
+}
+
+func (uc *inviteTeacherUseCase) Execute(ctx context.Context, input InviteTeacherInput) (*InviteTeacherOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	teacher, err := uc.teacherRepo.GetByID(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get teacher: %v", err)
+		return nil, err
+	}
+	if teacher == nil {
+		return nil, errors.New("teacher not found")
+	}
+
+	var invitation *account.Invitation
+	_, err = repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		var err error
+		invitation, err = uc.inviter.Invite(txCtx, account.InviteInput{
+			UserID:      teacher.UserID,
+			Email:       teacher.Email,
+			FullName:    teacher.FullName,
+			Role:        constants.RoleTeacher,
+			InvitedByID: input.InvitedByID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if teacher.UserID == nil {
+			teacher.UserID = &invitation.User.ID
+			return nil, uc.teacherRepo.Update(txCtx, teacher.ID, map[string]interface{}{"user_id": invitation.User.ID})
+		}
+		return nil, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to invite teacher %s: %v", teacher.ID, err)
+		return nil, err
+	}
+
+	uc.inviter.Send(ctx, invitation)
+
+	return &InviteTeacherOutput{Teacher: teacher}, nil
+}
@@ -0,0 +1,113 @@
+package user
+
+import (
+	"context"
+	"doan/internal/repositories"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
+	"doan/pkg/logger"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ActivateAccount
+
+type ActivateAccountInput struct {
+	Token       string
+	PasswordEnc string
+}
+
+// ActivateAccountUseCase sets the password of an invited account and activates it; each link works once
+type ActivateAccountUseCase interface {
+	Execute(ctx context.Context, in ActivateAccountInput) error
+}
+
+type activateAccountUseCase struct {
+	userRepo       repositoryinterface.UserRepository
+	invitationRepo repositoryinterface.AccountInvitationRepository
+	hasher         security.PasswordHasher
+	cipher         security.PasswordCipher
+	policy         userservice.PasswordPolicy
+	uow            repositories.UnitOfWork
+	log            logger.Logger
+}
+
+func NewActivateAccountUseCase(
+	userRepo repositoryinterface.UserRepository,
+	invitationRepo repositoryinterface.AccountInvitationRepository,
+	hasher security.PasswordHasher,
+	cipher security.PasswordCipher,
+	policy userservice.PasswordPolicy,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) ActivateAccountUseCase {
+	return &activateAccountUseCase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		hasher:         hasher,
+		cipher:         cipher,
+		policy:         policy,
+		uow:            uow,
+		log:            log,
+	}
+}
+
+func (u *activateAccountUseCase) Execute(ctx context.Context, in ActivateAccountInput) error {
+	if strings.TrimSpace(in.Token) == "" || strings.TrimSpace(in.PasswordEnc) == "" {
+		return errors.New("invalid payload")
+	}
+
+	passwordPlain, err := u.cipher.Decrypt(ctx, in.PasswordEnc)
+	if err != nil {
+		return fmt.Errorf("invalid password payload: %w", err)
+	}
+
+	_, err = repositories.ExecuteInTransaction(ctx, u.uow, u.log, func(txCtx context.Context) (interface{}, error) {
+		invitation, err := u.invitationRepo.LockPendingByTokenHash(txCtx, account.HashToken(in.Token))
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		if invitation == nil || now.After(invitation.ExpiresAt) {
+			return nil, errors.New("invalid or expired activation link")
+		}
+
+		// A rejected password leaves the link usable for another try
+		user, err := u.userRepo.GetByID(txCtx, invitation.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errors.New("invalid or expired activation link")
+		}
+		if err := u.policy.Validate(txCtx, userservice.PasswordCandidate{
+			Plain:       passwordPlain,
+			UserID:      user.ID,
+			Email:       user.Email,
+			FullName:    user.FullName,
+			CurrentHash: user.Password,
+		}); err != nil {
+			return nil, err
+		}
+		passwordHash, err := u.hasher.Hash(passwordPlain)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.policy.Retire(txCtx, user.ID, user.Password); err != nil {
+			return nil, err
+		}
+
+		if err := u.userRepo.Update(txCtx, invitation.UserID, map[string]interface{}{
+			"password":  passwordHash,
+			"is_active": true,
+		}); err != nil {
+			return nil, err
+		}
+		return nil, u.invitationRepo.Update(txCtx, invitation.ID, map[string]interface{}{"accepted_at": now})
+	})
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	repositoryinterface "doan/internal/repositories/interface"
 	"doan/internal/services/mailer"
 	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
 	"doan/pkg/config"
 	"doan/pkg/random" // New import for random token generation
 	"errors"
@@ -37,6 +38,7 @@ type forgotPasswordUseCase struct {
 	cfg               config.Manager
 	hasher            security.PasswordHasher // New dependency for hashing token
 	db                *gorm.DB                // New dependency for transaction
+	throttle          userservice.AttemptThrottle
 }
 
 func NewForgotPasswordUseCase(
@@ -46,6 +48,7 @@ func NewForgotPasswordUseCase(
 	cfg config.Manager,
 	hasher security.PasswordHasher, // New
 	db *gorm.DB, // New
+	throttle userservice.AttemptThrottle,
 ) ForgotPasswordUseCase {
 	return &forgotPasswordUseCase{
 		userRepo:          repo,
@@ -54,6 +57,7 @@ func NewForgotPasswordUseCase(
 		cfg:               cfg,
 		hasher:            hasher,
 		db:                db,
+		throttle:          throttle,
 	}
 }
 
@@ -63,6 +67,14 @@ func (u *forgotPasswordUseCase) Execute(ctx context.Context, in ForgotPasswordIn
 		return errors.New("invalid email")
 	}
 
+	// Every request counts, whether or not the email exists, so the answer reveals nothing
+	// and nobody can flood an inbox or churn through reset codes
+	attempt := userservice.Attempt{Scope: userservice.ScopeForgotPassword, Account: email, IP: in.RequestIP}
+	if err := u.throttle.Check(ctx, attempt); err != nil {
+		return err
+	}
+	u.throttle.Fail(ctx, attempt)
+
 	cond := repositories.NewCommonCondition()
 	cond.AddCondition("email", email, repositories.Equal)
 	userResult, err := u.userRepo.GetByCondition(ctx, cond)
@@ -190,6 +202,7 @@ type resetPasswordUseCase struct {
 	passwordResetRepo repositoryinterface.PasswordResetRepository // New dependency
 	hasher            security.PasswordHasher
 	cipher            security.PasswordCipher
+	policy            userservice.PasswordPolicy
 	cfg               config.Manager
 	db                *gorm.DB // New dependency for transaction
 }
@@ -199,6 +212,7 @@ func NewResetPasswordUseCase(
 	passwordResetRepo repositoryinterface.PasswordResetRepository, // New
 	hasher security.PasswordHasher,
 	cipher security.PasswordCipher,
+	policy userservice.PasswordPolicy,
 	cfg config.Manager,
 	db *gorm.DB, // New
 ) ResetPasswordUseCase {
@@ -207,6 +221,7 @@ func NewResetPasswordUseCase(
 		passwordResetRepo: passwordResetRepo,
 		hasher:            hasher,
 		cipher:            cipher,
+		policy:            policy,
 		cfg:               cfg,
 		db:                db,
 	}
@@ -223,7 +238,14 @@ func (u *resetPasswordUseCase) Execute(ctx context.Context, in ResetPasswordInpu
 		return errors.New("invalid reset token")
 	}
 
+	// Decrypt the new password first: a rejected one must not use up the token
+	plainNewPassword, err := u.cipher.Decrypt(ctx, in.NewPasswordEnc)
+	if err != nil {
+		return fmt.Errorf("invalid new password payload: %w", err)
+	}
+
 	var foundReset *entities.PasswordReset
+	var user *entities.User
 
 	// Validate and mark token as used in a transaction
 	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -237,6 +259,23 @@ func (u *resetPasswordUseCase) Execute(ctx context.Context, in ResetPasswordInpu
 			return errors.New("invalid or expired token")
 		}
 
+		user, err = u.userRepo.GetByID(ctx, reset.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return errors.New("invalid or expired token")
+		}
+		if err := u.policy.Validate(ctx, userservice.PasswordCandidate{
+			Plain:       plainNewPassword,
+			UserID:      user.ID,
+			Email:       user.Email,
+			FullName:    user.FullName,
+			CurrentHash: user.Password,
+		}); err != nil {
+			return err
+		}
+
 		// Mark token as used
 		if err := u.passwordResetRepo.MarkAsUsedTx(ctx, tx, reset.ID, time.Now()); err != nil {
 			return fmt.Errorf("failed to mark password reset token as used: %w", err)
@@ -249,21 +288,14 @@ func (u *resetPasswordUseCase) Execute(ctx context.Context, in ResetPasswordInpu
 		return err // This will contain "invalid or expired token" or internal errors
 	}
 
-	// Decrypt and hash new password
-	plainNewPassword, err := u.cipher.Decrypt(in.NewPasswordEnc)
-	if err != nil {
-		return errors.New("invalid new password payload")
-	}
-
-	// TODO: Add password strength validation here
-	if len(plainNewPassword) < 8 { // Example minimum length
-		return errors.New("new password is too short")
-	}
-
+	// Hash new password
 	hashedNewPassword, err := u.hasher.Hash(plainNewPassword)
 	if err != nil {
 		return fmt.Errorf("failed to hash new password: %w", err)
 	}
+	if err := u.policy.Retire(ctx, user.ID, user.Password); err != nil {
+		return fmt.Errorf("failed to record the previous password: %w", err)
+	}
 
 	// Update user's password
 	update := map[string]interface{}{"password": hashedNewPassword}
@@ -278,7 +310,7 @@ func (u *resetPasswordUseCase) Execute(ctx context.Context, in ResetPasswordInpu
 	return nil
 }
 
-// ChangePassword (no changes needed)
+// ChangePassword
 
 type ChangePasswordInput struct {
 	UserID         string
@@ -294,10 +326,11 @@ type changePasswordUseCase struct {
 	userRepo repositoryinterface.UserRepository
 	hasher   security.PasswordHasher
 	cipher   security.PasswordCipher
+	policy   userservice.PasswordPolicy
 }
 
-func NewChangePasswordUseCase(repo repositoryinterface.UserRepository, hasher security.PasswordHasher, cipher security.PasswordCipher) ChangePasswordUseCase {
-	return &changePasswordUseCase{userRepo: repo, hasher: hasher, cipher: cipher}
+func NewChangePasswordUseCase(repo repositoryinterface.UserRepository, hasher security.PasswordHasher, cipher security.PasswordCipher, policy userservice.PasswordPolicy) ChangePasswordUseCase {
+	return &changePasswordUseCase{userRepo: repo, hasher: hasher, cipher: cipher, policy: policy}
 }
 
 func (u *changePasswordUseCase) Execute(ctx context.Context, in ChangePasswordInput) error {
@@ -305,24 +338,36 @@ func (u *changePasswordUseCase) Execute(ctx context.Context, in ChangePasswordIn
 		return errors.New("invalid payload")
 	}
 	user, err := u.userRepo.GetByID(ctx, in.UserID)
-	if err != nil {
+	if err != nil || user == nil {
 		return errors.New("user not found")
 	}
-	oldPlain, err := u.cipher.Decrypt(in.OldPasswordEnc)
+	oldPlain, err := u.cipher.Decrypt(ctx, in.OldPasswordEnc)
 	if err != nil {
 		return err
 	}
 	if err := u.hasher.Compare(user.Password, oldPlain); err != nil {
 		return errors.New("old password incorrect")
 	}
-	newPlain, err := u.cipher.Decrypt(in.NewPasswordEnc)
+	newPlain, err := u.cipher.Decrypt(ctx, in.NewPasswordEnc)
 	if err != nil {
 		return err
 	}
+	if err := u.policy.Validate(ctx, userservice.PasswordCandidate{
+		Plain:       newPlain,
+		UserID:      user.ID,
+		Email:       user.Email,
+		FullName:    user.FullName,
+		CurrentHash: user.Password,
+	}); err != nil {
+		return err
+	}
 	hash, err := u.hasher.Hash(newPlain)
 	if err != nil {
 		return err
 	}
+	if err := u.policy.Retire(ctx, in.UserID, user.Password); err != nil {
+		return err
+	}
 	update := map[string]interface{}{"password": hash}
 	return u.userRepo.Update(ctx, in.UserID, update)
 }
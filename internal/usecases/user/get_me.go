@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/pkg/constants"
+	"errors"
+)
+
+// Profile kinds a login account can be linked to
+const (
+	ProfileTeacher  = "TEACHER"
+	ProfileStudent  = "STUDENT"
+	ProfileGuardian = "GUARDIAN"
+)
+
+type GetMeInput struct {
+	UserID string
+}
+
+// LinkedProfile is the teacher, student or guardian record behind a login account
+type LinkedProfile struct {
+	Type     string
+	ID       string
+	Code     string
+	FullName string
+}
+
+type GetMeOutput struct {
+	User    *GetUserByIdOutput
+	Profile *LinkedProfile // nil when the account has no linked profile, e.g. admins
+}
+
+// GetMeUseCase returns the signed-in account together with its linked profile
+type GetMeUseCase interface {
+	Execute(ctx context.Context, in GetMeInput) (*GetMeOutput, error)
+}
+
+type getMeUseCase struct {
+	getUserByID  GetUserByIdUseCase
+	teacherRepo  repositoryinterface.TeacherRepository
+	studentRepo  repositoryinterface.StudentRepository
+	guardianRepo repositoryinterface.GuardianRepository
+}
+
+func NewGetMeUseCase(
+	getUserByID GetUserByIdUseCase,
+	teacherRepo repositoryinterface.TeacherRepository,
+	studentRepo repositoryinterface.StudentRepository,
+	guardianRepo repositoryinterface.GuardianRepository,
+) GetMeUseCase {
+	return &getMeUseCase{
+		getUserByID:  getUserByID,
+		teacherRepo:  teacherRepo,
+		studentRepo:  studentRepo,
+		guardianRepo: guardianRepo,
+	}
+}
+
+func (u *getMeUseCase) Execute(ctx context.Context, in GetMeInput) (*GetMeOutput, error) {
+	if in.UserID == "" {
+		return nil, errors.New("user not found")
+	}
+	user, err := u.getUserByID.Execute(ctx, GetUserByIdInput{ID: in.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := u.linkedProfile(ctx, user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMeOutput{User: user, Profile: profile}, nil
+}
+
+// linkedProfile looks the profile up by the role, the only kind of profile the account can act as
+func (u *getMeUseCase) linkedProfile(ctx context.Context, userID, role string) (*LinkedProfile, error) {
+	switch role {
+	case constants.RoleTeacher:
+		teacher, err := u.teacherRepo.GetByUserID(ctx, userID)
+		if err != nil || teacher == nil {
+			return nil, err
+		}
+		return &LinkedProfile{Type: ProfileTeacher, ID: teacher.ID, Code: teacher.Code, FullName: teacher.FullName}, nil
+	case constants.RoleStudent:
+		student, err := u.studentRepo.GetByUserID(ctx, userID)
+		if err != nil || student == nil {
+			return nil, err
+		}
+		return &LinkedProfile{Type: ProfileStudent, ID: student.ID, Code: student.Code, FullName: student.FullName}, nil
+	case constants.RoleGuardian:
+		guardian, err := u.guardianRepo.GetByUserID(ctx, userID)
+		if err != nil || guardian == nil {
+			return nil, err
+		}
+		return &LinkedProfile{Type: ProfileGuardian, ID: guardian.ID, FullName: guardian.FullName}, nil
+	}
+	return nil, nil
+}
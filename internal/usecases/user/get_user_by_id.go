@@ -3,12 +3,13 @@ package user
 import (
 	"context"
 	_interface "doan/internal/repositories/interface"
+	"errors"
 	"time"
 )
 
 // GetUserByIdInput DTO Input for GetUserById UseCase
 type GetUserByIdInput struct {
-	ID int `json:"id"`
+	ID string `json:"id"`
 }
 
 // GetUserByIdOutput DTO Output for GetUserById UseCase
@@ -45,6 +46,9 @@ func (u *getUserByIdUseCase) Execute(ctx context.Context, input GetUserByIdInput
 	if err != nil {
 		return nil, err
 	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
 
 	result := &GetUserByIdOutput{
 		ID:        user.ID,
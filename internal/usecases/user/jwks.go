@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+	"doan/internal/services/security"
+)
+
+type GetJWKSOutput struct {
+	Keys []security.PublicJWK
+}
+
+// GetJWKSUseCase returns the public keys access tokens are verified with, for services outside this API
+type GetJWKSUseCase interface {
+	Execute(ctx context.Context) (*GetJWKSOutput, error)
+}
+
+type getJWKSUseCase struct {
+	signer security.TokenSigner
+}
+
+// NewGetJWKSUseCase creates a new instance of GetJWKSUseCase
+func NewGetJWKSUseCase(signer security.TokenSigner) GetJWKSUseCase {
+	return &getJWKSUseCase{signer: signer}
+}
+
+func (u *getJWKSUseCase) Execute(ctx context.Context) (*GetJWKSOutput, error) {
+	return &GetJWKSOutput{Keys: u.signer.JWKS()}, nil
+}
@@ -2,14 +2,18 @@ package user
 
 import (
 	"context"
+	"doan/internal/services/security"
 	"doan/internal/services/user"
 	"doan/pkg/logger"
+	"fmt"
 )
 
 // LoginInput represents the input of the LoginUseCase
 type LoginInput struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username    string `json:"username"`
+	PasswordEnc string `json:"password_enc"`
+	UserAgent   string `json:"user_agent"`
+	IPAddress   string `json:"ip_address"`
 }
 
 type LoginUseCaseUserOutput struct {
@@ -21,11 +25,16 @@ type LoginUseCaseUserOutput struct {
 	IsActive bool   `json:"is_active"`
 }
 
-// LoginOutput represents the output of the LoginUseCase
+// LoginOutput represents the output of the LoginUseCase. When MFARequired is set there are no tokens
+// yet; the login continues with MFAToken and the second factor.
 type LoginOutput struct {
-	AccessToken  string                 `json:"access_token"`
-	RefreshToken string                 `json:"refresh_token"`
-	User         LoginUseCaseUserOutput `json:"user"`
+	AccessToken          string                 `json:"access_token"`
+	RefreshToken         string                 `json:"refresh_token"`
+	MFARequired          bool                   `json:"mfa_required"`
+	MFAEnrolmentRequired bool                   `json:"mfa_enrolment_required"`
+	MFAToken             string                 `json:"mfa_token"`
+	RecoveryCodes        []string               `json:"recovery_codes"`
+	User                 LoginUseCaseUserOutput `json:"user"`
 }
 
 // LoginUseCase is a use case for login
@@ -39,18 +48,27 @@ type LoginUseCase interface {
 // loginUseCase implements LoginUseCase
 type loginUseCase struct {
 	authService user.AuthService
+	cipher      security.PasswordCipher
 }
 
-func NewLoginUseCase(authService user.AuthService) LoginUseCase {
-	return &loginUseCase{authService: authService}
+// NewLoginUseCase creates a new instance of LoginUseCase
+func NewLoginUseCase(authService user.AuthService, cipher security.PasswordCipher) LoginUseCase {
+	return &loginUseCase{authService: authService, cipher: cipher}
 }
 
 func (u *loginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
+	password, err := u.cipher.Decrypt(ctx, input.PasswordEnc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid password payload: %w", err)
+	}
+
 	token, err := u.authService.CreateAuthToken(ctx, user.CreateAuthTokenInput{
-		Username: input.Username,
-		Password: input.Password,
+		Username:  input.Username,
+		Password:  password,
+		UserAgent: input.UserAgent,
+		IPAddress: input.IPAddress,
 	})
 
 	if err != nil {
@@ -58,18 +76,24 @@ func (u *loginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOut
 		return nil, err
 	}
 
-	loginUser := LoginUseCaseUserOutput{
-		ID:       token.User.ID,
-		Code:     token.User.Code,
-		FullName: token.User.FullName,
-		Email:    token.User.Email,
-		Role:     token.User.Role,
-		IsActive: token.User.IsActive,
-	}
+	return mapLoginOutput(token), nil
+}
 
+func mapLoginOutput(token *user.CreateAuthTokenOutput) *LoginOutput {
 	return &LoginOutput{
-		AccessToken:  token.AccessToken,
-		RefreshToken: token.RefreshToken,
-		User:         loginUser,
-	}, nil
+		AccessToken:          token.AccessToken,
+		RefreshToken:         token.RefreshToken,
+		MFARequired:          token.MFARequired,
+		MFAEnrolmentRequired: token.MFAEnrolmentRequired,
+		MFAToken:             token.MFAToken,
+		RecoveryCodes:        token.RecoveryCodes,
+		User: LoginUseCaseUserOutput{
+			ID:       token.User.ID,
+			Code:     token.User.Code,
+			FullName: token.User.FullName,
+			Email:    token.User.Email,
+			Role:     token.User.Role,
+			IsActive: token.User.IsActive,
+		},
+	}
 }
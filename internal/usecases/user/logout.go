@@ -36,18 +36,12 @@ func NewLogoutUseCase(authService user.AuthService) LogoutUseCase {
 func (u *logoutUseCase) Execute(ctx context.Context, input LogoutInput) (*LogoutOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
-	// Validate token to ensure it's valid
-	_, err := u.authService.ValidateToken(ctx, input.Token)
-	if err != nil {
-		ctxLogger.Errorf("Failed to validate token: %v", err)
+	// Revoke the session of the token and deny the token itself until it expires
+	if err := u.authService.Logout(ctx, input.Token); err != nil {
+		ctxLogger.Errorf("Failed to logout: %v", err)
 		return nil, err
 	}
 
-	// In a production system, you would:
-	// 1. Add the token to a blacklist/revocation list (Redis)
-	// 2. Or invalidate the session in your session store
-	// For now, we just return success
-
 	ctxLogger.Info("User logged out successfully")
 
 	return &LogoutOutput{
@@ -0,0 +1,321 @@
+package user
+
+import (
+	"context"
+	"doan/internal/entities"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/logger"
+	"errors"
+	"strings"
+)
+
+// Second step of a login
+
+type LoginMFAInput struct {
+	MFAToken     string
+	Code         string
+	RecoveryCode string
+	UserAgent    string
+	IPAddress    string
+}
+
+// LoginMFAUseCase completes a login that is waiting for the second factor
+type LoginMFAUseCase interface {
+	Execute(ctx context.Context, input LoginMFAInput) (*LoginOutput, error)
+}
+
+type loginMFAUseCase struct {
+	authService  user.AuthService
+	auditLogRepo repositoryinterface.AuditLogRepository
+}
+
+// NewLoginMFAUseCase creates a new instance of LoginMFAUseCase
+func NewLoginMFAUseCase(authService user.AuthService, auditLogRepo repositoryinterface.AuditLogRepository) LoginMFAUseCase {
+	return &loginMFAUseCase{authService: authService, auditLogRepo: auditLogRepo}
+}
+
+func (u *loginMFAUseCase) Execute(ctx context.Context, input LoginMFAInput) (*LoginOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	token, err := u.authService.CompleteMFALogin(ctx, user.CompleteMFALoginInput{
+		MFAToken:     input.MFAToken,
+		Code:         input.Code,
+		RecoveryCode: input.RecoveryCode,
+		UserAgent:    input.UserAgent,
+		IPAddress:    input.IPAddress,
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to complete two-factor login: %v", err)
+		return nil, err
+	}
+
+	// Recovery codes only come back when this login confirmed a mandatory enrolment
+	if len(token.RecoveryCodes) > 0 {
+		auditAccount(ctx, u.auditLogRepo, entities.AuditActionMFAEnable, token.User.ID, token.User.ID, token.User.Role)
+	}
+	return mapLoginOutput(token), nil
+}
+
+// Enrolment during a login, for roles that require 2FA
+
+type LoginMFASetupInput struct {
+	MFAToken string
+}
+
+type MFASetupOutput struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// LoginMFASetupUseCase starts enrolment for a login whose role requires 2FA the user does not have yet
+type LoginMFASetupUseCase interface {
+	Execute(ctx context.Context, input LoginMFASetupInput) (*MFASetupOutput, error)
+}
+
+type loginMFASetupUseCase struct {
+	authService user.AuthService
+}
+
+// NewLoginMFASetupUseCase creates a new instance of LoginMFASetupUseCase
+func NewLoginMFASetupUseCase(authService user.AuthService) LoginMFASetupUseCase {
+	return &loginMFASetupUseCase{authService: authService}
+}
+
+func (u *loginMFASetupUseCase) Execute(ctx context.Context, input LoginMFASetupInput) (*MFASetupOutput, error) {
+	enrolment, err := u.authService.BeginLoginMFAEnrolment(ctx, input.MFAToken)
+	if err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to start two-factor enrolment at login: %v", err)
+		return nil, err
+	}
+	return &MFASetupOutput{Secret: enrolment.Secret, ProvisioningURI: enrolment.ProvisioningURI}, nil
+}
+
+// Setup by a signed-in user
+
+type SetupMFAInput struct {
+	UserID string
+}
+
+// SetupMFAUseCase stores a pending authenticator for the signed-in user
+type SetupMFAUseCase interface {
+	Execute(ctx context.Context, input SetupMFAInput) (*MFASetupOutput, error)
+}
+
+type setupMFAUseCase struct {
+	userRepo   repositoryinterface.UserRepository
+	mfaService user.MFAService
+}
+
+// NewSetupMFAUseCase creates a new instance of SetupMFAUseCase
+func NewSetupMFAUseCase(userRepo repositoryinterface.UserRepository, mfaService user.MFAService) SetupMFAUseCase {
+	return &setupMFAUseCase{userRepo: userRepo, mfaService: mfaService}
+}
+
+func (u *setupMFAUseCase) Execute(ctx context.Context, input SetupMFAInput) (*MFASetupOutput, error) {
+	account, err := u.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, user.ErrUserNotFound
+	}
+	enrolment, err := u.mfaService.BeginEnrolment(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return &MFASetupOutput{Secret: enrolment.Secret, ProvisioningURI: enrolment.ProvisioningURI}, nil
+}
+
+// Confirmation, recovery codes and disabling
+
+type MFACodeInput struct {
+	UserID    string
+	UserRole  string
+	Code      string
+	IPAddress string
+}
+
+type RecoveryCodesOutput struct {
+	RecoveryCodes []string
+}
+
+// ConfirmMFAUseCase enables the pending authenticator with its first code and returns the recovery codes
+type ConfirmMFAUseCase interface {
+	Execute(ctx context.Context, input MFACodeInput) (*RecoveryCodesOutput, error)
+}
+
+type confirmMFAUseCase struct {
+	mfaService   user.MFAService
+	throttle     user.AttemptThrottle
+	auditLogRepo repositoryinterface.AuditLogRepository
+}
+
+// NewConfirmMFAUseCase creates a new instance of ConfirmMFAUseCase
+func NewConfirmMFAUseCase(
+	mfaService user.MFAService,
+	throttle user.AttemptThrottle,
+	auditLogRepo repositoryinterface.AuditLogRepository,
+) ConfirmMFAUseCase {
+	return &confirmMFAUseCase{mfaService: mfaService, throttle: throttle, auditLogRepo: auditLogRepo}
+}
+
+func (u *confirmMFAUseCase) Execute(ctx context.Context, input MFACodeInput) (*RecoveryCodesOutput, error) {
+	var codes []string
+	err := throttledMFACheck(ctx, u.throttle, input, func() (err error) {
+		codes, err = u.mfaService.ConfirmEnrolment(ctx, input.UserID, strings.TrimSpace(input.Code))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	auditAccount(ctx, u.auditLogRepo, entities.AuditActionMFAEnable, input.UserID, input.UserID, input.UserRole)
+	return &RecoveryCodesOutput{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodesUseCase replaces the recovery codes, invalidating the old ones
+type RegenerateRecoveryCodesUseCase interface {
+	Execute(ctx context.Context, input MFACodeInput) (*RecoveryCodesOutput, error)
+}
+
+type regenerateRecoveryCodesUseCase struct {
+	mfaService user.MFAService
+	throttle   user.AttemptThrottle
+}
+
+// NewRegenerateRecoveryCodesUseCase creates a new instance of RegenerateRecoveryCodesUseCase
+func NewRegenerateRecoveryCodesUseCase(mfaService user.MFAService, throttle user.AttemptThrottle) RegenerateRecoveryCodesUseCase {
+	return &regenerateRecoveryCodesUseCase{mfaService: mfaService, throttle: throttle}
+}
+
+func (u *regenerateRecoveryCodesUseCase) Execute(ctx context.Context, input MFACodeInput) (*RecoveryCodesOutput, error) {
+	var codes []string
+	err := throttledMFACheck(ctx, u.throttle, input, func() (err error) {
+		codes, err = u.mfaService.RegenerateRecoveryCodes(ctx, input.UserID, strings.TrimSpace(input.Code))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RecoveryCodesOutput{RecoveryCodes: codes}, nil
+}
+
+// DisableMFAUseCase removes the authenticator of the signed-in user, unless their role requires one
+type DisableMFAUseCase interface {
+	Execute(ctx context.Context, input MFACodeInput) error
+}
+
+type disableMFAUseCase struct {
+	userRepo     repositoryinterface.UserRepository
+	mfaService   user.MFAService
+	throttle     user.AttemptThrottle
+	auditLogRepo repositoryinterface.AuditLogRepository
+}
+
+// NewDisableMFAUseCase creates a new instance of DisableMFAUseCase
+func NewDisableMFAUseCase(
+	userRepo repositoryinterface.UserRepository,
+	mfaService user.MFAService,
+	throttle user.AttemptThrottle,
+	auditLogRepo repositoryinterface.AuditLogRepository,
+) DisableMFAUseCase {
+	return &disableMFAUseCase{userRepo: userRepo, mfaService: mfaService, throttle: throttle, auditLogRepo: auditLogRepo}
+}
+
+func (u *disableMFAUseCase) Execute(ctx context.Context, input MFACodeInput) error {
+	account, err := u.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return user.ErrUserNotFound
+	}
+	err = throttledMFACheck(ctx, u.throttle, input, func() error {
+		return u.mfaService.Disable(ctx, account, strings.TrimSpace(input.Code))
+	})
+	if err != nil {
+		return err
+	}
+	auditAccount(ctx, u.auditLogRepo, entities.AuditActionMFADisable, input.UserID, input.UserID, input.UserRole)
+	return nil
+}
+
+// Reset by an admin
+
+type ResetMFAInput struct {
+	UserID    string
+	ActorID   string
+	ActorRole string
+}
+
+// ResetMFAUseCase lets an admin remove the authenticator of a user who lost it and their recovery codes.
+// A user whose role requires 2FA enrols again at the next login.
+type ResetMFAUseCase interface {
+	Execute(ctx context.Context, input ResetMFAInput) error
+}
+
+type resetMFAUseCase struct {
+	userRepo     repositoryinterface.UserRepository
+	mfaService   user.MFAService
+	auditLogRepo repositoryinterface.AuditLogRepository
+}
+
+// NewResetMFAUseCase creates a new instance of ResetMFAUseCase
+func NewResetMFAUseCase(
+	userRepo repositoryinterface.UserRepository,
+	mfaService user.MFAService,
+	auditLogRepo repositoryinterface.AuditLogRepository,
+) ResetMFAUseCase {
+	return &resetMFAUseCase{userRepo: userRepo, mfaService: mfaService, auditLogRepo: auditLogRepo}
+}
+
+func (u *resetMFAUseCase) Execute(ctx context.Context, input ResetMFAInput) error {
+	account, err := u.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return user.ErrUserNotFound
+	}
+	if err := u.mfaService.Reset(ctx, account.ID); err != nil {
+		return err
+	}
+	auditAccount(ctx, u.auditLogRepo, entities.AuditActionMFAReset, account.ID, input.ActorID, input.ActorRole)
+	logger.NewLogger(ctx).Infof("Two-factor authentication of %s reset by %s", account.ID, input.ActorID)
+	return nil
+}
+
+// throttledMFACheck runs check under the same failed-attempt throttle as the second step of a login, so a
+// stolen access token cannot be used to guess codes
+func throttledMFACheck(ctx context.Context, throttle user.AttemptThrottle, input MFACodeInput, check func() error) error {
+	attempt := user.Attempt{Scope: user.ScopeVerifyMFA, Account: input.UserID, IP: input.IPAddress}
+	if err := throttle.Check(ctx, attempt); err != nil {
+		return err
+	}
+	if err := check(); err != nil {
+		if errors.Is(err, user.ErrInvalidMFACode) {
+			throttle.Fail(ctx, attempt)
+		}
+		return err
+	}
+	throttle.Succeed(ctx, attempt)
+	return nil
+}
+
+// auditAccount records a change to the account of a user; the change already happened, so a failed
+// audit write is only logged
+func auditAccount(ctx context.Context, auditLogRepo repositoryinterface.AuditLogRepository, action, userID, actorID, actorRole string) {
+	var actor *string
+	if actorID != "" {
+		actor = &actorID
+	}
+	if _, err := auditLogRepo.Create(ctx, &entities.AuditLog{
+		ActorID:    actor,
+		ActorRole:  actorRole,
+		Action:     action,
+		EntityType: entities.AuditEntityUser,
+		EntityID:   userID,
+	}); err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to audit %s of account %s: %v", action, userID, err)
+	}
+}
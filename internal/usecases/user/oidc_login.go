@@ -0,0 +1,226 @@
+package user
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/oidc"
+	"doan/internal/services/user"
+	"doan/pkg/logger"
+	"time"
+)
+
+// Providers
+
+// ListOIDCProvidersUseCase lists the providers users can sign in with, for the login page
+type ListOIDCProvidersUseCase interface {
+	Execute(ctx context.Context) []string
+}
+
+type listOIDCProvidersUseCase struct {
+	authenticator oidc.Authenticator
+}
+
+// NewListOIDCProvidersUseCase creates a new instance of ListOIDCProvidersUseCase
+func NewListOIDCProvidersUseCase(authenticator oidc.Authenticator) ListOIDCProvidersUseCase {
+	return &listOIDCProvidersUseCase{authenticator: authenticator}
+}
+
+func (u *listOIDCProvidersUseCase) Execute(ctx context.Context) []string {
+	return u.authenticator.Providers()
+}
+
+// Start of a sign-in at a provider
+
+type BeginOIDCLoginInput struct {
+	Provider string
+}
+
+type BeginOIDCLoginOutput struct {
+	AuthorizationURL string
+	State            string
+}
+
+// BeginOIDCLoginUseCase returns the provider URL the browser signs in at
+type BeginOIDCLoginUseCase interface {
+	Execute(ctx context.Context, input BeginOIDCLoginInput) (*BeginOIDCLoginOutput, error)
+}
+
+type beginOIDCLoginUseCase struct {
+	authenticator oidc.Authenticator
+}
+
+// NewBeginOIDCLoginUseCase creates a new instance of BeginOIDCLoginUseCase
+func NewBeginOIDCLoginUseCase(authenticator oidc.Authenticator) BeginOIDCLoginUseCase {
+	return &beginOIDCLoginUseCase{authenticator: authenticator}
+}
+
+func (u *beginOIDCLoginUseCase) Execute(ctx context.Context, input BeginOIDCLoginInput) (*BeginOIDCLoginOutput, error) {
+	authorization, err := u.authenticator.Begin(ctx, input.Provider)
+	if err != nil {
+		logger.NewLogger(ctx).Errorf("Failed to start %s sign-in: %v", input.Provider, err)
+		return nil, err
+	}
+	return &BeginOIDCLoginOutput{AuthorizationURL: authorization.URL, State: authorization.State}, nil
+}
+
+// Return from the provider
+
+type OIDCLoginInput struct {
+	Provider  string
+	Code      string
+	State     string
+	UserAgent string
+	IPAddress string
+}
+
+// OIDCLoginUseCase finishes a sign-in at a provider and logs the user in like a password login, second
+// factor included. The account is found by the linked identity, else an account with the verified email is
+// linked, else one is provisioned with the provider's default role.
+type OIDCLoginUseCase interface {
+	Execute(ctx context.Context, input OIDCLoginInput) (*LoginOutput, error)
+}
+
+type oidcLoginUseCase struct {
+	authenticator oidc.Authenticator
+	authService   user.AuthService
+	userRepo      repositoryinterface.UserRepository
+	identityRepo  repositoryinterface.UserIdentityRepository
+	auditLogRepo  repositoryinterface.AuditLogRepository
+	uow           repositories.UnitOfWork
+	log           logger.Logger
+}
+
+// NewOIDCLoginUseCase creates a new instance of OIDCLoginUseCase
+func NewOIDCLoginUseCase(
+	authenticator oidc.Authenticator,
+	authService user.AuthService,
+	userRepo repositoryinterface.UserRepository,
+	identityRepo repositoryinterface.UserIdentityRepository,
+	auditLogRepo repositoryinterface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) OIDCLoginUseCase {
+	return &oidcLoginUseCase{
+		authenticator: authenticator,
+		authService:   authService,
+		userRepo:      userRepo,
+		identityRepo:  identityRepo,
+		auditLogRepo:  auditLogRepo,
+		uow:           uow,
+		log:           log,
+	}
+}
+
+func (u *oidcLoginUseCase) Execute(ctx context.Context, input OIDCLoginInput) (*LoginOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	identity, err := u.authenticator.Finish(ctx, input.Provider, input.State, input.Code)
+	if err != nil {
+		ctxLogger.Errorf("Failed to finish %s sign-in: %v", input.Provider, err)
+		return nil, err
+	}
+
+	account, err := u.resolveAccount(ctx, identity)
+	if err != nil {
+		ctxLogger.Errorf("Failed to resolve the account of %s identity %s: %v", identity.Provider, identity.Subject, err)
+		return nil, err
+	}
+
+	token, err := u.authService.CreateAuthTokenForUser(ctx, account, input.UserAgent, input.IPAddress)
+	if err != nil {
+		ctxLogger.Errorf("Failed to create auth token: %v", err)
+		return nil, err
+	}
+	return mapLoginOutput(token), nil
+}
+
+// resolveAccount returns the user of the identity, linking or provisioning one on the first sign-in
+func (u *oidcLoginUseCase) resolveAccount(ctx context.Context, identity *oidc.Identity) (*entities.User, error) {
+	now := time.Now()
+
+	linked, err := u.identityRepo.GetByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil {
+		account, err := u.userRepo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, user.ErrUserNotFound
+		}
+		if err := u.identityRepo.Update(ctx, linked.ID, map[string]interface{}{
+			"email":         identity.Email,
+			"last_login_at": now,
+		}); err != nil {
+			logger.NewLogger(ctx).Warnf("Failed to record sign-in of identity %s: %v", linked.ID, err)
+		}
+		return account, nil
+	}
+
+	// Only an address the provider verified proves the caller owns the matching account
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, oidc.ErrEmailNotVerified
+	}
+
+	condition := repositories.NewCommonCondition()
+	condition.AddCondition("email", identity.Email, repositories.Equal)
+	existing, err := u.userRepo.GetByCondition(ctx, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil && len(existing.Data) > 0 {
+		account := existing.Data[0]
+		// An invited account is activated through its invitation, which sets the password
+		if !account.IsActive {
+			return nil, user.ErrUserInactive
+		}
+		if _, err := u.identityRepo.Create(ctx, newIdentity(account.ID, identity, now)); err != nil {
+			return nil, err
+		}
+		auditAccount(ctx, u.auditLogRepo, entities.AuditActionIdentityLink, account.ID, account.ID, account.Role)
+		logger.NewLogger(ctx).Infof("Linked %s identity to account %s", identity.Provider, account.ID)
+		return account, nil
+	}
+
+	if !identity.Provision {
+		return nil, oidc.ErrProvisionDisabled
+	}
+	result, err := repositories.ExecuteInTransaction(ctx, u.uow, u.log, func(txCtx context.Context) (interface{}, error) {
+		// No password: the account signs in through the provider until its owner sets one with forgot-password
+		account, err := u.userRepo.Create(txCtx, &entities.User{
+			Email:    identity.Email,
+			FullName: identity.Name,
+			Role:     identity.DefaultRole,
+			IsActive: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := u.identityRepo.Create(txCtx, newIdentity(account.ID, identity, now)); err != nil {
+			return nil, err
+		}
+		return account, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	account := result.(*entities.User)
+	auditAccount(ctx, u.auditLogRepo, entities.AuditActionUserProvision, account.ID, account.ID, account.Role)
+	logger.NewLogger(ctx).Infof("Provisioned account %s from %s identity with role %s", account.ID, identity.Provider, account.Role)
+	return account, nil
+}
+
+func newIdentity(userID string, identity *oidc.Identity, now time.Time) *entities.UserIdentity {
+	return &entities.UserIdentity{
+		UserID:      userID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	}
+}
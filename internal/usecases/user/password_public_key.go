@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+	"doan/internal/services/security"
+)
+
+type GetPasswordPublicKeyOutput struct {
+	Key         security.PasswordPublicKey
+	AcceptPlain bool // plaintext passwords are still accepted, so encrypting is optional
+}
+
+// GetPasswordPublicKeyUseCase returns the key the frontend encrypts password fields with
+type GetPasswordPublicKeyUseCase interface {
+	Execute(ctx context.Context) (*GetPasswordPublicKeyOutput, error)
+}
+
+type getPasswordPublicKeyUseCase struct {
+	cipher security.PasswordCipher
+}
+
+// NewGetPasswordPublicKeyUseCase creates a new instance of GetPasswordPublicKeyUseCase
+func NewGetPasswordPublicKeyUseCase(cipher security.PasswordCipher) GetPasswordPublicKeyUseCase {
+	return &getPasswordPublicKeyUseCase{cipher: cipher}
+}
+
+func (u *getPasswordPublicKeyUseCase) Execute(ctx context.Context) (*GetPasswordPublicKeyOutput, error) {
+	return &GetPasswordPublicKeyOutput{
+		Key:         u.cipher.PublicKey(),
+		AcceptPlain: u.cipher.AcceptsPlain(),
+	}, nil
+}
@@ -8,10 +8,14 @@ import (
 
 type RefreshTokenInput struct {
 	RefreshToken string `json:"refresh_token"`
+	UserAgent    string `json:"user_agent"`
+	IPAddress    string `json:"ip_address"`
 }
 
+// RefreshTokenOutput carries the new access token and the rotated refresh token; the old one is spent
 type RefreshTokenOutput struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type RefreshTokenUseCase interface {
@@ -29,8 +33,12 @@ func NewRefreshTokenUseCase(authService user.AuthService) RefreshTokenUseCase {
 func (u *refreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenInput) (*RefreshTokenOutput, error) {
 	ctxLogger := logger.NewLogger(ctx)
 
-	// Refresh access token
-	accessToken, err := u.authService.RefreshAccessToken(ctx, input.RefreshToken)
+	// Rotate the refresh token and issue a new access token
+	tokens, err := u.authService.RefreshAccessToken(ctx, user.RefreshAccessTokenInput{
+		RefreshToken: input.RefreshToken,
+		UserAgent:    input.UserAgent,
+		IPAddress:    input.IPAddress,
+	})
 	if err != nil {
 		ctxLogger.Errorf("Failed to refresh token: %v", err)
 		return nil, err
@@ -39,6 +47,7 @@ func (u *refreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenInp
 	ctxLogger.Info("Token refreshed successfully")
 
 	return &RefreshTokenOutput{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
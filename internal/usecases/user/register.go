@@ -6,8 +6,10 @@ import (
 	repositoryinterface "doan/internal/repositories/interface"
 	"doan/internal/services/mailer"
 	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
 	"doan/pkg/random"
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
 	"time"
 )
@@ -32,6 +34,7 @@ type registerUseCase struct {
 	userRepo repositoryinterface.UserRepository
 	cipher   security.PasswordCipher
 	hasher   security.PasswordHasher
+	policy   userservice.PasswordPolicy
 	mailer   mailer.Mailer
 	db       *gorm.DB
 }
@@ -40,6 +43,7 @@ func NewRegisterUseCase(
 	repo repositoryinterface.UserRepository,
 	cipher security.PasswordCipher,
 	hasher security.PasswordHasher,
+	policy userservice.PasswordPolicy,
 	mailer mailer.Mailer,
 	db *gorm.DB,
 ) RegisterUseCase {
@@ -47,6 +51,7 @@ func NewRegisterUseCase(
 		userRepo: repo,
 		cipher:   cipher,
 		hasher:   hasher,
+		policy:   policy,
 		mailer:   mailer,
 		db:       db,
 	}
@@ -63,9 +68,17 @@ func (u *registerUseCase) Execute(ctx context.Context, in RegisterInput) (*Regis
 	}
 
 	// 2. decrypt password từ FE
-	passwordPlain, err := u.cipher.Decrypt(in.PasswordEnc)
+	passwordPlain, err := u.cipher.Decrypt(ctx, in.PasswordEnc)
 	if err != nil {
-		return nil, errors.New("invalid password payload")
+		return nil, fmt.Errorf("invalid password payload: %w", err)
+	}
+
+	if err := u.policy.Validate(ctx, userservice.PasswordCandidate{
+		Plain:    passwordPlain,
+		Email:    in.Email,
+		FullName: in.FullName,
+	}); err != nil {
+		return nil, err
 	}
 
 	// 3. hash password
@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"doan/internal/services/user"
+	"doan/pkg/logger"
+	"time"
+)
+
+type ListSessionsInput struct {
+	UserID           string
+	CurrentSessionID string
+}
+
+// SessionOutput is one signed-in device of the user
+type SessionOutput struct {
+	ID         string
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	Current    bool // the session of the calling access token
+}
+
+type ListSessionsOutput struct {
+	Sessions []SessionOutput
+}
+
+// ListSessionsUseCase lists the active login sessions of the signed-in user
+type ListSessionsUseCase interface {
+	Execute(ctx context.Context, input ListSessionsInput) (*ListSessionsOutput, error)
+}
+
+type listSessionsUseCase struct {
+	authService user.AuthService
+}
+
+func NewListSessionsUseCase(authService user.AuthService) ListSessionsUseCase {
+	return &listSessionsUseCase{authService: authService}
+}
+
+func (u *listSessionsUseCase) Execute(ctx context.Context, input ListSessionsInput) (*ListSessionsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	sessions, err := u.authService.ListSessions(ctx, input.UserID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list sessions: %v", err)
+		return nil, err
+	}
+
+	output := &ListSessionsOutput{Sessions: make([]SessionOutput, 0, len(sessions))}
+	for _, s := range sessions {
+		output.Sessions = append(output.Sessions, SessionOutput{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == input.CurrentSessionID,
+		})
+	}
+	return output, nil
+}
+
+// RevokeSessionsInput names one session to revoke; without SessionID every session except
+// CurrentSessionID is revoked
+type RevokeSessionsInput struct {
+	UserID           string
+	SessionID        string
+	CurrentSessionID string
+}
+
+type RevokeSessionsOutput struct {
+	Revoked int
+}
+
+// RevokeSessionsUseCase signs the user out of one or all other devices
+type RevokeSessionsUseCase interface {
+	Execute(ctx context.Context, input RevokeSessionsInput) (*RevokeSessionsOutput, error)
+}
+
+type revokeSessionsUseCase struct {
+	authService user.AuthService
+}
+
+func NewRevokeSessionsUseCase(authService user.AuthService) RevokeSessionsUseCase {
+	return &revokeSessionsUseCase{authService: authService}
+}
+
+func (u *revokeSessionsUseCase) Execute(ctx context.Context, input RevokeSessionsInput) (*RevokeSessionsOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.SessionID != "" {
+		if err := u.authService.RevokeSession(ctx, input.UserID, input.SessionID); err != nil {
+			ctxLogger.Errorf("Failed to revoke session %s: %v", input.SessionID, err)
+			return nil, err
+		}
+		return &RevokeSessionsOutput{Revoked: 1}, nil
+	}
+
+	revoked, err := u.authService.RevokeOtherSessions(ctx, input.UserID, input.CurrentSessionID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to revoke sessions: %v", err)
+		return nil, err
+	}
+	return &RevokeSessionsOutput{Revoked: revoked}, nil
+}
@@ -0,0 +1,62 @@
+package user
+
+import (
+	"context"
+	"doan/internal/entities"
+	repositoryinterface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/logger"
+)
+
+type UnlockAccountInput struct {
+	UserID    string
+	ActorID   string
+	ActorRole string
+}
+
+// UnlockAccountUseCase lets an admin lift the backoff and lockout left by failed logins or OTP checks
+type UnlockAccountUseCase interface {
+	Execute(ctx context.Context, input UnlockAccountInput) error
+}
+
+type unlockAccountUseCase struct {
+	authService  user.AuthService
+	auditLogRepo repositoryinterface.AuditLogRepository
+}
+
+func NewUnlockAccountUseCase(
+	authService user.AuthService,
+	auditLogRepo repositoryinterface.AuditLogRepository,
+) UnlockAccountUseCase {
+	return &unlockAccountUseCase{
+		authService:  authService,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+func (u *unlockAccountUseCase) Execute(ctx context.Context, input UnlockAccountInput) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if err := u.authService.UnlockAccount(ctx, input.UserID); err != nil {
+		ctxLogger.Errorf("Failed to unlock account %s: %v", input.UserID, err)
+		return err
+	}
+
+	var actor *string
+	if input.ActorID != "" {
+		actor = &input.ActorID
+	}
+	if _, err := u.auditLogRepo.Create(ctx, &entities.AuditLog{
+		ActorID:    actor,
+		ActorRole:  input.ActorRole,
+		Action:     entities.AuditActionAccountUnlock,
+		EntityType: entities.AuditEntityUser,
+		EntityID:   input.UserID,
+	}); err != nil {
+		// The account is already unlocked; a missing audit row must not report the unlock as failed
+		ctxLogger.Errorf("Failed to audit unlock of account %s: %v", input.UserID, err)
+	}
+
+	ctxLogger.Infof("Account %s unlocked by %s", input.UserID, input.ActorID)
+	return nil
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	repositoryinterface "doan/internal/repositories/interface"
 	"doan/internal/services/security"
+	userservice "doan/internal/services/user"
 	"errors"
 	"gorm.io/gorm"
 	"time"
 )
 
+// ErrInvalidOTP is returned when the code does not match; each one counts towards the throttle
+var ErrInvalidOTP = errors.New("invalid otp")
+
 type VerifyOTPInput struct {
-	UserID string
-	OTP    string
+	UserID    string
+	OTP       string
+	RequestIP string
 }
 
 type VerifyOTPUseCase interface {
@@ -22,17 +27,20 @@ type verifyOTPUseCase struct {
 	db       *gorm.DB
 	userRepo repositoryinterface.UserRepository
 	hasher   security.PasswordHasher
+	throttle userservice.AttemptThrottle
 }
 
 func NewVerifyOTPUseCase(
 	db *gorm.DB,
 	userRepo repositoryinterface.UserRepository,
 	hasher security.PasswordHasher,
+	throttle userservice.AttemptThrottle,
 ) VerifyOTPUseCase {
 	return &verifyOTPUseCase{
 		db:       db,
 		userRepo: userRepo,
 		hasher:   hasher,
+		throttle: throttle,
 	}
 }
 
@@ -41,7 +49,13 @@ func (u *verifyOTPUseCase) Execute(ctx context.Context, in VerifyOTPInput) error
 		return errors.New("otp is required")
 	}
 
-	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	// A six-digit code falls to brute force unless failures are limited per account and per IP
+	attempt := userservice.Attempt{Scope: userservice.ScopeVerifyOTP, Account: in.UserID, IP: in.RequestIP}
+	if err := u.throttle.Check(ctx, attempt); err != nil {
+		return err
+	}
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		otpEntity, err := u.userRepo.GetActiveOTPByUserIDTx(ctx, tx, in.UserID)
 		if err != nil {
 			return errors.New("otp not found or expired")
@@ -54,7 +68,7 @@ func (u *verifyOTPUseCase) Execute(ctx context.Context, in VerifyOTPInput) error
 
 		// compare otp
 		if hashErr := u.hasher.Compare(otpEntity.OTPHash, in.OTP); hashErr != nil {
-			return errors.New("invalid otp")
+			return ErrInvalidOTP
 		}
 
 		now := time.Now()
@@ -71,4 +85,11 @@ func (u *verifyOTPUseCase) Execute(ctx context.Context, in VerifyOTPInput) error
 
 		return nil
 	})
+	switch {
+	case errors.Is(err, ErrInvalidOTP):
+		u.throttle.Fail(ctx, attempt)
+	case err == nil:
+		u.throttle.Succeed(ctx, attempt)
+	}
+	return err
 }
@@ -0,0 +1,95 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// CreateUserInput represents the account to create
+type CreateUserInput struct {
+	Email     string
+	FullName  string
+	Role      string
+	Requester authz.Principal
+}
+
+// CreateUserOutput represents the created, not yet activated account
+type CreateUserOutput struct {
+	User *entities.User
+}
+
+// CreateUserUseCase creates an account with any role the requester could hold and emails its owner an
+// activation link to set the password; the account stays inactive until then
+type CreateUserUseCase interface {
+	Execute(ctx context.Context, input CreateUserInput) (*CreateUserOutput, error)
+}
+
+type createUserUseCase struct {
+	inviter      account.Inviter
+	authService  user.AuthService
+	roleRepo     repointerface.RoleRepository
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewCreateUserUseCase creates a new instance of CreateUserUseCase
+func NewCreateUserUseCase(
+	inviter account.Inviter,
+	authService user.AuthService,
+	roleRepo repointerface.RoleRepository,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) CreateUserUseCase {
+	return &createUserUseCase{
+		inviter:      inviter,
+		authService:  authService,
+		roleRepo:     roleRepo,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *createUserUseCase) Execute(ctx context.Context, input CreateUserInput) (*CreateUserOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	role := strings.ToUpper(strings.TrimSpace(input.Role))
+	if err := checkRole(ctx, uc.authService, uc.roleRepo, input.Requester, role); err != nil {
+		return nil, err
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		invitation, err := uc.inviter.Invite(txCtx, account.InviteInput{
+			Email:       input.Email,
+			FullName:    input.FullName,
+			Role:        role,
+			InvitedByID: input.Requester.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.Requester, entities.AuditActionUserCreate, invitation.User.ID,
+			entities.JSONMap{"email": invitation.User.Email, "role": role}); err != nil {
+			return nil, err
+		}
+		return invitation, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to create user %s: %v", input.Email, err)
+		return nil, err
+	}
+
+	invitation := result.(*account.Invitation)
+	uc.inviter.Send(ctx, invitation)
+	ctxLogger.Infof("User %s created with role %s by %s", invitation.User.ID, role, input.Requester.UserID)
+	return &CreateUserOutput{User: invitation.User}, nil
+}
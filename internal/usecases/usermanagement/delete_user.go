@@ -0,0 +1,90 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+	"time"
+)
+
+// DeleteUserInput represents the account to delete
+type DeleteUserInput struct {
+	ID        string
+	Requester authz.Principal
+}
+
+// DeleteUserUseCase soft-deletes an account and signs it out everywhere. The row is kept, email
+// included, so RestoreUserUseCase can bring it back.
+type DeleteUserUseCase interface {
+	Execute(ctx context.Context, input DeleteUserInput) error
+}
+
+type deleteUserUseCase struct {
+	userRepo       repointerface.UserRepository
+	invitationRepo repointerface.AccountInvitationRepository
+	authService    user.AuthService
+	auditLogRepo   repointerface.AuditLogRepository
+	uow            repositories.UnitOfWork
+	log            logger.Logger
+}
+
+// NewDeleteUserUseCase creates a new instance of DeleteUserUseCase
+func NewDeleteUserUseCase(
+	userRepo repointerface.UserRepository,
+	invitationRepo repointerface.AccountInvitationRepository,
+	authService user.AuthService,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) DeleteUserUseCase {
+	return &deleteUserUseCase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		authService:    authService,
+		auditLogRepo:   auditLogRepo,
+		uow:            uow,
+		log:            log,
+	}
+}
+
+func (uc *deleteUserUseCase) Execute(ctx context.Context, input DeleteUserInput) error {
+	ctxLogger := logger.NewLogger(ctx)
+
+	_, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		account, err := uc.userRepo.GetByID(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrUserNotFound
+		}
+		if err := checkTarget(txCtx, uc.authService, input.Requester, account); err != nil {
+			return nil, err
+		}
+
+		if err := uc.userRepo.SoftDelete(txCtx, account.ID); err != nil {
+			return nil, err
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.Requester, entities.AuditActionUserDelete, account.ID,
+			entities.JSONMap{"email": account.Email, "role": account.Role}); err != nil {
+			return nil, err
+		}
+		if err := uc.invitationRepo.RevokePending(txCtx, account.ID, time.Now()); err != nil {
+			return nil, err
+		}
+		// Last, so the sessions are only denied once everything else went through
+		_, err = uc.authService.RevokeUserSessions(txCtx, account.ID)
+		return nil, err
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to delete user %s: %v", input.ID, err)
+		return err
+	}
+
+	ctxLogger.Infof("User %s deleted by %s", input.ID, input.Requester.UserID)
+	return nil
+}
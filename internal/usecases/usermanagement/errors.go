@@ -0,0 +1,12 @@
+package usermanagement
+
+import "errors"
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrUnknownRole         = errors.New("unknown role")
+	ErrOwnAccount          = errors.New("you cannot do this to your own account")
+	ErrPrivilegedUser      = errors.New("the role grants permissions you do not hold")
+	ErrUserNotDeleted      = errors.New("user is not deleted")
+	ErrImpersonationNested = errors.New("an impersonation token cannot start another impersonation")
+)
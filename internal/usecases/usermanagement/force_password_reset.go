@@ -0,0 +1,124 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/account"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+)
+
+// ForcePasswordResetInput represents the account whose password must change
+type ForcePasswordResetInput struct {
+	ID        string
+	Requester authz.Principal
+}
+
+// ForcePasswordResetOutput reports how many sessions the reset ended
+type ForcePasswordResetOutput struct {
+	RevokedSessions int
+}
+
+// ForcePasswordResetUseCase voids the password of an active account, signs it out everywhere and emails
+// its owner a single-use link to set a new one. The account is inactive until the link is used.
+type ForcePasswordResetUseCase interface {
+	Execute(ctx context.Context, input ForcePasswordResetInput) (*ForcePasswordResetOutput, error)
+}
+
+type forcePasswordResetUseCase struct {
+	userRepo     repointerface.UserRepository
+	inviter      account.Inviter
+	authService  user.AuthService
+	policy       user.PasswordPolicy
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewForcePasswordResetUseCase creates a new instance of ForcePasswordResetUseCase
+func NewForcePasswordResetUseCase(
+	userRepo repointerface.UserRepository,
+	inviter account.Inviter,
+	authService user.AuthService,
+	policy user.PasswordPolicy,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) ForcePasswordResetUseCase {
+	return &forcePasswordResetUseCase{
+		userRepo:     userRepo,
+		inviter:      inviter,
+		authService:  authService,
+		policy:       policy,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *forcePasswordResetUseCase) Execute(ctx context.Context, input ForcePasswordResetInput) (*ForcePasswordResetOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	type reset struct {
+		invitation *account.Invitation
+		revoked    int
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		target, err := uc.userRepo.GetByID(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			return nil, ErrUserNotFound
+		}
+		if err := checkTarget(txCtx, uc.authService, input.Requester, target); err != nil {
+			return nil, err
+		}
+		// A deactivated account would otherwise be reactivated by whoever opens the link
+		if !target.IsActive {
+			return nil, user.ErrUserInactive
+		}
+
+		// The activation flow sets the new password and reactivates the account; the voided one stays
+		// in the history so it cannot simply be set again
+		if err := uc.policy.Retire(txCtx, target.ID, target.Password); err != nil {
+			return nil, err
+		}
+		if err := uc.userRepo.Update(txCtx, target.ID, map[string]interface{}{
+			"password":  "",
+			"is_active": false,
+		}); err != nil {
+			return nil, err
+		}
+		invitation, err := uc.inviter.Invite(txCtx, account.InviteInput{
+			UserID:        &target.ID,
+			InvitedByID:   input.Requester.UserID,
+			PasswordReset: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.Requester, entities.AuditActionPasswordReset, target.ID, nil); err != nil {
+			return nil, err
+		}
+		// Last, so the sessions are only denied once everything else went through
+		revoked, err := uc.authService.RevokeUserSessions(txCtx, target.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &reset{invitation: invitation, revoked: revoked}, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to force a password reset of user %s: %v", input.ID, err)
+		return nil, err
+	}
+
+	done := result.(*reset)
+	uc.inviter.Send(ctx, done.invitation)
+	ctxLogger.Infof("Password reset of user %s forced by %s", input.ID, input.Requester.UserID)
+	return &ForcePasswordResetOutput{RevokedSessions: done.revoked}, nil
+}
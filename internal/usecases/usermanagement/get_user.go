@@ -0,0 +1,59 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/logger"
+)
+
+// GetUserInput represents the account to show
+type GetUserInput struct {
+	ID string
+}
+
+// GetUserOutput represents an account, deleted or not, with its number of active sessions
+type GetUserOutput struct {
+	User           *entities.User
+	ActiveSessions int
+}
+
+// GetUserUseCase shows one user account, including a soft-deleted one so it can be restored
+type GetUserUseCase interface {
+	Execute(ctx context.Context, input GetUserInput) (*GetUserOutput, error)
+}
+
+type getUserUseCase struct {
+	userRepo    repointerface.UserRepository
+	authService user.AuthService
+}
+
+// NewGetUserUseCase creates a new instance of GetUserUseCase
+func NewGetUserUseCase(userRepo repointerface.UserRepository, authService user.AuthService) GetUserUseCase {
+	return &getUserUseCase{
+		userRepo:    userRepo,
+		authService: authService,
+	}
+}
+
+func (uc *getUserUseCase) Execute(ctx context.Context, input GetUserInput) (*GetUserOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	account, err := uc.userRepo.GetByIDWithDeleted(ctx, input.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to get user %s: %v", input.ID, err)
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrUserNotFound
+	}
+
+	sessions, err := uc.authService.ListSessions(ctx, account.ID)
+	if err != nil {
+		ctxLogger.Errorf("Failed to list sessions of user %s: %v", account.ID, err)
+		return nil, err
+	}
+
+	return &GetUserOutput{User: account, ActiveSessions: len(sessions)}, nil
+}
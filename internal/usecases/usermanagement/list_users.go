@@ -0,0 +1,121 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// User statuses the list can be filtered by
+const (
+	StatusActive   = "active"
+	StatusInactive = "inactive" // deactivated, invited or waiting for a forced password reset
+	StatusDeleted  = "deleted"
+)
+
+// sortableFields are the columns users can be sorted by
+var sortableFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"email":      true,
+	"full_name":  true,
+	"role":       true,
+}
+
+// ListUsersInput represents the filters of the user list; Status "deleted" lists soft-deleted users only
+type ListUsersInput struct {
+	Search    string
+	Role      string
+	Status    string
+	Page      int
+	Limit     int
+	SortBy    string
+	SortOrder string
+}
+
+// ListUsersOutput represents one page of users
+type ListUsersOutput struct {
+	Users      []*entities.User
+	Pagination *repositories.Meta
+}
+
+// ListUsersUseCase lists and searches user accounts
+type ListUsersUseCase interface {
+	Execute(ctx context.Context, input ListUsersInput) (*ListUsersOutput, error)
+}
+
+type listUsersUseCase struct {
+	userRepo repointerface.UserRepository
+}
+
+// NewListUsersUseCase creates a new instance of ListUsersUseCase
+func NewListUsersUseCase(userRepo repointerface.UserRepository) ListUsersUseCase {
+	return &listUsersUseCase{userRepo: userRepo}
+}
+
+func (uc *listUsersUseCase) Execute(ctx context.Context, input ListUsersInput) (*ListUsersOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	if input.Page <= 0 {
+		input.Page = 1
+	}
+	if input.Limit <= 0 {
+		input.Limit = 10
+	}
+	if input.Limit > 100 {
+		input.Limit = 100
+	}
+
+	condition := repositories.NewCommonCondition()
+	condition.SetPaging(uint64(input.Limit), uint64(input.Page))
+
+	if search := strings.TrimSpace(input.Search); search != "" {
+		condition.AddOrCondition([]repositories.Condition{
+			{Field: "full_name", Value: search, Op: repositories.ILikeContains},
+			{Field: "email", Value: search, Op: repositories.ILikeContains},
+			{Field: "code", Value: search, Op: repositories.ILikeContains},
+		})
+	}
+	if input.Role != "" {
+		condition.AddCondition("role", strings.ToUpper(input.Role), repositories.Equal)
+	}
+	switch input.Status {
+	case StatusActive:
+		condition.AddCondition("is_active", true, repositories.Equal)
+	case StatusInactive:
+		condition.AddCondition("is_active", false, repositories.Equal)
+	}
+
+	// Only known columns: the sort field is written into the query as is
+	if sortableFields[input.SortBy] {
+		order := repositories.Asc
+		if input.SortOrder == repositories.Desc {
+			order = repositories.Desc
+		}
+		condition.AddSorting(input.SortBy, order)
+	} else {
+		condition.AddSorting("created_at", repositories.Desc)
+	}
+
+	var (
+		page *repositories.Pagination[entities.User]
+		err  error
+	)
+	if input.Status == StatusDeleted {
+		page, err = uc.userRepo.ListDeleted(ctx, condition)
+	} else {
+		page, err = uc.userRepo.GetByCondition(ctx, condition)
+	}
+	if err != nil {
+		ctxLogger.Errorf("Failed to list users: %v", err)
+		return nil, err
+	}
+	if page == nil {
+		return &ListUsersOutput{Users: []*entities.User{}, Pagination: &repositories.Meta{CurrentPage: 1, TotalPages: 1}}, nil
+	}
+
+	return &ListUsersOutput{Users: page.Data, Pagination: &page.Meta}, nil
+}
@@ -0,0 +1,87 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+)
+
+// RestoreUserInput represents the deleted account to restore
+type RestoreUserInput struct {
+	ID        string
+	Requester authz.Principal
+}
+
+// RestoreUserOutput represents the restored account
+type RestoreUserOutput struct {
+	User *entities.User
+}
+
+// RestoreUserUseCase undoes the soft delete of an account. It comes back active or not as it was; its
+// sessions stay revoked, so the user logs in again.
+type RestoreUserUseCase interface {
+	Execute(ctx context.Context, input RestoreUserInput) (*RestoreUserOutput, error)
+}
+
+type restoreUserUseCase struct {
+	userRepo     repointerface.UserRepository
+	authService  user.AuthService
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewRestoreUserUseCase creates a new instance of RestoreUserUseCase
+func NewRestoreUserUseCase(
+	userRepo repointerface.UserRepository,
+	authService user.AuthService,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) RestoreUserUseCase {
+	return &restoreUserUseCase{
+		userRepo:     userRepo,
+		authService:  authService,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *restoreUserUseCase) Execute(ctx context.Context, input RestoreUserInput) (*RestoreUserOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		account, err := uc.userRepo.GetByIDWithDeleted(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrUserNotFound
+		}
+		if !account.DeletedAt.Valid {
+			return nil, ErrUserNotDeleted
+		}
+		if err := checkTarget(txCtx, uc.authService, input.Requester, account); err != nil {
+			return nil, err
+		}
+
+		if err := uc.userRepo.Restore(txCtx, account.ID); err != nil {
+			return nil, err
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.Requester, entities.AuditActionUserRestore, account.ID, nil); err != nil {
+			return nil, err
+		}
+		return uc.userRepo.GetByID(txCtx, account.ID)
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to restore user %s: %v", input.ID, err)
+		return nil, err
+	}
+
+	return &RestoreUserOutput{User: result.(*entities.User)}, nil
+}
@@ -0,0 +1,109 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+	"time"
+)
+
+// SetUserActiveInput represents the account to activate or deactivate
+type SetUserActiveInput struct {
+	ID        string
+	Active    bool
+	Requester authz.Principal
+}
+
+// SetUserActiveOutput represents the updated account and how many sessions a deactivation ended
+type SetUserActiveOutput struct {
+	User            *entities.User
+	RevokedSessions int
+}
+
+// SetUserActiveUseCase activates or deactivates an account. Deactivating signs the user out everywhere
+// and voids any pending activation link; the account can no longer log in or refresh tokens.
+type SetUserActiveUseCase interface {
+	Execute(ctx context.Context, input SetUserActiveInput) (*SetUserActiveOutput, error)
+}
+
+type setUserActiveUseCase struct {
+	userRepo       repointerface.UserRepository
+	invitationRepo repointerface.AccountInvitationRepository
+	authService    user.AuthService
+	auditLogRepo   repointerface.AuditLogRepository
+	uow            repositories.UnitOfWork
+	log            logger.Logger
+}
+
+// NewSetUserActiveUseCase creates a new instance of SetUserActiveUseCase
+func NewSetUserActiveUseCase(
+	userRepo repointerface.UserRepository,
+	invitationRepo repointerface.AccountInvitationRepository,
+	authService user.AuthService,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) SetUserActiveUseCase {
+	return &setUserActiveUseCase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		authService:    authService,
+		auditLogRepo:   auditLogRepo,
+		uow:            uow,
+		log:            log,
+	}
+}
+
+func (uc *setUserActiveUseCase) Execute(ctx context.Context, input SetUserActiveInput) (*SetUserActiveOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		account, err := uc.userRepo.GetByID(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrUserNotFound
+		}
+		if err := checkTarget(txCtx, uc.authService, input.Requester, account); err != nil {
+			return nil, err
+		}
+		output := &SetUserActiveOutput{User: account}
+		if account.IsActive == input.Active {
+			return output, nil
+		}
+
+		if err := uc.userRepo.Update(txCtx, account.ID, map[string]interface{}{"is_active": input.Active}); err != nil {
+			return nil, err
+		}
+		account.IsActive = input.Active
+
+		action := entities.AuditActionUserActivate
+		if !input.Active {
+			action = entities.AuditActionUserDeactivate
+		}
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.Requester, action, account.ID, nil); err != nil {
+			return nil, err
+		}
+		if !input.Active {
+			if err := uc.invitationRepo.RevokePending(txCtx, account.ID, time.Now()); err != nil {
+				return nil, err
+			}
+			// Last, so the sessions are only denied once everything else went through
+			if output.RevokedSessions, err = uc.authService.RevokeUserSessions(txCtx, account.ID); err != nil {
+				return nil, err
+			}
+		}
+		return output, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to set user %s active=%t: %v", input.ID, input.Active, err)
+		return nil, err
+	}
+
+	return result.(*SetUserActiveOutput), nil
+}
@@ -0,0 +1,105 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	"doan/internal/repositories"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/logger"
+	"strings"
+)
+
+// UpdateUserRoleInput represents the new role of an account
+type UpdateUserRoleInput struct {
+	ID        string
+	Role      string
+	Requester authz.Principal
+}
+
+// UpdateUserRoleOutput represents the updated account
+type UpdateUserRoleOutput struct {
+	User *entities.User
+}
+
+// UpdateUserRoleUseCase changes the role of an account. The requester must hold every permission of both
+// the current and the new role. The user's sessions are revoked, so the old permissions stop working at
+// once and the new ones apply from the next login.
+type UpdateUserRoleUseCase interface {
+	Execute(ctx context.Context, input UpdateUserRoleInput) (*UpdateUserRoleOutput, error)
+}
+
+type updateUserRoleUseCase struct {
+	userRepo     repointerface.UserRepository
+	roleRepo     repointerface.RoleRepository
+	authService  user.AuthService
+	auditLogRepo repointerface.AuditLogRepository
+	uow          repositories.UnitOfWork
+	log          logger.Logger
+}
+
+// NewUpdateUserRoleUseCase creates a new instance of UpdateUserRoleUseCase
+func NewUpdateUserRoleUseCase(
+	userRepo repointerface.UserRepository,
+	roleRepo repointerface.RoleRepository,
+	authService user.AuthService,
+	auditLogRepo repointerface.AuditLogRepository,
+	uow repositories.UnitOfWork,
+	log logger.Logger,
+) UpdateUserRoleUseCase {
+	return &updateUserRoleUseCase{
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		authService:  authService,
+		auditLogRepo: auditLogRepo,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+func (uc *updateUserRoleUseCase) Execute(ctx context.Context, input UpdateUserRoleInput) (*UpdateUserRoleOutput, error) {
+	ctxLogger := logger.NewLogger(ctx)
+
+	role := strings.ToUpper(strings.TrimSpace(input.Role))
+	if err := checkRole(ctx, uc.authService, uc.roleRepo, input.Requester, role); err != nil {
+		return nil, err
+	}
+
+	result, err := repositories.ExecuteInTransaction(ctx, uc.uow, uc.log, func(txCtx context.Context) (interface{}, error) {
+		account, err := uc.userRepo.GetByID(txCtx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrUserNotFound
+		}
+		if err := checkTarget(txCtx, uc.authService, input.Requester, account); err != nil {
+			return nil, err
+		}
+		if account.Role == role {
+			return account, nil
+		}
+
+		previous := account.Role
+		if err := uc.userRepo.Update(txCtx, account.ID, map[string]interface{}{"role": role}); err != nil {
+			return nil, err
+		}
+		account.Role = role
+		if err := writeAudit(txCtx, uc.auditLogRepo, input.Requester, entities.AuditActionUserRoleChange, account.ID,
+			entities.JSONMap{"previous_role": previous, "role": role}); err != nil {
+			return nil, err
+		}
+		// Last, so the sessions are only denied once everything else went through
+		if _, err := uc.authService.RevokeUserSessions(txCtx, account.ID); err != nil {
+			return nil, err
+		}
+		return account, nil
+	})
+	if err != nil {
+		ctxLogger.Errorf("Failed to change the role of user %s: %v", input.ID, err)
+		return nil, err
+	}
+
+	return &UpdateUserRoleOutput{User: result.(*entities.User)}, nil
+}
@@ -0,0 +1,65 @@
+package usermanagement
+
+import (
+	"context"
+	"doan/internal/entities"
+	repointerface "doan/internal/repositories/interface"
+	"doan/internal/services/user"
+	"doan/pkg/authz"
+	"doan/pkg/constants"
+)
+
+// checkRole returns an error unless the role exists and the requester holds every permission it grants,
+// so nobody can hand out more than they hold
+func checkRole(ctx context.Context, authService user.AuthService, roleRepo repointerface.RoleRepository, requester authz.Principal, role string) error {
+	if !constants.IsValidRole(role) {
+		custom, err := roleRepo.GetByName(ctx, role)
+		if err != nil {
+			return err
+		}
+		if custom == nil {
+			return ErrUnknownRole
+		}
+	}
+	permissions, err := authService.RolePermissions(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !requester.Covers(permissions) {
+		return ErrPrivilegedUser
+	}
+	return nil
+}
+
+// checkTarget returns an error unless the requester may act on the account: never their own, and only
+// accounts whose role grants nothing the requester lacks
+func checkTarget(ctx context.Context, authService user.AuthService, requester authz.Principal, target *entities.User) error {
+	if requester.Owns(target.ID) {
+		return ErrOwnAccount
+	}
+	permissions, err := authService.RolePermissions(ctx, target.Role)
+	if err != nil {
+		return err
+	}
+	if !requester.Covers(permissions) {
+		return ErrPrivilegedUser
+	}
+	return nil
+}
+
+// writeAudit records a change made by the requester to the account of userID
+func writeAudit(ctx context.Context, auditLogRepo repointerface.AuditLogRepository, requester authz.Principal, action, userID string, metadata entities.JSONMap) error {
+	var actor *string
+	if requester.UserID != "" {
+		actor = &requester.UserID
+	}
+	_, err := auditLogRepo.Create(ctx, &entities.AuditLog{
+		ActorID:    actor,
+		ActorRole:  requester.Role,
+		Action:     action,
+		EntityType: entities.AuditEntityUser,
+		EntityID:   userID,
+		Metadata:   metadata,
+	})
+	return err
+}
@@ -0,0 +1,64 @@
+package authz
+
+import (
+	"doan/pkg/constants"
+	"errors"
+)
+
+// ErrForbidden is returned when the caller lacks a permission or does not own the resource
+var ErrForbidden = errors.New("you don't have permission to perform this action")
+
+// Principal is the authenticated caller as seen by a use case
+type Principal struct {
+	UserID      string
+	Role        string
+	Permissions []string
+}
+
+// Can reports whether the principal holds perm
+func (p Principal) Can(perm string) bool {
+	return constants.HasPermission(p.Permissions, perm)
+}
+
+// CanAny reports whether the principal holds at least one of perms
+func (p Principal) CanAny(perms ...string) bool {
+	for _, perm := range perms {
+		if p.Can(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether the principal holds every one of perms, so that nobody hands out or acts with
+// more than they hold themselves
+func (p Principal) Covers(perms []string) bool {
+	for _, perm := range perms {
+		if !p.Can(perm) {
+			return false
+		}
+	}
+	return true
+}
+
+// Require returns ErrForbidden unless the principal holds perm
+func (p Principal) Require(perm string) error {
+	if !p.Can(perm) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Owns reports whether the resource owned by ownerID belongs to the principal
+func (p Principal) Owns(ownerID string) bool {
+	return p.UserID != "" && p.UserID == ownerID
+}
+
+// RequireOwnerOr passes when the principal owns the resource or holds the override permission,
+// such as a teacher deleting their own material versus material:manage for anyone's
+func (p Principal) RequireOwnerOr(ownerID, override string) error {
+	if p.Owns(ownerID) || p.Can(override) {
+		return nil
+	}
+	return ErrForbidden
+}
@@ -2,9 +2,12 @@ package config
 
 import (
 	"context"
-	"github.com/spf13/viper"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -36,8 +39,15 @@ func GetManager() Manager {
 }
 
 func (m *viperManager) Start(ctx context.Context) {
-	// Implement config watching if needed
-	// For now, just a placeholder
+	// A file config is reloaded when it changes on disk; every watcher is told, since a
+	// change event does not say which keys moved
+	if viper.ConfigFileUsed() != "" {
+		viper.OnConfigChange(func(fsnotify.Event) {
+			m.notify("")
+		})
+		viper.WatchConfig()
+	}
+
 	go func() {
 		<-ctx.Done()
 		// Cleanup watchers
@@ -48,9 +58,31 @@ func (m *viperManager) Start(ctx context.Context) {
 				close(ch)
 			}
 		}
+		m.watchers = make(map[string][]chan struct{})
 	}()
 }
 
+// notify signals the watchers of key, of its parents and of its children; an empty key signals all.
+// A watcher that has not drained its previous signal is not signalled twice.
+func (m *viperManager) notify(key string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key = strings.ToLower(key)
+	for watched, channels := range m.watchers {
+		w := strings.ToLower(watched)
+		if key != "" && w != key && !strings.HasPrefix(key, w+".") && !strings.HasPrefix(w, key+".") {
+			continue
+		}
+		for _, ch := range channels {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
 func (m *viperManager) WatchKey(key string) <-chan struct{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -110,6 +142,7 @@ func (m *viperManager) Unmarshal(rawVal interface{}) error {
 
 func (m *viperManager) Set(key string, value interface{}) {
 	viper.Set(key, value)
+	m.notify(key)
 }
 
 func (m *viperManager) SetDefault(key string, value interface{}) {
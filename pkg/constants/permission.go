@@ -0,0 +1,176 @@
+package constants
+
+import "strings"
+
+// Permissions are "resource:action" pairs granted to roles and carried in the JWT "perms" claim.
+// "*" grants everything and "resource:*" every action on one resource.
+const (
+	PermissionAll = "*"
+
+	PermissionStudentRead   = "student:read"
+	PermissionStudentWrite  = "student:write"
+	PermissionStudentInvite = "student:invite"
+
+	PermissionTeacherWrite  = "teacher:write"
+	PermissionTeacherInvite = "teacher:invite"
+
+	PermissionCourseWrite  = "course:write"
+	PermissionProgramWrite = "program:write"
+	PermissionRoomWrite    = "room:write"
+
+	PermissionClassCreate     = "class:create"
+	PermissionClassUpdate     = "class:update"
+	PermissionClassDelete     = "class:delete"
+	PermissionClassCompliance = "class:compliance"
+
+	PermissionEnrollmentRead    = "enrollment:read"
+	PermissionEnrollmentWrite   = "enrollment:write"
+	PermissionEnrollmentApprove = "enrollment:approve"
+
+	PermissionInvoiceRead = "invoice:read"
+
+	PermissionPaymentRead   = "payment:read"
+	PermissionPaymentRecord = "payment:record"
+	PermissionPaymentRefund = "payment:refund" // refunds and voids
+
+	PermissionPayrollRead     = "payroll:read"
+	PermissionPayrollWrite    = "payroll:write"
+	PermissionPayrollFinalise = "payroll:finalise"
+
+	PermissionReminderManage = "reminder:manage"
+
+	PermissionGuardianRead   = "guardian:read"
+	PermissionGuardianWrite  = "guardian:write"
+	PermissionGuardianPortal = "guardian:portal" // follow one's own linked children
+
+	PermissionMaterialRead   = "material:read"
+	PermissionMaterialUpload = "material:upload"
+	PermissionMaterialManage = "material:manage" // delete or audit materials uploaded by others
+
+	PermissionComplianceReview = "compliance:review"
+	PermissionReportRead       = "report:read"
+	PermissionDashboardRead    = "dashboard:read"
+
+	PermissionRoleRead  = "role:read"
+	PermissionRoleWrite = "role:write"
+
+	PermissionUserRead          = "user:read"
+	PermissionUserWrite         = "user:write"          // create, change the role of, deactivate, delete and restore accounts
+	PermissionUserUnlock        = "user:unlock"         // lift a failed-login lockout
+	PermissionUserMFAReset      = "user:mfa_reset"      // remove the authenticator of someone who lost it
+	PermissionUserResetPassword = "user:reset_password" // make someone pick a new password
+	PermissionUserImpersonate   = "user:impersonate"    // act as another user, every request audited
+
+	PermissionAuditRead = "audit:read" // search the audit log and verify its hash chain
+)
+
+// PermissionInfo describes one permission of the catalog
+type PermissionInfo struct {
+	Name        string
+	Description string
+}
+
+// permissionCatalog lists every known permission with a short description, in display order
+var permissionCatalog = []PermissionInfo{
+	{PermissionStudentRead, "View student profiles"},
+	{PermissionStudentWrite, "Create, update and delete students"},
+	{PermissionStudentInvite, "Invite students to activate an account"},
+	{PermissionTeacherWrite, "Create, update and delete teachers"},
+	{PermissionTeacherInvite, "Invite teachers to activate an account"},
+	{PermissionCourseWrite, "Create, update and delete courses"},
+	{PermissionProgramWrite, "Manage programs and their courses"},
+	{PermissionRoomWrite, "Create, update and delete rooms"},
+	{PermissionClassCreate, "Create classes"},
+	{PermissionClassUpdate, "Update classes"},
+	{PermissionClassDelete, "Delete classes"},
+	{PermissionClassCompliance, "Evaluate class compliance"},
+	{PermissionEnrollmentRead, "View enrollments"},
+	{PermissionEnrollmentWrite, "Create enrollments"},
+	{PermissionEnrollmentApprove, "Approve or reject enrollments"},
+	{PermissionInvoiceRead, "View invoices and outstanding balances"},
+	{PermissionPaymentRead, "View payments and reconciliation"},
+	{PermissionPaymentRecord, "Record payments"},
+	{PermissionPaymentRefund, "Refund or void payments"},
+	{PermissionPayrollRead, "View rate cards and payroll runs"},
+	{PermissionPayrollWrite, "Manage rate cards and draft payroll runs"},
+	{PermissionPayrollFinalise, "Finalise payroll runs"},
+	{PermissionReminderManage, "Run tuition reminders and manage opt-outs"},
+	{PermissionGuardianRead, "View guardians"},
+	{PermissionGuardianWrite, "Manage guardians, their children and accounts"},
+	{PermissionGuardianPortal, "Follow one's own children in the guardian portal"},
+	{PermissionMaterialRead, "View and download materials and their analyses"},
+	{PermissionMaterialUpload, "Upload materials and manage one's own"},
+	{PermissionMaterialManage, "Delete or audit any material"},
+	{PermissionComplianceReview, "Review audited materials"},
+	{PermissionReportRead, "View and export reports"},
+	{PermissionDashboardRead, "View the dashboard"},
+	{PermissionRoleRead, "View roles and permissions"},
+	{PermissionRoleWrite, "Create, update and delete roles"},
+	{PermissionUserRead, "View user accounts"},
+	{PermissionUserWrite, "Create, deactivate, delete and restore user accounts and change their role"},
+	{PermissionUserUnlock, "Unlock accounts locked after failed logins"},
+	{PermissionUserMFAReset, "Reset the two-factor authentication of accounts"},
+	{PermissionUserResetPassword, "Force users to set a new password"},
+	{PermissionUserImpersonate, "Sign in as another user; every request is audited"},
+	{PermissionAuditRead, "View the audit log and verify it was not tampered with"},
+}
+
+// defaultRolePermissions are the bundles the built-in roles are seeded with
+var defaultRolePermissions = map[string][]string{
+	RoleAdmin: {PermissionAll},
+	RoleTeacher: {
+		PermissionStudentRead,
+		PermissionMaterialRead,
+		PermissionMaterialUpload,
+	},
+	RoleStudent: {},
+	RoleCompliance: {
+		PermissionComplianceReview,
+		PermissionMaterialRead,
+		PermissionReportRead,
+		PermissionAuditRead,
+	},
+	RoleGuardian: {PermissionGuardianPortal},
+}
+
+// Permissions returns every known permission, in display order
+func Permissions() []PermissionInfo {
+	return append([]PermissionInfo{}, permissionCatalog...)
+}
+
+// IsValidPermission reports whether perm is a known permission, "*" or "resource:*" for a known resource
+func IsValidPermission(perm string) bool {
+	if perm == PermissionAll {
+		return true
+	}
+	resource, action, ok := strings.Cut(perm, ":")
+	if !ok {
+		return false
+	}
+	for _, p := range permissionCatalog {
+		if p.Name == perm || (action == "*" && strings.HasPrefix(p.Name, resource+":")) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRolePermissions returns a copy of the built-in bundle of a role, nil for custom roles
+func DefaultRolePermissions(role string) []string {
+	perms, ok := defaultRolePermissions[role]
+	if !ok {
+		return nil
+	}
+	return append([]string{}, perms...)
+}
+
+// HasPermission reports whether granted covers perm, honouring "*" and "resource:*"
+func HasPermission(granted []string, perm string) bool {
+	resource, _, _ := strings.Cut(perm, ":")
+	for _, g := range granted {
+		if g == perm || g == PermissionAll || g == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package constants
+
+// User roles, stored in users.role and carried in the JWT "role" claim
+const (
+	RoleAdmin      = "ADMIN"
+	RoleTeacher    = "TEACHER"
+	RoleStudent    = "STUDENT"
+	RoleCompliance = "COMPLIANCE" // Compliance officer: reviews audited materials
+	RoleGuardian   = "GUARDIAN"   // Parent or guardian: sees only their linked children
+)
+
+// IsValidRole reports whether role is one of the known user roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleTeacher, RoleStudent, RoleCompliance, RoleGuardian:
+		return true
+	}
+	return false
+}
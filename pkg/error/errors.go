@@ -403,7 +403,7 @@ func (e AppError) MakeGRPCStatus() AppError {
 		"ops":          strings.Join(e.Ops, ","),
 		"retryable":    fmt.Sprintf("%t", e.Retryable),
 		"meta":         string(data),
-		"type":         string(e.Type),
+		"type":         e.Type.String(),
 		"code":         e.Code,
 	}
 
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// Export formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Content types of the export formats
+const (
+	ContentTypeCSV  = "text/csv; charset=utf-8"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// WriteCSV writes a header row and the data rows as UTF-8 CSV with a BOM so Excel shows Vietnamese correctly
+func WriteCSV(w io.Writer, headers []string, rows [][]string) error {
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = escapeFormula(cell)
+		}
+		if err := writer.Write(escaped); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeFormula neutralises cells a spreadsheet would evaluate as a formula (CSV injection)
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
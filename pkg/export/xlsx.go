@@ -0,0 +1,150 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCellLength is the longest text an Excel cell accepts
+const maxCellLength = 32767
+
+// WriteXLSX writes a single-sheet workbook with a bold header row; every cell is stored as text
+func WriteXLSX(w io.Writer, sheetName string, headers []string, rows [][]string) error {
+	archive := zip.NewWriter(w)
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(xlsxContentTypes)},
+		{"_rels/.rels", []byte(xlsxRootRels)},
+		{"xl/workbook.xml", []byte(fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetTitle(sheetName))))},
+		{"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+		{"xl/worksheets/sheet1.xml", worksheet(headers, rows)},
+	}
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func worksheet(headers []string, rows [][]string) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+	writeRow(&b, 1, headers, 1)
+	for i, row := range rows {
+		writeRow(&b, i+2, row, 0)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.Bytes()
+}
+
+func writeRow(b *bytes.Buffer, number int, cells []string, style int) {
+	fmt.Fprintf(b, `<row r="%d">`, number)
+	for i, cell := range cells {
+		fmt.Fprintf(b, `<c r="%s%d" t="inlineStr"`, columnName(i), number)
+		if style > 0 {
+			fmt.Fprintf(b, ` s="%d"`, style)
+		}
+		b.WriteString(`><is><t xml:space="preserve">`)
+		b.WriteString(xmlEscape(truncateCell(cell)))
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+}
+
+// columnName converts a zero based index to a column letter (0 -> A, 26 -> AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xmlEscape escapes text and drops characters XML 1.0 cannot carry
+func xmlEscape(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r != 0xFFFE && r != 0xFFFF) {
+			return r
+		}
+		return -1
+	}, s)
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func truncateCell(s string) string {
+	if utf8.RuneCountInString(s) <= maxCellLength {
+		return s
+	}
+	return string([]rune(s)[:maxCellLength])
+}
+
+// sheetTitle applies Excel's sheet name rules: at most 31 characters, none of []:*?/\
+func sheetTitle(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Sheet1"
+	}
+	if utf8.RuneCountInString(name) > 31 {
+		name = string([]rune(name)[:31])
+	}
+	return name
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+// Style 0 is the default, style 1 the bold header
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
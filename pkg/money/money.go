@@ -0,0 +1,229 @@
+// Package money represents Vietnamese đồng amounts as integers so tuition arithmetic never rounds through float64
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency is the only currency the centre bills in
+const Currency = "VND"
+
+// ErrInvalidAmount is returned when a value cannot be read as a whole number of đồng
+var ErrInvalidAmount = errors.New("invalid money amount")
+
+// Amount is a whole number of đồng (VND has no minor unit)
+type Amount int64
+
+// Zero is the zero amount
+const Zero Amount = 0
+
+// New returns the amount of the given number of đồng
+func New(dong int64) Amount {
+	return Amount(dong)
+}
+
+// Parse reads "1500000", "1500000.00" or "-20000"; a non-zero fractional part is rejected
+func Parse(value string) (Amount, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if !rat.IsInt() {
+		return 0, fmt.Errorf("%w: %q has a fractional đồng", ErrInvalidAmount, value)
+	}
+	if !rat.Num().IsInt64() {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, value)
+	}
+	return Amount(rat.Num().Int64()), nil
+}
+
+// Int64 returns the number of đồng
+func (a Amount) Int64() int64 {
+	return int64(a)
+}
+
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+func (a Amount) Mul(n int64) Amount {
+	return a * Amount(n)
+}
+
+func (a Amount) Neg() Amount {
+	return -a
+}
+
+func (a Amount) IsZero() bool {
+	return a == 0
+}
+
+func (a Amount) IsPositive() bool {
+	return a > 0
+}
+
+func (a Amount) IsNegative() bool {
+	return a < 0
+}
+
+// Min returns the smaller of a and b
+func Min(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Percent returns a*percent/100 rounded half away from zero
+func (a Amount) Percent(percent int64) Amount {
+	return a.Ratio(percent, 100)
+}
+
+// Ratio returns a*num/den rounded half away from zero
+func (a Amount) Ratio(num, den int64) Amount {
+	if den == 0 {
+		return 0
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(num))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(den), new(big.Int))
+	// Round half away from zero: compare 2*|remainder| with |den|
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(big.NewInt(den))) >= 0 {
+		if (product.Sign() < 0) != (den < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Amount(quotient.Int64())
+}
+
+// Split divides a into n parts that sum exactly to a; the remainder goes to the first parts
+func (a Amount) Split(n int) []Amount {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]Amount, n)
+	base := a / Amount(n)
+	remainder := a % Amount(n)
+	for i := range parts {
+		parts[i] = base
+		if remainder > 0 {
+			parts[i]++
+			remainder--
+		} else if remainder < 0 {
+			parts[i]--
+			remainder++
+		}
+	}
+	return parts
+}
+
+// Sum adds amounts
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, a := range amounts {
+		total += a
+	}
+	return total
+}
+
+// String returns the plain number of đồng, e.g. "1500000"
+func (a Amount) String() string {
+	return strconv.FormatInt(int64(a), 10)
+}
+
+// Format returns the amount the Vietnamese way, e.g. "1.500.000 ₫"
+func (a Amount) Format() string {
+	digits := strconv.FormatInt(int64(a), 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String() + " ₫"
+}
+
+// MarshalJSON writes the amount as a JSON integer
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or string without a fractional đồng
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, string(data))
+		}
+		raw = number.String()
+	}
+	parsed, err := Parse(raw)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value stores the amount as a BIGINT
+func (a Amount) Value() (driver.Value, error) {
+	return int64(a), nil
+}
+
+// Scan reads BIGINT and NUMERIC columns
+func (a *Amount) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case int64:
+		*a = Amount(v)
+		return nil
+	case []byte:
+		parsed, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	case float64:
+		// Legacy numeric(10,2) columns through drivers that decode to float
+		parsed, err := Parse(strconv.FormatFloat(v, 'f', -1, 64))
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	}
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, value)
+}
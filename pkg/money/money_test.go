@@ -0,0 +1,204 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Amount
+		wantErr bool
+	}{
+		{"1500000", 1500000, false},
+		{" 1500000.00 ", 1500000, false},
+		{"-20000", -20000, false},
+		{"0", 0, false},
+		{"1500000.50", 0, true},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"99999999999999999999", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("Parse(%q) err = %v, want ErrInvalidAmount", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Amount
+		num, den int64
+		want     Amount
+	}{
+		{"exact", 1000000, 1, 4, 250000},
+		{"rounds down below half", 10, 1, 3, 3},
+		{"rounds half up", 5, 1, 2, 3},
+		{"rounds negative half away from zero", -5, 1, 2, -3},
+		{"negative denominator", 5, 1, -2, -3},
+		{"zero denominator", 1000, 1, 0, 0},
+		{"no overflow in the product", 9000000000000000, 3, 4, 6750000000000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Ratio(tt.num, tt.den); got != tt.want {
+				t.Errorf("%d.Ratio(%d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		amount  Amount
+		percent int64
+		want    Amount
+	}{
+		{1500000, 10, 150000},
+		{333, 15, 50},
+		{1, 50, 1},
+		{-1, 50, -1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Percent(tt.percent); got != tt.want {
+			t.Errorf("%d.Percent(%d) = %d, want %d", tt.amount, tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		n      int
+		want   []Amount
+	}{
+		{"even", 900, 3, []Amount{300, 300, 300}},
+		{"remainder to the first parts", 1000, 3, []Amount{334, 333, 333}},
+		{"negative remainder", -1000, 3, []Amount{-334, -333, -333}},
+		{"more parts than đồng", 2, 3, []Amount{1, 1, 0}},
+		{"no parts", 1000, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.amount.Split(tt.n)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%d.Split(%d) = %v, want %v", tt.amount, tt.n, got, tt.want)
+			}
+			if tt.n > 0 && Sum(got...) != tt.amount {
+				t.Errorf("parts sum to %d, want %d", Sum(got...), tt.amount)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		want   string
+	}{
+		{0, "0 ₫"},
+		{999, "999 ₫"},
+		{1000, "1.000 ₫"},
+		{1500000, "1.500.000 ₫"},
+		{-20000, "-20.000 ₫"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Format(); got != tt.want {
+			t.Errorf("%d.Format() = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Amount
+		wantErr bool
+	}{
+		{`1500000`, 1500000, false},
+		{`"1500000"`, 1500000, false},
+		{`1500000.0`, 1500000, false},
+		{`1500000.5`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got Amount
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) err = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+
+	raw, err := json.Marshal(struct{ Total Amount }{Total: 1500000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != `{"Total":1500000}` {
+		t.Errorf("Marshal = %s", raw)
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    Amount
+		wantErr bool
+	}{
+		{"bigint", int64(1500000), 1500000, false},
+		{"numeric bytes", []byte("1500000.00"), 1500000, false},
+		{"numeric string", "20000", 20000, false},
+		{"legacy float", float64(1500000), 1500000, false},
+		{"null", nil, 0, false},
+		{"fractional", "1.5", 0, true},
+		{"unsupported type", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Amount(-1)
+			err := got.Scan(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("Scan(%v) err = %v, want ErrInvalidAmount", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package requestinfo
+
+import (
+	"context"
+	"doan/pkg/logger"
+)
+
+// Keys the HTTP middlewares set the caller under, on the gin context
+const (
+	KeyInfo           = "request_info"
+	KeyUserID         = "user_id"
+	KeyUserRole       = "user_role"
+	KeyImpersonatorID = "impersonator_id"
+	KeyClientIP       = "client_ip"
+	KeyTraceID        = logger.TraceKey
+)
+
+// Info describes who made a request and from where, for the audit trail
+type Info struct {
+	UserID         string
+	Role           string
+	ImpersonatorID string // the admin acting as UserID, empty unless impersonating
+	IPAddress      string
+	TraceID        string
+}
+
+type infoKey struct{}
+
+// NewContext returns a context carrying info, for requests and jobs that act on someone's behalf.
+// The trace ID is also set under logger.TraceKey so log lines carry it.
+func NewContext(ctx context.Context, info *Info) context.Context {
+	ctx = context.WithValue(ctx, infoKey{}, info)
+	if info.TraceID != "" {
+		ctx = context.WithValue(ctx, logger.TraceKey, info.TraceID)
+	}
+	return ctx
+}
+
+// FromContext returns what ctx knows about the caller. A gin context without RequestInfoMiddleware still
+// yields the user set by AuthMiddleware; a context with nothing returns an empty Info.
+func FromContext(ctx context.Context) Info {
+	if ctx == nil {
+		return Info{}
+	}
+	if info, ok := ctx.Value(infoKey{}).(*Info); ok && info != nil {
+		return *info
+	}
+	if info, ok := ctx.Value(KeyInfo).(*Info); ok && info != nil {
+		return *info
+	}
+	info := Info{}
+	info.UserID, _ = ctx.Value(KeyUserID).(string)
+	info.Role, _ = ctx.Value(KeyUserRole).(string)
+	info.ImpersonatorID, _ = ctx.Value(KeyImpersonatorID).(string)
+	info.IPAddress, _ = ctx.Value(KeyClientIP).(string)
+	info.TraceID, _ = ctx.Value(KeyTraceID).(string)
+	return info
+}
@@ -0,0 +1,140 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors, "12345678901234567890", base32 encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code at %d: %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("Code at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestCodeSecretFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{"lower case", strings.ToLower(rfcSecret), false},
+		{"padded", rfcSecret + "====", false},
+		{"not base32", "not-a-secret!", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Code(tt.secret, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != "287082" {
+				t.Errorf("Code = %s, want 287082", got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+	codeAt := func(step int64) string {
+		code, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", codeAt(current), 1, current, true},
+		{"spaced as displayed", codeAt(current)[:3] + " " + codeAt(current)[3:], 1, current, true},
+		{"previous step within skew", codeAt(current - 1), 1, current - 1, true},
+		{"next step within skew", codeAt(current + 1), 1, current + 1, true},
+		{"outside skew", codeAt(current - 2), 1, 0, false},
+		{"no skew", codeAt(current - 1), 0, 0, false},
+		{"wrong length", "12345", 1, 0, false},
+		{"empty", "", 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, tt.code, now, tt.skew)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Validate(%q) = (%d, %v), want (%d, %v)", tt.code, step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Error("two secrets are equal")
+	}
+	if len(first) != 32 {
+		t.Errorf("secret length = %d, want 32 base32 characters", len(first))
+	}
+	if _, err := Code(first, 1); err != nil {
+		t.Errorf("generated secret does not decode: %v", err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Doan Centre", "an@example.com", rfcSecret)
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Doan%20Centre:an@example.com?") {
+		t.Fatalf("unexpected label in %s", uri)
+	}
+	if strings.Contains(uri, "+") {
+		t.Errorf("spaces encoded as + in %s", uri)
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	for key, want := range map[string]string{
+		"secret": rfcSecret, "issuer": "Doan Centre", "algorithm": "SHA1", "digits": "6", "period": "30",
+	} {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -39,4 +39,42 @@ type JWTConfig struct {
 	Secret               string `json:"secret,omitempty" yaml:"secret" mapstructure:"secret"`
 	AccessTokenDuration  string `json:"access_token_duration,omitempty" yaml:"access_token_duration" mapstructure:"access_token_duration"`
 	RefreshTokenDuration string `json:"refresh_token_duration,omitempty" yaml:"refresh_token_duration" mapstructure:"refresh_token_duration"`
+	// Issuer được ghi vào claim iss và bắt buộc khi xác thực token
+	Issuer string `json:"issuer,omitempty" yaml:"issuer" mapstructure:"issuer"`
+	// Audience được ghi vào claim aud; token phải dành cho ít nhất một audience trong danh sách
+	Audience []string `json:"audience,omitempty" yaml:"audience" mapstructure:"audience"`
+	// Keys là các khóa ký bất đối xứng (RS256/EdDSA); khi có Keys, Secret chỉ còn dùng để xác thực token cũ
+	Keys []JWTKeyConfig `json:"keys,omitempty" yaml:"keys" mapstructure:"keys"`
+}
+
+// JWTKeyConfig là một khóa ký JWT, nhận diện bằng kid
+type JWTKeyConfig struct {
+	KeyID          string `json:"kid,omitempty" yaml:"kid" mapstructure:"kid"`
+	Algorithm      string `json:"algorithm,omitempty" yaml:"algorithm" mapstructure:"algorithm"`                      // RS256 hoặc EdDSA; mặc định suy ra từ loại khóa
+	PrivateKey     string `json:"private_key,omitempty" yaml:"private_key" mapstructure:"private_key"`                // PEM, PKCS#1 hoặc PKCS#8
+	PrivateKeyFile string `json:"private_key_file,omitempty" yaml:"private_key_file" mapstructure:"private_key_file"` // đường dẫn file PEM, dùng khi private_key rỗng
+	NotBefore      string `json:"not_before,omitempty" yaml:"not_before" mapstructure:"not_before"`                   // RFC 3339; khóa bắt đầu ký từ thời điểm này
+}
+
+// BlobStorageConfig cấu hình cho nơi lưu trữ file (local hoặc S3-compatible)
+type BlobStorageConfig struct {
+	Driver string             `json:"driver,omitempty" yaml:"driver" mapstructure:"driver"`
+	Local  LocalStorageConfig `json:"local,omitempty" yaml:"local" mapstructure:"local"`
+	S3     S3StorageConfig    `json:"s3,omitempty" yaml:"s3" mapstructure:"s3"`
+}
+
+// LocalStorageConfig cấu hình lưu trữ trên ổ đĩa
+type LocalStorageConfig struct {
+	Root string `json:"root,omitempty" yaml:"root" mapstructure:"root"`
+}
+
+// S3StorageConfig cấu hình cho S3 hoặc dịch vụ tương thích (MinIO, R2, ...)
+type S3StorageConfig struct {
+	Endpoint       string `json:"endpoint,omitempty" yaml:"endpoint" mapstructure:"endpoint"`
+	Region         string `json:"region,omitempty" yaml:"region" mapstructure:"region"`
+	Bucket         string `json:"bucket,omitempty" yaml:"bucket" mapstructure:"bucket"`
+	AccessKey      string `json:"access_key,omitempty" yaml:"access_key" mapstructure:"access_key"`
+	SecretKey      string `json:"secret_key,omitempty" yaml:"secret_key" mapstructure:"secret_key"`
+	ForcePathStyle bool   `json:"force_path_style,omitempty" yaml:"force_path_style" mapstructure:"force_path_style"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
 }
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"archive/zip"
+	"bytes"
 	"doan/pkg/constants"
 	"doan/pkg/types"
 	"mime"
@@ -27,7 +29,6 @@ var (
 		constants.MimeTypeHTML:           true,
 		constants.MimeTypeMarkdown:       true,
 		constants.MimeTypeYAML:           true,
-		constants.MimeTyeExe:             true,
 		constants.MimeTypeSQL:            true,
 
 		// Images
@@ -57,9 +58,6 @@ var (
 		constants.MimeTypeGzip: true,
 		constants.MimeTypeTar:  true,
 		constants.MimeTypeRar:  true,
-
-		// Generic fallback
-		constants.MimeTypeOctetStream: true,
 	}
 
 	ExtensionMap = map[string]string{
@@ -115,8 +113,73 @@ func DetectFileType(headerBytes []byte, fileName string) (*types.FileTypeInfo, e
 	return result, nil
 }
 
+// DetectFileTypeFromContent detects the file type from the whole content instead of the header only.
+// ZIP based Office documents are identified by their package parts, so a renamed archive or
+// executable is never reported as a document just because of its extension.
+func DetectFileTypeFromContent(content []byte, fileName string) (*types.FileTypeInfo, error) {
+	header := content
+	if len(header) > 512 {
+		header = header[:512]
+	}
+	result, err := DetectFileType(header, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch result.DetectedMime {
+	case constants.MimeTypeZip, constants.MimeTypeWordDocx, constants.MimeTypeExcelXlsx, constants.MimeTypePowerPointPptx:
+		result.DetectedMime = detectZipPackage(content)
+		result.Extension = getExtensionForMime(result.DetectedMime)
+	}
+	return result, nil
+}
+
+// IsAllowedFileType reports whether the detected mime type is in AllowedFileTypes
+func IsAllowedFileType(mimeType string) bool {
+	baseMimeType := strings.TrimSpace(strings.Split(mimeType, ";")[0])
+	return AllowedFileTypes[baseMimeType]
+}
+
+func detectZipPackage(content []byte) string {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return constants.MimeTypeZip
+	}
+	for _, f := range reader.File {
+		switch f.Name {
+		case "word/document.xml":
+			return constants.MimeTypeWordDocx
+		case "xl/workbook.xml":
+			return constants.MimeTypeExcelXlsx
+		case "ppt/presentation.xml":
+			return constants.MimeTypePowerPointPptx
+		}
+	}
+	return constants.MimeTypeZip
+}
+
+// isExecutable checks well-known executable signatures (PE, ELF, Mach-O)
+func isExecutable(header []byte) bool {
+	switch {
+	case len(header) >= 2 && header[0] == 'M' && header[1] == 'Z':
+		return true
+	case len(header) >= 4 && bytes.Equal(header[:4], []byte{0x7f, 'E', 'L', 'F'}):
+		return true
+	case len(header) >= 4 && (bytes.Equal(header[:4], []byte{0xfe, 0xed, 0xfa, 0xce}) ||
+		bytes.Equal(header[:4], []byte{0xfe, 0xed, 0xfa, 0xcf}) ||
+		bytes.Equal(header[:4], []byte{0xce, 0xfa, 0xed, 0xfe}) ||
+		bytes.Equal(header[:4], []byte{0xcf, 0xfa, 0xed, 0xfe})):
+		return true
+	}
+	return false
+}
+
 func refineDetection(header []byte, filename, httpDetectedMime string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
+	// Executables are reported regardless of the file name
+	if isExecutable(header) {
+		return constants.MimeTyeExe
+	}
 	// Check for Office documents (ZIP-based)
 	if strings.HasPrefix(httpDetectedMime, constants.MimeTypeZip) {
 		switch ext {
@@ -129,41 +192,14 @@ func refineDetection(header []byte, filename, httpDetectedMime string) string {
 		}
 	}
 
-	// Check for specific video formats
+	// Media and legacy Office files are only recognised by their signature
+	if mimeType := detectBySignature(header, ext); mimeType != "" {
+		return mimeType
+	}
+
 	if strings.HasPrefix(httpDetectedMime, constants.MimeTypeOctetStream) {
-		// MP4 detection
-		if len(header) > 8 && string(header[4:8]) == "ftyp" {
-			return constants.MimeTypeVideoMP4
-		}
-		// Check file extension for common cases
-		switch ext {
-		case constants.ExtMP4:
-			return constants.MimeTypeVideoMP4
-		case constants.ExtWebM:
-			return constants.MimeTypeVideoWebM
-		case constants.ExtMOV:
-			return constants.MimeTypeVideoMOV
-		case constants.ExtMP3:
-			return constants.MimeTypeAudioMP3
-		case constants.ExtWAV:
-			return constants.MimeTypeAudioWAV
-		case constants.ExtPDF:
-			// Double-check PDF
-			if len(header) > 4 && string(header[:4]) == "%PDF" {
-				return constants.MimeTypePDF
-			}
-		case constants.ExtOGG:
-			return constants.MimeTypeAudioOGG
-		case constants.ExtDOC:
-			return constants.MimeTypeMSWord
-		case constants.ExtXLS:
-			return constants.MimeTypeMSExcel
-		case constants.ExtPPT:
-			return constants.MimeTypeMSPowerPoint
-		case constants.MimeTypeHEIC:
-			return constants.MimeTypeHEIC
-		case constants.MimeTypeHEIF:
-			return constants.MimeTypeHEIF
+		if len(header) > 4 && string(header[:4]) == "%PDF" {
+			return constants.MimeTypePDF
 		}
 	}
 
@@ -201,16 +237,48 @@ func refineDetection(header []byte, filename, httpDetectedMime string) string {
 		}
 	}
 
-	if strings.HasPrefix(httpDetectedMime, constants.MimeTypeVideoMP4) {
-		switch ext {
-		case constants.MimeTypeHEIC:
+	return httpDetectedMime
+}
+
+// oleSignature starts every OLE compound file, the container of .doc, .xls and .ppt
+var oleSignature = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+
+// detectBySignature identifies audio, video and legacy Office files from their magic bytes.
+// The extension only picks between formats sharing a container, it never makes the match on its own.
+func detectBySignature(header []byte, ext string) string {
+	switch {
+	case len(header) >= 12 && string(header[4:8]) == "ftyp":
+		switch string(header[8:12]) {
+		case "qt  ":
+			return constants.MimeTypeVideoMOV
+		case "heic", "heix", "hevc", "hevx":
 			return constants.MimeTypeHEIC
-		case constants.MimeTypeHEIF:
+		case "mif1", "msf1":
 			return constants.MimeTypeHEIF
 		}
+		return constants.MimeTypeVideoMP4
+	case len(header) >= 4 && bytes.Equal(header[:4], []byte{0x1a, 0x45, 0xdf, 0xa3}):
+		return constants.MimeTypeVideoWebM
+	case len(header) >= 3 && string(header[:3]) == "ID3":
+		return constants.MimeTypeAudioMP3
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xe0 == 0xe0 && ext == constants.ExtMP3:
+		// A bare MPEG frame sync is too short to trust without the extension agreeing
+		return constants.MimeTypeAudioMP3
+	case len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "WAVE":
+		return constants.MimeTypeAudioWAV
+	case len(header) >= 4 && string(header[:4]) == "OggS":
+		return constants.MimeTypeAudioOGG
+	case len(header) >= len(oleSignature) && bytes.Equal(header[:len(oleSignature)], oleSignature):
+		switch ext {
+		case constants.ExtDOC:
+			return constants.MimeTypeMSWord
+		case constants.ExtXLS:
+			return constants.MimeTypeMSExcel
+		case constants.ExtPPT:
+			return constants.MimeTypeMSPowerPoint
+		}
 	}
-
-	return httpDetectedMime
+	return ""
 }
 
 func getExtensionForMime(mimeType string) string {
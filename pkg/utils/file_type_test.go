@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"doan/pkg/constants"
+	"testing"
+)
+
+func TestDetectFileTypeFromContent(t *testing.T) {
+	ole := []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1, 0, 0, 0, 0}
+	random := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c}
+
+	tests := []struct {
+		name     string
+		content  []byte
+		fileName string
+		want     string
+		allowed  bool
+	}{
+		{"mp4", []byte("\x00\x00\x00\x18ftypisom\x00\x00\x02\x00"), "clip.mp4", constants.MimeTypeVideoMP4, true},
+		{"mov", []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x02\x00"), "clip.mov", constants.MimeTypeVideoMOV, true},
+		{"webm", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\xf7\x81"), "clip.webm", constants.MimeTypeVideoWebM, true},
+		{"mp3 with id3 tag", []byte("ID3\x04\x00\x00\x00\x00\x00\x00"), "song.mp3", constants.MimeTypeAudioMP3, true},
+		{"mp3 frame sync", []byte{0xff, 0xfb, 0x90, 0x64, 0, 0, 0, 0}, "song.mp3", constants.MimeTypeAudioMP3, true},
+		{"wav", []byte("RIFF\x24\x00\x00\x00WAVEfmt "), "sound.wav", constants.MimeTypeAudioWAV, true},
+		{"ogg", []byte("OggS\x00\x02\x00\x00\x00\x00\x00\x00"), "sound.ogg", constants.MimeTypeAudioOGG, true},
+		{"doc", ole, "report.doc", constants.MimeTypeMSWord, true},
+		{"xls", ole, "sheet.xls", constants.MimeTypeMSExcel, true},
+		{"ppt", ole, "slides.ppt", constants.MimeTypeMSPowerPoint, true},
+		{"ole with another extension", ole, "setup.msi", constants.MimeTypeOctetStream, false},
+		{"unknown bytes named mp4", random, "clip.mp4", constants.MimeTypeOctetStream, false},
+		{"unknown bytes named doc", random, "report.doc", constants.MimeTypeOctetStream, false},
+		{"frame sync without mp3 extension", []byte{0xff, 0xfb, 0x90, 0x64, 0, 0, 0, 0}, "data.bin", constants.MimeTypeOctetStream, false},
+		{"executable named mp3", []byte("MZ\x90\x00\x03\x00\x00\x00"), "song.mp3", constants.MimeTyeExe, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFileTypeFromContent(tt.content, tt.fileName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.DetectedMime != tt.want {
+				t.Errorf("DetectedMime = %q, want %q", got.DetectedMime, tt.want)
+			}
+			if allowed := IsAllowedFileType(got.DetectedMime); allowed != tt.allowed {
+				t.Errorf("IsAllowedFileType(%q) = %v, want %v", got.DetectedMime, allowed, tt.allowed)
+			}
+		})
+	}
+}
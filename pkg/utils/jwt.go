@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // JWTClaims represents JWT token claims
@@ -12,35 +13,66 @@ type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// Permissions of the role when the token was issued; absent on tokens issued before permissions existed
+	Permissions []string `json:"perms"`
+	// SessionID is the login session the token belongs to, revoked together with it
+	SessionID string `json:"sid,omitempty"`
+	// Actor is the administrator acting as the user, set on impersonation tokens only
+	Actor *TokenActor `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a JWT token for a user
-func GenerateToken(userID, email, role, secret string, duration time.Duration) (string, error) {
-	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
+// TokenActor is the "act" claim of RFC 8693: who is really behind the token
+type TokenActor struct {
+	Subject string `json:"sub"`
+	Role    string `json:"role"`
+}
+
+// SigningKey is the key a token is signed with
+type SigningKey struct {
+	ID     string // written as the kid header; empty for a shared secret
+	Method jwt.SigningMethod
+	Key    interface{} // private key, or the secret as []byte for HMAC
+}
+
+// TokenAudience names who issues a token and who it is meant for
+type TokenAudience struct {
+	Issuer   string
+	Audience []string
+}
+
+// GenerateToken signs the claims for duration with a fresh token ID (jti)
+func GenerateToken(claims JWTClaims, key SigningKey, audience TokenAudience, duration time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Issuer:    audience.Issuer,
+		Audience:  audience.Audience,
+		ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.Key)
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func ValidateToken(tokenString, secret string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
-		}
-		return []byte(secret), nil
-	})
+// ValidateToken validates a JWT token and returns the claims. keyFunc picks the verification key from the
+// kid and alg headers and must reject algorithms it does not expect. When set, the issuer must match and the
+// token must be meant for at least one of the audiences.
+func ValidateToken(tokenString string, keyFunc jwt.Keyfunc, audience TokenAudience) (*JWTClaims, error) {
+	options := []jwt.ParserOption{jwt.WithExpirationRequired()}
+	if audience.Issuer != "" {
+		options = append(options, jwt.WithIssuer(audience.Issuer))
+	}
+	if len(audience.Audience) > 0 {
+		options = append(options, jwt.WithAudience(audience.Audience...))
+	}
 
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, keyFunc, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +83,3 @@ func ValidateToken(tokenString, secret string) (*JWTClaims, error) {
 
 	return nil, errors.New("invalid token")
 }
-
-// RefreshToken generates a new access token from a valid refresh token
-func RefreshToken(refreshTokenString, secret string, accessTokenDuration time.Duration) (string, error) {
-	claims, err := ValidateToken(refreshTokenString, secret)
-	if err != nil {
-		return "", err
-	}
-
-	return GenerateToken(claims.UserID, claims.Email, claims.Role, secret, accessTokenDuration)
-}
@@ -69,6 +69,15 @@ func NewPtr[T any](v T) *T {
 	return &v
 }
 
+// Deref returns the value p points to, or the zero value when p is nil
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
 func IsValueNil(i interface{}) bool {
 	if i == nil {
 		return true
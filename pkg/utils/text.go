@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// RemoveVietnameseDiacritics strips tone and vowel marks ("Giáo dục" -> "Giao duc"), including đ/Đ
+func RemoveVietnameseDiacritics(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		result = s
+	}
+	return strings.NewReplacer("đ", "d", "Đ", "D").Replace(result)
+}
+
+// FoldText lowercases and strips diacritics so keyword matching ignores accents and case
+func FoldText(s string) string {
+	return strings.ToLower(RemoveVietnameseDiacritics(s))
+}
+
+// NormalizeText prepares extracted document text for storage and analysis:
+// invalid UTF-8 is dropped, combining Vietnamese tone marks are composed (NFC),
+// control and zero-width characters are removed, runs of spaces are collapsed
+// and at most one blank line is kept between paragraphs.
+func NormalizeText(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\f", "\n", "\v", "\n").Replace(s)
+	s = norm.NFC.String(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	blankLines := 0
+	for _, line := range strings.Split(s, "\n") {
+		line = collapseSpaces(line)
+		if line == "" {
+			blankLines++
+			continue
+		}
+		if b.Len() > 0 {
+			if blankLines > 0 {
+				b.WriteString("\n\n")
+			} else {
+				b.WriteByte('\n')
+			}
+		}
+		blankLines = 0
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// collapseSpaces trims a line and replaces every run of whitespace with a single space
+func collapseSpaces(line string) string {
+	var b strings.Builder
+	b.Grow(len(line))
+	pendingSpace := false
+	for _, r := range line {
+		switch {
+		case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\ufeff' || r == '\u00ad':
+			continue
+		case unicode.IsSpace(r):
+			pendingSpace = true
+			continue
+		case unicode.IsControl(r):
+			continue
+		}
+		if pendingSpace && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		pendingSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -83,3 +83,12 @@ func IsValidTime(value string, format string) bool {
 	}
 	return false
 }
+
+// VietnamLocation returns the Asia/Ho_Chi_Minh zone, or a fixed UTC+7 zone when tzdata is not installed
+func VietnamLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		return time.FixedZone("ICT", 7*60*60)
+	}
+	return loc
+}
@@ -0,0 +1,131 @@
+// Package vietqr builds NAPAS VietQR payloads (EMVCo merchant-presented QR) for bank account transfers
+package vietqr
+
+import (
+	"doan/pkg/money"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	napasGUID          = "A000000727"
+	serviceAccountXfer = "QRIBFTTA"
+	currencyVND        = "704"
+	countryVN          = "VN"
+
+	maxDescriptionLength = 50
+)
+
+var ErrInvalidPayload = errors.New("invalid VietQR payload")
+
+// Payload is a transfer request to a bank account
+type Payload struct {
+	BankBIN       string // 6-digit NAPAS bank identification number
+	AccountNumber string
+	Amount        money.Amount // zero makes a static QR where the payer types the amount
+	Description   string       // transfer content, reduced to ASCII letters, digits and spaces
+}
+
+// Build returns the EMVCo string to render as a QR code
+func Build(p Payload) (string, error) {
+	if len(p.BankBIN) != 6 || !isDigits(p.BankBIN) {
+		return "", fmt.Errorf("%w: bank BIN must be 6 digits", ErrInvalidPayload)
+	}
+	if p.AccountNumber == "" || len(p.AccountNumber) > 19 || !isAlnum(p.AccountNumber) {
+		return "", fmt.Errorf("%w: account number must be 1-19 letters or digits", ErrInvalidPayload)
+	}
+	if p.Amount.IsNegative() {
+		return "", fmt.Errorf("%w: negative amount", ErrInvalidPayload)
+	}
+
+	beneficiary := field("00", p.BankBIN) + field("01", p.AccountNumber)
+	merchant := field("00", napasGUID) + field("01", beneficiary) + field("02", serviceAccountXfer)
+
+	initiation := "11"
+	if p.Amount.IsPositive() {
+		initiation = "12"
+	}
+
+	var b strings.Builder
+	b.WriteString(field("00", "01"))
+	b.WriteString(field("01", initiation))
+	b.WriteString(field("38", merchant))
+	b.WriteString(field("53", currencyVND))
+	if p.Amount.IsPositive() {
+		b.WriteString(field("54", p.Amount.String()))
+	}
+	b.WriteString(field("58", countryVN))
+	if description := SanitizeDescription(p.Description); description != "" {
+		b.WriteString(field("62", field("08", description)))
+	}
+	b.WriteString("6304")
+	b.WriteString(fmt.Sprintf("%04X", crc16(b.String())))
+	return b.String(), nil
+}
+
+// SanitizeDescription strips Vietnamese diacritics and anything banks may reject from the transfer content
+func SanitizeDescription(s string) string {
+	s = strings.NewReplacer("đ", "d", "Đ", "D").Replace(s)
+	s, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	out := strings.TrimSpace(b.String())
+	if len(out) > maxDescriptionLength {
+		out = strings.TrimSpace(out[:maxDescriptionLength])
+	}
+	return out
+}
+
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// crc16 is CRC-16/CCITT-FALSE (poly 0x1021, init 0xFFFF) as required by EMVCo
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	for _, r := range s {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
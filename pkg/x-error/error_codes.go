@@ -100,6 +100,32 @@ const (
 	DataExisted = "DATA_EXISTED"
 )
 
+// Password policy x-error codes
+const (
+	// PasswordPolicyViolation is a constant for x-error code when a new password breaks the password policy
+	PasswordPolicyViolation = "PASSWORD_POLICY_VIOLATION"
+	// PasswordTooShort is a constant for x-error code when the password has fewer characters than required
+	PasswordTooShort = "PASSWORD_TOO_SHORT"
+	// PasswordTooLong is a constant for x-error code when the password has more characters than allowed
+	PasswordTooLong = "PASSWORD_TOO_LONG"
+	// PasswordMissingLowercase is a constant for x-error code when the password has no lowercase letter
+	PasswordMissingLowercase = "PASSWORD_MISSING_LOWERCASE"
+	// PasswordMissingUppercase is a constant for x-error code when the password has no uppercase letter
+	PasswordMissingUppercase = "PASSWORD_MISSING_UPPERCASE"
+	// PasswordMissingDigit is a constant for x-error code when the password has no digit
+	PasswordMissingDigit = "PASSWORD_MISSING_DIGIT"
+	// PasswordMissingSymbol is a constant for x-error code when the password has no symbol
+	PasswordMissingSymbol = "PASSWORD_MISSING_SYMBOL"
+	// PasswordContainsEmail is a constant for x-error code when the password contains the email of the account
+	PasswordContainsEmail = "PASSWORD_CONTAINS_EMAIL"
+	// PasswordContainsName is a constant for x-error code when the password contains the name of the account owner
+	PasswordContainsName = "PASSWORD_CONTAINS_NAME"
+	// PasswordReused is a constant for x-error code when the password is one of the last passwords of the account
+	PasswordReused = "PASSWORD_REUSED"
+	// PasswordBreached is a constant for x-error code when the password is a known common or breached password
+	PasswordBreached = "PASSWORD_BREACHED"
+)
+
 const (
 	SMSTemplateNotFound = "SMS_TEMPLATE_NOT_FOUND"
 )